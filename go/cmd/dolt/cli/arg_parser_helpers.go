@@ -17,6 +17,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -84,10 +85,7 @@ func CreateUpdateTagArgParser() *argparser.ArgParser {
 }
 
 func CreateMergeArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs("merge", 1)
-	ap.TooManyArgsErrorFunc = func(receivedArgs []string) error {
-		return errors.New("Error: Dolt does not support merging from multiple commits. You probably meant to checkout one and then merge from the other.")
-	}
+	ap := argparser.NewArgParserWithVariableArgs("merge")
 	ap.SupportsFlag(NoFFParam, "", "Create a merge commit even when the merge resolves as a fast-forward.")
 	ap.SupportsFlag(FFOnlyParam, "", "Refuse to merge unless the current HEAD is already up to date or the merge can be resolved as a fast-forward.")
 	ap.SupportsFlag(SquashParam, "", "Merge changes to the working set without updating the commit history")
@@ -98,6 +96,8 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
 	ap.SupportsFlag(SkipVerificationFlag, "", "Skip commit verification before merge")
+	ap.SupportsString(StrategyParam, "s", "strategy", "Use the given merge strategy. The only supported strategy is {{.EmphasisLeft}}ours{{.EmphasisRight}}, which records a merge commit whose contents are the same as the current HEAD, ignoring all changes from the other branch.")
+	ap.SupportsString(StrategyOptionParam, "X", "option", "Resolve conflicts automatically in favor of one side. {{.EmphasisLeft}}ours{{.EmphasisRight}} or {{.EmphasisLeft}}theirs{{.EmphasisRight}} applies to every table, and {{.EmphasisLeft}}{{.LessThan}}table{{.GreaterThan}}=ours{{.EmphasisRight}} or {{.EmphasisLeft}}{{.LessThan}}table{{.GreaterThan}}=theirs{{.EmphasisRight}} applies to a single table. Separate multiple options with commas.")
 
 	return ap
 }
@@ -149,6 +149,8 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(RemoteParam, "", "name", "Name of the remote to be added to the cloned database. The default is 'origin'.")
 	ap.SupportsString(BranchParam, "b", "branch", "The branch to be cloned. If not specified all branches will be cloned.")
 	ap.SupportsString(DepthFlag, "", "depth", "Clone a single branch and limit history to the given commit depth.")
+	ap.SupportsFlag(LazyFlag, "", "Fetch history and data missing from the clone from the remote when they are first read, instead of failing.")
+	ap.SupportsString(TablesFlag, "", "tables", "Comma separated list of tables, or table name patterns, whose row and index data is cloned. The schemas and history of all tables are cloned regardless.")
 	ap.SupportsString("ref", "", "ref", "Git ref to use as the Dolt data ref for git remotes (default: refs/dolt/data).")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
@@ -156,6 +158,7 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file.")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
+	ap.SupportsString(dbfactory.EncryptionKeyFileParam, "", "file", "Keyfile to decrypt the table files of a blobstore remote with.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
 	return ap
@@ -171,6 +174,7 @@ func CreateResetArgParser() *argparser.ArgParser {
 func CreateRemoteArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("remote")
 	ap.SupportsString("ref", "", "ref", "Git ref to use as the Dolt data ref for git remotes (default: refs/dolt/data).")
+	ap.SupportsString(dbfactory.EncryptionKeyFileParam, "", "file", "Keyfile to encrypt the table files of a blobstore remote with.")
 	return ap
 }
 
@@ -211,6 +215,7 @@ func CreateFetchArgParser() *argparser.ArgParser {
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(PruneFlag, "p", "After fetching, remove any remote-tracking references that don't exist on the remote.")
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
+	ap.SupportsString(TablesFlag, "", "tables", "Comma separated list of tables, or table name patterns, to add to a sparse clone. Their data is fetched for all history already present.")
 	return ap
 }
 
@@ -273,6 +278,62 @@ func CreateTagArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateHistoryPruneArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("prune", 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"branch", "The branch to prune. Defaults to the current branch."})
+	ap.SupportsInt(KeepParam, "", "n", "Keep the {{.LessThan}}n{{.GreaterThan}} most recent commits of the branch's first-parent history.")
+	ap.SupportsString(BeforeParam, "", "date", "Prune the branch's first-parent history from the first commit authored before {{.LessThan}}date{{.GreaterThan}}.")
+	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message of the new root commit.")
+	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author of the new root commit using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(DeleteTagsFlag, "", "Delete tags that point to pruned commits. Without this flag, such tags cause the prune to fail.")
+	return ap
+}
+
+func CreateBisectArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("bisect")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"subcommand", "One of start, good, bad, skip, reset, run, or status."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"rev", "The commits to mark. Defaults to the commit currently being tested."})
+	ap.SupportsString(TestParam, "", "test", "With {{.EmphasisLeft}}run{{.EmphasisRight}}, a commit is good if every test in the given dolt_tests test or test group passes.")
+	ap.SupportsString(QueryParam, "q", "query", "With {{.EmphasisLeft}}run{{.EmphasisRight}}, a commit is good if the first column of the first row returned by the query is true.")
+	return ap
+}
+
+func CreateNotesArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("notes")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"subcommand", "One of add, remove, merge, push, or pull."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commits to annotate. Defaults to HEAD."})
+	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the note.")
+	ap.SupportsFlag(ForceFlag, "f", "With {{.EmphasisLeft}}add{{.EmphasisRight}}, replace an existing note.")
+	ap.SupportsString(RefParam, "", "ref", "Use the notes ref {{.LessThan}}ref{{.GreaterThan}} instead of refs/notes/commits.")
+	ap.SupportsString(StrategyParam, "s", "strategy", "With {{.EmphasisLeft}}merge{{.EmphasisRight}} and {{.EmphasisLeft}}pull{{.EmphasisRight}}, resolve notes changed on both sides with one of union, ours, or theirs. Defaults to union.")
+	return ap
+}
+
+func CreatePrefetchArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("prefetch")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision", "The commit whose data to fetch."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The tables to fetch. Defaults to all tables."})
+	ap.SupportsString(RemoteParam, "", "name", "The remote to fetch from. Defaults to the only remote, or origin.")
+	return ap
+}
+
+func CreateRestoreArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("restore", 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"url", "The location the database's journal was shipped to."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The directory to restore the database into."})
+	ap.SupportsString(ToTimeParam, "", "time", "Restores the database as it was at this time. Defaults to the latest state shipped.")
+	return ap
+}
+
+func CreateGrepArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("grep")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"pattern", "The regular expression to search for."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision", "The revisions to search, or ranges of commits {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}} in which to look for matches appearing and disappearing. Defaults to the working set."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The tables to search, given after {{.EmphasisLeft}}--{{.EmphasisRight}}. Defaults to all tables."})
+	ap.SupportsFlag(IgnoreCaseFlag, "i", "Match the pattern case-insensitively.")
+	return ap
+}
+
 func CreateBackupArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("backup")
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"region", "cloud provider region associated with this backup."})
@@ -285,6 +346,7 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.EncryptionKeyFileParam, "", "file", "Keyfile to encrypt the table files of a blobstore backup with")
 	return ap
 }
 
@@ -306,12 +368,17 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap.SupportsStringList(NotFlag, "", "revision", "Excludes commits from revision.")
 	ap.SupportsFlag(AllFlag, "", "Automatically select every branch in database")
 	ap.SupportsFlag(ShowSignatureFlag, "", "Shows the signature of each commit.")
+	ap.SupportsString(AuthorParam, "", "pattern", "Limits the log to commits whose author, formatted as A U Thor {{.LessThan}}author@example.com{{.GreaterThan}}, matches the regular expression.")
+	ap.SupportsString(GrepParam, "", "pattern", "Limits the log to commits whose message matches the regular expression.")
+	ap.SupportsString(SinceParam, "", "date", "Limits the log to commits made on or after the date.")
+	ap.SupportsString(UntilParam, "", "date", "Limits the log to commits made on or before the date.")
 	if isTableFunction {
 		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables.")
 	} else {
 		ap.SupportsFlag(OneLineFlag, "", "Shows logs in a compact format.")
 		ap.SupportsFlag(StatFlag, "", "Shows the diffstat for each commit.")
 		ap.SupportsFlag(GraphFlag, "", "Shows the commit graph.")
+		ap.SupportsFlag(NotesFlag, "", "Shows the notes attached to each commit.")
 	}
 	return ap
 }
@@ -352,10 +419,12 @@ func CreateDiffArgParser(isTableFunction bool) *argparser.ArgParser {
 		ap.SupportsFlag(StagedFlag, "", "Show only the staged data changes.")
 		ap.SupportsFlag(CachedFlag, "c", "Synonym for --staged")
 		ap.SupportsFlag(MergeBase, "", "Uses merge base of the first commit and second commit (or HEAD if not supplied) as the first commit")
-		ap.SupportsString(DiffMode, "", "diff mode", "Determines how to display modified rows with tabular output. Valid values are row, line, in-place, context. Defaults to context.")
+		ap.SupportsString(DiffMode, "", "diff mode", "Determines how to display modified rows with tabular output. Valid values are row, line, in-place, word, context. Defaults to context.")
 		ap.SupportsFlag(ReverseFlag, "R", "Reverses the direction of the diff.")
 		ap.SupportsFlag(NameOnlyFlag, "", "Only shows table names.")
 		ap.SupportsFlag(SystemFlag, "", "Show system tables in addition to user tables")
+		ap.SupportsFlag(WordDiffFlag, "", "Shows modified text and JSON cells as a single value with only the changed words highlighted. Same as {{.EmphasisLeft}}--diff-mode=word{{.EmphasisRight}}.")
+		ap.SupportsFlag(FindRenamesFlag, "M", "Detect tables and columns that were dropped and re-added under a new name as renames.")
 	}
 	return ap
 }
@@ -464,6 +533,24 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// AddEncryptionParams sets the dbfactory.EncryptionKeyFileParam of |params| from |apr|, as an absolute path. It is an
+// error to give a keyfile for a remote whose |scheme| is not stored in a blobstore.
+func AddEncryptionParams(scheme string, apr *argparser.ArgParseResults, params map[string]string) error {
+	keyfile, ok := apr.GetValue(dbfactory.EncryptionKeyFileParam)
+	if !ok {
+		return nil
+	}
+	if !dbfactory.SupportsEncryptionKeyFile(scheme) {
+		return fmt.Errorf("%s param is only valid for remotes stored in a blobstore, such as aws, gs or git remotes", dbfactory.EncryptionKeyFileParam)
+	}
+	keyfile, err := filepath.Abs(keyfile)
+	if err != nil {
+		return err
+	}
+	params[dbfactory.EncryptionKeyFileParam] = keyfile
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(AwsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
@@ -23,6 +23,7 @@ const (
 	AmendFlag              = "amend"
 	AuthorParam            = "author"
 	ArchiveLevelParam      = "archive-level"
+	BeforeParam            = "before"
 	BranchParam            = "branch"
 	CachedFlag             = "cached"
 	CheckoutCreateBranch   = "b"
@@ -34,6 +35,7 @@ const (
 	DecorateFlag           = "decorate"
 	DeleteFlag             = "delete"
 	DeleteForceFlag        = "D"
+	DeleteTagsFlag         = "delete-tags"
 	DepthFlag              = "depth"
 	DryRunFlag             = "dry-run"
 	EmptyParam             = "empty"
@@ -41,12 +43,16 @@ const (
 	ForceFlag              = "force"
 	FullFlag               = "full"
 	GraphFlag              = "graph"
+	GrepParam              = "grep"
 	HardResetParam         = "hard"
 	HostFlag               = "host"
+	IgnoreCaseFlag         = "ignore-case"
 	IncludeUntrackedFlag   = "include-untracked"
 	IncrementalGCFileSize  = "incremental-file-size"
 	InteractiveFlag        = "interactive"
 	JobFlag                = "job"
+	KeepParam              = "keep"
+	LazyFlag               = "lazy"
 	ListFlag               = "list"
 	MergesFlag             = "merges"
 	MessageArg             = "message"
@@ -61,6 +67,7 @@ const (
 	NoTLSFlag              = "no-tls"
 	NoJsonMergeFlag        = "dont-merge-json"
 	NotFlag                = "not"
+	NotesFlag              = "notes"
 	NumberFlag             = "number"
 	OneLineFlag            = "oneline"
 	OursFlag               = "ours"
@@ -71,14 +78,17 @@ const (
 	PasswordFlag           = "password"
 	PortFlag               = "port"
 	PruneFlag              = "prune"
+	QueryParam             = "query"
 	QuietFlag              = "quiet"
 	RebaseParam            = "rebase"
+	RefParam               = "ref"
 	RemoteParam            = "remote"
 	SetUpstreamFlag        = "set-upstream"
 	SetUpstreamToFlag      = "set-upstream-to"
 	ShallowFlag            = "shallow"
 	ShowIgnoredFlag        = "ignored"
 	ShowSignatureFlag      = "show-signature"
+	SinceParam             = "since"
 	SignFlag               = "gpg-sign"
 	SilentFlag             = "silent"
 	SingleBranchFlag       = "single-branch"
@@ -88,27 +98,34 @@ const (
 	SquashParam            = "squash"
 	StagedFlag             = "staged"
 	StatFlag               = "stat"
+	StrategyParam          = "strategy"
+	StrategyOptionParam    = "strategy-option"
 	SystemFlag             = "system"
 	TablesFlag             = "tables"
+	TestParam              = "test"
 	TheirsFlag             = "theirs"
+	ToTimeParam            = "to-time"
 	TrackFlag              = "track"
+	UntilParam             = "until"
 	UpperCaseAllFlag       = "ALL"
 	UserFlag               = "user"
 )
 
 // Flags used by `dolt diff` command and `dolt_diff()` table function.
 const (
-	SkinnyFlag   = "skinny"
-	IncludeCols  = "include-cols"
-	DataFlag     = "data"
-	SchemaFlag   = "schema"
-	NameOnlyFlag = "name-only"
-	SummaryFlag  = "summary"
-	WhereParam   = "where"
-	LimitParam   = "limit"
-	FilterParam  = "filter"
-	MergeBase    = "merge-base"
-	DiffMode     = "diff-mode"
-	ReverseFlag  = "reverse"
-	FormatFlag   = "result-format"
+	SkinnyFlag      = "skinny"
+	IncludeCols     = "include-cols"
+	DataFlag        = "data"
+	SchemaFlag      = "schema"
+	NameOnlyFlag    = "name-only"
+	SummaryFlag     = "summary"
+	WhereParam      = "where"
+	LimitParam      = "limit"
+	FilterParam     = "filter"
+	MergeBase       = "merge-base"
+	DiffMode        = "diff-mode"
+	ReverseFlag     = "reverse"
+	FormatFlag      = "result-format"
+	FindRenamesFlag = "find-renames"
+	WordDiffFlag    = "word-diff"
 )
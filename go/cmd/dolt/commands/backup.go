@@ -51,6 +51,8 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 
 GCP backup URLs should follow the format {{.EmphasisLeft}}gs://gcs-bucket/database{{.EmphasisRight}}. Backups will use the credentials that you configure using the gcloud CLI.
 
+The table files of aws, gs, az, oci, oss and git backups are encrypted with the keys in the file given by {{.EmphasisLeft}}--encryption-key-file{{.EmphasisRight}}, which must be given again to restore the backup.
+
 The local filesystem can be used as a backup by providing a repository URL in the format {{.EmphasisLeft}}file://absolute-path{{.EmphasisRight}}. See https://en.wikipedia.org/wiki/File_URI_scheme.
 
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
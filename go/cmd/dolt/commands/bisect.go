@@ -0,0 +1,150 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var bisectDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Use binary search to find the commit that introduced a bug.`,
+	LongDesc: `Searches the commit history between a known bad commit and one or more known good commits for the first bad commit. Each step proposes a commit halfway between them; mark it {{.EmphasisLeft}}good{{.EmphasisRight}} or {{.EmphasisLeft}}bad{{.EmphasisRight}} and the range is narrowed until a single commit is left. Commits that cannot be tested can be marked with {{.EmphasisLeft}}skip{{.EmphasisRight}}.
+
+The branch HEAD does not move while bisecting. To examine the proposed commit, query the revision database {{.EmphasisLeft}}{{.LessThan}}database{{.GreaterThan}}/{{.LessThan}}commit{{.GreaterThan}}{{.EmphasisRight}}. When no commit is given, {{.EmphasisLeft}}good{{.EmphasisRight}}, {{.EmphasisLeft}}bad{{.EmphasisRight}} and {{.EmphasisLeft}}skip{{.EmphasisRight}} apply to the proposed commit, or to HEAD before a commit has been proposed.
+
+{{.EmphasisLeft}}run{{.EmphasisRight}} automates the search. With {{.EmphasisLeft}}--test{{.EmphasisRight}}, each commit is good if the named test or test group from the {{.EmphasisLeft}}dolt_tests{{.EmphasisRight}} table of the current branch passes against it. With {{.EmphasisLeft}}--query{{.EmphasisRight}}, each commit is good if the query returns true; commits where the query fails are skipped. The query must be a single read-only {{.EmphasisLeft}}SELECT{{.EmphasisRight}} statement.
+
+The bisect session is stored in the working set of the current branch, and ends with {{.EmphasisLeft}}dolt bisect reset{{.EmphasisRight}}. This command is backed by the {{.EmphasisLeft}}dolt_bisect(){{.EmphasisRight}} stored procedure.`,
+	Synopsis: []string{
+		`start [{{.LessThan}}bad{{.GreaterThan}} [{{.LessThan}}good{{.GreaterThan}}...]]`,
+		`(good|bad|skip) [{{.LessThan}}rev{{.GreaterThan}}...]`,
+		`run (--test {{.LessThan}}test{{.GreaterThan}} | --query {{.LessThan}}query{{.GreaterThan}})`,
+		`status`,
+		`reset`,
+	},
+}
+
+type BisectCmd struct{}
+
+var _ cli.Command = BisectCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BisectCmd) Name() string {
+	return "bisect"
+}
+
+// Description returns a description of the command
+func (cmd BisectCmd) Description() string {
+	return bisectDocs.ShortDesc
+}
+
+func (cmd BisectCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bisectDocs, ap)
+}
+
+func (cmd BisectCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateBisectArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd BisectCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd BisectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bisectDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := constructInterpolatedDoltBisectQuery(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	_, rowIter, _, err := queryist.Queryist.Query(queryist.Context, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	rows, err := sql.RowIterToRows(queryist.Context, rowIter)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	for i, row := range rows {
+		status, message := fmt.Sprint(row[0]), fmt.Sprint(row[3])
+		if i < len(rows)-1 {
+			// every row but the last describes a commit tested by run
+			cli.Printf("%s: %s (%s)\n", row[1], status, message)
+			continue
+		}
+		switch status {
+		case "found":
+			cli.Printf("%s is the first bad commit\n", row[1])
+		case "bisecting":
+			cli.Println(message)
+			cli.Printf("Next commit to test: %s\n", row[1])
+		default:
+			cli.Println(message)
+		}
+	}
+	return 0
+}
+
+// constructInterpolatedDoltBisectQuery generates the sql query necessary to call the DOLT_BISECT() procedure.
+func constructInterpolatedDoltBisectQuery(apr *argparser.ArgParseResults) (string, error) {
+	var params []interface{}
+	var placeholders []string
+	addArg := func(arg string) {
+		params = append(params, arg)
+		placeholders = append(placeholders, "?")
+	}
+
+	for _, param := range []string{cli.TestParam, cli.QueryParam} {
+		if val, ok := apr.GetValue(param); ok {
+			addArg("--" + param)
+			addArg(val)
+		}
+	}
+	for _, arg := range apr.Args {
+		addArg(arg)
+	}
+
+	query := fmt.Sprintf("CALL DOLT_BISECT(%s)", strings.Join(placeholders, ", "))
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
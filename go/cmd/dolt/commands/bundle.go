@@ -0,0 +1,293 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var bundleDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Move commits between databases in a single file.",
+	LongDesc: `Creates and reads bundles. A bundle is a single file holding the commits of a set of branches and tags, which can be carried to a database that can't reach a common remote, e.g. on removable media.
+
+{{.EmphasisLeft}}create{{.EmphasisRight}}
+Writes the branches and tags named by {{.LessThan}}ref{{.GreaterThan}} to {{.LessThan}}file{{.GreaterThan}}, or every branch and tag if none are named. With {{.EmphasisLeft}}--base{{.EmphasisRight}}, the bundle is incremental: everything reachable from the base commit is left out, and the bundle can only be used by a database which already has that commit.
+
+{{.EmphasisLeft}}verify{{.EmphasisRight}}
+Checks that {{.LessThan}}file{{.GreaterThan}} is a valid bundle and that this database has its prerequisite commits.
+
+{{.EmphasisLeft}}list-heads{{.EmphasisRight}}
+Lists the refs stored in {{.LessThan}}file{{.GreaterThan}}.
+
+{{.EmphasisLeft}}unbundle{{.EmphasisRight}}
+Adds the commits stored in {{.LessThan}}file{{.GreaterThan}} to this database and lists its refs. No branches are changed.
+
+A bundle can also be used as a remote with a url of the form {{.EmphasisLeft}}bundle://{{.LessThan}}path{{.GreaterThan}}{{.EmphasisRight}}, so that {{.EmphasisLeft}}dolt clone{{.EmphasisRight}}, {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} and {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} can read from it.`,
+	Synopsis: []string{
+		"create [--base {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}file{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}...]",
+		"verify {{.LessThan}}file{{.GreaterThan}}",
+		"list-heads {{.LessThan}}file{{.GreaterThan}}",
+		"unbundle {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+const (
+	bundleCreateId    = "create"
+	bundleVerifyId    = "verify"
+	bundleListHeadsId = "list-heads"
+	bundleUnbundleId  = "unbundle"
+	bundleBaseParam   = "base"
+)
+
+type BundleCmd struct{}
+
+var _ cli.Command = BundleCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BundleCmd) Name() string {
+	return "bundle"
+}
+
+// Description returns a description of the command
+func (cmd BundleCmd) Description() string {
+	return bundleDocs.ShortDesc
+}
+
+func (cmd BundleCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bundleDocs, ap)
+}
+
+func (cmd BundleCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.SupportsString(bundleBaseParam, "", "commit", "Leave out everything reachable from {{.LessThan}}commit{{.GreaterThan}}, making an incremental bundle.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd BundleCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd BundleCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bundleDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() < 2 {
+		usage()
+		return 1
+	}
+	if apr.Contains(bundleBaseParam) && apr.Arg(0) != bundleCreateId {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s can only be used with %s", bundleBaseParam, bundleCreateId).Build(), usage)
+	}
+
+	var verr errhand.VerboseError
+	switch apr.Arg(0) {
+	case bundleCreateId:
+		verr = createBundle(ctx, dEnv, apr)
+	case bundleVerifyId, bundleListHeadsId, bundleUnbundleId:
+		if apr.NArg() != 2 {
+			usage()
+			return 1
+		}
+		verr = readBundle(ctx, dEnv, apr.Arg(0), apr.Arg(1))
+	default:
+		verr = errhand.BuildDError("error: unknown bundle subcommand '%s'", apr.Arg(0)).SetPrintUsage().Build()
+	}
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func createBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	ddb := dEnv.DoltDB(ctx)
+	path := apr.Arg(1)
+
+	refs, err := resolveBundleRefs(ctx, ddb, apr.Args[2:])
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	var prerequisites []hash.Hash
+	if base, ok := apr.GetValue(bundleBaseParam); ok {
+		cm, err := resolveBundleBase(ctx, dEnv, base)
+		if err != nil {
+			return errhand.BuildDError("error: invalid base '%s'", base).AddCause(err).Build()
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		prerequisites = append(prerequisites, h)
+	}
+
+	tempDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errhand.BuildDError("error: could not create bundle '%s'", path).AddCause(err).Build()
+	}
+	wr := bufio.NewWriter(f)
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.ExposeDatabaseFromDoltDB(ddb))
+	_, err = bundle.Create(ctx, tempDir, cs, refs, prerequisites, wr)
+	if err == nil {
+		err = wr.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		if errors.Is(err, bundle.ErrEmptyBundle) {
+			return errhand.BuildDError("error: %s", err.Error()).Build()
+		}
+		return errhand.BuildDError("error: failed to create bundle '%s'", path).AddCause(err).Build()
+	}
+
+	cli.Printf("Created bundle %s with %d refs\n", path, len(refs))
+	return nil
+}
+
+// resolveBundleRefs returns the branches and tags named by |names|, or every branch and tag if |names| is empty.
+func resolveBundleRefs(ctx context.Context, ddb *doltdb.DoltDB, names []string) ([]bundle.Ref, error) {
+	all, err := ddb.GetRefsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	isBundleable := func(r ref.DoltRef) bool {
+		return r.GetType() == ref.BranchRefType || r.GetType() == ref.TagRefType
+	}
+
+	var refs []bundle.Ref
+	if len(names) == 0 {
+		for _, r := range all {
+			if isBundleable(r.Ref) {
+				refs = append(refs, bundle.Ref{Name: r.Ref.String(), Hash: r.Hash.String()})
+			}
+		}
+		if len(refs) == 0 {
+			return nil, errors.New("error: there are no branches or tags to bundle")
+		}
+		return refs, nil
+	}
+
+	for _, name := range names {
+		var match *doltdb.RefWithHash
+		for i, r := range all {
+			if !isBundleable(r.Ref) || (r.Ref.GetPath() != name && r.Ref.String() != name) {
+				continue
+			}
+			// a branch wins over a tag of the same name
+			if match == nil || r.Ref.GetType() == ref.BranchRefType {
+				match = &all[i]
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("error: '%s' is not a branch or tag", name)
+		}
+		refs = append(refs, bundle.Ref{Name: match.Ref.String(), Hash: match.Hash.String()})
+	}
+	return refs, nil
+}
+
+func resolveBundleBase(ctx context.Context, dEnv *env.DoltEnv, base string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(base)
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := dEnv.DoltDB(ctx).Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// readBundle runs the verify, list-heads and unbundle subcommands, which all read the bundle at |path|.
+func readBundle(ctx context.Context, dEnv *env.DoltEnv, subcommand, path string) errhand.VerboseError {
+	r, err := bundle.Open(path)
+	if err != nil {
+		return errhand.BuildDError("error: could not read bundle '%s'", path).AddCause(err).Build()
+	}
+	defer r.Close()
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.ExposeDatabaseFromDoltDB(dEnv.DoltDB(ctx)))
+	switch subcommand {
+	case bundleVerifyId:
+		missing, err := r.MissingPrerequisites(ctx, cs)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		if len(missing) > 0 {
+			cli.Println("The bundle requires these commits, which this database lacks:")
+			for _, h := range missing {
+				cli.Println("\t" + h.String())
+			}
+			return errhand.BuildDError("error: %s is not usable by this database", path).Build()
+		}
+		cli.Printf("The bundle contains %d refs\n", len(r.Header.Refs))
+		printBundleRefs(r.Header.Refs)
+		if len(r.Header.Prerequisites) == 0 {
+			cli.Println("The bundle records a complete history.")
+		} else {
+			cli.Printf("The bundle requires %d commits\n", len(r.Header.Prerequisites))
+			for _, h := range r.Header.Prerequisites {
+				cli.Println(h)
+			}
+		}
+		cli.Printf("%s is okay\n", path)
+	case bundleListHeadsId:
+		printBundleRefs(r.Header.Refs)
+	case bundleUnbundleId:
+		if err = r.Unbundle(ctx, cs); err != nil {
+			return errhand.BuildDError("error: failed to unbundle '%s'", path).AddCause(err).Build()
+		}
+		printBundleRefs(r.Header.Refs)
+	}
+	return nil
+}
+
+func printBundleRefs(refs []bundle.Ref) {
+	for _, r := range refs {
+		cli.Printf("%s %s\n", r.Hash, r.Name)
+	}
+}
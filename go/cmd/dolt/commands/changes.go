@@ -0,0 +1,133 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var changesDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Show every row-level change made by a range of commits",
+	LongDesc: `Shows one line for every row inserted, updated or deleted by each commit reachable from {{.LessThan}}to{{.GreaterThan}} but not from {{.LessThan}}from{{.GreaterThan}}. Changes are listed from the oldest commit to the newest, and each commit is compared against its first parent.
+
+Every line carries the commit hash, committer, commit date, table name, the kind of change, and the row before and after the change as JSON objects. Unlike {{.EmphasisLeft}}dolt diff{{.EmphasisRight}}, which compares two revisions directly, this shows the individual steps between them, which makes it suitable for feeding change-data-capture consumers.
+
+The range may be given as two revisions or as {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}}. If {{.LessThan}}table{{.GreaterThan}} is given, only changes to that table are shown.
+
+This command is backed by the {{.EmphasisLeft}}dolt_changes(){{.EmphasisRight}} table function.`,
+	Synopsis: []string{
+		`[-r {{.LessThan}}result-format{{.GreaterThan}}] {{.LessThan}}from{{.GreaterThan}} {{.LessThan}}to{{.GreaterThan}} [{{.LessThan}}table{{.GreaterThan}}]`,
+		`[-r {{.LessThan}}result-format{{.GreaterThan}}] {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}} [{{.LessThan}}table{{.GreaterThan}}]`,
+	},
+}
+
+type ChangesCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ChangesCmd) Name() string {
+	return "changes"
+}
+
+// Description returns a description of the command
+func (cmd ChangesCmd) Description() string {
+	return "Show every row-level change made by a range of commits."
+}
+
+func (cmd ChangesCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(changesDocs, ap)
+}
+
+func (cmd ChangesCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 3)
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json and vertical. Defaults to tabular.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd ChangesCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd ChangesCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, changesDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	format := engine.FormatTabular
+	if formatSr, ok := apr.GetValue(FormatFlag); ok {
+		var verr errhand.VerboseError
+		format, verr = GetResultFormat(formatSr)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	query, err := constructInterpolatedDoltChangesQuery(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("%s", err.Error()).SetPrintUsage().Build(), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	schema, rowIter, _, err := queryist.Queryist.Query(queryist.Context, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	err = engine.PrettyPrintResults(queryist.Context, format, schema, rowIter, false, false, false, false)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	return 0
+}
+
+// constructInterpolatedDoltChangesQuery generates the sql query necessary to call the DOLT_CHANGES() function.
+// Also interpolates this query to prevent sql injection.
+func constructInterpolatedDoltChangesQuery(apr *argparser.ArgParseResults) (string, error) {
+	var params []interface{}
+	switch {
+	case apr.NArg() >= 1 && strings.Contains(apr.Arg(0), ".."):
+		if apr.NArg() > 2 {
+			return "", fmt.Errorf("expected a commit range and an optional table name")
+		}
+	case apr.NArg() < 2:
+		return "", fmt.Errorf("expected a commit range, either as two revisions or as <from>..<to>")
+	}
+	for _, arg := range apr.Args {
+		params = append(params, arg)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(params)), ", ")
+	query := fmt.Sprintf("SELECT * FROM DOLT_CHANGES(%s)", placeholders)
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
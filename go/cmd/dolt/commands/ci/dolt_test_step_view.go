@@ -33,36 +33,36 @@ func previewDoltTestStatements(dt *dolt_ci.DoltTestStep) ([]string, error) {
 // buildPreviewSelectors computes which selectors (test names and group names) to preview based on
 // the provided DoltTestStep configuration. Wildcards collapse the corresponding set to a single "*".
 func buildPreviewSelectors(dt *dolt_ci.DoltTestStep) []string {
-	spec := deriveSelectionSpec(dt)
-	testsProvided := len(dt.Tests) > 0
-	groupsProvided := len(dt.TestGroups) > 0
+	sel := dolt_ci.NewDoltTestSelection(dt)
+	testsProvided := len(sel.Tests) > 0
+	groupsProvided := len(sel.Groups) > 0
 
 	switch {
 	case testsProvided && groupsProvided:
-		if spec.testsWildcard && !spec.groupsWildcard {
-			return nodesToValues(dt.TestGroups)
+		if sel.TestsWildcard && !sel.GroupsWildcard {
+			return sel.Groups
 		}
-		if spec.groupsWildcard && !spec.testsWildcard {
-			return nodesToValues(dt.Tests)
+		if sel.GroupsWildcard && !sel.TestsWildcard {
+			return sel.Tests
 		}
-		if spec.testsWildcard && spec.groupsWildcard {
+		if sel.TestsWildcard && sel.GroupsWildcard {
 			return []string{"*"}
 		}
-		args := append([]string{}, nodesToValues(dt.Tests)...)
-		args = append(args, nodesToValues(dt.TestGroups)...)
+		args := append([]string{}, sel.Tests...)
+		args = append(args, sel.Groups...)
 		return args
 
 	case testsProvided:
-		if spec.testsWildcard {
+		if sel.TestsWildcard {
 			return []string{"*"}
 		}
-		return nodesToValues(dt.Tests)
+		return sel.Tests
 
 	case groupsProvided:
-		if spec.groupsWildcard {
+		if sel.GroupsWildcard {
 			return []string{"*"}
 		}
-		return nodesToValues(dt.TestGroups)
+		return sel.Groups
 	}
 
 	return []string{"*"}
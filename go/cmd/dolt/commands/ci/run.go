@@ -16,11 +16,9 @@ package ci
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -105,68 +103,45 @@ func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, _
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	savedQueries, err := getSavedQueries(queryist.Context, queryist.Queryist)
+	cli.Println(color.CyanString("Running workflow: %s", workflowName))
+	result, err := dolt_ci.RunWorkflow(queryist.Context, queryist.Queryist, config, nil)
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
-	cli.Println(color.CyanString("Running workflow: %s", workflowName))
-	failed := queryAndPrint(queryist.Context, queryist.Queryist, config, savedQueries)
+	printWorkflowResult(result)
 
-	if failed {
+	if result.Failed() {
 		return 1
 	}
 	return 0
 }
 
-// queryAndPrint iterates through the jobs and steps for the given config, then runs each saved query and given assertion
-func queryAndPrint(sqlCtx *sql.Context, queryist cli.Queryist, config *dolt_ci.WorkflowConfig, savedQueries map[string]string) bool {
-	// returns true if any job had failures
-	overallFailed := false
-	for _, job := range config.Jobs {
-		cli.Println(color.CyanString("Running job: %s", job.Name.Value))
+// printWorkflowResult prints the outcome of every job and step of a workflow run
+func printWorkflowResult(result *dolt_ci.WorkflowRunResult) {
+	for _, job := range result.Jobs {
+		cli.Println(color.CyanString("Running job: %s", job.Name))
 
-		jobFailures := make([]string, 0)
 		for _, step := range job.Steps {
 			// Print a step header; details will follow on subsequent lines
-			_, isDoltTest := step.(*dolt_ci.DoltTestStep)
-			_, isSavedQuery := step.(*dolt_ci.SavedQueryStep)
-			cli.Println(color.CyanString("  Step: %s", step.GetName()))
+			cli.Println(color.CyanString("  Step: %s", step.Name))
 
-			var err error
 			var details string
-			if sq, ok := step.(*dolt_ci.SavedQueryStep); ok {
-				query := savedQueries[sq.SavedQueryName.Value]
-				rows, qErr := runCIQuery(queryist, sqlCtx, sq, query)
-				if qErr == nil {
-					err = assertQueries(rows, sq.ExpectedRows.Value, sq.ExpectedColumns.Value, query)
-				} else {
-					err = qErr
-				}
-				details = formatSavedQueryDetails(sq.SavedQueryName.Value, query, err)
-			} else if dt, ok := step.(*dolt_ci.DoltTestStep); ok {
-				details, err = runDoltTestStep(sqlCtx, queryist, dt)
+			if step.SavedQueryName != "" {
+				details = formatSavedQueryDetails(step.SavedQueryName, step.Query, step.Err)
 			} else {
-				panic("unsupported step type")
+				details = formatDoltTestResults(step.Tests)
 			}
-
-			// Print details for DoltTest and SavedQuery steps; they do not emit PASS/FAIL inline
-			if (isDoltTest || isSavedQuery) && details != "" {
+			if details != "" {
 				cli.Println(indentLines(details, "  "))
 			}
-
-			// Unified failure handling
-			if err != nil {
-				jobFailures = append(jobFailures, fmt.Sprintf("step '%s': %s", step.GetName(), err.Error()))
-			}
 		}
-		if len(jobFailures) > 0 {
-			cli.Println(color.CyanString("Result of '%s':", job.Name.Value) + " " + color.RedString("FAIL"))
-			overallFailed = true
+
+		if job.Failed() {
+			cli.Println(color.CyanString("Result of '%s':", job.Name) + " " + color.RedString("FAIL"))
 		} else {
-			cli.Println(color.CyanString("Result of '%s':", job.Name.Value) + " " + color.GreenString("PASS"))
+			cli.Println(color.CyanString("Result of '%s':", job.Name) + " " + color.GreenString("PASS"))
 		}
 	}
-	return overallFailed
 }
 
 // indentLines prefixes every line in s with the given prefix.
@@ -223,48 +198,24 @@ func formatSavedQueryDetails(savedQueryName, query string, err error) string {
 	return strings.Join(lines, "\n")
 }
 
-func runCIQuery(queryist cli.Queryist, sqlCtx *sql.Context, step *dolt_ci.SavedQueryStep, query string) ([]sql.Row, error) {
-	if query == "" {
-		return nil, fmt.Errorf("Could not find saved query: %s", step.SavedQueryName.Value)
-	}
-
-	rows, err := cli.GetRowsForSql(queryist, sqlCtx, query)
-	if err != nil {
-		return nil, err
-	}
-
-	return rows, nil
-}
-
-// assertQueries takes in the result of a saved query execution, and the unparsed assertions,
-// then returns if the assertions failed
-func assertQueries(rows []sql.Row, expectedRowsAndComparison string, expectedColumnsAndComparison string, query string) error {
-	var colCount int64
-	var errs []string
-	rowCount := int64(len(rows))
-	if rowCount > 0 {
-		colCount = int64(len(rows[0]))
-	}
-
-	colCompType, expectedCols, err := dolt_ci.ParseSavedQueryExpectedResultString(expectedColumnsAndComparison)
-	if colCompType != dolt_ci.WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-		err = dolt_ci.ValidateQueryExpectedRowOrColumnCount(colCount, expectedCols, colCompType, "column")
-		if err != nil {
-			errStr := fmt.Sprintf("Assertion failed: %s", err.Error())
-			errs = append(errs, errStr)
+// formatDoltTestResults returns a formatted summary of the individual results of a dolt test step
+func formatDoltTestResults(tests []dolt_ci.DoltTestResult) string {
+	var lines []string
+	for _, test := range tests {
+		var statusColored string
+		switch test.Status {
+		case "PASS":
+			statusColored = color.GreenString(test.Status)
+		case "FAIL":
+			statusColored = color.RedString(test.Status)
+		default:
+			statusColored = test.Status
 		}
-	}
-	rowCompType, expectedRows, err := dolt_ci.ParseSavedQueryExpectedResultString(expectedRowsAndComparison)
-	if rowCompType != dolt_ci.WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-		err = dolt_ci.ValidateQueryExpectedRowOrColumnCount(rowCount, expectedRows, rowCompType, "row")
-		if err != nil {
-			errStr := fmt.Sprintf("Assertion failed: %s", err.Error())
-			errs = append(errs, errStr)
+		lines = append(lines, fmt.Sprintf("  - test: %s (group: %s) - %s", test.Name, test.Group, statusColored))
+		if test.Status != "PASS" {
+			// add separate error line, with error message colored red
+			lines = append(lines, fmt.Sprintf("    - error: %s", color.RedString(test.Message)))
 		}
 	}
-
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-	return nil
+	return strings.Join(lines, "\n")
 }
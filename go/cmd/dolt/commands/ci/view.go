@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dolthub/go-mysql-server/sql"
 	"gopkg.in/yaml.v3"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -27,7 +26,6 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
-	"github.com/dolthub/dolt/go/store/val"
 )
 
 var viewDocs = cli.CommandDocumentationContent{
@@ -107,7 +105,7 @@ func (cmd ViewCmd) Exec(ctx context.Context, commandStr string, args []string, _
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	savedQueries, err := getSavedQueries(queryist.Context, queryist.Queryist)
+	savedQueries, err := dolt_ci.GetSavedQueries(queryist.Context, queryist.Queryist)
 	if err != nil {
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
@@ -181,59 +179,3 @@ func updateConfigQueryStatements(config *dolt_ci.WorkflowConfig, savedQueries ma
 
 	return config, nil
 }
-
-func getSavedQueries(sqlCtx *sql.Context, queryist cli.Queryist) (map[string]string, error) {
-	savedQueries := make(map[string]string)
-	resetFunc, err := cli.SetSystemVar(queryist, sqlCtx, true)
-	if err != nil {
-		return nil, err
-	}
-
-	_, rowIter, _, err := queryist.Query(sqlCtx, "SHOW TABLES LIKE 'dolt_query_catalog'")
-	if err != nil {
-		return nil, err
-	}
-	if resetFunc != nil {
-		err = resetFunc()
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
-	if len(rows) > 0 {
-		_, rowIter, _, err = queryist.Query(sqlCtx, "SELECT * FROM dolt_query_catalog")
-		if err != nil {
-			return nil, err
-		}
-		rows, err = sql.RowIterToRows(sqlCtx, rowIter)
-		if err != nil {
-			return nil, err
-		}
-		for _, row := range rows {
-			var queryName, queryStatement string
-			queryName, err = getStringColAsString(sqlCtx, row[2])
-			if err != nil {
-				return nil, err
-			}
-			queryStatement, err := getStringColAsString(sqlCtx, row[3])
-			if err != nil {
-				return nil, err
-			}
-			savedQueries[queryName] = queryStatement
-		}
-	}
-	return savedQueries, nil
-}
-
-// The dolt_query_catalog system table returns *val.TextStorage types under certain situations,
-// so we use a special parser to get the correct string values
-func getStringColAsString(sqlCtx *sql.Context, tableValue interface{}) (string, error) {
-	if ts, ok := tableValue.(*val.TextStorage); ok {
-		return ts.Unwrap(sqlCtx)
-	} else if str, ok := tableValue.(string); ok {
-		return str, nil
-	} else {
-		return "", fmt.Errorf("unexpected type %T, was expecting string", tableValue)
-	}
-}
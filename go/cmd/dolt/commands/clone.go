@@ -44,6 +44,8 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}}, the clone is sparse: only the row and index data of the listed tables is cloned, along with the schemas of all tables and the full commit history. Reading any other table is an error until it is added with {{.EmphasisLeft}}dolt fetch --tables{{.EmphasisRight}}. A sparse clone can commit and push changes to the tables it has.
 `,
 	Synopsis: []string{
 		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
@@ -166,6 +168,17 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	// Nil out the old Dolt env so we don't accidentally operate on the wrong database
 	dEnv = nil
 
+	if tables, ok := apr.GetValue(cli.TablesFlag); ok {
+		patterns := env.ParseSparseTables(tables)
+		if len(patterns) == 0 {
+			return errhand.BuildDError("error: --%s requires at least one table", cli.TablesFlag).Build()
+		}
+		err = clonedEnv.SetSparseTables(patterns)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+	}
+
 	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
 		err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, clonedEnv, statsCh)
 	})
@@ -180,6 +193,13 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 		return errhand.VerboseErrorFromError(err)
 	}
 
+	if apr.Contains(cli.LazyFlag) {
+		err = clonedEnv.EnableLazyFetch(remoteName)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+	}
+
 	evt := events.GetEventFromContext(ctx)
 	u, err := earl.Parse(remoteUrl)
 	if err == nil {
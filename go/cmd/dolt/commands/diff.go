@@ -37,6 +37,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typecompatibility"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/tabular"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
@@ -88,7 +89,9 @@ To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expre
 
 To filter diff output by change type, use {{.EmphasisLeft}}--filter <type>{{.EmphasisRight}} where {{.EmphasisLeft}}<type>{{.EmphasisRight}} is one of {{.EmphasisLeft}}added{{.EmphasisRight}}, {{.EmphasisLeft}}modified{{.EmphasisRight}}, {{.EmphasisLeft}}renamed{{.EmphasisRight}}, or {{.EmphasisLeft}}dropped{{.EmphasisRight}}. The {{.EmphasisLeft}}added{{.EmphasisRight}} filter shows only additions (new tables or rows), {{.EmphasisLeft}}modified{{.EmphasisRight}} shows only schema modifications or row updates, {{.EmphasisLeft}}renamed{{.EmphasisRight}} shows only renamed tables, and {{.EmphasisLeft}}dropped{{.EmphasisRight}} shows only deletions (dropped tables or deleted rows). You can also use {{.EmphasisLeft}}removed{{.EmphasisRight}} as an alias for {{.EmphasisLeft}}dropped{{.EmphasisRight}}. For example, {{.EmphasisLeft}}dolt diff --filter=dropped{{.EmphasisRight}} shows only deleted rows and dropped tables.
 
-The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
+Tables and columns are matched between the two sides by their tags, so a table that was copied under a new name, or a column that was dropped and re-added under a new name, is shown as a drop and an add. With {{.EmphasisLeft}}--find-renames{{.EmphasisRight}}, a dropped and an added table with mostly the same columns and rows, or a dropped and an added column holding mostly the same values, are shown as a rename instead. The similarity required can be changed with the {{.EmphasisLeft}}@@dolt_find_renames{{.EmphasisRight}} system variable.
+
+The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}word{{.EmphasisRight}}, modified rows are presented as a single row, and only the words that changed within each column are highlighted, which suits long text and JSON documents. Without a color-enabled terminal, removed words are shown as {{.EmphasisLeft}}[-word-]{{.EmphasisRight}} and added words as {{.EmphasisLeft}}{+word+}{{.EmphasisRight}}. {{.EmphasisLeft}}--word-diff{{.EmphasisRight}} is a shorthand for {{.EmphasisLeft}}--diff-mode=word{{.EmphasisRight}}. When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
 		`[options] [{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}tables{{.GreaterThan}}...]`,
@@ -322,6 +325,13 @@ func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, _
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	if apr.Contains(cli.FindRenamesFlag) {
+		query := fmt.Sprintf("SET @@session.%s = %d", dsess.DoltFindRenames, diff.DefaultRenameSimilarity)
+		if _, err = cli.GetRowsForSql(queryist.Queryist, queryist.Context, query); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	dArgs, err := parseDiffArgs(queryist.Queryist, queryist.Context, apr)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -397,13 +407,19 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 	switch strings.ToLower(f) {
 	case "tabular":
 		displaySettings.diffOutput = TabularDiffOutput
-		switch strings.ToLower(apr.GetValueOrDefault(cli.DiffMode, "context")) {
+		diffMode := apr.GetValueOrDefault(cli.DiffMode, "context")
+		if apr.Contains(cli.WordDiffFlag) {
+			diffMode = "word"
+		}
+		switch strings.ToLower(diffMode) {
 		case "row":
 			displaySettings.diffMode = diff.ModeRow
 		case "line":
 			displaySettings.diffMode = diff.ModeLine
 		case "in-place":
 			displaySettings.diffMode = diff.ModeInPlace
+		case "word":
+			displaySettings.diffMode = diff.ModeWord
 		case "context":
 			displaySettings.diffMode = diff.ModeContext
 		}
@@ -54,12 +54,15 @@ const (
 	schemaOnlyFlag   = "schema-only"
 	noCreateDbFlag   = "no-create-db"
 
-	sqlFileExt     = "sql"
-	csvFileExt     = "csv"
-	jsonFileExt    = "json"
-	parquetFileExt = "parquet"
-	emptyFileExt   = ""
-	emptyStr       = ""
+	sqlFileExt         = "sql"
+	csvFileExt         = "csv"
+	jsonFileExt        = "json"
+	parquetFileExt     = "parquet"
+	arrowFileExt       = "arrow"
+	arrowStreamFileExt = "arrows"
+	avroFileExt        = "avro"
+	emptyFileExt       = ""
+	emptyStr           = ""
 )
 
 var dumpDocs = cli.CommandDocumentationContent{
@@ -68,7 +71,7 @@ var dumpDocs = cli.CommandDocumentationContent{
 If a dump file already exists then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag 
 is provided. The force flag forces the existing dump file to be overwritten. The {{.EmphasisLeft}}-r{{.EmphasisRight}} flag 
 is used to support different file formats of the dump. In the case of non .sql files each table is written to a separate
-csv,json or parquet file. 
+csv, json, parquet, arrow, arrows or avro file. 
 `,
 
 	Synopsis: []string{
@@ -96,7 +99,7 @@ func (cmd DumpCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json and parquet.")
+	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json, parquet, arrow, arrows and avro.")
 	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`.")
 	ap.SupportsString(directoryFlag, "d", "directory_name", "Define directory name to dump the files in. Defaults to `doltdump/`.")
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
@@ -204,7 +207,7 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		if err != nil {
 			return HandleVErrAndExitCode(err, usage)
 		}
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case csvFileExt, jsonFileExt, parquetFileExt, arrowFileExt, arrowStreamFileExt, avroFileExt:
 		err = dumpNonSqlTables(sqlCtx, engine.GetUnderlyingEngine(), root, dEnv, force, tblNames, resFormat, outputFileOrDirName, false)
 		if err != nil {
 			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -723,7 +726,7 @@ func validateDumpArgs(apr *argparser.ArgParseResults) (string, errhand.VerboseEr
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, sqlFileExt).SetPrintUsage().Build()
 		}
 		return fn, nil
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case csvFileExt, jsonFileExt, parquetFileExt, arrowFileExt, arrowStreamFileExt, avroFileExt:
 		if fnOk {
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", filenameFlag, rf).SetPrintUsage().Build()
 		}
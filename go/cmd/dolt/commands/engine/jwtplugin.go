@@ -27,6 +27,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
 )
 
+// DoltJWTAuthPlugin is the name of the auth plugin of users who authenticate with a JWT.
+const DoltJWTAuthPlugin = "authentication_dolt_jwt"
+
 // authenticateDoltJWTPlugin is used to authenticate plaintext user plugins
 type authenticateDoltJWTPlugin struct {
 	jwksConfig []servercfg.JwksConfig
@@ -40,33 +43,50 @@ func (p *authenticateDoltJWTPlugin) Authenticate(db *mysql_db.MySQLDb, user stri
 	return validateJWT(p.jwksConfig, user, userEntry.Identity, pass, time.Now())
 }
 
+// ValidateUserJWT validates |token| for |userEntry| in the same way as the authentication_dolt_jwt plugin does for
+// MySQL connections, and returns its claims. Users with any other auth plugin are refused.
+func ValidateUserJWT(config []servercfg.JwksConfig, userEntry *mysql_db.User, token string, reqTime time.Time) (*jwtauth.Claims, error) {
+	if userEntry.Plugin != DoltJWTAuthPlugin {
+		return nil, fmt.Errorf("ValidateJWT: user %s does not authenticate with %s", userEntry.User, DoltJWTAuthPlugin)
+	}
+	return validateJWTClaims(config, userEntry.User, userEntry.Identity, token, reqTime)
+}
+
 func validateJWT(config []servercfg.JwksConfig, username, identity, token string, reqTime time.Time) (bool, error) {
+	_, err := validateJWTClaims(config, username, identity, token, reqTime)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func validateJWTClaims(config []servercfg.JwksConfig, username, identity, token string, reqTime time.Time) (*jwtauth.Claims, error) {
 	if len(config) == 0 {
-		return false, errors.New("ValidateJWT: JWKS server config not found")
+		return nil, errors.New("ValidateJWT: JWKS server config not found")
 	}
 
 	expectedClaimsMap := parseUserIdentity(identity)
 	sub, ok := expectedClaimsMap["sub"]
 	if ok && sub != username {
-		return false, errors.New("ValidateJWT: Subjects do not match")
+		return nil, errors.New("ValidateJWT: Subjects do not match")
 	}
 
 	jwksConfig, err := getMatchingJwksConfig(config, expectedClaimsMap["jwks"])
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	pr, err := getJWTProvider(expectedClaimsMap, jwksConfig.LocationUrl)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	vd, err := jwtauth.NewJWTValidator(pr)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	claims, err := vd.ValidateJWT(token, reqTime)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logString := "Authenticating with JWT: "
@@ -74,7 +94,7 @@ func validateJWT(config []servercfg.JwksConfig, username, identity, token string
 		logString += fmt.Sprintf("%s: %s,", field, getClaimFromKey(claims, field))
 	}
 	logrus.Info(logString)
-	return true, nil
+	return claims, nil
 }
 
 func getJWTProvider(expectedClaimsMap map[string]string, url string) (jwtauth.JWTProvider, error) {
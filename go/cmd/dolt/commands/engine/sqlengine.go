@@ -86,6 +86,9 @@ type SqlEngineConfig struct {
 	SystemVariables            SystemVariables
 	ClusterController          *cluster.Controller
 	AutoGCController           *sqle.AutoGCController
+	WebhookController          *sqle.WebhookController
+	CIController               *sqle.CIController
+	JournalShippingController  *sqle.JournalShippingController
 	BinlogReplicaController    binlogreplication.BinlogReplicaController
 	EventSchedulerStatus       eventscheduler.SchedulerStatus
 	BranchActivityTracking     bool
@@ -270,7 +273,7 @@ func NewSqlEngine(
 	engine.Analyzer.Catalog.MySQLDb.SetPersister(persister)
 
 	engine.Analyzer.Catalog.MySQLDb.SetPlugins(map[string]mysql_db.PlaintextAuthPlugin{
-		"authentication_dolt_jwt": NewAuthenticateDoltJWTPlugin(config.JwksConfig),
+		DoltJWTAuthPlugin: NewAuthenticateDoltJWTPlugin(config.JwksConfig),
 	})
 
 	if config.AutoGCController != nil {
@@ -292,6 +295,45 @@ func NewSqlEngine(
 		})
 	}
 
+	if config.WebhookController != nil {
+		err = config.WebhookController.RunBackgroundThread(bThreads)
+		if err != nil {
+			return nil, err
+		}
+		err = config.WebhookController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.WebhookController.InitDatabaseHook())
+		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.WebhookController.DropDatabaseHook())
+	}
+
+	if config.CIController != nil {
+		err = config.CIController.RunBackgroundThread(bThreads, sqlEngine.NewLocalContext, sqlEngine, engine.Analyzer.Catalog)
+		if err != nil {
+			return nil, err
+		}
+		err = config.CIController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.CIController.InitDatabaseHook())
+		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.CIController.DropDatabaseHook())
+	}
+
+	if config.JournalShippingController != nil {
+		err = config.JournalShippingController.RunBackgroundThread(bThreads)
+		if err != nil {
+			return nil, err
+		}
+		err = config.JournalShippingController.AddDatabases(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.JournalShippingController.InitDatabaseHook())
+		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.JournalShippingController.DropDatabaseHook())
+	}
+
 	var statsPro sql.StatsProvider
 	_, enabled, _ := sql.SystemVariables.GetGlobal(dsess.DoltStatsEnabled)
 	if enabled.(int8) == 1 {
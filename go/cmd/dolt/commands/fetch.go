@@ -37,6 +37,8 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+In a sparse clone, {{.EmphasisLeft}}--tables{{.EmphasisRight}} adds tables to the clone, and fetches their data for the history already present.
 `,
 
 	Synopsis: []string{
@@ -143,6 +145,11 @@ func constructInterpolatedDoltFetchQuery(apr *argparser.ArgParseResults) (string
 		args = append(args, "?")
 		params = append(params, user)
 	}
+	if tables, hasTables := apr.GetValue(cli.TablesFlag); hasTables {
+		args = append(args, "'--tables'")
+		args = append(args, "?")
+		params = append(params, tables)
+	}
 	for _, arg := range apr.Args {
 		args = append(args, "?")
 		params = append(params, arg)
@@ -44,7 +44,9 @@ copied during a prior garbage collection.
 
 If the {{.EmphasisLeft}}--shallow{{.EmphasisRight}} flag is supplied, a faster but less thorough garbage collection will be performed.
 
-If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed.`,
+If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed.
+
+When encryption at rest is configured with {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY{{.EmphasisRight}} or {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_FILE{{.EmphasisRight}}, a full garbage collection rewrites every table file and the chunk journal with the current key. To rotate keys, make the new key current while still providing the old one, run {{.EmphasisLeft}}dolt gc --full{{.EmphasisRight}}, and then stop providing the old key. Setting {{.EmphasisLeft}}DOLT_ENCRYPTION_REQUIRED{{.EmphasisRight}} makes Dolt refuse to read or write unencrypted files, so an existing plaintext database must be encrypted by a full garbage collection before it is set.`,
 	Synopsis: []string{
 		"[--shallow|--full]",
 	},
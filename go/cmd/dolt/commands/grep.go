@@ -0,0 +1,170 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/fatih/color"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/util/outputpager"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var grepDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Search table data for a pattern.",
+	LongDesc: `Searches the text, binary and JSON columns of tables for cells matching the regular expression {{.LessThan}}pattern{{.GreaterThan}}, and prints the commit, table, primary key and column of every match. JSON values are matched against their serialized form.
+
+By default the working set is searched. When {{.LessThan}}revision{{.GreaterThan}} arguments are given, each of them is searched instead. A revision of the form {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}} searches the history between the two commits: every commit in the range is compared to its parent, and a match is printed with a {{.EmphasisLeft}}+{{.EmphasisRight}} for the commit it first appears in and a {{.EmphasisLeft}}-{{.EmphasisRight}} for the commit it disappears in. Only the rows changed by each commit are searched.
+
+Tables named after {{.EmphasisLeft}}--{{.EmphasisRight}} limit the search to those tables.
+
+The same search is available in SQL with the {{.EmphasisLeft}}dolt_grep(){{.EmphasisRight}} table function.`,
+	Synopsis: []string{
+		"[-i] {{.LessThan}}pattern{{.GreaterThan}} [{{.LessThan}}revision{{.GreaterThan}}...] [-- {{.LessThan}}table{{.GreaterThan}}...]",
+	},
+}
+
+type GrepCmd struct{}
+
+var _ cli.Command = GrepCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd GrepCmd) Name() string {
+	return "grep"
+}
+
+// Description returns a description of the command
+func (cmd GrepCmd) Description() string {
+	return grepDocs.ShortDesc
+}
+
+func (cmd GrepCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(grepDocs, ap)
+}
+
+func (cmd GrepCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateGrepArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd GrepCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command. Like git grep, it exits with 1 when nothing matches.
+func (cmd GrepCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, grepDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 || apr.PositionalArgsSeparatorIndex == 0 {
+		usage()
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := constructInterpolatedDoltGrepQuery(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err := cli.GetRowsForSql(queryist.Queryist, queryist.Context, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(rows) == 0 {
+		return 1
+	}
+
+	printGrepMatches(rows)
+	return 0
+}
+
+// constructInterpolatedDoltGrepQuery generates the sql query that calls the DOLT_GREP() table function with the
+// arguments given to the command, interpolating them to prevent sql injection.
+func constructInterpolatedDoltGrepQuery(apr *argparser.ArgParseResults) (string, error) {
+	var params []interface{}
+	var args []string
+
+	if apr.Contains(cli.IgnoreCaseFlag) {
+		args = append(args, "'--"+cli.IgnoreCaseFlag+"'")
+	}
+	for i, arg := range apr.Args {
+		if i == apr.PositionalArgsSeparatorIndex {
+			args = append(args, "'--'")
+		}
+		params = append(params, arg)
+		args = append(args, "?")
+	}
+
+	query := fmt.Sprintf("SELECT commit_hash, table_name, cast(primary_key as char), column_name, diff_type, value FROM DOLT_GREP(%s)", strings.Join(args, ", "))
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
+
+// printGrepMatches prints a line for every row of dolt_grep, in the form <commit>:<table>:<primary key>:<column>:
+// <value>. Matches found in commit ranges are prefixed with + or - for the commit they appeared or disappeared in.
+func printGrepMatches(rows []sql.Row) {
+	if cli.ExecuteWithStdioRestored == nil {
+		return
+	}
+	cli.ExecuteWithStdioRestored(func() {
+		pager := outputpager.Start()
+		defer pager.Stop()
+
+		for _, row := range rows {
+			prefix := ""
+			switch grepRowString(row[4]) {
+			case "added":
+				prefix = color.GreenString("+")
+			case "removed":
+				prefix = color.RedString("-")
+			}
+			line := fmt.Sprintf("%s%s:%s:%s:%s: %s\n",
+				prefix,
+				color.YellowString(grepRowString(row[0])),
+				color.MagentaString(grepRowString(row[1])),
+				grepRowString(row[2]),
+				color.CyanString(grepRowString(row[3])),
+				grepRowString(row[5]))
+			pager.Writer.Write([]byte(line))
+		}
+	})
+}
+
+func grepRowString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histcmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var pruneDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Squash old commits of a branch into a single root commit",
+	LongDesc: `Rewrites the history of a branch so that old commits are replaced by a single root commit with the same contents as the newest of them. Commits are selected along the first-parent history of the branch: with {{.EmphasisLeft}}--keep{{.EmphasisRight}}, the most recent {{.LessThan}}n{{.GreaterThan}} commits are kept, and with {{.EmphasisLeft}}--before{{.EmphasisRight}}, commits authored before the given date are pruned. If both are given, whichever selects the newer commit applies.
+
+Every kept commit is rewritten with the same data and metadata, but new parents, so its commit hash changes. Tags on kept commits are moved to the rewritten commits. Tags on pruned commits cause the prune to fail, unless {{.EmphasisLeft}}--delete-tags{{.EmphasisRight}} is given. The old branch head remains in the reflog.
+
+Pruning only rewrites the branch, it does not free any storage. Run {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} afterwards to collect chunks that are no longer referenced. Other branches are not rewritten, so pruned commits that are still in the history of another branch, or reachable from a remote, are not collected. The names of other branches whose history contains pruned commits are reported in a warning; prune or delete them as well to free the space. Pushing a pruned branch to a remote that has the old history requires {{.EmphasisLeft}}--force{{.EmphasisRight}}.
+
+Branches protected against force pushes or hard resets cannot be pruned.
+
+This command is backed by the {{.EmphasisLeft}}dolt_history_prune(){{.EmphasisRight}} stored procedure.`,
+	Synopsis: []string{
+		`--keep {{.LessThan}}n{{.GreaterThan}} [--delete-tags] [-m {{.LessThan}}msg{{.GreaterThan}}] [{{.LessThan}}branch{{.GreaterThan}}]`,
+		`--before {{.LessThan}}date{{.GreaterThan}} [--delete-tags] [-m {{.LessThan}}msg{{.GreaterThan}}] [{{.LessThan}}branch{{.GreaterThan}}]`,
+	},
+}
+
+type PruneCmd struct{}
+
+var _ cli.Command = PruneCmd{}
+
+// Name implements cli.Command.
+func (cmd PruneCmd) Name() string {
+	return "prune"
+}
+
+// Description implements cli.Command.
+func (cmd PruneCmd) Description() string {
+	return pruneDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd PruneCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(pruneDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd PruneCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateHistoryPruneArgParser()
+}
+
+// Exec implements cli.Command.
+func (cmd PruneCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, pruneDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if !apr.Contains(cli.KeepParam) && !apr.Contains(cli.BeforeParam) {
+		usage()
+		return 1
+	}
+
+	// This command creates a commit, so we need user identity.
+	if !apr.Contains(cli.AuthorParam) && !cli.CheckUserNameAndEmail(cliCtx.Config()) {
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := constructInterpolatedDoltHistoryPruneQuery(apr, cliCtx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	_, rowIter, _, err := queryist.Queryist.Query(queryist.Context, query)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	rows, err := sql.RowIterToRows(queryist.Context, rowIter)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if len(rows) != 1 || len(rows[0]) != 4 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("unexpected result from dolt_history_prune").Build(), usage)
+	}
+
+	rewritten, err := cli.QueryValueAsInt64(rows[0][2])
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	cli.Printf("Pruned history; new root commit is %s\n", rows[0][1])
+	cli.Printf("Rewrote %d commits; new head is %s\n", rewritten, rows[0][0])
+	if unpruned := fmt.Sprint(rows[0][3]); unpruned != "" {
+		cli.PrintErrf("warning: branches %s still refer to pruned commits. Their history is not rewritten, and the "+
+			"pruned commits cannot be garbage collected until those branches are pruned or deleted.\n",
+			strings.ReplaceAll(unpruned, ",", ", "))
+	}
+	cli.Println("Run 'dolt gc' to reclaim the space used by the pruned commits.")
+	return 0
+}
+
+// constructInterpolatedDoltHistoryPruneQuery generates the sql query necessary to call the DOLT_HISTORY_PRUNE()
+// procedure.
+func constructInterpolatedDoltHistoryPruneQuery(apr *argparser.ArgParseResults, cliCtx cli.CliContext) (string, error) {
+	var params []interface{}
+	var placeholders []string
+	addArg := func(arg string) {
+		params = append(params, arg)
+		placeholders = append(placeholders, "?")
+	}
+
+	author, ok := apr.GetValue(cli.AuthorParam)
+	if !ok {
+		name, email, err := env.GetNameAndEmail(cliCtx.Config())
+		if err != nil {
+			return "", err
+		}
+		author = fmt.Sprintf("%s <%s>", name, email)
+	}
+	addArg("--" + cli.AuthorParam)
+	addArg(author)
+
+	for _, param := range []string{cli.KeepParam, cli.BeforeParam, cli.MessageArg} {
+		if val, ok := apr.GetValue(param); ok {
+			addArg("--" + param)
+			addArg(val)
+		}
+	}
+	if apr.Contains(cli.DeleteTagsFlag) {
+		addArg("--" + cli.DeleteTagsFlag)
+	}
+	for _, arg := range apr.Args {
+		addArg(arg)
+	}
+
+	query := fmt.Sprintf("CALL DOLT_HISTORY_PRUNE(%s)", strings.Join(placeholders, ", "))
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/util/outputpager"
 	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
@@ -50,6 +51,9 @@ The command takes options to control what is shown and how.
 {{.EmphasisLeft}}dolt log [<revisions>...] -- <table>{{.EmphasisRight}}
   Lists commit logs starting from revisions, only including commits with changes to table.
 	
+{{.EmphasisLeft}}dolt log [--author <pattern>] [--grep <pattern>] [--since <date>] [--until <date>]{{.EmphasisRight}}
+  Lists only commits whose author and message match the given regular expressions and which were committed within the given dates. The author is matched in the form A U Thor {{.LessThan}}author@example.com{{.GreaterThan}}. These options can be combined with revisions and tables.
+	
 {{.EmphasisLeft}}dolt log <revisionB>..<revisionA>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log <revisionA> --not <revisionB>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log ^<revisionB> <revisionA>{{.EmphasisRight}}
@@ -59,7 +63,7 @@ The command takes options to control what is shown and how.
 {{.EmphasisLeft}}dolt log <revisionA> <revisionB> --not $(dolt merge-base <revisionA> <revisionB>){{.EmphasisRight}}
   Different ways to list three dot logs. These will list commit logs reachable by revisionA OR revisionB, while excluding commits reachable by BOTH revisionA AND revisionB.`,
 	Synopsis: []string{
-		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}revision-range{{.GreaterThan}}] [[--] {{.LessThan}}table{{.GreaterThan}}]`,
+		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [--author {{.LessThan}}pattern{{.GreaterThan}}] [--grep {{.LessThan}}pattern{{.GreaterThan}}] [--since {{.LessThan}}date{{.GreaterThan}}] [--until {{.LessThan}}date{{.GreaterThan}}] [{{.LessThan}}revision-range{{.GreaterThan}}] [[--] {{.LessThan}}table{{.GreaterThan}}]`,
 	},
 }
 
@@ -235,6 +239,13 @@ func constructInterpolatedDoltLogQuery(apr *argparser.ArgParseResults, queryist
 		writeToBuffer("'--merges'")
 	}
 
+	for _, param := range []string{cli.AuthorParam, cli.GrepParam, cli.SinceParam, cli.UntilParam} {
+		if val, ok := apr.GetValue(param); ok {
+			writeToBuffer("?")
+			params = append(params, "--"+param+"="+val)
+		}
+	}
+
 	if excludedCommits, hasExcludedCommits := apr.GetValueList(cli.NotFlag); hasExcludedCommits {
 		writeToBuffer("'--not'")
 		for _, commit := range excludedCommits {
@@ -311,6 +322,20 @@ func logCommits(apr *argparser.ArgParseResults, commitHashes []sql.Row, queryist
 		commitsInfo = append(commitsInfo, *commit)
 	}
 
+	if apr.Contains(cli.NotesFlag) {
+		rows, err := InterpolateAndRunQuery(queryist, sqlCtx, "select commit_hash, note from dolt_notes where ref = ?", ref.DefaultNotesRefName)
+		if err != nil {
+			return err
+		}
+		notes := make(map[string]string, len(rows))
+		for _, row := range rows {
+			notes[row[0].(string)] = row[1].(string)
+		}
+		for i := range commitsInfo {
+			commitsInfo[i].note = notes[commitsInfo[i].commitHash]
+		}
+	}
+
 	// Resolve auto before opening the pager. checkIsTerminal uses ExecuteWithStdioRestored,
 	// which mutates os.Stdout, and the pager block calls ExecuteWithStdioRestored too.
 	// Calling it from inside the pager block leaves the pager holding a stale stdout handle.
@@ -76,6 +76,33 @@ func TestComputeColumnEnds(t *testing.T) {
 	require.Equal(t, 0, result[2].Col)
 	require.Equal(t, 0, result[3].Col)
 
+	// Test an octopus merge of two branches:
+	//
+	//        1A (branchA)
+	//       /  \
+	//   1M - 2M - 3M (main)
+	//       \  /
+	//        1B (branchB)
+	commits = []*commitInfoWithChildren{
+		{Commit: CommitInfo{commitHash: "3M", parentHashes: []string{"2M", "1A", "1B"}}, Children: []string{}, Row: 0},
+		{Commit: CommitInfo{commitHash: "1B", parentHashes: []string{"1M"}}, Children: []string{"3M"}, Row: 1},
+		{Commit: CommitInfo{commitHash: "1A", parentHashes: []string{"1M"}}, Children: []string{"3M"}, Row: 2},
+		{Commit: CommitInfo{commitHash: "2M", parentHashes: []string{"1M"}}, Children: []string{"3M"}, Row: 3},
+		{Commit: CommitInfo{commitHash: "1M", parentHashes: []string{}}, Children: []string{"1B", "1A", "2M"}, Row: 4},
+	}
+	commitsMap = map[string]*commitInfoWithChildren{
+		"3M": commits[0],
+		"1B": commits[1],
+		"1A": commits[2],
+		"2M": commits[3],
+		"1M": commits[4],
+	}
+	result, _ = computeColumnEnds(commits, commitsMap)
+	require.Equal(t, 0, result[0].Col)
+	require.Equal(t, 1, result[1].Col)
+	require.Equal(t, 2, result[2].Col)
+	require.Equal(t, 0, result[3].Col)
+	require.Equal(t, 0, result[4].Col)
 }
 
 func TestDrawCommitDotsAndBranchPaths(t *testing.T) {
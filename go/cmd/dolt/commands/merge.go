@@ -49,12 +49,20 @@ var mergeDocs = cli.CommandDocumentationContent{
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+{{.EmphasisLeft}}-s ours{{.EmphasisRight}} records a merge without taking any changes from the other branch. {{.EmphasisLeft}}-X ours{{.EmphasisRight}} and {{.EmphasisLeft}}-X theirs{{.EmphasisRight}} merge normally, but resolve every data and schema conflict in favor of one side instead of stopping the merge. A table with a schema conflict is taken whole from the chosen side, which discards the other side's changes to that table, including changes that don't conflict; a warning names each such table. The side can be chosen per table, e.g. {{.EmphasisLeft}}-X ours,customers=theirs{{.EmphasisRight}}. Constraint violations are not resolved by either option.
+
+When more than one branch is given, they are all merged into the current branch with a single merge commit, called an octopus merge, whose parents are HEAD followed by each branch in the order given. An octopus merge is only made when every branch merges cleanly, and requires a clean working set; if any branch would produce conflicts or constraint violations, the merge is abandoned without changing anything.
+
+Tables are merged by name, so a table renamed on one branch and changed on the other is a conflict. When the {{.EmphasisLeft}}@@dolt_find_renames{{.EmphasisRight}} system variable is set to a similarity percentage, tables and columns renamed on either branch, including tables copied under a new name and columns dropped and re-added under a new name, are found as they are by {{.EmphasisLeft}}dolt diff --find-renames{{.EmphasisRight}}, and the other branch's changes are merged into them under their new names.
 `,
 
 	Synopsis: []string{
 		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--ff-only {{.LessThan}}branch{{.GreaterThan}}",
+		"[-s {{.LessThan}}strategy{{.GreaterThan}}] [-X {{.LessThan}}option{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
+		"[-m message] {{.LessThan}}branch{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}...",
 		"--abort",
 	},
 }
@@ -178,10 +186,16 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 			cli.Println("merge finished, but failed to get hash of HEAD ref")
 			cli.Println(headHashErr.Error())
 		}
-		mergeHash, mergeHashErr := getHashOf(queryist.Queryist, queryist.Context, apr.Arg(0))
-		if mergeHashErr != nil {
-			cli.Println("merge finished, but failed to get hash of merge ref")
-			cli.Println(mergeHashErr.Error())
+		var mergeHash string
+		if apr.NArg() > 1 {
+			cli.Println("Merge made by the 'octopus' strategy.")
+		} else {
+			var mergeHashErr error
+			mergeHash, mergeHashErr = getHashOf(queryist.Queryist, queryist.Context, apr.Arg(0))
+			if mergeHashErr != nil {
+				cli.Println("merge finished, but failed to get hash of merge ref")
+				cli.Println(mergeHashErr.Error())
+			}
 		}
 
 		fastFwd := getFastforward(mergeResultRow, dprocedures.MergeProcFFIndex)
@@ -223,7 +237,7 @@ func validateDoltMergeArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinte
 			return 1
 		}
 	} else if apr.Contains(cli.NoFFParam) {
-		if apr.NArg() == 0 || apr.NArg() > 2 {
+		if apr.NArg() == 0 {
 			usage()
 			return 1
 		}
@@ -324,13 +338,23 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 		params = append(params, msg)
 	}
 
+	for _, param := range []string{cli.StrategyParam, cli.StrategyOptionParam} {
+		if val, ok := apr.GetValue(param); ok {
+			writeToBuffer("--"+param, false)
+			writeToBuffer("?", true)
+			params = append(params, val)
+		}
+	}
+
 	if apr.Contains(cli.SkipVerificationFlag) {
 		writeToBuffer("--skip-verification", false)
 	}
 
 	if !apr.Contains(cli.AbortParam) && !apr.Contains(cli.SquashParam) {
-		writeToBuffer("?", true)
-		params = append(params, apr.Arg(0))
+		for _, arg := range apr.Args {
+			writeToBuffer("?", true)
+			params = append(params, arg)
+		}
 	}
 
 	buffer.WriteString(")")
@@ -0,0 +1,173 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var notesDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Add or inspect notes attached to commits.",
+	LongDesc: `Attaches notes to commits without changing them, e.g. to record review approvals, ticket ids or data quality results after the fact.
+
+Notes are kept in a notes ref, {{.EmphasisLeft}}refs/notes/commits{{.EmphasisRight}} unless another is named with {{.EmphasisLeft}}--ref{{.EmphasisRight}}. Every change to a notes ref is recorded as a commit, so notes refs have a history of their own and can be pushed, pulled and merged independently of branches.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Attaches the note given with {{.EmphasisLeft}}-m{{.EmphasisRight}} to the commit, HEAD by default. An existing note is only replaced with {{.EmphasisLeft}}-f{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}show{{.EmphasisRight}}
+Prints the note attached to the commit, HEAD by default.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+Lists the annotated commits, or the note of the commit given.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}
+Removes the notes attached to the commits given, HEAD by default.
+
+{{.EmphasisLeft}}merge{{.EmphasisRight}}
+Merges the notes ref given into the current notes ref. Notes changed on both sides are concatenated, or resolved with {{.EmphasisLeft}}--strategy{{.EmphasisRight}} {{.EmphasisLeft}}ours{{.EmphasisRight}} or {{.EmphasisLeft}}theirs{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}, {{.EmphasisLeft}}pull{{.EmphasisRight}}
+Sends the notes ref to a remote, or fetches it from a remote and merges it as {{.EmphasisLeft}}merge{{.EmphasisRight}} does. Pushes must fast-forward the remote's notes.
+
+Notes are shown by {{.EmphasisLeft}}dolt log --notes{{.EmphasisRight}} and can be queried with the {{.EmphasisLeft}}dolt_notes{{.EmphasisRight}} system table. This command is backed by the {{.EmphasisLeft}}dolt_notes(){{.EmphasisRight}} stored procedure.`,
+	Synopsis: []string{
+		"add [-f] -m {{.LessThan}}msg{{.GreaterThan}} [{{.LessThan}}commit{{.GreaterThan}}...]",
+		"show [{{.LessThan}}commit{{.GreaterThan}}]",
+		"list [{{.LessThan}}commit{{.GreaterThan}}]",
+		"remove [{{.LessThan}}commit{{.GreaterThan}}...]",
+		"merge [-s {{.LessThan}}strategy{{.GreaterThan}}] {{.LessThan}}notes-ref{{.GreaterThan}}",
+		"push [{{.LessThan}}remote{{.GreaterThan}}]",
+		"pull [-s {{.LessThan}}strategy{{.GreaterThan}}] [{{.LessThan}}remote{{.GreaterThan}}]",
+	},
+}
+
+type NotesCmd struct{}
+
+var _ cli.Command = NotesCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd NotesCmd) Name() string {
+	return "notes"
+}
+
+// Description returns a description of the command
+func (cmd NotesCmd) Description() string {
+	return notesDocs.ShortDesc
+}
+
+func (cmd NotesCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(notesDocs, ap)
+}
+
+func (cmd NotesCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateNotesArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd NotesCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd NotesCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, notesDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	var verr errhand.VerboseError
+	switch strings.ToLower(apr.Arg(0)) {
+	case "show", "list":
+		verr = showNotes(queryist.Queryist, queryist.Context, apr)
+	default:
+		query, err := interpolateStoredProcedureCall("DOLT_NOTES", args)
+		if err == nil {
+			_, err = cli.GetRowsForSql(queryist.Queryist, queryist.Context, query)
+		}
+		if err != nil {
+			verr = errhand.VerboseErrorFromError(err)
+		}
+	}
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+// showNotes runs the show and list subcommands, which read the dolt_notes system table.
+func showNotes(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) errhand.VerboseError {
+	subcommand := strings.ToLower(apr.Arg(0))
+	for _, param := range []string{cli.MessageArg, cli.ForceFlag, cli.StrategyParam} {
+		if apr.Contains(param) {
+			return errhand.BuildDError("error: --%s can't be used with %s", param, subcommand).SetPrintUsage().Build()
+		}
+	}
+	if apr.NArg() > 2 {
+		return errhand.BuildDError("error: %s takes at most one commit", subcommand).SetPrintUsage().Build()
+	}
+	parsedRef, err := ref.ParseNotesRef(apr.GetValueOrDefault(cli.RefParam, ref.DefaultNotesRefName))
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	notesRef := parsedRef.GetPath()
+
+	if subcommand == "list" && apr.NArg() == 1 {
+		rows, err := InterpolateAndRunQuery(queryist, sqlCtx, "select commit_hash from dolt_notes where ref = ? order by commit_hash", notesRef)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		for _, row := range rows {
+			cli.Println(row[0])
+		}
+		return nil
+	}
+
+	rev := "HEAD"
+	if apr.NArg() == 2 {
+		rev = apr.Arg(1)
+	}
+	commitHash, err := getHashOf(queryist, sqlCtx, rev)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	rows, err := InterpolateAndRunQuery(queryist, sqlCtx, "select note from dolt_notes where ref = ? and commit_hash = ?", notesRef, commitHash)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if len(rows) == 0 {
+		return errhand.BuildDError("error: no note found for commit %s", commitHash).Build()
+	}
+	cli.Println(fmt.Sprint(rows[0][0]))
+	return nil
+}
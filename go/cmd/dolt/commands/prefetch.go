@@ -0,0 +1,91 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var prefetchDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Fetch the data of a revision missing from a shallow clone.",
+	LongDesc: `Fetches the data of the revision given, or of the tables given at that revision, from a remote ahead of time.
+
+A clone made with {{.EmphasisLeft}}dolt clone --depth {{.LessThan}}depth{{.GreaterThan}} --lazy{{.EmphasisRight}} fetches the history and data it is missing from its remote as they are read, one request at a time. Prefetching the revisions that will be queried, e.g. with {{.EmphasisLeft}}AS OF{{.EmphasisRight}}, fetches their data in bulk instead. Lazy fetching is configured by the {{.EmphasisLeft}}lazyfetch.remote{{.EmphasisRight}} config, which names the remote to fetch from.
+
+Chunks fetched lazily or prefetched are kept in the local database until a {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} finds them unreachable from the history of the clone. This command is backed by the {{.EmphasisLeft}}dolt_prefetch(){{.EmphasisRight}} stored procedure.`,
+	Synopsis: []string{
+		"[--remote {{.LessThan}}name{{.GreaterThan}}] {{.LessThan}}revision{{.GreaterThan}} [{{.LessThan}}table{{.GreaterThan}}...]",
+	},
+}
+
+type PrefetchCmd struct{}
+
+var _ cli.Command = PrefetchCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd PrefetchCmd) Name() string {
+	return "prefetch"
+}
+
+// Description returns a description of the command
+func (cmd PrefetchCmd) Description() string {
+	return prefetchDocs.ShortDesc
+}
+
+func (cmd PrefetchCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(prefetchDocs, ap)
+}
+
+func (cmd PrefetchCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreatePrefetchArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd PrefetchCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd PrefetchCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, prefetchDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := interpolateStoredProcedureCall("DOLT_PREFETCH", args)
+	if err == nil {
+		_, err = cli.GetRowsForSql(queryist.Queryist, queryist.Context, query)
+	}
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
@@ -57,6 +57,10 @@ GCP remote urls should be of the form gs://gcs-bucket/database and will use the
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+The table files of aws, gs, az, oci, oss and git remotes are encrypted with the keys in the file given by {{.EmphasisLeft}}--encryption-key-file{{.EmphasisRight}}, in the same format as {{.EmphasisLeft}}DOLT_ENCRYPTION_KEY_FILE{{.EmphasisRight}}. These keys are separate from the ones that encrypt local databases, and are needed by anyone who clones or fetches from the remote.
+
+A bundle made by {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}} can be used as a read-only remote by providing a url in the format bundle://path to the bundle file.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
 Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
 
@@ -227,6 +231,9 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
+	if err == nil {
+		err = cli.AddEncryptionParams(scheme, apr, params)
+	}
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
 	}
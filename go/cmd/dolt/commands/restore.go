@@ -0,0 +1,185 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var restoreDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Restore a database from its shipped journal, as of any point in time.",
+	LongDesc: `Restores the database whose chunk journal a sql-server shipped to {{.LessThan}}url{{.GreaterThan}} into the new directory {{.LessThan}}name{{.GreaterThan}}.
+
+A sql-server configured with {{.EmphasisLeft}}journal_shipping{{.EmphasisRight}} continuously ships the journal of each of its databases to {{.LessThan}}journal_shipping.url{{.GreaterThan}}/{{.LessThan}}database{{.GreaterThan}}, including the working set changes made between commits. {{.EmphasisLeft}}file://{{.EmphasisRight}}, {{.EmphasisLeft}}localbs://{{.EmphasisRight}} and {{.EmphasisLeft}}gs://{{.EmphasisRight}} URLs are supported.
+
+With {{.EmphasisLeft}}--to-time{{.EmphasisRight}}, the database is restored as it was at that time, to the second. Times without a time zone are in UTC. Otherwise the latest state shipped is restored. Every branch, tag and working set is restored, and the restored database has the default branch checked out.`,
+	Synopsis: []string{
+		"[--to-time {{.LessThan}}time{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+	},
+}
+
+type RestoreCmd struct{}
+
+var _ cli.Command = RestoreCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RestoreCmd) Name() string {
+	return "restore"
+}
+
+// Description returns a description of the command
+func (cmd RestoreCmd) Description() string {
+	return restoreDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd RestoreCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd RestoreCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(restoreDocs, ap)
+}
+
+func (cmd RestoreCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateRestoreArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd RestoreCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd RestoreCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, restoreDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 2 {
+		usage()
+		return 1
+	}
+
+	verr := restoreShippedJournal(ctx, apr, dEnv)
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func restoreShippedJournal(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
+	urlStr, dir := apr.Arg(0), apr.Arg(1)
+
+	var at time.Time
+	if toTime, ok := apr.GetValue(cli.ToTimeParam); ok {
+		var err error
+		at, err = dconfig.ParseDate(toTime)
+		if err != nil {
+			return errhand.BuildDError("error: invalid --%s '%s'", cli.ToTimeParam, toTime).AddCause(err).Build()
+		}
+	}
+
+	if exists, _ := dEnv.FS.Exists(dir); exists {
+		return errhand.BuildDError("error: '%s' already exists", dir).Build()
+	}
+
+	bs, err := dbfactory.NewBlobstore(ctx, urlStr)
+	if err != nil {
+		return errhand.BuildDError("error: '%s' is not valid.", urlStr).AddCause(err).Build()
+	}
+
+	// The shipped store is rebuilt in a temporary directory and then copied into the new database, which gets a fresh
+	// journal of its own.
+	shippedDir, err := os.MkdirTemp("", "dolt-restore-")
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	defer os.RemoveAll(shippedDir)
+
+	restoredAt, err := nbs.RestoreShippedJournal(ctx, bs, shippedDir, at)
+	if errors.Is(err, nbs.ErrNoShippedRoot) {
+		return errhand.BuildDError("error: %s", err.Error()).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to restore from '%s'", urlStr).AddCause(err).Build()
+	}
+
+	params := map[string]interface{}{
+		dbfactory.ChunkJournalParam:          struct{}{},
+		dbfactory.DisableSingletonCacheParam: struct{}{},
+	}
+	srcDB, err := doltdb.LoadDoltDBWithParams(ctx, types.Format_DOLT, earl.FileUrlFromPath(shippedDir, os.PathSeparator), filesys.LocalFS, params)
+	if err != nil {
+		return errhand.BuildDError("error: failed to load the restored database").AddCause(err).Build()
+	}
+	defer srcDB.Close()
+
+	restoredEnv, err := actions.EnvForClone(ctx, types.Format_DOLT, env.NoRemote, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	err = syncRestoredRoots(ctx, srcDB, restoredEnv)
+	if err != nil {
+		restoredEnv.FS.Delete(".", true)
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	cli.Printf("restored %s as of %s\n", dir, restoredAt.Local().Format(time.RFC3339))
+	return nil
+}
+
+// syncRestoredRoots copies every ref of |srcDB|, including working sets, into the new database of |restoredEnv| and
+// checks out its default branch.
+func syncRestoredRoots(ctx context.Context, srcDB *doltdb.DoltDB, restoredEnv *env.DoltEnv) error {
+	tempTableDir, err := restoredEnv.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+	destDB := restoredEnv.DoltDB(ctx)
+	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+		err = actions.SyncRoots(ctx, srcDB, destDB, tempTableDir, actions.SyncRootsDBRelationshipUnrelated, statsCh)
+	})
+	if err != nil {
+		return err
+	}
+
+	branches, err := destDB.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	branch := env.GetDefaultBranch(restoredEnv, branches)
+	restoredEnv.RepoState, err = env.CreateRepoState(restoredEnv.FS, ref.NewBranchRef(branch).String())
+	return err
+}
@@ -604,6 +604,26 @@ func (cfg *commandLineServerConfig) AutoGCBehavior() servercfg.AutoGCBehavior {
 	return stubAutoGCBehavior{}
 }
 
+// Webhooks returns nil for command-line config, which cannot configure webhooks.
+func (cfg *commandLineServerConfig) Webhooks() []servercfg.WebhookConfig {
+	return nil
+}
+
+// HTTPAPI returns nil for command-line config, which cannot enable the HTTP query API.
+func (cfg *commandLineServerConfig) HTTPAPI() servercfg.HTTPAPIConfig {
+	return nil
+}
+
+// JournalShipping returns nil for command-line config, which cannot enable journal shipping.
+func (cfg *commandLineServerConfig) JournalShipping() servercfg.JournalShippingConfig {
+	return nil
+}
+
+// CIWorkflows returns the default for command-line config, which cannot enable CI workflows.
+func (cfg *commandLineServerConfig) CIWorkflows() bool {
+	return servercfg.DefaultCIWorkflows
+}
+
 func (cfg *commandLineServerConfig) Overrides() sql.EngineOverrides {
 	return sql.EngineOverrides{}
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
 
@@ -120,6 +121,37 @@ func FindAndLoadLocalCreds(fs filesys.Filesys) (creds *LocalCreds, err error) {
 	return nil, nil
 }
 
+// FindWorktreeLocalCreds looks for a ServerLocalCredsFile in the .dolt
+// directory of each working directory of the repository of |dEnv|, which has
+// worktrees. A server started in any of them serves the repository, and so
+// the branches checked out in all of them. When we find one, we return its
+// contents along with the name of the database the server knows the
+// repository by. If no server is running, returns `nil` *LocalCreds and a
+// `nil` error.
+func FindWorktreeLocalCreds(dEnv *env.DoltEnv) (creds *LocalCreds, dbName string, err error) {
+	worktrees, err := dEnv.Worktrees()
+	if err != nil {
+		return nil, "", err
+	}
+	for _, wt := range worktrees {
+		if wt.Missing {
+			continue
+		}
+		wtFS, err := dEnv.FS.WithWorkingDir(wt.Path)
+		if err != nil {
+			return nil, "", err
+		}
+		creds, err := LoadLocalCreds(wtFS)
+		if errors.Is(err, iofs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, "", err
+		}
+		return creds, dbfactory.DirToDBName(filepath.Base(wt.Path)), nil
+	}
+	return nil, "", nil
+}
+
 func LoadLocalCreds(fs filesys.Filesys) (creds *LocalCreds, err error) {
 	rd, err := fs.OpenForRead(filepath.Join(dbfactory.DoltDir, ServerLocalCredsFile))
 	if err != nil {
@@ -0,0 +1,549 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	jsontable "github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+)
+
+const (
+	httpAPIQueryPath = "/v1/query"
+	// maxHTTPQueryRequestBytes bounds the size of a query request body
+	maxHTTPQueryRequestBytes = 16 * 1024 * 1024
+
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// httpQueryRequest is the body of a request to the HTTP query API.
+type httpQueryRequest struct {
+	// Query is a single SQL statement, which may contain ? placeholders
+	Query string `json:"query"`
+	// Params are bound to the placeholders of Query, in order
+	Params []interface{} `json:"params,omitempty"`
+	// Database is the database the statement is run in. It may be omitted if the statement qualifies its tables.
+	Database string `json:"database,omitempty"`
+	// Branch runs the statement against a branch of Database other than its default branch
+	Branch string `json:"branch,omitempty"`
+	// Commit runs the statement against a commit of Database, which is read-only
+	Commit string `json:"commit,omitempty"`
+	// Format is "json" or "ndjson". If it is empty, the format is chosen from the Accept header.
+	Format string `json:"format,omitempty"`
+}
+
+type httpColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// httpOkResult is the response to a statement which doesn't return rows.
+type httpOkResult struct {
+	RowsAffected uint64 `json:"rows_affected"`
+	LastInsertID uint64 `json:"last_insert_id"`
+}
+
+type httpError struct {
+	Error string `json:"error"`
+}
+
+// httpAPIServer serves the HTTP query API, which runs one SQL statement per request as an authenticated user and
+// returns its results as JSON or newline-delimited JSON. Users authenticate with HTTP basic auth, using the same
+// users and passwords as the MySQL listener, or with a bearer token. The subject of a bearer token names the user the
+// statement runs as, who must be identified with the authentication_dolt_jwt plugin against the configured JWKS.
+type httpAPIServer struct {
+	cfg       servercfg.HTTPAPIConfig
+	sqlEngine *engine.SqlEngine
+	lgr       *logrus.Entry
+
+	jwks *servercfg.JwksConfig
+}
+
+func newHTTPAPIServer(cfg servercfg.HTTPAPIConfig, jwks []servercfg.JwksConfig, sqlEngine *engine.SqlEngine, lgr *logrus.Logger) (*httpAPIServer, error) {
+	s := &httpAPIServer{
+		cfg:       cfg,
+		sqlEngine: sqlEngine,
+		lgr:       lgr.WithField("component", "http-api"),
+	}
+	if cfg.Jwks() == "" {
+		return s, nil
+	}
+
+	for i := range jwks {
+		if jwks[i].Name == cfg.Jwks() {
+			s.jwks = &jwks[i]
+		}
+	}
+	if s.jwks == nil {
+		return nil, fmt.Errorf("http_api: no jwks config is named \"%s\"", cfg.Jwks())
+	}
+	return s, nil
+}
+
+// Handler returns the http.Handler serving the API.
+func (s *httpAPIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(httpAPIQueryPath, s.serveQuery)
+	return s.withCORS(mux)
+}
+
+// withCORS answers preflight requests and adds CORS headers to the responses of requests from allowed origins.
+func (s *httpAPIServer) withCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !originAllowed(s.cfg.AllowedOrigins(), origin) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept")
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, o := range allowed {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *httpAPIServer) serveQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHTTPError(w, http.StatusMethodNotAllowed, errors.New("queries must be sent with POST"))
+		return
+	}
+
+	user, err := s.authenticate(r)
+	if err != nil {
+		s.lgr.Warnf("authentication failed for %s: %v", r.RemoteAddr, err)
+		w.Header().Set("WWW-Authenticate", `Basic realm="dolt"`)
+		if s.jwks != nil {
+			w.Header().Add("WWW-Authenticate", `Bearer realm="dolt"`)
+		}
+		writeHTTPError(w, http.StatusUnauthorized, errors.New("authentication failed"))
+		return
+	}
+
+	var req httpQueryRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPQueryRequestBytes))
+	dec.UseNumber()
+	if err = dec.Decode(&req); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	dbName, err := req.databaseName()
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	bindings, err := httpQueryBindings(req.Params)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	ndjson, err := negotiateNDJSON(req.Format, r.Header.Get("Accept"))
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := r.Context()
+	if timeout := s.cfg.QueryTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	sqlCtx, err := s.sqlEngine.NewDefaultContext(ctx)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+	sqlCtx.Session.SetClient(sql.Client{User: user, Address: remoteHost(r), Capabilities: 0})
+
+	if dbName != "" {
+		if err = s.exec(sqlCtx, "USE "+sqlfmt.QuoteIdentifier(sqlCtx, dbName)); err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	sch, iter, _, err := s.sqlEngine.QueryWithBindings(sqlCtx, req.Query, nil, bindings, nil)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err = s.writeResults(sqlCtx, w, sch, iter, ndjson); err != nil {
+		s.lgr.Warnf("error writing query results to %s: %v", r.RemoteAddr, err)
+	}
+}
+
+// authenticate returns the user a request is authenticated as.
+func (s *httpAPIServer) authenticate(r *http.Request) (string, error) {
+	mysqlDb := s.sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return s.authenticateJWT(mysqlDb, remoteHost(r), token, time.Now())
+	}
+	return s.authenticateBasic(mysqlDb, r)
+}
+
+// authenticateBasic returns the user a request with basic auth credentials is authenticated as. The password is
+// checked against the account matching the request's remote host, which is the account whose privileges the statement
+// runs with. Like the MySQL listener, passwords are only accepted in cleartext over a connection without TLS if the
+// config allows it.
+func (s *httpAPIServer) authenticateBasic(mysqlDb *mysql_db.MySQLDb, r *http.Request) (string, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return "", errors.New("no credentials provided")
+	}
+	if r.TLS == nil && !s.cfg.AllowCleartextPasswords() {
+		return "", errors.New("passwords are not accepted over HTTP without TLS unless allow_cleartext_passwords is set")
+	}
+	addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if err = commands.ValidatePasswordFromAddr(mysqlDb, user, password, addr); err != nil {
+		return "", err
+	}
+	return user, nil
+}
+
+// authenticateJWT returns the user a bearer token is authenticated as. The token's subject names the user, and the
+// token is validated against that user's identity, just as it is when the user connects with the MySQL protocol.
+// Users who don't authenticate with the authentication_dolt_jwt plugin, or whose identity names a JWKS other than the
+// one configured for the API, can't use bearer tokens.
+func (s *httpAPIServer) authenticateJWT(mysqlDb *mysql_db.MySQLDb, host, token string, reqTime time.Time) (string, error) {
+	if s.jwks == nil {
+		return "", errors.New("bearer tokens are not accepted without a jwks config")
+	}
+
+	// the subject is read before the token is validated, as it names the user whose identity the token is validated
+	// against. ValidateUserJWT checks the signature, and the subject is checked again once it has.
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse JWT token: %w", err)
+	}
+	var unverified jwt.Claims
+	if err = parsed.UnsafeClaimsWithoutVerification(&unverified); err != nil {
+		return "", fmt.Errorf("unable to parse JWT token: %w", err)
+	}
+	if unverified.Subject == "" {
+		return "", errors.New("JWT token has no subject")
+	}
+
+	userEntry := lookupUser(mysqlDb, unverified.Subject, host)
+	if userEntry == nil {
+		return "", fmt.Errorf("no user named %s", unverified.Subject)
+	}
+	claims, err := engine.ValidateUserJWT([]servercfg.JwksConfig{*s.jwks}, userEntry, token, reqTime)
+	if err != nil {
+		return "", err
+	}
+	if claims.Subject != userEntry.User {
+		return "", errors.New("JWT token subject does not match user")
+	}
+	return userEntry.User, nil
+}
+
+// lookupUser returns the account of the user named |user| who may connect from |host|, or nil if there is none. The
+// host is matched against the host patterns of the user's accounts the same way it is for the MySQL listener.
+func lookupUser(mysqlDb *mysql_db.MySQLDb, user, host string) *mysql_db.User {
+	rd := mysqlDb.Reader()
+	defer rd.Close()
+	return mysqlDb.GetUser(rd, user, host, false)
+}
+
+// exec runs |query| and discards its results.
+func (s *httpAPIServer) exec(ctx *sql.Context, query string) error {
+	_, iter, _, err := s.sqlEngine.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	_, err = sql.RowIterToRows(ctx, iter)
+	return err
+}
+
+// writeResults writes the results of a statement. The first row is read before anything is written, so that most
+// errors are reported with an error status. Errors after that are reported at the end of the response body.
+func (s *httpAPIServer) writeResults(ctx *sql.Context, w http.ResponseWriter, sch sql.Schema, iter sql.RowIter, ndjson bool) error {
+	row, rowErr := iter.Next(ctx)
+	if rowErr != nil && rowErr != io.EOF {
+		iter.Close(ctx)
+		writeHTTPError(w, http.StatusBadRequest, rowErr)
+		return nil
+	}
+
+	if sch.Equals(types.OkResultSchema) {
+		// statements are committed when their results are closed, so they must be closed before reporting success
+		if err := iter.Close(ctx); err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return nil
+		}
+		var res httpOkResult
+		if row != nil {
+			if okResult, ok := row[0].(types.OkResult); ok {
+				res = httpOkResult{RowsAffected: okResult.RowsAffected, LastInsertID: okResult.InsertID}
+			}
+		}
+		return writeHTTPJSON(w, http.StatusOK, contentType(ndjson), res)
+	}
+
+	w.Header().Set("Content-Type", contentType(ndjson))
+	w.WriteHeader(http.StatusOK)
+	rw := newHTTPResultWriter(w, ndjson)
+
+	columns := make([]httpColumn, len(sch))
+	for i, col := range sch {
+		columns[i] = httpColumn{Name: col.Name, Type: col.Type.String()}
+	}
+	if err := rw.begin(columns); err != nil {
+		iter.Close(ctx)
+		return err
+	}
+
+	truncated := false
+	for maxRows := s.cfg.MaxRows(); rowErr == nil; row, rowErr = iter.Next(ctx) {
+		if maxRows > 0 && rw.rows >= maxRows {
+			truncated = true
+			break
+		}
+		if err := rw.row(ctx, sch, row); err != nil {
+			iter.Close(ctx)
+			return err
+		}
+	}
+	if rowErr == io.EOF {
+		rowErr = nil
+	}
+	if err := iter.Close(ctx); err != nil && rowErr == nil {
+		rowErr = err
+	}
+	return rw.end(truncated, rowErr)
+}
+
+// httpResultWriter writes a result set as either a single JSON object, or as newline-delimited JSON where the first
+// line holds the columns, each following array holds one row, and a final object is written if the rows were
+// truncated or an error occurred.
+type httpResultWriter struct {
+	wr     *bufio.Writer
+	ndjson bool
+	rows   int
+}
+
+func newHTTPResultWriter(w io.Writer, ndjson bool) *httpResultWriter {
+	return &httpResultWriter{wr: bufio.NewWriterSize(w, jsontable.WriteBufSize), ndjson: ndjson}
+}
+
+func (rw *httpResultWriter) begin(columns []httpColumn) error {
+	cols, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	if rw.ndjson {
+		_, err = fmt.Fprintf(rw.wr, "{\"columns\":%s}\n", cols)
+	} else {
+		_, err = fmt.Fprintf(rw.wr, "{\"columns\":%s,\"rows\":[", cols)
+	}
+	return err
+}
+
+func (rw *httpResultWriter) row(ctx *sql.Context, sch sql.Schema, row sql.Row) error {
+	vals := make([]interface{}, len(sch))
+	for i, col := range sch {
+		v, err := jsontable.SqlValueToJSON(ctx, col.Type, row[i])
+		if err != nil {
+			return err
+		}
+		vals[i] = v
+	}
+	data, err := types.MarshallJsonValue(vals)
+	if err != nil {
+		return fmt.Errorf("error marshalling row to json: %w", err)
+	}
+
+	switch {
+	case rw.ndjson:
+		data = append(data, '\n')
+	case rw.rows > 0:
+		if err = rw.wr.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	if _, err = rw.wr.Write(data); err != nil {
+		return err
+	}
+	rw.rows++
+	return nil
+}
+
+func (rw *httpResultWriter) end(truncated bool, rowErr error) error {
+	var err error
+	if rw.ndjson {
+		switch {
+		case rowErr != nil:
+			err = writeJSONLine(rw.wr, httpError{Error: rowErr.Error()})
+		case truncated:
+			err = writeJSONLine(rw.wr, map[string]bool{"truncated": true})
+		}
+	} else {
+		_, err = fmt.Fprintf(rw.wr, "],\"truncated\":%t", truncated)
+		if err == nil && rowErr != nil {
+			msg, _ := json.Marshal(rowErr.Error())
+			_, err = fmt.Fprintf(rw.wr, ",\"error\":%s", msg)
+		}
+		if err == nil {
+			_, err = rw.wr.WriteString("}\n")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return rw.wr.Flush()
+}
+
+func (req httpQueryRequest) databaseName() (string, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return "", errors.New("query is required")
+	}
+	if req.Branch != "" && req.Commit != "" {
+		return "", errors.New("only one of branch or commit may be given")
+	}
+	if (req.Branch != "" || req.Commit != "") && req.Database == "" {
+		return "", errors.New("database is required when a branch or commit is given")
+	}
+	switch {
+	case req.Branch != "":
+		return req.Database + doltdb.DbRevisionDelimiter + req.Branch, nil
+	case req.Commit != "":
+		return req.Database + doltdb.DbRevisionDelimiter + req.Commit, nil
+	default:
+		return req.Database, nil
+	}
+}
+
+// httpQueryBindings returns the bindings for the ? placeholders of a query from the JSON values |params|.
+func httpQueryBindings(params []interface{}) (map[string]sqlparser.Expr, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	bindings := make(map[string]sqlparser.Expr, len(params))
+	for i, p := range params {
+		var expr sqlparser.Expr
+		switch v := p.(type) {
+		case nil:
+			expr = &sqlparser.NullVal{}
+		case bool:
+			expr = sqlparser.BoolVal(v)
+		case string:
+			expr = sqlparser.NewStrVal([]byte(v))
+		case json.Number:
+			if strings.ContainsAny(v.String(), ".eE") {
+				expr = sqlparser.NewFloatVal([]byte(v.String()))
+			} else {
+				expr = sqlparser.NewIntVal([]byte(v.String()))
+			}
+		default:
+			return nil, fmt.Errorf("params[%d]: must be a string, number, boolean or null", i)
+		}
+		bindings[fmt.Sprintf("v%d", i+1)] = expr
+	}
+	return bindings, nil
+}
+
+// negotiateNDJSON returns whether results should be written as newline-delimited JSON, given the format of a request
+// and its Accept header.
+func negotiateNDJSON(format, accept string) (bool, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return false, nil
+	case "ndjson":
+		return true, nil
+	case "":
+		return strings.Contains(accept, contentTypeNDJSON), nil
+	default:
+		return false, fmt.Errorf("format must be json or ndjson, not %s", format)
+	}
+}
+
+func contentType(ndjson bool) string {
+	if ndjson {
+		return contentTypeNDJSON
+	}
+	return contentTypeJSON
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, err error) {
+	_ = writeHTTPJSON(w, status, contentTypeJSON, httpError{Error: err.Error()})
+}
+
+func writeHTTPJSON(w http.ResponseWriter, status int, contentType string, v interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	return writeJSONLine(w, v)
+}
+
+func writeJSONLine(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
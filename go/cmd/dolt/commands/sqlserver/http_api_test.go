@@ -0,0 +1,277 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestHTTPQueryRequestDatabaseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      httpQueryRequest
+		expected string
+		err      bool
+	}{
+		{name: "no database", req: httpQueryRequest{Query: "select 1"}, expected: ""},
+		{name: "database", req: httpQueryRequest{Query: "select 1", Database: "db"}, expected: "db"},
+		{name: "branch", req: httpQueryRequest{Query: "select 1", Database: "db", Branch: "feature"}, expected: "db/feature"},
+		{name: "commit", req: httpQueryRequest{Query: "select 1", Database: "db", Commit: "abc"}, expected: "db/abc"},
+		{name: "missing query", req: httpQueryRequest{Database: "db"}, err: true},
+		{name: "branch and commit", req: httpQueryRequest{Query: "select 1", Database: "db", Branch: "b", Commit: "c"}, err: true},
+		{name: "branch without database", req: httpQueryRequest{Query: "select 1", Branch: "b"}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, err := test.req.databaseName()
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
+
+func TestHTTPQueryBindings(t *testing.T) {
+	var req httpQueryRequest
+	dec := json.NewDecoder(bytes.NewBufferString(`{"query": "select ?, ?, ?, ?, ?", "params": [1, 2.5, "a", true, null]}`))
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&req))
+
+	bindings, err := httpQueryBindings(req.Params)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]sqlparser.Expr{
+		"v1": sqlparser.NewIntVal([]byte("1")),
+		"v2": sqlparser.NewFloatVal([]byte("2.5")),
+		"v3": sqlparser.NewStrVal([]byte("a")),
+		"v4": sqlparser.BoolVal(true),
+		"v5": &sqlparser.NullVal{},
+	}, bindings)
+
+	_, err = httpQueryBindings([]interface{}{map[string]interface{}{}})
+	assert.Error(t, err)
+}
+
+func TestNegotiateNDJSON(t *testing.T) {
+	ndjson, err := negotiateNDJSON("", "application/json")
+	require.NoError(t, err)
+	assert.False(t, ndjson)
+
+	ndjson, err = negotiateNDJSON("", "application/x-ndjson")
+	require.NoError(t, err)
+	assert.True(t, ndjson)
+
+	ndjson, err = negotiateNDJSON("json", "application/x-ndjson")
+	require.NoError(t, err)
+	assert.False(t, ndjson)
+
+	ndjson, err = negotiateNDJSON("NDJSON", "")
+	require.NoError(t, err)
+	assert.True(t, ndjson)
+
+	_, err = negotiateNDJSON("csv", "")
+	assert.Error(t, err)
+}
+
+func TestHTTPResultWriter(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	sch := sql.Schema{
+		{Name: "id", Type: types.Int64},
+		{Name: "name", Type: types.Text},
+	}
+	columns := []httpColumn{{Name: "id", Type: "bigint"}, {Name: "name", Type: "text"}}
+
+	write := func(ndjson, truncated bool, rowErr error, rows ...sql.Row) string {
+		var buf bytes.Buffer
+		rw := newHTTPResultWriter(&buf, ndjson)
+		require.NoError(t, rw.begin(columns))
+		for _, row := range rows {
+			require.NoError(t, rw.row(ctx, sch, row))
+		}
+		require.NoError(t, rw.end(truncated, rowErr))
+		return buf.String()
+	}
+
+	assert.Equal(t,
+		`{"columns":[{"name":"id","type":"bigint"},{"name":"name","type":"text"}],"rows":[[1,"a"],[2,null]],"truncated":false}`+"\n",
+		write(false, false, nil, sql.Row{int64(1), "a"}, sql.Row{int64(2), nil}))
+	assert.Equal(t,
+		`{"columns":[{"name":"id","type":"bigint"},{"name":"name","type":"text"}],"rows":[],"truncated":true,"error":"boom"}`+"\n",
+		write(false, true, errors.New("boom")))
+	assert.Equal(t,
+		`{"columns":[{"name":"id","type":"bigint"},{"name":"name","type":"text"}]}`+"\n"+
+			`[1,"a"]`+"\n"+
+			`{"truncated":true}`+"\n",
+		write(true, true, nil, sql.Row{int64(1), "a"}))
+	assert.Equal(t,
+		`{"columns":[{"name":"id","type":"bigint"},{"name":"name","type":"text"}]}`+"\n"+
+			`{"error":"boom"}`+"\n",
+		write(true, false, errors.New("boom")))
+}
+
+func TestHTTPAPICORS(t *testing.T) {
+	cfg := &servercfg.HTTPAPIYAMLConfig{AllowedOrigins_: []string{"https://app.example.com"}}
+	s := &httpAPIServer{cfg: cfg}
+	h := s.withCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, httpAPIQueryPath, nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+
+	req = httptest.NewRequest(http.MethodPost, httpAPIQueryPath, nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	req = httptest.NewRequest(http.MethodPost, httpAPIQueryPath, nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestHTTPAPIAuthenticateJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test-key", Algorithm: "RS256", Use: "sig"},
+		}})
+	}))
+	defer jwksServer.Close()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "test-key"}}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	token := func(subject string) string {
+		tok, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   "dolthub.com",
+			Audience: jwt.Audience{"my_resource"},
+			Subject:  subject,
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		}).CompactSerialize()
+		require.NoError(t, err)
+		return tok
+	}
+
+	mysqlDb := mysql_db.CreateEmptyMySQLDb()
+	ed := mysqlDb.Editor()
+	mysqlDb.AddSuperUser(ed, "root", "%", "")
+	ed.PutUser(&mysql_db.User{
+		User:         "jwt_user",
+		Host:         "127.0.0.%",
+		PrivilegeSet: mysql_db.NewPrivilegeSet(),
+		Plugin:       engine.DoltJWTAuthPlugin,
+		Identity:     "jwks=test,sub=jwt_user,iss=dolthub.com,aud=my_resource",
+	})
+	ed.PutUser(&mysql_db.User{
+		User:         "other_jwks_user",
+		Host:         "%",
+		PrivilegeSet: mysql_db.NewPrivilegeSet(),
+		Plugin:       engine.DoltJWTAuthPlugin,
+		Identity:     "jwks=other,sub=other_jwks_user,iss=dolthub.com,aud=my_resource",
+	})
+	ed.Close()
+
+	s := &httpAPIServer{jwks: &servercfg.JwksConfig{Name: "test", LocationUrl: jwksServer.URL}}
+
+	user, err := s.authenticateJWT(mysqlDb, "127.0.0.1", token("jwt_user"), now)
+	require.NoError(t, err)
+	assert.Equal(t, "jwt_user", user)
+
+	// a valid token whose subject is a user with a password is refused
+	_, err = s.authenticateJWT(mysqlDb, "127.0.0.1", token("root"), now)
+	assert.Error(t, err)
+
+	_, err = s.authenticateJWT(mysqlDb, "127.0.0.1", token("other_jwks_user"), now)
+	assert.Error(t, err)
+	_, err = s.authenticateJWT(mysqlDb, "127.0.0.1", token("no_such_user"), now)
+	assert.Error(t, err)
+	_, err = s.authenticateJWT(mysqlDb, "127.0.0.1", token("jwt_user"), now.Add(2*time.Hour))
+	assert.Error(t, err)
+	// jwt_user may only connect from hosts matching 127.0.0.%
+	_, err = s.authenticateJWT(mysqlDb, "10.0.0.1", token("jwt_user"), now)
+	assert.Error(t, err)
+}
+
+func TestHTTPAPIAuthenticateBasic(t *testing.T) {
+	mysqlDb := mysql_db.CreateEmptyMySQLDb()
+	mysqlDb.SetEnabled(true)
+	ed := mysqlDb.Editor()
+	mysqlDb.AddSuperUser(ed, "root", "localhost", "")
+	ed.PutUser(&mysql_db.User{
+		User:         "api",
+		Host:         "10.0.0.%",
+		PrivilegeSet: mysql_db.NewPrivilegeSet(),
+		Plugin:       "mysql_native_password",
+	})
+	ed.Close()
+
+	request := func(user, remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, httpAPIQueryPath, nil)
+		req.SetBasicAuth(user, "")
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	s := &httpAPIServer{cfg: &servercfg.HTTPAPIYAMLConfig{}}
+	_, err := s.authenticateBasic(mysqlDb, request("api", "10.0.0.7:5000"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allow_cleartext_passwords")
+
+	allowCleartext := true
+	s = &httpAPIServer{cfg: &servercfg.HTTPAPIYAMLConfig{AllowCleartextPasswords_: &allowCleartext}}
+	user, err := s.authenticateBasic(mysqlDb, request("api", "10.0.0.7:5000"))
+	require.NoError(t, err)
+	assert.Equal(t, "api", user)
+
+	// accounts are matched against the remote host of the request, not localhost
+	_, err = s.authenticateBasic(mysqlDb, request("api", "192.168.0.7:5000"))
+	assert.Error(t, err)
+	_, err = s.authenticateBasic(mysqlDb, request("root", "10.0.0.7:5000"))
+	assert.Error(t, err)
+}
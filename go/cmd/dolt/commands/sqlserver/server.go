@@ -54,6 +54,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhook"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
@@ -319,6 +320,51 @@ func ConfigureServices(
 	}
 	controller.Register(InitAutoGCController)
 
+	InitWebhookController := &svcs.AnonService{
+		InitF: func(context.Context) (err error) {
+			webhooks := cfg.ServerConfig.Webhooks()
+			if len(webhooks) == 0 {
+				return nil
+			}
+			endpoints := make([]webhook.Endpoint, len(webhooks))
+			for i, w := range webhooks {
+				endpoints[i] = webhook.Endpoint{
+					URL:         w.URL(),
+					Databases:   w.Databases(),
+					Branches:    w.Branches(),
+					Headers:     w.Headers(),
+					Secret:      w.Secret(),
+					Timeout:     w.Timeout(),
+					MaxAttempts: w.MaxAttempts(),
+				}
+			}
+			outboxDir := filepath.Join(cfg.ServerConfig.CfgDir(), servercfg.DefaultWebhookOutboxDir)
+			config.WebhookController, err = sqle.NewWebhookController(fs, outboxDir, endpoints, lgr)
+			return err
+		},
+	}
+	controller.Register(InitWebhookController)
+
+	InitCIController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if cfg.ServerConfig.CIWorkflows() {
+				config.CIController = sqle.NewCIController(lgr)
+			}
+			return nil
+		},
+	}
+	controller.Register(InitCIController)
+
+	InitJournalShippingController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if shipping := cfg.ServerConfig.JournalShipping(); shipping != nil {
+				config.JournalShippingController = sqle.NewJournalShippingController(shipping.URL(), shipping.Interval(), lgr)
+			}
+			return nil
+		},
+	}
+	controller.Register(InitJournalShippingController)
+
 	// mySQLServer is going to be populated down below once further services
 	// are initialized. However, we want to block Controller shutdown on all
 	// connections being fully drained from the Server. Stopping the
@@ -717,6 +763,68 @@ func ConfigureServices(
 	}
 	controller.Register(RunMetricsServer)
 
+	type HTTPAPIService struct {
+		enabled bool
+		lis     net.Listener
+		srv     *http.Server
+	}
+
+	var httpAPISrv HTTPAPIService
+	RunHTTPAPIServer := &svcs.AnonService{
+		InitF: func(context.Context) (err error) {
+			apiConfig := cfg.ServerConfig.HTTPAPI()
+			if apiConfig == nil {
+				return nil
+			}
+			httpAPISrv.enabled = true
+
+			api, err := newHTTPAPIServer(apiConfig, cfg.ServerConfig.JwksConfig(), sqlEngine, lgr)
+			if err != nil {
+				return err
+			}
+			addr := net.JoinHostPort(apiConfig.Host(), strconv.Itoa(apiConfig.Port()))
+			httpAPISrv.lis, err = net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			httpAPISrv.srv = &http.Server{
+				Addr:    addr,
+				Handler: api.Handler(),
+			}
+			if apiConfig.TLSCert() != "" {
+				c, err := tls.LoadX509KeyPair(apiConfig.TLSCert(), apiConfig.TLSKey())
+				if err != nil {
+					httpAPISrv.lis.Close()
+					return fmt.Errorf("http_api: unable to load tls_cert and tls_key: %w", err)
+				}
+				httpAPISrv.srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{c}}
+			}
+			logrus.Infof("Starting HTTP API server. tls = %t, jwks = %q, addr = %s", apiConfig.TLSCert() != "", apiConfig.Jwks(), addr)
+			return nil
+		},
+		RunF: func(context.Context) {
+			if httpAPISrv.enabled {
+				if httpAPISrv.srv.TLSConfig != nil {
+					_ = httpAPISrv.srv.ServeTLS(httpAPISrv.lis, "", "")
+				} else {
+					_ = httpAPISrv.srv.Serve(httpAPISrv.lis)
+				}
+			}
+		},
+		StopF: func(rs svcs.RunState) error {
+			if !httpAPISrv.enabled {
+				return nil
+			}
+			if rs == svcs.RunInvoked {
+				httpAPISrv.srv.Close()
+			} else if httpAPISrv.srv != nil {
+				httpAPISrv.lis.Close()
+			}
+			return nil
+		},
+	}
+	controller.Register(RunHTTPAPIServer)
+
 	type RemoteSrvService struct {
 		enabled bool
 		lis     remotesrv.Listeners
@@ -708,7 +708,22 @@ branch_control_file: dir1/dir2/abc.db
     # - https://standby_replica_two.svc.cluster.local
     # server_name_dns:
     # - standby_replica_one.svc.cluster.local
-    # - standby_replica_two.svc.cluster.local`
+    # - standby_replica_two.svc.cluster.local
+
+# webhooks:
+# - url: https://hooks.example.com/dolt
+  # branches:
+  # - main
+
+# http_api:
+  # host: localhost
+  # port: 8080
+  # allowed_origins:
+  # - https://app.example.com
+
+# journal_shipping:
+  # url: file:///var/backups/dolt
+  # interval_millis: 1000`
 
 	ap := SqlServerCmd{}.ArgParser()
 
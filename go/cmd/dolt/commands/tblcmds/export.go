@@ -44,10 +44,12 @@ var exportDocs = cli.CommandDocumentationContent{
 
 The output format is inferred from the file extension, or can be set explicitly with {{.EmphasisLeft}}--file-type{{.EmphasisRight}}.
 
-Supported file types: {{.EmphasisLeft}}csv{{.EmphasisRight}}, {{.EmphasisLeft}}psv{{.EmphasisRight}}, {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, {{.EmphasisLeft}}sql{{.EmphasisRight}}, {{.EmphasisLeft}}parquet{{.EmphasisRight}}.
+Supported file types: {{.EmphasisLeft}}csv{{.EmphasisRight}}, {{.EmphasisLeft}}psv{{.EmphasisRight}}, {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, {{.EmphasisLeft}}sql{{.EmphasisRight}}, {{.EmphasisLeft}}parquet{{.EmphasisRight}}, {{.EmphasisLeft}}arrow{{.EmphasisRight}}, {{.EmphasisLeft}}arrows{{.EmphasisRight}}, {{.EmphasisLeft}}avro{{.EmphasisRight}}.
 
 {{.EmphasisLeft}}.json{{.EmphasisRight}} exports a single JSON object containing a {{.EmphasisLeft}}rows{{.EmphasisRight}} array; {{.EmphasisLeft}}.jsonl{{.EmphasisRight}} exports one JSON object per line.
 
+{{.EmphasisLeft}}.arrow{{.EmphasisRight}} exports the Arrow IPC file format and {{.EmphasisLeft}}.arrows{{.EmphasisRight}} the Arrow IPC stream format. {{.EmphasisLeft}}.avro{{.EmphasisRight}} exports an Avro object container file. These formats record the SQL type of each column and the table's primary key, so that the table can be recreated exactly with {{.EmphasisLeft}}dolt table import -c{{.EmphasisRight}}. The arrows and avro formats can also be written to stdout.
+
 See the help for {{.EmphasisLeft}}dolt table import{{.EmphasisRight}} as the options are the same.
 `,
 	Synopsis: []string{
@@ -118,7 +120,7 @@ func getExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
 		if val.Format == mvdata.InvalidDataFormat {
 			val = mvdata.StreamDataLocation{Format: mvdata.CsvFile, Reader: os.Stdin, Writer: iohelp.NopWrCloser(cli.CliOut)}
 			destLoc = val
-		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile {
+		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile && val.Format != mvdata.ArrowStreamFile && val.Format != mvdata.AvroFile {
 			cli.PrintErrln(color.RedString("Cannot export this format to stdout"))
 			return nil
 		}
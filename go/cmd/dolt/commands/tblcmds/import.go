@@ -103,7 +103,7 @@ var importDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Imports data into a dolt table`,
 	LongDesc: `If {{.EmphasisLeft}}--create-table | -c{{.EmphasisRight}} is given the operation will create {{.LessThan}}table{{.GreaterThan}} and import the contents of file into it.  If a table already exists at this location then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag is provided. The force flag forces the existing table to be overwritten.
 
-The schema for the new table can be specified explicitly by providing a SQL schema definition file, or may be inferred from the imported file (depending on file type). All schemas, inferred or explicitly defined must define a primary key. If the file format being imported does not support defining a primary key, then the {{.EmphasisLeft}}--pk{{.EmphasisRight}} parameter must supply the name of the field that should be used as the primary key. If no primary key is explicitly defined, the first column in the import file will be used as the primary key. For {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, and {{.EmphasisLeft}}parquet{{.EmphasisRight}} create operations, a schema file must be provided with {{.EmphasisLeft}}--schema{{.EmphasisRight}}.
+The schema for the new table can be specified explicitly by providing a SQL schema definition file, or may be inferred from the imported file (depending on file type). All schemas, inferred or explicitly defined must define a primary key. If the file format being imported does not support defining a primary key, then the {{.EmphasisLeft}}--pk{{.EmphasisRight}} parameter must supply the name of the field that should be used as the primary key. If no primary key is explicitly defined, the first column in the import file will be used as the primary key. For {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, and {{.EmphasisLeft}}parquet{{.EmphasisRight}} create operations, a schema file must be provided with {{.EmphasisLeft}}--schema{{.EmphasisRight}}. For {{.EmphasisLeft}}arrow{{.EmphasisRight}}, {{.EmphasisLeft}}arrows{{.EmphasisRight}} and {{.EmphasisLeft}}avro{{.EmphasisRight}} create operations, the column types are read from the file, and files exported from Dolt also restore the primary key.
 
 If {{.EmphasisLeft}}--update-table | -u{{.EmphasisRight}} is given the operation will update {{.LessThan}}table{{.GreaterThan}} with the contents of file. The table's existing schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
@@ -111,6 +111,8 @@ If {{.EmphasisLeft}}--append-table | -a{{.EmphasisRight}} is given the operation
 
 If {{.EmphasisLeft}}--replace-table | -r{{.EmphasisRight}} is given the operation will replace {{.LessThan}}table{{.GreaterThan}} with the contents of the file. The table's existing schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
+Create and replace operations build the table's indexes directly from the imported rows rather than inserting them one at a time. Rows are sorted by primary key using temporary files first, unless {{.EmphasisLeft}}--continue{{.EmphasisRight}} is given, in which case rows that are not already sorted by primary key are imported one at a time. The same bulk load is available to {{.EmphasisLeft}}LOAD DATA{{.EmphasisRight}} and {{.EmphasisLeft}}INSERT{{.EmphasisRight}} statements into empty tables through the {{.EmphasisLeft}}dolt_bulk_load{{.EmphasisRight}} system variable.
+
 If the schema for the existing table does not match the schema for the new file, the import will be aborted by default. To overwrite both the table and the schema, use {{.EmphasisLeft}}-c -f{{.EmphasisRight}}.
 
 A mapping file can be used to map fields between the file being imported and the table being written to. This can be used when creating a new table, or updating or replacing an existing table.
@@ -123,7 +125,7 @@ During import, if there is an error importing any row, the import will be aborte
 		`
 ` + jsonlInputFileHelp +
 		`
- In create, update, and replace scenarios the file's extension is used to infer the type of the file. If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet). For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
+ In create, update, and replace scenarios the file's extension is used to infer the type of the file. If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet, arrow, arrows, avro). For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
 
 	Synopsis: []string{
 		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--all-text] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] [--no-header] [--columns {{.LessThan}}col1,col2,...{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
@@ -189,6 +191,20 @@ func (m importOptions) srcIsJson() bool {
 	return isJson
 }
 
+// srcIsSelfDescribing returns whether the source file carries the types of its columns, so that the schema of a
+// created table can be read from the file rather than inferred from its values.
+func (m importOptions) srcIsSelfDescribing() bool {
+	fileLoc, isFile := m.src.(mvdata.FileDataLocation)
+	if !isFile {
+		return false
+	}
+	switch fileLoc.Format {
+	case mvdata.ArrowFile, mvdata.ArrowStreamFile, mvdata.AvroFile:
+		return true
+	}
+	return false
+}
+
 func (m importOptions) srcIsStream() bool {
 	_, isStream := m.src.(mvdata.StreamDataLocation)
 	return isStream
@@ -844,6 +860,14 @@ func getImportSchema(ctx *sql.Context, root doltdb.RootValue, dEnv *env.DoltEnv,
 			return rd.GetSchema(), nil
 		}
 
+		if impOpts.srcIsSelfDescribing() {
+			outSch, err := generateTypedSchema(ctx, root, rd, impOpts)
+			if err != nil {
+				return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
+			}
+			return outSch, nil
+		}
+
 		outSch, err := mvdata.InferSchema(ctx, root, rd, impOpts.destTableName, impOpts.primaryKeys, impOpts)
 		if err != nil {
 			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
@@ -891,6 +915,66 @@ func generateAllTextSchema(rd table.ReadCloser, impOpts *importOptions) (schema.
 	return schema.SchemaFromCols(schema.NewColCollection(cols...))
 }
 
+// generateTypedSchema returns a schema using the column types read from a self describing file. The primary key is
+// the one given with --pk if any, otherwise the one recorded in the file, if it was exported from Dolt.
+func generateTypedSchema(ctx context.Context, root doltdb.RootValue, rd table.ReadCloser, impOpts *importOptions) (schema.Schema, error) {
+	rdSch := rd.GetSchema()
+	pks := impOpts.primaryKeys
+	if len(pks) == 0 {
+		pks = rdSch.GetPKCols().GetColumnNames()
+	}
+	mapper := impOpts.ColNameMapper()
+
+	var cols []schema.Column
+	_ = rdSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.IsPartOfPK = slices.Contains(pks, col.Name) || slices.Contains(pks, mapper.Map(col.Name))
+		col.Name = mapper.Map(col.Name)
+		if col.IsPartOfPK && col.IsNullable() {
+			col.Constraints = append(col.Constraints, schema.NotNullConstraint{})
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	colColl := schema.NewColCollection(cols...)
+	for _, pk := range pks {
+		col, ok := colColl.GetByName(mapper.Map(pk))
+		if !ok {
+			col, ok = colColl.GetByName(pk)
+		}
+		if !ok || !col.IsPartOfPK {
+			return nil, fmt.Errorf("column '%s' not found", pk)
+		}
+	}
+
+	// NOTE: This code is only used in the import codepath for Dolt, so we don't use a schema to qualify the table name
+	colColl, err := doltdb.GenerateTagsForNewColColl(ctx, root, impOpts.destTableName, colColl)
+	if err != nil {
+		return nil, err
+	}
+	if err = schema.ValidateForInsert(colColl); err != nil {
+		return nil, err
+	}
+
+	sch, err := schema.SchemaFromCols(colColl)
+	if err != nil {
+		return nil, err
+	}
+	if len(pks) > 1 {
+		// the key columns are in the order given by --pk or recorded in the file, not the order of the columns
+		ords := make([]int, len(pks))
+		for i, pk := range pks {
+			if ords[i] = colColl.IndexOf(mapper.Map(pk)); ords[i] < 0 {
+				ords[i] = colColl.IndexOf(pk)
+			}
+		}
+		if err = sch.SetPkOrdinals(ords); err != nil {
+			return nil, err
+		}
+	}
+	return sch, nil
+}
+
 func newDataMoverErrToVerr(mvOpts *importOptions, err *mvdata.DataMoverCreationError) errhand.VerboseError {
 	switch err.ErrType {
 	case mvdata.CreateReaderErr:
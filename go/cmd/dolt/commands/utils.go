@@ -61,6 +61,7 @@ type CommitInfo struct {
 	localBranchNames  []string
 	remoteBranchNames []string
 	tagNames          []string
+	note              string
 }
 
 var fwtStageName = "fwt"
@@ -470,12 +471,18 @@ func getTimestampColAsUint64(col interface{}) (uint64, error) {
 	}
 }
 
-// passwordValidate validates the password for the given user. This is a helper function around ValidateHash. Returns
-// nil if the user is authenticated, an error otherwise.
+// passwordValidate validates the password for the given user, connecting from localhost. Returns nil if the user is
+// authenticated, an error otherwise.
 func passwordValidate(rawDb *mysql_db.MySQLDb, salt []byte, user string, authResponse []byte) error {
 	// The port is meaningless here. It's going to be stripped in the ValidateHash function
 	addr, _ := net.ResolveTCPAddr("tcp", "localhost:3306")
+	return passwordValidateFromAddr(rawDb, salt, user, authResponse, addr)
+}
 
+// passwordValidateFromAddr validates the password for the given user, connecting from |addr|. This is a helper
+// function around ValidateHash, which matches the host of |addr| against the hosts of the user's accounts. Returns nil
+// if the user is authenticated, an error otherwise.
+func passwordValidateFromAddr(rawDb *mysql_db.MySQLDb, salt []byte, user string, authResponse []byte, addr net.Addr) error {
 	authenticated, err := rawDb.ValidateHash(salt, user, authResponse, addr)
 	if err != nil {
 		return err
@@ -531,6 +538,20 @@ func ValidatePasswordWithAuthResponse(rawDb *mysql_db.MySQLDb, user, password st
 	return passwordValidate(rawDb, salt, user, authResponse)
 }
 
+// ValidatePasswordFromAddr validates the password of |user| connecting from |addr|. Unlike
+// ValidatePasswordWithAuthResponse, which always authenticates the user as connecting from localhost, the account is
+// chosen by matching the host of |addr| against the host patterns of the user's accounts, as it is for connections to
+// the MySQL listener.
+func ValidatePasswordFromAddr(rawDb *mysql_db.MySQLDb, user, password string, addr net.Addr) error {
+	salt, err := mysql.NewSalt()
+	if err != nil {
+		return err
+	}
+
+	authResponse := buildAuthResponse(salt, password)
+	return passwordValidateFromAddr(rawDb, salt, user, authResponse, addr)
+}
+
 // GetDoltStatus retrieves the status of the current working set of changes in the working set, and returns two
 // lists of modified tables: staged and unstaged. If both lists are empty, there are no changes in the working set.
 // The list of unstaged tables does not include tables that are ignored, as configured by the dolt_ignore table.
@@ -625,6 +646,11 @@ func PrintCommitInfo(pager *outputpager.Pager, minParents int, showParents, show
 	formattedDesc := "\n\n\t" + strings.Replace(comm.commitMeta.Description, "\n", "\n\t", -1) + "\n\n"
 	pager.Writer.Write([]byte(fmt.Sprintf("%s", formattedDesc)))
 
+	if comm.note != "" {
+		formattedNote := "Notes:\n\t" + strings.Replace(comm.note, "\n", "\n\t", -1) + "\n\n"
+		pager.Writer.Write([]byte(formattedNote))
+	}
+
 }
 
 // printRefs prints the refs associated with the commit in the formatting used by log and show.
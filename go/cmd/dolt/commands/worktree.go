@@ -0,0 +1,148 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var worktreeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Manage multiple working directories of a repository.",
+	LongDesc: `Worktrees are additional working directories of a repository, each with its own checked out branch, which share the repository's storage. Several branches can be worked on at once without cloning the database again.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Creates a worktree at {{.LessThan}}path{{.GreaterThan}}, which must not exist or be empty, with {{.LessThan}}branch{{.GreaterThan}} checked out. The remotes and branch configuration of the new worktree are copied from the current one. A branch can only be checked out in one worktree at a time.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+Lists the working directories of the repository and the branch checked out in each, the main working directory first.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}
+Deletes the worktree at {{.LessThan}}path{{.GreaterThan}}. Its branch is kept. Worktrees whose branch has uncommitted changes, or whose directory holds files other than its {{.EmphasisLeft}}.dolt{{.EmphasisRight}} directory, are only removed with {{.EmphasisLeft}}--force{{.EmphasisRight}}; the changes stay in the branch's working set.
+
+Dolt commands can be run in several worktrees at once. Only one dolt process at a time holds the repository's storage, so they take turns: each waits up to a minute for the others to finish before opening the repository read-only. A long-running process, such as {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}}, holds the storage for as long as it runs; dolt commands run in any worktree of the repository while a server is running in one of them connect to it, and work on the branch checked out in their own worktree.`,
+	Synopsis: []string{
+		"add {{.LessThan}}path{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}",
+		"list",
+		"remove [-f] {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+type WorktreeCmd struct{}
+
+var _ cli.Command = WorktreeCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd WorktreeCmd) Name() string {
+	return "worktree"
+}
+
+// Description returns a description of the command
+func (cmd WorktreeCmd) Description() string {
+	return worktreeDocs.ShortDesc
+}
+
+func (cmd WorktreeCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(worktreeDocs, ap)
+}
+
+func (cmd WorktreeCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 3)
+	ap.SupportsFlag(cli.ForceFlag, "f", "Remove the worktree even if its branch has uncommitted changes or its directory holds other files.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd WorktreeCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd WorktreeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, worktreeDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	subcommand := strings.ToLower(apr.Arg(0))
+	if apr.Contains(cli.ForceFlag) && subcommand != "remove" {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s can only be used with remove", cli.ForceFlag).SetPrintUsage().Build(), usage)
+	}
+
+	var verr errhand.VerboseError
+	switch subcommand {
+	case "add":
+		if apr.NArg() != 3 {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: add takes a path and a branch").SetPrintUsage().Build(), usage)
+		}
+		if err := dEnv.AddWorktree(ctx, apr.Arg(1), apr.Arg(2)); err != nil {
+			verr = errhand.VerboseErrorFromError(err)
+		}
+	case "list":
+		if apr.NArg() != 1 {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: list takes no arguments").SetPrintUsage().Build(), usage)
+		}
+		verr = listWorktrees(dEnv)
+	case "remove":
+		if apr.NArg() != 2 {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: remove takes the path of a worktree").SetPrintUsage().Build(), usage)
+		}
+		verr = removeWorktree(ctx, dEnv, apr.Arg(1), apr.Contains(cli.ForceFlag))
+	default:
+		verr = errhand.BuildDError("error: unknown worktree subcommand '%s'", apr.Arg(0)).SetPrintUsage().Build()
+	}
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func listWorktrees(dEnv *env.DoltEnv) errhand.VerboseError {
+	worktrees, err := dEnv.Worktrees()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	width := 0
+	for _, wt := range worktrees {
+		width = max(width, len(wt.Path))
+	}
+	for _, wt := range worktrees {
+		if wt.Missing {
+			cli.Printf("%-*s  (missing)\n", width, wt.Path)
+		} else {
+			cli.Printf("%-*s  [%s]\n", width, wt.Path, wt.Branch)
+		}
+	}
+	return nil
+}
+
+func removeWorktree(ctx context.Context, dEnv *env.DoltEnv, path string, force bool) errhand.VerboseError {
+	err := dEnv.RemoveWorktree(ctx, path, force)
+	if errors.Is(err, env.ErrWorktreeHasUncommittedChanges) || errors.Is(err, env.ErrWorktreeNotEmpty) {
+		return errhand.BuildDError("error: %s; use --force to remove the worktree anyway", err.Error()).Build()
+	} else if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	return nil
+}
@@ -71,6 +71,7 @@ var dumpZshCommand = &commands.GenZshCompCmd{}
 
 var commandsWithoutCliCtx = []cli.Command{
 	commands.CloneCmd{},
+	commands.RestoreCmd{},
 	commands.LoginCmd{},
 	credcmds.Commands,
 	cvcmds.Commands,
@@ -95,6 +96,7 @@ var commandsWithoutCliCtx = []cli.Command{
 var commandsWithoutGlobalArgSupport = []cli.Command{
 	commands.InitCmd{},
 	commands.CloneCmd{},
+	commands.RestoreCmd{},
 	docscmds.Commands,
 	commands.ReadTablesCmd{},
 	commands.LoginCmd{},
@@ -785,6 +787,22 @@ If you're interested in running this command against a remote host, hit us up on
 		if err != nil {
 			return nil, err
 		}
+		if localCreds == nil && targetEnv.HasWorktrees() {
+			// A server running in another worktree of the repository holds its storage. Connect to it, and work
+			// on the branch checked out in this worktree.
+			var serverDb string
+			localCreds, serverDb, err = sqlserver.FindWorktreeLocalCreds(targetEnv)
+			if err != nil {
+				return nil, err
+			}
+			if localCreds != nil && !hasUseDb && targetEnv.RepoState != nil {
+				branch := targetEnv.RepoState.CWBHeadRef().GetPath()
+				if hasBranch {
+					branch = useBranch
+				}
+				useDb = serverDb + "/" + branch
+			}
+		}
 		if localCreds != nil {
 			if verbose {
 				cli.Println("verbose: starting remote mode")
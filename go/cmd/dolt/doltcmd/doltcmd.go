@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/credcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/cvcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/docscmds"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/histcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/indexcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/schcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver"
@@ -54,17 +55,23 @@ var doltSubCommands = []cli.Command{
 	commands.RevertCmd{},
 	commands.CloneCmd{},
 	commands.FetchCmd{},
+	commands.PrefetchCmd{},
 	commands.PullCmd{},
 	commands.PushCmd{},
 	commands.ConfigCmd{},
 	commands.RemoteCmd{},
 	commands.BackupCmd{},
+	commands.RestoreCmd{},
+	commands.BundleCmd{},
 	commands.LoginCmd{},
 	credcmds.Commands,
 	commands.LsCmd{},
 	schcmds.Commands,
 	tblcmds.Commands,
 	commands.TagCmd{},
+	commands.NotesCmd{},
+	commands.WorktreeCmd{},
+	commands.GrepCmd{},
 	commands.BlameCmd{},
 	cvcmds.Commands,
 	commands.SendMetricsCmd{},
@@ -73,7 +80,9 @@ var doltSubCommands = []cli.Command{
 	commands.GarbageCollectionCmd{},
 	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
+	histcmds.Commands,
 	commands.MergeBaseCmd{},
+	commands.BisectCmd{},
 	commands.RootsCmd{},
 	commands.VersionCmd{VersionStr: doltversion.Version},
 	commands.DumpCmd{},
@@ -86,6 +95,7 @@ var doltSubCommands = []cli.Command{
 	commands.ProfileCmd{},
 	commands.QueryDiff{},
 	commands.ReflogCmd{},
+	commands.ChangesCmd{},
 	commands.RebaseCmd{},
 	ci.Commands,
 	commands.DebugCmd{},
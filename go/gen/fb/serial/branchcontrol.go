@@ -84,7 +84,23 @@ func (rcv *BranchControl) TryNamespaceTbl(obj *BranchControlNamespace) (*BranchC
 	return nil, nil
 }
 
-const BranchControlNumFields = 2
+func (rcv *BranchControl) TryProtectionTbl(obj *BranchControlProtection) (*BranchControlProtection, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(BranchControlProtection)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlProtectionNumFields < obj.Table().NumFields() {
+			return nil, flatbuffers.ErrTableHasUnknownFields
+		}
+		return obj, nil
+	}
+	return nil, nil
+}
+
+const BranchControlNumFields = 3
 
 func BranchControlStart(builder *flatbuffers.Builder) {
 	builder.StartObject(BranchControlNumFields)
@@ -95,6 +111,9 @@ func BranchControlAddAccessTbl(builder *flatbuffers.Builder, accessTbl flatbuffe
 func BranchControlAddNamespaceTbl(builder *flatbuffers.Builder, namespaceTbl flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(namespaceTbl), 0)
 }
+func BranchControlAddProtectionTbl(builder *flatbuffers.Builder, protectionTbl flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(protectionTbl), 0)
+}
 func BranchControlEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -694,6 +713,268 @@ func BranchControlNamespaceValueEnd(builder *flatbuffers.Builder) flatbuffers.UO
 	return builder.EndObject()
 }
 
+type BranchControlProtection struct {
+	_tab flatbuffers.Table
+}
+
+func InitBranchControlProtectionRoot(o *BranchControlProtection, buf []byte, offset flatbuffers.UOffsetT) error {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	return o.Init(buf, n+offset)
+}
+
+func TryGetRootAsBranchControlProtection(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtection, error) {
+	x := &BranchControlProtection{}
+	return x, InitBranchControlProtectionRoot(x, buf, offset)
+}
+
+func TryGetSizePrefixedRootAsBranchControlProtection(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtection, error) {
+	x := &BranchControlProtection{}
+	return x, InitBranchControlProtectionRoot(x, buf, offset+flatbuffers.SizeUint32)
+}
+
+func (rcv *BranchControlProtection) Init(buf []byte, i flatbuffers.UOffsetT) error {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+	if BranchControlProtectionNumFields < rcv.Table().NumFields() {
+		return flatbuffers.ErrTableHasUnknownFields
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtection) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *BranchControlProtection) TryDatabases(obj *BranchControlMatchExpression, j int) (bool, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlMatchExpressionNumFields < obj.Table().NumFields() {
+			return false, flatbuffers.ErrTableHasUnknownFields
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (rcv *BranchControlProtection) DatabasesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *BranchControlProtection) TryBranches(obj *BranchControlMatchExpression, j int) (bool, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlMatchExpressionNumFields < obj.Table().NumFields() {
+			return false, flatbuffers.ErrTableHasUnknownFields
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (rcv *BranchControlProtection) BranchesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *BranchControlProtection) TryValues(obj *BranchControlProtectionValue, j int) (bool, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlProtectionValueNumFields < obj.Table().NumFields() {
+			return false, flatbuffers.ErrTableHasUnknownFields
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (rcv *BranchControlProtection) ValuesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+const BranchControlProtectionNumFields = 3
+
+func BranchControlProtectionStart(builder *flatbuffers.Builder) {
+	builder.StartObject(BranchControlProtectionNumFields)
+}
+func BranchControlProtectionAddDatabases(builder *flatbuffers.Builder, databases flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(databases), 0)
+}
+func BranchControlProtectionStartDatabasesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func BranchControlProtectionAddBranches(builder *flatbuffers.Builder, branches flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(branches), 0)
+}
+func BranchControlProtectionStartBranchesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func BranchControlProtectionAddValues(builder *flatbuffers.Builder, values flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(values), 0)
+}
+func BranchControlProtectionStartValuesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func BranchControlProtectionEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
+type BranchControlProtectionValue struct {
+	_tab flatbuffers.Table
+}
+
+func InitBranchControlProtectionValueRoot(o *BranchControlProtectionValue, buf []byte, offset flatbuffers.UOffsetT) error {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	return o.Init(buf, n+offset)
+}
+
+func TryGetRootAsBranchControlProtectionValue(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtectionValue, error) {
+	x := &BranchControlProtectionValue{}
+	return x, InitBranchControlProtectionValueRoot(x, buf, offset)
+}
+
+func TryGetSizePrefixedRootAsBranchControlProtectionValue(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtectionValue, error) {
+	x := &BranchControlProtectionValue{}
+	return x, InitBranchControlProtectionValueRoot(x, buf, offset+flatbuffers.SizeUint32)
+}
+
+func (rcv *BranchControlProtectionValue) Init(buf []byte, i flatbuffers.UOffsetT) error {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+	if BranchControlProtectionValueNumFields < rcv.Table().NumFields() {
+		return flatbuffers.ErrTableHasUnknownFields
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *BranchControlProtectionValue) Database() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) Branch() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) RequiredTestGroups() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) DenyForcePush() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *BranchControlProtectionValue) MutateDenyForcePush(n bool) bool {
+	return rcv._tab.MutateBoolSlot(10, n)
+}
+
+func (rcv *BranchControlProtectionValue) DenyHardReset() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *BranchControlProtectionValue) MutateDenyHardReset(n bool) bool {
+	return rcv._tab.MutateBoolSlot(12, n)
+}
+
+func (rcv *BranchControlProtectionValue) RequireMerge() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *BranchControlProtectionValue) MutateRequireMerge(n bool) bool {
+	return rcv._tab.MutateBoolSlot(14, n)
+}
+
+func (rcv *BranchControlProtectionValue) RequireSignedCommits() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *BranchControlProtectionValue) MutateRequireSignedCommits(n bool) bool {
+	return rcv._tab.MutateBoolSlot(16, n)
+}
+
+const BranchControlProtectionValueNumFields = 7
+
+func BranchControlProtectionValueStart(builder *flatbuffers.Builder) {
+	builder.StartObject(BranchControlProtectionValueNumFields)
+}
+func BranchControlProtectionValueAddDatabase(builder *flatbuffers.Builder, database flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(database), 0)
+}
+func BranchControlProtectionValueAddBranch(builder *flatbuffers.Builder, branch flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(branch), 0)
+}
+func BranchControlProtectionValueAddRequiredTestGroups(builder *flatbuffers.Builder, requiredTestGroups flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(requiredTestGroups), 0)
+}
+func BranchControlProtectionValueAddDenyForcePush(builder *flatbuffers.Builder, denyForcePush bool) {
+	builder.PrependBoolSlot(3, denyForcePush, false)
+}
+func BranchControlProtectionValueAddDenyHardReset(builder *flatbuffers.Builder, denyHardReset bool) {
+	builder.PrependBoolSlot(4, denyHardReset, false)
+}
+func BranchControlProtectionValueAddRequireMerge(builder *flatbuffers.Builder, requireMerge bool) {
+	builder.PrependBoolSlot(5, requireMerge, false)
+}
+func BranchControlProtectionValueAddRequireSignedCommits(builder *flatbuffers.Builder, requireSignedCommits bool) {
+	builder.PrependBoolSlot(6, requireSignedCommits, false)
+}
+func BranchControlProtectionValueEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
 type BranchControlBinlog struct {
 	_tab flatbuffers.Table
 }
@@ -41,6 +41,11 @@ var (
 	ErrUpdatingToRow         = errors.NewKind("`%s`@`%s` cannot update the row [%q, %q, %q, %q] to the new branch expression [%q, %q]")
 	ErrDeletingRow           = errors.NewKind("`%s`@`%s` cannot delete the row [%q, %q, %q, %q]")
 	ErrMissingController     = errors.NewKind("a context has a non-nil session but is missing its branch controller")
+	ErrInsertingProtection   = errors.NewKind("`%s`@`%s` cannot add the protection row [%q, %q]")
+	ErrUpdatingProtection    = errors.NewKind("`%s`@`%s` cannot update the protection row [%q, %q]")
+	ErrDeletingProtection    = errors.NewKind("`%s`@`%s` cannot delete the protection row [%q, %q]")
+	ErrBranchProtected       = errors.NewKind("branch `%s` is protected: %s")
+	ErrModifyingPolicies     = errors.NewKind("`%s`@`%s` cannot modify dolt_policies without administrative privileges on database `%s`")
 )
 
 // Context represents the interface that must be inherited from the context.
@@ -56,8 +61,9 @@ type Context interface {
 
 // Controller is the central hub for branch control functions. This is passed within a context.
 type Controller struct {
-	Access    *Access
-	Namespace *Namespace
+	Access     *Access
+	Namespace  *Namespace
+	Protection *Protection
 
 	Serialized atomic.Pointer[[]byte]
 
@@ -87,6 +93,7 @@ func LoadData(ctx context.Context, branchControlFilePath string, doltConfigDirPa
 	controller := &Controller{
 		Access:                accessTbl,
 		Namespace:             newNamespace(accessTbl),
+		Protection:            newProtection(accessTbl),
 		branchControlFilePath: branchControlFilePath,
 		doltConfigDirPath:     doltConfigDirPath,
 	}
@@ -140,6 +147,10 @@ func (controller *Controller) LoadData(ctx context.Context, data []byte, isFirst
 	if err != nil {
 		return err
 	}
+	protection, err := bc.TryProtectionTbl(nil)
+	if err != nil {
+		return err
+	}
 
 	rollback := controller.Serialized.Load()
 
@@ -161,6 +172,13 @@ func (controller *Controller) LoadData(ctx context.Context, data []byte, isFirst
 		}
 		return err
 	}
+	if err = controller.Protection.Deserialize(protection); err != nil {
+		// TODO: More principaled rollback. Hopefully this does not fail.
+		if rollback != nil {
+			_ = controller.LoadData(ctx, *rollback, isFirstLoad)
+		}
+		return err
+	}
 
 	controller.Serialized.Store(&data)
 	if controller.SavedCallback != nil {
@@ -206,9 +224,18 @@ func (controller *Controller) SaveData(ctx context.Context, fs filesys.Filesys)
 	// The Serialize functions acquire read locks, so we don't acquire them here
 	accessOffset := controller.Access.Serialize(b)
 	namespaceOffset := controller.Namespace.Serialize(b)
+	// The protection table is only written when it has rows, so that older versions may still read files that do not
+	// make use of branch protection.
+	var protectionOffset flatbuffers.UOffsetT
+	if len(controller.Protection.Values) > 0 {
+		protectionOffset = controller.Protection.Serialize(b)
+	}
 	serial.BranchControlStart(b)
 	serial.BranchControlAddAccessTbl(b, accessOffset)
 	serial.BranchControlAddNamespaceTbl(b, namespaceOffset)
+	if protectionOffset != 0 {
+		serial.BranchControlAddProtectionTbl(b, protectionOffset)
+	}
 	root := serial.BranchControlEnd(b)
 	// serial.FinishMessage() limits files to 2^24 bytes, so this works around it while maintaining read compatibility
 	b.Prep(1, flatbuffers.SizeInt32+4+serial.MessagePrefixSz)
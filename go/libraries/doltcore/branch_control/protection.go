@@ -0,0 +1,319 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+)
+
+// Protection contains all of the expressions that comprise the "dolt_branch_protection" table, which restricts the
+// operations that may be performed on matching branches regardless of a user's permissions. Modification of this table
+// is handled by the Access table.
+type Protection struct {
+	access *Access
+
+	Databases []MatchExpression
+	Branches  []MatchExpression
+	Values    []ProtectionValue
+	RWMutex   *sync.RWMutex
+}
+
+// ProtectionValue contains the user-facing values of a particular row.
+type ProtectionValue struct {
+	Database string
+	Branch   string
+	// RequiredTestGroups is a comma-separated list of the dolt_tests groups that must pass for a commit to be made.
+	RequiredTestGroups   string
+	DenyForcePush        bool
+	DenyHardReset        bool
+	RequireMerge         bool
+	RequireSignedCommits bool
+}
+
+// ProtectionRules are the combined rules of every protection row that matches a branch.
+type ProtectionRules struct {
+	Branch               string
+	RequiredTestGroups   []string
+	DenyForcePush        bool
+	DenyHardReset        bool
+	RequireMerge         bool
+	RequireSignedCommits bool
+}
+
+// newProtection returns a new Protection.
+func newProtection(accessTbl *Access) *Protection {
+	return &Protection{
+		access:    accessTbl,
+		Databases: nil,
+		Branches:  nil,
+		Values:    nil,
+		RWMutex:   accessTbl.RWMutex,
+	}
+}
+
+// Match returns the combination of every rule that matches the given database and branch. Unlike the other tables,
+// rules are not restricted to the longest match, as a protection should never be weakened by a more specific row.
+// Requires external synchronization handling, therefore manually manage the RWMutex.
+func (tbl *Protection) Match(database string, branch string) ProtectionRules {
+	rules := ProtectionRules{Branch: branch}
+	filteredIndexes := Match(tbl.Databases, database, sql.Collation_utf8mb4_0900_ai_ci)
+	// If there are no database entries, then the branch is unprotected
+	if len(filteredIndexes) == 0 {
+		indexPool.Put(filteredIndexes)
+		return rules
+	}
+	filteredBranches := tbl.filterBranches(filteredIndexes)
+	indexPool.Put(filteredIndexes)
+	matchedSet := Match(filteredBranches, branch, sql.Collation_utf8mb4_0900_ai_ci)
+	matchExprPool.Put(filteredBranches)
+
+	groups := make(map[string]struct{})
+	for _, matched := range matchedSet {
+		value := tbl.Values[matched]
+		rules.DenyForcePush = rules.DenyForcePush || value.DenyForcePush
+		rules.DenyHardReset = rules.DenyHardReset || value.DenyHardReset
+		rules.RequireMerge = rules.RequireMerge || value.RequireMerge
+		rules.RequireSignedCommits = rules.RequireSignedCommits || value.RequireSignedCommits
+		for _, group := range ParseTestGroups(value.RequiredTestGroups) {
+			groups[group] = struct{}{}
+		}
+	}
+	indexPool.Put(matchedSet)
+
+	for group := range groups {
+		rules.RequiredTestGroups = append(rules.RequiredTestGroups, group)
+	}
+	sort.Strings(rules.RequiredTestGroups)
+	return rules
+}
+
+// GetIndex returns the index of the given database and branch expressions. If the expressions cannot be found, returns
+// -1. Assumes that the given expressions have already been folded.
+func (tbl *Protection) GetIndex(databaseExpr string, branchExpr string) int {
+	for i, value := range tbl.Values {
+		if value.Database == databaseExpr && value.Branch == branchExpr {
+			return i
+		}
+	}
+	return -1
+}
+
+// Access returns the Access table.
+func (tbl *Protection) Access() *Access {
+	return tbl.access
+}
+
+// Insert adds the given value to the table. Assumes that the database and branch expressions have already been folded,
+// and that the value does not already exist in the table. Requires external synchronization handling, therefore
+// manually manage the RWMutex.
+func (tbl *Protection) Insert(value ProtectionValue) {
+	databaseExpr := ParseExpression(value.Database, sql.Collation_utf8mb4_0900_ai_ci)
+	branchExpr := ParseExpression(value.Branch, sql.Collation_utf8mb4_0900_ai_ci)
+	nextIdx := uint32(len(tbl.Values))
+	tbl.Databases = append(tbl.Databases, MatchExpression{CollectionIndex: nextIdx, SortOrders: databaseExpr})
+	tbl.Branches = append(tbl.Branches, MatchExpression{CollectionIndex: nextIdx, SortOrders: branchExpr})
+	tbl.Values = append(tbl.Values, value)
+}
+
+// Delete removes the given database and branch expressions from the table. Assumes that the expressions have already
+// been folded. Requires external synchronization handling, therefore manually manage the RWMutex.
+func (tbl *Protection) Delete(databaseExpr string, branchExpr string) {
+	tblIndex := tbl.GetIndex(databaseExpr, branchExpr)
+	if tblIndex == -1 {
+		return
+	}
+
+	endIndex := len(tbl.Values) - 1
+	// Remove the matching row from all slices by first swapping with the last element
+	tbl.Databases[tblIndex], tbl.Databases[endIndex] = tbl.Databases[endIndex], tbl.Databases[tblIndex]
+	tbl.Branches[tblIndex], tbl.Branches[endIndex] = tbl.Branches[endIndex], tbl.Branches[tblIndex]
+	tbl.Values[tblIndex], tbl.Values[endIndex] = tbl.Values[endIndex], tbl.Values[tblIndex]
+	// Then we remove the last element
+	tbl.Databases = tbl.Databases[:endIndex]
+	tbl.Branches = tbl.Branches[:endIndex]
+	tbl.Values = tbl.Values[:endIndex]
+	// Then we update the index for the match expressions
+	if tblIndex != endIndex {
+		tbl.Databases[tblIndex].CollectionIndex = uint32(tblIndex)
+		tbl.Branches[tblIndex].CollectionIndex = uint32(tblIndex)
+	}
+}
+
+// Serialize returns the offset for the Protection table written to the given builder.
+func (tbl *Protection) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
+	// Initialize field offset slices
+	databaseOffsets := make([]flatbuffers.UOffsetT, len(tbl.Databases))
+	branchOffsets := make([]flatbuffers.UOffsetT, len(tbl.Branches))
+	valueOffsets := make([]flatbuffers.UOffsetT, len(tbl.Values))
+	// Get field offsets
+	for i, matchExpr := range tbl.Databases {
+		databaseOffsets[i] = matchExpr.Serialize(b)
+	}
+	for i, matchExpr := range tbl.Branches {
+		branchOffsets[i] = matchExpr.Serialize(b)
+	}
+	for i, val := range tbl.Values {
+		valueOffsets[i] = val.Serialize(b)
+	}
+	// Get the field vectors
+	serial.BranchControlProtectionStartDatabasesVector(b, len(databaseOffsets))
+	for i := len(databaseOffsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(databaseOffsets[i])
+	}
+	databases := b.EndVector(len(databaseOffsets))
+	serial.BranchControlProtectionStartBranchesVector(b, len(branchOffsets))
+	for i := len(branchOffsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(branchOffsets[i])
+	}
+	branches := b.EndVector(len(branchOffsets))
+	serial.BranchControlProtectionStartValuesVector(b, len(valueOffsets))
+	for i := len(valueOffsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(valueOffsets[i])
+	}
+	values := b.EndVector(len(valueOffsets))
+	// Write the table
+	serial.BranchControlProtectionStart(b)
+	serial.BranchControlProtectionAddDatabases(b, databases)
+	serial.BranchControlProtectionAddBranches(b, branches)
+	serial.BranchControlProtectionAddValues(b, values)
+	return serial.BranchControlProtectionEnd(b)
+}
+
+func (tbl *Protection) reinit() {
+	tbl.Databases = nil
+	tbl.Branches = nil
+	tbl.Values = nil
+}
+
+// Deserialize populates the table with the data from the flatbuffers representation. A nil table, which is what files
+// written before the introduction of branch protection contain, results in an empty table.
+func (tbl *Protection) Deserialize(fb *serial.BranchControlProtection) error {
+	tbl.reinit()
+	if fb == nil {
+		return nil
+	}
+	// Verify that all fields have the same length
+	if fb.DatabasesLength() != fb.BranchesLength() || fb.BranchesLength() != fb.ValuesLength() {
+		return fmt.Errorf("cannot deserialize a protection table with differing field lengths")
+	}
+
+	// Initialize every slice
+	tbl.Databases = make([]MatchExpression, fb.DatabasesLength())
+	tbl.Branches = make([]MatchExpression, fb.BranchesLength())
+	tbl.Values = make([]ProtectionValue, fb.ValuesLength())
+	// Read the databases
+	for i := 0; i < fb.DatabasesLength(); i++ {
+		serialMatchExpr := &serial.BranchControlMatchExpression{}
+		if _, err := fb.TryDatabases(serialMatchExpr, i); err != nil {
+			return err
+		}
+		tbl.Databases[i] = deserializeMatchExpression(serialMatchExpr)
+	}
+	// Read the branches
+	for i := 0; i < fb.BranchesLength(); i++ {
+		serialMatchExpr := &serial.BranchControlMatchExpression{}
+		if _, err := fb.TryBranches(serialMatchExpr, i); err != nil {
+			return err
+		}
+		tbl.Branches[i] = deserializeMatchExpression(serialMatchExpr)
+	}
+	// Read the values
+	for i := 0; i < fb.ValuesLength(); i++ {
+		serialProtectionValue := &serial.BranchControlProtectionValue{}
+		if _, err := fb.TryValues(serialProtectionValue, i); err != nil {
+			return err
+		}
+		tbl.Values[i] = ProtectionValue{
+			Database:             string(serialProtectionValue.Database()),
+			Branch:               string(serialProtectionValue.Branch()),
+			RequiredTestGroups:   string(serialProtectionValue.RequiredTestGroups()),
+			DenyForcePush:        serialProtectionValue.DenyForcePush(),
+			DenyHardReset:        serialProtectionValue.DenyHardReset(),
+			RequireMerge:         serialProtectionValue.RequireMerge(),
+			RequireSignedCommits: serialProtectionValue.RequireSignedCommits(),
+		}
+	}
+	return nil
+}
+
+// filterBranches returns all branches that match the given collection indexes.
+func (tbl *Protection) filterBranches(filters []uint32) []MatchExpression {
+	if len(filters) == 0 {
+		return nil
+	}
+	matchExprs := matchExprPool.Get().([]MatchExpression)[:0]
+	for _, filter := range filters {
+		matchExprs = append(matchExprs, tbl.Branches[filter])
+	}
+	return matchExprs
+}
+
+// Serialize returns the offset for the ProtectionValue written to the given builder.
+func (val *ProtectionValue) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
+	database := b.CreateSharedString(val.Database)
+	branch := b.CreateSharedString(val.Branch)
+	requiredTestGroups := b.CreateSharedString(val.RequiredTestGroups)
+
+	serial.BranchControlProtectionValueStart(b)
+	serial.BranchControlProtectionValueAddDatabase(b, database)
+	serial.BranchControlProtectionValueAddBranch(b, branch)
+	serial.BranchControlProtectionValueAddRequiredTestGroups(b, requiredTestGroups)
+	serial.BranchControlProtectionValueAddDenyForcePush(b, val.DenyForcePush)
+	serial.BranchControlProtectionValueAddDenyHardReset(b, val.DenyHardReset)
+	serial.BranchControlProtectionValueAddRequireMerge(b, val.RequireMerge)
+	serial.BranchControlProtectionValueAddRequireSignedCommits(b, val.RequireSignedCommits)
+	return serial.BranchControlProtectionValueEnd(b)
+}
+
+// ParseTestGroups splits a comma-separated list of test groups, ignoring any empty entries.
+func ParseTestGroups(groups string) []string {
+	var parsed []string
+	for _, group := range strings.Split(groups, ",") {
+		if group = strings.TrimSpace(group); len(group) > 0 {
+			parsed = append(parsed, group)
+		}
+	}
+	return parsed
+}
+
+// GetProtectionRules returns the protection rules for the given branch of the given database. In general, SQL
+// statements will almost always return a *sql.Context, so any checks from the SQL path will find the rules for the
+// branch. However, not all CLI commands use *sql.Context, and in these cases no rules are returned, as we want to allow
+// all local commands to ignore branch protection in the same way that they ignore branch permissions.
+func GetProtectionRules(ctx context.Context, database string, branch string) (ProtectionRules, error) {
+	branchAwareSession := GetBranchAwareSession(ctx)
+	// A nil session means we're not in the SQL context, so nothing is protected
+	if branchAwareSession == nil {
+		return ProtectionRules{Branch: branch}, nil
+	}
+	controller := branchAwareSession.GetController()
+	// Any context that has a non-nil session should always have a non-nil controller, so this is an error
+	if controller == nil {
+		return ProtectionRules{}, ErrMissingController.New()
+	}
+	controller.Protection.RWMutex.RLock()
+	defer controller.Protection.RWMutex.RUnlock()
+
+	return controller.Protection.Match(getDatabaseNameOnly(database), branch), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"testing"
+
+	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+)
+
+func TestProtectionMatch(t *testing.T) {
+	tbl := newProtection(newAccess())
+	tbl.Insert(ProtectionValue{Database: "%", Branch: "main", DenyForcePush: true, RequiredTestGroups: "smoke"})
+	tbl.Insert(ProtectionValue{Database: "mydb", Branch: "ma%", DenyHardReset: true, RequiredTestGroups: "smoke,full"})
+	tbl.Insert(ProtectionValue{Database: "mydb", Branch: "release/%", RequireMerge: true, RequireSignedCommits: true})
+
+	// Every matching row applies, not only the longest match
+	rules := tbl.Match("mydb", "main")
+	assert.Equal(t, ProtectionRules{
+		Branch:             "main",
+		RequiredTestGroups: []string{"full", "smoke"},
+		DenyForcePush:      true,
+		DenyHardReset:      true,
+	}, rules)
+
+	rules = tbl.Match("otherdb", "MAIN")
+	assert.Equal(t, ProtectionRules{
+		Branch:             "MAIN",
+		RequiredTestGroups: []string{"smoke"},
+		DenyForcePush:      true,
+	}, rules)
+
+	rules = tbl.Match("mydb", "release/1.0")
+	assert.True(t, rules.RequireMerge)
+	assert.True(t, rules.RequireSignedCommits)
+	assert.Empty(t, rules.RequiredTestGroups)
+
+	assert.Equal(t, ProtectionRules{Branch: "feature"}, tbl.Match("mydb", "feature"))
+
+	tbl.Delete("%", "main")
+	assert.Equal(t, -1, tbl.GetIndex("%", "main"))
+	rules = tbl.Match("mydb", "main")
+	assert.False(t, rules.DenyForcePush)
+	assert.True(t, rules.DenyHardReset)
+	assert.Equal(t, ProtectionRules{Branch: "main"}, tbl.Match("otherdb", "main"))
+}
+
+func TestProtectionSerialization(t *testing.T) {
+	tbl := newProtection(newAccess())
+	tbl.Insert(ProtectionValue{Database: "%", Branch: "main", DenyForcePush: true, DenyHardReset: true})
+	tbl.Insert(ProtectionValue{Database: "mydb", Branch: "release/%", RequiredTestGroups: "smoke", RequireMerge: true, RequireSignedCommits: true})
+
+	b := flatbuffers.NewBuilder(1024)
+	offset := tbl.Serialize(b)
+	b.Finish(offset)
+	fb, err := serial.TryGetRootAsBranchControlProtection(b.FinishedBytes(), 0)
+	require.NoError(t, err)
+
+	deserialized := newProtection(newAccess())
+	require.NoError(t, deserialized.Deserialize(fb))
+	assert.Equal(t, tbl.Values, deserialized.Values)
+	assert.Equal(t, tbl.Match("mydb", "release/2.0"), deserialized.Match("mydb", "release/2.0"))
+	assert.Equal(t, tbl.Match("mydb", "main"), deserialized.Match("mydb", "main"))
+
+	// Files written before branch protection existed have no protection table
+	require.NoError(t, deserialized.Deserialize(nil))
+	assert.Empty(t, deserialized.Values)
+}
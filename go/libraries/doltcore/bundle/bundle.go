@@ -0,0 +1,347 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle reads and writes dolt bundles. A bundle is a single file holding the chunks reachable from a set of
+// refs, minus the chunks reachable from a set of prerequisite commits, so that a database or the changes made to one
+// can be moved between machines which share no remote.
+//
+// A bundle file starts with a magic line, followed by a big-endian uint32 length and a JSON encoded Header of that
+// length. The table files listed in the header follow, concatenated in the order they are listed. The table files are
+// written by the same puller used to push and fetch, so they are archives in the usual case.
+package bundle
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/memlimit"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// FormatVersion is the version of the bundle format written by Create.
+	FormatVersion = 1
+
+	magic = "# dolt bundle v1\n"
+
+	// maxHeaderLen bounds the header length read from a bundle, so that a corrupt file can't cause a huge allocation.
+	maxHeaderLen = 64 * 1024 * 1024
+)
+
+// ErrNotABundle is returned when opening a file which isn't a dolt bundle.
+var ErrNotABundle = errors.New("not a dolt bundle")
+
+// ErrEmptyBundle is returned by Create when every ref to bundle is already reachable from the prerequisites.
+var ErrEmptyBundle = errors.New("refusing to create an empty bundle; every ref is already contained in the base")
+
+// ErrMissingPrerequisites is returned when unbundling into a database which lacks the prerequisite commits of a bundle.
+var ErrMissingPrerequisites = errors.New("the database lacks the prerequisite commits of this bundle")
+
+// Ref is a ref stored in a bundle, and the address of the commit or tag it points to.
+type Ref struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// TableFile describes one of the table files stored in a bundle.
+type TableFile struct {
+	ID          string `json:"id"`
+	NumChunks   int    `json:"num_chunks"`
+	SplitOffset uint64 `json:"split_offset"`
+	ContentHash []byte `json:"content_hash"`
+	Size        uint64 `json:"size"`
+}
+
+// Header is the table of contents of a bundle.
+type Header struct {
+	Version int `json:"version"`
+	// NbfVersion is the storage format of the database the bundle was created from.
+	NbfVersion string `json:"nbf_version"`
+	Refs       []Ref  `json:"refs"`
+	// Prerequisites are the commits which the database being unbundled into must already have. They are empty for a
+	// bundle which holds the complete history of its refs.
+	Prerequisites []string    `json:"prerequisites,omitempty"`
+	TableFiles    []TableFile `json:"table_files"`
+}
+
+func writeHeader(w io.Writer, hdr *Header) error {
+	data, err := json.Marshal(hdr)
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(w, magic); err != nil {
+		return err
+	}
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(data)))
+	if _, err = w.Write(l[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readHeader reads the header at the start of |r|, and returns it along with the number of bytes it took up.
+func readHeader(r io.Reader) (*Header, int64, error) {
+	prefix := make([]byte, len(magic)+4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, 0, ErrNotABundle
+		}
+		return nil, 0, err
+	}
+	if string(prefix[:len(magic)]) != magic {
+		return nil, 0, ErrNotABundle
+	}
+
+	l := binary.BigEndian.Uint32(prefix[len(magic):])
+	if l > maxHeaderLen {
+		return nil, 0, fmt.Errorf("%w: header is too large", ErrNotABundle)
+	}
+	data := make([]byte, l)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrNotABundle, err)
+	}
+
+	var hdr Header
+	if err := json.Unmarshal(data, &hdr); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrNotABundle, err)
+	}
+	if hdr.Version != FormatVersion {
+		return nil, 0, fmt.Errorf("unsupported bundle version %d", hdr.Version)
+	}
+	return &hdr, int64(len(prefix)) + int64(l), nil
+}
+
+// Reader provides access to the contents of a bundle file.
+type Reader struct {
+	Header *Header
+
+	f       *os.File
+	offsets []int64
+}
+
+// Open opens the bundle at |path| and reads its header. The returned Reader must be closed.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr, off, err := readHeader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	offsets := make([]int64, len(hdr.TableFiles))
+	for i, tf := range hdr.TableFiles {
+		offsets[i] = off
+		off += int64(tf.Size)
+	}
+	if off != st.Size() {
+		f.Close()
+		return nil, fmt.Errorf("bundle %s is truncated or corrupt: expected %d bytes, found %d", path, off, st.Size())
+	}
+
+	return &Reader{Header: hdr, f: f, offsets: offsets}, nil
+}
+
+// Close closes the bundle file.
+func (r *Reader) Close() error {
+	return r.f.Close()
+}
+
+// MissingPrerequisites returns the prerequisite commits of the bundle which are not in |cs|.
+func (r *Reader) MissingPrerequisites(ctx context.Context, cs chunks.ChunkStore) (hash.HashSlice, error) {
+	if len(r.Header.Prerequisites) == 0 {
+		return nil, nil
+	}
+
+	prereqs := make(hash.HashSet)
+	for _, s := range r.Header.Prerequisites {
+		h, ok := hash.MaybeParse(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid prerequisite commit in bundle: %s", s)
+		}
+		prereqs.Insert(h)
+	}
+
+	absent, err := cs.HasMany(ctx, prereqs)
+	if err != nil {
+		return nil, err
+	}
+	missing := absent.ToSlice()
+	sort.Sort(missing)
+	return missing, nil
+}
+
+// Unbundle adds the chunks stored in the bundle to |cs|, which must have the bundle's prerequisite commits. No refs
+// are changed; the caller decides what to do with the refs listed in the bundle's header.
+func (r *Reader) Unbundle(ctx context.Context, cs chunks.ChunkStore) error {
+	if cs.Version() != r.Header.NbfVersion {
+		return fmt.Errorf("cannot unbundle; the bundle's storage format is %s and the database's is %s", r.Header.NbfVersion, cs.Version())
+	}
+	tfs, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return errors.New("cannot unbundle; the database does not support adding table files")
+	}
+
+	missing, err := r.MissingPrerequisites(ctx, cs)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingPrerequisites, joinHashes(missing))
+	}
+
+	nbf, err := types.GetFormatForVersionString(cs.Version())
+	if err != nil {
+		return err
+	}
+	return r.addTableFiles(ctx, tfs, getAddrsForFormat(nbf))
+}
+
+// OpenDatabase returns a read-only view of the bundle as a database, which has a dataset for each of the bundle's refs.
+// This lets a bundle be cloned or fetched from like a remote. The bundle's table files are copied into a temporary
+// store, which is removed when the database is closed.
+func (r *Reader) OpenDatabase(ctx context.Context) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	dir, err := os.MkdirTemp("", "dolt-bundle-")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	st, err := nbs.NewLocalStore(ctx, r.Header.NbfVersion, dir, memlimit.MemtableSize(), nbs.NewUnlimitedMemQuotaProvider(), false)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, nil, err
+	}
+	cs := &tempStore{NomsBlockStore: st, dir: dir}
+
+	// The chunks of an incremental bundle refer to chunks of its prerequisites, which this store doesn't have, so the
+	// references of the table files can't be checked here. They are checked when they're fetched into a database.
+	if err = r.addTableFiles(ctx, st, noAddrs); err != nil {
+		cs.Close()
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+	for _, ref := range r.Header.Refs {
+		h, ok := hash.MaybeParse(ref.Hash)
+		if !ok {
+			db.Close()
+			return nil, nil, nil, fmt.Errorf("invalid hash for ref %s in bundle: %s", ref.Name, ref.Hash)
+		}
+		ds, err := db.GetDataset(ctx, ref.Name)
+		if err != nil {
+			db.Close()
+			return nil, nil, nil, err
+		}
+		if _, err = db.SetHead(ctx, ds, h, ""); err != nil {
+			db.Close()
+			return nil, nil, nil, err
+		}
+	}
+
+	return db, vrw, ns, nil
+}
+
+// addTableFiles writes every table file in the bundle to |tfs| and adds them to its manifest.
+func (r *Reader) addTableFiles(ctx context.Context, tfs chunks.TableFileStore, getAddrs chunks.InsertAddrsCurry) error {
+	var pending []io.Closer
+	defer func() {
+		for _, c := range pending {
+			c.Close()
+		}
+	}()
+
+	updates := make(map[string]int)
+	for i, tf := range r.Header.TableFiles {
+		rd := io.NewSectionReader(r.f, r.offsets[i], int64(tf.Size))
+		c, err := tfs.WriteTableFile(ctx, tf.ID, tf.SplitOffset, tf.NumChunks, tf.ContentHash, func() (io.ReadCloser, uint64, error) {
+			if _, err := rd.Seek(0, io.SeekStart); err != nil {
+				return nil, 0, err
+			}
+			return io.NopCloser(rd), tf.Size, nil
+		})
+		if err != nil {
+			return err
+		}
+		pending = append(pending, c)
+		updates[strings.TrimSuffix(tf.ID, nbs.ArchiveFileSuffix)] = tf.NumChunks
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return tfs.AddTableFilesToManifest(ctx, updates, getAddrs)
+}
+
+// tempStore is a NomsBlockStore in a temporary directory, which is removed when the store is closed.
+type tempStore struct {
+	*nbs.NomsBlockStore
+	dir string
+}
+
+func (s *tempStore) Close() error {
+	err := s.NomsBlockStore.Close()
+	return errors.Join(err, os.RemoveAll(s.dir))
+}
+
+func getAddrsForFormat(nbf *types.NomsBinFormat) chunks.InsertAddrsCurry {
+	walkAddrs := types.WalkAddrsForNBF(nbf, nil)
+	return func(c chunks.Chunk) chunks.InsertAddrsCb {
+		return func(ctx context.Context, ins hash.HashSet, filter chunks.PendingRefExists) error {
+			return walkAddrs(c, func(h hash.Hash, _ bool) error {
+				if !filter(h) {
+					ins.Insert(h)
+				}
+				return nil
+			})
+		}
+	}
+}
+
+func noAddrs(chunks.Chunk) chunks.InsertAddrsCb {
+	return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+		return nil
+	}
+}
+
+func joinHashes(hashes hash.HashSlice) string {
+	strs := make([]string, len(hashes))
+	for i, h := range hashes {
+		strs[i] = h.String()
+	}
+	return strings.Join(strs, ", ")
+}
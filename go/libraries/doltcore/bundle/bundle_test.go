@@ -0,0 +1,154 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	mainRef := ref.NewBranchRef("main")
+
+	src := loadDB(t, ctx)
+	require.NoError(t, src.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bill@billerson.com"))
+	first := headHash(t, ctx, src, mainRef)
+	second := commit(t, ctx, src, mainRef, "second commit")
+	srcCS := chunkStore(src)
+
+	dir := t.TempDir()
+	fullPath := filepath.Join(dir, "full.bundle")
+	writeBundle(t, ctx, srcCS, fullPath, []bundle.Ref{{Name: mainRef.String(), Hash: first.String()}}, nil)
+	incrementalPath := filepath.Join(dir, "incremental.bundle")
+	writeBundle(t, ctx, srcCS, incrementalPath, []bundle.Ref{{Name: mainRef.String(), Hash: second.String()}}, []hash.Hash{first})
+
+	t.Run("empty bundle", func(t *testing.T) {
+		_, err := bundle.Create(ctx, t.TempDir(), srcCS, []bundle.Ref{{Name: mainRef.String(), Hash: first.String()}}, []hash.Hash{first}, io.Discard)
+		require.ErrorIs(t, err, bundle.ErrEmptyBundle)
+	})
+
+	t.Run("not a bundle", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "not.bundle")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+		_, err := bundle.Open(path)
+		require.ErrorIs(t, err, bundle.ErrNotABundle)
+	})
+
+	t.Run("unbundle", func(t *testing.T) {
+		destCS := chunkStore(loadDB(t, ctx))
+
+		incremental, err := bundle.Open(incrementalPath)
+		require.NoError(t, err)
+		defer incremental.Close()
+		assert.Equal(t, []string{first.String()}, incremental.Header.Prerequisites)
+		// the second commit has the same root value as the first, so only the commit and its parent closure are bundled
+		numChunks := 0
+		for _, tf := range incremental.Header.TableFiles {
+			numChunks += tf.NumChunks
+		}
+		assert.LessOrEqual(t, numChunks, 2)
+		require.ErrorIs(t, incremental.Unbundle(ctx, destCS), bundle.ErrMissingPrerequisites)
+
+		full, err := bundle.Open(fullPath)
+		require.NoError(t, err)
+		defer full.Close()
+		assert.Empty(t, full.Header.Prerequisites)
+		require.NoError(t, full.Unbundle(ctx, destCS))
+		ok, err := destCS.Has(ctx, first)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, incremental.Unbundle(ctx, destCS))
+		ok, err = destCS.Has(ctx, second)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("open as database", func(t *testing.T) {
+		r, err := bundle.Open(incrementalPath)
+		require.NoError(t, err)
+		defer r.Close()
+
+		db, _, _, err := r.OpenDatabase(ctx)
+		require.NoError(t, err)
+		defer db.Close()
+
+		ds, err := db.GetDataset(ctx, mainRef.String())
+		require.NoError(t, err)
+		addr, ok := ds.MaybeHeadAddr()
+		require.True(t, ok)
+		assert.Equal(t, second, addr)
+	})
+}
+
+func loadDB(t *testing.T, ctx context.Context) *doltdb.DoltDB {
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, "file://"+filepath.ToSlash(t.TempDir()), filesys.LocalFS)
+	require.NoError(t, err)
+	t.Cleanup(func() { ddb.Close() })
+	return ddb
+}
+
+func chunkStore(ddb *doltdb.DoltDB) chunks.ChunkStore {
+	return datas.ChunkStoreFromDatabase(doltdb.ExposeDatabaseFromDoltDB(ddb))
+}
+
+func headHash(t *testing.T, ctx context.Context, ddb *doltdb.DoltDB, r ref.DoltRef) hash.Hash {
+	cm, err := ddb.ResolveCommitRef(ctx, r)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	return h
+}
+
+// commit makes a commit on |r| with the same root value as its parent.
+func commit(t *testing.T, ctx context.Context, ddb *doltdb.DoltDB, r ref.DoltRef, msg string) hash.Hash {
+	parent, err := ddb.ResolveCommitRef(ctx, r)
+	require.NoError(t, err)
+	root, err := parent.GetRootValue(ctx)
+	require.NoError(t, err)
+	_, rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := datas.NewCommitMeta("Bill Billerson", "bill@billerson.com", msg)
+	require.NoError(t, err)
+	cm, err := ddb.CommitWithParentCommits(ctx, rootHash, r, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	return h
+}
+
+func writeBundle(t *testing.T, ctx context.Context, cs chunks.ChunkStore, path string, refs []bundle.Ref, prerequisites []hash.Hash) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = bundle.Create(ctx, t.TempDir(), cs, refs, prerequisites, f)
+	require.NoError(t, err)
+}
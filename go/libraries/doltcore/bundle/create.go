@@ -0,0 +1,371 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const targetFileSize = 1 << 30
+
+// Create writes a bundle of the chunks reachable from |refs| in |cs| to |w|. Chunks reachable from the |prerequisites|
+// commits are left out, so the bundle can only be unbundled into a database which has those commits. With no
+// prerequisites, the bundle holds the complete history of its refs. |tempDir| is used for the table files written while
+// the bundle is being built.
+func Create(ctx context.Context, tempDir string, cs chunks.ChunkStore, refs []Ref, prerequisites []hash.Hash, w io.Writer) (*Header, error) {
+	if len(refs) == 0 {
+		return nil, errors.New("no refs to bundle")
+	}
+
+	nbf, err := types.GetFormatForVersionString(cs.Version())
+	if err != nil {
+		return nil, err
+	}
+	walkAddrs := types.WalkAddrsForNBF(nbf, nil)
+
+	targets := make([]hash.Hash, len(refs))
+	for i, r := range refs {
+		h, ok := hash.MaybeParse(r.Hash)
+		if !ok {
+			return nil, fmt.Errorf("invalid hash for ref %s: %s", r.Name, r.Hash)
+		}
+		targets[i] = h
+	}
+
+	bundled, err := bundledChunks(ctx, cs, walkAddrs, targets, prerequisites)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &bundleSink{
+		ChunkStore: (&chunks.MemoryStorage{}).NewViewWithFormat(cs.Version()),
+		bundled:    bundled,
+		tempDir:    tempDir,
+	}
+	defer sink.removeFiles()
+
+	puller, err := pull.NewPuller(ctx, tempDir, targetFileSize, cs, sink, walkAddrs, targets, nil)
+	if errors.Is(err, pull.ErrDBUpToDate) {
+		return nil, ErrEmptyBundle
+	} else if err != nil {
+		return nil, err
+	}
+	if err = puller.Pull(ctx); err != nil {
+		return nil, err
+	}
+
+	hdr := &Header{
+		Version:    FormatVersion,
+		NbfVersion: cs.Version(),
+		Refs:       refs,
+	}
+	for _, h := range prerequisites {
+		hdr.Prerequisites = append(hdr.Prerequisites, h.String())
+	}
+	for _, f := range sink.files {
+		hdr.TableFiles = append(hdr.TableFiles, f.TableFile)
+	}
+
+	if err = writeHeader(w, hdr); err != nil {
+		return nil, err
+	}
+	for _, f := range sink.files {
+		if err = copyFile(w, f.path); err != nil {
+			return nil, err
+		}
+	}
+	return hdr, nil
+}
+
+// bundledChunks returns the addresses of the chunks a bundle of |targets| needs, given that the database it's unbundled
+// into has the |prerequisites| commits and everything reachable from them.
+//
+// Rather than collecting every chunk reachable from the prerequisites, this walks down from the targets a level at a
+// time and filters each level against the prerequisites, in the same way the puller filters against the chunks a
+// destination has. Commits are checked against the commit closures of the prerequisites, so their history is only
+// walked as far down as the commits the targets reach. Other chunks are checked against the chunks reachable from the
+// prerequisites' root values and commit closures, which are walked a level at a time alongside the targets, skipping
+// the subtrees the targets have already reached. A chunk that's reachable from the prerequisites but not found by this
+// walk, because it sits at a different depth or was only reachable from older history, is bundled needlessly, which
+// makes the bundle bigger but still correct.
+func bundledChunks(ctx context.Context, cs chunks.ChunkStore, walkAddrs pull.WalkAddrs, targets, prerequisites []hash.Hash) (hash.HashSet, error) {
+	vs := types.NewValueStore(cs)
+	closures := make([]datas.CommitClosure, len(prerequisites))
+	for i, h := range prerequisites {
+		cm, err := datas.LoadCommitAddr(ctx, vs, h)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bundle; unable to load prerequisite %s: %w", h.String(), err)
+		}
+		closures[i] = datas.NewLazyCommitClosure(cm, vs)
+	}
+	inBase := func(ctx context.Context, h hash.Hash) (bool, error) {
+		cm, err := datas.LoadCommitAddr(ctx, vs, h)
+		if err != nil {
+			return false, err
+		}
+		for _, cl := range closures {
+			if ok, err := cl.Contains(ctx, cm); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+
+	// the prerequisites' walk starts below the prerequisite commits, which the closures already cover
+	baseSeen := hash.NewHashSet(prerequisites...)
+	baseNext := hash.NewHashSet()
+	_, err := walkLevel(ctx, cs, hash.NewHashSet(prerequisites...), func(c chunks.Chunk) error {
+		msg, err := serial.TryGetRootAsCommit(c.Data(), serial.MessagePrefixSz)
+		if err != nil {
+			return err
+		}
+		baseNext.Insert(hash.New(msg.RootBytes()))
+		if closure := hash.New(msg.ParentClosureBytes()); !closure.IsEmpty() {
+			baseNext.Insert(closure)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bundled := hash.NewHashSet()
+	next := hash.NewHashSet(targets...)
+	for len(next) > 0 {
+		// advance the prerequisites' walk by a level, leaving out the chunks the targets' walk has reached
+		baseSeen.InsertAll(baseNext)
+		baseLevel := baseNext
+		baseNext = hash.NewHashSet()
+		_, err = walkLevel(ctx, cs, baseLevel, func(c chunks.Chunk) error {
+			return walkAddrs(c, func(h hash.Hash, _ bool) error {
+				if !baseSeen.Has(h) && !bundled.Has(h) {
+					baseNext.Insert(h)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		level := hash.NewHashSet()
+		for h := range next {
+			if !baseSeen.Has(h) {
+				level.Insert(h)
+			}
+		}
+		var commits []hash.Hash
+		next = hash.NewHashSet()
+		missing, err := walkLevel(ctx, cs, level, func(c chunks.Chunk) error {
+			if serial.GetFileID(c.Data()) == serial.CommitFileID {
+				// commits are checked after the level is loaded, since checking them reads more chunks
+				commits = append(commits, c.Hash())
+				return nil
+			}
+			bundled.Insert(c.Hash())
+			return walkAddrs(c, func(h hash.Hash, _ bool) error {
+				if !bundled.Has(h) && !baseSeen.Has(h) {
+					next.Insert(h)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if missing > 0 {
+			return nil, fmt.Errorf("cannot bundle; %d chunks reachable from the bundle's refs are missing from the database", missing)
+		}
+
+		for _, h := range commits {
+			ok, err := inBase(ctx, h)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				continue
+			}
+			bundled.Insert(h)
+			c, err := cs.Get(ctx, h)
+			if err != nil {
+				return nil, err
+			}
+			err = walkAddrs(c, func(h hash.Hash, _ bool) error {
+				if !bundled.Has(h) && !baseSeen.Has(h) {
+					next.Insert(h)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return bundled, nil
+}
+
+// walkLevel calls |cb| with each chunk of |level|, one at a time, and returns the number of chunks that are missing
+// from |cs|.
+func walkLevel(ctx context.Context, cs chunks.ChunkStore, level hash.HashSet, cb func(c chunks.Chunk) error) (int, error) {
+	if len(level) == 0 {
+		return 0, nil
+	}
+	var mu sync.Mutex
+	var cbErr error
+	found := 0
+	err := cs.GetMany(ctx, level, func(ctx context.Context, c *chunks.Chunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		found++
+		if cbErr == nil {
+			cbErr = cb(*c)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	if cbErr != nil {
+		return 0, cbErr
+	}
+	return len(level) - found, nil
+}
+
+// bundleFile is a table file written by the puller while creating a bundle, and the temporary file holding it.
+type bundleFile struct {
+	TableFile
+	path string
+}
+
+// bundleSink is the destination of the puller when creating a bundle. It claims to have every chunk except those
+// found by bundledChunks, so that the puller only sends the chunks the bundle needs, and keeps the table files it
+// receives in temporary files until they're copied into the bundle.
+type bundleSink struct {
+	chunks.ChunkStore
+
+	bundled hash.HashSet
+	tempDir string
+
+	mu    sync.Mutex
+	files []bundleFile
+}
+
+var _ chunks.TableFileStore = (*bundleSink)(nil)
+
+func (s *bundleSink) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	return !s.bundled.Has(h), nil
+}
+
+func (s *bundleSink) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent := hash.NewHashSet()
+	for h := range hashes {
+		if s.bundled.Has(h) {
+			absent.Insert(h)
+		}
+	}
+	return absent, nil
+}
+
+func (s *bundleSink) Sources(ctx context.Context) (chunks.TableFileSources, error) {
+	return chunks.TableFileSources{}, nil
+}
+
+func (s *bundleSink) Size(ctx context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sz uint64
+	for _, f := range s.files {
+		sz += f.Size
+	}
+	return sz, nil
+}
+
+func (s *bundleSink) WriteTableFile(ctx context.Context, fileId string, splitOffset uint64, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) (io.Closer, error) {
+	rd, sz, err := getRd()
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+
+	f, err := os.CreateTemp(s.tempDir, "bundle-")
+	if err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(f, rd)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && uint64(n) != sz {
+		err = fmt.Errorf("table file %s: expected %d bytes, read %d", fileId, sz, n)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files = append(s.files, bundleFile{
+		TableFile: TableFile{
+			ID:          fileId,
+			NumChunks:   numChunks,
+			SplitOffset: splitOffset,
+			ContentHash: contentHash,
+			Size:        sz,
+		},
+		path: f.Name(),
+	})
+	return io.NopCloser(nil), nil
+}
+
+// AddTableFilesToManifest implements chunks.TableFileStore. The table files were already recorded by WriteTableFile.
+func (s *bundleSink) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.InsertAddrsCurry) error {
+	return nil
+}
+
+func (s *bundleSink) PruneTableFiles(ctx context.Context) error {
+	return chunks.ErrUnsupportedOperation
+}
+
+func (s *bundleSink) SupportedOperations(ctx context.Context) (chunks.TableFileStoreOps, error) {
+	return chunks.TableFileStoreOps{CanWrite: true}, nil
+}
+
+func (s *bundleSink) removeFiles() {
+	for _, f := range s.files {
+		os.Remove(f.path)
+	}
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -74,8 +74,12 @@ func (fact AzureDBFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 	}
 
 	bs := blobstore.NewAzureBlobstore(azClient, containerName, blobPrefix)
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	azStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	azStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -0,0 +1,99 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// EncryptionKeyFileParam is a creation parameter naming the keyfile used to encrypt the table files of a blobstore
+// backed remote or backup. These keys are separate from those that encrypt local databases at rest.
+const EncryptionKeyFileParam = "encryption-key-file"
+
+// NewBlobstore returns the blobstore at |urlStr|, for data stored as plain blobs rather than as a database, such as
+// the chunk journals shipped by a sql-server. file:// and localbs:// URLs name a local directory, which is created if
+// it does not exist, and gs:// URLs name a GCS bucket and a path within it.
+func NewBlobstore(ctx context.Context, urlStr string) (blobstore.Blobstore, error) {
+	urlObj, err := earl.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(urlObj.Scheme) {
+	case FileScheme, LocalBSScheme:
+		path, err := localBlobstorePath(urlObj)
+		if err != nil {
+			return nil, err
+		}
+		if err = os.MkdirAll(path, os.ModePerm); err != nil {
+			return nil, err
+		}
+		return blobstore.NewLocalBlobstore(path), nil
+	case GSScheme:
+		gcs, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path), nil
+	default:
+		return nil, fmt.Errorf("unsupported blobstore url scheme: '%s'", urlObj.Scheme)
+	}
+}
+
+func localBlobstorePath(urlObj *url.URL) (string, error) {
+	path, err := url.PathUnescape(urlObj.Path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(urlObj.Host + filepath.FromSlash(path))
+}
+
+// SupportsEncryptionKeyFile returns whether the remotes and backups of |scheme| store their table files in a
+// blobstore, and so can be encrypted with the keys of an EncryptionKeyFileParam. file:// remotes are local databases,
+// which are encrypted with the same keys as any other.
+func SupportsEncryptionKeyFile(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case AWSScheme, GSScheme, AzScheme, OCIScheme, OSSScheme, MemScheme, LocalBSScheme,
+		GitFileScheme, GitHTTPScheme, GitHTTPSScheme, GitSSHScheme:
+		return true
+	default:
+		return false
+	}
+}
+
+// encryptionKeys returns the keys of the keyfile named by the EncryptionKeyFileParam of |params|, or nil if it is
+// not set, in which case the table files of the store are not encrypted.
+func encryptionKeys(params map[string]interface{}) (nbs.KeyProvider, error) {
+	path, ok := params[EncryptionKeyFileParam]
+	if !ok || len(path.(string)) == 0 {
+		return nil, nil
+	}
+	keys, err := nbs.NewKeyfileProvider(path.(string))
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// BundleFactory is a DBFactory implementation for reading bundle files made by `dolt bundle create`, so that they can
+// be cloned and fetched from like any other remote. Bundles are read-only.
+type BundleFactory struct {
+}
+
+// PrepareDB implements DBFactory. Bundles can't be pushed to.
+func (fact BundleFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, u *url.URL, params map[string]interface{}) error {
+	return errors.New("bundles are read-only; use `dolt bundle create` to write one")
+}
+
+// CreateDB opens the bundle file at the URL given as a read-only database
+func (fact BundleFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	path, err := url.PathUnescape(urlObj.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	path = filepath.FromSlash(path)
+	path = urlObj.Host + path
+
+	r, err := bundle.Open(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer r.Close()
+
+	return r.OpenDatabase(ctx)
+}
@@ -59,6 +59,9 @@ const (
 
 	OSSScheme = "oss"
 
+	// BundleScheme is the scheme of bundle files made by `dolt bundle create`
+	BundleScheme = "bundle"
+
 	// Git remote dbfactory schemes (Git remotes as Dolt remotes)
 	GitFileScheme  = "git+file"
 	GitHTTPScheme  = "git+http"
@@ -95,6 +98,7 @@ var DBFactories = map[string]DBFactory{
 	GitHTTPSScheme: GitRemoteFactory{},
 	GitSSHScheme:   GitRemoteFactory{},
 	SSHScheme:      SSHRemoteFactory{},
+	BundleScheme:   BundleFactory{},
 }
 
 // CreateDB creates a database based on the supplied urlStr, and creation params.  The DBFactory used for creation is
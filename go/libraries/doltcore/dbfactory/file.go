@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -76,6 +77,10 @@ const (
 	// soon as a non-blocking fslock call indicates that the LOCK is unavailable. Do not spend a short timeout
 	// waiting for it to become available.
 	SkipJournalLockTimeoutParam = "skip_journal_lock_timeout"
+
+	// JournalLockTimeoutParam is a time.Duration to wait for the exclusive journal manifest lock, instead of Dolt's
+	// internal lock timeout, before failing or falling back to read-only mode.
+	JournalLockTimeoutParam = "journal_lock_timeout"
 )
 
 // DoltDataDir is the directory where noms files will be stored
@@ -225,6 +230,9 @@ func (fact FileFactory) CreateDbNoCache(ctx context.Context, nbf *types.NomsBinF
 			if _, ok := params[SkipJournalLockTimeoutParam]; ok {
 				opts.SkipLockFileTimeout = true
 			}
+			if timeout, ok := params[JournalLockTimeoutParam].(time.Duration); ok {
+				opts.LockTimeout = timeout
+			}
 		}
 		newGenSt, err = nbs.NewLocalJournalingStoreWithOptions(ctx, nbf.VersionString(), path, q, mmapArchiveIndexes, recCb, opts)
 	} else {
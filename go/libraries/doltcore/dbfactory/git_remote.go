@@ -195,13 +195,17 @@ func (fact GitRemoteFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFor
 		return nil, nil, nil, err
 	}
 
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	bsOpts := blobstore.GitBlobstoreOptions{
 		RemoteName:     remoteName,
 		InfoBranch:     blobstore.DefaultInfoBranch,
 		SyncForReadTTL: gitBlobstoreSyncForReadTTLOverride,
 	}
-	nbsCS, err := nbs.NewGitStore(ctx, nbf.VersionString(), cacheRepo, ref, bsOpts, memlimit.MemtableSize(), q)
+	nbsCS, err := nbs.NewGitStore(ctx, nbf.VersionString(), cacheRepo, ref, bsOpts, memlimit.MemtableSize(), q, keys)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -48,8 +48,12 @@ func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, ur
 	}
 
 	bs := blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path)
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	gcsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	gcsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -81,8 +85,12 @@ func (fact LocalBSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 	}
 
 	bs := blobstore.NewLocalBlobstore(absPath)
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	bsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	bsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -42,8 +42,12 @@ func (fact MemFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 	var db datas.Database
 
 	bs := blobstore.NewInMemoryBlobstore(uuid.New().String())
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	cs, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	cs, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -53,9 +53,13 @@ func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 		return nil, nil, nil, err
 	}
 
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
 
-	ociStore, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	ociStore, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 	if err != nil {
 		return nil, nil, nil, err
 	}
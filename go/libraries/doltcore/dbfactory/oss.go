@@ -97,8 +97,12 @@ func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 		return nil, errors.New("failed to initialize oss blob store")
 	}
 
+	keys, err := encryptionKeys(params)
+	if err != nil {
+		return nil, err
+	}
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
+	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q, keys)
 }
 
 func ossConfigFromParams(params map[string]interface{}) ossCredential {
@@ -50,6 +50,14 @@ const (
 	EnvDoltRootPassword              = "DOLT_ROOT_PASSWORD"
 	EnvDoltGCScheduler               = "DOLT_GC_SCHEDULER"
 
+	// Keys for encrypting table files, archives and the chunk journal at rest. EnvEncryptionKeyFile names a file
+	// holding the current key followed by any rotated-out keys, and takes precedence over EnvEncryptionKey.
+	EnvEncryptionKey     = "DOLT_ENCRYPTION_KEY"
+	EnvEncryptionOldKeys = "DOLT_ENCRYPTION_OLD_KEYS"
+	EnvEncryptionKeyFile = "DOLT_ENCRYPTION_KEY_FILE"
+	// If set, unencrypted table files, archives and chunk journals are refused, and are never written.
+	EnvEncryptionRequired = "DOLT_ENCRYPTION_REQUIRED"
+
 	// If set, must be "kill_connections" or "session_aware"
 	// Will go away after session_aware is made default-and-only.
 	EnvGCSafepointControllerChoice = "DOLT_GC_SAFEPOINT_CONTROLLER_CHOICE"
@@ -44,6 +44,7 @@ const (
 	ModeLine    Mode = 1
 	ModeInPlace Mode = 2
 	ModeContext Mode = 3
+	ModeWord    Mode = 4
 )
 
 // SqlRowDiffWriter knows how to write diff rows for a table to an arbitrary format and destination.
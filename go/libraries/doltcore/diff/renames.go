@@ -0,0 +1,410 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// DefaultRenameSimilarity is the similarity, as a percentage, used to detect renames when none is given.
+const DefaultRenameSimilarity = 50
+
+// renameSampleSize is the number of rows compared when estimating how similar the data of two tables or two columns is.
+const renameSampleSize = 1000
+
+// DetectRenames pairs up the tables and columns in |deltas| that were dropped and re-added under another name in a
+// way that did not preserve their tags, such as copying a table or dropping and re-adding a column, and returns the
+// deltas with each such pair reported as a rename.
+//
+// A dropped and an added table are paired when both the share of their columns with the same name and type, and the
+// share of the dropped table's rows whose keys are found in the added table, are at least |similarity| percent. Within
+// a table, a dropped column is paired with an added column of the same type that either has the same name, or holds
+// the same values in at least |similarity| percent of the sampled rows. FromSch of a delta with renamed columns is
+// rewritten to use the tags of the columns they were renamed to, so that code matching columns by tag sees them as the
+// same column. A |similarity| of zero or less disables detection.
+func DetectRenames(ctx context.Context, deltas []TableDelta, similarity int) ([]TableDelta, error) {
+	if similarity <= 0 {
+		return deltas, nil
+	}
+
+	deltas, err := pairRenamedTables(ctx, deltas, similarity)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deltas {
+		if deltas[i].FromTable == nil || deltas[i].ToTable == nil {
+			continue
+		}
+		if err = pairRenamedColumns(ctx, &deltas[i], similarity); err != nil {
+			return nil, err
+		}
+	}
+
+	return deltas, nil
+}
+
+type renameCandidate struct {
+	from, to int
+	score    int
+}
+
+// pairRenames returns the candidates accepted when pairing greedily from the most similar, each side being used once.
+func pairRenames(candidates []renameCandidate) []renameCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	var paired []renameCandidate
+	usedFrom, usedTo := make(map[int]bool), make(map[int]bool)
+	for _, c := range candidates {
+		if usedFrom[c.from] || usedTo[c.to] {
+			continue
+		}
+		usedFrom[c.from], usedTo[c.to] = true, true
+		paired = append(paired, c)
+	}
+	return paired
+}
+
+func pairRenamedTables(ctx context.Context, deltas []TableDelta, similarity int) ([]TableDelta, error) {
+	var dropped, added []int
+	for i, td := range deltas {
+		if td.FromTable != nil && td.ToTable == nil {
+			dropped = append(dropped, i)
+		} else if td.FromTable == nil && td.ToTable != nil {
+			added = append(added, i)
+		}
+	}
+	if len(dropped) == 0 || len(added) == 0 {
+		return deltas, nil
+	}
+
+	var candidates []renameCandidate
+	for _, f := range dropped {
+		for _, t := range added {
+			score, err := tableSimilarity(ctx, deltas[f], deltas[t])
+			if err != nil {
+				return nil, err
+			}
+			if score >= similarity {
+				candidates = append(candidates, renameCandidate{from: f, to: t, score: score})
+			}
+		}
+	}
+
+	renames := pairRenames(candidates)
+	if len(renames) == 0 {
+		return deltas, nil
+	}
+
+	consumed := make(map[int]bool)
+	result := make([]TableDelta, 0, len(deltas)-len(renames))
+	for _, r := range renames {
+		consumed[r.from], consumed[r.to] = true, true
+		from, to := deltas[r.from], deltas[r.to]
+		result = append(result, TableDelta{
+			FromName:         from.FromName,
+			ToName:           to.ToName,
+			FromTable:        from.FromTable,
+			ToTable:          to.ToTable,
+			FromNodeStore:    from.FromNodeStore,
+			ToNodeStore:      to.ToNodeStore,
+			FromVRW:          from.FromVRW,
+			ToVRW:            to.ToVRW,
+			FromSch:          from.FromSch,
+			ToSch:            to.ToSch,
+			FromFks:          from.FromFks,
+			ToFks:            to.ToFks,
+			FromFksParentSch: from.FromFksParentSch,
+			ToFksParentSch:   to.ToFksParentSch,
+		})
+	}
+	for i, td := range deltas {
+		if !consumed[i] {
+			result = append(result, td)
+		}
+	}
+
+	sortTableDeltas(result)
+	return result, nil
+}
+
+// tableSimilarity returns how similar, as a percentage, the table dropped in |from| is to the table added in |to|.
+// Tables whose primary keys have different types are never similar, since their rows cannot be matched.
+func tableSimilarity(ctx context.Context, from, to TableDelta) (int, error) {
+	if !primaryKeyTypesMatch(from.FromSch, to.ToSch) {
+		return 0, nil
+	}
+
+	colScore := columnNameSimilarity(from.FromSch, to.ToSch)
+	if colScore == 0 {
+		return 0, nil
+	}
+
+	fromRows, err := prollyRowData(ctx, from.FromTable)
+	if err != nil {
+		return 0, err
+	}
+	toRows, err := prollyRowData(ctx, to.ToTable)
+	if err != nil {
+		return 0, err
+	}
+
+	rowScore, empty, err := rowKeySimilarity(ctx, fromRows, toRows)
+	if err != nil {
+		return 0, err
+	}
+	if empty {
+		return colScore, nil
+	}
+
+	return min(colScore, rowScore), nil
+}
+
+func prollyRowData(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+func primaryKeyTypesMatch(from, to schema.Schema) bool {
+	if schema.IsKeyless(from) || schema.IsKeyless(to) {
+		return schema.IsKeyless(from) && schema.IsKeyless(to)
+	}
+
+	fromPks, toPks := from.GetPKCols(), to.GetPKCols()
+	if fromPks.Size() != toPks.Size() {
+		return false
+	}
+	for i := 0; i < fromPks.Size(); i++ {
+		if !fromPks.GetByIndex(i).TypeInfo.ToSqlType().Equals(toPks.GetByIndex(i).TypeInfo.ToSqlType()) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnNameSimilarity returns the percentage of the columns of |from| and |to| that have a column of the same name and
+// type in the other schema.
+func columnNameSimilarity(from, to schema.Schema) int {
+	total := from.GetAllCols().Size() + to.GetAllCols().Size()
+	if total == 0 {
+		return 0
+	}
+
+	matches := 0
+	for _, col := range from.GetAllCols().GetColumns() {
+		toCol, ok := to.GetAllCols().GetByNameCaseInsensitive(col.Name)
+		if ok && toCol.TypeInfo.ToSqlType().Equals(col.TypeInfo.ToSqlType()) {
+			matches++
+		}
+	}
+	return 200 * matches / total
+}
+
+// rowKeySimilarity returns the percentage of the sampled keys of |from| that are also keys of |to|, scaled down by the
+// difference in the sizes of the two maps. It returns true if both maps are empty, in which case there is nothing to
+// compare.
+func rowKeySimilarity(ctx context.Context, from, to prolly.Map) (int, bool, error) {
+	fromCount, err := from.Count()
+	if err != nil {
+		return 0, false, err
+	}
+	toCount, err := to.Count()
+	if err != nil {
+		return 0, false, err
+	}
+	if fromCount == 0 && toCount == 0 {
+		return 0, true, nil
+	}
+	if fromCount == 0 || toCount == 0 {
+		return 0, false, nil
+	}
+
+	iter, err := from.IterAll(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	sampled, found := 0, 0
+	for sampled < renameSampleSize {
+		k, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, false, err
+		}
+
+		ok, err := to.Has(ctx, k)
+		if err != nil {
+			return 0, false, err
+		}
+		sampled++
+		if ok {
+			found++
+		}
+	}
+
+	score := found * 100 / sampled
+	return score * min(fromCount, toCount) / max(fromCount, toCount), false, nil
+}
+
+// pairRenamedColumns finds the columns of |td| that were renamed without keeping their tags, records them in
+// RenamedColumns and retags FromSch to match.
+func pairRenamedColumns(ctx context.Context, td *TableDelta, similarity int) error {
+	fromCols, toCols := td.FromSch.GetAllCols(), td.ToSch.GetAllCols()
+
+	var dropped, added []schema.Column
+	for _, col := range fromCols.GetColumns() {
+		if _, ok := toCols.GetByTag(col.Tag); !ok {
+			dropped = append(dropped, col)
+		}
+	}
+	for _, col := range toCols.GetColumns() {
+		if _, ok := fromCols.GetByTag(col.Tag); !ok {
+			added = append(added, col)
+		}
+	}
+	if len(dropped) == 0 || len(added) == 0 {
+		return nil
+	}
+
+	// Column values can only be compared between rows with the same key
+	keyed := !schema.IsKeyless(td.FromSch) && primaryKeyTypesMatch(td.FromSch, td.ToSch)
+	var fromRows, toRows prolly.Map
+	if keyed {
+		var err error
+		if fromRows, err = prollyRowData(ctx, td.FromTable); err != nil {
+			return err
+		}
+		if toRows, err = prollyRowData(ctx, td.ToTable); err != nil {
+			return err
+		}
+	}
+
+	var candidates []renameCandidate
+	for i, f := range dropped {
+		for j, t := range added {
+			if f.IsPartOfPK != t.IsPartOfPK || f.Virtual != t.Virtual || !f.TypeInfo.ToSqlType().Equals(t.TypeInfo.ToSqlType()) {
+				continue
+			}
+
+			score := 0
+			if strings.EqualFold(f.Name, t.Name) {
+				score = 100
+			} else if keyed && !f.IsPartOfPK && !f.Virtual {
+				var err error
+				score, err = columnValueSimilarity(ctx, td, fromRows, toRows, f, t)
+				if err != nil {
+					return err
+				}
+			}
+
+			if score >= similarity {
+				candidates = append(candidates, renameCandidate{from: i, to: j, score: score})
+			}
+		}
+	}
+
+	renames := pairRenames(candidates)
+	if len(renames) == 0 {
+		return nil
+	}
+
+	tags := make(map[uint64]uint64, len(renames))
+	for _, r := range renames {
+		tags[dropped[r.from].Tag] = added[r.to].Tag
+	}
+
+	fromSch, err := schema.RetagColumns(td.FromSch, tags)
+	if err != nil {
+		return err
+	}
+	td.FromSch = fromSch
+	td.RenamedColumns = tags
+	return nil
+}
+
+// columnValueSimilarity returns the percentage of the sampled rows present in both |fromRows| and |toRows| in which
+// column |f| of the former holds the same value as column |t| of the latter. Rows where |f| is NULL are not counted.
+func columnValueSimilarity(ctx context.Context, td *TableDelta, fromRows, toRows prolly.Map, f, t schema.Column) (int, error) {
+	fromIdx, ok := td.FromSch.GetNonPKCols().StoredIndexByTag(f.Tag)
+	if !ok {
+		return 0, nil
+	}
+	toIdx, ok := td.ToSch.GetNonPKCols().StoredIndexByTag(t.Tag)
+	if !ok {
+		return 0, nil
+	}
+
+	iter, err := fromRows.IterAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sampled, matched := 0, 0
+	for scanned := 0; sampled < renameSampleSize && scanned < 4*renameSampleSize; scanned++ {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+
+		fromVal := v.GetField(fromIdx)
+		if fromVal == nil {
+			continue
+		}
+
+		var toVal []byte
+		found := false
+		err = toRows.Get(ctx, k, func(_, tv val.Tuple) error {
+			if tv != nil {
+				found = true
+				toVal = tv.GetField(toIdx)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			continue
+		}
+
+		sampled++
+		if bytes.Equal(fromVal, toVal) {
+			matched++
+		}
+	}
+
+	if sampled == 0 {
+		return 0, nil
+	}
+	return matched * 100 / sampled, nil
+}
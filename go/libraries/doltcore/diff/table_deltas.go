@@ -78,6 +78,9 @@ type TableDelta struct {
 	ToFks            []doltdb.ForeignKey
 	ToFksParentSch   map[doltdb.TableName]schema.Schema
 	FromFksParentSch map[doltdb.TableName]schema.Schema
+	// RenamedColumns maps the original tags of columns found by DetectRenames to have been renamed to the tags of the
+	// columns they were renamed to. FromSch already uses the new tags.
+	RenamedColumns map[uint64]uint64
 }
 
 type TableDeltaSummary struct {
@@ -262,14 +265,18 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot doltdb.RootValue) (del
 	}
 
 	// Make sure we always return the same order of deltas
+	sortTableDeltas(deltas)
+
+	return deltas, nil
+}
+
+func sortTableDeltas(deltas []TableDelta) {
 	sort.Slice(deltas, func(i, j int) bool {
 		if deltas[i].FromName == deltas[j].FromName {
 			return deltas[i].ToName.Less(deltas[j].ToName)
 		}
 		return deltas[i].FromName.Less(deltas[j].FromName)
 	})
-
-	return deltas, nil
 }
 
 func getFkParentSchs(ctx context.Context, root doltdb.RootValue, fks ...doltdb.ForeignKey) (map[doltdb.TableName]schema.Schema, error) {
@@ -0,0 +1,227 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// TextGranularity is the unit that a text diff is computed in.
+type TextGranularity int
+
+const (
+	// WordGranularity diffs text as runs of letters and digits, runs of whitespace and single punctuation characters.
+	WordGranularity TextGranularity = iota
+	// CharGranularity diffs text character by character.
+	CharGranularity
+)
+
+// TextSpan is a span of text in the diff of two strings. Positions are 0-based character offsets into the old and new
+// strings at which the span starts.
+type TextSpan struct {
+	// Type is None for text in both strings, Removed for text only in the old string and Added for text only in the
+	// new string.
+	Type    ChangeType
+	Text    string
+	FromPos int
+	ToPos   int
+}
+
+// TextChange is a changed range in the diff of two strings: the characters [FromPos, FromPos+FromLen) of the old
+// string were replaced by the characters [ToPos, ToPos+ToLen) of the new string. Positions are 0-based.
+type TextChange struct {
+	FromPos, FromLen int
+	ToPos, ToLen     int
+	From, To         string
+}
+
+// ChangeType returns Added for a change that only inserts text, Removed for one that only deletes text and
+// ModifiedNew for one that replaces text.
+func (c TextChange) ChangeType() ChangeType {
+	switch {
+	case c.FromLen == 0:
+		return Added
+	case c.ToLen == 0:
+		return Removed
+	default:
+		return ModifiedNew
+	}
+}
+
+// TextDiff returns the spans of the diff of |from| and |to| at the given granularity, in order. Removed text is
+// placed before the text that replaces it.
+func TextDiff(from, to string, granularity TextGranularity) []TextSpan {
+	if from == to {
+		if from == "" {
+			return nil
+		}
+		return []TextSpan{{Type: None, Text: from}}
+	}
+
+	dmp := diffmatchpatch.New()
+	var diffs []diffmatchpatch.Diff
+	if granularity == WordGranularity {
+		tokens := newTokenTable()
+		fromRunes, toRunes := tokens.encode(from), tokens.encode(to)
+		diffs = dmp.DiffMainRunes(fromRunes, toRunes, false)
+		diffs = dmp.DiffCleanupSemantic(diffs)
+		for i := range diffs {
+			diffs[i].Text = tokens.decode(diffs[i].Text)
+		}
+	} else {
+		diffs = dmp.DiffMain(from, to, false)
+		diffs = dmp.DiffCleanupSemantic(diffs)
+	}
+
+	spans := make([]TextSpan, 0, len(diffs))
+	var fromPos, toPos int
+	for _, d := range diffs {
+		if d.Text == "" {
+			continue
+		}
+		span := TextSpan{Text: d.Text, FromPos: fromPos, ToPos: toPos}
+		n := utf8.RuneCountInString(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			span.Type = None
+			fromPos += n
+			toPos += n
+		case diffmatchpatch.DiffDelete:
+			span.Type = Removed
+			fromPos += n
+		case diffmatchpatch.DiffInsert:
+			span.Type = Added
+			toPos += n
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// TextChanges groups the spans of a text diff into changed ranges, joining adjacent removed and added spans into a
+// single change.
+func TextChanges(spans []TextSpan) []TextChange {
+	var changes []TextChange
+	var cur *TextChange
+	for _, span := range spans {
+		if span.Type == None {
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			changes = append(changes, TextChange{FromPos: span.FromPos, ToPos: span.ToPos})
+			cur = &changes[len(changes)-1]
+		}
+		n := utf8.RuneCountInString(span.Text)
+		if span.Type == Removed {
+			cur.From += span.Text
+			cur.FromLen += n
+		} else {
+			cur.To += span.Text
+			cur.ToLen += n
+		}
+	}
+	return changes
+}
+
+// tokenTable assigns each distinct word token of a text diff a rune, so that the diff can be computed over tokens
+// rather than characters.
+type tokenTable struct {
+	tokens []string
+	ids    map[string]rune
+}
+
+func newTokenTable() *tokenTable {
+	return &tokenTable{ids: make(map[string]rune)}
+}
+
+// encode returns |s| as a sequence of token runes.
+func (t *tokenTable) encode(s string) []rune {
+	var runes []rune
+	for _, tok := range splitWords(s) {
+		r, ok := t.ids[tok]
+		if !ok {
+			r = tokenRune(len(t.tokens))
+			t.ids[tok] = r
+			t.tokens = append(t.tokens, tok)
+		}
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// decode returns the text of the token runes in |s|.
+func (t *tokenTable) decode(s string) string {
+	var text []byte
+	for _, r := range s {
+		text = append(text, t.tokens[tokenIndex(r)]...)
+	}
+	return string(text)
+}
+
+// tokenRune returns the rune for the token at index |i|, skipping the surrogate range, which cannot be encoded in a
+// string.
+func tokenRune(i int) rune {
+	if i < 0xD800 {
+		return rune(i)
+	}
+	return rune(i + 0x800)
+}
+
+func tokenIndex(r rune) int {
+	if r < 0xD800 {
+		return int(r)
+	}
+	return int(r) - 0x800
+}
+
+// splitWords splits |s| into runs of letters and digits, runs of whitespace, and single other characters.
+func splitWords(s string) []string {
+	var words []string
+	start := 0
+	prev := -1
+	for i, r := range s {
+		class := wordClass(r)
+		if i > 0 && (class != prev || class == otherClass) {
+			words = append(words, s[start:i])
+			start = i
+		}
+		prev = class
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+const (
+	wordCharClass = iota
+	spaceClass
+	otherClass
+)
+
+func wordClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return wordCharClass
+	case unicode.IsSpace(r):
+		return spaceClass
+	default:
+		return otherClass
+	}
+}
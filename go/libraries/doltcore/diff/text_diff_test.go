@@ -0,0 +1,129 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		from, to    string
+		granularity TextGranularity
+		expected    []TextSpan
+	}{
+		{
+			name:        "equal",
+			from:        "the quick brown fox",
+			to:          "the quick brown fox",
+			granularity: WordGranularity,
+			expected: []TextSpan{
+				{Type: None, Text: "the quick brown fox"},
+			},
+		},
+		{
+			name:        "empty",
+			granularity: WordGranularity,
+		},
+		{
+			name:        "changed word",
+			from:        "the quick brown fox",
+			to:          "the quick red fox",
+			granularity: WordGranularity,
+			expected: []TextSpan{
+				{Type: None, Text: "the quick "},
+				{Type: Removed, Text: "brown", FromPos: 10, ToPos: 10},
+				{Type: Added, Text: "red", FromPos: 15, ToPos: 10},
+				{Type: None, Text: " fox", FromPos: 15, ToPos: 13},
+			},
+		},
+		{
+			name:        "word granularity does not split words",
+			from:        "cat",
+			to:          "cart",
+			granularity: WordGranularity,
+			expected: []TextSpan{
+				{Type: Removed, Text: "cat"},
+				{Type: Added, Text: "cart", FromPos: 3},
+			},
+		},
+		{
+			name:        "added words",
+			from:        "hello world",
+			to:          "hello there, world",
+			granularity: WordGranularity,
+			expected: []TextSpan{
+				{Type: None, Text: "hello "},
+				{Type: Added, Text: "there, ", FromPos: 6, ToPos: 6},
+				{Type: None, Text: "world", FromPos: 6, ToPos: 13},
+			},
+		},
+		{
+			name:        "character granularity",
+			from:        "cat",
+			to:          "cart",
+			granularity: CharGranularity,
+			expected: []TextSpan{
+				{Type: None, Text: "ca"},
+				{Type: Added, Text: "r", FromPos: 2, ToPos: 2},
+				{Type: None, Text: "t", FromPos: 2, ToPos: 3},
+			},
+		},
+		{
+			name:        "positions count characters",
+			from:        "café au lait",
+			to:          "café noir",
+			granularity: WordGranularity,
+			expected: []TextSpan{
+				{Type: None, Text: "café "},
+				{Type: Removed, Text: "au lait", FromPos: 5, ToPos: 5},
+				{Type: Added, Text: "noir", FromPos: 12, ToPos: 5},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TextDiff(test.from, test.to, test.granularity))
+		})
+	}
+}
+
+func TestTextChanges(t *testing.T) {
+	spans := TextDiff("one two three four", "one 2 three four five", WordGranularity)
+	changes := TextChanges(spans)
+	assert.Equal(t, []TextChange{
+		{FromPos: 4, FromLen: 3, ToPos: 4, ToLen: 1, From: "two", To: "2"},
+		{FromPos: 18, FromLen: 0, ToPos: 16, ToLen: 5, From: "", To: " five"},
+	}, changes)
+	assert.Equal(t, ModifiedNew, changes[0].ChangeType())
+	assert.Equal(t, Added, changes[1].ChangeType())
+}
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"Hello", ",", "  ", "world", "!", "!", "\n", "x_1"}, splitWords("Hello,  world!!\nx_1"))
+	assert.Nil(t, splitWords(""))
+}
+
+func TestTokenRune(t *testing.T) {
+	for _, i := range []int{0, 1, 0xD7FF, 0xD800, 0xE000, 0x10000} {
+		r := tokenRune(i)
+		assert.True(t, r < 0xD800 || r > 0xDFFF)
+		assert.Equal(t, i, tokenIndex(r))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// ciRunsTupleKey is the tuple key under which the history of dolt ci workflow runs is stored. The history is
+	// not versioned, so recording a run does not create a commit or change any working set.
+	ciRunsTupleKey = "dolt_ci_runs"
+
+	// MaxCIRuns is the number of workflow runs kept in the history of a database. Older runs are discarded as new
+	// runs are recorded.
+	MaxCIRuns = 500
+)
+
+const (
+	CIRunStatusSuccess = "success"
+	CIRunStatusFailure = "failure"
+	CIRunStatusError   = "error"
+)
+
+// CIRun is a single execution of a dolt ci workflow.
+type CIRun struct {
+	Id       string `json:"id"`
+	Workflow string `json:"workflow"`
+	// Event is the kind of trigger that started the run, e.g. "push" or "schedule".
+	Event string `json:"event"`
+	// Branch and Commit identify the revision the workflow was run against.
+	Branch string `json:"branch"`
+	Commit string `json:"commit"`
+	Status string `json:"status"`
+	// Error is set when the run could not be executed at all, as opposed to one of its steps failing.
+	Error      string      `json:"error,omitempty"`
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt time.Time   `json:"finished_at"`
+	Steps      []CIRunStep `json:"steps"`
+}
+
+// CIRunStep is the outcome of a single step of a CIRun.
+type CIRunStep struct {
+	Job     string `json:"job"`
+	Step    string `json:"step"`
+	Status  string `json:"status"`
+	Failure string `json:"failure,omitempty"`
+}
+
+// GetCIRuns returns the recorded dolt ci workflow runs of this database, oldest first.
+func (ddb *DoltDB) GetCIRuns(ctx context.Context) ([]CIRun, error) {
+	data, ok, err := ddb.GetTuple(ctx, ciRunsTupleKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	var runs []CIRun
+	err = json.Unmarshal(data, &runs)
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// AddCIRun appends |run| to the workflow run history of this database, discarding the oldest runs once more
+// than MaxCIRuns are stored. Callers are responsible for serializing concurrent calls.
+func (ddb *DoltDB) AddCIRun(ctx context.Context, run CIRun) error {
+	runs, err := ddb.GetCIRuns(ctx)
+	if err != nil {
+		return err
+	}
+
+	runs = append(runs, run)
+	if len(runs) > MaxCIRuns {
+		runs = runs[len(runs)-MaxCIRuns:]
+	}
+
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, ciRunsTupleKey, data)
+}
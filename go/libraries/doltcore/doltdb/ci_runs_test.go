@@ -0,0 +1,56 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCIRuns(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_DOLT, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	runs, err := ddb.GetCIRuns(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	for i := 0; i < MaxCIRuns+2; i++ {
+		err = ddb.AddCIRun(ctx, CIRun{
+			Id:       fmt.Sprintf("run-%d", i),
+			Workflow: "wf",
+			Status:   CIRunStatusSuccess,
+			Steps:    []CIRunStep{{Job: "job", Step: "step", Status: CIRunStatusSuccess}},
+		})
+		require.NoError(t, err)
+	}
+
+	runs, err = ddb.GetCIRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, runs, MaxCIRuns)
+	assert.Equal(t, "run-2", runs[0].Id)
+	assert.Equal(t, fmt.Sprintf("run-%d", MaxCIRuns+1), runs[len(runs)-1].Id)
+	assert.Equal(t, []CIRunStep{{Job: "job", Step: "step", Status: CIRunStatusSuccess}}, runs[0].Steps)
+}
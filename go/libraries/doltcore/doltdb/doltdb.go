@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -137,6 +138,11 @@ type DoltDB struct {
 
 	// Keep a LRU Cache of materialized commits to speed up future commit resolutions
 	commitCache *lru.Cache[hash.Hash, *OptionalCommit]
+
+	// sparse holds the tables whose data is pulled into a sparse clone, or nil. See SetSparseTables.
+	sparse atomic.Pointer[sparseTables]
+	// lazyFetch is set when missing chunks are fetched on read. See SetLazyFetchSource.
+	lazyFetch atomic.Bool
 }
 
 // IsWorkingSetRef reports whether |ref| identifies the working set or staging area rather than a commit.
@@ -350,6 +356,9 @@ func (ddb *DoltDB) WriteEmptyRepoWithCommitMetaGeneratorAndDefaultBranch(
 }
 
 func (ddb *DoltDB) Close() error {
+	if _, ok := sparseClones.LoadAndDelete(ddb.vrw); ok {
+		sparseCloneCount.Add(-1)
+	}
 	return ddb.db.Close()
 }
 
@@ -1969,6 +1978,10 @@ func (ddb *DoltDB) GC(ctx context.Context, gcConfig chunks.GCConfig, safepointCo
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
 	}
+	if ddb.sparse.Load() != nil {
+		// The walk of a GC would find the data a sparse clone left out to be missing.
+		return ErrSparseCloneGC
+	}
 
 	err := ddb.pruneUnreferencedDatasets(ctx)
 	if err != nil {
@@ -2060,7 +2073,8 @@ func (ddb *DoltDB) PullChunks(
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
 ) error {
-	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes)
+	waf := ddb.walkAddrs(srcDB.Format(), skipHashes)
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, waf)
 }
 
 func pullHash(
@@ -2069,11 +2083,10 @@ func pullHash(
 	targetHashes []hash.Hash,
 	tempDir string,
 	statsCh chan pull.Stats,
-	skipHashes hash.HashSet,
+	waf pull.WalkAddrs,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
-	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
 	srcCanUsePuller, err := datas.CanUsePuller(ctx, srcDB)
 	if err != nil {
@@ -2540,6 +2553,70 @@ func (ddb *DoltDB) IsShallow() bool {
 	return gcs.GhostGen().HasGhosts()
 }
 
+// SetLazyFetchSource makes this database fetch the history skipped by a shallow clone, its ghost commits and the
+// chunks below them, from the database opened by |open| the first time they are read. Fetched chunks are kept in this
+// database, until they are collected by a GC that finds them unreachable.
+func (ddb *DoltDB) SetLazyFetchSource(open func(ctx context.Context) (*DoltDB, error)) error {
+	gcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(*nbs.GenerationalNBS)
+	if !ok {
+		return errors.New("database does not support lazily fetching missing chunks")
+	}
+	ddb.lazyFetch.Store(open != nil)
+	if open == nil {
+		gcs.SetLazyFetchSource(nil, nil)
+		return nil
+	}
+	gcs.SetLazyFetchSource(func(ctx context.Context) (chunks.ChunkStore, error) {
+		src, err := open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return datas.ChunkStoreFromDatabase(src.db), nil
+	}, func(c chunks.Chunk, cb func(hash.Hash) error) error {
+		return types.WalkAddrsFromNomsValue(c, ddb.Format(), cb)
+	})
+	return nil
+}
+
+// Prefetch pulls the chunks reachable from |addrs| from |srcDB|, so that reading them does not fetch them lazily.
+// An address which is already present, because it was read before, has its children pulled instead.
+func (ddb *DoltDB) Prefetch(ctx context.Context, tempDir string, srcDB *DoltDB, addrs []hash.Hash, statsCh chan pull.Stats) error {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	absent, err := cs.HasMany(ctx, hash.NewHashSet(addrs...))
+	if err != nil {
+		return err
+	}
+
+	walkAddrs := types.WalkAddrsForNBF(ddb.Format(), nil)
+	var targets []hash.Hash
+	for _, addr := range addrs {
+		if absent.Has(addr) {
+			targets = append(targets, addr)
+			continue
+		}
+		c, err := cs.Get(ctx, addr)
+		if err != nil {
+			return err
+		}
+		err = walkAddrs(c, func(h hash.Hash, _ bool) error {
+			targets = append(targets, h)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	err = ddb.PullChunks(ctx, tempDir, srcDB, targets, statsCh, nil)
+	if errors.Is(err, pull.ErrDBUpToDate) {
+		return nil
+	}
+	return err
+}
+
 // Purge in-memory read caches associated with this DoltDB. This needs
 // to be done at a specific point during a GC operation to ensure that
 // everything the application layer sees still exists in the database
@@ -0,0 +1,237 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Notes maps the hashes of commits to the notes attached to them. Notes are stored as the value of the commits of a
+// notes ref, so that every change to them is recorded and notes refs can be pushed, pulled and merged like branches,
+// without changing the commits they annotate.
+type Notes map[string]string
+
+// Sorted returns the hashes of the annotated commits in order.
+func (n Notes) Sorted() []string {
+	hashes := make([]string, 0, len(n))
+	for h := range n {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// NotesMergeStrategy determines how a note changed on both sides of a notes merge is resolved.
+type NotesMergeStrategy string
+
+const (
+	// NotesMergeUnion concatenates the two versions of the note, ours first.
+	NotesMergeUnion NotesMergeStrategy = "union"
+	// NotesMergeOurs keeps our version of the note.
+	NotesMergeOurs NotesMergeStrategy = "ours"
+	// NotesMergeTheirs takes their version of the note.
+	NotesMergeTheirs NotesMergeStrategy = "theirs"
+)
+
+// ParseNotesMergeStrategy returns the NotesMergeStrategy named by |s|.
+func ParseNotesMergeStrategy(s string) (NotesMergeStrategy, error) {
+	switch strategy := NotesMergeStrategy(s); strategy {
+	case NotesMergeUnion, NotesMergeOurs, NotesMergeTheirs:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown notes merge strategy '%s'", s)
+	}
+}
+
+var notesRefFilter = map[ref.RefType]struct{}{ref.NotesRefType: {}}
+
+// GetNotesRefs returns a list of all notes refs in the database.
+func (ddb *DoltDB) GetNotesRefs(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, notesRefFilter)
+}
+
+// ResolveNotes returns the notes of the notes ref given and the commit it points to. If the notes ref doesn't exist,
+// the notes are empty and the commit is nil.
+func (ddb *DoltDB) ResolveNotes(ctx context.Context, notesRef ref.NotesRef) (Notes, *Commit, error) {
+	cm, err := ddb.ResolveCommitRef(ctx, notesRef)
+	if errors.Is(err, ErrBranchNotFound) {
+		return Notes{}, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	notes, err := ddb.ReadNotes(ctx, cm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return notes, cm, nil
+}
+
+// ReadNotes returns the notes stored in |cm|, which must be a commit of a notes ref in this database.
+func (ddb *DoltDB) ReadNotes(ctx context.Context, cm *Commit) (Notes, error) {
+	addr, err := datas.GetCommitRootHash(cm.Value())
+	if err != nil {
+		return nil, err
+	}
+	val, err := ddb.vrw.MustReadValue(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, ok := val.(types.SerialMessage)
+	if !ok || serial.GetFileID(msg) != serial.TupleFileID {
+		return nil, fmt.Errorf("commit %s is not a notes commit", addr.String())
+	}
+	tup, err := serial.TryGetRootAsTuple(msg, serial.MessagePrefixSz)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := Notes{}
+	if err = json.Unmarshal(tup.ValueBytes(), &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// CommitNotes records |notes| as the new value of the notes ref given, with |parents| as the parents of the new
+// commit. If the notes ref exists, its current commit must be one of |parents|; no parents means the current commit.
+func (ddb *DoltDB) CommitNotes(ctx context.Context, notesRef ref.NotesRef, notes Notes, parents []*Commit, meta *datas.CommitMeta) (*Commit, error) {
+	data, err := json.Marshal(notes)
+	if err != nil {
+		return nil, err
+	}
+
+	parentHashes := make([]hash.Hash, len(parents))
+	for i, p := range parents {
+		parentHashes[i], err = p.HashOf()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	val := types.SerialMessage(datas.Tuple_flatbuffer(data))
+	return ddb.CommitValue(ctx, notesRef, val, datas.CommitOptions{Parents: parentHashes, Meta: meta})
+}
+
+// MergeNotes merges two versions of a set of notes, |ours| and |theirs|, which both derive from |base|. A note which
+// was changed or removed on only one side takes that side's version. A note changed differently on both sides is
+// resolved according to |strategy|.
+func MergeNotes(base, ours, theirs Notes, strategy NotesMergeStrategy) Notes {
+	merged := Notes{}
+	visit := func(h string) {
+		if _, ok := merged[h]; ok {
+			return
+		}
+		b, inBase := base[h]
+		o, inOurs := ours[h]
+		t, inTheirs := theirs[h]
+
+		var note string
+		var keep bool
+		switch {
+		case inOurs == inTheirs && o == t:
+			note, keep = o, inOurs
+		case inBase == inOurs && b == o:
+			note, keep = t, inTheirs
+		case inBase == inTheirs && b == t:
+			note, keep = o, inOurs
+		case strategy == NotesMergeOurs:
+			note, keep = o, inOurs
+		case strategy == NotesMergeTheirs:
+			note, keep = t, inTheirs
+		case !inOurs:
+			note, keep = t, true
+		case !inTheirs:
+			note, keep = o, true
+		default:
+			note, keep = o+"\n\n"+t, true
+		}
+		if keep {
+			merged[h] = note
+		}
+	}
+
+	for h := range ours {
+		visit(h)
+	}
+	for h := range theirs {
+		visit(h)
+	}
+	return merged
+}
+
+// MergeNotesCommit merges the notes of |theirs|, a notes commit in this database, into the notes ref given. The notes
+// ref is fast-forwarded when possible, and otherwise gets a merge commit with |meta|. Returns the new commit of the
+// notes ref.
+func (ddb *DoltDB) MergeNotesCommit(ctx context.Context, notesRef ref.NotesRef, theirs *Commit, strategy NotesMergeStrategy, meta *datas.CommitMeta) (*Commit, error) {
+	oursNotes, ours, err := ddb.ResolveNotes(ctx, notesRef)
+	if err != nil {
+		return nil, err
+	}
+	if ours == nil {
+		return theirs, ddb.SetHeadToCommit(ctx, notesRef, theirs)
+	}
+
+	oursHash, err := ours.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	theirsHash, err := theirs.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	baseNotes := Notes{}
+	optAnc, err := GetCommitAncestor(ctx, ours, theirs)
+	if err != nil && !errors.Is(err, ErrNoCommonAncestor) {
+		return nil, err
+	} else if err == nil {
+		anc, ok := optAnc.ToCommit()
+		if !ok {
+			return nil, ErrGhostCommitEncountered
+		}
+		ancHash, err := anc.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		switch ancHash {
+		case theirsHash:
+			return ours, nil
+		case oursHash:
+			return theirs, ddb.FastForward(ctx, notesRef, theirs)
+		}
+		if baseNotes, err = ddb.ReadNotes(ctx, anc); err != nil {
+			return nil, err
+		}
+	}
+
+	theirsNotes, err := ddb.ReadNotes(ctx, theirs)
+	if err != nil {
+		return nil, err
+	}
+	merged := MergeNotes(baseNotes, oursNotes, theirsNotes, strategy)
+	return ddb.CommitNotes(ctx, notesRef, merged, []*Commit{ours, theirs}, meta)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestNotes(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_DOLT, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "notes")
+	require.NoError(t, err)
+	ours, theirs := ref.NewNotesRef(ref.DefaultNotesRefName), ref.NewNotesRef("refs/notes/theirs")
+
+	notes, head, err := ddb.ResolveNotes(ctx, ours)
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+	assert.Nil(t, head)
+
+	base, err := ddb.CommitNotes(ctx, ours, Notes{"a": "base"}, nil, meta)
+	require.NoError(t, err)
+	require.NoError(t, ddb.SetHeadToCommit(ctx, theirs, base))
+
+	_, err = ddb.CommitNotes(ctx, ours, Notes{"a": "base", "b": "ours"}, []*Commit{base}, meta)
+	require.NoError(t, err)
+	theirsHead, err := ddb.CommitNotes(ctx, theirs, Notes{"a": "theirs"}, nil, meta)
+	require.NoError(t, err)
+
+	// the notes ref has moved on from |base|, so it can't be a commit's only parent
+	_, err = ddb.CommitNotes(ctx, ours, Notes{}, []*Commit{base}, meta)
+	require.ErrorIs(t, err, datas.ErrMergeNeeded)
+
+	merged, err := ddb.MergeNotesCommit(ctx, ours, theirsHead, NotesMergeUnion, meta)
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged.NumParents())
+	notes, err = ddb.ReadNotes(ctx, merged)
+	require.NoError(t, err)
+	assert.Equal(t, Notes{"a": "theirs", "b": "ours"}, notes)
+
+	// merging the same commit again changes nothing
+	again, err := ddb.MergeNotesCommit(ctx, ours, theirsHead, NotesMergeUnion, meta)
+	require.NoError(t, err)
+	assert.Equal(t, merged.Value(), again.Value())
+
+	// theirs fast-forwards to the merge
+	ff, err := ddb.MergeNotesCommit(ctx, theirs, merged, NotesMergeUnion, meta)
+	require.NoError(t, err)
+	assert.Equal(t, merged.Value(), ff.Value())
+
+	refs, err := ddb.GetNotesRefs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, refs, 2)
+}
+
+func TestMergeNotes(t *testing.T) {
+	base := Notes{"same": "x", "ours": "x", "theirs": "x", "both": "x", "removed": "x", "removedAndChanged": "x"}
+	ours := Notes{"same": "x", "ours": "o", "theirs": "x", "both": "o", "removedAndChanged": "o", "added": "o"}
+	theirs := Notes{"same": "x", "ours": "x", "theirs": "t", "both": "t", "removed": "x", "added": "t"}
+
+	tests := []struct {
+		strategy NotesMergeStrategy
+		expected Notes
+	}{
+		{
+			strategy: NotesMergeUnion,
+			expected: Notes{"same": "x", "ours": "o", "theirs": "t", "both": "o\n\nt", "removedAndChanged": "o", "added": "o\n\nt"},
+		},
+		{
+			strategy: NotesMergeOurs,
+			expected: Notes{"same": "x", "ours": "o", "theirs": "t", "both": "o", "removedAndChanged": "o", "added": "o"},
+		},
+		{
+			strategy: NotesMergeTheirs,
+			expected: Notes{"same": "x", "ours": "o", "theirs": "t", "both": "t", "added": "t"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.strategy), func(t *testing.T) {
+			assert.Equal(t, test.expected, MergeNotes(base, ours, theirs, test.strategy))
+		})
+	}
+}
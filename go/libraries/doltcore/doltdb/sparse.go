@@ -0,0 +1,367 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrTableNotFetched is returned when reading a table whose data was left out of a sparse clone.
+var ErrTableNotFetched = errors.New("table data was not fetched by this sparse clone")
+
+// ErrNotSparseClone is returned when widening the tables of a database which is not a sparse clone.
+var ErrNotSparseClone = errors.New("database is not a sparse clone")
+
+// ErrSparseCloneGC is returned when garbage collecting a sparse clone, which is not supported.
+var ErrSparseCloneGC = errors.New("garbage collection is not supported in a sparse clone")
+
+// sparseTables holds the tables of a sparse clone, which only pulls the row and index data of some tables.
+type sparseTables struct {
+	patterns []string
+	compiled CompiledTablePatterns
+	// fetched holds the addresses of tables whose data has been found to be present.
+	fetched sync.Map
+}
+
+// sparseClones maps the types.ValueReadWriter of each sparse clone to its DoltDB, so that the tables read from it can
+// be checked for missing data, see checkTableFetched.
+var sparseClones sync.Map
+
+// sparseCloneCount is the number of entries in |sparseClones|, which lets reads skip it when there are none.
+var sparseCloneCount atomic.Int32
+
+func newSparseTables(patterns []string) (*sparseTables, error) {
+	compiled, err := CompileTablePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return &sparseTables{patterns: patterns, compiled: compiled}, nil
+}
+
+// includes returns whether the data of the table named |name| is pulled. System tables are always pulled.
+func (s *sparseTables) includes(name TableName) bool {
+	return IsSystemTable(name) || s.compiled.TableMatchesAny(name.Name)
+}
+
+// SetSparseTables makes this database a sparse clone, which only pulls the row and index data of the tables matching
+// |patterns|. The schemas of all tables and the full commit graph are still pulled. Empty |patterns| make this
+// database pull the data of all tables again.
+func (ddb *DoltDB) SetSparseTables(patterns []string) error {
+	if len(patterns) == 0 {
+		ddb.sparse.Store(nil)
+		if _, ok := sparseClones.LoadAndDelete(ddb.vrw); ok {
+			sparseCloneCount.Add(-1)
+		}
+		return nil
+	}
+	sparse, err := newSparseTables(patterns)
+	if err != nil {
+		return err
+	}
+	ddb.sparse.Store(sparse)
+	if _, loaded := sparseClones.LoadOrStore(ddb.vrw, ddb); !loaded {
+		sparseCloneCount.Add(1)
+	}
+	return nil
+}
+
+// SparseTables returns the table patterns of a sparse clone, or nil if this database pulls the data of all tables.
+func (ddb *DoltDB) SparseTables() []string {
+	if sparse := ddb.sparse.Load(); sparse != nil {
+		return sparse.patterns
+	}
+	return nil
+}
+
+// CheckTableFetched returns ErrTableNotFetched if |tbl| is missing data that a sparse clone did not pull. Databases
+// which are not sparse clones, or which lazily fetch missing chunks, never return an error.
+func (ddb *DoltDB) CheckTableFetched(ctx context.Context, name TableName, tbl *Table) error {
+	fetched, err := ddb.tableFetched(ctx, tbl)
+	if err != nil {
+		return err
+	} else if !fetched {
+		return fmt.Errorf("%w: '%s'; run `dolt fetch --tables %s` to fetch it", ErrTableNotFetched, name, name.Name)
+	}
+	return nil
+}
+
+// checkTableFetched returns ErrTableNotFetched if |tbl| was read from a sparse clone which did not pull its data. It
+// guards the reads of row and index data, which would otherwise find their chunks missing.
+func checkTableFetched(ctx context.Context, tbl *Table) error {
+	if sparseCloneCount.Load() == 0 {
+		return nil
+	}
+	ddb, ok := sparseClones.Load(tbl.ValueReadWriter())
+	if !ok {
+		return nil
+	}
+	fetched, err := ddb.(*DoltDB).tableFetched(ctx, tbl)
+	if err != nil {
+		return err
+	} else if !fetched {
+		return fmt.Errorf("%w; run `dolt fetch --tables` with the name of the table to fetch it", ErrTableNotFetched)
+	}
+	return nil
+}
+
+// tableFetched returns whether the data of |tbl| is present in this database.
+func (ddb *DoltDB) tableFetched(ctx context.Context, tbl *Table) (bool, error) {
+	sparse := ddb.sparse.Load()
+	if sparse == nil || ddb.lazyFetch.Load() {
+		return true, nil
+	}
+	addr, err := tbl.HashOf()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := sparse.fetched.Load(addr); ok {
+		return true, nil
+	}
+
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	c, err := cs.Get(ctx, addr)
+	if err != nil {
+		return false, err
+	}
+	children := hash.NewHashSet()
+	err = types.WalkAddrsForNBF(ddb.Format(), nil)(c, func(h hash.Hash, _ bool) error {
+		children.Insert(h)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	absent, err := cs.HasMany(ctx, children)
+	if err != nil {
+		return false, err
+	}
+	if absent.Size() > 0 {
+		return false, nil
+	}
+
+	sparse.fetched.Store(addr, struct{}{})
+	return true, nil
+}
+
+// WidenSparseTables adds |patterns| to the tables of this sparse clone, and pulls from |srcDB| the data of the tables
+// they newly match at every commit reachable from the local and remote branches.
+func (ddb *DoltDB) WidenSparseTables(ctx context.Context, tempDir string, srcDB *DoltDB, patterns []string, statsCh chan pull.Stats) error {
+	sparse := ddb.sparse.Load()
+	if sparse == nil {
+		return ErrNotSparseClone
+	}
+	widened, err := newSparseTables(append(append([]string{}, sparse.patterns...), patterns...))
+	if err != nil {
+		return err
+	}
+
+	var queue []hash.Hash
+	err = ddb.VisitRefsOfType(ctx, map[ref.RefType]struct{}{ref.BranchRefType: {}, ref.RemoteRefType: {}}, func(_ ref.DoltRef, addr hash.Hash) error {
+		queue = append(queue, addr)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tables := hash.NewHashSet()
+	seen := hash.NewHashSet()
+	for len(queue) > 0 {
+		h := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if seen.Has(h) {
+			continue
+		}
+		seen.Insert(h)
+
+		cm, err := HashToCommit(ctx, ddb.vrw, ddb.ns, h)
+		if errors.Is(err, ErrGhostCommitEncountered) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		names, err := root.GetAllTableNames(ctx, false)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if sparse.includes(name) || !widened.includes(name) {
+				continue
+			}
+			addr, ok, err := root.GetTableHash(ctx, name)
+			if err != nil {
+				return err
+			}
+			if ok {
+				tables.Insert(addr)
+			}
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, parents...)
+	}
+
+	// The table chunks themselves are already present, so their children are pulled instead.
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	walkAddrs := types.WalkAddrsForNBF(ddb.Format(), nil)
+	children := hash.NewHashSet()
+	for addr := range tables {
+		c, err := cs.Get(ctx, addr)
+		if err != nil {
+			return err
+		}
+		err = walkAddrs(c, func(h hash.Hash, _ bool) error {
+			children.Insert(h)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	targets, err := cs.HasMany(ctx, children)
+	if err != nil {
+		return err
+	}
+	if targets.Size() > 0 {
+		err = ddb.PullChunks(ctx, tempDir, srcDB, targets.ToSlice(), statsCh, nil)
+		if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+			return err
+		}
+	}
+
+	ddb.sparse.Store(widened)
+	return nil
+}
+
+// walkAddrs returns the function which finds the children of the chunks pulled into this database. For a sparse
+// clone, it leaves out the children of the tables which are not included, other than their schemas.
+func (ddb *DoltDB) walkAddrs(nbf *types.NomsBinFormat, skipHashes hash.HashSet) pull.WalkAddrs {
+	walk := types.WalkAddrsForNBF(nbf, skipHashes)
+	sparse := ddb.sparse.Load()
+	if sparse == nil {
+		return walk
+	}
+	w := &sparseWalker{
+		walk:      walk,
+		sparse:    sparse,
+		tableMaps: hash.NewHashSet(),
+		included:  hash.NewHashSet(),
+		excluded:  hash.NewHashSet(),
+	}
+	return w.walkAddrs
+}
+
+// sparseWalker finds the children of chunks pulled into a sparse clone. Table chunks are only reachable through the
+// table maps of root values, so it classifies the tables of every root value it walks by name, before the puller
+// walks the tables themselves.
+type sparseWalker struct {
+	walk   pull.WalkAddrs
+	sparse *sparseTables
+
+	mu sync.Mutex
+	// tableMaps holds the addresses of the internal chunks of table maps too large to inline in a root value.
+	tableMaps hash.HashSet
+	included  hash.HashSet
+	excluded  hash.HashSet
+}
+
+func (w *sparseWalker) walkAddrs(c chunks.Chunk, cb func(hash.Hash, bool) error) error {
+	data := c.Data()
+	if len(data) == 0 || types.NomsKind(data[0]) != types.SerialMessageKind {
+		return w.walk(c, cb)
+	}
+
+	switch serial.GetFileID(data) {
+	case serial.RootValueFileID:
+		var msg serial.RootValue
+		err := serial.InitRootValueRoot(&msg, data, serial.MessagePrefixSz)
+		if err != nil {
+			return err
+		}
+		if err = w.classifyTables(msg.TablesBytes()); err != nil {
+			return err
+		}
+	case serial.AddressMapFileID:
+		w.mu.Lock()
+		isTableMap := w.tableMaps.Has(c.Hash())
+		w.mu.Unlock()
+		if isTableMap {
+			if err := w.classifyTables(data); err != nil {
+				return err
+			}
+		}
+	case serial.TableFileID:
+		w.mu.Lock()
+		skipData := w.excluded.Has(c.Hash()) && !w.included.Has(c.Hash())
+		w.mu.Unlock()
+		if skipData {
+			var msg serial.Table
+			err := serial.InitTableRoot(&msg, data, serial.MessagePrefixSz)
+			if err != nil {
+				return err
+			}
+			return cb(hash.New(msg.SchemaBytes()), false)
+		}
+	}
+	return w.walk(c, cb)
+}
+
+// classifyTables records the table addresses in the table map node |msg| as included or excluded by name, and the
+// addresses of its children if it is an internal node.
+func (w *sparseWalker) classifyTables(msg []byte) error {
+	if serial.GetFileID(msg) != serial.AddressMapFileID {
+		return nil
+	}
+	_, keys, values, level, count, err := message.UnpackFields(serial.Message(msg))
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := 0; i < int(count); i++ {
+		addr := hash.New(values.GetItem(i, serial.Message(msg)))
+		if level > 0 {
+			w.tableMaps.Insert(addr)
+			continue
+		}
+		name, ok := decodeTableNameFromSerialization(string(keys.GetItem(i, serial.Message(msg))))
+		if ok && !w.sparse.includes(name) {
+			w.excluded.Insert(addr)
+		} else {
+			w.included.Insert(addr)
+		}
+	}
+	return nil
+}
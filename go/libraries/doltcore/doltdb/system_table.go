@@ -124,6 +124,8 @@ var getWriteableSystemTables = func() []string {
 		SchemasTableName,
 		ProceduresTableName,
 		IgnoreTableName,
+		PoliciesTableName,
+		MergeStrategiesTableName,
 		GetRebaseTableName(),
 		GetQueryCatalogTableName(),
 		GetTestsTableName(),
@@ -160,6 +162,9 @@ func GeneratedSystemTableNames() []string {
 		GetBackupsTableName(),
 		GetStashesTableName(),
 		GetBranchActivityTableName(),
+		GetCIRunsTableName(),
+		GetCIRunStepsTableName(),
+		GetNotesTableName(),
 		// [dtables.StatusTable] now uses [adapters.DoltTableAdapterRegistry] in its constructor for Doltgres.
 		StatusTableName,
 		StatusIgnoredTableName,
@@ -399,6 +404,18 @@ var GetBranchActivityTableName = func() string {
 	return BranchActivityTableName
 }
 
+var GetCIRunsTableName = func() string {
+	return CIRunsTableName
+}
+
+var GetCIRunStepsTableName = func() string {
+	return CIRunStepsTableName
+}
+
+var GetNotesTableName = func() string {
+	return NotesTableName
+}
+
 const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
@@ -448,6 +465,12 @@ const (
 	// IgnoreTableName is the ignore table name
 	IgnoreTableName = "dolt_ignore"
 
+	// PoliciesTableName is the system table name for row filters and column masks applied to reads of user tables
+	PoliciesTableName = "dolt_policies"
+
+	// MergeStrategiesTableName is the system table name for the strategies used to resolve cell conflicts during merges
+	MergeStrategiesTableName = "dolt_merge_strategies"
+
 	// RebaseTableName is the rebase system table name.
 	RebaseTableName = "dolt_rebase"
 
@@ -462,6 +485,15 @@ const (
 
 	// BranchActivityTableName is the branch activity system table name
 	BranchActivityTableName = "dolt_branch_activity"
+
+	// CIRunsTableName is the system table name for the history of dolt ci workflow runs
+	CIRunsTableName = "dolt_ci_runs"
+
+	// CIRunStepsTableName is the system table name for the step results of dolt ci workflow runs
+	CIRunStepsTableName = "dolt_ci_run_steps"
+
+	// NotesTableName is the system table name for the notes attached to commits
+	NotesTableName = "dolt_notes"
 )
 
 // DoltGeneratedTableNames is a list of all the generated dolt system tables that are not specific to a user table.
@@ -313,10 +313,16 @@ func (t *Table) UpdateRows(ctx context.Context, updatedRows durable.Index) (*Tab
 
 // GetRowData retrieves the underlying map which is a map from a primary key to a list of field values.
 func (t *Table) GetRowData(ctx context.Context) (durable.Index, error) {
+	if err := checkTableFetched(ctx, t); err != nil {
+		return nil, err
+	}
 	return t.table.GetTableRows(ctx)
 }
 
 func (t *Table) GetRowDataWithDescriptors(ctx context.Context, kd, vd *val.TupleDesc) (durable.Index, error) {
+	if err := checkTableFetched(ctx, t); err != nil {
+		return nil, err
+	}
 	return t.table.GetTableRowsWithDescriptors(ctx, kd, vd)
 }
 
@@ -331,6 +337,9 @@ func (t *Table) GetRowDataHash(ctx context.Context) (hash.Hash, error) {
 
 // GetIndexSet returns the internal index map which goes from index name to a ref of the row data map.
 func (t *Table) GetIndexSet(ctx context.Context) (durable.IndexSet, error) {
+	if err := checkTableFetched(ctx, t); err != nil {
+		return nil, err
+	}
 	return t.table.GetIndexes(ctx)
 }
 
@@ -126,6 +126,57 @@ func (rs RebaseState) SkipVerification() bool {
 	return rs.skipVerification
 }
 
+// BisectState tracks an in-progress bisect session: the commit known to be bad, the commits known to be good, and the
+// commits that could not be tested. Candidates are computed from these on demand, so nothing else needs to be recorded.
+type BisectState struct {
+	bad     hash.Hash
+	goods   []hash.Hash
+	skipped []hash.Hash
+}
+
+// Bad returns the commit most recently marked bad, or the empty hash if no commit has been marked bad yet.
+func (bs BisectState) Bad() hash.Hash {
+	return bs.bad
+}
+
+// Goods returns the commits that have been marked good.
+func (bs BisectState) Goods() []hash.Hash {
+	return bs.goods
+}
+
+// Skipped returns the commits that have been marked as untestable.
+func (bs BisectState) Skipped() []hash.Hash {
+	return bs.skipped
+}
+
+// WithBad returns a copy of this BisectState with |bad| recorded as the bad commit, replacing any previous one.
+func (bs BisectState) WithBad(bad hash.Hash) *BisectState {
+	bs.bad = bad
+	return &bs
+}
+
+// WithGood returns a copy of this BisectState with |good| added to the good commits.
+func (bs BisectState) WithGood(good hash.Hash) *BisectState {
+	bs.goods = appendUniqueHash(bs.goods, good)
+	return &bs
+}
+
+// WithSkipped returns a copy of this BisectState with |skipped| added to the skipped commits.
+func (bs BisectState) WithSkipped(skipped hash.Hash) *BisectState {
+	bs.skipped = appendUniqueHash(bs.skipped, skipped)
+	return &bs
+}
+
+func appendUniqueHash(hashes []hash.Hash, h hash.Hash) []hash.Hash {
+	for _, existing := range hashes {
+		if existing == h {
+			return hashes
+		}
+	}
+	// copy so that working sets sharing the old slice are not modified
+	return append(append([]hash.Hash(nil), hashes...), h)
+}
+
 type MergeState struct {
 	// the source commit
 	commit *Commit
@@ -308,6 +359,7 @@ type WorkingSet struct {
 	stagedRoot  RootValue
 	mergeState  *MergeState
 	rebaseState *RebaseState
+	bisectState *BisectState
 }
 
 var _ Rootish = &WorkingSet{}
@@ -339,6 +391,11 @@ func (ws WorkingSet) WithRebaseState(rebaseState *RebaseState) *WorkingSet {
 	return &ws
 }
 
+func (ws WorkingSet) WithBisectState(bisectState *BisectState) *WorkingSet {
+	ws.bisectState = bisectState
+	return &ws
+}
+
 func (ws WorkingSet) WithUnmergableTables(tables []TableName) *WorkingSet {
 	ws.mergeState.unmergableTables = tables
 	return &ws
@@ -451,6 +508,11 @@ func (ws WorkingSet) ClearRebase() *WorkingSet {
 	return &ws
 }
 
+func (ws WorkingSet) ClearBisect() *WorkingSet {
+	ws.bisectState = nil
+	return &ws
+}
+
 func (ws *WorkingSet) WorkingRoot() RootValue {
 	return ws.workingRoot
 }
@@ -467,6 +529,14 @@ func (ws *WorkingSet) RebaseState() *RebaseState {
 	return ws.rebaseState
 }
 
+func (ws *WorkingSet) BisectState() *BisectState {
+	return ws.bisectState
+}
+
+func (ws *WorkingSet) BisectActive() bool {
+	return ws.bisectState != nil
+}
+
 func (ws *WorkingSet) MergeActive() bool {
 	return ws.mergeState != nil
 }
@@ -644,6 +714,15 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		}
 	}
 
+	var bisectState *BisectState
+	if dsws.BisectState != nil {
+		bisectState = &BisectState{
+			bad:     dsws.BisectState.BadCommitAddr(),
+			goods:   dsws.BisectState.GoodCommitAddrs(),
+			skipped: dsws.BisectState.SkippedCommitAddrs(),
+		}
+	}
+
 	addr, _ := ds.MaybeHeadAddr()
 
 	return &WorkingSet{
@@ -654,6 +733,7 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		stagedRoot:  stagedRoot,
 		mergeState:  mergeState,
 		rebaseState: rebaseState,
+		bisectState: bisectState,
 	}, nil
 }
 
@@ -757,11 +837,17 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 			ws.rebaseState.lastAttemptedStep, ws.rebaseState.rebasingStarted, ws.rebaseState.skipVerification)
 	}
 
+	var bisectState *datas.BisectState
+	if ws.bisectState != nil {
+		bisectState = datas.NewBisectState(ws.bisectState.bad, ws.bisectState.goods, ws.bisectState.skipped)
+	}
+
 	return &datas.WorkingSetSpec{
 		Meta:        meta,
 		WorkingRoot: workingRoot,
 		StagedRoot:  stagedRoot,
 		MergeState:  mergeState,
 		RebaseState: rebaseState,
+		BisectState: bisectState,
 	}, nil
 }
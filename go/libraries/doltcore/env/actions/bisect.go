@@ -0,0 +1,215 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// BisectStatus describes where a bisect session stands after its most recent step.
+type BisectStatus string
+
+const (
+	// BisectStatusWaiting means a bad commit and at least one good commit must be marked before bisecting can begin.
+	BisectStatusWaiting BisectStatus = "waiting"
+	// BisectStatusBisecting means there is a candidate commit that needs to be tested.
+	BisectStatusBisecting BisectStatus = "bisecting"
+	// BisectStatusFound means the first bad commit has been identified.
+	BisectStatusFound BisectStatus = "found"
+	// BisectStatusSkipped means only skipped commits are left to test, so the first bad commit can only be narrowed
+	// down to a set of possibilities.
+	BisectStatusSkipped BisectStatus = "skipped"
+)
+
+// BisectStep is the result of computing the next step of a bisect session.
+type BisectStep struct {
+	Status BisectStatus
+	// Commit is the commit to test next when bisecting, or the first bad commit once it has been found.
+	Commit hash.Hash
+	// Remaining is the number of untested, unskipped commits that could still be the first bad commit, not counting
+	// Commit itself.
+	Remaining int
+	// Possible lists every commit that could be the first bad commit when Status is BisectStatusSkipped.
+	Possible []hash.Hash
+}
+
+// Message returns a human-readable description of this step.
+func (s BisectStep) Message() string {
+	switch s.Status {
+	case BisectStatusWaiting:
+		return "waiting for both good and bad commits"
+	case BisectStatusFound:
+		return "found the first bad commit"
+	case BisectStatusSkipped:
+		possible := make([]string, len(s.Possible))
+		for i, h := range s.Possible {
+			possible[i] = h.String()
+		}
+		return fmt.Sprintf("there are only skipped commits left to test; the first bad commit could be any of: %s", strings.Join(possible, ", "))
+	default:
+		steps := 0
+		for n := s.Remaining; n > 0; n /= 2 {
+			steps++
+		}
+		return fmt.Sprintf("Bisecting: %d revisions left to test after this (roughly %d steps)", s.Remaining, steps)
+	}
+}
+
+// NextBisectStep computes the next step of the bisect session described by |state|. The commits that could be the
+// first bad commit are those reachable from the bad commit but not from any of the good commits, exactly the commits
+// that `dolt log good..bad` would list. Of these, the candidate chosen for testing is the one that splits them most
+// evenly, so that either answer eliminates as many commits as possible.
+func NextBisectStep(ctx context.Context, ddb *doltdb.DoltDB, state *doltdb.BisectState) (BisectStep, error) {
+	bad := state.Bad()
+	if bad.IsEmpty() || len(state.Goods()) == 0 {
+		return BisectStep{Status: BisectStatusWaiting}, nil
+	}
+	if err := ValidateBisectState(ctx, ddb, state); err != nil {
+		return BisectStep{}, err
+	}
+
+	revs, err := commitwalk.GetDotDotRevisions(ctx, ddb, []hash.Hash{bad}, ddb, state.Goods(), -1)
+	if err != nil {
+		return BisectStep{}, err
+	}
+
+	order := make([]hash.Hash, len(revs))
+	parents := make(map[hash.Hash][]hash.Hash, len(revs))
+	for i, rev := range revs {
+		cm, ok := rev.ToCommit()
+		if !ok {
+			return BisectStep{}, doltdb.ErrGhostCommitEncountered
+		}
+		order[i] = rev.Addr
+		parents[rev.Addr], err = cm.ParentHashes(ctx)
+		if err != nil {
+			return BisectStep{}, err
+		}
+	}
+
+	return chooseBisectStep(bad, order, parents, state.Skipped()), nil
+}
+
+// chooseBisectStep picks the next commit to test from the candidate commits in |order|, which must be sorted newest
+// first. |parents| holds the parents of every candidate; parents that are not candidates themselves are ignored.
+func chooseBisectStep(bad hash.Hash, order []hash.Hash, parents map[hash.Hash][]hash.Hash, skipped []hash.Hash) BisectStep {
+	isSkipped := make(map[hash.Hash]bool, len(skipped))
+	for _, h := range skipped {
+		isSkipped[h] = true
+	}
+
+	var untested []hash.Hash
+	var possible []hash.Hash
+	for _, h := range order {
+		if h == bad {
+			continue
+		}
+		if isSkipped[h] {
+			possible = append(possible, h)
+		} else {
+			untested = append(untested, h)
+		}
+	}
+
+	if len(untested) == 0 {
+		if len(possible) == 0 {
+			return BisectStep{Status: BisectStatusFound, Commit: bad}
+		}
+		return BisectStep{Status: BisectStatusSkipped, Commit: bad, Possible: append(possible, bad)}
+	}
+
+	// If a candidate is good, its ancestors are eliminated; if it is bad, everything else is. The best candidate
+	// balances the two outcomes.
+	var best hash.Hash
+	bestScore := -1
+	for _, h := range untested {
+		ancestors := countCandidateAncestors(h, parents)
+		score := ancestors
+		if rest := len(order) - ancestors; rest < score {
+			score = rest
+		}
+		if score > bestScore {
+			best, bestScore = h, score
+		}
+	}
+
+	return BisectStep{Status: BisectStatusBisecting, Commit: best, Remaining: len(untested) - 1}
+}
+
+// countCandidateAncestors returns the number of candidates reachable from |start|, including |start| itself.
+func countCandidateAncestors(start hash.Hash, parents map[hash.Hash][]hash.Hash) int {
+	seen := map[hash.Hash]bool{start: true}
+	stack := []hash.Hash{start}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range parents[h] {
+			if _, ok := parents[p]; ok && !seen[p] {
+				seen[p] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+	return len(seen)
+}
+
+// ValidateBisectState returns an error if any commit marked good in |state| is the bad commit or one of its
+// descendants, using the same merge base computation as `dolt merge-base`.
+func ValidateBisectState(ctx context.Context, ddb *doltdb.DoltDB, state *doltdb.BisectState) error {
+	bad := state.Bad()
+	if bad.IsEmpty() {
+		return nil
+	}
+	badCommit, err := resolveBisectCommit(ctx, ddb, bad)
+	if err != nil {
+		return err
+	}
+
+	for _, good := range state.Goods() {
+		if good == bad {
+			return fmt.Errorf("error: a commit cannot be marked both good and bad")
+		}
+		goodCommit, err := resolveBisectCommit(ctx, ddb, good)
+		if err != nil {
+			return err
+		}
+		optCmt, err := doltdb.GetCommitAncestor(ctx, goodCommit, badCommit)
+		if err != nil {
+			return err
+		}
+		if optCmt.Addr == bad {
+			return fmt.Errorf("error: the bad commit is an ancestor of a good commit; the bad commit must come after the good commits")
+		}
+	}
+	return nil
+}
+
+func resolveBisectCommit(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (*doltdb.Commit, error) {
+	optCmt, err := ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// linearHistory returns n commits, newest first, where each commit's parent is the next one in the slice. The
+// oldest commit's parent is a commit outside the returned candidates, as a good commit would be.
+func linearHistory(n int) ([]hash.Hash, map[hash.Hash][]hash.Hash) {
+	order := make([]hash.Hash, n)
+	for i := range order {
+		order[i] = hash.Of([]byte{byte(i)})
+	}
+	parents := make(map[hash.Hash][]hash.Hash, n)
+	for i, h := range order {
+		if i+1 < n {
+			parents[h] = []hash.Hash{order[i+1]}
+		} else {
+			parents[h] = []hash.Hash{hash.Of([]byte("good"))}
+		}
+	}
+	return order, parents
+}
+
+func TestChooseBisectStep(t *testing.T) {
+	t.Run("linear history", func(t *testing.T) {
+		order, parents := linearHistory(8)
+		step := chooseBisectStep(order[0], order, parents, nil)
+		assert.Equal(t, BisectStatusBisecting, step.Status)
+		// the commit with four candidate ancestors, including itself, splits the eight candidates in half
+		assert.Equal(t, order[4], step.Commit)
+		assert.Equal(t, 6, step.Remaining)
+	})
+
+	t.Run("skipped candidates are not proposed", func(t *testing.T) {
+		order, parents := linearHistory(8)
+		step := chooseBisectStep(order[0], order, parents, []hash.Hash{order[4]})
+		assert.Equal(t, BisectStatusBisecting, step.Status)
+		assert.NotEqual(t, order[4], step.Commit)
+		assert.Equal(t, 5, step.Remaining)
+	})
+
+	t.Run("merge history", func(t *testing.T) {
+		// bad merges two branches of three commits each, which both start from the good commit
+		bad := hash.Of([]byte("bad"))
+		left, leftParents := linearHistory(3)
+		right := []hash.Hash{hash.Of([]byte("r0")), hash.Of([]byte("r1")), hash.Of([]byte("r2"))}
+		parents := map[hash.Hash][]hash.Hash{bad: {left[0], right[0]}}
+		for h, p := range leftParents {
+			parents[h] = p
+		}
+		parents[right[0]] = []hash.Hash{right[1]}
+		parents[right[1]] = []hash.Hash{right[2]}
+		parents[right[2]] = []hash.Hash{hash.Of([]byte("good"))}
+		order := append(append([]hash.Hash{bad}, left...), right...)
+
+		step := chooseBisectStep(bad, order, parents, nil)
+		assert.Equal(t, BisectStatusBisecting, step.Status)
+		// the tip of either branch has three candidate ancestors, and leaves four commits if it is good
+		assert.Equal(t, left[0], step.Commit)
+		assert.Equal(t, 5, step.Remaining)
+	})
+
+	t.Run("found", func(t *testing.T) {
+		order, parents := linearHistory(1)
+		step := chooseBisectStep(order[0], order, parents, nil)
+		assert.Equal(t, BisectStatusFound, step.Status)
+		assert.Equal(t, order[0], step.Commit)
+	})
+
+	t.Run("only skipped commits left", func(t *testing.T) {
+		order, parents := linearHistory(3)
+		step := chooseBisectStep(order[0], order, parents, []hash.Hash{order[1], order[2]})
+		assert.Equal(t, BisectStatusSkipped, step.Status)
+		assert.ElementsMatch(t, order, step.Possible)
+	})
+}
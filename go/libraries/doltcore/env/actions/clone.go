@@ -35,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -186,8 +187,11 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 
 	var checkedOutCommit *doltdb.Commit
 
-	// Step 1) Pull the remote information we care about to a local disk.
-	if depth <= 0 {
+	// Step 1) Pull the remote information we care about to a local disk. A sparse clone can't copy the remote's table
+	// files wholesale, so it pulls the chunks of the refs instead.
+	if depth <= 0 && dEnv.DoltDB(ctx).SparseTables() != nil {
+		checkedOutCommit, err = sparseClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch, statsCh)
+	} else if depth <= 0 {
 		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
 	} else {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(ctx), srcDB, remoteName, branch, depth, statsCh)
@@ -292,35 +296,76 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 		return nil, err
 	}
 
+	err = setClonedRefs(ctx, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// sparseClone pulls the refs of a clone into a sparse clone, which leaves out the data of the tables it does not
+// include, and keeps the full history of the rest.
+func sparseClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool, statsCh chan pull.Stats) (*doltdb.Commit, error) {
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var toFetch []hash.Hash
+	for _, refHash := range srcRefHashes {
+		switch refHash.Ref.GetType() {
+		case ref.BranchRefType:
+			if !singleBranch || refHash.Ref.GetPath() == branch {
+				toFetch = append(toFetch, refHash.Hash)
+			}
+		case ref.TagRefType:
+			toFetch = append(toFetch, refHash.Hash)
+		}
+	}
+
+	err = dEnv.DoltDB(ctx).PullChunks(ctx, tmpDir, srcDB, toFetch, statsCh, nil)
+	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+		return nil, err
+	}
+
+	err = setClonedRefs(ctx, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+	if err != nil {
+		return nil, err
+	}
+	return dEnv.DoltDB(ctx).ResolveCommitRef(ctx, ref.NewBranchRef(branch))
+}
+
+// setClonedRefs creates the refs of a clone whose chunks have been pulled into |dEnv|.
+func setClonedRefs(ctx context.Context, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) error {
 	// Preserve only branch and tag references from the remote. Branches are translated into remote branches, tags are preserved.
 	for _, refHash := range srcRefHashes {
 		if refHash.Ref.GetType() == ref.BranchRefType {
 			br := refHash.Ref.(ref.BranchRef)
 			if !singleBranch || br.GetPath() == branch {
 				remoteRef := ref.NewRemoteRef(remoteName, br.GetPath())
-				err = dEnv.DoltDB(ctx).SetHead(ctx, remoteRef, refHash.Hash)
+				err := dEnv.DoltDB(ctx).SetHead(ctx, remoteRef, refHash.Hash)
 				if err != nil {
-					return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateRemoteRef, remoteRef.String(), err.Error())
+					return fmt.Errorf("%w: %s; %s", ErrFailedToCreateRemoteRef, remoteRef.String(), err.Error())
 
 				}
 			}
 			if br.GetPath() == branch {
 				// This is the only local branch after the clone is complete.
-				err = dEnv.DoltDB(ctx).SetHead(ctx, br, refHash.Hash)
+				err := dEnv.DoltDB(ctx).SetHead(ctx, br, refHash.Hash)
 				if err != nil {
-					return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateLocalBranch, br.String(), err.Error())
+					return fmt.Errorf("%w: %s; %s", ErrFailedToCreateLocalBranch, br.String(), err.Error())
 				}
 			}
 		} else if refHash.Ref.GetType() == ref.TagRefType {
 			tr := refHash.Ref.(ref.TagRef)
-			err = dEnv.DoltDB(ctx).SetHead(ctx, tr, refHash.Hash)
+			err := dEnv.DoltDB(ctx).SetHead(ctx, tr, refHash.Hash)
 			if err != nil {
-				return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateTagRef, tr.String(), err.Error())
+				return fmt.Errorf("%w: %s; %s", ErrFailedToCreateTagRef, tr.String(), err.Error())
 			}
 		}
 	}
 
-	return cm, nil
+	return nil
 }
 
 // shallowCloneDataPull is a shallow clone specific helper function to pull only the data required to show the given branch
@@ -40,10 +40,17 @@ type CommitStagedProps struct {
 	Amend            bool
 	Force            bool
 	SkipVerification bool
+	// RequiredTestGroups are test groups that must pass for the commit to be made, such as those required by a
+	// protected branch. Unlike the groups in dolt_commit_verification_groups, these are run even when SkipVerification
+	// is set.
+	RequiredTestGroups []string
 	// Author is the identity of the person who wrote the change.
 	Author datas.CommitIdent
 	// Committer is the identity of the person who applied the change.
 	Committer datas.CommitIdent
+	// MergeParents are commits to record as parents of the new commit after HEAD. They are set by octopus merges,
+	// which don't leave a merge in progress in the working set.
+	MergeParents []*doltdb.Commit
 }
 
 const (
@@ -172,13 +179,15 @@ func GetCommitStaged(
 		}
 	}
 
+	var testGroups []string
 	if !props.SkipVerification {
-		testGroups := getCommitRunTestGroups()
-		if len(testGroups) > 0 {
-			err := runCommitVerification(ctx, testGroups)
-			if err != nil {
-				return nil, err
-			}
+		testGroups = getCommitRunTestGroups()
+	}
+	testGroups = append(testGroups, props.RequiredTestGroups...)
+	if len(testGroups) > 0 {
+		err := runCommitVerification(ctx, testGroups)
+		if err != nil {
+			return nil, err
 		}
 	}
 
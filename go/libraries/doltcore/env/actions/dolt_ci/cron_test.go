@@ -0,0 +1,101 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	// 2026-03-02 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 30, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr    string
+		matches []time.Time
+		misses  []time.Time
+	}{
+		{
+			expr:    "* * * * *",
+			matches: []time.Time{at(2, 0, 0), at(7, 23, 59)},
+		},
+		{
+			expr:    "*/15 9-17 * * *",
+			matches: []time.Time{at(2, 9, 0), at(2, 9, 45), at(2, 17, 30)},
+			misses:  []time.Time{at(2, 9, 10), at(2, 8, 45), at(2, 18, 0)},
+		},
+		{
+			expr:    "0 0 * * mon-fri",
+			matches: []time.Time{at(2, 0, 0), at(6, 0, 0)},
+			misses:  []time.Time{at(7, 0, 0), at(8, 0, 0), at(2, 0, 1)},
+		},
+		{
+			expr:    "30 6 1,15 * *",
+			matches: []time.Time{at(1, 6, 30), at(15, 6, 30)},
+			misses:  []time.Time{at(2, 6, 30), at(15, 6, 31)},
+		},
+		{
+			// both day fields restricted: either may match
+			expr:    "0 12 1 * 1",
+			matches: []time.Time{at(1, 12, 0), at(2, 12, 0), at(9, 12, 0)},
+			misses:  []time.Time{at(3, 12, 0)},
+		},
+		{
+			// 7 is Sunday
+			expr:    "5/20 * * MAR 7",
+			matches: []time.Time{at(1, 3, 5), at(8, 3, 25), at(8, 3, 45)},
+			misses:  []time.Time{at(2, 3, 5), at(1, 3, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+			for _, m := range tt.matches {
+				assert.True(t, s.Matches(m), "expected match at %s", m)
+			}
+			for _, m := range tt.misses {
+				assert.False(t, s.Matches(m), "expected no match at %s", m)
+			}
+		})
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * * mon-",
+	} {
+		_, err := ParseCronSchedule(expr)
+		assert.Error(t, err, "expected error for %q", expr)
+	}
+}
@@ -208,10 +208,16 @@ type PullRequest struct {
 
 type WorkflowDispatch struct{}
 
+// Schedule runs a workflow at every minute matched by a five field cron expression, evaluated in UTC.
+type Schedule struct {
+	Cron yaml.Node `yaml:"cron"`
+}
+
 type On struct {
 	Push             *Push             `yaml:"push,omitempty"`
 	PullRequest      *PullRequest      `yaml:"pull_request,omitempty"`
 	WorkflowDispatch *WorkflowDispatch `yaml:"workflow_dispatch,omitempty"`
+	Schedule         []Schedule        `yaml:"schedule,omitempty"`
 }
 
 type WorkflowConfig struct {
@@ -249,7 +255,7 @@ func WorkflowConfigToYaml(workflow *WorkflowConfig) (r io.Reader, err error) {
 }
 
 func ValidateWorkflowConfig(workflow *WorkflowConfig) error {
-	if workflow.On.WorkflowDispatch == nil && workflow.On.Push == nil && workflow.On.PullRequest == nil {
+	if workflow.On.WorkflowDispatch == nil && workflow.On.Push == nil && workflow.On.PullRequest == nil && len(workflow.On.Schedule) == 0 {
 		return fmt.Errorf("invalid config: no event triggers defined for workflow")
 	}
 
@@ -296,6 +302,19 @@ func ValidateWorkflowConfig(workflow *WorkflowConfig) error {
 		}
 	}
 
+	crons := make(map[string]bool)
+	for _, schedule := range workflow.On.Schedule {
+		_, err := ParseCronSchedule(schedule.Cron.Value)
+		if err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+		_, ok := crons[schedule.Cron.Value]
+		if ok {
+			return fmt.Errorf("invalid config: on schedule cron duplicated: %s", schedule.Cron.Value)
+		}
+		crons[schedule.Cron.Value] = true
+	}
+
 	jobs := make(map[string]bool)
 	steps := make(map[string]bool)
 
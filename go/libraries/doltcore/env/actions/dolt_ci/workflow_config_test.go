@@ -195,3 +195,41 @@ jobs:
 	err = ValidateWorkflowConfig(wf)
 	require.NoError(t, err)
 }
+
+func TestParseWorkflowWithSchedule(t *testing.T) {
+	yml := `name: nightly
+on:
+  schedule:
+    - cron: "0 2 * * *"
+    - cron: "30 14 * * mon-fri"
+
+jobs:
+  - name: nightly job
+    steps:
+      - name: run all tests
+        dolt_test_groups:
+          - "*"
+`
+
+	wf, err := ParseWorkflowConfig(strings.NewReader(yml))
+	require.NoError(t, err)
+	require.NotNil(t, wf)
+
+	require.Equal(t, 2, len(wf.On.Schedule))
+	require.Equal(t, "0 2 * * *", wf.On.Schedule[0].Cron.Value)
+	require.Equal(t, "30 14 * * mon-fri", wf.On.Schedule[1].Cron.Value)
+
+	// a schedule on its own is a valid trigger
+	err = ValidateWorkflowConfig(wf)
+	require.NoError(t, err)
+
+	wf.On.Schedule = append(wf.On.Schedule, Schedule{Cron: newScalarDoubleQuotedYamlNode("0 2 * * *")})
+	err = ValidateWorkflowConfig(wf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "on schedule cron duplicated")
+
+	wf.On.Schedule = []Schedule{{Cron: newScalarDoubleQuotedYamlNode("0 25 * * *")}}
+	err = ValidateWorkflowConfig(wf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid cron expression")
+}
@@ -1,4 +1,4 @@
-// Copyright 2025 Dolthub, Inc.
+// Copyright 2026 Dolthub, Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -12,45 +12,47 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package ci
+package dolt_ci
 
 import (
 	"fmt"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/fatih/color"
 	"gopkg.in/yaml.v3"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
-	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 )
 
 // runDoltTestStep evaluates a Dolt Test step per selection rules and requires all selected tests to PASS.
-// It returns a human-readable summary of individual test results and an error aggregating any failures.
-func runDoltTestStep(sqlCtx *sql.Context, queryist cli.Queryist, dt *dolt_ci.DoltTestStep) (string, error) {
-	spec := deriveSelectionSpec(dt)
+// It returns the individual test results and an error aggregating any failures.
+func runDoltTestStep(sqlCtx *sql.Context, queryist cli.Queryist, dt *DoltTestStep) ([]DoltTestResult, error) {
+	sel := NewDoltTestSelection(dt)
 
-	rows, err := resolveDoltTestRows(sqlCtx, queryist, dt, spec)
+	rows, err := resolveDoltTestRows(sqlCtx, queryist, sel)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	return summarizeDoltTestRows(sqlCtx, rows)
 }
 
-type selectionSpec struct {
-	testsProvided  bool
-	groupsProvided bool
-	testsWildcard  bool
-	groupsWildcard bool
+// DoltTestSelection describes the tests selected by a DoltTestStep. A lone "*" in either list selects everything
+// in that dimension.
+type DoltTestSelection struct {
+	Tests          []string
+	Groups         []string
+	TestsWildcard  bool
+	GroupsWildcard bool
 }
 
-func deriveSelectionSpec(dt *dolt_ci.DoltTestStep) selectionSpec {
-	testsProvided := len(dt.Tests) > 0
-	groupsProvided := len(dt.TestGroups) > 0
-	testsWildcard := testsProvided && hasWildcard(dt.Tests)
-	groupsWildcard := groupsProvided && hasWildcard(dt.TestGroups)
-	return selectionSpec{testsProvided, groupsProvided, testsWildcard, groupsWildcard}
+// NewDoltTestSelection returns the DoltTestSelection for |dt|.
+func NewDoltTestSelection(dt *DoltTestStep) DoltTestSelection {
+	return DoltTestSelection{
+		Tests:          nodesToValues(dt.Tests),
+		Groups:         nodesToValues(dt.TestGroups),
+		TestsWildcard:  hasWildcard(dt.Tests),
+		GroupsWildcard: hasWildcard(dt.TestGroups),
+	}
 }
 
 func hasWildcard(nodes []yaml.Node) bool {
@@ -60,45 +62,47 @@ func hasWildcard(nodes []yaml.Node) bool {
 	return false
 }
 
-func resolveDoltTestRows(sqlCtx *sql.Context, queryist cli.Queryist, dt *dolt_ci.DoltTestStep, spec selectionSpec) ([]sql.Row, error) {
+func nodesToValues(nodes []yaml.Node) []string {
+	var vals []string
+	for _, n := range nodes {
+		vals = append(vals, n.Value)
+	}
+	return vals
+}
+
+func resolveDoltTestRows(sqlCtx *sql.Context, queryist cli.Queryist, sel DoltTestSelection) ([]sql.Row, error) {
+	testsProvided := len(sel.Tests) > 0
+	groupsProvided := len(sel.Groups) > 0
 	switch {
-	case !spec.testsProvided && !spec.groupsProvided:
+	case !testsProvided && !groupsProvided:
 		return getAllDoltTestRunRows(sqlCtx, queryist)
 
-	case spec.testsProvided && !spec.groupsProvided:
-		if spec.testsWildcard {
+	case testsProvided && !groupsProvided:
+		if sel.TestsWildcard {
 			return getAllDoltTestRunRows(sqlCtx, queryist)
 		}
-		return collectRowsForSelectors(sqlCtx, queryist, "test", nodesToValues(dt.Tests))
+		return collectRowsForSelectors(sqlCtx, queryist, "test", sel.Tests)
 
-	case spec.groupsProvided && !spec.testsProvided:
-		if spec.groupsWildcard {
+	case groupsProvided && !testsProvided:
+		if sel.GroupsWildcard {
 			return getAllDoltTestRunRows(sqlCtx, queryist)
 		}
-		return collectRowsForSelectors(sqlCtx, queryist, "group", nodesToValues(dt.TestGroups))
+		return collectRowsForSelectors(sqlCtx, queryist, "group", sel.Groups)
 
 	default: // both provided
-		if spec.testsWildcard && !spec.groupsWildcard {
+		if sel.TestsWildcard && !sel.GroupsWildcard {
 			// All tests in specified groups
-			return collectRowsForSelectors(sqlCtx, queryist, "group", nodesToValues(dt.TestGroups))
+			return collectRowsForSelectors(sqlCtx, queryist, "group", sel.Groups)
 		}
-		if spec.groupsWildcard && !spec.testsWildcard {
+		if sel.GroupsWildcard && !sel.TestsWildcard {
 			// Only specified test names across all groups
-			return collectRowsForSelectors(sqlCtx, queryist, "test", nodesToValues(dt.Tests))
+			return collectRowsForSelectors(sqlCtx, queryist, "test", sel.Tests)
 		}
 		// Neither wildcard: intersection
-		return collectIntersectionRows(sqlCtx, queryist, nodesToValues(dt.Tests), nodesToValues(dt.TestGroups))
+		return collectIntersectionRows(sqlCtx, queryist, sel.Tests, sel.Groups)
 	}
 }
 
-func nodesToValues(nodes []yaml.Node) []string {
-	var vals []string
-	for _, n := range nodes {
-		vals = append(vals, n.Value)
-	}
-	return vals
-}
-
 // collectRowsForSelectors fetches rows for each selector using dolt_test_run('<selector>').
 // kind should be "test" or "group" to produce specific error messages if an empty result is somehow returned without error.
 func collectRowsForSelectors(sqlCtx *sql.Context, queryist cli.Queryist, kind string, selectors []string) ([]sql.Row, error) {
@@ -134,7 +138,7 @@ func collectIntersectionRows(sqlCtx *sql.Context, queryist cli.Queryist, testNam
 		}
 		groupTests := make(map[string]bool)
 		for _, r := range rows {
-			tName, err := getStringColAsString(sqlCtx, r[0])
+			tName, err := stringColAsString(sqlCtx, r[0])
 			if err != nil {
 				return nil, err
 			}
@@ -148,7 +152,7 @@ func collectIntersectionRows(sqlCtx *sql.Context, queryist cli.Queryist, testNam
 		}
 		// filter rows to only requested tests
 		for _, r := range rows {
-			tName, err := getStringColAsString(sqlCtx, r[0])
+			tName, err := stringColAsString(sqlCtx, r[0])
 			if err != nil {
 				return nil, err
 			}
@@ -163,70 +167,53 @@ func collectIntersectionRows(sqlCtx *sql.Context, queryist cli.Queryist, testNam
 	return allRows, nil
 }
 
-// summarizeDoltTestRows formats and returns details and an aggregated error if any failures occurred.
-func summarizeDoltTestRows(sqlCtx *sql.Context, rows []sql.Row) (string, error) {
-	details, failures, err := formatDoltTestRows(sqlCtx, rows)
-	if err != nil {
-		return "", err
-	}
-	if len(failures) > 0 {
-		return details, fmt.Errorf("%s", strings.Join(failures, "; "))
-	}
-	return details, nil
-}
-
-// fetchDoltTestRunRows runs dolt_test_run for the provided selector (test or group value)
-func fetchDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist, selector string) ([]sql.Row, error) {
-	q := fmt.Sprintf("SELECT * FROM dolt_test_run('%s')", strings.ReplaceAll(selector, "'", "''"))
-	return cli.GetRowsForSql(queryist, sqlCtx, q)
-}
-
-// getAllDoltTestRunRows runs dolt_test_run() with no arguments to return all rows
-func getAllDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist) ([]sql.Row, error) {
-	return cli.GetRowsForSql(queryist, sqlCtx, "SELECT * FROM dolt_test_run()")
-}
-
-// formatDoltTestRows returns a formatted summary of all tests and a list of failure messages
-func formatDoltTestRows(sqlCtx *sql.Context, rows []sql.Row) (string, []string, error) {
-	var lines []string
+// summarizeDoltTestRows converts dolt_test_run rows to results and returns an aggregated error if any test failed.
+func summarizeDoltTestRows(sqlCtx *sql.Context, rows []sql.Row) ([]DoltTestResult, error) {
+	var results []DoltTestResult
 	var failures []string
 	for _, row := range rows {
-		tName, err := getStringColAsString(sqlCtx, row[0])
+		tName, err := stringColAsString(sqlCtx, row[0])
 		if err != nil {
-			return "", nil, err
+			return nil, err
 		}
-		gName, err := getStringColAsString(sqlCtx, row[1])
+		gName, err := stringColAsString(sqlCtx, row[1])
 		if err != nil {
-			return "", nil, err
+			return nil, err
 		}
-		status, err := getStringColAsString(sqlCtx, row[3])
+		status, err := stringColAsString(sqlCtx, row[3])
 		if err != nil {
-			return "", nil, err
+			return nil, err
 		}
-		message, err := getStringColAsString(sqlCtx, row[4])
+		message, err := stringColAsString(sqlCtx, row[4])
 		if err != nil {
-			return "", nil, err
+			return nil, err
 		}
 		statusUpper := strings.ToUpper(status)
-		var statusColored string
 		switch statusUpper {
 		case "PASS":
-			statusColored = color.GreenString(statusUpper)
 		case "FAIL":
-			statusColored = color.RedString(statusUpper)
-		default:
-			return "", nil, fmt.Errorf("unknown dolt test status %q for test %s (group %s)", statusUpper, tName, gName)
-		}
-		baseLine := fmt.Sprintf("  - test: %s (group: %s) - %s", tName, gName, statusColored)
-		lines = append(lines, baseLine)
-		if statusUpper != "PASS" {
 			if message == "" {
 				message = "failed"
 			}
-			// add separate error line, with error message colored red
-			lines = append(lines, fmt.Sprintf("    - error: %s", color.RedString(message)))
 			failures = append(failures, fmt.Sprintf("%s: %s", tName, message))
+		default:
+			return nil, fmt.Errorf("unknown dolt test status %q for test %s (group %s)", statusUpper, tName, gName)
 		}
+		results = append(results, DoltTestResult{Name: tName, Group: gName, Status: statusUpper, Message: message})
 	}
-	return strings.Join(lines, "\n"), failures, nil
+	if len(failures) > 0 {
+		return results, fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return results, nil
+}
+
+// fetchDoltTestRunRows runs dolt_test_run for the provided selector (test or group value)
+func fetchDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist, selector string) ([]sql.Row, error) {
+	q := fmt.Sprintf("SELECT * FROM dolt_test_run('%s')", strings.ReplaceAll(selector, "'", "''"))
+	return cli.GetRowsForSql(queryist, sqlCtx, q)
+}
+
+// getAllDoltTestRunRows runs dolt_test_run() with no arguments to return all rows
+func getAllDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist) ([]sql.Row, error) {
+	return cli.GetRowsForSql(queryist, sqlCtx, "SELECT * FROM dolt_test_run()")
 }
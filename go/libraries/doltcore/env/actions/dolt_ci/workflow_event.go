@@ -27,6 +27,7 @@ const (
 	WorkflowEventTypePush
 	WorkflowEventTypePullRequest
 	WorkflowEventTypeWorkflowDispatch
+	WorkflowEventTypeSchedule
 )
 
 type WorkflowEventId string
@@ -46,6 +47,8 @@ func ToWorkflowEventType(t int) (WorkflowEventType, error) {
 		return WorkflowEventTypePullRequest, nil
 	case int(WorkflowEventTypeWorkflowDispatch):
 		return WorkflowEventTypeWorkflowDispatch, nil
+	case int(WorkflowEventTypeSchedule):
+		return WorkflowEventTypeSchedule, nil
 	default:
 		return WorkflowEventTypeUnspecified, ErrUnknownWorkflowEventType
 	}
@@ -61,6 +64,8 @@ func WorkflowEventTypeToString(t WorkflowEventType) (string, error) {
 		return "pull request", nil
 	case WorkflowEventTypeWorkflowDispatch:
 		return "workflow dispatch", nil
+	case WorkflowEventTypeSchedule:
+		return "schedule", nil
 	default:
 		return "", ErrUnknownWorkflowEventType
 	}
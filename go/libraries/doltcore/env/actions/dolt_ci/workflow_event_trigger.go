@@ -31,6 +31,9 @@ const (
 	WorkflowEventTriggerTypeActivityClosed
 	WorkflowEventTriggerTypeActivityReopened
 	WorkflowEventTriggerTypeActivitySynchronized
+	// WorkflowEventTriggerTypeSchedule triggers keep their cron expression in the branch column of the
+	// workflow event trigger branches table, so that schedules do not require a new dolt_ci table.
+	WorkflowEventTriggerTypeSchedule
 )
 
 type WorkflowEventTriggerId string
@@ -54,6 +57,8 @@ func ToWorkflowEventTriggerType(t int) (WorkflowEventTriggerType, error) {
 		return WorkflowEventTriggerTypeActivityReopened, nil
 	case int(WorkflowEventTriggerTypeActivitySynchronized):
 		return WorkflowEventTriggerTypeActivitySynchronized, nil
+	case int(WorkflowEventTriggerTypeSchedule):
+		return WorkflowEventTriggerTypeSchedule, nil
 	default:
 		return WorkflowEventTriggerTypeUnspecified, ErrUnknownWorkflowEventTriggerType
 	}
@@ -264,6 +264,11 @@ func (d *doltWorkflowManager) deleteFromWorkflowEventsTableByWorkflowNameQueryWh
 	return mustInterpolate(tmpl, workflowName)
 }
 
+func (d *doltWorkflowManager) deleteFromWorkflowEventsTableByWorkflowNameQueryWhereWorkflowEventTypeIsSchedule(workflowName string) string {
+	tmpl := fmt.Sprintf("delete from %s where `%s` = ? and `%s` = %d;", doltdb.WorkflowEventsTableName, doltdb.WorkflowEventsWorkflowNameFkColName, doltdb.WorkflowEventsEventTypeColName, WorkflowEventTypeSchedule)
+	return mustInterpolate(tmpl, workflowName)
+}
+
 func (d *doltWorkflowManager) deleteFromWorkflowEventTriggersTableByWorkflowEventTriggerIdQuery(triggerID string) string {
 	tmpl := fmt.Sprintf("delete from %s where `%s` = ?;", doltdb.WorkflowEventTriggersTableName, doltdb.WorkflowEventTriggersIdPkColName)
 	return mustInterpolate(tmpl, triggerID)
@@ -1074,6 +1079,18 @@ func (d *doltWorkflowManager) updateExistingWorkflow(ctx *sql.Context, config *W
 		}
 	}
 
+	// schedules are always rewritten, deleting the event cascades to its triggers and their cron expressions
+	err := d.deleteScheduleWorkflowEvents(ctx, WorkflowName(config.Name.Value))
+	if err != nil {
+		return err
+	}
+	if len(config.On.Schedule) > 0 {
+		err = d.writeScheduleWorkflowEvent(ctx, WorkflowName(config.Name.Value), config.On.Schedule)
+		if err != nil {
+			return err
+		}
+	}
+
 	// handle on push
 	if config.On.Push != nil {
 		if len(config.On.Push.Branches) == 0 {
@@ -1796,6 +1813,11 @@ func (d *doltWorkflowManager) deleteWorkflowDispatchWorkflowEvents(ctx *sql.Cont
 	return d.sqlWriteQuery(ctx, query)
 }
 
+func (d *doltWorkflowManager) deleteScheduleWorkflowEvents(ctx *sql.Context, workflowName WorkflowName) error {
+	query := d.deleteFromWorkflowEventsTableByWorkflowNameQueryWhereWorkflowEventTypeIsSchedule(string(workflowName))
+	return d.sqlWriteQuery(ctx, query)
+}
+
 func (d *doltWorkflowManager) deleteWorkflowSavedQueryStepExpectedRowColumnResults(ctx *sql.Context, savedQueryStepID WorkflowSavedQueryStepId) error {
 	query := d.deleteFromSavedQueryStepExpectedRowColumnResultsTableBySavedQueryStepIdQuery(string(savedQueryStepID))
 	return d.sqlWriteQuery(ctx, query)
@@ -1934,6 +1956,27 @@ func (d *doltWorkflowManager) toSavedQueryExpectedResultString(comparisonType Wo
 	return fmt.Sprintf("%s %d", compareStr, count), nil
 }
 
+// writeScheduleWorkflowEvent writes a single schedule event for |workflowName| with a trigger for each of |schedules|.
+// The cron expression of each trigger is stored in the workflow event trigger branches table.
+func (d *doltWorkflowManager) writeScheduleWorkflowEvent(ctx *sql.Context, workflowName WorkflowName, schedules []Schedule) error {
+	eventID, err := d.writeWorkflowEventRow(ctx, workflowName, WorkflowEventTypeSchedule)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		triggerID, err := d.writeWorkflowEventTriggerRow(ctx, eventID, WorkflowEventTriggerTypeSchedule)
+		if err != nil {
+			return err
+		}
+		_, err = d.writeWorkflowEventTriggerBranchesRow(ctx, triggerID, schedule.Cron.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *doltWorkflowManager) createWorkflow(ctx *sql.Context, config *WorkflowConfig) error {
 	workflowName, err := d.writeWorkflowRow(ctx, WorkflowName(config.Name.Value))
 	if err != nil {
@@ -1967,6 +2010,14 @@ func (d *doltWorkflowManager) createWorkflow(ctx *sql.Context, config *WorkflowC
 		}
 	}
 
+	// handle on schedule
+	if len(config.On.Schedule) > 0 {
+		err = d.writeScheduleWorkflowEvent(ctx, workflowName, config.On.Schedule)
+		if err != nil {
+			return err
+		}
+	}
+
 	// insert into triggers
 	// handle push
 	var pushBranchesTriggerEventID WorkflowEventTriggerId
@@ -2157,6 +2208,7 @@ func (d *doltWorkflowManager) getWorkflowConfig(ctx *sql.Context, workflowName s
 
 		activities := make([]yaml.Node, 0)
 		branches := make([]yaml.Node, 0)
+		schedules := make([]Schedule, 0)
 
 		for _, trigger := range triggers {
 			switch trigger.EventTriggerType {
@@ -2177,6 +2229,14 @@ func (d *doltWorkflowManager) getWorkflowConfig(ctx *sql.Context, workflowName s
 					return nil, err
 				}
 				activities = append(activities, newScalarDoubleQuotedYamlNode(activity))
+			case WorkflowEventTriggerTypeSchedule:
+				crons, err := d.listWorkflowEventTriggerBranchesByEventTriggerId(ctx, *trigger.Id)
+				if err != nil {
+					return nil, err
+				}
+				for _, cron := range crons {
+					schedules = append(schedules, Schedule{Cron: newScalarDoubleQuotedYamlNode(cron.Branch)})
+				}
 			case WorkflowEventTriggerTypeUnspecified:
 			default:
 				return nil, fmt.Errorf("unknown trigger type: %d", trigger.EventTriggerType)
@@ -2198,6 +2258,8 @@ func (d *doltWorkflowManager) getWorkflowConfig(ctx *sql.Context, workflowName s
 			}
 		} else if event.EventType == WorkflowEventTypeWorkflowDispatch {
 			on.WorkflowDispatch = &WorkflowDispatch{}
+		} else if event.EventType == WorkflowEventTypeSchedule {
+			on.Schedule = append(on.Schedule, schedules...)
 		}
 	}
 
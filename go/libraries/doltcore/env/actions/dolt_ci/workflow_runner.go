@@ -0,0 +1,219 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// WorkflowRunResult is the outcome of running every job of a workflow.
+type WorkflowRunResult struct {
+	Jobs []WorkflowJobResult
+}
+
+// Failed returns whether any step of any job failed.
+func (r *WorkflowRunResult) Failed() bool {
+	for _, job := range r.Jobs {
+		if job.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowJobResult is the outcome of running every step of a job, in order.
+type WorkflowJobResult struct {
+	Name  string
+	Steps []WorkflowStepResult
+}
+
+// Failed returns whether any step of the job failed.
+func (r *WorkflowJobResult) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowStepResult is the outcome of running a single step.
+type WorkflowStepResult struct {
+	Name string
+	// SavedQueryName and Query are set for saved query steps.
+	SavedQueryName string
+	Query          string
+	// Tests holds the results of the tests selected by a dolt test step.
+	Tests []DoltTestResult
+	// Err is set if the step failed, either because it could not be run or because its assertions did not hold.
+	Err error
+}
+
+// DoltTestResult is the result of a single test run by a dolt test step.
+type DoltTestResult struct {
+	Name    string
+	Group   string
+	Status  string
+	Message string
+}
+
+// QueryChecker returns an error if |query| must not be run as a saved query step.
+type QueryChecker func(sqlCtx *sql.Context, query string) error
+
+// RunWorkflow runs the steps of every job of |config| against the current database of |sqlCtx|. A failing step
+// does not stop the remaining steps from running. An error is returned only if the workflow could not be run at all.
+// If |checkQuery| is not nil, each saved query is passed to it first, and the step fails if it returns an error.
+func RunWorkflow(sqlCtx *sql.Context, queryist cli.Queryist, config *WorkflowConfig, checkQuery QueryChecker) (*WorkflowRunResult, error) {
+	savedQueries, err := GetSavedQueries(sqlCtx, queryist)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkflowRunResult{}
+	for _, job := range config.Jobs {
+		jobResult := WorkflowJobResult{Name: job.Name.Value}
+		for _, step := range job.Steps {
+			jobResult.Steps = append(jobResult.Steps, runWorkflowStep(sqlCtx, queryist, step, savedQueries, checkQuery))
+		}
+		result.Jobs = append(result.Jobs, jobResult)
+	}
+	return result, nil
+}
+
+func runWorkflowStep(sqlCtx *sql.Context, queryist cli.Queryist, step Step, savedQueries map[string]string, checkQuery QueryChecker) WorkflowStepResult {
+	result := WorkflowStepResult{Name: step.GetName()}
+	switch st := step.(type) {
+	case *SavedQueryStep:
+		result.SavedQueryName = st.SavedQueryName.Value
+		result.Query = savedQueries[st.SavedQueryName.Value]
+		rows, err := runSavedQuery(sqlCtx, queryist, st, result.Query, checkQuery)
+		if err == nil {
+			err = assertSavedQueryResults(rows, st.ExpectedRows.Value, st.ExpectedColumns.Value)
+		}
+		result.Err = err
+	case *DoltTestStep:
+		result.Tests, result.Err = runDoltTestStep(sqlCtx, queryist, st)
+	default:
+		result.Err = fmt.Errorf("unsupported step type for step: %s", step.GetName())
+	}
+	return result
+}
+
+func runSavedQuery(sqlCtx *sql.Context, queryist cli.Queryist, step *SavedQueryStep, query string, checkQuery QueryChecker) ([]sql.Row, error) {
+	if query == "" {
+		return nil, fmt.Errorf("Could not find saved query: %s", step.SavedQueryName.Value)
+	}
+	if checkQuery != nil {
+		if err := checkQuery(sqlCtx, query); err != nil {
+			return nil, err
+		}
+	}
+
+	return cli.GetRowsForSql(queryist, sqlCtx, query)
+}
+
+// assertSavedQueryResults takes in the result of a saved query execution, and the unparsed assertions,
+// then returns if the assertions failed
+func assertSavedQueryResults(rows []sql.Row, expectedRowsAndComparison string, expectedColumnsAndComparison string) error {
+	var colCount int64
+	var errs []string
+	rowCount := int64(len(rows))
+	if rowCount > 0 {
+		colCount = int64(len(rows[0]))
+	}
+
+	colCompType, expectedCols, err := ParseSavedQueryExpectedResultString(expectedColumnsAndComparison)
+	if colCompType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+		err = ValidateQueryExpectedRowOrColumnCount(colCount, expectedCols, colCompType, "column")
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Assertion failed: %s", err.Error()))
+		}
+	}
+	rowCompType, expectedRows, err := ParseSavedQueryExpectedResultString(expectedRowsAndComparison)
+	if rowCompType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+		err = ValidateQueryExpectedRowOrColumnCount(rowCount, expectedRows, rowCompType, "row")
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Assertion failed: %s", err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// GetSavedQueries returns the statements of the saved queries in dolt_query_catalog, keyed by name. If the
+// database has no query catalog, an empty map is returned.
+func GetSavedQueries(sqlCtx *sql.Context, queryist cli.Queryist) (map[string]string, error) {
+	savedQueries := make(map[string]string)
+	resetFunc, err := cli.SetSystemVar(queryist, sqlCtx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, rowIter, _, err := queryist.Query(sqlCtx, "SHOW TABLES LIKE 'dolt_query_catalog'")
+	if err != nil {
+		return nil, err
+	}
+	if resetFunc != nil {
+		err = resetFunc()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		rows, err = cli.GetRowsForSql(queryist, sqlCtx, "SELECT * FROM dolt_query_catalog")
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			queryName, err := stringColAsString(sqlCtx, row[2])
+			if err != nil {
+				return nil, err
+			}
+			queryStatement, err := stringColAsString(sqlCtx, row[3])
+			if err != nil {
+				return nil, err
+			}
+			savedQueries[queryName] = queryStatement
+		}
+	}
+	return savedQueries, nil
+}
+
+// The dolt_query_catalog system table returns *val.TextStorage types under certain situations,
+// so we use a special parser to get the correct string values
+func stringColAsString(sqlCtx *sql.Context, tableValue interface{}) (string, error) {
+	if ts, ok := tableValue.(*val.TextStorage); ok {
+		return ts.Unwrap(sqlCtx)
+	} else if str, ok := tableValue.(string); ok {
+		return str, nil
+	} else {
+		return "", fmt.Errorf("unexpected type %T, was expecting string", tableValue)
+	}
+}
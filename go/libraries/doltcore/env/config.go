@@ -96,6 +96,12 @@ func LoadDoltCliConfig(hdp HomeDirProvider, fs filesys.ReadWriteFS) (*DoltCliCon
 	ch := config.NewConfigHierarchy()
 
 	lPath := getLocalConfigPath()
+	if exists, _ := fs.Exists(lPath); !exists {
+		// worktrees use the local config of their repository
+		if doltDir := readWorktreeLink(fs); doltDir != "" {
+			lPath = filepath.Join(doltDir, configFile)
+		}
+	}
 	if exists, _ := fs.Exists(lPath); exists {
 		lCfg, err := config.FromFile(lPath, fs)
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -34,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -96,6 +98,10 @@ type DoltEnv struct {
 	urlStr string
 	hdp    HomeDirProvider
 
+	// worktreeOf is the absolute path of the .dolt directory of the repository this environment is a worktree of, or
+	// empty if it isn't a worktree.
+	worktreeOf string
+
 	// DBLoadParams are optional parameters passed through to doltdb.LoadDoltDBWithParams when this environment
 	// loads its underlying DoltDB. This allows higher layers (e.g. SQL engine / embedded driver) to influence
 	// dbfactory / storage open behavior.
@@ -211,6 +217,7 @@ func LoadWithoutDB(_ context.Context, hdp HomeDirProvider, fs filesys.Filesys, u
 		FS:         fs,
 		hdp:        hdp,
 		urlStr:     urlStr,
+		worktreeOf: readWorktreeLink(fs),
 	}
 }
 
@@ -243,10 +250,35 @@ func LoadDoltDB(ctx context.Context, dEnv *DoltEnv) {
 				params[k] = v
 			}
 		}
-		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_DOLT, dEnv.urlStr, dEnv.FS, params)
+		urlStr := dEnv.urlStr
+		if dEnv.HasWorktrees() && urlStr == doltdb.LocalDirDoltDB {
+			if params == nil {
+				params = make(map[string]interface{})
+			}
+			// Several CLIs may work in the worktrees of a repository at once. Rather than opening the shared chunk
+			// store read-only when another one is using it, wait for it to finish.
+			if _, ok := params[dbfactory.JournalLockTimeoutParam]; !ok {
+				params[dbfactory.JournalLockTimeoutParam] = WorktreeLockTimeout
+			}
+			if dEnv.IsWorktree() {
+				// a worktree opens the database of the repository it was added to
+				urlStr = earl.FileUrlFromPath(filepath.ToSlash(filepath.Join(dEnv.worktreeOf, dbfactory.DataDir)), os.PathSeparator)
+				params[dbfactory.ChunkJournalParam] = struct{}{}
+			}
+		}
+		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_DOLT, urlStr, dEnv.FS, params)
 		dEnv.doltDB = ddb
 		dEnv.DBLoadError = dbLoadErr
 
+		if dbLoadErr == nil {
+			if remoteName := GetStringOrDefault(dEnv.Config, config.LazyFetchRemoteKey, ""); remoteName != "" {
+				dEnv.DBLoadError = dEnv.setLazyFetchSource(ddb, remoteName)
+			}
+			if tables := GetStringOrDefault(dEnv.Config, config.SparseTablesKey, ""); tables != "" && dEnv.DBLoadError == nil {
+				dEnv.DBLoadError = ddb.SetSparseTables(ParseSparseTables(tables))
+			}
+		}
+
 		if ddb != nil && ddb.AccessMode() != chunks.ExclusiveAccessMode_ReadOnly {
 			// Only do the following when we have write access to the database.
 			if dbLoadErr == nil && dEnv.HasDoltDir() {
@@ -294,6 +326,80 @@ func LoadDoltDB(ctx context.Context, dEnv *DoltEnv) {
 	})
 }
 
+// EnableLazyFetch configures this repository to fetch the chunks missing from its database, such as the history
+// skipped by a shallow clone, from the remote named |remoteName| when they are first read.
+func (dEnv *DoltEnv) EnableLazyFetch(remoteName string) error {
+	cfg, ok := dEnv.Config.GetConfig(LocalConfig)
+	if !ok {
+		return errors.New("no local config found; cannot enable lazy fetching")
+	}
+	err := cfg.SetStrings(map[string]string{config.LazyFetchRemoteKey: remoteName})
+	if err != nil {
+		return err
+	}
+	if dEnv.doltDB == nil {
+		return dEnv.DBLoadError
+	}
+	return dEnv.setLazyFetchSource(dEnv.doltDB, remoteName)
+}
+
+func (dEnv *DoltEnv) setLazyFetchSource(ddb *doltdb.DoltDB, remoteName string) error {
+	return ddb.SetLazyFetchSource(func(ctx context.Context) (*doltdb.DoltDB, error) {
+		remotes, err := dEnv.GetRemotes()
+		if err != nil {
+			return nil, err
+		}
+		r, ok := remotes.Get(remoteName)
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s' is configured by %s", ErrRemoteNotFound, remoteName, config.LazyFetchRemoteKey)
+		}
+		return dEnv.GetRemoteDB(ctx, ddb.Format(), r)
+	})
+}
+
+// SetSparseTables configures this repository as a sparse clone, which only pulls the row and index data of the tables
+// matching |patterns|.
+func (dEnv *DoltEnv) SetSparseTables(patterns []string) error {
+	cfg, ok := dEnv.Config.GetConfig(LocalConfig)
+	if !ok {
+		return errors.New("no local config found; cannot configure a sparse clone")
+	}
+	err := cfg.SetStrings(map[string]string{config.SparseTablesKey: strings.Join(patterns, ",")})
+	if err != nil {
+		return err
+	}
+	if dEnv.doltDB == nil {
+		return dEnv.DBLoadError
+	}
+	return dEnv.doltDB.SetSparseTables(patterns)
+}
+
+// SaveSparseTables records |patterns| as the tables of the sparse clone whose repository is in |fs|. It is used where
+// no DoltEnv for the repository is at hand, such as from SQL.
+func SaveSparseTables(fs filesys.ReadWriteFS, patterns []string) error {
+	path := getLocalConfigPath()
+	if exists, _ := fs.Exists(path); !exists {
+		_, err := config.NewFileConfig(path, fs, map[string]string{config.SparseTablesKey: strings.Join(patterns, ",")})
+		return err
+	}
+	cfg, err := config.FromFile(path, fs)
+	if err != nil {
+		return err
+	}
+	return cfg.SetStrings(map[string]string{config.SparseTablesKey: strings.Join(patterns, ",")})
+}
+
+// ParseSparseTables splits a comma separated list of table patterns, as given to `dolt clone --tables`.
+func ParseSparseTables(tables string) []string {
+	var patterns []string
+	for _, p := range strings.Split(tables, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
 func GetDefaultInitBranch(cfg config.ReadableConfig) string {
 	return GetStringOrDefault(cfg, config.InitBranchName, DefaultInitBranch)
 }
@@ -390,11 +496,17 @@ func (dEnv *DoltEnv) HasDoltDir() bool {
 	return dEnv.hasDoltDir("./")
 }
 
+// HasDoltDataDir returns true if the directory holding the database exists. For a worktree, this is the data directory
+// of the repository it was added to.
 func (dEnv *DoltEnv) HasDoltDataDir() bool {
 	if dEnv == nil {
 		return false
 	}
-	exists, isDir := dEnv.FS.Exists(dbfactory.DoltDataDir)
+	dataDir := dbfactory.DoltDataDir
+	if dEnv.IsWorktree() {
+		dataDir = filepath.Join(dEnv.worktreeOf, dbfactory.DataDir)
+	}
+	exists, isDir := dEnv.FS.Exists(dataDir)
 	return exists && isDir
 }
 
@@ -425,11 +537,15 @@ func mustAbs(dEnv *DoltEnv, path ...string) string {
 	return absPath
 }
 
-// GetDoltDir returns the path to the .dolt directory
+// GetDoltDir returns the path to the .dolt directory holding the database. For a worktree, this is the .dolt directory
+// of the repository it was added to.
 func (dEnv *DoltEnv) GetDoltDir() string {
 	if !dEnv.HasDoltDataDir() {
 		return ""
 	}
+	if dEnv.IsWorktree() {
+		return dEnv.worktreeOf
+	}
 
 	return mustAbs(dEnv, dbfactory.DoltDir)
 }
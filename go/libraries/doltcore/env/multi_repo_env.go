@@ -235,6 +235,10 @@ func multiEnvForConfigDirectoryEnv(ctx context.Context, config config.ReadWriteC
 		if len(dbLoadParams) > 0 {
 			newEnv.DBLoadParams = maps.Clone(dbLoadParams)
 		}
+		if newEnv.IsWorktree() {
+			// worktrees share the database of another directory, which may be this one
+			return false
+		}
 		if newEnv.Valid() {
 			envSet[dbfactory.DirToDBName(dir)] = newEnv
 			openedEnvs = append(openedEnvs, newEnv)
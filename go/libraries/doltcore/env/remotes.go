@@ -710,6 +710,14 @@ func GetAbsRemoteUrl(fs filesys2.Filesys, cfg config.ReadableConfig, urlArg stri
 				return "", "", err
 			}
 
+			return u.Scheme, absUrl, err
+		} else if u.Scheme == dbfactory.BundleScheme {
+			absUrl, err := getAbsBundleRemoteUrl(u, fs)
+
+			if err != nil {
+				return "", "", err
+			}
+
 			return u.Scheme, absUrl, err
 		}
 
@@ -762,6 +770,24 @@ func getAbsFileRemoteUrl(u *url.URL, fs filesys2.Filesys) (string, error) {
 	return scheme + "://" + urlStr, nil
 }
 
+// getAbsBundleRemoteUrl returns the url of the bundle file given with an absolute path. Unlike a file remote, the bundle
+// must already exist.
+func getAbsBundleRemoteUrl(u *url.URL, fs filesys2.Filesys) (string, error) {
+	urlStr, err := fs.Abs(filepath.Clean(u.Host + u.Path))
+	if err != nil {
+		return "", err
+	}
+
+	exists, isDir := fs.Exists(urlStr)
+	if !exists {
+		return "", fmt.Errorf("bundle '%s' does not exist", urlStr)
+	} else if isDir {
+		return "", fmt.Errorf("bundle '%s' is a directory", urlStr)
+	}
+
+	return u.Scheme + "://" + filepath.ToSlash(urlStr), nil
+}
+
 // GetDefaultBranch returns the default branch from among the branches given, returning
 // the configs default config branch first, then init branch main, then the old init branch master,
 // and finally the first lexicographical branch if none of the others are found
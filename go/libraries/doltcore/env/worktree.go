@@ -0,0 +1,448 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+// A worktree is an additional working directory of a repository. Its .dolt directory holds its own repo state, and
+// so its own checked out branch, and a link file naming the .dolt directory of the repository, whose chunk store it
+// shares. The repository keeps a list of its worktrees, so that a branch is only checked out in one of them at once.
+//
+// Only one process at a time holds the lock of a chunk store. Dolt commands run in the worktrees of a repository wait
+// for each other to release it, rather than opening the store read-only as soon as it's taken, so that commands on
+// different branches can be run at once from different worktrees.
+
+const (
+	worktreeLinkFile = "worktree.json"
+	worktreesFile    = "worktrees.json"
+)
+
+var ErrNotAWorktree = errors.New("not a worktree of this repository")
+var ErrRemoveCurrentWorktree = errors.New("cannot remove the worktree in use")
+var ErrWorktreeNotEmpty = errors.New("worktree contains files other than its .dolt directory")
+var ErrWorktreeHasUncommittedChanges = errors.New("worktree has uncommitted changes")
+var ErrBranchCheckedOutInWorktree = errors.New("branch is checked out in another worktree")
+
+// WorktreeLockTimeout is how long a dolt process working in a repository with worktrees waits for another one to
+// release the lock of the repository's chunk store before opening it read-only.
+const WorktreeLockTimeout = time.Minute
+
+type worktreeLink struct {
+	DoltDir string `json:"dolt_dir"`
+}
+
+type worktreeList struct {
+	Paths []string `json:"paths"`
+}
+
+// Worktree is one of the working directories of a repository.
+type Worktree struct {
+	// Path is the absolute path of the working directory.
+	Path string
+	// Branch is the branch checked out in the working directory.
+	Branch string
+	// Main is true for the working directory the repository was created in.
+	Main bool
+	// Missing is true if the working directory of a worktree no longer exists.
+	Missing bool
+}
+
+// readWorktreeLink returns the absolute path of the .dolt directory of the repository the worktree in the working
+// directory of |fs| belongs to, or the empty string if it isn't a worktree.
+func readWorktreeLink(fs filesys.ReadableFS) string {
+	data, err := fs.ReadFile(filepath.Join(dbfactory.DoltDir, worktreeLinkFile))
+	if err != nil {
+		return ""
+	}
+	var link worktreeLink
+	if err = json.Unmarshal(data, &link); err != nil {
+		return ""
+	}
+	return link.DoltDir
+}
+
+// IsWorktree returns whether this environment is a worktree added to another repository.
+func (dEnv *DoltEnv) IsWorktree() bool {
+	return dEnv != nil && dEnv.worktreeOf != ""
+}
+
+// HasWorktrees returns whether this environment is a worktree, or a repository that worktrees were added to. The
+// chunk store of such an environment may be used by several dolt processes at once.
+func (dEnv *DoltEnv) HasWorktrees() bool {
+	if dEnv.IsWorktree() {
+		return true
+	}
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return false
+	}
+	paths, err := readWorktreeList(dEnv.FS, doltDir)
+	return err == nil && len(paths) > 0
+}
+
+// repoDoltDir returns the absolute path of the .dolt directory holding the database of the repository, or worktree,
+// in the working directory of |fs|, or the empty string if there's none.
+func repoDoltDir(fs filesys.Filesys) string {
+	if doltDir := readWorktreeLink(fs); doltDir != "" {
+		return doltDir
+	}
+	doltDir, err := fs.Abs(dbfactory.DoltDir)
+	if err != nil {
+		return ""
+	}
+	if exists, isDir := fs.Exists(filepath.Join(doltDir, dbfactory.DataDir)); !exists || !isDir {
+		return ""
+	}
+	return doltDir
+}
+
+func readWorktreeList(fs filesys.ReadableFS, doltDir string) ([]string, error) {
+	data, err := fs.ReadFile(filepath.Join(doltDir, worktreesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var list worktreeList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Paths, nil
+}
+
+func (dEnv *DoltEnv) writeWorktreeList(doltDir string, paths []string) error {
+	data, err := json.MarshalIndent(worktreeList{Paths: paths}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return dEnv.FS.WriteFile(filepath.Join(doltDir, worktreesFile), data, os.ModePerm)
+}
+
+// Worktrees returns the working directories of the repository of this environment, the main one first.
+func (dEnv *DoltEnv) Worktrees() ([]Worktree, error) {
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return nil, ErrDoltRepositoryNotFound
+	}
+	return listWorktrees(dEnv.FS, doltDir)
+}
+
+// listWorktrees returns the working directories of the repository whose .dolt directory is |doltDir|, the main one
+// first.
+func listWorktrees(fs filesys.Filesys, doltDir string) ([]Worktree, error) {
+	paths, err := readWorktreeList(fs, doltDir)
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := make([]Worktree, 0, len(paths)+1)
+	for i, path := range append([]string{filepath.Dir(doltDir)}, paths...) {
+		wt := Worktree{Path: path, Main: i == 0}
+		if exists, _ := fs.Exists(filepath.Join(path, dbfactory.DoltDir)); !exists {
+			wt.Missing = true
+			worktrees = append(worktrees, wt)
+			continue
+		}
+		wtFS, err := fs.WithWorkingDir(path)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := LoadRepoState(wtFS)
+		if err != nil {
+			return nil, err
+		}
+		wt.Branch = rs.CWBHeadRef().GetPath()
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees, nil
+}
+
+// BranchCheckedOutAt returns the path of the working directory, other than this environment's, in which |branch| is
+// checked out.
+func (dEnv *DoltEnv) BranchCheckedOutAt(branch string) (string, bool, error) {
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return "", false, ErrDoltRepositoryNotFound
+	}
+	return branchCheckedOutAt(dEnv.FS, doltDir, branch)
+}
+
+func branchCheckedOutAt(fs filesys.Filesys, doltDir, branch string) (string, bool, error) {
+	worktrees, err := listWorktrees(fs, doltDir)
+	if err != nil {
+		return "", false, err
+	}
+	cwd, err := fs.Abs("")
+	if err != nil {
+		return "", false, err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch && wt.Path != cwd {
+			return wt.Path, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// CheckBranchNotInOtherWorktree returns ErrBranchCheckedOutInWorktree if |branch| is checked out in a working directory
+// of the repository in the working directory of |fs| other than that one. The working set of a branch is shared by
+// every working directory that checks it out, so a branch in use by another worktree can't be checked out or deleted.
+func CheckBranchNotInOtherWorktree(fs filesys.Filesys, branch string) error {
+	doltDir := repoDoltDir(fs)
+	if doltDir == "" {
+		return nil
+	}
+	if paths, err := readWorktreeList(fs, doltDir); err != nil || len(paths) == 0 {
+		return err
+	}
+	at, ok, err := branchCheckedOutAt(fs, doltDir, branch)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return fmt.Errorf("%w: '%s' is already checked out at '%s'", ErrBranchCheckedOutInWorktree, branch, at)
+	}
+	return nil
+}
+
+// exclusiveDoltDB returns the database of this environment if this process holds the lock of its chunk store. The lock
+// also serializes changes to the list of worktrees.
+func (dEnv *DoltEnv) exclusiveDoltDB(ctx context.Context) (*doltdb.DoltDB, error) {
+	ddb := dEnv.DoltDB(ctx)
+	if ddb == nil {
+		return nil, dEnv.DBLoadError
+	}
+	if ddb.AccessMode() == chunks.ExclusiveAccessMode_ReadOnly {
+		return nil, ErrDatabaseIsLocked
+	}
+	return ddb, nil
+}
+
+// AddWorktree creates a worktree of this environment's repository at |path|, which must not exist or be an empty
+// directory, with |branch| checked out. The branch must not be checked out in another working directory, since the
+// working set of a branch is shared by everyone who checks it out. The remotes and branch configuration of the new
+// worktree are copied from this environment.
+func (dEnv *DoltEnv) AddWorktree(ctx context.Context, path, branch string) error {
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return ErrDoltRepositoryNotFound
+	}
+	absPath, err := dEnv.FS.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	ddb, err := dEnv.exclusiveDoltDB(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok, err := ddb.HasBranch(ctx, branch); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: %s", doltdb.ErrBranchNotFound, branch)
+	}
+	worktrees, err := dEnv.Worktrees()
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			return fmt.Errorf("'%s' is already checked out at '%s'", branch, wt.Path)
+		}
+	}
+
+	if exists, isDir := dEnv.FS.Exists(absPath); exists {
+		if !isDir {
+			return fmt.Errorf("'%s' already exists", path)
+		}
+		empty := true
+		if err = dEnv.FS.Iter(absPath, false, func(string, int64, bool) bool {
+			empty = false
+			return true
+		}); err != nil {
+			return err
+		}
+		if !empty {
+			return fmt.Errorf("'%s' already exists and is not empty", path)
+		}
+	}
+	if err = dEnv.FS.MkDirs(filepath.Join(absPath, dbfactory.DoltDir)); err != nil {
+		return err
+	}
+	wtFS, err := dEnv.FS.WithWorkingDir(absPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(worktreeLink{DoltDir: doltDir}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = wtFS.WriteFile(filepath.Join(dbfactory.DoltDir, worktreeLinkFile), data, os.ModePerm); err != nil {
+		return err
+	}
+	rs := &RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef(branch)},
+		Remotes:  dEnv.RepoState.Remotes.DeepCopy(),
+		Backups:  dEnv.RepoState.Backups.DeepCopy(),
+		Branches: dEnv.RepoState.Branches.DeepCopy(),
+	}
+	if err = rs.Save(wtFS); err != nil {
+		return err
+	}
+
+	paths, err := readWorktreeList(dEnv.FS, doltDir)
+	if err != nil {
+		return err
+	}
+	return dEnv.writeWorktreeList(doltDir, append(paths, absPath))
+}
+
+// RemoveWorktree deletes the worktree at |path| of this environment's repository. The branch checked out in it, and
+// any changes in its working set, are kept. A worktree whose branch has uncommitted changes, or whose directory holds
+// anything besides its .dolt directory, is only deleted if |force| is true. A worktree whose directory was deleted is
+// only unregistered.
+func (dEnv *DoltEnv) RemoveWorktree(ctx context.Context, path string, force bool) error {
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return ErrDoltRepositoryNotFound
+	}
+	ddb, err := dEnv.exclusiveDoltDB(ctx)
+	if err != nil {
+		return err
+	}
+	absPath, err := dEnv.FS.Abs(path)
+	if err != nil {
+		return err
+	}
+	cwd, err := dEnv.FS.Abs("")
+	if err != nil {
+		return err
+	}
+	if absPath == cwd {
+		return ErrRemoveCurrentWorktree
+	}
+
+	paths, err := readWorktreeList(dEnv.FS, doltDir)
+	if err != nil {
+		return err
+	}
+	remaining := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if p != absPath {
+			remaining = append(remaining, p)
+		}
+	}
+	if len(remaining) == len(paths) {
+		return fmt.Errorf("%w: %s", ErrNotAWorktree, path)
+	}
+
+	if exists, _ := dEnv.FS.Exists(absPath); exists {
+		if !force {
+			wtFS, err := dEnv.FS.WithWorkingDir(absPath)
+			if err != nil {
+				return err
+			}
+			rs, err := LoadRepoState(wtFS)
+			if err != nil {
+				return err
+			}
+			branch := rs.CWBHeadRef().GetPath()
+			if dirty, err := branchHasUncommittedChanges(ctx, ddb, branch); err != nil {
+				return err
+			} else if dirty {
+				return fmt.Errorf("%w: branch '%s'", ErrWorktreeHasUncommittedChanges, branch)
+			}
+
+			var other string
+			if err = dEnv.FS.Iter(absPath, false, func(p string, _ int64, _ bool) bool {
+				if filepath.Base(p) != dbfactory.DoltDir {
+					other = p
+					return true
+				}
+				return false
+			}); err != nil {
+				return err
+			}
+			if other != "" {
+				return fmt.Errorf("%w: '%s'", ErrWorktreeNotEmpty, other)
+			}
+		}
+		if err = dEnv.FS.Delete(absPath, true); err != nil {
+			return err
+		}
+	}
+	return dEnv.writeWorktreeList(doltDir, remaining)
+}
+
+// branchHasUncommittedChanges returns whether the working set of |branch| differs from its head, ignoring differences
+// in feature version.
+func branchHasUncommittedChanges(ctx context.Context, ddb *doltdb.DoltDB, branch string) (bool, error) {
+	branchRef := ref.NewBranchRef(branch)
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return false, err
+	}
+	ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	head, err := ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return false, err
+	}
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return false, err
+	}
+	fv, _, err := headRoot.GetFeatureVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	headHash, err := headRoot.HashOf()
+	if err != nil {
+		return false, err
+	}
+	for _, root := range []doltdb.RootValue{ws.StagedRoot(), ws.WorkingRoot()} {
+		root, err = root.SetFeatureVersion(fv)
+		if err != nil {
+			return false, err
+		}
+		h, err := root.HashOf()
+		if err != nil {
+			return false, err
+		}
+		if h != headHash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
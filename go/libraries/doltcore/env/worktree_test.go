@@ -0,0 +1,118 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestWorktrees(t *testing.T) {
+	ctx := context.Background()
+	dEnv, fs := createTestEnv(false, false)
+	require.NoError(t, dEnv.InitRepo(ctx, types.Format_DOLT, "aoeu aoeu", "aoeu@aoeu.org", DefaultInitBranch))
+	defer dEnv.Close()
+	assert.False(t, dEnv.HasWorktrees())
+
+	head, err := dEnv.HeadCommit(ctx)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB(ctx).NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), head, nil))
+	require.NoError(t, dEnv.DoltDB(ctx).NewBranchAtCommit(ctx, ref.NewBranchRef("other"), head, nil))
+
+	wtPath := filepath.Join(filepath.Dir(workingDir), "feature")
+	require.NoError(t, dEnv.AddWorktree(ctx, wtPath, "feature"))
+	assert.True(t, dEnv.HasWorktrees())
+	assert.False(t, dEnv.IsWorktree())
+
+	err = dEnv.AddWorktree(ctx, filepath.Join(filepath.Dir(workingDir), "main"), DefaultInitBranch)
+	assert.ErrorContains(t, err, "'main' is already checked out at '"+workingDir+"'")
+	err = dEnv.AddWorktree(ctx, filepath.Join(filepath.Dir(workingDir), "other"), "feature")
+	assert.ErrorContains(t, err, "'feature' is already checked out at '"+wtPath+"'")
+	err = dEnv.AddWorktree(ctx, wtPath, "other")
+	assert.ErrorContains(t, err, "already exists and is not empty")
+	err = dEnv.AddWorktree(ctx, filepath.Join(filepath.Dir(workingDir), "missing"), "missing")
+	assert.ErrorIs(t, err, doltdb.ErrBranchNotFound)
+
+	wtFS, err := fs.WithWorkingDir(wtPath)
+	require.NoError(t, err)
+	wtEnv := LoadWithoutDB(ctx, testHomeDirFunc, wtFS, doltdb.InMemDoltDB, "test")
+	require.NoError(t, wtEnv.RSLoadErr)
+	assert.True(t, wtEnv.IsWorktree())
+	assert.True(t, wtEnv.HasWorktrees())
+	assert.True(t, wtEnv.HasDoltDataDir())
+	assert.Equal(t, filepath.Join(workingDir, dbfactory.DoltDir), wtEnv.GetDoltDir())
+	assert.Equal(t, ref.NewBranchRef("feature"), wtEnv.RepoState.CWBHeadRef())
+
+	worktrees, err := wtEnv.Worktrees()
+	require.NoError(t, err)
+	assert.Equal(t, []Worktree{
+		{Path: workingDir, Branch: DefaultInitBranch, Main: true},
+		{Path: wtPath, Branch: "feature"},
+	}, worktrees)
+
+	at, ok, err := wtEnv.BranchCheckedOutAt(DefaultInitBranch)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, workingDir, at)
+	_, ok, err = wtEnv.BranchCheckedOutAt("feature")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, CheckBranchNotInOtherWorktree(wtFS, "feature"))
+	require.NoError(t, CheckBranchNotInOtherWorktree(wtFS, "other"))
+	assert.ErrorIs(t, CheckBranchNotInOtherWorktree(wtFS, DefaultInitBranch), ErrBranchCheckedOutInWorktree)
+	assert.ErrorIs(t, CheckBranchNotInOtherWorktree(fs, "feature"), ErrBranchCheckedOutInWorktree)
+
+	assert.ErrorIs(t, dEnv.RemoveWorktree(ctx, workingDir, false), ErrRemoveCurrentWorktree)
+	assert.ErrorIs(t, dEnv.RemoveWorktree(ctx, filepath.Join(filepath.Dir(workingDir), "other"), false), ErrNotAWorktree)
+
+	// a worktree whose branch has uncommitted changes is kept unless forced
+	headRoot, err := head.GetRootValue(ctx)
+	require.NoError(t, err)
+	changedRoot, err := headRoot.CreateDatabaseSchema(ctx, schema.DatabaseSchema{Name: "s"})
+	require.NoError(t, err)
+	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef("feature"))
+	require.NoError(t, err)
+	ws, err := dEnv.DoltDB(ctx).ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+	prevHash, err := ws.HashOf()
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB(ctx).UpdateWorkingSet(ctx, wsRef, ws.WithWorkingRoot(changedRoot), prevHash, doltdb.TodoWorkingSetMeta(), nil))
+	assert.ErrorIs(t, dEnv.RemoveWorktree(ctx, wtPath, false), ErrWorktreeHasUncommittedChanges)
+	dirtyWs, err := dEnv.DoltDB(ctx).ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+	prevHash, err = dirtyWs.HashOf()
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB(ctx).UpdateWorkingSet(ctx, wsRef, ws, prevHash, doltdb.TodoWorkingSetMeta(), nil))
+
+	require.NoError(t, wtFS.WriteFile("notes.txt", []byte("keep me"), 0644))
+	assert.ErrorIs(t, dEnv.RemoveWorktree(ctx, wtPath, false), ErrWorktreeNotEmpty)
+	exists, _ := fs.Exists(filepath.Join(wtPath, "notes.txt"))
+	assert.True(t, exists)
+	require.NoError(t, dEnv.RemoveWorktree(ctx, wtPath, true))
+	exists, _ = fs.Exists(wtPath)
+	assert.False(t, exists)
+	assert.False(t, dEnv.HasWorktrees())
+}
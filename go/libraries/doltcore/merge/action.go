@@ -53,6 +53,12 @@ type MergeSpec struct {
 	Email           string
 	Name            string
 	Date            *datas.CommitDate
+	// Strategy is the merge strategy given with --strategy. The only supported strategy is MergeStrategyOurs, which
+	// records the merge without taking any changes from MergeC. When empty, the commits are merged normally.
+	Strategy MergeStrategy
+	// StrategyOptions resolve the conflicts of a normal merge in favor of one side, and are nil when conflicts should
+	// be left for the user to resolve.
+	StrategyOptions *StrategyOptions
 }
 
 type MergeSpecOpt func(*MergeSpec)
@@ -81,6 +87,18 @@ func WithForce(force bool) MergeSpecOpt {
 	}
 }
 
+func WithStrategy(strategy MergeStrategy) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.Strategy = strategy
+	}
+}
+
+func WithStrategyOptions(opts *StrategyOptions) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.StrategyOptions = opts
+	}
+}
+
 func WithSquash(squash bool) MergeSpecOpt {
 	return func(ms *MergeSpec) {
 		ms.Squash = squash
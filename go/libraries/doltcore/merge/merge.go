@@ -54,6 +54,17 @@ const (
 )
 
 func MergeCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+	ourRoot, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MergeCommitIntoRoot(ctx, tableResolver, ourRoot, commit, mergeCommit, opts)
+}
+
+// MergeCommitIntoRoot three-way merges |mergeCommit| into |ourRoot|, using the merge base of |commit| and
+// |mergeCommit| as the ancestor. |ourRoot| is usually the root of |commit|, but octopus merges use it to merge each of
+// their commits into the result of merging the ones before it.
+func MergeCommitIntoRoot(ctx *sql.Context, tableResolver doltdb.TableResolver, ourRoot doltdb.RootValue, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
 	optCmt, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 	if err != nil {
 		return nil, err
@@ -64,24 +75,37 @@ func MergeCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit,
 		return nil, doltdb.ErrGhostCommitRuntimeFailure
 	}
 
-	ourRoot, err := commit.GetRootValue(ctx)
+	theirRoot, err := mergeCommit.GetRootValue(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	theirRoot, err := mergeCommit.GetRootValue(ctx)
+	ancRoot, err := ancCommit.GetRootValue(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ancRoot, err := ancCommit.GetRootValue(ctx)
+	ourTime, err := commitTimestamp(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	theirTime, err := commitTimestamp(ctx, mergeCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	similarity, err := renameSimilarity(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	mo := MergeOpts{
-		IsCherryPick:        false,
-		KeepSchemaConflicts: true,
+		IsCherryPick:         false,
+		KeepSchemaConflicts:  true,
+		OursTimestamp:        ourTime,
+		TheirsTimestamp:      theirTime,
+		RenameSimilarity:     similarity,
+		ApplyMergeStrategies: true,
 	}
 	return MergeRoots(ctx, tableResolver, ourRoot, theirRoot, ancRoot, mergeCommit, ancCommit, opts, mo)
 }
@@ -223,13 +247,20 @@ func MergeRoots(
 
 	tblToStats := make(map[doltdb.TableName]*MergeStats)
 
-	// Merge tables one at a time. This is done based on name. With table names from ourRoot being merged first,
-	// renaming a table will return delete/modify conflict error consistently.
-	// TODO: merge based on a more durable table identity that persists across renames
+	// Merge tables one at a time. This is done based on name, except for tables renamed since the ancestor when
+	// |mergeOpts.RenameSimilarity| is set, which are merged under their new name together with the table of the old
+	// name on the other side. With table names from ourRoot being merged first, a rename that isn't followed returns a
+	// delete/modify conflict error consistently when the other side modified the table.
 	merger, err := NewMerger(ourRoot, theirRoot, ancRoot, theirs, ancestor, ourRoot.VRW(), ourRoot.NodeStore())
 	if err != nil {
 		return nil, err
 	}
+	if mergeOpts.RenameSimilarity > 0 {
+		merger.renames, err = findMergeRenames(ctx, ourRoot, theirRoot, ancRoot, mergeOpts.RenameSimilarity)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	destSchemaNames, err := getDatabaseSchemaNames(ctx, ourRoot)
 	if err != nil {
@@ -240,6 +271,11 @@ func MergeRoots(
 	visitedTables := make(map[string]struct{})
 	var schConflicts []SchemaConflict
 	for _, tblName := range tblNames {
+		if merger.renames.isMerged(tblName) {
+			// This is the old name of a renamed table, which is merged under its new name
+			continue
+		}
+
 		mergedTable, stats, err := merger.MergeTable(ctx, tblName, opts, mergeOpts)
 
 		if errors.Is(ErrTableDeletedAndModified, err) && doltdb.IsFullTextTable(tblName.Name) {
@@ -291,6 +327,14 @@ func MergeRoots(
 				destSchemaNames.Add(tblName.Schema)
 			}
 
+			if ourName, _, _ := merger.renames.names(tblName); ourName != tblName {
+				// Their root renamed this table, so it no longer belongs under our name for it
+				mergedRoot, err = mergedRoot.RemoveTables(ctx, false, true, ourName)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			mergedRoot, err = mergedRoot.PutTable(ctx, tblName, mergedTable.table)
 			var errTagPreviouslyUsed schema.ErrTagPrevUsed
 			if errors.As(err, &errTagPreviouslyUsed) {
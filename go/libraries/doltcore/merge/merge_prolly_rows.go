@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"runtime/debug"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -1909,6 +1910,13 @@ type valueMerger struct {
 	keyless                                bool
 	ns                                     tree.NodeStore
 	valueBuilder                           *val.TupleBuilder
+
+	// tableName is the name of the table being merged, used in error messages
+	tableName string
+	// strategies holds the merge strategy for each column of the merged schema, if any, from dolt_merge_strategies
+	strategies []MergeStrategy
+	// oursTime and theirsTime are the timestamps of the commits being merged, or zero if a side isn't a commit
+	oursTime, theirsTime time.Time
 }
 
 func NewValueMerger(ctx context.Context, merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -2206,8 +2214,8 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftVal, false, err
 		}
 
-		// conflicting inserts
-		return nil, true, nil
+		// conflicting inserts, which may be resolved by the column's merge strategy
+		return m.resolveWithStrategy(ctx, i, sqlType, nil, leftVal, rightVal)
 	}
 
 	// If left and right both contain byte-level changes to an existing column,
@@ -2297,17 +2305,22 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return nil, true, err
 		}
 		if _, ok := sqlType.(types.JsonType); ok && !disallowJsonMerge {
-			// if any of the values are NULL, this is an unresolvable conflict
-			if baseCol == nil || leftCol == nil || rightCol == nil {
-				return nil, true, nil
-			}
-			if resultType.Enc == val.JsonAdaptiveEnc {
-				return m.mergeJSONAdaptive(ctx, baseCol, leftCol, rightCol)
+			// if any of the values are NULL, the documents can't be merged
+			if baseCol != nil && leftCol != nil && rightCol != nil {
+				var merged interface{}
+				var conflict bool
+				if resultType.Enc == val.JsonAdaptiveEnc {
+					merged, conflict, err = m.mergeJSONAdaptive(ctx, baseCol, leftCol, rightCol)
+				} else {
+					merged, conflict, err = m.mergeJSONAddr(ctx, baseCol, leftCol, rightCol)
+				}
+				if err != nil || !conflict {
+					return merged, conflict, err
+				}
 			}
-			return m.mergeJSONAddr(ctx, baseCol, leftCol, rightCol)
 		}
-		// otherwise, this is a conflict.
-		return nil, true, nil
+		// otherwise, this is a conflict, unless the column's merge strategy resolves it.
+		return m.resolveWithStrategy(ctx, i, sqlType, baseVal, leftVal, rightVal)
 	case leftModified:
 		return leftVal, false, nil
 	default:
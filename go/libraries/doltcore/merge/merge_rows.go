@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -55,6 +57,17 @@ type MergeOpts struct {
 	// dolt_verify_constraints() stored procedure to allow callers to verify constraints for a
 	// subset of tables.
 	RecordViolationsForTables map[doltdb.TableName]struct{}
+	// OursTimestamp and TheirsTimestamp are the timestamps of the commits being merged. They are used by the "latest"
+	// merge strategy of dolt_merge_strategies, which leaves cells in conflict when either is unset.
+	OursTimestamp, TheirsTimestamp time.Time
+	// RenameSimilarity is the similarity, in percent, at which tables and columns renamed on either side since the
+	// ancestor are found and merged across the rename. When it is zero, tables are merged by name only, and a table
+	// renamed on one side and modified on the other is a conflict.
+	RenameSimilarity int
+	// ApplyMergeStrategies resolves conflicting cells with the strategies configured in dolt_merge_strategies. It is
+	// only set for merges of commits, such as dolt_merge. Other merges, in particular those of concurrent
+	// transactions, must report every conflicting cell, so that one side's write is never silently dropped.
+	ApplyMergeStrategies bool
 }
 
 type TableMerger struct {
@@ -83,10 +96,28 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// strategies are the merge strategies configured for this table's columns in dolt_merge_strategies
+	strategies           columnStrategies
+	oursTime, theirsTime time.Time
 }
 
 func (tm TableMerger) GetNewValueMerger(ctx context.Context, mergeSch schema.Schema, leftRows prolly.Map) *valueMerger {
-	return NewValueMerger(ctx, mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm := NewValueMerger(ctx, mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm.tableName = tm.name.Name
+	vm.oursTime, vm.theirsTime = tm.oursTime, tm.theirsTime
+	if len(tm.strategies) > 0 {
+		vm.strategies = make([]MergeStrategy, vm.numCols)
+		i := 0
+		for _, col := range mergeSch.GetNonPKCols().GetColumns() {
+			if col.Virtual {
+				continue
+			}
+			vm.strategies[i] = tm.strategies[strings.ToLower(col.Name)]
+			i++
+		}
+	}
+	return vm
 }
 
 func rowsFromTable(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
@@ -162,6 +193,12 @@ type RootMerger struct {
 
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
+
+	// strategies caches the contents of dolt_merge_strategies in our root, by schema name
+	strategies map[string]map[string]columnStrategies
+
+	// renames holds the tables that are merged across renames, if any
+	renames *mergeRenames
 }
 
 // NewMerger creates a new merger utility object.
@@ -281,12 +318,22 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		vrw:              rm.vrw,
 		ns:               rm.ns,
 		recordViolations: recordViolations,
+		oursTime:         mergeOpts.OursTimestamp,
+		theirsTime:       mergeOpts.TheirsTimestamp,
 	}
 
 	var err error
+	if mergeOpts.ApplyMergeStrategies {
+		tm.strategies, err = rm.mergeStrategiesForTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var leftSideTableExists, rightSideTableExists, ancTableExists bool
+	leftName, rightName, ancName := rm.renames.names(tblName)
 
-	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, tblName)
+	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, leftName)
 	if err != nil {
 		return nil, err
 	}
@@ -295,13 +342,13 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 			return nil, err
 		}
 	} else {
-		tm.leftRootObj, _, err = rm.left.GetRootObject(ctx, tblName)
+		tm.leftRootObj, _, err = rm.left.GetRootObject(ctx, leftName)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	tm.rightTbl, rightSideTableExists, err = rm.right.GetTable(ctx, tblName)
+	tm.rightTbl, rightSideTableExists, err = rm.right.GetTable(ctx, rightName)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +357,7 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 			return nil, err
 		}
 	} else {
-		tm.rightRootObj, _, err = rm.right.GetRootObject(ctx, tblName)
+		tm.rightRootObj, _, err = rm.right.GetRootObject(ctx, rightName)
 		if err != nil {
 			return nil, err
 		}
@@ -338,7 +385,7 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
-	tm.ancTbl, ancTableExists, err = rm.anc.GetTable(ctx, tblName)
+	tm.ancTbl, ancTableExists, err = rm.anc.GetTable(ctx, ancName)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +401,7 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 			return nil, err
 		}
 	} else {
-		tm.ancRootObj, _, err = rm.anc.GetRootObject(ctx, tblName)
+		tm.ancRootObj, _, err = rm.anc.GetRootObject(ctx, ancName)
 		if err != nil {
 			return nil, err
 		}
@@ -365,6 +412,10 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		return nil, errors.New("Attempting to merge fundamentally different objects, which has not yet been implemented\n" +
 			"Please contact us and share how you ran into this error to better help our development efforts.")
 	}
+
+	if err = rm.renames.retagToLeft(&tm); err != nil {
+		return nil, err
+	}
 	return &tm, nil
 }
 
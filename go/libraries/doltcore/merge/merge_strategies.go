@@ -0,0 +1,438 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	errorkinds "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeStrategy is a rule for automatically resolving conflicting changes to a column's value. Merge strategies are
+// configured per column in the dolt_merge_strategies system table, and are only consulted for cells that would
+// otherwise be recorded as data conflicts in merges of commits. See MergeOpts.ApplyMergeStrategies.
+type MergeStrategy string
+
+const (
+	// MergeStrategyOurs resolves a conflict with our value.
+	MergeStrategyOurs MergeStrategy = "ours"
+	// MergeStrategyTheirs resolves a conflict with their value.
+	MergeStrategyTheirs MergeStrategy = "theirs"
+	// MergeStrategyMax resolves a conflict with the greater of the two values. NULL values lose to non-NULL values.
+	MergeStrategyMax MergeStrategy = "max"
+	// MergeStrategyMin resolves a conflict with the lesser of the two values. NULL values lose to non-NULL values.
+	MergeStrategyMin MergeStrategy = "min"
+	// MergeStrategySum resolves a conflict in a numeric column by applying both sides' changes to the base value, i.e.
+	// base + (ours - base) + (theirs - base). NULL values, and the base value of a row inserted on both sides, count
+	// as zero.
+	MergeStrategySum MergeStrategy = "sum"
+	// MergeStrategyLatest resolves a conflict with the value from the side whose commit has the later timestamp. It
+	// can only be used when both sides of the merge are commits.
+	MergeStrategyLatest MergeStrategy = "latest"
+	// MergeStrategyUnion resolves a conflict in a JSON column whose values are arrays by taking the set union of the
+	// elements of both sides, less any elements of the base value that either side removed.
+	MergeStrategyUnion MergeStrategy = "union"
+	// MergeStrategyConcat resolves a conflict in a text column by concatenating both sides' values, ours first. When
+	// both sides appended to the base value, only the text appended by their side is added to ours.
+	MergeStrategyConcat MergeStrategy = "concat"
+)
+
+// ErrUnknownMergeStrategy is returned when a column has a merge strategy that isn't supported.
+var ErrUnknownMergeStrategy = errorkinds.NewKind("unknown merge strategy '%s' for column %s.%s in %s; " +
+	"supported strategies are ours, theirs, max, min, sum, latest, union and concat")
+
+// ErrInapplicableMergeStrategy is returned when a column has a merge strategy that can't be used with its type.
+var ErrInapplicableMergeStrategy = errorkinds.NewKind("merge strategy '%s' cannot be used for column %s.%s of type %s")
+
+// StrategyOptions resolve every conflict of a merge in favor of one side, like git's `-X ours` and `-X theirs`. They
+// are given to dolt_merge as a comma-separated list, where `ours` or `theirs` sets the side for every table and
+// `<table>=ours` or `<table>=theirs` overrides it for a single table. Only MergeStrategyOurs and MergeStrategyTheirs
+// are valid sides.
+type StrategyOptions struct {
+	// Default is the side used for tables without an override, or empty if conflicts in those tables are left alone.
+	Default MergeStrategy
+	// Tables maps lower-cased table names to the side used for their conflicts.
+	Tables map[string]MergeStrategy
+}
+
+// ErrInvalidStrategyOption is returned when a strategy option can't be parsed.
+var ErrInvalidStrategyOption = errorkinds.NewKind("invalid strategy option '%s'; " +
+	"expected ours, theirs, <table>=ours or <table>=theirs")
+
+// ParseStrategyOptions parses the comma-separated strategy options in |opts|.
+func ParseStrategyOptions(opts string) (*StrategyOptions, error) {
+	so := &StrategyOptions{Tables: make(map[string]MergeStrategy)}
+	for _, opt := range strings.Split(opts, ",") {
+		opt = strings.TrimSpace(opt)
+		table, side, hasTable := strings.Cut(opt, "=")
+		if !hasTable {
+			table, side = "", table
+		}
+		strategy := MergeStrategy(strings.ToLower(strings.TrimSpace(side)))
+		if strategy != MergeStrategyOurs && strategy != MergeStrategyTheirs {
+			return nil, ErrInvalidStrategyOption.New(opt)
+		}
+		table = strings.TrimSpace(table)
+		if !hasTable {
+			so.Default = strategy
+		} else if table == "" {
+			return nil, ErrInvalidStrategyOption.New(opt)
+		} else {
+			so.Tables[strings.ToLower(table)] = strategy
+		}
+	}
+	return so, nil
+}
+
+// ForTable returns the side that conflicts in |tblName| should be resolved with, and false if they should be left for
+// the user to resolve.
+func (so *StrategyOptions) ForTable(tblName doltdb.TableName) (MergeStrategy, bool) {
+	if so == nil {
+		return "", false
+	}
+	if strategy, ok := so.Tables[strings.ToLower(tblName.Name)]; ok {
+		return strategy, true
+	}
+	return so.Default, so.Default != ""
+}
+
+// columnStrategies maps lower-cased column names to the merge strategy configured for them.
+type columnStrategies map[string]MergeStrategy
+
+// loadMergeStrategies reads the dolt_merge_strategies table in the schema named |schemaName| of |root|, and returns
+// the strategies it configures keyed by lower-cased table name.
+func loadMergeStrategies(ctx context.Context, root doltdb.RootValue, schemaName string) (map[string]columnStrategies, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: doltdb.MergeStrategiesTableName, Schema: schemaName})
+	if err != nil {
+		return nil, err
+	}
+	strategies := make(map[string]columnStrategies)
+	if !ok {
+		return strategies, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := durable.ProllyMapFromIndex(rowData)
+	if err != nil {
+		return nil, err
+	}
+	ns := rows.NodeStore()
+	keyDesc, valDesc := sch.GetMapDescriptors(ns)
+
+	tableIdx := sch.GetPKCols().IndexOf("table_name")
+	columnIdx := sch.GetPKCols().IndexOf("column_name")
+	strategyIdx := sch.GetNonPKCols().IndexOf("strategy")
+	if tableIdx < 0 || columnIdx < 0 || strategyIdx < 0 {
+		return nil, fmt.Errorf("%s has an unexpected schema", doltdb.MergeStrategiesTableName)
+	}
+
+	iter, err := rows.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var fields [3]string
+		for i, f := range []struct {
+			desc *val.TupleDesc
+			idx  int
+			tup  val.Tuple
+		}{{keyDesc, tableIdx, k}, {keyDesc, columnIdx, k}, {valDesc, strategyIdx, v}} {
+			field, err := tree.GetField(ctx, f.desc, f.idx, f.tup, ns)
+			if err != nil {
+				return nil, err
+			}
+			if fields[i], err = textValue(ctx, field); err != nil {
+				return nil, err
+			}
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		tableName, columnName := strings.ToLower(fields[0]), strings.ToLower(fields[1])
+		if strategies[tableName] == nil {
+			strategies[tableName] = make(columnStrategies)
+		}
+		strategies[tableName][columnName] = MergeStrategy(strings.ToLower(fields[2]))
+	}
+	return strategies, nil
+}
+
+// mergeStrategiesForTable returns the merge strategies configured for the columns of |tblName| in our root. The
+// contents of dolt_merge_strategies are read once per schema for each merge.
+func (rm *RootMerger) mergeStrategiesForTable(ctx context.Context, tblName doltdb.TableName) (columnStrategies, error) {
+	if rm.strategies == nil {
+		rm.strategies = make(map[string]map[string]columnStrategies)
+	}
+	strategies, ok := rm.strategies[tblName.Schema]
+	if !ok {
+		var err error
+		if strategies, err = loadMergeStrategies(ctx, rm.left, tblName.Schema); err != nil {
+			return nil, err
+		}
+		rm.strategies[tblName.Schema] = strategies
+	}
+	return strategies[strings.ToLower(tblName.Name)], nil
+}
+
+// resolveWithStrategy resolves a conflict between |leftVal| and |rightVal| in column |i| of the merged schema using
+// the merge strategy configured for the column. |baseVal| is nil when both sides inserted the cell. It returns
+// conflict=true when the column has no strategy, or its strategy can't resolve these values.
+func (m *valueMerger) resolveWithStrategy(ctx *sql.Context, i int, sqlType sql.Type, baseVal, leftVal, rightVal interface{}) (result interface{}, conflict bool, err error) {
+	if m.strategies == nil || m.strategies[i] == "" {
+		return nil, true, nil
+	}
+	strategy := m.strategies[i]
+
+	switch strategy {
+	case MergeStrategyOurs:
+		return leftVal, false, nil
+	case MergeStrategyTheirs:
+		return rightVal, false, nil
+	case MergeStrategyMax, MergeStrategyMin:
+		if leftVal == nil {
+			return rightVal, false, nil
+		} else if rightVal == nil {
+			return leftVal, false, nil
+		}
+		cmp, err := sqlType.Compare(ctx, leftVal, rightVal)
+		if err != nil {
+			return nil, true, err
+		}
+		if (cmp >= 0) == (strategy == MergeStrategyMax) {
+			return leftVal, false, nil
+		}
+		return rightVal, false, nil
+	case MergeStrategyLatest:
+		if m.oursTime.IsZero() || m.theirsTime.IsZero() || m.oursTime.Equal(m.theirsTime) {
+			return nil, true, nil
+		}
+		if m.theirsTime.After(m.oursTime) {
+			return rightVal, false, nil
+		}
+		return leftVal, false, nil
+	case MergeStrategySum:
+		if !types.IsNumber(sqlType) {
+			return nil, true, m.inapplicableStrategy(i, strategy, sqlType)
+		}
+		return sumDeltas(ctx, sqlType, baseVal, leftVal, rightVal)
+	case MergeStrategyUnion:
+		if _, ok := sqlType.(types.JsonType); !ok {
+			return nil, true, m.inapplicableStrategy(i, strategy, sqlType)
+		}
+		return unionJSONArrays(ctx, baseVal, leftVal, rightVal)
+	case MergeStrategyConcat:
+		if !types.IsText(sqlType) {
+			return nil, true, m.inapplicableStrategy(i, strategy, sqlType)
+		}
+		return concatText(ctx, sqlType, baseVal, leftVal, rightVal)
+	default:
+		col := m.resultSchema.GetNonPKCols().GetByIndex(i)
+		return nil, true, ErrUnknownMergeStrategy.New(string(strategy), m.tableName, col.Name, doltdb.MergeStrategiesTableName)
+	}
+}
+
+func (m *valueMerger) inapplicableStrategy(i int, strategy MergeStrategy, sqlType sql.Type) error {
+	col := m.resultSchema.GetNonPKCols().GetByIndex(i)
+	return ErrInapplicableMergeStrategy.New(string(strategy), m.tableName, col.Name, sqlType.String())
+}
+
+// sumDeltas returns |baseVal| with the changes made by both |leftVal| and |rightVal| added to it. If the result is
+// out of range for |sqlType|, the values are left in conflict.
+func sumDeltas(ctx *sql.Context, sqlType sql.Type, baseVal, leftVal, rightVal interface{}) (result interface{}, conflict bool, err error) {
+	var sum decimal.Decimal
+	for _, v := range []struct {
+		val  interface{}
+		sign int64
+	}{{leftVal, 1}, {rightVal, 1}, {baseVal, -1}} {
+		if v.val == nil {
+			continue
+		}
+		d, _, err := types.InternalDecimalType.Convert(ctx, v.val)
+		if err != nil {
+			return nil, true, err
+		}
+		sum = sum.Add(d.(decimal.Decimal).Mul(decimal.NewFromInt(v.sign)))
+	}
+	result, inRange, err := sqlType.Convert(ctx, sum)
+	if err != nil || inRange == sql.OutOfRange {
+		return nil, true, nil
+	}
+	return result, false, nil
+}
+
+// unionJSONArrays returns the elements of |leftVal| followed by the elements of |rightVal| that |leftVal| doesn't
+// contain, less any elements of |baseVal| that either side removed. If any of the values isn't a JSON array, the
+// values are left in conflict.
+func unionJSONArrays(ctx *sql.Context, baseVal, leftVal, rightVal interface{}) (result interface{}, conflict bool, err error) {
+	base, ok, err := jsonArrayElements(ctx, baseVal)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+	left, ok, err := jsonArrayElements(ctx, leftVal)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+	right, ok, err := jsonArrayElements(ctx, rightVal)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+
+	leftKeys, err := jsonElementKeys(left)
+	if err != nil {
+		return nil, true, err
+	}
+	rightKeys, err := jsonElementKeys(right)
+	if err != nil {
+		return nil, true, err
+	}
+	baseKeys, err := jsonElementKeys(base)
+	if err != nil {
+		return nil, true, err
+	}
+	inLeft, inRight, inBase := keySet(leftKeys), keySet(rightKeys), keySet(baseKeys)
+
+	union := make([]interface{}, 0, len(left)+len(right))
+	seen := make(map[string]struct{}, len(left)+len(right))
+	for _, side := range []struct {
+		elements []interface{}
+		keys     []string
+		other    map[string]struct{}
+	}{{left, leftKeys, inRight}, {right, rightKeys, inLeft}} {
+		for i, key := range side.keys {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			_, wasInBase := inBase[key]
+			_, inOther := side.other[key]
+			if wasInBase && !inOther {
+				// removed by the other side
+				continue
+			}
+			seen[key] = struct{}{}
+			union = append(union, side.elements[i])
+		}
+	}
+	return types.JSONDocument{Val: union}, false, nil
+}
+
+// jsonArrayElements returns the elements of the JSON array |v|, or false if |v| isn't an array. A NULL value is an
+// empty array.
+func jsonArrayElements(ctx *sql.Context, v interface{}) ([]interface{}, bool, error) {
+	if v == nil {
+		return nil, true, nil
+	}
+	wrapper, ok := v.(sql.JSONWrapper)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected type for JSON value: %T", v)
+	}
+	doc, err := wrapper.ToInterface(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	elements, ok := doc.([]interface{})
+	return elements, ok, nil
+}
+
+// jsonElementKeys returns a string for each of |elements| that is equal for equal JSON values.
+func jsonElementKeys(elements []interface{}) ([]string, error) {
+	keys := make([]string, len(elements))
+	for i, e := range elements {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = string(b)
+	}
+	return keys, nil
+}
+
+func keySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// concatText returns |leftVal| followed by |rightVal|, or by only the text |rightVal| appended to |baseVal| when both
+// sides appended to it. If the result is too long for |sqlType|, the values are left in conflict.
+func concatText(ctx *sql.Context, sqlType sql.Type, baseVal, leftVal, rightVal interface{}) (result interface{}, conflict bool, err error) {
+	var strs [3]string
+	for i, v := range []interface{}{baseVal, leftVal, rightVal} {
+		if strs[i], err = textValue(ctx, v); err != nil {
+			return nil, true, err
+		}
+	}
+	base, left, right := strs[0], strs[1], strs[2]
+
+	concatenated := left + right
+	if base != "" && strings.HasPrefix(left, base) && strings.HasPrefix(right, base) {
+		concatenated = left + right[len(base):]
+	}
+	result, inRange, err := sqlType.Convert(ctx, concatenated)
+	if err != nil || inRange == sql.OutOfRange {
+		return nil, true, nil
+	}
+	return result, false, nil
+}
+
+func textValue(ctx context.Context, v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	s, _, err := types.LongText.Convert(ctx, v)
+	if err != nil {
+		return "", err
+	}
+	return s.(string), nil
+}
+
+// commitTimestamp returns the user timestamp of |cm|, or the zero time if |cm| is nil.
+func commitTimestamp(ctx context.Context, cm *doltdb.Commit) (time.Time, error) {
+	if cm == nil {
+		return time.Time{}, nil
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(meta.UserTimestampMillis()), nil
+}
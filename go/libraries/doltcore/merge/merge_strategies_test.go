@@ -0,0 +1,176 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func TestTryMergeWithStrategies(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	now := time.Now()
+
+	// the third column is modified on both sides, and would be a conflict without a strategy
+	tc := testCase{
+		row:      build(2, 2, 128),
+		mergeRow: build(1, 3, 255),
+		ancRow:   build(1, 2, 100),
+		rowCnt:   3, mRowCnt: 3, aRowCnt: 3,
+	}
+	tests := []struct {
+		name      string
+		strategy  MergeStrategy
+		oursTime  time.Time
+		expected  []*int
+		conflict  bool
+		expectErr bool
+	}{
+		{name: "no strategy", conflict: true},
+		{name: "ours", strategy: MergeStrategyOurs, expected: build(2, 3, 128)},
+		{name: "theirs", strategy: MergeStrategyTheirs, expected: build(2, 3, 255)},
+		{name: "max", strategy: MergeStrategyMax, expected: build(2, 3, 255)},
+		{name: "min", strategy: MergeStrategyMin, expected: build(2, 3, 128)},
+		{name: "sum", strategy: MergeStrategySum, expected: build(2, 3, 283)},
+		{name: "latest ours", strategy: MergeStrategyLatest, oursTime: now.Add(time.Hour), expected: build(2, 3, 128)},
+		{name: "latest theirs", strategy: MergeStrategyLatest, oursTime: now.Add(-time.Hour), expected: build(2, 3, 255)},
+		{name: "latest without timestamps", strategy: MergeStrategyLatest, conflict: true},
+		{name: "inapplicable", strategy: MergeStrategyConcat, expectErr: true},
+		{name: "unknown", strategy: "newest", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rmt := createRowMergeStruct(tc)
+			v := NewValueMerger(ctx, rmt.mergedSch, rmt.leftSch, rmt.rightSch, rmt.baseSch, syncPool, nil)
+			v.tableName = "t"
+			v.strategies = []MergeStrategy{"", "", test.strategy}
+			if !test.oursTime.IsZero() {
+				v.oursTime, v.theirsTime = test.oursTime, now
+			}
+
+			merged, ok, err := v.TryMerge(ctx, rmt.row, rmt.mergeRow, rmt.ancRow)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.conflict, !ok)
+			if !test.conflict {
+				vD := rmt.mergedSch.GetValueDescriptor(v.ns)
+				expected := buildTup(rmt.mergedSch, test.expected)
+				assert.Equal(t, vD.Format(ctx, expected), vD.Format(ctx, merged))
+			}
+		})
+	}
+}
+
+func TestSumDeltas(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	result, conflict, err := sumDeltas(ctx, gmstypes.Int64, int64(10), int64(15), int64(12))
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, int64(17), result)
+
+	result, conflict, err = sumDeltas(ctx, gmstypes.Int64, nil, int64(3), int64(4))
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, int64(7), result)
+
+	result, conflict, err = sumDeltas(ctx, gmstypes.Float64, 1.5, 2.0, 3.0)
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, 3.5, result)
+
+	_, conflict, err = sumDeltas(ctx, gmstypes.Int8, int8(0), int8(100), int8(100))
+	require.NoError(t, err)
+	assert.True(t, conflict)
+}
+
+func TestUnionJSONArrays(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	doc := func(v ...interface{}) sql.JSONWrapper {
+		return gmstypes.JSONDocument{Val: v}
+	}
+
+	result, conflict, err := unionJSONArrays(ctx, doc("a", "b"), doc("a", "b", "c"), doc("b", "d"))
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, gmstypes.JSONDocument{Val: []interface{}{"b", "c", "d"}}, result)
+
+	result, conflict, err = unionJSONArrays(ctx, nil, doc(1.0, map[string]interface{}{"k": "v"}), doc(map[string]interface{}{"k": "v"}, 2.0))
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, gmstypes.JSONDocument{Val: []interface{}{1.0, map[string]interface{}{"k": "v"}, 2.0}}, result)
+
+	_, conflict, err = unionJSONArrays(ctx, doc("a"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, doc("b"))
+	require.NoError(t, err)
+	assert.True(t, conflict)
+}
+
+func TestConcatText(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	result, conflict, err := concatText(ctx, gmstypes.Text, "log:", "log: ours", "log: theirs")
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, "log: ours theirs", result)
+
+	result, conflict, err = concatText(ctx, gmstypes.Text, "base", "ours", "theirs")
+	require.NoError(t, err)
+	assert.False(t, conflict)
+	assert.Equal(t, "ourstheirs", result)
+
+	_, conflict, err = concatText(ctx, gmstypes.MustCreateStringWithDefaults(sqltypes.VarChar, 4), nil, "abc", "def")
+	require.NoError(t, err)
+	assert.True(t, conflict)
+}
+
+func TestParseStrategyOptions(t *testing.T) {
+	so, err := ParseStrategyOptions("theirs, Customers=ours")
+	require.NoError(t, err)
+	assert.Equal(t, MergeStrategyTheirs, so.Default)
+
+	strategy, ok := so.ForTable(doltdb.TableName{Name: "customers"})
+	assert.True(t, ok)
+	assert.Equal(t, MergeStrategyOurs, strategy)
+	strategy, ok = so.ForTable(doltdb.TableName{Name: "orders"})
+	assert.True(t, ok)
+	assert.Equal(t, MergeStrategyTheirs, strategy)
+
+	// without a default, only the named tables are resolved
+	so, err = ParseStrategyOptions("orders=theirs")
+	require.NoError(t, err)
+	_, ok = so.ForTable(doltdb.TableName{Name: "customers"})
+	assert.False(t, ok)
+
+	var nilOpts *StrategyOptions
+	_, ok = nilOpts.ForTable(doltdb.TableName{Name: "customers"})
+	assert.False(t, ok)
+
+	for _, invalid := range []string{"", "max", "=ours", "t=", "t=union", "ours,"} {
+		_, err = ParseStrategyOptions(invalid)
+		assert.True(t, ErrInvalidStrategyOption.Is(err), invalid)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// renamedTable is a table that was renamed, or had columns renamed, on one or both sides of a merge.
+type renamedTable struct {
+	left, right, anc doltdb.TableName
+
+	// leftCols and rightCols map the ancestor tags of columns renamed on each side to the tags they have on that side
+	leftCols, rightCols map[uint64]uint64
+}
+
+// mergeRenames holds the renamed tables of a merge, keyed by the name each is merged under.
+type mergeRenames struct {
+	tables map[doltdb.TableName]renamedTable
+
+	// merged holds the other names of renamed tables, which are merged as part of the table under its new name
+	merged map[doltdb.TableName]struct{}
+}
+
+// sideRename is a table renamed on one side of a merge since the ancestor.
+type sideRename struct {
+	name doltdb.TableName
+	cols map[uint64]uint64
+}
+
+// renameSimilarityVar is the session variable holding the similarity at which merges follow renames. It is
+// dsess.DoltFindRenames, which this package can't import.
+const renameSimilarityVar = "dolt_find_renames"
+
+// renameSimilarity returns the similarity at which the merges of the session in |ctx| follow renames, which is zero
+// unless the session has set @@dolt_find_renames.
+func renameSimilarity(ctx *sql.Context) (int, error) {
+	similarity, err := ctx.Session.GetSessionVariable(ctx, renameSimilarityVar)
+	if err != nil {
+		return 0, err
+	}
+	switch similarity := similarity.(type) {
+	case int64:
+		return int(similarity), nil
+	case int8:
+		return int(similarity), nil
+	default:
+		return 0, nil
+	}
+}
+
+// findMergeRenames finds the tables and columns renamed by either side of a merge since the ancestor, so that changes
+// made to them on the other side can follow them. Renames that keep column tags are found by their overlapping
+// schemas, and renames that don't, such as a table copied under a new name or a column dropped and added again, are
+// found when they are at least |similarity| percent alike. A table renamed to different names on each side, or
+// dropped on one side, is merged by name.
+func findMergeRenames(ctx context.Context, ourRoot, theirRoot, ancRoot doltdb.RootValue, similarity int) (*mergeRenames, error) {
+	ours, err := renamesSinceAncestor(ctx, ancRoot, ourRoot, similarity)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := renamesSinceAncestor(ctx, ancRoot, theirRoot, similarity)
+	if err != nil {
+		return nil, err
+	}
+
+	var ancNames []doltdb.TableName
+	for name := range ours {
+		ancNames = append(ancNames, name)
+	}
+	for name := range theirs {
+		if _, ok := ours[name]; !ok {
+			ancNames = append(ancNames, name)
+		}
+	}
+	sort.Slice(ancNames, func(i, j int) bool {
+		return ancNames[i].Less(ancNames[j])
+	})
+
+	renames := &mergeRenames{
+		tables: make(map[doltdb.TableName]renamedTable),
+		merged: make(map[doltdb.TableName]struct{}),
+	}
+	for _, ancName := range ancNames {
+		rt := renamedTable{left: ancName, right: ancName, anc: ancName}
+		if r, ok := ours[ancName]; ok {
+			rt.left, rt.leftCols = r.name, r.cols
+		}
+		if r, ok := theirs[ancName]; ok {
+			rt.right, rt.rightCols = r.name, r.cols
+		}
+		if rt.left != ancName && rt.right != ancName && rt.left != rt.right {
+			continue
+		}
+
+		name := rt.left
+		if name == ancName {
+			name = rt.right
+		}
+		if _, ok := renames.tables[name]; ok {
+			continue
+		}
+
+		ok, err := canMergeRenamed(ctx, ourRoot, theirRoot, rt, name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		renames.tables[name] = rt
+		if rt.left != name {
+			renames.merged[rt.left] = struct{}{}
+		}
+		if rt.right != name {
+			renames.merged[rt.right] = struct{}{}
+		}
+	}
+
+	return renames, nil
+}
+
+// canMergeRenamed returns whether |rt| can be merged under |name|: both sides must still have the table, and neither
+// side may have an unrelated table called |name|.
+func canMergeRenamed(ctx context.Context, ourRoot, theirRoot doltdb.RootValue, rt renamedTable, name doltdb.TableName) (bool, error) {
+	if ok, err := ourRoot.HasTable(ctx, rt.left); err != nil || !ok {
+		return false, err
+	}
+	if ok, err := theirRoot.HasTable(ctx, rt.right); err != nil || !ok {
+		return false, err
+	}
+	if rt.left != name {
+		if ok, err := ourRoot.HasTable(ctx, name); err != nil || ok {
+			return false, err
+		}
+	}
+	if rt.right != name {
+		if ok, err := theirRoot.HasTable(ctx, name); err != nil || ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// renamesSinceAncestor returns the tables of |ancRoot| that were renamed, or had columns renamed, in |root|, keyed by
+// their ancestor name.
+func renamesSinceAncestor(ctx context.Context, ancRoot, root doltdb.RootValue, similarity int) (map[doltdb.TableName]sideRename, error) {
+	deltas, err := diff.GetTableDeltas(ctx, ancRoot, root)
+	if err != nil {
+		return nil, err
+	}
+	deltas, err = diff.DetectRenames(ctx, deltas, similarity)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make(map[doltdb.TableName]sideRename)
+	for _, td := range deltas {
+		if td.FromTable == nil || td.ToTable == nil {
+			continue
+		}
+		if td.FromName != td.ToName || len(td.RenamedColumns) > 0 {
+			renames[td.FromName] = sideRename{name: td.ToName, cols: td.RenamedColumns}
+		}
+	}
+	return renames, nil
+}
+
+// names returns the names of the table merged as |tblName| in the left, right and ancestor roots.
+func (r *mergeRenames) names(tblName doltdb.TableName) (left, right, anc doltdb.TableName) {
+	if r != nil {
+		if rt, ok := r.tables[tblName]; ok {
+			return rt.left, rt.right, rt.anc
+		}
+	}
+	return tblName, tblName, tblName
+}
+
+// isMerged returns whether |tblName| is the old name of a renamed table, and so is merged under its new name.
+func (r *mergeRenames) isMerged(tblName doltdb.TableName) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.merged[tblName]
+	return ok
+}
+
+// retagToLeft rewrites the ancestor and right schemas of |tm| to use the tags that the same columns have in the left
+// schema, so that columns renamed without keeping their tags are merged as one column.
+func (r *mergeRenames) retagToLeft(tm *TableMerger) error {
+	if r == nil || tm.leftSch == nil || tm.rightSch == nil || tm.ancSch == nil {
+		return nil
+	}
+	rt, ok := r.tables[tm.name]
+	if !ok || (len(rt.leftCols) == 0 && len(rt.rightCols) == 0) {
+		return nil
+	}
+
+	ancTags := make(map[uint64]uint64)
+	rightTags := make(map[uint64]uint64)
+	for _, col := range tm.ancSch.GetAllCols().GetColumns() {
+		left, ok := rt.leftCols[col.Tag]
+		if !ok {
+			left = col.Tag
+		}
+		right, ok := rt.rightCols[col.Tag]
+		if !ok {
+			right = col.Tag
+		}
+		if left != col.Tag {
+			ancTags[col.Tag] = left
+		}
+		if right != left {
+			rightTags[right] = left
+		}
+	}
+
+	var err error
+	if tm.ancSch, err = retagUnlessTaken(tm.ancSch, ancTags); err != nil {
+		return err
+	}
+	if tm.rightSch, err = retagUnlessTaken(tm.rightSch, rightTags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// retagUnlessTaken retags the columns of |sch| as given by |tags|, leaving out any column that |sch| does not have and
+// any column whose new tag is used by another column that keeps its tag.
+func retagUnlessTaken(sch schema.Schema, tags map[uint64]uint64) (schema.Schema, error) {
+	cols := sch.GetAllCols()
+	for changed := true; changed; {
+		changed = false
+		for from, to := range tags {
+			_, hasFrom := cols.GetByTag(from)
+			_, taken := cols.GetByTag(to)
+			_, moved := tags[to]
+			if !hasFrom || (taken && !moved) {
+				delete(tags, from)
+				changed = true
+			}
+		}
+	}
+
+	if len(tags) == 0 {
+		return sch, nil
+	}
+	return schema.RetagColumns(sch, tags)
+}
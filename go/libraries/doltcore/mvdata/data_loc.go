@@ -60,6 +60,15 @@ const (
 
 	// ParquetFile is the format of a data location that is a .paquet file
 	ParquetFile DataFormat = ".parquet"
+
+	// ArrowFile is the format of a data location that is an .arrow file in the Arrow IPC file format
+	ArrowFile DataFormat = ".arrow"
+
+	// ArrowStreamFile is the format of a data location that is an .arrows file in the Arrow IPC stream format
+	ArrowStreamFile DataFormat = ".arrows"
+
+	// AvroFile is the format of a data location that is an .avro object container file
+	AvroFile DataFormat = ".avro"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -81,6 +90,12 @@ func (df DataFormat) ReadableStr() string {
 		return "sql file"
 	case ParquetFile:
 		return "parquet file"
+	case ArrowFile:
+		return "arrow file"
+	case ArrowStreamFile:
+		return "arrow stream file"
+	case AvroFile:
+		return "avro file"
 	default:
 		return "invalid"
 	}
@@ -127,6 +142,12 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 			dataFmt = SqlFile
 		case string(ParquetFile):
 			dataFmt = ParquetFile
+		case string(ArrowFile), ".feather":
+			dataFmt = ArrowFile
+		case string(ArrowStreamFile):
+			dataFmt = ArrowStreamFile
+		case string(AvroFile):
+			dataFmt = AvroFile
 		}
 	}
 
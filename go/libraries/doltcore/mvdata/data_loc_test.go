@@ -71,6 +71,10 @@ func TestBasics(t *testing.T) {
 		{NewDataLocation("file.json", ""), JsonFile.ReadableStr() + ":file.json", true},
 		{NewDataLocation("file.jsonl", ""), JsonlFile.ReadableStr() + ":file.jsonl", true},
 		{NewDataLocation("file.ignored", "jsonl"), JsonlFile.ReadableStr() + ":file.ignored", true},
+		{NewDataLocation("file.arrow", ""), ArrowFile.ReadableStr() + ":file.arrow", true},
+		{NewDataLocation("file.feather", ""), ArrowFile.ReadableStr() + ":file.feather", true},
+		{NewDataLocation("file.arrows", ""), ArrowStreamFile.ReadableStr() + ":file.arrows", true},
+		{NewDataLocation("file.avro", ""), AvroFile.ReadableStr() + ":file.avro", true},
 		// {NewDataLocation("file.nbf", ""), NbfFile, "file.nbf", true},
 	}
 
@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/transform"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 )
@@ -127,6 +128,19 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		return err
 	}
 
+	// New and emptied tables are built bottom-up. With --continue, rows must be checked for duplicate keys as they are
+	// inserted, so the input is not sorted first. Unsorted input is then written row by row.
+	if s.importOption == CreateOp || s.importOption == ReplaceOp {
+		bulkMode := dsess.BulkLoadExternalSort
+		if s.contOnErr {
+			bulkMode = dsess.BulkLoadSorted
+		}
+		err = s.sqlCtx.SetSessionVariable(s.sqlCtx, dsess.DoltBulkLoad, bulkMode)
+		if err != nil {
+			return err
+		}
+	}
+
 	updateStats := func(row sql.Row) {
 		if row == nil {
 			return
@@ -28,6 +28,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/avro"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
@@ -53,6 +55,12 @@ func DFFromString(dfStr string) DataFormat {
 		return SqlFile
 	case "parquet", ".parquet":
 		return ParquetFile
+	case "arrow", ".arrow", "feather", ".feather":
+		return ArrowFile
+	case "arrows", ".arrows":
+		return ArrowStreamFile
+	case "avro", ".avro":
+		return AvroFile
 	default:
 		return InvalidDataFormat
 	}
@@ -159,6 +167,14 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 		}
 		rd, rErr := parquet.OpenParquetReader(root.VRW(), dl.Path, tableSch)
 		return rd, false, rErr
+
+	case ArrowFile, ArrowStreamFile:
+		rd, err := arrow.OpenArrowReader(ctx, dl.Path, fs)
+		return rd, false, err
+
+	case AvroFile:
+		rd, err := avro.OpenAvroReader(ctx, dl.Path, fs)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New("unsupported format")
@@ -224,6 +240,12 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 		}
 	case ParquetFile:
 		return parquet.NewParquetRowWriterForFile(ctx, outSch, mvOpts.DestName())
+	case ArrowFile:
+		return arrow.NewArrowFileWriter(ctx, wr, outSch)
+	case ArrowStreamFile:
+		return arrow.NewArrowStreamWriter(ctx, wr, outSch)
+	case AvroFile:
+		return avro.NewAvroWriter(ctx, wr, outSch, mvOpts.SrcName())
 	}
 
 	panic("Invalid Data Format." + string(dl.Format))
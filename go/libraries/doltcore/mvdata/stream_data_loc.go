@@ -25,6 +25,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/avro"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -65,6 +67,14 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, o
 		csvInfo := CreateCSVInfo(opts, "|")
 		rd, err := csv.NewCSVReader(root.VRW().Format(), io.NopCloser(dl.Reader), csvInfo)
 		return rd, false, err
+
+	case ArrowStreamFile:
+		rd, err := arrow.NewArrowReader(ctx, io.NopCloser(dl.Reader))
+		return rd, false, err
+
+	case AvroFile:
+		rd, err := avro.NewAvroReader(ctx, io.NopCloser(dl.Reader))
+		return rd, false, err
 	}
 
 	return nil, false, errors.New(string(dl.Format) + "is an unsupported format to read from stdin")
@@ -79,6 +89,12 @@ func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataM
 
 	case PsvFile:
 		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo().SetDelim("|"))
+
+	case ArrowStreamFile:
+		return arrow.NewArrowStreamWriter(ctx, iohelp.NopWrCloser(dl.Writer), outSch)
+
+	case AvroFile:
+		return avro.NewAvroWriter(ctx, iohelp.NopWrCloser(dl.Writer), outSch, mvOpts.SrcName())
 	}
 
 	return nil, errors.New(string(dl.Format) + "is an unsupported format to write to stdout")
@@ -0,0 +1,340 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrNothingToPrune is returned by PruneHistory when no commits match the prune options.
+var ErrNothingToPrune = errors.New("nothing to prune: no commits match the given options")
+
+// PruneOptions selects the commits removed by PruneHistory. Commits are selected along the first-parent history of
+// the head being pruned: the newest commit that is beyond the last |Keep| commits, or that was authored before
+// |Before|, is the prune boundary. The boundary and all of its ancestors are pruned.
+type PruneOptions struct {
+	// Keep is the number of commits on the first-parent history to keep, or zero to keep any number of commits.
+	Keep int
+	// Before prunes commits authored before this time, unless it is the zero time.
+	Before time.Time
+
+	// Name and Email identify the committer of the new root commit.
+	Name  string
+	Email string
+	// Message is the commit message of the new root commit. If empty, a message naming the boundary is used.
+	Message string
+}
+
+// PruneResult describes the history rewritten by PruneHistory.
+type PruneResult struct {
+	// NewHead is the rewritten head commit.
+	NewHead *doltdb.Commit
+	// Root is the new root commit, which has the same root value as the prune boundary and no parents.
+	Root *doltdb.Commit
+	// Boundary is the newest pruned commit.
+	Boundary *doltdb.Commit
+	// Rewritten maps the hash of each kept commit to the commit that replaces it.
+	Rewritten map[hash.Hash]*doltdb.Commit
+
+	boundaryHeight uint64
+}
+
+// PruneHistory rewrites the history of |head| so that the commits selected by |opts| are replaced by a single root
+// commit with the contents of the newest of them. Every kept commit is rewritten with the same root value and
+// metadata, but new parents. The rewritten commits are dangling; callers are responsible for updating refs to them.
+// Once no ref refers to the old commits, their chunks can be collected by garbage collection.
+func PruneHistory(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, opts PruneOptions) (*PruneResult, error) {
+	if opts.Keep < 0 {
+		return nil, fmt.Errorf("the number of commits to keep must not be negative")
+	}
+	if opts.Keep == 0 && opts.Before.IsZero() {
+		return nil, fmt.Errorf("either the number of commits to keep or a cutoff time must be given")
+	}
+
+	boundary, err := findPruneBoundary(ctx, head, opts)
+	if err != nil {
+		return nil, err
+	}
+	// A boundary without parents is already a root commit, so squashing it removes nothing
+	if boundary == nil || boundary.NumParents() == 0 {
+		return nil, ErrNothingToPrune
+	}
+
+	boundaryHash, err := boundary.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	boundaryMeta, err := boundary.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msg := opts.Message
+	if msg == "" {
+		msg = fmt.Sprintf("Pruned history up to commit %s", boundaryHash.String())
+	}
+	author := datas.CommitIdent{Name: opts.Name, Email: opts.Email, Date: boundaryMeta.Author.Date}
+	committer := datas.CommitIdent{Name: opts.Name, Email: opts.Email}
+	meta, err := datas.NewCommitMetaWithAuthorCommitter(author, committer, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := commitRootHash(ctx, ddb, boundary)
+	if err != nil {
+		return nil, err
+	}
+	root, err := ddb.CommitDanglingWithParentCommits(ctx, rootHash, nil, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &PruneResult{
+		Root:      root,
+		Boundary:  boundary,
+		Rewritten: make(map[hash.Hash]*doltdb.Commit),
+	}
+	if res.boundaryHeight, err = boundary.Height(); err != nil {
+		return nil, err
+	}
+	if res.NewHead, err = res.rewrite(ctx, ddb, head); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsPruned returns whether |cm| is the prune boundary or one of its ancestors.
+func (r *PruneResult) IsPruned(ctx context.Context, cm *doltdb.Commit) (bool, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return false, err
+	}
+	boundaryHash, err := r.Boundary.HashOf()
+	if err != nil {
+		return false, err
+	}
+	if h == boundaryHash {
+		return true, nil
+	}
+
+	// ancestors of the boundary are always lower than it, so most kept commits can be ruled out without a search
+	height, err := cm.Height()
+	if err != nil {
+		return false, err
+	}
+	if height >= r.boundaryHeight {
+		return false, nil
+	}
+
+	optAnc, err := doltdb.GetCommitAncestor(ctx, cm, r.Boundary)
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return optAnc.Addr == h, nil
+}
+
+// SharesPrunedHistory returns whether the history of |cm| contains a pruned commit. Such history is not rewritten, so
+// it keeps the pruned commits reachable.
+func (r *PruneResult) SharesPrunedHistory(ctx context.Context, cm *doltdb.Commit) (bool, error) {
+	// any common ancestor of |cm| and the boundary is an ancestor of the boundary, and so is pruned
+	_, err := doltdb.GetCommitAncestor(ctx, cm, r.Boundary)
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// rewrite rewrites |head| and every kept commit in its history, and returns the rewritten head.
+func (r *PruneResult) rewrite(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) (*doltdb.Commit, error) {
+	kept, pruned, err := r.keptCommits(ctx, ddb, head)
+	if err != nil {
+		return nil, err
+	}
+	if len(kept) == 0 {
+		return r.Root, nil
+	}
+
+	for _, k := range kept {
+		var parents []*doltdb.Commit
+		seen := make(hash.HashSet)
+		for _, ph := range k.parents {
+			rewrittenParent := r.Root
+			if !pruned.Has(ph) {
+				rewrittenParent = r.Rewritten[ph]
+			}
+			// merges of pruned commits can have several parents that were replaced by the new root
+			rph, err := rewrittenParent.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			if seen.Has(rph) {
+				continue
+			}
+			seen.Insert(rph)
+			parents = append(parents, rewrittenParent)
+		}
+
+		rootHash, err := commitRootHash(ctx, ddb, k.cm)
+		if err != nil {
+			return nil, err
+		}
+		meta, err := k.cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the signature covers the old parents and no longer applies
+		rewrittenMeta := *meta
+		rewrittenMeta.Signature = ""
+
+		rewritten, err := ddb.CommitDanglingWithParentCommits(ctx, rootHash, parents, &rewrittenMeta)
+		if err != nil {
+			return nil, err
+		}
+		r.Rewritten[k.hash] = rewritten
+	}
+	return r.Rewritten[kept[len(kept)-1].hash], nil
+}
+
+// keptCommit is a commit in the history being rewritten that is not pruned.
+type keptCommit struct {
+	cm      *doltdb.Commit
+	hash    hash.Hash
+	parents []hash.Hash
+}
+
+// keptCommits returns the commits in the history of |head| that are not pruned, sorted so that every commit comes
+// after its parents, along with the hashes of the pruned parents of those commits. The history is walked with an
+// explicit stack, since it can be much deeper than the call stack allows.
+func (r *PruneResult) keptCommits(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) ([]keptCommit, hash.HashSet, error) {
+	type frame struct {
+		kept    keptCommit
+		parents []*doltdb.Commit
+		next    int
+	}
+
+	var sorted []keptCommit
+	pruned := make(hash.HashSet)
+	visited := make(hash.HashSet)
+	var stack []*frame
+
+	// visit pushes |cm| onto the stack, unless it has already been visited or is pruned
+	visit := func(cm *doltdb.Commit) error {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		if visited.Has(h) {
+			return nil
+		}
+		visited.Insert(h)
+		if isPruned, err := r.IsPruned(ctx, cm); err != nil {
+			return err
+		} else if isPruned {
+			pruned.Insert(h)
+			return nil
+		}
+
+		optParents, err := ddb.ResolveAllParents(ctx, cm)
+		if err != nil {
+			return err
+		}
+		f := &frame{kept: keptCommit{cm: cm, hash: h}}
+		for _, optParent := range optParents {
+			parent, ok := optParent.ToCommit()
+			if !ok {
+				return doltdb.ErrGhostCommitEncountered
+			}
+			ph, err := parent.HashOf()
+			if err != nil {
+				return err
+			}
+			f.parents = append(f.parents, parent)
+			f.kept.parents = append(f.kept.parents, ph)
+		}
+		stack = append(stack, f)
+		return nil
+	}
+
+	if err := visit(head); err != nil {
+		return nil, nil, err
+	}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if top.next < len(top.parents) {
+			parent := top.parents[top.next]
+			top.next++
+			if err := visit(parent); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		// all parents of the commit on top of the stack have been sorted
+		stack = stack[:len(stack)-1]
+		sorted = append(sorted, top.kept)
+	}
+	return sorted, pruned, nil
+}
+
+// findPruneBoundary walks the first-parent history of |head| and returns the first commit selected by |opts|, or nil
+// if there is none.
+func findPruneBoundary(ctx context.Context, head *doltdb.Commit, opts PruneOptions) (*doltdb.Commit, error) {
+	cm := head
+	for i := 0; ; i++ {
+		if opts.Keep > 0 && i >= opts.Keep {
+			return cm, nil
+		}
+		if !opts.Before.IsZero() {
+			meta, err := cm.GetCommitMeta(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if meta.Author.Date.Time().Before(opts.Before) {
+				return cm, nil
+			}
+		}
+
+		if cm.NumParents() == 0 {
+			return nil, nil
+		}
+		optParent, err := cm.GetParent(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		parent, ok := optParent.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		cm = parent
+	}
+}
+
+func commitRootHash(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (hash.Hash, error) {
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	_, h, err := ddb.WriteRootValue(ctx, root)
+	return h, err
+}
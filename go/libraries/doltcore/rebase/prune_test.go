@@ -0,0 +1,165 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+)
+
+var setupPrune = []testCommand{
+	{cmd.SqlCmd{}, args{"-q", "insert into test values (3,3);"}},
+	{cmd.CommitCmd{}, args{"-am", "2021", "--date", "2021-01-01T00:00:00Z"}},
+	{cmd.SqlCmd{}, args{"-q", "insert into test values (4,4);"}},
+	{cmd.CommitCmd{}, args{"-am", "2022", "--date", "2022-01-01T00:00:00Z"}},
+	{cmd.SqlCmd{}, args{"-q", "insert into test values (5,5);"}},
+	{cmd.CommitCmd{}, args{"-am", "2023", "--date", "2023-01-01T00:00:00Z"}},
+	{cmd.SqlCmd{}, args{"-q", "insert into test values (6,6);"}},
+	{cmd.CommitCmd{}, args{"-am", "2024", "--date", "2024-01-01T00:00:00Z"}},
+}
+
+func TestPruneHistory(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         rebase.PruneOptions
+		boundaryMsg  string
+		expectedMsgs []string
+	}{
+		{
+			name:         "keep",
+			opts:         rebase.PruneOptions{Keep: 2},
+			boundaryMsg:  "2022",
+			expectedMsgs: []string{"2024", "2023", "pruned"},
+		},
+		{
+			name:         "before",
+			opts:         rebase.PruneOptions{Before: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+			boundaryMsg:  "2022",
+			expectedMsgs: []string{"2024", "2023", "pruned"},
+		},
+		{
+			name:         "keep and before select the newer boundary",
+			opts:         rebase.PruneOptions{Keep: 4, Before: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			boundaryMsg:  "2023",
+			expectedMsgs: []string{"2024", "pruned"},
+		},
+		{
+			name:         "keep one",
+			opts:         rebase.PruneOptions{Keep: 1},
+			boundaryMsg:  "2023",
+			expectedMsgs: []string{"2024", "pruned"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := setupFilterBranchTests(t)
+			defer dEnv.Close()
+			cliCtx, err := cmd.NewArgFreeCliContext(ctx, dEnv, dEnv.FS)
+			require.NoError(t, err)
+			defer cliCtx.Close()
+			for _, c := range setupPrune {
+				exitCode := c.cmd.Exec(ctx, c.cmd.Name(), c.args, dEnv, cliCtx)
+				require.Equal(t, 0, exitCode)
+			}
+
+			ddb := dEnv.DoltDB(ctx)
+			head, err := dEnv.HeadCommit(ctx)
+			require.NoError(t, err)
+
+			opts := test.opts
+			opts.Name, opts.Email, opts.Message = "pruner", "pruner@example.com", "pruned"
+			res, err := rebase.PruneHistory(ctx, ddb, head, opts)
+			require.NoError(t, err)
+
+			boundaryMeta, err := res.Boundary.GetCommitMeta(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, test.boundaryMsg, boundaryMeta.Description)
+			assert.Equal(t, 0, res.Root.NumParents())
+			assert.Equal(t, len(test.expectedMsgs)-1, len(res.Rewritten))
+			assert.Equal(t, rootHash(t, res.Boundary), rootHash(t, res.Root))
+			assert.Equal(t, rootHash(t, head), rootHash(t, res.NewHead))
+
+			// the root commit keeps the author date of the boundary
+			rootMeta, err := res.Root.GetCommitMeta(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, boundaryMeta.Author.Date.Time().Unix(), rootMeta.Author.Date.Time().Unix())
+			assert.Equal(t, "pruner", rootMeta.Committer.Name)
+
+			assert.Equal(t, test.expectedMsgs, firstParentMessages(t, res.NewHead))
+
+			pruned, err := res.IsPruned(ctx, head)
+			require.NoError(t, err)
+			assert.False(t, pruned)
+			pruned, err = res.IsPruned(ctx, res.Boundary)
+			require.NoError(t, err)
+			assert.True(t, pruned)
+
+			shares, err := res.SharesPrunedHistory(ctx, head)
+			require.NoError(t, err)
+			assert.True(t, shares)
+			shares, err = res.SharesPrunedHistory(ctx, res.NewHead)
+			require.NoError(t, err)
+			assert.False(t, shares)
+		})
+	}
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		ctx := context.Background()
+		dEnv := setupFilterBranchTests(t)
+		defer dEnv.Close()
+		head, err := dEnv.HeadCommit(ctx)
+		require.NoError(t, err)
+
+		opts := rebase.PruneOptions{Keep: 10, Name: "pruner", Email: "pruner@example.com"}
+		_, err = rebase.PruneHistory(ctx, dEnv.DoltDB(ctx), head, opts)
+		assert.ErrorIs(t, err, rebase.ErrNothingToPrune)
+	})
+}
+
+func rootHash(t *testing.T, cm *doltdb.Commit) string {
+	root, err := cm.GetRootValue(context.Background())
+	require.NoError(t, err)
+	h, err := root.HashOf()
+	require.NoError(t, err)
+	return h.String()
+}
+
+func firstParentMessages(t *testing.T, cm *doltdb.Commit) []string {
+	ctx := context.Background()
+	var msgs []string
+	for {
+		meta, err := cm.GetCommitMeta(ctx)
+		require.NoError(t, err)
+		msgs = append(msgs, meta.Description)
+		if cm.NumParents() == 0 {
+			return msgs
+		}
+		optParent, err := cm.GetParent(ctx, 0)
+		require.NoError(t, err)
+		var ok bool
+		cm, ok = optParent.ToCommit()
+		require.True(t, ok)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultNotesRefName is the name of the notes ref used when none is given.
+const DefaultNotesRefName = "commits"
+
+// NotesRef is a reference to a history of notes attached to commits. Notes refs point to commits, but unlike branches
+// the value of those commits is the set of notes rather than a root value.
+type NotesRef struct {
+	name string
+}
+
+var _ DoltRef = NotesRef{}
+
+// NewNotesRef creates a reference to the notes ref with the given name, which may be given fully qualified, e.g.
+// refs/notes/commits.
+func NewNotesRef(name string) NotesRef {
+	if IsRef(name) {
+		prefix := PrefixForType(NotesRefType)
+		if strings.HasPrefix(name, prefix) {
+			name = name[len(prefix):]
+		} else {
+			panic(name + " is a ref that is not of type " + prefix)
+		}
+	}
+
+	return NotesRef{name}
+}
+
+// ParseNotesRef creates a reference to the notes ref with the given name, which may be given fully qualified, e.g.
+// refs/notes/commits. Unlike NewNotesRef, it returns an error for a ref of another type or a name that isn't valid.
+func ParseNotesRef(name string) (NotesRef, error) {
+	if IsRef(name) {
+		prefix := PrefixForType(NotesRefType)
+		if !strings.HasPrefix(name, prefix) {
+			return NotesRef{}, fmt.Errorf("'%s' is not a notes ref; notes refs must be under %s", name, prefix)
+		}
+		name = name[len(prefix):]
+	}
+	if !IsValidBranchName(name) {
+		return NotesRef{}, fmt.Errorf("'%s' is not a valid notes ref name", name)
+	}
+
+	return NotesRef{name}, nil
+}
+
+// GetType will return NotesRefType
+func (nr NotesRef) GetType() RefType {
+	return NotesRefType
+}
+
+// GetPath returns the name of the notes ref
+func (nr NotesRef) GetPath() string {
+	return nr.name
+}
+
+// String returns the fully qualified reference name e.g. refs/notes/commits
+func (nr NotesRef) String() string {
+	return String(nr)
+}
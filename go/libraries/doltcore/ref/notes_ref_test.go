@@ -0,0 +1,41 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotesRef(t *testing.T) {
+	nr, err := ParseNotesRef("commits")
+	require.NoError(t, err)
+	assert.Equal(t, "refs/notes/commits", nr.String())
+
+	nr, err = ParseNotesRef("refs/notes/review/v1")
+	require.NoError(t, err)
+	assert.Equal(t, "review/v1", nr.GetPath())
+
+	_, err = ParseNotesRef("refs/heads/main")
+	assert.Error(t, err)
+	_, err = ParseNotesRef("refs/notes/")
+	assert.Error(t, err)
+	_, err = ParseNotesRef("bad..name")
+	assert.Error(t, err)
+	_, err = ParseNotesRef("")
+	assert.Error(t, err)
+}
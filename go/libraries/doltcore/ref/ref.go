@@ -60,6 +60,9 @@ const (
 
 	// TupleRefType is a reference to a statistics table
 	TupleRefType RefType = "tuples"
+
+	// NotesRefType is a reference to a history of commit notes
+	NotesRefType RefType = "notes"
 )
 
 // HeadRefTypes are the ref types that point to a HEAD and contain a Commit struct. These are the types that are
@@ -212,5 +215,9 @@ func Parse(str string) (DoltRef, error) {
 		return NewTupleRef(str[len(prefix):]), nil
 	}
 
+	if prefix := PrefixForType(NotesRefType); strings.HasPrefix(str, prefix) {
+		return NewNotesRef(str[len(prefix):]), nil
+	}
+
 	return nil, ErrUnknownRefType
 }
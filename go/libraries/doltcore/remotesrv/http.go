@@ -400,18 +400,18 @@ func getFileReader(path string) (io.ReadCloser, int64, error) {
 	return openFile(path)
 }
 
-func openFile(path string) (*os.File, int64, error) {
-	info, err := os.Stat(path)
-	if err != nil {
+// openFile opens the table file, archive or journal at the given path. Files encrypted at rest are served decrypted.
+func openFile(path string) (nbs.StorageFileReader, int64, error) {
+	if _, err := os.Stat(path); err != nil {
 		return nil, 0, fmt.Errorf("failed to get stats for file at path %s: %w", path, err)
 	}
 
-	f, err := os.Open(path)
+	f, size, err := nbs.OpenStorageFile(path)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to open file at path %s: %w", path, err)
 	}
 
-	return f, info.Size(), nil
+	return f, size, nil
 }
 
 type closerReaderWrapper struct {
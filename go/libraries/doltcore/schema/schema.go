@@ -401,6 +401,71 @@ func CopyIndexes(from, to Schema) Schema {
 	return toSch
 }
 
+// RetagColumns returns a copy of |sch| in which every column whose tag is a key of |tags| has the tag it maps to
+// instead. Its secondary indexes are rebuilt over the new tags. Rows are stored by column position, so the returned
+// schema describes the same row data as |sch|.
+func RetagColumns(sch Schema, tags map[uint64]uint64) (Schema, error) {
+	if len(tags) == 0 {
+		return sch, nil
+	}
+	retag := func(tag uint64) uint64 {
+		if t, ok := tags[tag]; ok {
+			return t
+		}
+		return tag
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	var pkCols []Column
+	for i := range cols {
+		cols[i].Tag = retag(cols[i].Tag)
+		if cols[i].IsPartOfPK {
+			pkCols = append(pkCols, cols[i])
+		}
+	}
+	allCols := NewColCollection(cols...)
+	pkColl := NewColCollection(pkCols...)
+	if len(sch.GetPkOrdinals()) == len(pkCols) {
+		// primary key columns are ordered by their ordinals, not their position in the table
+		ordered := make([]Column, len(pkCols))
+		for i, ord := range sch.GetPkOrdinals() {
+			ordered[i] = cols[ord]
+		}
+		pkColl = NewColCollection(ordered...)
+	}
+
+	indexes := NewIndexCollection(allCols, pkColl)
+	err := sch.Indexes().Iter(func(idx Index) (stop bool, err error) {
+		idxTags := make([]uint64, len(idx.IndexedColumnTags()))
+		for i, tag := range idx.IndexedColumnTags() {
+			idxTags[i] = retag(tag)
+		}
+		_, err = indexes.UnsafeAddIndexByColTags(idx.Name(), idxTags, idx.PrefixLengths(), IndexProperties{
+			IsUnique:           idx.IsUnique(),
+			IsSpatial:          idx.IsSpatial(),
+			IsFullText:         idx.IsFullText(),
+			IsVector:           idx.IsVector(),
+			IsUserDefined:      idx.IsUserDefined(),
+			Comment:            idx.Comment(),
+			Predicate:          idx.Predicate(),
+			FullTextProperties: idx.FullTextProperties(),
+			VectorProperties:   idx.VectorProperties(),
+		})
+		return err != nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	retagged, err := NewSchema(allCols, sch.GetPkOrdinals(), sch.GetCollation(), indexes, sch.Checks().Copy())
+	if err != nil {
+		return nil, err
+	}
+	retagged.SetComment(sch.GetComment())
+	retagged.SetTargetRowSize(sch.GetTargetRowSize())
+	return retagged, nil
+}
+
 // GetKeyColumnTags returns a set.Uint64Set containing the column tags
 // of every key column of every primary and secondary index in |sch|.
 func GetKeyColumnTags(sch Schema) *set.Uint64Set {
@@ -378,6 +378,29 @@ func TestArePrimaryKeySetsDiffableTypeChanges(t *testing.T) {
 	}
 }
 
+func TestRetagColumns(t *testing.T) {
+	sch, err := SchemaFromCols(NewColCollection(allCols...))
+	require.NoError(t, err)
+	_, err = sch.Indexes().AddIndexByColNames("idx_title", []string{titleColName, ageColName}, nil, IndexProperties{IsUserDefined: true})
+	require.NoError(t, err)
+
+	retagged, err := RetagColumns(sch, map[uint64]uint64{fnColTag: 100, titleColTag: 101})
+	require.NoError(t, err)
+
+	assert.Equal(t, sch.GetAllCols().GetColumnNames(), retagged.GetAllCols().GetColumnNames())
+	assert.Equal(t, []uint64{lnColTag, 100}, retagged.GetPKCols().Tags)
+	assert.Equal(t, []uint64{addrColTag, ageColTag, 101, reservedColTag}, retagged.GetNonPKCols().Tags)
+	assert.Equal(t, []uint64{101, ageColTag}, retagged.Indexes().GetByName("idx_title").IndexedColumnTags())
+
+	// the original schema is unchanged
+	assert.Equal(t, []uint64{lnColTag, fnColTag}, sch.GetPKCols().Tags)
+	assert.Equal(t, []uint64{titleColTag, ageColTag}, sch.Indexes().GetByName("idx_title").IndexedColumnTags())
+
+	same, err := RetagColumns(sch, nil)
+	require.NoError(t, err)
+	assert.True(t, SchemasAreEqual(sch, same))
+}
+
 func testSchema(method string, sch Schema, t *testing.T) {
 	validateCols(t, allCols, sch.GetAllCols(), method+"GetAllCols")
 	validateCols(t, pkCols, sch.GetPKCols(), method+"GetPKCols")
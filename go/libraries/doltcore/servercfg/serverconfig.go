@@ -20,7 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -64,6 +66,7 @@ const (
 	DefaultAutoGCBehaviorEnable      = true
 	DefaultDoltTransactionCommit     = false
 	DefaultBranchActivityTracking    = false
+	DefaultCIWorkflows               = false
 	DefaultMaxConnections            = 1000
 	DefaultMaxWaitConnections        = 50
 	DefaultMaxWaitConnectionsTimeout = 60 * time.Second
@@ -74,11 +77,14 @@ const (
 	DefaultMetricsHost               = ""
 	DefaultMetricsPort               = -1
 	DefaultMCPPort                   = 7007
+	DefaultHTTPAPIPort               = 8080
+	DefaultJournalShippingInterval   = time.Second
 	DefaultAllowCleartextPasswords   = false
 	DefaultMySQLUnixSocketFilePath   = "/tmp/mysql.sock"
 	DefaultMaxLoggedQueryLen         = 0
 	DefaultEncodeLoggedQuery         = false
 	DefaultCompressionLevel          = 1
+	DefaultWebhookOutboxDir          = "webhook_outbox"
 )
 
 func ptr[T any](t T) *T {
@@ -129,6 +135,58 @@ type ClusterStandbyRemoteConfig interface {
 	RemoteURLTemplate() string
 }
 
+// WebhookConfig configures an HTTP endpoint which is notified whenever a branch head moves.
+type WebhookConfig interface {
+	// URL is the http or https URL that events are POSTed to.
+	URL() string
+	// Databases restricts the webhook to the named databases. Empty means all databases.
+	Databases() []string
+	// Branches restricts the webhook to branches matching these glob patterns. Empty means all branches.
+	Branches() []string
+	// Headers are added to every request.
+	Headers() map[string]string
+	// Secret, if set, is used to sign each request body with HMAC-SHA256.
+	Secret() string
+	// Timeout bounds a single delivery attempt. Zero means the default timeout.
+	Timeout() time.Duration
+	// MaxAttempts is the number of failed attempts after which an event is abandoned. Zero means
+	// deliveries are retried until they succeed.
+	MaxAttempts() int
+}
+
+// HTTPAPIConfig configures the HTTP query API, which runs SQL sent in HTTP requests and returns the results as JSON.
+type HTTPAPIConfig interface {
+	// Host is the address the HTTP API listens on.
+	Host() string
+	// Port is the port the HTTP API listens on.
+	Port() int
+	// TLSCert is a path to a PEM-encoded certificate chain. The API is served over HTTPS when it and TLSKey are set.
+	TLSCert() string
+	// TLSKey is a path to a PEM-encoded private key.
+	TLSKey() string
+	// Jwks names the entry of the jwks config used to validate bearer tokens. Only users identified with the
+	// authentication_dolt_jwt plugin against this entry may use bearer tokens. Empty means bearer tokens are not
+	// accepted.
+	Jwks() string
+	// AllowedOrigins are the origins browsers may make cross-origin requests from. "*" allows any origin.
+	AllowedOrigins() []string
+	// MaxRows bounds the number of rows returned for a single query. Zero means no limit.
+	MaxRows() int
+	// QueryTimeout bounds the time a single request may run for. Zero means no limit.
+	QueryTimeout() time.Duration
+	// AllowCleartextPasswords is true if basic auth passwords are accepted over HTTP without TLS.
+	AllowCleartextPasswords() bool
+}
+
+// JournalShippingConfig configures journal shipping, which continuously copies the chunk journal of every database
+// to a backup location, from which `dolt restore --to-time` can rebuild a database as of any root update.
+type JournalShippingConfig interface {
+	// URL is the backup location. Each database is shipped to a directory of it named after the database.
+	URL() string
+	// Interval is the time between shipping the journal records of each database.
+	Interval() time.Duration
+}
+
 type JwksConfig struct {
 	Name        string            `yaml:"name"`
 	LocationUrl string            `yaml:"location_url"`
@@ -165,6 +223,9 @@ type ServerConfig interface {
 	DoltTransactionCommit() bool
 	// BranchActivityTracking enables or disables the tracking of branch activity for the dolt_branch_activity table
 	BranchActivityTracking() bool
+	// CIWorkflows enables or disables running the dolt ci workflows of each database when its branches are pushed to
+	// or committed to, and on their schedules.
+	CIWorkflows() bool
 	// DataDir is the path to a directory to use as the data dir, both to create new databases and locate existing ones.
 	DataDir() string
 	// CfgDir is the path to a directory to use to store the dolt configuration files.
@@ -249,6 +310,12 @@ type ServerConfig interface {
 	ValueSet(value string) bool
 	// AutoGCBehavior defines parameters around how auto-GC works for the running server.
 	AutoGCBehavior() AutoGCBehavior
+	// Webhooks returns the HTTP endpoints which are notified when branch heads move.
+	Webhooks() []WebhookConfig
+	// HTTPAPI returns the configuration of the HTTP query API, or nil if it is not enabled.
+	HTTPAPI() HTTPAPIConfig
+	// JournalShipping returns the configuration of journal shipping, or nil if it is not enabled.
+	JournalShipping() JournalShippingConfig
 	// Overrides returns any overrides that are defined. This is primarily used by Doltgres.
 	Overrides() sql.EngineOverrides
 }
@@ -269,6 +336,7 @@ func defaultServerConfigYAML() *YAMLConfig {
 			AutoCommit:             ptr(DefaultAutoCommit),
 			DoltTransactionCommit:  ptr(DefaultDoltTransactionCommit),
 			BranchActivityTracking: ptr(DefaultBranchActivityTracking),
+			CIWorkflows:            ptr(DefaultCIWorkflows),
 			AutoGCBehavior: &AutoGCBehaviorYAMLConfig{
 				Enable_:       ptr(DefaultAutoGCBehaviorEnable),
 				ArchiveLevel_: ptr(DefaultCompressionLevel),
@@ -332,6 +400,15 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateWebhooksConfig(config.Webhooks()); err != nil {
+		return err
+	}
+	if err := ValidateHTTPAPIConfig(config.HTTPAPI(), config.JwksConfig()); err != nil {
+		return err
+	}
+	if err := ValidateJournalShippingConfig(config.JournalShipping()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
@@ -348,6 +425,7 @@ const (
 	AutoCommitKey                     = "autocommit"
 	DoltTransactionCommitKey          = "dolt_transaction_commit"
 	BranchActivityTrackingKey         = "branch_activity_tracking"
+	CIWorkflowsKey                    = "ci_workflows"
 	DataDirKey                        = "data_dir"
 	CfgDirKey                         = "cfg_dir"
 	MaxConnectionsKey                 = "max_connections"
@@ -378,6 +456,9 @@ const (
 	RemotesapiReadOnlyKey             = "remotesapi_read_only"
 	ClusterConfigKey                  = "cluster_config"
 	EventSchedulerKey                 = "event_scheduler"
+	WebhooksKey                       = "webhooks"
+	HTTPAPIKey                        = "http_api"
+	JournalShippingKey                = "journal_shipping"
 )
 
 type SystemVariableTarget interface {
@@ -417,6 +498,73 @@ func ApplySystemVariables(ctx *sql.Context, cfg ServerConfig, sysVarTarget Syste
 	return nil
 }
 
+func ValidateWebhooksConfig(webhooks []WebhookConfig) error {
+	seen := make(map[string]struct{}, len(webhooks))
+	for i, w := range webhooks {
+		u, err := url.Parse(w.URL())
+		if err != nil {
+			return fmt.Errorf("webhooks[%d]: url: %w", i, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhooks[%d]: url: is \"%s\" but must be an absolute http or https URL", i, w.URL())
+		}
+		if _, ok := seen[w.URL()]; ok {
+			return fmt.Errorf("webhooks[%d]: url: \"%s\" is configured more than once", i, w.URL())
+		}
+		seen[w.URL()] = struct{}{}
+		for _, pattern := range w.Branches() {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("webhooks[%d]: branches: invalid pattern \"%s\"", i, pattern)
+			}
+		}
+		if w.MaxAttempts() < 0 {
+			return fmt.Errorf("webhooks[%d]: max_attempts: is %d but must be >= 0", i, w.MaxAttempts())
+		}
+	}
+	return nil
+}
+
+func ValidateHTTPAPIConfig(config HTTPAPIConfig, jwks []JwksConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.Host() != "localhost" && net.ParseIP(config.Host()) == nil {
+		return fmt.Errorf("http_api: host: is \"%s\" but must be localhost or an IP address", config.Host())
+	}
+	if config.Port() < 1 || config.Port() > 65535 {
+		return fmt.Errorf("http_api: port: is %d but must be in the range 1-65535", config.Port())
+	}
+	if (config.TLSCert() == "") != (config.TLSKey() == "") {
+		return fmt.Errorf("http_api: tls_cert and tls_key must be set together")
+	}
+	if config.Jwks() != "" {
+		found := false
+		for _, j := range jwks {
+			found = found || j.Name == config.Jwks()
+		}
+		if !found {
+			return fmt.Errorf("http_api: jwks: no jwks config is named \"%s\"", config.Jwks())
+		}
+	}
+	if config.MaxRows() < 0 {
+		return fmt.Errorf("http_api: max_rows: is %d but must be >= 0", config.MaxRows())
+	}
+	return nil
+}
+
+func ValidateJournalShippingConfig(config JournalShippingConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.URL() == "" {
+		return fmt.Errorf("journal_shipping: url: must be set")
+	}
+	if config.Interval() <= 0 {
+		return fmt.Errorf("journal_shipping: interval_millis: must be > 0")
+	}
+	return nil
+}
+
 func ValidateClusterConfig(config ClusterConfig) error {
 	if config == nil {
 		return nil
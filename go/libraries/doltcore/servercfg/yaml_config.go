@@ -71,6 +71,8 @@ type BehaviorYAMLConfig struct {
 	AutoGCBehavior *AutoGCBehaviorYAMLConfig `yaml:"auto_gc_behavior,omitempty" minver:"1.50.0"`
 
 	BranchActivityTracking *bool `yaml:"branch_activity_tracking,omitempty" minver:"1.77.0"`
+
+	CIWorkflows *bool `yaml:"ci_workflows,omitempty" minver:"TBD"`
 }
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
@@ -143,6 +145,92 @@ type MCPServerYAMLConfig struct {
 	Database *string `yaml:"database,omitempty"`
 }
 
+// HTTPAPIYAMLConfig contains configuration for the HTTP query API served alongside sql-server
+type HTTPAPIYAMLConfig struct {
+	Host_               *string  `yaml:"host,omitempty"`
+	Port_               *int     `yaml:"port,omitempty"`
+	TLSCert_            string   `yaml:"tls_cert,omitempty"`
+	TLSKey_             string   `yaml:"tls_key,omitempty"`
+	Jwks_               string   `yaml:"jwks,omitempty"`
+	AllowedOrigins_     []string `yaml:"allowed_origins,omitempty"`
+	MaxRows_            *int     `yaml:"max_rows,omitempty"`
+	QueryTimeoutMillis_ *uint64  `yaml:"query_timeout_millis,omitempty"`
+	// AllowCleartextPasswords_ accepts basic auth passwords over HTTP without TLS.
+	AllowCleartextPasswords_ *bool `yaml:"allow_cleartext_passwords,omitempty"`
+}
+
+var _ HTTPAPIConfig = (*HTTPAPIYAMLConfig)(nil)
+
+func (h *HTTPAPIYAMLConfig) Host() string {
+	if h.Host_ == nil {
+		return DefaultHost
+	}
+	return *h.Host_
+}
+
+func (h *HTTPAPIYAMLConfig) Port() int {
+	if h.Port_ == nil {
+		return DefaultHTTPAPIPort
+	}
+	return *h.Port_
+}
+
+func (h *HTTPAPIYAMLConfig) TLSCert() string {
+	return h.TLSCert_
+}
+
+func (h *HTTPAPIYAMLConfig) TLSKey() string {
+	return h.TLSKey_
+}
+
+func (h *HTTPAPIYAMLConfig) Jwks() string {
+	return h.Jwks_
+}
+
+func (h *HTTPAPIYAMLConfig) AllowedOrigins() []string {
+	return h.AllowedOrigins_
+}
+
+func (h *HTTPAPIYAMLConfig) MaxRows() int {
+	if h.MaxRows_ == nil {
+		return 0
+	}
+	return *h.MaxRows_
+}
+
+func (h *HTTPAPIYAMLConfig) QueryTimeout() time.Duration {
+	if h.QueryTimeoutMillis_ == nil {
+		return 0
+	}
+	return time.Duration(*h.QueryTimeoutMillis_) * time.Millisecond
+}
+
+func (h *HTTPAPIYAMLConfig) AllowCleartextPasswords() bool {
+	if h.AllowCleartextPasswords_ == nil {
+		return DefaultAllowCleartextPasswords
+	}
+	return *h.AllowCleartextPasswords_
+}
+
+// JournalShippingYAMLConfig contains configuration for shipping the chunk journal of every database to a backup location
+type JournalShippingYAMLConfig struct {
+	URL_            string  `yaml:"url"`
+	IntervalMillis_ *uint64 `yaml:"interval_millis,omitempty"`
+}
+
+var _ JournalShippingConfig = (*JournalShippingYAMLConfig)(nil)
+
+func (j *JournalShippingYAMLConfig) URL() string {
+	return j.URL_
+}
+
+func (j *JournalShippingYAMLConfig) Interval() time.Duration {
+	if j.IntervalMillis_ == nil {
+		return DefaultJournalShippingInterval
+	}
+	return time.Duration(*j.IntervalMillis_) * time.Millisecond
+}
+
 type UserSessionVars struct {
 	Name string                 `yaml:"name"`
 	Vars map[string]interface{} `yaml:"vars"`
@@ -165,12 +253,15 @@ type YAMLConfig struct {
 	PrivilegeFile     *string                `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string                `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
-	Vars            []UserSessionVars      `yaml:"user_session_vars"`
-	SystemVars_     map[string]interface{} `yaml:"system_variables,omitempty" minver:"1.11.1"`
-	Jwks            []JwksConfig           `yaml:"jwks"`
-	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
-	MetricsConfig   MetricsYAMLConfig      `yaml:"metrics,omitempty"`
-	ClusterCfg      *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+	Vars             []UserSessionVars          `yaml:"user_session_vars"`
+	SystemVars_      map[string]interface{}     `yaml:"system_variables,omitempty" minver:"1.11.1"`
+	Jwks             []JwksConfig               `yaml:"jwks"`
+	GoldenMysqlConn  *string                    `yaml:"golden_mysql_conn,omitempty"`
+	MetricsConfig    MetricsYAMLConfig          `yaml:"metrics,omitempty"`
+	ClusterCfg       *ClusterYAMLConfig         `yaml:"cluster,omitempty"`
+	Webhooks_        []WebhookYAMLConfig        `yaml:"webhooks,omitempty" minver:"TBD"`
+	HTTPAPI_         *HTTPAPIYAMLConfig         `yaml:"http_api,omitempty" minver:"TBD"`
+	JournalShipping_ *JournalShippingYAMLConfig `yaml:"journal_shipping,omitempty" minver:"TBD"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -221,6 +312,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			DisableClientMultiStatements: ptr(cfg.DisableClientMultiStatements()),
 			DoltTransactionCommit:        ptr(cfg.DoltTransactionCommit()),
 			BranchActivityTracking:       ptr(cfg.BranchActivityTracking()),
+			CIWorkflows:                  ptr(cfg.CIWorkflows()),
 			EventSchedulerStatus:         ptr(cfg.EventSchedulerStatus()),
 			AutoGCBehavior:               autoGCBehavior,
 		},
@@ -260,7 +352,69 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       systemVars,
 		Vars:              cfg.UserVars(),
 		Jwks:              cfg.JwksConfig(),
+		Webhooks_:         webhooksAsYAMLConfig(cfg.Webhooks()),
+		HTTPAPI_:          httpAPIAsYAMLConfig(cfg.HTTPAPI()),
+		JournalShipping_:  journalShippingAsYAMLConfig(cfg.JournalShipping()),
+	}
+}
+
+func httpAPIAsYAMLConfig(config HTTPAPIConfig) *HTTPAPIYAMLConfig {
+	if config == nil {
+		return nil
+	}
+
+	ret := &HTTPAPIYAMLConfig{
+		Host_:           ptr(config.Host()),
+		Port_:           ptr(config.Port()),
+		TLSCert_:        config.TLSCert(),
+		TLSKey_:         config.TLSKey(),
+		Jwks_:           config.Jwks(),
+		AllowedOrigins_: config.AllowedOrigins(),
+	}
+	if config.MaxRows() != 0 {
+		ret.MaxRows_ = ptr(config.MaxRows())
+	}
+	if config.QueryTimeout() != 0 {
+		ret.QueryTimeoutMillis_ = ptr(uint64(config.QueryTimeout().Milliseconds()))
+	}
+	if config.AllowCleartextPasswords() {
+		ret.AllowCleartextPasswords_ = ptr(true)
+	}
+	return ret
+}
+
+func journalShippingAsYAMLConfig(config JournalShippingConfig) *JournalShippingYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &JournalShippingYAMLConfig{
+		URL_:            config.URL(),
+		IntervalMillis_: ptr(uint64(config.Interval().Milliseconds())),
+	}
+}
+
+func webhooksAsYAMLConfig(webhooks []WebhookConfig) []WebhookYAMLConfig {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ret := make([]WebhookYAMLConfig, len(webhooks))
+	for i, w := range webhooks {
+		ret[i] = WebhookYAMLConfig{
+			URL_:       w.URL(),
+			Databases_: w.Databases(),
+			Branches_:  w.Branches(),
+			Headers_:   w.Headers(),
+			Secret_:    w.Secret(),
+		}
+		if w.Timeout() != 0 {
+			ret[i].TimeoutMillis_ = ptr(uint64(w.Timeout().Milliseconds()))
+		}
+		if w.MaxAttempts() != 0 {
+			ret[i].MaxAttempts_ = ptr(w.MaxAttempts())
+		}
 	}
+	return ret
 }
 
 func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
@@ -300,6 +454,7 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			DisableClientMultiStatements: zeroIf(ptr(cfg.DisableClientMultiStatements()), !cfg.ValueSet(DisableClientMultiStatementsKey)),
 			DoltTransactionCommit:        zeroIf(ptr(cfg.DoltTransactionCommit()), !cfg.ValueSet(DoltTransactionCommitKey)),
 			BranchActivityTracking:       zeroIf(ptr(cfg.BranchActivityTracking()), !cfg.ValueSet(BranchActivityTrackingKey)),
+			CIWorkflows:                  zeroIf(ptr(cfg.CIWorkflows()), !cfg.ValueSet(CIWorkflowsKey)),
 			EventSchedulerStatus:         zeroIf(ptr(cfg.EventSchedulerStatus()), !cfg.ValueSet(EventSchedulerKey)),
 		},
 		ListenerConfig: ListenerYAMLConfig{
@@ -338,6 +493,9 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       zeroIf(systemVars, !cfg.ValueSet(SystemVarsKey)),
 		Vars:              zeroIf(cfg.UserVars(), !cfg.ValueSet(UserVarsKey)),
 		Jwks:              zeroIf(cfg.JwksConfig(), !cfg.ValueSet(JwksConfigKey)),
+		Webhooks_:         zeroIf(webhooksAsYAMLConfig(cfg.Webhooks()), !cfg.ValueSet(WebhooksKey)),
+		HTTPAPI_:          zeroIf(httpAPIAsYAMLConfig(cfg.HTTPAPI()), !cfg.ValueSet(HTTPAPIKey)),
+		JournalShipping_:  zeroIf(journalShippingAsYAMLConfig(cfg.JournalShipping()), !cfg.ValueSet(JournalShippingKey)),
 	}
 }
 
@@ -508,6 +666,30 @@ func (cfg YAMLConfig) withPlaceholdersFilledIn() YAMLConfig {
 		withPlaceholders.Jwks = []JwksConfig{}
 	}
 
+	if withPlaceholders.Webhooks_ == nil {
+		withPlaceholders.Webhooks_ = []WebhookYAMLConfig{
+			{
+				URL_:      "https://hooks.example.com/dolt",
+				Branches_: []string{"main"},
+			},
+		}
+	}
+
+	if withPlaceholders.HTTPAPI_ == nil {
+		withPlaceholders.HTTPAPI_ = &HTTPAPIYAMLConfig{
+			Host_:           ptr(DefaultHost),
+			Port_:           ptr(DefaultHTTPAPIPort),
+			AllowedOrigins_: []string{"https://app.example.com"},
+		}
+	}
+
+	if withPlaceholders.JournalShipping_ == nil {
+		withPlaceholders.JournalShipping_ = &JournalShippingYAMLConfig{
+			URL_:            "file:///var/backups/dolt",
+			IntervalMillis_: ptr(uint64(DefaultJournalShippingInterval.Milliseconds())),
+		}
+	}
+
 	return withPlaceholders
 }
 
@@ -711,6 +893,15 @@ func (cfg YAMLConfig) BranchActivityTracking() bool {
 	return *cfg.BehaviorConfig.BranchActivityTracking
 }
 
+// CIWorkflows enables or disables running dolt ci workflows on push and on their schedules
+func (cfg YAMLConfig) CIWorkflows() bool {
+	if cfg.BehaviorConfig.CIWorkflows == nil {
+		return DefaultCIWorkflows
+	}
+
+	return *cfg.BehaviorConfig.CIWorkflows
+}
+
 // LogLevel returns the level of logging that the server will use.
 func (cfg YAMLConfig) LogLevel() LogLevel {
 	if cfg.LogLevelStr == nil {
@@ -1026,6 +1217,34 @@ func (cfg YAMLConfig) AutoGCBehavior() AutoGCBehavior {
 	return cfg.BehaviorConfig.AutoGCBehavior
 }
 
+// Webhooks returns the HTTP endpoints which are notified when branch heads move.
+func (cfg YAMLConfig) Webhooks() []WebhookConfig {
+	if len(cfg.Webhooks_) == 0 {
+		return nil
+	}
+	ret := make([]WebhookConfig, len(cfg.Webhooks_))
+	for i := range cfg.Webhooks_ {
+		ret[i] = cfg.Webhooks_[i]
+	}
+	return ret
+}
+
+// HTTPAPI returns the configuration of the HTTP query API, or nil if it is not enabled.
+func (cfg YAMLConfig) HTTPAPI() HTTPAPIConfig {
+	if cfg.HTTPAPI_ == nil {
+		return nil
+	}
+	return cfg.HTTPAPI_
+}
+
+// JournalShipping returns the configuration of journal shipping, or nil if it is not enabled.
+func (cfg YAMLConfig) JournalShipping() JournalShippingConfig {
+	if cfg.JournalShipping_ == nil {
+		return nil
+	}
+	return cfg.JournalShipping_
+}
+
 func (cfg YAMLConfig) EventSchedulerStatus() string {
 	if cfg.BehaviorConfig.EventSchedulerStatus == nil {
 		return "ON"
@@ -1051,6 +1270,54 @@ type ClusterYAMLConfig struct {
 	RemotesAPI      ClusterRemotesAPIYAMLConfig `yaml:"remotesapi"`
 }
 
+// WebhookYAMLConfig configures one HTTP endpoint which receives a JSON event whenever a branch head
+// moves.
+type WebhookYAMLConfig struct {
+	URL_           string            `yaml:"url"`
+	Databases_     []string          `yaml:"databases,omitempty"`
+	Branches_      []string          `yaml:"branches,omitempty"`
+	Headers_       map[string]string `yaml:"headers,omitempty"`
+	Secret_        string            `yaml:"secret,omitempty"`
+	TimeoutMillis_ *uint64           `yaml:"timeout_millis,omitempty"`
+	MaxAttempts_   *int              `yaml:"max_attempts,omitempty"`
+}
+
+var _ WebhookConfig = WebhookYAMLConfig{}
+
+func (w WebhookYAMLConfig) URL() string {
+	return w.URL_
+}
+
+func (w WebhookYAMLConfig) Databases() []string {
+	return w.Databases_
+}
+
+func (w WebhookYAMLConfig) Branches() []string {
+	return w.Branches_
+}
+
+func (w WebhookYAMLConfig) Headers() map[string]string {
+	return w.Headers_
+}
+
+func (w WebhookYAMLConfig) Secret() string {
+	return w.Secret_
+}
+
+func (w WebhookYAMLConfig) Timeout() time.Duration {
+	if w.TimeoutMillis_ == nil {
+		return 0
+	}
+	return time.Duration(*w.TimeoutMillis_) * time.Millisecond
+}
+
+func (w WebhookYAMLConfig) MaxAttempts() int {
+	if w.MaxAttempts_ == nil {
+		return 0
+	}
+	return *w.MaxAttempts_
+}
+
 type StandbyRemoteYAMLConfig struct {
 	Name_              string `yaml:"name"`
 	RemoteURLTemplate_ string `yaml:"remote_url_template"`
@@ -1136,6 +1403,12 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 		return cfg.ListenerConfig.MaxConnectionsTimeoutMs != nil
 	case EventSchedulerKey:
 		return cfg.BehaviorConfig.EventSchedulerStatus != nil
+	case WebhooksKey:
+		return cfg.Webhooks_ != nil
+	case HTTPAPIKey:
+		return cfg.HTTPAPI_ != nil
+	case JournalShippingKey:
+		return cfg.JournalShipping_ != nil
 	}
 	return false
 }
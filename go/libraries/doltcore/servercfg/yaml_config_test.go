@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,7 @@ behavior:
         enable: true
         archive_level: 1
     branch_activity_tracking: false
+    ci_workflows: true
 
 listener:
     host: localhost
@@ -104,6 +106,7 @@ jwks:
 	expected.BehaviorConfig.DoltTransactionCommit = &trueValue
 	falseValue := false
 	expected.BehaviorConfig.BranchActivityTracking = &falseValue
+	expected.BehaviorConfig.CIWorkflows = &trueValue
 	expected.CfgDirStr = nillableStrPtr("")
 	expected.PrivilegeFile = ptr("some other nonsense")
 	expected.BranchControlFile = ptr("third nonsense")
@@ -208,6 +211,249 @@ cluster:
 	require.Equal(t, "http://doltdb-1.doltdb:50051/{database}", config.ClusterConfig().StandbyRemotes()[0].RemoteURLTemplate())
 }
 
+func TestUnmarshallWebhooks(t *testing.T) {
+	testStr := `
+webhooks:
+- url: https://hooks.example.com/dolt
+  databases: [mydb]
+  branches: [main, release/*]
+  headers:
+    Authorization: Bearer abc
+  secret: s3cr3t
+  timeout_millis: 2500
+  max_attempts: 5
+- url: http://localhost:8080/hook
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	webhooks := config.Webhooks()
+	require.Len(t, webhooks, 2)
+	assert.Equal(t, "https://hooks.example.com/dolt", webhooks[0].URL())
+	assert.Equal(t, []string{"mydb"}, webhooks[0].Databases())
+	assert.Equal(t, []string{"main", "release/*"}, webhooks[0].Branches())
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, webhooks[0].Headers())
+	assert.Equal(t, "s3cr3t", webhooks[0].Secret())
+	assert.Equal(t, 2500*time.Millisecond, webhooks[0].Timeout())
+	assert.Equal(t, 5, webhooks[0].MaxAttempts())
+	assert.Equal(t, "http://localhost:8080/hook", webhooks[1].URL())
+	assert.Empty(t, webhooks[1].Branches())
+	assert.Equal(t, time.Duration(0), webhooks[1].Timeout())
+	assert.Equal(t, 0, webhooks[1].MaxAttempts())
+	require.NoError(t, ValidateWebhooksConfig(webhooks))
+}
+
+func TestValidateWebhooksConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+		Error  bool
+	}{
+		{
+			Name:   "no webhooks",
+			Config: "",
+			Error:  false,
+		},
+		{
+			Name: "relative url",
+			Config: `
+webhooks:
+- url: /dolt
+`,
+			Error: true,
+		},
+		{
+			Name: "unsupported scheme",
+			Config: `
+webhooks:
+- url: ftp://hooks.example.com/dolt
+`,
+			Error: true,
+		},
+		{
+			Name: "duplicate url",
+			Config: `
+webhooks:
+- url: https://hooks.example.com/dolt
+- url: https://hooks.example.com/dolt
+`,
+			Error: true,
+		},
+		{
+			Name: "bad branch pattern",
+			Config: `
+webhooks:
+- url: https://hooks.example.com/dolt
+  branches: ["release/["]
+`,
+			Error: true,
+		},
+		{
+			Name: "negative max_attempts",
+			Config: `
+webhooks:
+- url: https://hooks.example.com/dolt
+  max_attempts: -1
+`,
+			Error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			if c.Error {
+				require.Error(t, ValidateWebhooksConfig(cfg.Webhooks()))
+			} else {
+				require.NoError(t, ValidateWebhooksConfig(cfg.Webhooks()))
+			}
+		})
+	}
+}
+
+func TestUnmarshallHTTPAPI(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.HTTPAPI())
+
+	testStr := `
+jwks:
+- name: auth0
+  location_url: https://example.auth0.com/.well-known/jwks.json
+  claims:
+    iss: https://example.auth0.com/
+    aud: dolt
+  fields_to_log: [sub]
+http_api:
+  host: 0.0.0.0
+  port: 9090
+  jwks: auth0
+  allowed_origins: ["https://app.example.com"]
+  max_rows: 1000
+  query_timeout_millis: 30000
+  allow_cleartext_passwords: true
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	api := config.HTTPAPI()
+	require.NotNil(t, api)
+	assert.Equal(t, "0.0.0.0", api.Host())
+	assert.Equal(t, 9090, api.Port())
+	assert.Equal(t, "auth0", api.Jwks())
+	assert.Equal(t, []string{"https://app.example.com"}, api.AllowedOrigins())
+	assert.Equal(t, 1000, api.MaxRows())
+	assert.Equal(t, 30*time.Second, api.QueryTimeout())
+	assert.True(t, api.AllowCleartextPasswords())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte("http_api: {}\n"))
+	require.NoError(t, err)
+	api = config.HTTPAPI()
+	require.NotNil(t, api)
+	assert.Equal(t, DefaultHost, api.Host())
+	assert.Equal(t, DefaultHTTPAPIPort, api.Port())
+	assert.Equal(t, 0, api.MaxRows())
+	assert.Equal(t, time.Duration(0), api.QueryTimeout())
+	assert.False(t, api.AllowCleartextPasswords())
+}
+
+func TestValidateHTTPAPIConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+		Error  bool
+	}{
+		{
+			Name:   "no http api",
+			Config: "",
+			Error:  false,
+		},
+		{
+			Name: "bad host",
+			Config: `
+http_api:
+  host: example.com
+`,
+			Error: true,
+		},
+		{
+			Name: "bad port",
+			Config: `
+http_api:
+  port: 70000
+`,
+			Error: true,
+		},
+		{
+			Name: "tls cert without key",
+			Config: `
+http_api:
+  tls_cert: cert.pem
+`,
+			Error: true,
+		},
+		{
+			Name: "unknown jwks",
+			Config: `
+http_api:
+  jwks: auth0
+`,
+			Error: true,
+		},
+		{
+			Name: "negative max_rows",
+			Config: `
+http_api:
+  max_rows: -1
+`,
+			Error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			if c.Error {
+				require.Error(t, ValidateHTTPAPIConfig(cfg.HTTPAPI(), cfg.JwksConfig()))
+			} else {
+				require.NoError(t, ValidateHTTPAPIConfig(cfg.HTTPAPI(), cfg.JwksConfig()))
+			}
+		})
+	}
+}
+
+func TestUnmarshallJournalShipping(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	assert.Nil(t, config.JournalShipping())
+
+	testStr := `
+journal_shipping:
+  url: file:///var/backups/dolt
+  interval_millis: 250
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	shipping := config.JournalShipping()
+	require.NotNil(t, shipping)
+	assert.Equal(t, "file:///var/backups/dolt", shipping.URL())
+	assert.Equal(t, 250*time.Millisecond, shipping.Interval())
+	require.NoError(t, ValidateConfig(config))
+
+	config, err = NewYamlConfig([]byte("journal_shipping:\n  url: localbs:///tmp/backups\n"))
+	require.NoError(t, err)
+	shipping = config.JournalShipping()
+	require.NotNil(t, shipping)
+	assert.Equal(t, DefaultJournalShippingInterval, shipping.Interval())
+
+	config, err = NewYamlConfig([]byte("journal_shipping: {}\n"))
+	require.NoError(t, err)
+	require.Error(t, ValidateJournalShippingConfig(config.JournalShipping()))
+
+	config, err = NewYamlConfig([]byte("journal_shipping:\n  url: file:///tmp/backups\n  interval_millis: 0\n"))
+	require.NoError(t, err)
+	require.Error(t, ValidateJournalShippingConfig(config.JournalShipping()))
+}
+
 func TestYamlConfigFromFileEnvInterpolation_String(t *testing.T) {
 	t.Setenv("DOLT_TEST_SQLSERVER_HOST", "127.0.0.1")
 
@@ -0,0 +1,474 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// CI workflows let a running SQL server run the workflows stored in
+// the dolt_ci tables of a database without anyone invoking
+// `dolt ci run`.
+//
+// A CIController is created for a running SQL Engine when CI
+// workflows are enabled. Post Commit Hooks are installed on every
+// database in the DoltDatabaseProvider for the SQL Engine. When a
+// branch head moves, the hook enqueues a push request for the new
+// head. Once a minute, a scheduler thread enqueues a schedule request
+// for every database. A single runner thread works through the
+// requests in order: it reads the workflows as of the commit in
+// question, runs those whose triggers match, and records the outcome
+// with doltdb.AddCIRun, where it can be queried from the dolt_ci_runs
+// and dolt_ci_run_steps system tables.
+//
+// Workflows are always run against a read-only revision database of
+// the commit which triggered them, so a workflow can never modify
+// the branch it is checking. Saved queries come from
+// dolt_query_catalog, which anyone who can write to a branch can
+// edit, so they are run with the privileges of the client whose
+// commit moved the branch head, and only if they are read-only.
+// Workflows which have no such client, such as those run on a
+// schedule or for a push received by the remotes API, are run as
+// ciWorkflowClient, which has no privileges until an administrator
+// creates the account and grants it read access.
+
+// ciRequestQueueSize bounds the number of pending requests. Requests which arrive while the queue is full are
+// dropped with a warning rather than blocking the commit which produced them.
+const ciRequestQueueSize = 1024
+
+// ciWorkflowClient is the account workflows are run as when no client triggered them.
+var ciWorkflowClient = sql.Client{User: "dolt_ci", Address: "localhost"}
+
+// The events recorded for CI runs, matching the trigger names of the workflow config.
+const (
+	ciEventPush     = "push"
+	ciEventSchedule = "schedule"
+)
+
+type CIController struct {
+	lgr      *logrus.Logger
+	requests chan ciRequest
+	hooks    map[string]*ciCommitHook
+	mu       sync.Mutex
+
+	ctxF     func(context.Context) (*sql.Context, error)
+	queryist cli.Queryist
+	catalog  sql.Catalog
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// ciRequest asks the runner thread to run the workflows of database |db| triggered by |event|.
+type ciRequest struct {
+	db    string
+	ddb   *doltdb.DoltDB
+	event string
+	// branch and commit are the branch head which moved, for push requests. For schedule requests they are
+	// resolved to the head of the database's default branch when the request is run.
+	branch string
+	commit string
+	// at is the minute a schedule request was made for.
+	at time.Time
+	// client is the client whose session the workflows are run with.
+	client sql.Client
+}
+
+func NewCIController(lgr *logrus.Logger) *CIController {
+	return &CIController{
+		lgr:      lgr,
+		requests: make(chan ciRequest, ciRequestQueueSize),
+		hooks:    make(map[string]*ciCommitHook),
+		now:      time.Now,
+	}
+}
+
+// During engine initialization, this should be called to start the
+// background threads which schedule and run workflows. Workflows are
+// run with sessions created by |ctxF| and queries made through
+// |queryist|. Saved queries are bound against |catalog| to check
+// that they are read-only.
+func (c *CIController) RunBackgroundThread(threads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error), queryist cli.Queryist, catalog sql.Catalog) error {
+	c.ctxF = ctxF
+	c.queryist = queryist
+	c.catalog = catalog
+	err := threads.Add("ci_workflow_scheduler_thread", c.schedulerThread)
+	if err != nil {
+		return err
+	}
+	return threads.Add("ci_workflow_runner_thread", c.runnerThread)
+}
+
+// During engine initialization, called on the original set of
+// databases to install CI commit hooks.
+func (c *CIController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		ddb := denv.DoltDB(ctx)
+		ddb.PrependCommitHooks(ctx, c.newCommitHook(db.Name(), ddb))
+	}
+	return nil
+}
+
+func (c *CIController) InitDatabaseHook() InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		ddb := env.DoltDB(ctx)
+		ddb.PrependCommitHooks(ctx, c.newCommitHook(name, ddb))
+		return nil
+	}
+}
+
+func (c *CIController) DropDatabaseHook() DropDatabaseHook {
+	return func(_ *sql.Context, name string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		hook := c.hooks[name]
+		if hook != nil {
+			hook.stop()
+			delete(c.hooks, name)
+		}
+	}
+}
+
+func (c *CIController) newCommitHook(name string, ddb *doltdb.DoltDB) *ciCommitHook {
+	ret := &ciCommitHook{
+		c:    c,
+		name: name,
+		ddb:  ddb,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev := c.hooks[name]; prev != nil {
+		prev.stop()
+	}
+	c.hooks[name] = ret
+	return ret
+}
+
+func (c *CIController) enqueue(req ciRequest) {
+	select {
+	case c.requests <- req:
+	default:
+		c.lgr.Warnf("sqle/ci: dropping %s request for database %s: too many pending requests", req.event, req.db)
+	}
+}
+
+// schedulerThread enqueues a schedule request for every database at the start of every minute.
+func (c *CIController) schedulerThread(ctx context.Context) {
+	for {
+		now := c.now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		c.mu.Lock()
+		for name, hook := range c.hooks {
+			c.enqueue(ciRequest{db: name, ddb: hook.ddb, event: ciEventSchedule, at: next, client: ciWorkflowClient})
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *CIController) runnerThread(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-c.requests:
+			c.runRequest(ctx, req)
+		}
+	}
+}
+
+// runRequest runs and records every workflow of the requested database whose triggers match |req|.
+func (c *CIController) runRequest(ctx context.Context, req ciRequest) {
+	sqlCtx, err := c.ctxF(ctx)
+	if err != nil {
+		c.lgr.Warnf("sqle/ci: could not create session to run workflows for %s: %v", req.db, err)
+		return
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+
+	if req.event == ciEventSchedule {
+		req.branch, req.commit, err = c.defaultBranchHead(sqlCtx, req.db)
+		if err != nil {
+			c.lgr.Warnf("sqle/ci: could not resolve the default branch of %s: %v", req.db, err)
+			return
+		}
+	}
+
+	_, err = cli.GetRowsForSql(c.queryist, sqlCtx, "USE "+quoteIdentifier(req.db+"/"+req.commit))
+	if err != nil {
+		c.lgr.Warnf("sqle/ci: could not use %s at commit %s: %v", req.db, req.commit, err)
+		return
+	}
+
+	hasTables, err := dolt_ci.HasDoltCITables(c.queryist, sqlCtx)
+	if err != nil {
+		c.lgr.Warnf("sqle/ci: could not read dolt ci tables of %s at commit %s: %v", req.db, req.commit, err)
+		return
+	}
+	if !hasTables {
+		return
+	}
+
+	wm := dolt_ci.NewWorkflowManager("", "", c.queryist.Query)
+	names, err := wm.ListWorkflows(sqlCtx)
+	if err != nil {
+		c.lgr.Warnf("sqle/ci: could not list workflows of %s at commit %s: %v", req.db, req.commit, err)
+		return
+	}
+
+	for _, name := range names {
+		config, err := wm.GetWorkflowConfig(sqlCtx, name)
+		if err != nil {
+			c.lgr.Warnf("sqle/ci: could not read workflow %s of %s at commit %s: %v", name, req.db, req.commit, err)
+			continue
+		}
+		if !workflowTriggered(config, req) {
+			continue
+		}
+
+		run := c.runWorkflow(ctx, name, config, req)
+		c.lgr.Infof("sqle/ci: workflow %s of %s finished with status %s for %s event on %s", name, req.db, run.Status, req.event, req.branch)
+		err = req.ddb.AddCIRun(ctx, run)
+		if err != nil {
+			c.lgr.Errorf("sqle/ci: could not record run of workflow %s of %s: %v", name, req.db, err)
+		}
+	}
+}
+
+// runWorkflow runs the workflow |name| in a session of the client of |req|.
+func (c *CIController) runWorkflow(ctx context.Context, name string, config *dolt_ci.WorkflowConfig, req ciRequest) doltdb.CIRun {
+	run := doltdb.CIRun{
+		Id:        uuid.NewString(),
+		Workflow:  name,
+		Event:     req.event,
+		Branch:    req.branch,
+		Commit:    req.commit,
+		StartedAt: c.now().UTC(),
+	}
+
+	result, err := c.runWorkflowAsClient(ctx, config, req)
+	run.FinishedAt = c.now().UTC()
+	if err != nil {
+		run.Status = doltdb.CIRunStatusError
+		run.Error = err.Error()
+		return run
+	}
+
+	run.Status = doltdb.CIRunStatusSuccess
+	for _, job := range result.Jobs {
+		for _, step := range job.Steps {
+			s := doltdb.CIRunStep{Job: job.Name, Step: step.Name, Status: doltdb.CIRunStatusSuccess}
+			if step.Err != nil {
+				s.Status = doltdb.CIRunStatusFailure
+				s.Failure = step.Err.Error()
+				run.Status = doltdb.CIRunStatusFailure
+			}
+			run.Steps = append(run.Steps, s)
+		}
+	}
+	return run
+}
+
+func (c *CIController) runWorkflowAsClient(ctx context.Context, config *dolt_ci.WorkflowConfig, req ciRequest) (*dolt_ci.WorkflowRunResult, error) {
+	sqlCtx, err := c.ctxF(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+	sqlCtx.Session.SetClient(req.client)
+
+	_, err = cli.GetRowsForSql(c.queryist, sqlCtx, "USE "+quoteIdentifier(req.db+"/"+req.commit))
+	if err != nil {
+		return nil, err
+	}
+	return dolt_ci.RunWorkflow(sqlCtx, c.queryist, config, c.checkQuery)
+}
+
+// checkQuery returns an error unless |query| is a single read-only statement.
+func (c *CIController) checkQuery(sqlCtx *sql.Context, query string) error {
+	statements, err := sqlparser.SplitStatementToPieces(query)
+	if err != nil {
+		return err
+	} else if len(statements) != 1 {
+		return errors.New("saved queries run by workflows must be a single statement")
+	}
+	isWrite, err := dtablefunctions.IsWriteQuery(query, sqlCtx, c.catalog)
+	if err != nil {
+		return err
+	} else if isWrite {
+		return errors.New("saved queries run by workflows must be read-only")
+	}
+	return nil
+}
+
+// defaultBranchHead returns the branch a new session for |db| starts on, and the hash of its head.
+func (c *CIController) defaultBranchHead(sqlCtx *sql.Context, db string) (string, string, error) {
+	_, err := cli.GetRowsForSql(c.queryist, sqlCtx, "USE "+quoteIdentifier(db))
+	if err != nil {
+		return "", "", err
+	}
+	rows, err := cli.GetRowsForSql(c.queryist, sqlCtx, "SELECT active_branch(), HASHOF('HEAD')")
+	if err != nil {
+		return "", "", err
+	}
+	if len(rows) != 1 || len(rows[0]) != 2 {
+		return "", "", errors.New("unexpected result resolving the default branch head")
+	}
+	branch, err := cli.QueryValueAsString(rows[0][0])
+	if err != nil {
+		return "", "", err
+	}
+	commit, err := cli.QueryValueAsString(rows[0][1])
+	if err != nil {
+		return "", "", err
+	}
+	if branch == "" || commit == "" {
+		return "", "", fmt.Errorf("database %s is not on a branch", db)
+	}
+	return branch, commit, nil
+}
+
+// workflowTriggered returns whether |config| has a trigger matching |req|.
+func workflowTriggered(config *dolt_ci.WorkflowConfig, req ciRequest) bool {
+	switch req.event {
+	case ciEventPush:
+		if config.On.Push == nil {
+			return false
+		}
+		if len(config.On.Push.Branches) == 0 {
+			return true
+		}
+		for _, b := range config.On.Push.Branches {
+			if strings.EqualFold(b.Value, req.branch) {
+				return true
+			}
+		}
+		return false
+	case ciEventSchedule:
+		for _, s := range config.On.Schedule {
+			cron, err := dolt_ci.ParseCronSchedule(s.Cron.Value)
+			if err != nil {
+				// stored workflows are validated before they are saved
+				continue
+			}
+			if cron.Matches(req.at) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func quoteIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// The doltdb.CommitHook which watches for branch head movements and
+// enqueues push requests for them.
+type ciCommitHook struct {
+	c    *CIController
+	name string
+	ddb  *doltdb.DoltDB
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+var _ doltdb.CommitHook = (*ciCommitHook)(nil)
+
+// Execute implements CommitHook. It enqueues a push request for every branch head which is moved to a commit.
+func (h *ciCommitHook) Execute(ctx context.Context, ds datas.Dataset, db *doltdb.DoltDB) (func(context.Context) error, error) {
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	dref, err := ref.Parse(ds.ID())
+	if err != nil || dref.GetType() != ref.BranchRefType {
+		return nil, nil
+	}
+	newHead, ok := ds.MaybeHeadAddr()
+	if !ok || newHead.IsEmpty() {
+		return nil, nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return nil, nil
+	}
+	client := ciWorkflowClient
+	if sqlCtx, ok := ctx.(*sql.Context); ok && sqlCtx.Session != nil && sqlCtx.Client().User != "" {
+		client = sqlCtx.Client()
+	}
+	h.c.enqueue(ciRequest{
+		db:     h.name,
+		ddb:    h.ddb,
+		event:  ciEventPush,
+		branch: dref.GetPath(),
+		commit: newHead.String(),
+		client: client,
+	})
+	return nil, nil
+}
+
+func (h *ciCommitHook) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+}
+
+func (*ciCommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
+
+func (*ciCommitHook) ExecuteForReplicaWrite() bool {
+	return false
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCICommitHook(t *testing.T) {
+	ctx := context.Background()
+
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_DOLT, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	c := NewCIController(logrus.New())
+	ddb.PrependCommitHooks(ctx, c.newCommitHook("mydb", ddb))
+
+	cs, err := doltdb.NewCommitSpec("main")
+	require.NoError(t, err)
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	cm, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	cmHash, err := cm.HashOf()
+	require.NoError(t, err)
+
+	// Creating a branch is a push of its head, deleting one is not.
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm, nil))
+	require.NoError(t, ddb.DeleteBranch(ctx, ref.NewBranchRef("feature"), nil))
+
+	require.Len(t, c.requests, 1)
+	req := <-c.requests
+	assert.Equal(t, "mydb", req.db)
+	assert.Equal(t, ddb, req.ddb)
+	assert.Equal(t, ciEventPush, req.event)
+	assert.Equal(t, "feature", req.branch)
+	assert.Equal(t, cmHash.String(), req.commit)
+	// Without a SQL session, the workflow runs as the restricted workflow account.
+	assert.Equal(t, ciWorkflowClient, req.client)
+
+	// Once the database is dropped, its hook stops enqueueing requests.
+	c.DropDatabaseHook()(nil, "mydb")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm, nil))
+	assert.Len(t, c.requests, 0)
+}
+
+func TestCIWorkflowTriggered(t *testing.T) {
+	config, err := dolt_ci.ParseWorkflowConfig(strings.NewReader(`name: checks
+on:
+  push:
+    branches:
+      - main
+  schedule:
+    - cron: "0 2 * * *"
+jobs:
+  - name: check
+    steps:
+      - name: run tests
+        dolt_test_groups:
+          - "*"
+`))
+	require.NoError(t, err)
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.March, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	assert.True(t, workflowTriggered(config, ciRequest{event: ciEventPush, branch: "main"}))
+	assert.True(t, workflowTriggered(config, ciRequest{event: ciEventPush, branch: "MAIN"}))
+	assert.False(t, workflowTriggered(config, ciRequest{event: ciEventPush, branch: "feature"}))
+	assert.True(t, workflowTriggered(config, ciRequest{event: ciEventSchedule, at: at(2, 0)}))
+	assert.False(t, workflowTriggered(config, ciRequest{event: ciEventSchedule, at: at(2, 1)}))
+
+	// A push trigger without branches matches every branch.
+	config.On.Push.Branches = nil
+	assert.True(t, workflowTriggered(config, ciRequest{event: ciEventPush, branch: "feature"}))
+
+	// Without a schedule, schedule requests never match.
+	config.On.Schedule = nil
+	assert.False(t, workflowTriggered(config, ciRequest{event: ciEventSchedule, at: at(2, 0)}))
+}
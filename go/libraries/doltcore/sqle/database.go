@@ -50,6 +50,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -324,7 +325,45 @@ func (db Database) getTableInsensitive(ctx *sql.Context, tblName string, readNon
 		return nil, false, err
 	}
 
-	return db.getTableInsensitiveWithRoot(ctx, nil, ds, root, tblName, "", readNonlocalTables)
+	tbl, ok, err := db.getTableInsensitiveWithRoot(ctx, nil, ds, root, tblName, "", readNonlocalTables)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return db.applyPolicies(ctx, tblName, tbl)
+}
+
+// applyPolicies returns |tbl| with the dolt_policies of the user table it is derived from applied to its rows, if any
+// of them apply to the current user. Tables that expose rows of a restricted user table which can't be filtered are
+// refused.
+func (db Database) applyPolicies(ctx *sql.Context, tblName string, tbl sql.Table) (sql.Table, bool, error) {
+	lwrName := strings.ToLower(tblName)
+	baseName, kind, refused := tblName, policy.KindTable, false
+	switch {
+	case strings.HasPrefix(lwrName, doltdb.DoltDiffTablePrefix):
+		baseName, kind = tblName[len(doltdb.DoltDiffTablePrefix):], policy.KindDiff
+	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
+		baseName, kind = tblName[len(doltdb.DoltCommitDiffTablePrefix):], policy.KindDiff
+	case strings.HasPrefix(lwrName, doltdb.DoltHistoryTablePrefix):
+		baseName = tblName[len(doltdb.DoltHistoryTablePrefix):]
+	case strings.HasPrefix(lwrName, doltdb.DoltConfTablePrefix):
+		baseName, refused = tblName[len(doltdb.DoltConfTablePrefix):], true
+	case strings.HasPrefix(lwrName, doltdb.DoltConstViolTablePrefix):
+		baseName, refused = tblName[len(doltdb.DoltConstViolTablePrefix):], true
+	case strings.HasPrefix(lwrName, doltdb.DoltWorkspaceTablePrefix):
+		baseName, refused = tblName[len(doltdb.DoltWorkspaceTablePrefix):], true
+	}
+
+	policies, err := policy.ForTable(ctx, db, baseName)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(policies) == 0 {
+		return tbl, true, nil
+	}
+	if refused {
+		return nil, false, policy.ErrRestrictedTable.New(tblName, baseName)
+	}
+	return policy.NewTable(tbl, db.RevisionQualifiedName(), baseName, kind, policies), true, nil
 }
 
 func (db Database) getDoltDBTableInsensitive(ctx *sql.Context, tblName doltdb.TableName, readNonlocalTables readNonlocalTablesFlag) (doltdb.TableName, *doltdb.Table, bool, error) {
@@ -365,7 +404,7 @@ func (db Database) getTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 	if doltdb.IsReadOnlySystemTable(doltdb.TableName{Name: tableName, Schema: db.schemaName}) {
 		// currently, system tables do not need to be "locked to root"
 		//  see comment below in getTableInsensitiveWithRoot
-		return table, ok, nil
+		return db.applyPolicies(ctx, tableName, table)
 	}
 
 	switch t := table.(type) {
@@ -374,7 +413,7 @@ func (db Database) getTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 		if err != nil {
 			return nil, false, err
 		}
-		return versionedTable, true, nil
+		return db.applyPolicies(ctx, tableName, versionedTable)
 
 	case *plan.EmptyTable:
 		// getTableInsensitive returns *plan.EmptyTable if the table doesn't exist in the data root, but
@@ -746,6 +785,30 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewStashesTable(ctx, db.Name(), db.ddb, lwrName), true
 		}
+	case doltdb.CIRunsTableName, doltdb.GetCIRunsTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCIRunsTable(ctx, db.Name(), db.ddb, lwrName), true
+		}
+	case doltdb.CIRunStepsTableName, doltdb.GetCIRunStepsTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCIRunStepsTable(ctx, db.Name(), db.ddb, lwrName), true
+		}
+	case doltdb.NotesTableName, doltdb.GetNotesTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewNotesTable(ctx, db.Name(), db.ddb, lwrName), true
+		}
 	case doltdb.CommitsTableName, doltdb.GetCommitsTableName():
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -816,6 +879,13 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 				dt, found = dtables.NewBranchNamespaceControlTable(controller.Namespace), true
 			}
 		}
+	case dtables.ProtectionTableName:
+		basCtx := branch_control.GetBranchAwareSession(ctx)
+		if basCtx != nil {
+			if controller := basCtx.GetController(); controller != nil {
+				dt, found = dtables.NewBranchProtectionTable(controller.Protection), true
+			}
+		}
 	case doltdb.IgnoreTableName:
 		if resolve.UseSearchPath && db.schemaName == "" {
 			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
@@ -835,6 +905,28 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewIgnoreTable(ctx, versionableTable, db.schemaName), true
 		}
+	case doltdb.PoliciesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.PoliciesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyPoliciesTable(ctx, db.AliasedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewPoliciesTable(ctx, versionableTable, db.AliasedName(), db.schemaName), true
+		}
+	case doltdb.MergeStrategiesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.MergeStrategiesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeStrategiesTable(ctx, db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeStrategiesTable(ctx, versionableTable, db.schemaName), true
+		}
 	case doltdb.GetDocTableName(), doltdb.DocTableName:
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -459,7 +459,7 @@ func (p *DoltDatabaseProvider) attemptCloneReplica(ctx *sql.Context, dbName stri
 	// TODO: remote params for AWS, others
 	// TODO: this needs to be robust in the face of the DB not having the default branch
 	// TODO: this treats every database not found error as a clone error, need to tighten
-	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, nil)
+	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, false, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -924,6 +924,8 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 	ctx *sql.Context,
 	dbName, branch, remoteName, remoteUrl string,
 	depth int,
+	lazy bool,
+	tables []string,
 	remoteParams map[string]string,
 ) error {
 	p.mu.Lock()
@@ -940,7 +942,7 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 		return fmt.Errorf("cannot create DB, file exists at %s", dbName)
 	}
 
-	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, remoteParams)
+	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, lazy, tables, remoteParams)
 	if err != nil {
 		// Make a best effort to clean up any artifacts on disk from a failed clone
 		// before we return the error
@@ -966,6 +968,8 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 	ctx *sql.Context,
 	dbName, remoteName, branch, remoteUrl string,
 	depth int,
+	lazy bool,
+	tables []string,
 	remoteParams map[string]string,
 ) error {
 	if p.remoteDialer == nil {
@@ -988,6 +992,13 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 	}
 	p.applyDBLoadParamsToEnv(dEnv)
 
+	if len(tables) > 0 {
+		err = dEnv.SetSparseTables(tables)
+		if err != nil {
+			return err
+		}
+	}
+
 	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
 		err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, dEnv, statsCh)
 	})
@@ -1000,6 +1011,13 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 		Remote: remoteName,
 	})
 
+	if lazy {
+		err = dEnv.EnableLazyFetch(remoteName)
+		if err != nil {
+			return err
+		}
+	}
+
 	return p.registerNewDatabase(ctx, dbName, dEnv)
 }
 
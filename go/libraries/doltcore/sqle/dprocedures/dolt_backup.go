@@ -52,6 +52,10 @@ var awsParamsUsage = []string{
 	fmt.Sprintf("--%s=<profile>", dbfactory.AWSCredsProfile),
 }
 
+var encryptionParamsUsage = []string{
+	fmt.Sprintf("--%s=<file>", dbfactory.EncryptionKeyFileParam),
+}
+
 // doltBackup implements backup operations for Dolt databases. It routes |args| to the appropriate operation handler
 // based on the first argument. The procedure requires superuser privileges and write access to the current database.
 // Supported operations are: add, remove/rm, sync, sync-url, and restore.
@@ -92,7 +96,7 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	switch funcParam {
 	case DoltBackupParamAdd:
 		if apr.NArg() != 3 {
-			return nil, errDoltBackupUsage(funcParam, []string{"name", "url"}, awsParamsUsage)
+			return nil, errDoltBackupUsage(funcParam, []string{"name", "url"}, append(awsParamsUsage, encryptionParamsUsage...))
 		}
 		err = doltBackupAdd(ctx, dbData, doltSess, apr)
 	case DoltBackupParamRemove, DoltBackupParamRm:
@@ -109,13 +113,13 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 		err = doltBackupSync(ctx, dbData, doltSess, name)
 	case DoltBackupParamSyncUrl:
 		if apr.NArg() != 2 {
-			return nil, errDoltBackupUsage(funcParam, []string{"remote_url"}, awsParamsUsage)
+			return nil, errDoltBackupUsage(funcParam, []string{"remote_url"}, append(awsParamsUsage, encryptionParamsUsage...))
 		}
 		err = doltBackupSyncUrl(ctx, dbData, doltSess, apr)
 	case DoltBackupParamRestore:
 		if apr.NArg() != 3 {
 			forceParamUsage := []string{fmt.Sprintf("--%s", cli.ForceFlag)}
-			return nil, errDoltBackupUsage(funcParam, []string{"remote_url", "new_db_name"}, append(forceParamUsage, awsParamsUsage...))
+			return nil, errDoltBackupUsage(funcParam, []string{"remote_url", "new_db_name"}, append(append(forceParamUsage, awsParamsUsage...), encryptionParamsUsage...))
 		}
 		err = doltBackupRestore(ctx, dbData, doltSess, apr)
 	default:
@@ -147,6 +151,11 @@ func doltBackupAdd(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dse
 		}
 	}
 
+	err = cli.AddEncryptionParams(backupUrlScheme, apr, backupParams)
+	if err != nil {
+		return err
+	}
+
 	backupRemote := env.NewRemote(backupName, backupUrl, backupParams)
 	err = dbData.Rsw.AddBackup(backupRemote)
 	return err
@@ -191,6 +200,11 @@ func doltBackupSyncUrl(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 		}
 	}
 
+	err = cli.AddEncryptionParams(remoteUrlScheme, apr, remoteParams)
+	if err != nil {
+		return err
+	}
+
 	remote := env.NewRemote(DoltBackupParamSyncUrl, remoteUrl, remoteParams)
 	return syncRemote(ctx, dbData, dsess, remote)
 }
@@ -220,6 +234,11 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 		}
 	}
 
+	err = cli.AddEncryptionParams(remoteUrlScheme, apr, remoteParams)
+	if err != nil {
+		return err
+	}
+
 	remote := env.NewRemote(DoltBackupParamRestore, remoteUrl, remoteParams)
 
 	remoteDb, err := dsess.Provider().GetRemoteDB(ctx, types.Format_DOLT, remote)
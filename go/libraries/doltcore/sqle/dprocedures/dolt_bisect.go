@@ -0,0 +1,424 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var doltBisectSchema = []*sql.Column{
+	{
+		Name:     "status",
+		Type:     types.LongText,
+		Nullable: false,
+	},
+	{
+		Name:     "commit_hash",
+		Type:     types.LongText,
+		Nullable: true,
+	},
+	{
+		Name:     "remaining",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     types.LongText,
+		Nullable: false,
+	},
+}
+
+const (
+	bisectVerdictGood = "good"
+	bisectVerdictBad  = "bad"
+	bisectVerdictSkip = "skip"
+)
+
+// RunDoltTests evaluates |tests|, rows read from the dolt_tests system table, against the current database of |ctx|
+// and returns a description of each test that failed. It is set by the dtablefunctions package, which implements
+// dolt_test_run() and cannot be imported here.
+var RunDoltTests func(ctx *sql.Context, engine *gms.Engine, tests []sql.Row) ([]string, error)
+
+// doltBisect is the stored procedure version for the CLI command `dolt bisect`.
+func doltBisect(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	rows, err := doDoltBisect(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// doDoltBisect runs a bisect subcommand. The bisect session is stored in the working set of the current branch, so it
+// survives across calls and connections. HEAD never moves during a bisect; instead, each result names the commit to
+// test next, which can be examined with the revision database `db/<hash>`.
+func doDoltBisect(ctx *sql.Context, args []string) ([]sql.Row, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+
+	apr, err := cli.CreateBisectArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() == 0 {
+		return nil, fmt.Errorf("error: a subcommand is required: start, good, bad, skip, reset, run, or status")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	subcommand := strings.ToLower(apr.Arg(0))
+	revs := apr.Args[1:]
+	if subcommand != "run" && (apr.Contains(cli.TestParam) || apr.Contains(cli.QueryParam)) {
+		return nil, fmt.Errorf("error: --%s and --%s can only be used with run", cli.TestParam, cli.QueryParam)
+	}
+
+	if subcommand == "start" {
+		state := &doltdb.BisectState{}
+		hashes, err := resolveBisectRevs(ctx, dbData, revs)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) > 0 {
+			state = state.WithBad(hashes[0])
+			for _, h := range hashes[1:] {
+				state = state.WithGood(h)
+			}
+		}
+		return saveBisectStep(ctx, dSess, dbName, dbData, ws, state)
+	}
+
+	state := ws.BisectState()
+	if state == nil {
+		return nil, fmt.Errorf("error: no bisect in progress; use 'start' to begin bisecting")
+	}
+
+	switch subcommand {
+	case "reset":
+		if err = dSess.SetWorkingSet(ctx, dbName, ws.ClearBisect()); err != nil {
+			return nil, err
+		}
+		return []sql.Row{{"reset", nil, int64(0), "bisect session ended"}}, nil
+	case "status":
+		step, err := actions.NextBisectStep(ctx, dbData.Ddb, state)
+		if err != nil {
+			return nil, err
+		}
+		return []sql.Row{bisectStepRow(step)}, nil
+	case bisectVerdictGood, bisectVerdictBad, bisectVerdictSkip:
+		hashes, err := resolveBisectRevs(ctx, dbData, revs)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			h, err := defaultBisectRev(ctx, dbData, state)
+			if err != nil {
+				return nil, err
+			}
+			hashes = []hash.Hash{h}
+		}
+		if subcommand == bisectVerdictBad && len(hashes) > 1 {
+			return nil, fmt.Errorf("error: only one commit can be marked bad")
+		}
+		for _, h := range hashes {
+			state = markBisectCommit(state, subcommand, h)
+		}
+		return saveBisectStep(ctx, dSess, dbName, dbData, ws, state)
+	case "run":
+		if len(revs) > 0 {
+			return nil, fmt.Errorf("error: run does not take any commits")
+		}
+		return runBisect(ctx, dSess, dbName, dbData, ws, apr.GetValueOrDefault(cli.TestParam, ""), apr.GetValueOrDefault(cli.QueryParam, ""))
+	default:
+		return nil, fmt.Errorf("error: unknown bisect subcommand '%s'", apr.Arg(0))
+	}
+}
+
+// runBisect repeatedly tests the next candidate commit and marks it until the first bad commit is found, or until
+// only skipped commits remain. It returns a row for each commit tested, followed by the final status.
+func runBisect(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, dbData env.DbData[*sql.Context], ws *doltdb.WorkingSet, testArg, query string) ([]sql.Row, error) {
+	if (testArg == "") == (query == "") {
+		return nil, fmt.Errorf("error: run requires exactly one of --%s or --%s", cli.TestParam, cli.QueryParam)
+	}
+	if query != "" {
+		if err := checkBisectQuery(query); err != nil {
+			return nil, err
+		}
+	}
+
+	state := ws.BisectState()
+	step, err := actions.NextBisectStep(ctx, dbData.Ddb, state)
+	if err != nil {
+		return nil, err
+	}
+	if step.Status == actions.BisectStatusWaiting {
+		return nil, fmt.Errorf("error: run requires a bad commit and at least one good commit to be marked")
+	}
+
+	engine := gms.NewDefault(dSess.GenericProvider())
+
+	var tests []sql.Row
+	if testArg != "" {
+		// The tests are read from the current branch, so that tests written after the regression was introduced can
+		// be used to find it.
+		tests, err = loadBisectTests(ctx, engine, testArg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []sql.Row
+	for step.Status == actions.BisectStatusBisecting {
+		verdict, message, err := evaluateBisectCandidate(ctx, engine, dbName, step.Commit, tests, query)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, sql.Row{verdict, step.Commit.String(), int64(step.Remaining), message})
+
+		state = markBisectCommit(state, verdict, step.Commit)
+		step, err = actions.NextBisectStep(ctx, dbData.Ddb, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(state)); err != nil {
+		return nil, err
+	}
+	return append(rows, bisectStepRow(step)), nil
+}
+
+// errBisectQueryNotReadOnly is returned for bisect queries that write. Bisect queries run in an engine of their own,
+// which doesn't check the caller's privileges, so they must only read.
+var errBisectQueryNotReadOnly = fmt.Errorf("error: --%s must be a single read-only SELECT statement", cli.QueryParam)
+
+// checkBisectQuery returns an error unless |query| is a single SELECT statement. Each candidate commit also checks
+// that the query is read-only once it has been bound to that commit's tables.
+func checkBisectQuery(query string) error {
+	if statements, err := sqlparser.SplitStatementToPieces(query); err != nil {
+		return err
+	} else if len(statements) != 1 {
+		return errBisectQueryNotReadOnly
+	}
+	parsed, err := sqlparser.Parse(query)
+	if err != nil {
+		return err
+	}
+	if sel, ok := parsed.(sqlparser.SelectStatement); !ok || sel.GetInto() != nil {
+		return errBisectQueryNotReadOnly
+	}
+	return nil
+}
+
+// evaluateBisectCandidate decides whether |commit| is good or bad by running either |tests| or |query| against the
+// revision database for that commit. A query that fails to run, which is often caused by schema changes in older
+// commits, causes the commit to be skipped.
+func evaluateBisectCandidate(ctx *sql.Context, engine *gms.Engine, dbName string, commit hash.Hash, tests []sql.Row, query string) (verdict string, message string, err error) {
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	ctx.SetCurrentDatabase(baseName + doltdb.DbRevisionDelimiter + commit.String())
+	defer ctx.SetCurrentDatabase(dbName)
+
+	if tests != nil {
+		if RunDoltTests == nil {
+			return "", "", fmt.Errorf("dolt_tests are not supported by this engine")
+		}
+		failures, err := RunDoltTests(ctx, engine, tests)
+		if err != nil {
+			return "", "", err
+		}
+		if len(failures) > 0 {
+			return bisectVerdictBad, "failed: " + strings.Join(failures, ", "), nil
+		}
+		return bisectVerdictGood, "all tests passed", nil
+	}
+
+	// the query is bound against each candidate, since the tables it reads may not exist in every commit
+	parsed, err := sqlparser.Parse(query)
+	if err != nil {
+		return "", "", err
+	}
+	node, _, err := planbuilder.New(ctx, engine.Analyzer.Catalog, nil).BindOnly(parsed, query, nil)
+	if err != nil {
+		return bisectVerdictSkip, fmt.Sprintf("query error: %s", err.Error()), nil
+	}
+	if !node.IsReadOnly() {
+		return "", "", errBisectQueryNotReadOnly
+	}
+
+	_, iter, _, err := engine.Query(ctx, query)
+	if err != nil {
+		return bisectVerdictSkip, fmt.Sprintf("query error: %s", err.Error()), nil
+	}
+	var first sql.Row
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return bisectVerdictSkip, fmt.Sprintf("query error: %s", err.Error()), nil
+		}
+		if first == nil {
+			first = row
+		}
+	}
+	if len(first) == 0 || first[0] == nil {
+		return bisectVerdictBad, "query returned no value", nil
+	}
+	passed, err := sql.ConvertToBool(ctx, first[0])
+	if err != nil {
+		return bisectVerdictSkip, fmt.Sprintf("query result is not a boolean: %s", err.Error()), nil
+	}
+	if !passed {
+		return bisectVerdictBad, "query returned false", nil
+	}
+	return bisectVerdictGood, "query returned true", nil
+}
+
+// loadBisectTests returns the rows of dolt_tests in the current database that match |testArg|, which is either a test
+// name or a test group, in the same way as dolt_test_run().
+func loadBisectTests(ctx *sql.Context, engine *gms.Engine, testArg string) ([]sql.Row, error) {
+	query := "SELECT * FROM dolt_tests"
+	if testArg != "*" {
+		var err error
+		query, err = dbr.InterpolateForDialect("SELECT * FROM dolt_tests WHERE test_name = ? OR test_group = ?", []interface{}{testArg, testArg}, dialect.MySQL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, iter, _, err := engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var tests []sql.Row
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		tests = append(tests, row)
+	}
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("could not find tests for argument: %s", testArg)
+	}
+	return tests, nil
+}
+
+// saveBisectStep validates |state|, persists it in the working set, and returns a row describing the next step.
+func saveBisectStep(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, dbData env.DbData[*sql.Context], ws *doltdb.WorkingSet, state *doltdb.BisectState) ([]sql.Row, error) {
+	step, err := actions.NextBisectStep(ctx, dbData.Ddb, state)
+	if err != nil {
+		return nil, err
+	}
+	if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(state)); err != nil {
+		return nil, err
+	}
+	return []sql.Row{bisectStepRow(step)}, nil
+}
+
+func markBisectCommit(state *doltdb.BisectState, verdict string, h hash.Hash) *doltdb.BisectState {
+	switch verdict {
+	case bisectVerdictGood:
+		return state.WithGood(h)
+	case bisectVerdictBad:
+		return state.WithBad(h)
+	default:
+		return state.WithSkipped(h)
+	}
+}
+
+// defaultBisectRev returns the commit that good, bad and skip apply to when no commit is given: the commit currently
+// being tested, or HEAD if bisecting has not begun yet.
+func defaultBisectRev(ctx *sql.Context, dbData env.DbData[*sql.Context], state *doltdb.BisectState) (hash.Hash, error) {
+	step, err := actions.NextBisectStep(ctx, dbData.Ddb, state)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	if step.Status == actions.BisectStatusBisecting {
+		return step.Commit, nil
+	}
+	hashes, err := resolveBisectRevs(ctx, dbData, []string{"HEAD"})
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return hashes[0], nil
+}
+
+func resolveBisectRevs(ctx *sql.Context, dbData env.DbData[*sql.Context], revs []string) ([]hash.Hash, error) {
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]hash.Hash, len(revs))
+	for i, rev := range revs {
+		cs, err := doltdb.NewCommitSpec(rev)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		hashes[i], err = cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+func bisectStepRow(step actions.BisectStep) sql.Row {
+	var commit interface{}
+	if !step.Commit.IsEmpty() {
+		commit = step.Commit.String()
+	}
+	return sql.Row{string(step.Status), commit, int64(step.Remaining), step.Message()}
+}
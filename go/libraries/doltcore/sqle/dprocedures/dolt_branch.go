@@ -152,6 +152,8 @@ func renameBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argpar
 		// If force is enabled, we can overwrite the destination branch, so we require a permission check here, even if the
 		// destination branch doesn't exist. An unauthorized user could simply rerun the command without the force flag.
 		return err
+	} else if err = checkForceMoveProtection(ctx, dbData.Ddb, newBranchName); err != nil {
+		return err
 	}
 
 	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
@@ -239,6 +241,9 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argp
 		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
+		if err = checkBranchNotInOtherWorktree(ctx, dbName, branchName); err != nil {
+			return err
+		}
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -464,6 +469,11 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *arg
 	if err != nil {
 		return err
 	}
+	if apr.Contains(cli.ForceFlag) {
+		if err = checkForceMoveProtection(ctx, dbData.Ddb, branchName); err != nil {
+			return err
+		}
+	}
 	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag), rsc)
 	if err != nil {
 		return err
@@ -498,9 +508,31 @@ func copyBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argparse
 	}
 
 	force := apr.Contains(cli.ForceFlag)
+	if force {
+		if err := checkForceMoveProtection(ctx, dbData.Ddb, destBr); err != nil {
+			return err
+		}
+	}
 	return copyABranch(ctx, dbData, srcBr, destBr, force, giveAdminPrivs, rsc)
 }
 
+// checkForceMoveProtection returns an error if |branchName| exists and forbids hard resets, since forcing it to a new
+// head discards its history just as a hard reset does.
+func checkForceMoveProtection(ctx *sql.Context, ddb *doltdb.DoltDB, branchName string) error {
+	branchName, exists, err := ddb.HasBranch(ctx, branchName)
+	if err != nil || !exists {
+		return err
+	}
+	rules, err := branch_control.GetProtectionRules(ctx, ctx.GetCurrentDatabase(), branchName)
+	if err != nil {
+		return err
+	}
+	if rules.DenyHardReset {
+		return branch_control.ErrBranchProtected.New(rules.Branch, "forcing the branch to a new head is not allowed")
+	}
+	return nil
+}
+
 // copyABranch copies |srcBr| to |destBr|, forcing the copy if |force| is set, and giving branch control admin privileges
 // if |giveAdminPrivs| is set.
 func copyABranch(ctx *sql.Context, dbData env.DbData[*sql.Context], srcBr string, destBr string, force bool, giveAdminPrivs bool, rsc *doltdb.ReplicationStatusController) error {
@@ -445,6 +445,12 @@ func checkoutNewBranch(
 	if optionBBranch != "" {
 		newBranchName = optionBBranch
 	}
+	if createBranchForcibly {
+		// -B resets the branch, and with it the working set of any worktree it's checked out in
+		if err = checkBranchNotInOtherWorktree(ctx, dbName, newBranchName); err != nil {
+			return "", "", err
+		}
+	}
 
 	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, createBranchForcibly, rsc)
 	if err != nil {
@@ -527,6 +533,10 @@ func checkoutExistingBranch(
 	apr *argparser.ArgParseResults,
 	overwriteIgnore bool,
 ) error {
+	if err := checkBranchNotInOtherWorktree(ctx, dbName, branchName); err != nil {
+		return err
+	}
+
 	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef(branchName))
 	if err != nil {
 		return err
@@ -226,3 +226,15 @@ func generateSuccessMessage(newBranch, upstream string) string {
 	}
 	return result
 }
+
+// checkBranchNotInOtherWorktree returns an error if |branchName| is checked out in a worktree of the database named
+// |dbName|, other than the working directory the database was loaded from. Databases without a working directory on
+// disk have no worktrees.
+func checkBranchNotInOtherWorktree(ctx *sql.Context, dbName, branchName string) error {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	fs, err := dSess.Provider().FileSystemForDatabase(dbName)
+	if err != nil {
+		return nil
+	}
+	return env.CheckBranchNotInOtherWorktree(fs, branchName)
+}
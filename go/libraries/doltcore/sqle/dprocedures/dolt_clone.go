@@ -71,12 +71,24 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 		remoteParms[dbfactory.GitRefParam] = ref
 	}
 
+	if err = cli.AddEncryptionParams(scheme, apr, remoteParms); err != nil {
+		return nil, err
+	}
+
 	depth, ok := apr.GetInt(cli.DepthFlag)
 	if !ok {
 		depth = -1
 	}
 
-	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, remoteParms)
+	var tables []string
+	if tablesArg, ok := apr.GetValue(cli.TablesFlag); ok {
+		tables = env.ParseSparseTables(tablesArg)
+		if len(tables) == 0 {
+			return nil, errhand.BuildDError("error: --%s requires at least one table", cli.TablesFlag).Build()
+		}
+	}
+
+	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, apr.Contains(cli.LazyFlag), tables, remoteParms)
 	if err != nil {
 		return nil, err
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
@@ -92,6 +93,13 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("failed to read latest version of remote db: %w", err)
 	}
 
+	if tables, ok := apr.GetValue(cli.TablesFlag); ok {
+		err = widenSparseTables(ctx, sess, dbName, dbData, srcDB, env.ParseSparseTables(tables))
+		if err != nil {
+			return cmdFailure, fmt.Errorf("fetch failed: %w", err)
+		}
+	}
+
 	prune := apr.Contains(cli.PruneFlag)
 	mode := ref.UpdateMode{Force: true, Prune: prune}
 	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
@@ -103,6 +111,29 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 	return cmdSuccess, nil
 }
 
+// widenSparseTables adds |patterns| to the tables of a sparse clone, fetching their data for the history already
+// present, and records the new table list in the repository config.
+func widenSparseTables(ctx *sql.Context, sess *dsess.DoltSession, dbName string, dbData env.DbData[*sql.Context], srcDB *doltdb.DoltDB, patterns []string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("--%s requires at least one table", cli.TablesFlag)
+	}
+	tmpDir, err := dbData.Rsw.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+		err = dbData.Ddb.WidenSparseTables(ctx, tmpDir, srcDB, patterns, statsCh)
+	})
+	if err != nil {
+		return err
+	}
+	fs, err := sess.Provider().FileSystemForDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	return env.SaveSparseTables(fs, dbData.Ddb.SparseTables())
+}
+
 // validateFetchArgs returns an error if the arguments provided aren't valid.
 func validateFetchArgs(apr *argparser.ArgParseResults, refSpecArgs []string) error {
 	if len(refSpecArgs) > 0 && apr.Contains(cli.PruneFlag) {
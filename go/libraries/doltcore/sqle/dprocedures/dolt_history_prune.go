@@ -0,0 +1,293 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var doltHistoryPruneSchema = []*sql.Column{
+	{
+		Name:     "hash",
+		Type:     types.LongText,
+		Nullable: false,
+	},
+	{
+		Name:     "root_hash",
+		Type:     types.LongText,
+		Nullable: false,
+	},
+	{
+		Name:     "rewritten_commits",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "unpruned_branches",
+		Type:     types.LongText,
+		Nullable: false,
+	},
+}
+
+const doltHistoryPruneWarningCode int = 1105
+
+// doltHistoryPrune is the stored procedure version for the CLI command `dolt history prune`.
+func doltHistoryPrune(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	newHead, root, rewritten, unpruned, err := doDoltHistoryPrune(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(newHead, root, int64(rewritten), strings.Join(unpruned, ",")), nil
+}
+
+// doDoltHistoryPrune squashes the old history of a branch into a single root commit and moves the branch, and any
+// tags on its kept commits, to the rewritten commits. It returns the new head and root commit hashes, the number of
+// kept commits that were rewritten, and the names of other branches whose history still contains pruned commits.
+func doDoltHistoryPrune(ctx *sql.Context, args []string) (string, string, int, []string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return "", "", 0, nil, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return "", "", 0, nil, err
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return "", "", 0, nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	apr, err := cli.CreateHistoryPruneArgParser().Parse(args)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	isReadOnly, err := isReadOnlyDatabase(ctx, dbName)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	if isReadOnly {
+		return "", "", 0, nil, fmt.Errorf("unable to prune history in read-only databases")
+	}
+
+	var opts rebase.PruneOptions
+	if keepStr, ok := apr.GetValue(cli.KeepParam); ok {
+		opts.Keep, err = strconv.Atoi(keepStr)
+		if err != nil || opts.Keep < 1 {
+			return "", "", 0, nil, fmt.Errorf("error: --%s must be a positive number of commits, got '%s'", cli.KeepParam, keepStr)
+		}
+	}
+	if beforeStr, ok := apr.GetValue(cli.BeforeParam); ok {
+		opts.Before, err = dconfig.ParseDate(beforeStr)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+	}
+	if opts.Keep == 0 && opts.Before.IsZero() {
+		return "", "", 0, nil, fmt.Errorf("error: one of --%s or --%s is required", cli.KeepParam, cli.BeforeParam)
+	}
+
+	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
+		opts.Name, opts.Email, err = cli.ParseAuthor(authorStr)
+	} else {
+		opts.Name, opts.Email, _, _, err = dsess.ResolveNameEmail(ctx, dsess.DoltCommitterName, dsess.DoltCommitterEmail)
+	}
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	opts.Message, _ = apr.GetValue(cli.MessageArg)
+
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	branchName := headRef.GetPath()
+	if apr.NArg() == 1 {
+		branchName = apr.Arg(0)
+	}
+	branchRef := ref.NewBranchRef(branchName)
+	if _, exists, err := dbData.Ddb.HasBranch(ctx, branchName); err != nil {
+		return "", "", 0, nil, err
+	} else if !exists {
+		return "", "", 0, nil, fmt.Errorf("branch '%s' not found", branchName)
+	}
+
+	// Pruning replaces the branch with an unrelated history, which needs the same permission as deleting it
+	if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
+		return "", "", 0, nil, err
+	}
+	rules, err := branch_control.GetProtectionRules(ctx, dbName, branchName)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	if rules.DenyForcePush || rules.DenyHardReset {
+		return "", "", 0, nil, branch_control.ErrBranchProtected.New(branchName, "history rewrites are not allowed")
+	}
+
+	isCurrentBranch := ref.Equals(headRef, branchRef)
+	if err = checkNoMergeOrRebase(ctx, dSess, dbName, dbData.Ddb, branchRef, isCurrentBranch); err != nil {
+		return "", "", 0, nil, err
+	}
+
+	head, err := dbData.Ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	res, err := rebase.PruneHistory(ctx, dbData.Ddb, head, opts)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	// Tags on pruned commits would keep the old history reachable, so they must be deleted explicitly. Check them all
+	// before moving any refs.
+	tags, err := dbData.Ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	var retargeted, pruned []doltdb.TagWithHash
+	for _, t := range tags {
+		if _, ok := res.Rewritten[t.Hash]; ok {
+			retargeted = append(retargeted, t)
+			continue
+		}
+		if isPruned, err := res.IsPruned(ctx, t.Tag.Commit); err != nil {
+			return "", "", 0, nil, err
+		} else if isPruned {
+			pruned = append(pruned, t)
+		}
+	}
+	if len(pruned) > 0 && !apr.Contains(cli.DeleteTagsFlag) {
+		names := make([]string, len(pruned))
+		for i, t := range pruned {
+			names[i] = t.Tag.Name
+		}
+		return "", "", 0, nil, fmt.Errorf("error: tags %s refer to pruned commits; delete them or use --%s",
+			strings.Join(names, ", "), cli.DeleteTagsFlag)
+	}
+
+	// The new head has the same root value as the old one, so the branch's working set is still valid
+	if err = dbData.Ddb.SetHeadToCommit(ctx, branchRef, res.NewHead); err != nil {
+		return "", "", 0, nil, err
+	}
+
+	for _, t := range retargeted {
+		tagRef := ref.NewTagRef(t.Tag.Name)
+		if err = dbData.Ddb.DeleteTag(ctx, tagRef); err != nil {
+			return "", "", 0, nil, err
+		}
+		if err = dbData.Ddb.NewTagAtCommit(ctx, tagRef, res.Rewritten[t.Hash], t.Tag.Meta); err != nil {
+			return "", "", 0, nil, err
+		}
+	}
+	for _, t := range pruned {
+		if err = dbData.Ddb.DeleteTag(ctx, ref.NewTagRef(t.Tag.Name)); err != nil {
+			return "", "", 0, nil, err
+		}
+	}
+
+	if isCurrentBranch {
+		// the roots are unchanged, but the session must pick up the new head when the transaction commits
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		if err = dSess.SetWorkingSet(ctx, dbName, ws); err != nil {
+			return "", "", 0, nil, err
+		}
+		if err = commitTransaction(ctx, dSess, nil); err != nil {
+			return "", "", 0, nil, err
+		}
+	}
+
+	// Other branches are left alone, so any pruned commits in their history are still reachable and are not freed by
+	// garbage collection
+	branches, err := dbData.Ddb.GetBranches(ctx)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	var unpruned []string
+	for _, br := range branches {
+		if ref.Equals(br, branchRef) {
+			continue
+		}
+		cm, err := dbData.Ddb.ResolveCommitRef(ctx, br)
+		if err != nil {
+			return "", "", 0, nil, err
+		}
+		if shares, err := res.SharesPrunedHistory(ctx, cm); err != nil {
+			return "", "", 0, nil, err
+		} else if shares {
+			unpruned = append(unpruned, br.GetPath())
+		}
+	}
+	if len(unpruned) > 0 {
+		ctx.Warn(doltHistoryPruneWarningCode, "branches %s still refer to pruned commits; their history must be "+
+			"pruned or deleted before garbage collection can free it", strings.Join(unpruned, ", "))
+	}
+
+	newHeadHash, err := res.NewHead.HashOf()
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	rootHash, err := res.Root.HashOf()
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	return newHeadHash.String(), rootHash.String(), len(res.Rewritten), unpruned, nil
+}
+
+// checkNoMergeOrRebase returns an error if a merge or rebase is in progress on the branch given, since either would
+// refer to commits that are about to be rewritten.
+func checkNoMergeOrRebase(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, ddb *doltdb.DoltDB, branchRef ref.DoltRef, isCurrentBranch bool) error {
+	var ws *doltdb.WorkingSet
+	var err error
+	if isCurrentBranch {
+		ws, err = dSess.WorkingSet(ctx, dbName)
+	} else {
+		wsRef, refErr := ref.WorkingSetRefForHead(branchRef)
+		if refErr != nil {
+			return refErr
+		}
+		ws, err = ddb.ResolveWorkingSet(ctx, wsRef)
+		if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if ws.MergeActive() {
+		return fmt.Errorf("error: cannot prune history of branch '%s' while a merge is in progress", branchRef.GetPath())
+	}
+	if ws.RebaseActive() {
+		return fmt.Errorf("error: cannot prune history of branch '%s' while a rebase is in progress", branchRef.GetPath())
+	}
+	return nil
+}
@@ -127,6 +127,13 @@ func doDoltMerge(ctx *sql.Context, args []string) (string, int, int, string, err
 	if apr.ContainsAll(cli.FFOnlyParam, cli.SquashParam) {
 		return "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("error: Flags '--%s' and '--%s' cannot be used together", cli.FFOnlyParam, cli.SquashParam)
 	}
+	if apr.Contains(cli.StrategyParam) {
+		for _, param := range []string{cli.SquashParam, cli.FFOnlyParam, cli.StrategyOptionParam} {
+			if apr.Contains(param) {
+				return "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("error: Flags '--%s' and '--%s' cannot be used together", cli.StrategyParam, param)
+			}
+		}
+	}
 
 	ws, err := sess.WorkingSet(ctx, dbName)
 	if err != nil {
@@ -160,6 +167,10 @@ func doDoltMerge(ctx *sql.Context, args []string) (string, int, int, string, err
 		return "", noConflictsOrViolations, threeWayMerge, "merge aborted", nil
 	}
 
+	if apr.NArg() > 1 {
+		return doDoltOctopusMerge(ctx, sess, dbName, apr, ws, roots)
+	}
+
 	branchName := apr.Arg(0)
 
 	mergeSpec, err := createMergeSpec(ctx, sess, dbName, apr, branchName)
@@ -233,8 +244,25 @@ func performMerge(
 		}
 	}
 
-	if canFF {
-		if spec.FFMode == merge.NoFastForward {
+	// A fast-forward moves the branch without making a commit, which would skip the tests required by a protected
+	// branch. In that case we make a merge commit instead, so that the tests are run.
+	if canFF && spec.FFMode != merge.NoFastForward && !spec.Squash && spec.Strategy == "" {
+		rules, err := sess.BranchProtectionRules(ctx, dbName)
+		if err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		if len(rules.RequiredTestGroups) > 0 {
+			if spec.FFMode == merge.FastForwardOnly {
+				return ws, "", noConflictsOrViolations, threeWayMerge, "", branch_control.ErrBranchProtected.New(rules.Branch, "fast-forward merges would skip its required tests")
+			}
+			spec.FFMode = merge.NoFastForward
+		}
+	}
+
+	// The ours strategy always makes a merge commit, even when the merge could be a fast-forward
+	keepOurs := spec.Strategy == merge.MergeStrategyOurs
+	if canFF || keepOurs {
+		if spec.FFMode == merge.NoFastForward || keepOurs {
 			var commit *doltdb.Commit
 			ws, commit, err = executeNoFFMerge(ctx, sess, spec, msg, dbName, ws, noCommit, skipVerification)
 			if err == doltdb.ErrUnresolvedConflictsOrViolations {
@@ -287,7 +315,7 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs, spec.StrategyOptions)
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -343,6 +371,7 @@ func executeMerge(
 	ws *doltdb.WorkingSet,
 	opts editor.Options,
 	workingDiffs map[doltdb.TableName]hash.Hash,
+	strategyOpts *merge.StrategyOptions,
 ) (*doltdb.WorkingSet, error) {
 	sqlDB, err := dsess.GetTableResolver(ctx, dbName)
 	if err != nil {
@@ -359,9 +388,92 @@ func executeMerge(
 			return nil, err
 		}
 	}
+	if strategyOpts != nil {
+		result, err = resolveConflictsWithStrategy(ctx, dbName, result, cm, opts, strategyOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return mergeRootToWorking(ctx, sess, dbName, squash, force, ws, result, workingDiffs, cm, cmSpec, head)
 }
 
+// resolveConflictsWithStrategy resolves the conflicts in |result| in favor of the side that |strategyOpts| chooses for
+// each table, the same way dolt_conflicts_resolve does. Since the data of a table with a schema conflict hasn't been
+// merged, the whole table is taken from the chosen side, and any changes the other side made to its rows are lost
+// even if they didn't conflict. A warning is issued for each such table. Constraint violations are left for the user
+// to resolve.
+func resolveConflictsWithStrategy(
+	ctx *sql.Context,
+	dbName string,
+	result *merge.Result,
+	theirs *doltdb.Commit,
+	opts editor.Options,
+	strategyOpts *merge.StrategyOptions,
+) (*merge.Result, error) {
+	theirRoot, err := theirs.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root := result.Root
+
+	var schConflicts []merge.SchemaConflict
+	for _, conflict := range result.SchemaConflicts {
+		strategy, ok := strategyOpts.ForTable(conflict.TableName)
+		if !ok {
+			schConflicts = append(schConflicts, conflict)
+			continue
+		}
+		// the merged root already has our side of any table with a schema conflict
+		if strategy == merge.MergeStrategyTheirs {
+			tbl, ok, err := theirRoot.GetTable(ctx, conflict.TableName)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				root, err = root.PutTable(ctx, conflict.TableName, tbl)
+			} else {
+				root, err = root.RemoveTables(ctx, false, true, conflict.TableName)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		ctx.Warn(DoltMergeWarningCode, "table %s has a schema conflict, so it was taken whole from %s and the other "+
+			"side's changes to it were discarded", conflict.TableName, strategy)
+		if stats, ok := result.Stats[conflict.TableName]; ok {
+			stats.SchemaConflicts = 0
+		}
+	}
+
+	getEditorOpts := func() (editor.Options, error) {
+		return opts, nil
+	}
+	for tblName, stats := range result.Stats {
+		if !stats.HasDataConflicts() {
+			continue
+		}
+		strategy, ok := strategyOpts.ForTable(tblName)
+		if !ok {
+			continue
+		}
+		newRoot, hasConflicts, err := ResolveDataConflictsForTable(ctx, dbName, root, tblName, strategy == merge.MergeStrategyOurs, getEditorOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve conflicts in table %s with strategy option '%s': %w", tblName, strategy, err)
+		}
+		if hasConflicts {
+			root = newRoot
+		}
+		stats.DataConflicts = 0
+	}
+
+	return &merge.Result{
+		Root:                  root,
+		SchemaConflicts:       schConflicts,
+		Stats:                 result.Stats,
+		CommitVerificationErr: result.CommitVerificationErr,
+	}, nil
+}
+
 func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.WorkingSet, dbData env.DbData[*sql.Context], cm2 *doltdb.Commit, spec *merge.MergeSpec) (*doltdb.WorkingSet, error) {
 	stagedRoot, err := cm2.GetRootValue(ctx)
 	if err != nil {
@@ -411,7 +523,8 @@ func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.Wor
 }
 
 // executeNoFFMerge is a helper function for performing a merge that is not a fast-forward merge. It returns the new
-// working set, the resulting commit, and an error. If the error is nil, the commit will be non-nil.
+// working set, the resulting commit, and an error. If the error is nil, the commit will be non-nil. The merged root is
+// the root of spec.MergeC, or of spec.HeadC when merging with the ours strategy.
 func executeNoFFMerge(
 	ctx *sql.Context,
 	dSess *dsess.DoltSession,
@@ -422,7 +535,11 @@ func executeNoFFMerge(
 	noCommit bool,
 	skipVerification bool,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
-	mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+	mergeRootCommit := spec.MergeC
+	if spec.Strategy == merge.MergeStrategyOurs {
+		mergeRootCommit = spec.HeadC
+	}
+	mergeRoot, err := mergeRootCommit.GetRootValue(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -459,28 +576,10 @@ func executeNoFFMerge(
 		return ws.WithStagedRoot(roots.Staged), nil, nil
 	}
 
-	commitStagedProps, committerSet, err := dsess.NewCommitStagedProps(ctx, msg)
+	commitStagedProps, err := newMergeCommitProps(ctx, spec, msg, skipVerification)
 	if err != nil {
 		return nil, nil, err
 	}
-	// Author identity and date are overridden only when the corresponding flags were
-	// explicitly provided. NewCommitStagedProps already resolved both from the
-	// dolt_author_name, dolt_author_email, and dolt_author_date session variables.
-	if spec.Name != "" {
-		commitStagedProps.Author.Name = spec.Name
-		commitStagedProps.Author.Email = spec.Email
-		// Old ver. of Dolt treated author as a synonym for committer. Unless specified, eval as so.
-		if !committerSet {
-			commitStagedProps.Committer.Name = spec.Name
-			commitStagedProps.Committer.Email = spec.Email
-		}
-	}
-	if spec.Date != nil {
-		commitStagedProps.Author.Date = *spec.Date
-		commitStagedProps.Committer.Date = commitStagedProps.Committer.Date.Or(spec.Date.Time())
-	}
-	commitStagedProps.Force = spec.Force
-	commitStagedProps.SkipVerification = skipVerification
 	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, commitStagedProps)
 	if err != nil {
 		if actions.ErrCommitVerificationFailed.Is(err) {
@@ -501,6 +600,33 @@ func executeNoFFMerge(
 	return ws, commit, nil
 }
 
+// newMergeCommitProps returns the properties of the merge commit for |spec|, with the message |msg|.
+func newMergeCommitProps(ctx *sql.Context, spec *merge.MergeSpec, msg string, skipVerification bool) (actions.CommitStagedProps, error) {
+	commitStagedProps, committerSet, err := dsess.NewCommitStagedProps(ctx, msg)
+	if err != nil {
+		return actions.CommitStagedProps{}, err
+	}
+	// Author identity and date are overridden only when the corresponding flags were
+	// explicitly provided. NewCommitStagedProps already resolved both from the
+	// dolt_author_name, dolt_author_email, and dolt_author_date session variables.
+	if spec.Name != "" {
+		commitStagedProps.Author.Name = spec.Name
+		commitStagedProps.Author.Email = spec.Email
+		// Old ver. of Dolt treated author as a synonym for committer. Unless specified, eval as so.
+		if !committerSet {
+			commitStagedProps.Committer.Name = spec.Name
+			commitStagedProps.Committer.Email = spec.Email
+		}
+	}
+	if spec.Date != nil {
+		commitStagedProps.Author.Date = *spec.Date
+		commitStagedProps.Committer.Date = commitStagedProps.Committer.Date.Or(spec.Date.Time())
+	}
+	commitStagedProps.Force = spec.Force
+	commitStagedProps.SkipVerification = skipVerification
+	return commitStagedProps, nil
+}
+
 func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults, commitSpecStr string) (*merge.MergeSpec, error) {
 	ddb, ok := sess.GetDoltDB(ctx, dbName)
 
@@ -542,6 +668,23 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		ffMode = merge.FastForwardOnly
 	}
 
+	var strategy merge.MergeStrategy
+	if strategyStr, ok := apr.GetValue(cli.StrategyParam); ok {
+		strategy = merge.MergeStrategy(strings.ToLower(strategyStr))
+		if strategy != merge.MergeStrategyOurs {
+			return nil, fmt.Errorf("error: unknown merge strategy '%s'; the only supported strategy is 'ours'", strategyStr)
+		}
+	}
+
+	var strategyOpts *merge.StrategyOptions
+	if optsStr, ok := apr.GetValue(cli.StrategyOptionParam); ok {
+		var err error
+		strategyOpts, err = merge.ParseStrategyOptions(optsStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return merge.NewMergeSpec(
 		ctx,
 		dbData.Rsr,
@@ -556,6 +699,8 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithStrategy(strategy),
+		merge.WithStrategyOptions(strategyOpts),
 	)
 }
 
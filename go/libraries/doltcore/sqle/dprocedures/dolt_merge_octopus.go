@@ -0,0 +1,203 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// ErrOctopusMergeConflicts is returned when one of the branches of an octopus merge doesn't merge cleanly.
+var ErrOctopusMergeConflicts = goerrors.NewKind("error: merging '%s' produced conflicts or constraint violations; " +
+	"an octopus merge is only made when every branch merges cleanly. Merge the branches one at a time to resolve them")
+
+// doDoltOctopusMerge merges every branch named in |apr| into the current branch, making a single merge commit whose
+// parents are HEAD followed by each merged commit in the order given. Branches that are already merged are skipped.
+// Unlike a merge of one branch, an octopus merge never leaves a merge in progress: if any branch would produce
+// conflicts or constraint violations, or if the merge commit can't be made, nothing is changed and an error is returned.
+func doDoltOctopusMerge(
+	ctx *sql.Context,
+	sess *dsess.DoltSession,
+	dbName string,
+	apr *argparser.ArgParseResults,
+	ws *doltdb.WorkingSet,
+	roots doltdb.Roots,
+) (string, int, int, string, error) {
+	for _, param := range []string{cli.SquashParam, cli.FFOnlyParam, cli.NoCommitFlag, cli.StrategyParam, cli.StrategyOptionParam} {
+		if apr.Contains(param) {
+			return "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("error: Flag '--%s' cannot be used when merging more than one branch", param)
+		}
+	}
+	if ws.MergeActive() {
+		return "", noConflictsOrViolations, threeWayMerge, "", doltdb.ErrMergeActive
+	}
+	if clean, err := rootsAreClean(roots); err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	} else if !clean {
+		return "", noConflictsOrViolations, threeWayMerge, "", ErrUncommittedChanges.New()
+	}
+
+	var specs []*merge.MergeSpec
+	var branchNames []string
+	merged := make(map[string]bool)
+	for _, branchName := range apr.Args {
+		spec, err := createMergeSpec(ctx, sess, dbName, apr, branchName)
+		if err != nil {
+			return "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		if merged[spec.MergeH.String()] {
+			continue
+		}
+		merged[spec.MergeH.String()] = true
+
+		if _, err = spec.HeadC.CanFastForwardTo(ctx, spec.MergeC); err == doltdb.ErrIsAhead || err == doltdb.ErrUpToDate {
+			continue
+		} else if err != nil {
+			return "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		specs = append(specs, spec)
+		branchNames = append(branchNames, branchName)
+	}
+	if len(specs) == 0 {
+		ctx.Warn(DoltMergeWarningCode, "%s", doltdb.ErrUpToDate.Error())
+		return "", noConflictsOrViolations, threeWayMerge, doltdb.ErrUpToDate.Error(), nil
+	}
+
+	tableResolver, err := dsess.GetTableResolver(ctx, dbName)
+	if err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	} else if !ok {
+		return "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	root := roots.Head
+	parents := make([]*doltdb.Commit, len(specs))
+	for i, spec := range specs {
+		result, err := merge.MergeCommitIntoRoot(ctx, tableResolver, root, spec.HeadC, spec.MergeC, dbState.EditOpts())
+		if err != nil {
+			return "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		if result.HasMergeArtifacts() {
+			return "", noConflictsOrViolations, threeWayMerge, "", ErrOctopusMergeConflicts.New(branchNames[i])
+		}
+		root = result.Root
+		parents[i] = spec.MergeC
+	}
+
+	msg, err := octopusMergeMessage(ctx, sess, dbName, apr, branchNames)
+	if err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	props, err := newMergeCommitProps(ctx, specs[0], msg, apr.Contains(cli.SkipVerificationFlag))
+	if err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	props.MergeParents = parents
+
+	mergedRoots := roots
+	mergedRoots.Working, mergedRoots.Staged = root, root
+	if err = sess.SetRoots(ctx, dbName, mergedRoots); err != nil {
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	h, err := commitOctopusMerge(ctx, sess, dbName, mergedRoots, props)
+	if err != nil {
+		// An octopus merge that can't be committed leaves nothing behind, so put the original roots back.
+		if rerr := sess.SetRoots(ctx, dbName, roots); rerr != nil {
+			return "", noConflictsOrViolations, threeWayMerge, "", rerr
+		}
+		return "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+
+	return h, noConflictsOrViolations, threeWayMerge, "merge successful", nil
+}
+
+// commitOctopusMerge commits |roots| as a merge commit described by |props| and returns the new commit's hash.
+func commitOctopusMerge(ctx *sql.Context, sess *dsess.DoltSession, dbName string, roots doltdb.Roots, props actions.CommitStagedProps) (string, error) {
+	pendingCommit, err := sess.NewPendingCommit(ctx, dbName, roots, props)
+	if err != nil {
+		return "", err
+	}
+	if pendingCommit == nil {
+		return "", errors.New("nothing to commit")
+	}
+	commit, err := sess.DoltCommit(ctx, dbName, sess.GetTransaction(), pendingCommit)
+	if err != nil {
+		return "", err
+	}
+	h, err := commit.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
+// rootsAreClean returns whether the working and staged roots of |roots| are the same as HEAD.
+func rootsAreClean(roots doltdb.Roots) (bool, error) {
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return false, err
+	}
+	for _, root := range []doltdb.RootValue{roots.Staged, roots.Working} {
+		h, err := root.HashOf()
+		if err != nil {
+			return false, err
+		}
+		if h != headHash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// octopusMergeMessage returns the message given with -m, or a message listing the merged branches.
+func octopusMergeMessage(ctx *sql.Context, sess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults, branchNames []string) (string, error) {
+	if userMsg, ok := apr.GetValue(cli.MessageArg); ok {
+		return userMsg, nil
+	}
+
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return "", fmt.Errorf("Could not load database %s", dbName)
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(branchNames) == 1 {
+		return fmt.Sprintf("Merge branch '%s' into %s", branchNames[0], headRef.GetPath()), nil
+	}
+	quoted := make([]string, len(branchNames))
+	for i, name := range branchNames {
+		quoted[i] = "'" + name + "'"
+	}
+	last := len(quoted) - 1
+	return fmt.Sprintf("Merge branches %s and %s into %s", strings.Join(quoted[:last], ", "), quoted[last], headRef.GetPath()), nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// doltNotes is the stored procedure version for the CLI command `dolt notes`.
+func doltNotes(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltNotes(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+// doDoltNotes adds, removes, merges and exchanges commit notes. Notes are read with the dolt_notes system table.
+func doDoltNotes(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return cmdFailure, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return cmdFailure, err
+	}
+
+	apr, err := cli.CreateNotesArgParser().Parse(args)
+	if err != nil {
+		return cmdFailure, err
+	}
+	if apr.NArg() == 0 {
+		return cmdFailure, fmt.Errorf("error: a subcommand is required: add, remove, merge, push, or pull; use the dolt_notes system table to read notes")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	notesRef, err := ref.ParseNotesRef(apr.GetValueOrDefault(cli.RefParam, ref.DefaultNotesRefName))
+	if err != nil {
+		return cmdFailure, err
+	}
+
+	subcommand := strings.ToLower(apr.Arg(0))
+	if apr.Contains(cli.MessageArg) && subcommand != "add" {
+		return cmdFailure, fmt.Errorf("error: --%s can only be used with add", cli.MessageArg)
+	}
+	if apr.Contains(cli.StrategyParam) && subcommand != "merge" && subcommand != "pull" {
+		return cmdFailure, fmt.Errorf("error: --%s can only be used with merge and pull", cli.StrategyParam)
+	}
+
+	strategy := doltdb.NotesMergeUnion
+	if s, ok := apr.GetValue(cli.StrategyParam); ok {
+		if strategy, err = doltdb.ParseNotesMergeStrategy(s); err != nil {
+			return cmdFailure, err
+		}
+	}
+
+	switch subcommand {
+	case "add", "remove":
+		err = editNotes(ctx, dbData, notesRef, subcommand, apr)
+	case "merge":
+		if apr.NArg() != 2 {
+			return cmdFailure, fmt.Errorf("error: merge takes the notes ref to merge")
+		}
+		var theirsRef ref.NotesRef
+		if theirsRef, err = ref.ParseNotesRef(apr.Arg(1)); err != nil {
+			return cmdFailure, err
+		}
+		err = mergeNotes(ctx, dbData.Ddb, dbData.Ddb, notesRef, theirsRef, strategy)
+	case "push", "pull":
+		if apr.NArg() > 2 {
+			return cmdFailure, fmt.Errorf("error: %s takes at most the name of a remote", subcommand)
+		}
+		err = exchangeNotes(ctx, dSess, dbData, notesRef, subcommand, apr.Args[1:], strategy)
+	default:
+		return cmdFailure, fmt.Errorf("error: unknown notes subcommand '%s'", apr.Arg(0))
+	}
+	if err != nil {
+		return cmdFailure, err
+	}
+	return cmdSuccess, nil
+}
+
+// editNotes adds a note to, or removes the notes from, the commits named by the arguments of |apr|.
+func editNotes(ctx *sql.Context, dbData env.DbData[*sql.Context], notesRef ref.NotesRef, subcommand string, apr *argparser.ArgParseResults) error {
+	revs := apr.Args[1:]
+	if len(revs) == 0 {
+		revs = []string{"HEAD"}
+	}
+	hashes, err := resolveBisectRevs(ctx, dbData, revs)
+	if err != nil {
+		return err
+	}
+
+	notes, head, err := dbData.Ddb.ResolveNotes(ctx, notesRef)
+	if err != nil {
+		return err
+	}
+
+	var desc string
+	switch subcommand {
+	case "add":
+		note, ok := apr.GetValue(cli.MessageArg)
+		if !ok {
+			return fmt.Errorf("error: add requires a note given with -m")
+		}
+		for i, h := range hashes {
+			if _, ok := notes[h.String()]; ok && !apr.Contains(cli.ForceFlag) {
+				return fmt.Errorf("error: %s already has a note; use -f to replace it", revs[i])
+			}
+			notes[h.String()] = note
+		}
+		desc = "Notes added by 'dolt notes add'"
+	case "remove":
+		for i, h := range hashes {
+			if _, ok := notes[h.String()]; !ok {
+				return fmt.Errorf("error: %s has no note", revs[i])
+			}
+			delete(notes, h.String())
+		}
+		desc = "Notes removed by 'dolt notes remove'"
+	}
+
+	meta, err := notesCommitMeta(ctx, desc)
+	if err != nil {
+		return err
+	}
+	var parents []*doltdb.Commit
+	if head != nil {
+		parents = append(parents, head)
+	}
+	_, err = dbData.Ddb.CommitNotes(ctx, notesRef, notes, parents, meta)
+	return err
+}
+
+// mergeNotes merges the notes ref |theirs| in |src| into the notes ref |ours| in |dest|. The chunks of |theirs| must
+// already be in |dest|.
+func mergeNotes(ctx *sql.Context, dest, src *doltdb.DoltDB, ours, theirs ref.NotesRef, strategy doltdb.NotesMergeStrategy) error {
+	_, theirsHead, err := src.ResolveNotes(ctx, theirs)
+	if err != nil {
+		return err
+	}
+	if theirsHead == nil {
+		return fmt.Errorf("error: notes ref %s does not exist", theirs.String())
+	}
+	theirsHash, err := theirsHead.HashOf()
+	if err != nil {
+		return err
+	}
+	optCmt, err := dest.ReadCommit(ctx, theirsHash)
+	if err != nil {
+		return err
+	}
+	theirsHead, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+
+	meta, err := notesCommitMeta(ctx, fmt.Sprintf("Notes merged from %s with strategy %s", theirs.String(), strategy))
+	if err != nil {
+		return err
+	}
+	_, err = dest.MergeNotesCommit(ctx, ours, theirsHead, strategy, meta)
+	return err
+}
+
+// exchangeNotes pushes the notes ref given to a remote, or pulls it from a remote and merges it into the local notes.
+// Pushes only succeed when they fast-forward the remote's notes; pull first to merge diverged notes.
+func exchangeNotes(ctx *sql.Context, dSess *dsess.DoltSession, dbData env.DbData[*sql.Context], notesRef ref.NotesRef, subcommand string, args []string, strategy doltdb.NotesMergeStrategy) error {
+	var remote env.Remote
+	var err error
+	if len(args) == 0 {
+		remote, err = env.GetDefaultRemote(dbData.Rsr)
+	} else {
+		remotes, rerr := dbData.Rsr.GetRemotes()
+		if rerr != nil {
+			return rerr
+		}
+		var ok bool
+		if remote, ok = remotes.Get(args[0]); !ok {
+			err = env.ErrInvalidRepository.New(args[0])
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	remoteDB, err := dSess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote)
+	if err != nil {
+		return err
+	}
+	defer remoteDB.Close()
+	if err = remoteDB.Rebase(ctx); err != nil {
+		return fmt.Errorf("failed to read latest version of remote db: %w", err)
+	}
+
+	tmpDir, err := dbData.Rsw.TempTableFilesDir()
+	if err != nil {
+		return err
+	}
+
+	src, dest := dbData.Ddb, remoteDB
+	if subcommand == "pull" {
+		src, dest = remoteDB, dbData.Ddb
+	}
+	_, head, err := src.ResolveNotes(ctx, notesRef)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return fmt.Errorf("error: notes ref %s does not exist", notesRef.String())
+	}
+	addr, err := head.HashOf()
+	if err != nil {
+		return err
+	}
+
+	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+		err = dest.PullChunks(ctx, tmpDir, src, []hash.Hash{addr}, statsCh, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if subcommand == "pull" {
+		return mergeNotes(ctx, dest, src, notesRef, notesRef, strategy)
+	}
+
+	err = dest.FastForwardToHash(ctx, notesRef, addr)
+	if errors.Is(err, datas.ErrAlreadyCommitted) {
+		return nil
+	} else if errors.Is(err, datas.ErrMergeNeeded) {
+		return fmt.Errorf("error: the remote's notes have changed; pull them before pushing")
+	}
+	return err
+}
+
+func notesCommitMeta(ctx *sql.Context, desc string) (*datas.CommitMeta, error) {
+	name, email, _, _, err := dsess.ResolveNameEmail(ctx, dsess.DoltCommitterName, dsess.DoltCommitterEmail)
+	if err != nil {
+		return nil, err
+	}
+	return datas.NewCommitMeta(name, email, desc)
+}
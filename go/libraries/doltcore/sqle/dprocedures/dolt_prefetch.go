@@ -0,0 +1,147 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// doltPrefetch is the stored procedure version for the CLI command `dolt prefetch`. It fetches the data of a
+// revision, or of some of its tables, that a shallow clone would otherwise fetch lazily as it is read.
+func doltPrefetch(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltPrefetch(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltPrefetch(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return cmdFailure, fmt.Errorf("empty database name")
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	apr, err := cli.CreatePrefetchArgParser().Parse(args)
+	if err != nil {
+		return cmdFailure, err
+	}
+	if apr.NArg() == 0 {
+		return cmdFailure, fmt.Errorf("error: a revision to prefetch must be given")
+	}
+
+	var remote env.Remote
+	if name, ok := apr.GetValue(cli.RemoteParam); ok {
+		remotes, err := dbData.Rsr.GetRemotes()
+		if err != nil {
+			return cmdFailure, err
+		}
+		if remote, ok = remotes.Get(name); !ok {
+			return cmdFailure, env.ErrInvalidRepository.New(name)
+		}
+	} else if remote, err = env.GetDefaultRemote(dbData.Rsr); err != nil {
+		return cmdFailure, err
+	}
+
+	addrs, err := prefetchAddrs(ctx, dbData, apr.Arg(0), apr.Args[1:])
+	if err != nil {
+		return cmdFailure, err
+	}
+
+	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote)
+	if err != nil {
+		return cmdFailure, err
+	}
+	defer srcDB.Close()
+
+	tmpDir, err := dbData.Rsw.TempTableFilesDir()
+	if err != nil {
+		return cmdFailure, err
+	}
+
+	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+		err = dbData.Ddb.Prefetch(ctx, tmpDir, srcDB, addrs, statsCh)
+	})
+	if err != nil {
+		return cmdFailure, fmt.Errorf("prefetch failed: %w", err)
+	}
+	return cmdSuccess, nil
+}
+
+// prefetchAddrs returns the addresses of the root value of |rev|, or of the |tables| given in it.
+func prefetchAddrs(ctx *sql.Context, dbData env.DbData[*sql.Context], rev string, tables []string) ([]hash.Hash, error) {
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := doltdb.NewCommitSpec(rev)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, fmt.Errorf("%w; enable lazy fetching with `dolt config --local --add lazyfetch.remote <remote>` to prefetch it", doltdb.ErrGhostCommitEncountered)
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tables) == 0 {
+		addr, err := root.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		return []hash.Hash{addr}, nil
+	}
+
+	addrs := make([]hash.Hash, 0, len(tables))
+	for _, table := range tables {
+		tName, ok, err := resolve.TableName(ctx, root, table)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("%w: %s at %s", doltdb.ErrTableNotFound, table, rev)
+		}
+		addr, ok, err := root.GetTableHash(ctx, tName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("%w: %s at %s", doltdb.ErrTableNotFound, table, rev)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
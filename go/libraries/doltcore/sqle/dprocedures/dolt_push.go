@@ -27,12 +27,16 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
+// doltPushWarningCode is the code for warnings produced by dolt_push, which uses 1105, the code for an unknown error.
+const doltPushWarningCode int = 1105
+
 var doltPushSchema = []*sql.Column{
 	{
 		Name:     "status",
@@ -83,6 +87,9 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 	if err != nil {
 		return cmdFailure, "", err
 	}
+	if err = applyForcePushProtection(ctx, dbName, targets); err != nil {
+		return cmdFailure, "", err
+	}
 
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		rmt := (*remote).WithParams(map[string]string{
@@ -140,3 +147,21 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 	// TODO : set upstream should be persisted outside of session
 	return cmdSuccess, returnMsg, nil
 }
+
+// applyForcePushProtection rejects a forced push to any target whose destination branch forbids force pushes in the
+// database |dbName|. A remotesapi server enforces its own rules as well, see sqle.hooksFiringRemoteSrvStore.
+func applyForcePushProtection(ctx *sql.Context, dbName string, targets []*env.PushTarget) error {
+	for _, target := range targets {
+		if !target.Mode.Force || target.DestRef == nil || target.DestRef.GetType() != ref.BranchRefType {
+			continue
+		}
+		rules, err := branch_control.GetProtectionRules(ctx, dbName, target.DestRef.GetPath())
+		if err != nil {
+			return err
+		}
+		if rules.DenyForcePush {
+			return branch_control.ErrBranchProtected.New(rules.Branch, "force pushes are not allowed")
+		}
+	}
+	return nil
+}
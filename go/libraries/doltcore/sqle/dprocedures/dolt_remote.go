@@ -118,6 +118,11 @@ func addRemote(_ *sql.Context, dbName string, dbd env.DbData[*sql.Context], apr
 		params[dbfactory.GitRefParam] = ref
 	}
 
+	err = cli.AddEncryptionParams(scheme, apr, params)
+	if err != nil {
+		return err
+	}
+
 	r := env.NewRemote(remoteName, absRemoteUrl, params)
 	return dbd.Rsw.AddRemote(r)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
@@ -264,6 +265,9 @@ func resetHard(
 		if err != nil {
 			return err
 		}
+		if err := checkHardResetProtection(ctx, dSess, dbName, dbData, headRef, newHead); err != nil {
+			return err
+		}
 		if err := dbData.Ddb.SetHeadToCommit(ctx, headRef, newHead); err != nil {
 			return err
 		}
@@ -285,3 +289,39 @@ func resetHard(
 
 	return nil
 }
+
+// checkHardResetProtection returns an error if the branch given forbids hard resets and |newHead| is not its current
+// head. Hard resets that leave the head in place only discard working changes, so they are always allowed.
+func checkHardResetProtection(
+	ctx *sql.Context,
+	dSess *dsess.DoltSession,
+	dbName string,
+	dbData env.DbData[*sql.Context],
+	headRef ref.DoltRef,
+	newHead *doltdb.Commit,
+) error {
+	rules, err := dSess.BranchProtectionRules(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if !rules.DenyHardReset {
+		return nil
+	}
+
+	curHead, err := dbData.Ddb.ResolveCommitRef(ctx, headRef)
+	if err != nil {
+		return err
+	}
+	curHash, err := curHead.HashOf()
+	if err != nil {
+		return err
+	}
+	newHash, err := newHead.HashOf()
+	if err != nil {
+		return err
+	}
+	if curHash != newHash {
+		return branch_control.ErrBranchProtected.New(rules.Branch, "hard resets to another commit are not allowed")
+	}
+	return nil
+}
@@ -103,3 +103,23 @@ func mergePermDenied(ctx *sql.Context) error {
 	}
 	return branch_control.ErrIncorrectPermissions.New(user, host, branch)
 }
+
+// BranchProtectionRules returns the protection rules for the branch head of the database named.
+func (d *DoltSession) BranchProtectionRules(ctx *sql.Context, dbName string) (branch_control.ProtectionRules, error) {
+	branchState, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil {
+		return branch_control.ProtectionRules{}, err
+	} else if !ok {
+		return branch_control.ProtectionRules{}, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	return branchProtectionRules(ctx, branchState)
+}
+
+// branchProtectionRules returns the protection rules for the branch head of the given branch state. Only branches may
+// be protected, so the rules for any other revision are always empty.
+func branchProtectionRules(ctx *sql.Context, branchState *branchState) (branch_control.ProtectionRules, error) {
+	if branchState.revisionType != RevisionTypeBranch {
+		return branch_control.ProtectionRules{Branch: branchState.head}, nil
+	}
+	return branch_control.GetProtectionRules(ctx, branchState.dbState.dbName, branchState.head)
+}
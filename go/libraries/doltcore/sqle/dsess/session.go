@@ -621,6 +621,14 @@ func (d *DoltSession) DoltCommit(
 	tx sql.Transaction,
 	commit *doltdb.PendingCommit,
 ) (*doltdb.Commit, error) {
+	rules, err := d.BranchProtectionRules(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	if rules.RequireSignedCommits && commit.CommitOptions.Signer == nil {
+		return nil, branch_control.ErrBranchProtected.New(rules.Branch, "commits must be signed")
+	}
+
 	commitFunc := func(ctx *sql.Context, dtx *DoltTransaction, workingSet *doltdb.WorkingSet) (*doltdb.WorkingSet, *doltdb.Commit, error) {
 		ws, commit, err := dtx.DoltCommit(
 			ctx,
@@ -746,6 +754,8 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, dbName string, branchSt
 	var mergeParentCommits []*doltdb.Commit
 	if branchState.WorkingSet().MergeCommitParents() {
 		mergeParentCommits = []*doltdb.Commit{branchState.WorkingSet().MergeState().Commit()}
+	} else if len(props.MergeParents) > 0 {
+		mergeParentCommits = props.MergeParents
 	} else if props.Amend {
 		numParentsHeadForAmend := headCommit.NumParents()
 
@@ -773,6 +783,12 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, dbName string, branchSt
 		}
 	}
 
+	rules, err := branchProtectionRules(ctx, branchState)
+	if err != nil {
+		return nil, err
+	}
+	props.RequiredTestGroups = append(props.RequiredTestGroups, rules.RequiredTestGroups...)
+
 	tableResolver, err := GetTableResolver(ctx, dbName)
 	if err != nil {
 		return nil, err
@@ -785,6 +801,12 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, dbName string, branchSt
 		}
 	}
 
+	// Amending a merge commit keeps its parents, so the amended commit is still a merge
+	isMerge := branchState.WorkingSet().MergeCommitParents() || len(props.MergeParents) > 0 || (props.Amend && headCommit.NumParents() > 1)
+	if pendingCommit != nil && rules.RequireMerge && !isMerge {
+		return nil, branch_control.ErrBranchProtected.New(rules.Branch, "commits must be merges")
+	}
+
 	return pendingCommit, nil
 }
 
@@ -59,6 +59,8 @@ const (
 	DoltLogLevel                         = "dolt_log_level"
 	ShowSystemTables                     = "dolt_show_system_tables"
 	AllowCICreation                      = "dolt_allow_ci_creation"
+	DoltBulkLoad                         = "dolt_bulk_load"
+	DoltFindRenames                      = "dolt_find_renames"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
@@ -82,6 +84,17 @@ const (
 	DoltCommitterDate  = "dolt_committer_date"
 )
 
+// Values of the dolt_bulk_load system variable. When bulk loading is enabled, inserts into an empty table build its
+// indexes bottom-up rather than editing them row by row. BulkLoadSorted streams rows that arrive in primary key order
+// directly into the table's index, falling back to row by row edits at the first row out of order. BulkLoadExternalSort
+// sorts rows in temporary files first, so that input in any order can be loaded bottom-up, but reports duplicate keys
+// only when the statement completes, so statements which ignore or replace duplicates are written row by row.
+const (
+	BulkLoadOff          = "off"
+	BulkLoadSorted       = "sorted"
+	BulkLoadExternalSort = "external_sort"
+)
+
 const URLTemplateDatabasePlaceholder = "{database}"
 
 // DefineSystemVariablesForDB defines per database dolt-session variables in the engine as necessary
@@ -0,0 +1,390 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+const cellDiffTableDefaultRowCount = 100
+
+var _ sql.TableFunction = (*CellDiffTableFunction)(nil)
+
+// CellDiffTableFunction implements the DOLT_CELL_DIFF table function.
+// It takes two values and an optional granularity of 'word' (the default) or 'char'. Each row of the result table
+// represents a range of text that changed between the two values. Text values are diffed directly. JSON values are
+// diffed by path, and string values at the same path are diffed as text.
+type CellDiffTableFunction struct {
+	fromExpr        sql.Expression
+	toExpr          sql.Expression
+	granularityExpr sql.Expression
+	database        sql.Database
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dtf *CellDiffTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &CellDiffTableFunction{
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(ctx, expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (dtf *CellDiffTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(dtf.Schema(ctx))
+	numRows, _, err := dtf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (dtf *CellDiffTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return cellDiffTableDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (dtf *CellDiffTableFunction) Database() sql.Database {
+	return dtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dtf *CellDiffTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ndtf := *dtf
+	ndtf.database = database
+	return &ndtf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (dtf *CellDiffTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{dtf.fromExpr, dtf.toExpr}
+	if dtf.granularityExpr != nil {
+		exprs = append(exprs, dtf.granularityExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (dtf *CellDiffTableFunction) WithExpressions(ctx *sql.Context, expressions ...sql.Expression) (sql.Node, error) {
+	if len(expressions) < 2 || len(expressions) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(dtf.Name(), "2 or 3", len(expressions))
+	}
+	newDtf := *dtf
+	newDtf.fromExpr = expressions[0]
+	newDtf.toExpr = expressions[1]
+	newDtf.granularityExpr = nil
+	if len(expressions) == 3 {
+		newDtf.granularityExpr = expressions[2]
+	}
+	return &newDtf, nil
+}
+
+// Children implements the sql.Node interface
+func (dtf *CellDiffTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// RowIter implements the sql.Node interface
+func (dtf *CellDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	granularity, err := dtf.evalGranularity(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	fromValue, err := evalCellDiffValue(ctx, dtf.fromExpr, row)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := evalCellDiffValue(ctx, dtf.toExpr, row)
+	if err != nil {
+		return nil, err
+	}
+
+	_, fromJson := fromValue.(sql.JSONWrapper)
+	_, toJson := toValue.(sql.JSONWrapper)
+	if fromJson || toJson {
+		rows, err := jsonCellDiffRows(ctx, fromValue, toValue, granularity)
+		if err != nil {
+			return nil, err
+		}
+		return sql.RowsToRowIter(rows...), nil
+	}
+
+	fromText, err := cellDiffText(ctx, fromValue)
+	if err != nil {
+		return nil, err
+	}
+	toText, err := cellDiffText(ctx, toValue)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(textCellDiffRows(nil, fromText, toText, granularity)...), nil
+}
+
+// evalGranularity returns the text diff granularity named by the optional third argument.
+func (dtf *CellDiffTableFunction) evalGranularity(ctx *sql.Context, row sql.Row) (diff.TextGranularity, error) {
+	if dtf.granularityExpr == nil {
+		return diff.WordGranularity, nil
+	}
+	v, err := dtf.granularityExpr.Eval(ctx, row)
+	if err != nil {
+		return 0, err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("%s granularity must be 'word' or 'char', got %v", dtf.Name(), v)
+	}
+	switch strings.ToLower(s) {
+	case "word":
+		return diff.WordGranularity, nil
+	case "char":
+		return diff.CharGranularity, nil
+	default:
+		return 0, fmt.Errorf("%s granularity must be 'word' or 'char', got '%s'", dtf.Name(), s)
+	}
+}
+
+// evalCellDiffValue evaluates |expr|, unwrapping any out-of-band value such as a TEXT or JSON column value.
+func evalCellDiffValue(ctx *sql.Context, expr sql.Expression, row sql.Row) (interface{}, error) {
+	v, err := expr.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	if w, ok := v.(sql.AnyWrapper); ok {
+		return sql.UnwrapAny(ctx, w)
+	}
+	return v, nil
+}
+
+// cellDiffText returns |v| as text. NULL is diffed as the empty string.
+func cellDiffText(ctx *sql.Context, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case sql.JSONWrapper:
+		i, err := v.ToInterface(ctx)
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(i)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// textCellDiffRows returns a row for each changed range between |from| and |to|. Positions are 1-based character
+// positions, like those of LOCATE() and SUBSTRING().
+func textCellDiffRows(path interface{}, from, to string, granularity diff.TextGranularity) []sql.Row {
+	var rows []sql.Row
+	for _, change := range diff.TextChanges(diff.TextDiff(from, to, granularity)) {
+		rows = append(rows, sql.NewRow(
+			path,
+			textChangeTypeString(change.ChangeType()),
+			int64(change.FromPos+1),
+			int64(change.FromLen),
+			int64(change.ToPos+1),
+			int64(change.ToLen),
+			change.From,
+			change.To,
+		))
+	}
+	return rows
+}
+
+func textChangeTypeString(t diff.ChangeType) string {
+	switch t {
+	case diff.Added:
+		return "added"
+	case diff.Removed:
+		return "removed"
+	default:
+		return "modified"
+	}
+}
+
+// jsonCellDiffRows returns a row for each path that changed between the JSON documents |from| and |to|. A string
+// that changed at the same path is diffed as text, with a row for each changed range within it. Any other change is
+// returned as a single row holding the serialized values, with no positions.
+func jsonCellDiffRows(ctx *sql.Context, from, to interface{}, granularity diff.TextGranularity) ([]sql.Row, error) {
+	fromJson, _, err := gmstypes.JSON.Convert(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	toJson, _, err := gmstypes.JSON.Convert(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if fromJson == nil || toJson == nil {
+		// a NULL document has no paths to diff, so the whole document was added or removed
+		if fromJson == nil && toJson == nil {
+			return nil, nil
+		}
+		if fromJson == nil {
+			toText, err := cellDiffText(ctx, toJson)
+			if err != nil {
+				return nil, err
+			}
+			return []sql.Row{jsonCellDiffRow("$", tree.AddedDiff.DiffTypeString(), nil, toText)}, nil
+		}
+		fromText, err := cellDiffText(ctx, fromJson)
+		if err != nil {
+			return nil, err
+		}
+		return []sql.Row{jsonCellDiffRow("$", tree.RemovedDiff.DiffTypeString(), fromText, nil)}, nil
+	}
+
+	differ, err := tree.NewJsonDiffer(ctx, fromJson.(sql.JSONWrapper), toJson.(sql.JSONWrapper))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for {
+		jsonDiff, err := differ.Next(ctx)
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		path := tree.MySqlJsonPathFromKey(jsonDiff.Key)
+		if jsonDiff.Type == tree.ModifiedDiff {
+			fromStr, fromIsStr, err := jsonString(ctx, jsonDiff.From)
+			if err != nil {
+				return nil, err
+			}
+			toStr, toIsStr, err := jsonString(ctx, jsonDiff.To)
+			if err != nil {
+				return nil, err
+			}
+			if fromIsStr && toIsStr {
+				rows = append(rows, textCellDiffRows(path, fromStr, toStr, granularity)...)
+				continue
+			}
+		}
+
+		fromText, err := jsonDiffValueText(ctx, jsonDiff.From)
+		if err != nil {
+			return nil, err
+		}
+		toText, err := jsonDiffValueText(ctx, jsonDiff.To)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, jsonCellDiffRow(path, jsonDiff.Type.DiffTypeString(), fromText, toText))
+	}
+}
+
+// jsonCellDiffRow returns a row for a JSON change that is not diffed as text.
+func jsonCellDiffRow(path, diffType string, from, to interface{}) sql.Row {
+	return sql.NewRow(path, diffType, nil, nil, nil, nil, from, to)
+}
+
+// jsonString returns the value of |v| if it is a JSON string.
+func jsonString(ctx *sql.Context, v sql.JSONWrapper) (string, bool, error) {
+	if v == nil {
+		return "", false, nil
+	}
+	i, err := v.ToInterface(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	s, ok := i.(string)
+	return s, ok, nil
+}
+
+// jsonDiffValueText returns the serialized form of a value from a JSON diff, or nil if the value is missing.
+func jsonDiffValueText(ctx *sql.Context, v sql.JSONWrapper) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return cellDiffText(ctx, v)
+}
+
+// WithChildren implements the sql.Node interface
+func (dtf *CellDiffTableFunction) WithChildren(ctx *sql.Context, node ...sql.Node) (sql.Node, error) {
+	if len(node) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dtf, nil
+}
+
+var cellDiffTableSchema = sql.Schema{
+	&sql.Column{Name: "path", Type: gmstypes.Text, Nullable: true},
+	&sql.Column{Name: "diff_type", Type: gmstypes.Text},
+	&sql.Column{Name: "from_position", Type: gmstypes.Int64, Nullable: true},
+	&sql.Column{Name: "from_length", Type: gmstypes.Int64, Nullable: true},
+	&sql.Column{Name: "to_position", Type: gmstypes.Int64, Nullable: true},
+	&sql.Column{Name: "to_length", Type: gmstypes.Int64, Nullable: true},
+	&sql.Column{Name: "from_text", Type: gmstypes.LongText, Nullable: true},
+	&sql.Column{Name: "to_text", Type: gmstypes.LongText, Nullable: true},
+}
+
+// Schema implements the sql.Node interface
+func (dtf *CellDiffTableFunction) Schema(ctx *sql.Context) sql.Schema {
+	return cellDiffTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dtf *CellDiffTableFunction) Resolved() bool {
+	for _, expr := range dtf.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (dtf *CellDiffTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (dtf *CellDiffTableFunction) String() string {
+	exprs := dtf.Expressions()
+	args := make([]string, len(exprs))
+	for i, expr := range exprs {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_CELL_DIFF(%s)", strings.Join(args, ", "))
+}
+
+// Name implements the sql.TableFunction interface
+func (dtf *CellDiffTableFunction) Name() string {
+	return "dolt_cell_diff"
+}
@@ -0,0 +1,698 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const changesDefaultRowCount = 100
+
+var _ sql.TableFunction = (*ChangesTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ChangesTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*ChangesTableFunction)(nil)
+
+// ChangesTableFunction implements the dolt_changes table function. It emits one row for every row-level change made
+// by every commit in a commit range, ordered from the oldest commit to the newest. Each commit is diffed against its
+// first parent, so the stream describes exactly how the tip of the range was built up, one commit at a time.
+type ChangesTableFunction struct {
+	fromCommitExpr sql.Expression
+	toCommitExpr   sql.Expression
+	dotCommitExpr  sql.Expression
+	tableNameExpr  sql.Expression
+	database       sql.Database
+}
+
+var changesTableSchema = sql.Schema{
+	&sql.Column{Name: "commit_hash", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "committer", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "email", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "date", Type: types.Datetime3, Nullable: false},
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "diff_type", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "from_row", Type: types.JSON, Nullable: true},
+	&sql.Column{Name: "to_row", Type: types.JSON, Nullable: true},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (ctf *ChangesTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ChangesTableFunction{
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(ctx, expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (ctf *ChangesTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(ctf.Schema(ctx))
+	numRows, _, err := ctf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (ctf *ChangesTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return changesDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (ctf *ChangesTableFunction) Database() sql.Database {
+	return ctf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (ctf *ChangesTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nctf := *ctf
+	nctf.database = database
+	return &nctf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (ctf *ChangesTableFunction) Name() string {
+	return "dolt_changes"
+}
+
+func (ctf *ChangesTableFunction) commitsResolved() bool {
+	if ctf.dotCommitExpr != nil {
+		return ctf.dotCommitExpr.Resolved()
+	}
+	return ctf.fromCommitExpr.Resolved() && ctf.toCommitExpr.Resolved()
+}
+
+// Resolved implements the sql.Resolvable interface
+func (ctf *ChangesTableFunction) Resolved() bool {
+	if ctf.tableNameExpr != nil {
+		return ctf.commitsResolved() && ctf.tableNameExpr.Resolved()
+	}
+	return ctf.commitsResolved()
+}
+
+func (ctf *ChangesTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (ctf *ChangesTableFunction) String() string {
+	args := make([]string, 0, 3)
+	for _, expr := range ctf.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_CHANGES(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (ctf *ChangesTableFunction) Schema(_ *sql.Context) sql.Schema {
+	return changesTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (ctf *ChangesTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (ctf *ChangesTableFunction) WithChildren(_ *sql.Context, children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return ctf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (ctf *ChangesTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	baseDB, _ := doltdb.SplitRevisionDbName(ctf.database.Name())
+	if ctf.tableNameExpr != nil {
+		if !types.IsText(ctf.tableNameExpr.Type(ctx)) {
+			return ExpressionIsDeferred(ctx, ctf.tableNameExpr)
+		}
+
+		tableNameVal, err := ctf.tableNameExpr.Eval(ctx, nil)
+		if err != nil {
+			return false
+		}
+		tableName, ok, err := sql.Unwrap[string](ctx, tableNameVal)
+		if err != nil || !ok {
+			return false
+		}
+
+		subject := sql.PrivilegeCheckSubject{Database: baseDB, Table: tableName}
+		return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+	}
+
+	tblNames, err := ctf.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		subject := sql.PrivilegeCheckSubject{Database: baseDB, Table: tblName}
+		operations = append(operations, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (ctf *ChangesTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{}
+	if ctf.dotCommitExpr != nil {
+		exprs = append(exprs, ctf.dotCommitExpr)
+	} else {
+		exprs = append(exprs, ctf.fromCommitExpr, ctf.toCommitExpr)
+	}
+	if ctf.tableNameExpr != nil {
+		exprs = append(exprs, ctf.tableNameExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (ctf *ChangesTableFunction) WithExpressions(ctx *sql.Context, exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(ctf.Name(), "1 to 3", len(exprs))
+	}
+
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(ctf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(ctf.Name(), expr.String())
+		}
+	}
+
+	nctf := *ctf
+	if strings.Contains(exprs[0].String(), "..") {
+		if len(exprs) > 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(nctf.Name(), "1 or 2", len(exprs))
+		}
+		nctf.dotCommitExpr = exprs[0]
+		if len(exprs) == 2 {
+			nctf.tableNameExpr = exprs[1]
+		}
+	} else {
+		if len(exprs) < 2 || len(exprs) > 3 {
+			return nil, sql.ErrInvalidArgumentNumber.New(nctf.Name(), "2 or 3", len(exprs))
+		}
+		nctf.fromCommitExpr = exprs[0]
+		nctf.toCommitExpr = exprs[1]
+		if len(exprs) == 3 {
+			nctf.tableNameExpr = exprs[2]
+		}
+	}
+
+	for _, expr := range nctf.Expressions() {
+		if !types.IsText(expr.Type(ctx)) && !expression.IsBindVar(expr) {
+			return nil, sql.ErrInvalidArgumentDetails.New(nctf.Name(), expr.String())
+		}
+	}
+
+	return &nctf, nil
+}
+
+// RowIter implements the sql.Node interface
+func (ctf *ChangesTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	fromCommitVal, toCommitVal, dotCommitVal, tableName, err := ctf.evaluateArguments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := ctf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", ctf.database)
+	}
+
+	fromStr, toStr, err := loadCommitStrings(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.Name())
+	if err == doltdb.ErrOperationNotSupportedInDetachedHead {
+		// In detached HEAD state, we can still resolve commits without a branch ref
+		headRef = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ddb := sqledb.DbData().Ddb
+	fromCm, err := resolveCommit(ctx, ddb, headRef, fromStr)
+	if err != nil {
+		return nil, err
+	}
+	toCm, err := resolveCommit(ctx, ddb, headRef, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := commitsInRange(ctx, ddb, fromCm, toCm)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChangesTableFunctionRowIter(ctx, sqledb, commits, tableName), nil
+}
+
+// evaluateArguments returns fromCommitVal, toCommitVal, dotCommitVal, and tableName.
+// It evaluates the argument expressions to turn them into values this ChangesTableFunction
+// can use. Note that this method only evals the expressions, and doesn't validate the values.
+func (ctf *ChangesTableFunction) evaluateArguments(ctx *sql.Context) (interface{}, interface{}, interface{}, string, error) {
+	var tableName string
+	if ctf.tableNameExpr != nil {
+		tableNameVal, err := ctf.tableNameExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", err
+		}
+		tn, ok := tableNameVal.(string)
+		if !ok {
+			return nil, nil, nil, "", ErrInvalidTableName.New(ctf.tableNameExpr.String())
+		}
+		tableName = tn
+	}
+
+	if ctf.dotCommitExpr != nil {
+		dotCommitVal, err := ctf.dotCommitExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", err
+		}
+
+		return nil, nil, dotCommitVal, tableName, nil
+	}
+
+	fromCommitVal, err := ctf.fromCommitExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	toCommitVal, err := ctf.toCommitExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	return fromCommitVal, toCommitVal, nil, tableName, nil
+}
+
+// commitsInRange returns the commits reachable from |to| but not from |from|, ordered so that every commit comes
+// after all of its ancestors.
+func commitsInRange(ctx *sql.Context, ddb *doltdb.DoltDB, from, to *doltdb.Commit) ([]*doltdb.Commit, error) {
+	fromHash, err := from.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := to.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := commitwalk.GetDotDotRevisionsIterator[*sql.Context](ctx, ddb, []hash.Hash{toHash}, ddb, []hash.Hash{fromHash}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*doltdb.Commit
+	for {
+		_, optCmt, _, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		commits = append(commits, cm)
+	}
+
+	// The iterator yields commits newest first; a change stream is consumed oldest first.
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// ------------------------------------
+// changesTableFunctionRowIter
+// ------------------------------------
+
+var _ sql.RowIter = (*changesTableFunctionRowIter)(nil)
+
+// changesTableFunctionRowIter produces the rows of dolt_changes on a background goroutine, so that a change stream
+// spanning many commits never needs to be held in memory all at once. The producer always closes |rows| when it
+// finishes; an error, if any, is left in |errChan| beforehand and is only reported once every queued row has been
+// returned, so that the rows a query sees don't depend on goroutine scheduling.
+type changesTableFunctionRowIter struct {
+	sqledb  dsess.SqlDatabase
+	rows    chan sql.Row
+	errChan chan error
+	cancel  context.CancelFunc
+}
+
+func newChangesTableFunctionRowIter(ctx *sql.Context, sqledb dsess.SqlDatabase, commits []*doltdb.Commit, tableName string) *changesTableFunctionRowIter {
+	child, cancel := context.WithCancel(ctx)
+	itr := &changesTableFunctionRowIter{
+		sqledb:  sqledb,
+		rows:    make(chan sql.Row, 64),
+		errChan: make(chan error, 1),
+		cancel:  cancel,
+	}
+
+	go func() {
+		itr.queueRows(ctx.WithContext(child), commits, tableName)
+	}()
+
+	return itr
+}
+
+func (itr *changesTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case row, ok := <-itr.rows:
+		if !ok {
+			select {
+			case err := <-itr.errChan:
+				return nil, err
+			default:
+				return nil, io.EOF
+			}
+		}
+		return row, nil
+	}
+}
+
+func (itr *changesTableFunctionRowIter) Close(_ *sql.Context) error {
+	itr.cancel()
+	return nil
+}
+
+func (itr *changesTableFunctionRowIter) queueRows(ctx *sql.Context, commits []*doltdb.Commit, tableName string) {
+	// |rows| is closed on every path, so that Next drains everything already queued before it reports an error
+	defer close(itr.rows)
+	for _, cm := range commits {
+		if err := itr.queueCommitRows(ctx, cm, tableName); err != nil {
+			itr.errChan <- err
+			return
+		}
+	}
+}
+
+// queueCommitRows queues a row for every row-level change between |cm| and its first parent.
+func (itr *changesTableFunctionRowIter) queueCommitRows(ctx *sql.Context, cm *doltdb.Commit, tableName string) error {
+	h, err := cm.HashOf()
+	if err != nil {
+		return err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return err
+	}
+	toRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+
+	var fromRoot doltdb.RootValue
+	if cm.NumParents() == 0 {
+		fromRoot, err = doltdb.EmptyRootValue(ctx, toRoot.VRW(), toRoot.NodeStore())
+		if err != nil {
+			return err
+		}
+	} else {
+		optCmt, err := cm.GetParent(ctx, 0)
+		if err != nil {
+			return err
+		}
+		parent, ok := optCmt.ToCommit()
+		if !ok {
+			return doltdb.ErrGhostCommitEncountered
+		}
+		fromRoot, err = parent.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	info := changeCommitInfo{hash: h.String(), meta: meta}
+	for _, delta := range deltas {
+		if delta.FromTable == nil && delta.ToTable == nil {
+			continue
+		}
+		if tableName != "" && !strings.EqualFold(delta.FromName.Name, tableName) && !strings.EqualFold(delta.ToName.Name, tableName) {
+			continue
+		}
+		if !schema.ArePrimaryKeySetsDiffable(delta.FromSch, delta.ToSch) {
+			ctx.Warn(dtables.PrimaryKeyChangeWarningCode, dtables.PrimaryKeyChangeWarning, info.hash+"^", info.hash)
+			continue
+		}
+		if err = itr.queueDeltaRows(ctx, info, delta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type changeCommitInfo struct {
+	hash string
+	meta *datas.CommitMeta
+}
+
+// queueDeltaRows queues a row for every row-level change in |delta|. Keyless tables produce one row per duplicate
+// row added or removed. The dolt_policies of the table are applied to both versions of each changed row, and a change
+// is skipped unless every version present passes the row filters.
+func (itr *changesTableFunctionRowIter) queueDeltaRows(ctx *sql.Context, info changeCommitInfo, delta diff.TableDelta) error {
+	fromIdx, toIdx, err := delta.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	var from, to prolly.Map
+	fromSch, toSch := schema.EmptySchema, schema.EmptySchema
+	if fromIdx != nil {
+		if from, err = durable.ProllyMapFromIndex(fromIdx); err != nil {
+			return err
+		}
+		fromSch = delta.FromSch
+	}
+	if toIdx != nil {
+		if to, err = durable.ProllyMapFromIndex(toIdx); err != nil {
+			return err
+		}
+		toSch = delta.ToSch
+	}
+
+	ns := delta.ToNodeStore
+	if delta.ToTable == nil {
+		ns = delta.FromNodeStore
+	}
+	fromConverter, err := dtables.NewProllyRowConverter(ctx, fromSch, fromSch, ctx.Warn, ns)
+	if err != nil {
+		return err
+	}
+	toConverter, err := dtables.NewProllyRowConverter(ctx, toSch, toSch, ctx.Warn, ns)
+	if err != nil {
+		return err
+	}
+
+	tableName := delta.CurName()
+	policies, err := policiesForDelta(ctx, itr.sqledb, delta)
+	if err != nil {
+		return err
+	}
+	fromSide, err := itr.newChangeSide(ctx, tableName, fromSch, fromConverter, policies)
+	if err != nil {
+		return err
+	}
+	toSide, err := itr.newChangeSide(ctx, tableName, toSch, toConverter, policies)
+	if err != nil {
+		return err
+	}
+
+	keyless := schema.IsKeyless(fromSch) && schema.IsKeyless(toSch)
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, d tree.Diff) error {
+		n := uint64(1)
+		if keyless {
+			d, n = keylessDiffAndCardinality(d)
+		}
+
+		var fromRow, toRow interface{}
+		var ok bool
+		var err error
+		if d.Type != tree.AddedDiff {
+			if fromRow, ok, err = fromSide.rowToJSON(ctx, d.Key, d.From); err != nil || !ok {
+				return err
+			}
+		}
+		if d.Type != tree.RemovedDiff {
+			if toRow, ok, err = toSide.rowToJSON(ctx, d.Key, d.To); err != nil || !ok {
+				return err
+			}
+		}
+
+		r := sql.Row{
+			info.hash,
+			info.meta.Committer.Name,
+			info.meta.Committer.Email,
+			info.meta.Committer.Date.Time(),
+			tableName,
+			d.Type.DiffTypeString(),
+			fromRow,
+			toRow,
+		}
+		for i := uint64(0); i < n; i++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case itr.rows <- r:
+			}
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// keylessDiffAndCardinality translates a diff of a keyless row into the diff of a single row instance, and returns
+// the number of instances it applies to.
+func keylessDiffAndCardinality(d tree.Diff) (tree.Diff, uint64) {
+	switch d.Type {
+	case tree.AddedDiff:
+		return d, val.ReadKeylessCardinality(val.Tuple(d.To))
+	case tree.RemovedDiff:
+		return d, val.ReadKeylessCardinality(val.Tuple(d.From))
+	default:
+		fN := val.ReadKeylessCardinality(val.Tuple(d.From))
+		tN := val.ReadKeylessCardinality(val.Tuple(d.To))
+		if fN < tN {
+			d.Type = tree.AddedDiff
+			return d, tN - fN
+		}
+		d.Type = tree.RemovedDiff
+		return d, fN - tN
+	}
+}
+
+// changeSide converts one version of the changed rows of a table into JSON objects.
+type changeSide struct {
+	conv dtables.ProllyRowConverter
+	sch  schema.Schema
+	// policies is nil unless the current user's reads of the table are restricted by dolt_policies
+	policies *policy.RowEvaluator
+}
+
+func (itr *changesTableFunctionRowIter) newChangeSide(ctx *sql.Context, tableName string, sch schema.Schema, conv dtables.ProllyRowConverter, policies []policy.Policy) (changeSide, error) {
+	cs := changeSide{conv: conv, sch: sch}
+	if len(policies) == 0 || sch.GetAllCols().Size() == 0 {
+		return cs, nil
+	}
+	sqlSch, err := sqlutil.FromDoltSchema(ctx, itr.sqledb.RevisionQualifiedName(), tableName, sch)
+	if err != nil {
+		return changeSide{}, err
+	}
+	cs.policies, err = policy.NewRowEvaluator(ctx, itr.sqledb.RevisionQualifiedName(), tableName, policies, sqlSch.Schema)
+	if err != nil {
+		return changeSide{}, err
+	}
+	return cs, nil
+}
+
+// rowToJSON converts the row stored as |key| and |value| into a JSON object keyed by column name. It returns false if
+// the row is filtered out by the table's policies.
+func (cs changeSide) rowToJSON(ctx *sql.Context, key, value tree.Item) (interface{}, bool, error) {
+	cols := cs.sch.GetAllCols().GetColumns()
+	r := make(sql.Row, len(cols))
+	if err := cs.conv.PutConverted(ctx, val.Tuple(key), val.Tuple(value), r); err != nil {
+		return nil, false, err
+	}
+	if cs.policies != nil {
+		var ok bool
+		var err error
+		if r, ok, err = cs.policies.Apply(ctx, r); err != nil || !ok {
+			return nil, false, err
+		}
+	}
+
+	obj := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		if col.Virtual {
+			continue
+		}
+		v, err := changeValueToJSON(ctx, col.TypeInfo.ToSqlType(), r[i])
+		if err != nil {
+			return nil, false, err
+		}
+		obj[col.Name] = v
+	}
+	return types.JSONDocument{Val: obj}, true, nil
+}
+
+// changeValueToJSON returns a JSON compatible representation of |v|. Values without a natural JSON representation,
+// such as decimals, temporal types and enums, are rendered as their SQL string form.
+func changeValueToJSON(ctx *sql.Context, typ sql.Type, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, string, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64:
+		return v, nil
+	case sql.JSONWrapper:
+		return v.ToInterface(ctx)
+	}
+
+	sqlVal, err := typ.SQL(ctx, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return sqlVal.ToString(), nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangesRowIterDrainsRowsBeforeError(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	expectedErr := errors.New("producer failed")
+
+	itr := &changesTableFunctionRowIter{
+		rows:    make(chan sql.Row, 3),
+		errChan: make(chan error, 1),
+		cancel:  func() {},
+	}
+	itr.rows <- sql.Row{1}
+	itr.rows <- sql.Row{2}
+	itr.errChan <- expectedErr
+	close(itr.rows)
+
+	row, err := itr.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{1}, row)
+	row, err = itr.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{2}, row)
+	_, err = itr.Next(ctx)
+	assert.Equal(t, expectedErr, err)
+}
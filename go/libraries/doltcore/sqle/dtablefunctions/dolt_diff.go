@@ -34,6 +34,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	dolttable "github.com/dolthub/dolt/go/libraries/doltcore/table"
@@ -347,7 +348,50 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 		toSchema, fromSchema,
 		nil)
 
-	return dtables.NewDiffPartitionRowIter(dp, ddb), nil
+	return dtf.applyPolicies(ctx, dtables.NewDiffPartitionRowIter(dp, ddb))
+}
+
+// applyPolicies returns |iter| with the dolt_policies of the diffed table applied to its rows, if any of them apply to
+// the current user.
+func (dtf *DiffTableFunction) applyPolicies(ctx *sql.Context, iter sql.RowIter) (sql.RowIter, error) {
+	sqledb, ok := dtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unable to get dolt database")
+	}
+
+	tableName := dtf.tableDelta.ToName.Name
+	if dtf.tableDelta.ToTable == nil {
+		tableName = dtf.tableDelta.FromName.Name
+	}
+	policies, err := policiesForDelta(ctx, sqledb, dtf.tableDelta)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return iter, nil
+	}
+	return policy.NewRowIter(ctx, sqledb.RevisionQualifiedName(), tableName, policy.KindDiff, policies, dtf.Schema(ctx), iter)
+}
+
+// policiesForDelta returns the dolt_policies that restrict the current user's reads of the table diffed by |delta|. A
+// renamed table is restricted by the policies of both of its names.
+func policiesForDelta(ctx *sql.Context, sqledb dsess.SqlDatabase, delta diff.TableDelta) ([]policy.Policy, error) {
+	names := []string{delta.ToName.Name}
+	if fromName := delta.FromName.Name; !strings.EqualFold(fromName, names[0]) {
+		names = append(names, fromName)
+	}
+	var policies []policy.Policy
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		namePolicies, err := policy.ForTable(ctx, sqledb, name)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, namePolicies...)
+	}
+	return policies, nil
 }
 
 // findMatchingDelta returns the best matching table delta for the table name
@@ -684,14 +728,20 @@ func (dtf *DiffTableFunction) PartitionRows(ctx *sql.Context, part sql.Partition
 	if part == nil {
 		return dtf.RowIter(ctx, nil)
 	}
+	var iter sql.RowIter
+	var err error
 	switch p := part.(type) {
 	case *dtables.DiffPartition:
-		return p.GetRowIter(ctx)
+		iter, err = p.GetRowIter(ctx)
 	case *dtables.SecondaryDiffPartition:
-		return p.GetRowIter(ctx)
+		iter, err = p.GetRowIter(ctx)
 	default:
 		return nil, fmt.Errorf("unexpected partition type: %T", part)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return dtf.applyPolicies(ctx, iter)
 }
 
 func (dtf *DiffTableFunction) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
@@ -894,6 +944,10 @@ func (dtf *DiffTableFunction) cacheTableDelta(ctx *sql.Context, fromCommitVal, t
 	if err != nil {
 		return diff.TableDelta{}, err
 	}
+	deltas, err = findRenames(ctx, deltas)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
 
 	dtf.fromRefDetails = fromRefDetails
 	dtf.toRefDetails = toRefDetails
@@ -289,6 +289,10 @@ func (ds *DiffStatTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.Row
 	if err != nil {
 		return nil, err
 	}
+	deltas, err = findRenames(ctx, deltas)
+	if err != nil {
+		return nil, err
+	}
 
 	// If tableNameExpr defined, return a single table diff stat result
 	if ds.tableNameExpr != nil {
@@ -281,6 +281,10 @@ func (ds *DiffSummaryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.
 	if err != nil {
 		return nil, err
 	}
+	deltas, err = findRenames(ctx, deltas)
+	if err != nil {
+		return nil, err
+	}
 
 	sort.Slice(deltas, func(i, j int) bool {
 		return deltas[i].ToName.Less(deltas[j].ToName)
@@ -481,3 +485,21 @@ func getIgnorePatternsFromContext(ctx *sql.Context, database sql.Database) (dolt
 	// Return patterns for default schema
 	return ignorePatternMap[""], nil
 }
+
+// findRenames pairs up dropped and added tables and columns in |deltas| that are similar enough to be renames, as
+// configured by the |@@dolt_find_renames| session variable. Detection is off when the variable is zero.
+func findRenames(ctx *sql.Context, deltas []diff.TableDelta) ([]diff.TableDelta, error) {
+	similarity, err := ctx.GetSessionVariable(ctx, dsess.DoltFindRenames)
+	if err != nil {
+		return nil, err
+	}
+
+	switch similarity := similarity.(type) {
+	case int64:
+		return diff.DetectRenames(ctx, deltas, int(similarity))
+	case int8:
+		return diff.DetectRenames(ctx, deltas, int(similarity))
+	default:
+		return deltas, nil
+	}
+}
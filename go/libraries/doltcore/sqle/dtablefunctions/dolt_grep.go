@@ -0,0 +1,855 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const grepDefaultRowCount = 100
+
+var _ sql.TableFunction = (*GrepTableFunction)(nil)
+var _ sql.ExecSourceRel = (*GrepTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*GrepTableFunction)(nil)
+
+// GrepTableFunction implements the dolt_grep table function. It searches the text, JSON and binary columns of
+// revisions for a regular expression, emitting a row for every matching cell. For a commit range, it instead diffs
+// every commit in the range against its first parent and emits a row whenever a match appears or disappears, so
+// only the rows changed by each commit are searched.
+type GrepTableFunction struct {
+	database      sql.Database
+	argumentExprs []sql.Expression
+}
+
+var grepTableSchema = sql.Schema{
+	&sql.Column{Name: "commit_hash", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "primary_key", Type: types.JSON, Nullable: true},
+	&sql.Column{Name: "column_name", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "diff_type", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "value", Type: types.LongText, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (gtf *GrepTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	hasDeferredExpression := false
+	for _, expr := range expressions {
+		var err error
+		sql.Inspect(ctx, expr, func(ctx *sql.Context, expr sql.Expression) bool {
+			switch expr.(type) {
+			case sql.FunctionExpression, *expression.UnresolvedFunction:
+				err = ErrInvalidNonLiteralArgument.New(gtf.Name(), expr.String())
+				return false
+			}
+			if isDeferredExpression(expr) {
+				hasDeferredExpression = true
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newInstance := &GrepTableFunction{database: db, argumentExprs: expressions}
+	if !hasDeferredExpression {
+		// parse literal arguments now, so that bad patterns and options fail during analysis
+		if _, err := newInstance.evaluateArguments(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+	return newInstance, nil
+}
+
+func (gtf *GrepTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(gtf.Schema(ctx))
+	numRows, _, err := gtf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (gtf *GrepTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return grepDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (gtf *GrepTableFunction) Database() sql.Database {
+	return gtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (gtf *GrepTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ngtf := *gtf
+	ngtf.database = database
+	return &ngtf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (gtf *GrepTableFunction) Name() string {
+	return "dolt_grep"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (gtf *GrepTableFunction) Resolved() bool {
+	for _, expr := range gtf.argumentExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (gtf *GrepTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (gtf *GrepTableFunction) String() string {
+	args := make([]string, 0, len(gtf.argumentExprs))
+	for _, expr := range gtf.argumentExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_GREP(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (gtf *GrepTableFunction) Schema(_ *sql.Context) sql.Schema {
+	return grepTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (gtf *GrepTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (gtf *GrepTableFunction) WithChildren(_ *sql.Context, children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return gtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode. The user must be able to select from every table
+// searched in any of the revisions, which may include tables that no longer exist in the current working set. When
+// the arguments are bind variables, the revisions aren't known until the statement runs, so SELECT on the whole
+// database is required instead.
+func (gtf *GrepTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	baseDB, _ := doltdb.SplitRevisionDbName(gtf.database.Name())
+	for _, expr := range gtf.argumentExprs {
+		if isDeferredExpression(expr) {
+			subject := sql.PrivilegeCheckSubject{Database: baseDB}
+			return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+		}
+	}
+
+	args, err := gtf.evaluateArguments(ctx, nil)
+	if err != nil {
+		return false
+	}
+	sqledb, ok := gtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return false
+	}
+	tblNames, err := grepTableNames(ctx, sqledb, args)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		subject := sql.PrivilegeCheckSubject{Database: baseDB, Table: tblName}
+		operations = append(operations, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// grepTableNames returns the names of the tables dolt_grep searches for |args|: the tables of each revision, and for a
+// commit range, the tables of every commit in the range and of its first parent.
+func grepTableNames(ctx *sql.Context, db dsess.SqlDatabase, args grepArgs) ([]string, error) {
+	seen := make(map[string]struct{})
+	var names []string
+	addRoot := func(root doltdb.RootValue) error {
+		tableNames, err := doltdb.UnionTableNames(ctx, root)
+		if err != nil {
+			return err
+		}
+		for _, tableName := range tableNames {
+			lwr := strings.ToLower(tableName.Name)
+			if _, ok := seen[lwr]; ok || !args.includesTable(tableName.Name) {
+				continue
+			}
+			seen[lwr] = struct{}{}
+			names = append(names, tableName.Name)
+		}
+		return nil
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	for _, rev := range args.revisions {
+		if !strings.Contains(rev, "..") {
+			root, _, _, err := sess.ResolveRootForRef(ctx, db.Name(), rev)
+			if err != nil {
+				return nil, err
+			}
+			if err = addRoot(root); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		commits, err := grepRangeCommits(ctx, db, rev)
+		if err != nil {
+			return nil, err
+		}
+		for _, cm := range commits {
+			toRoot, fromRoot, err := grepCommitRoots(ctx, cm)
+			if err != nil {
+				return nil, err
+			}
+			if err = addRoot(toRoot); err != nil {
+				return nil, err
+			}
+			if err = addRoot(fromRoot); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return names, nil
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (gtf *GrepTableFunction) Expressions() []sql.Expression {
+	return gtf.argumentExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (gtf *GrepTableFunction) WithExpressions(_ *sql.Context, exprs ...sql.Expression) (sql.Node, error) {
+	ngtf := *gtf
+	ngtf.argumentExprs = exprs
+	return &ngtf, nil
+}
+
+// grepArgs are the parsed arguments of dolt_grep.
+type grepArgs struct {
+	pattern   *regexp.Regexp
+	revisions []string
+	tables    map[string]struct{}
+}
+
+// includesTable returns whether the table named |name| should be searched.
+func (ga grepArgs) includesTable(name string) bool {
+	if ga.tables == nil {
+		return true
+	}
+	_, ok := ga.tables[strings.ToLower(name)]
+	return ok
+}
+
+// evaluateArguments evaluates the argument expressions and parses them with the same parser as the CLI.
+func (gtf *GrepTableFunction) evaluateArguments(ctx *sql.Context, row sql.Row) (grepArgs, error) {
+	args, err := getDoltArgs(ctx, gtf.argumentExprs, gtf.Name(), row)
+	if err != nil {
+		return grepArgs{}, err
+	}
+	apr, err := cli.CreateGrepArgParser().Parse(args)
+	if err != nil {
+		return grepArgs{}, err
+	}
+
+	positional := apr.Args
+	var tables []string
+	if sep := apr.PositionalArgsSeparatorIndex; sep >= 0 {
+		positional, tables = apr.Args[:sep], apr.Args[sep:]
+	}
+	if len(positional) == 0 {
+		return grepArgs{}, fmt.Errorf("%s requires a pattern to search for", gtf.Name())
+	}
+
+	expr := positional[0]
+	if apr.Contains(cli.IgnoreCaseFlag) {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return grepArgs{}, fmt.Errorf("invalid pattern '%s': %w", positional[0], err)
+	}
+
+	ga := grepArgs{pattern: pattern, revisions: positional[1:]}
+	for _, rev := range ga.revisions {
+		if strings.Contains(rev, "...") {
+			return grepArgs{}, fmt.Errorf("%s does not support three dot ranges: %s", gtf.Name(), rev)
+		}
+	}
+	if len(ga.revisions) == 0 {
+		ga.revisions = []string{doltdb.Working}
+	}
+	if len(tables) > 0 {
+		ga.tables = make(map[string]struct{}, len(tables))
+		for _, t := range tables {
+			ga.tables[strings.ToLower(t)] = struct{}{}
+		}
+	}
+	return ga, nil
+}
+
+// RowIter implements the sql.Node interface
+func (gtf *GrepTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	args, err := gtf.evaluateArguments(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := gtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", gtf.database)
+	}
+
+	return newGrepTableFunctionRowIter(ctx, sqledb, args), nil
+}
+
+// ------------------------------------
+// grepTableFunctionRowIter
+// ------------------------------------
+
+var _ sql.RowIter = (*grepTableFunctionRowIter)(nil)
+
+// grepTableFunctionRowIter produces the rows of dolt_grep on a background goroutine, like the iterator of
+// dolt_changes, so that searching a long history never holds all of its matches in memory. The producer always
+// closes |rows| when it finishes; an error, if any, is left in |errChan| beforehand and is only reported once every
+// queued row has been returned.
+type grepTableFunctionRowIter struct {
+	db      dsess.SqlDatabase
+	args    grepArgs
+	rows    chan sql.Row
+	errChan chan error
+	cancel  context.CancelFunc
+}
+
+func newGrepTableFunctionRowIter(ctx *sql.Context, db dsess.SqlDatabase, args grepArgs) *grepTableFunctionRowIter {
+	child, cancel := context.WithCancel(ctx)
+	itr := &grepTableFunctionRowIter{
+		db:      db,
+		args:    args,
+		rows:    make(chan sql.Row, 64),
+		errChan: make(chan error, 1),
+		cancel:  cancel,
+	}
+
+	go func() {
+		itr.queueRows(ctx.WithContext(child))
+	}()
+
+	return itr
+}
+
+func (itr *grepTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case row, ok := <-itr.rows:
+		if !ok {
+			select {
+			case err := <-itr.errChan:
+				return nil, err
+			default:
+				return nil, io.EOF
+			}
+		}
+		return row, nil
+	}
+}
+
+func (itr *grepTableFunctionRowIter) Close(_ *sql.Context) error {
+	itr.cancel()
+	return nil
+}
+
+func (itr *grepTableFunctionRowIter) queueRows(ctx *sql.Context) {
+	// |rows| is closed on every path, so that Next drains everything already queued before it reports an error
+	defer close(itr.rows)
+	for _, rev := range itr.args.revisions {
+		var err error
+		if strings.Contains(rev, "..") {
+			err = itr.queueRangeRows(ctx, rev)
+		} else {
+			err = itr.queueRevisionRows(ctx, rev)
+		}
+		if err != nil {
+			itr.errChan <- err
+			return
+		}
+	}
+}
+
+func (itr *grepTableFunctionRowIter) queueRow(ctx *sql.Context, r sql.Row) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case itr.rows <- r:
+		return nil
+	}
+}
+
+// queueRevisionRows queues a row for every matching cell of the revision |rev|.
+func (itr *grepTableFunctionRowIter) queueRevisionRows(ctx *sql.Context, rev string) error {
+	sess := dsess.DSessFromSess(ctx.Session)
+	root, _, hashStr, err := sess.ResolveRootForRef(ctx, itr.db.Name(), rev)
+	if err != nil {
+		return err
+	}
+
+	tableNames, err := doltdb.UnionTableNames(ctx, root)
+	if err != nil {
+		return err
+	}
+	sort.Slice(tableNames, func(i, j int) bool {
+		return tableNames[i].String() < tableNames[j].String()
+	})
+
+	for _, tableName := range tableNames {
+		if !itr.args.includesTable(tableName.Name) {
+			continue
+		}
+		tbl, ok, err := root.GetTable(ctx, tableName)
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasGrepColumns(sch) {
+			continue
+		}
+
+		idx, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return err
+		}
+		m, err := durable.ProllyMapFromIndex(idx)
+		if err != nil {
+			return err
+		}
+		conv, err := dtables.NewProllyRowConverter(ctx, sch, sch, ctx.Warn, tbl.NodeStore())
+		if err != nil {
+			return err
+		}
+		policies, err := policy.ForTable(ctx, itr.db, tableName.Name)
+		if err != nil {
+			return err
+		}
+		side, err := itr.newGrepSide(ctx, tableName.Name, sch, conv, policies)
+		if err != nil {
+			return err
+		}
+
+		iter, err := m.IterAll(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			k, v, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			matches, _, err := side.matchRow(ctx, itr.args.pattern, k, v)
+			if err != nil {
+				return err
+			}
+			for _, match := range matches {
+				if err = itr.queueRow(ctx, sql.Row{hashStr, tableName.String(), match.pk, match.column, nil, match.value}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// queueRangeRows queues a row for every match that appears or disappears in a commit of the range |rev|, diffing
+// each commit against its first parent.
+func (itr *grepTableFunctionRowIter) queueRangeRows(ctx *sql.Context, rev string) error {
+	commits, err := grepRangeCommits(ctx, itr.db, rev)
+	if err != nil {
+		return err
+	}
+	for _, cm := range commits {
+		if err = itr.queueCommitRows(ctx, cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grepRangeCommits returns the commits of the two dot range |rev|.
+func grepRangeCommits(ctx *sql.Context, db dsess.SqlDatabase, rev string) ([]*doltdb.Commit, error) {
+	revs := strings.Split(rev, "..")
+	if len(revs) != 2 || revs[0] == "" || revs[1] == "" {
+		return nil, fmt.Errorf("invalid commit range: %s", rev)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, db.Name())
+	if err == doltdb.ErrOperationNotSupportedInDetachedHead {
+		headRef = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ddb := db.DbData().Ddb
+	fromCm, err := resolveCommit(ctx, ddb, headRef, revs[0])
+	if err != nil {
+		return nil, err
+	}
+	toCm, err := resolveCommit(ctx, ddb, headRef, revs[1])
+	if err != nil {
+		return nil, err
+	}
+	return commitsInRange(ctx, ddb, fromCm, toCm)
+}
+
+// grepCommitRoots returns the root of |cm| and the root of its first parent, which is empty for the first commit.
+func grepCommitRoots(ctx *sql.Context, cm *doltdb.Commit) (toRoot, fromRoot doltdb.RootValue, err error) {
+	toRoot, err = cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cm.NumParents() == 0 {
+		fromRoot, err = doltdb.EmptyRootValue(ctx, toRoot.VRW(), toRoot.NodeStore())
+		if err != nil {
+			return nil, nil, err
+		}
+		return toRoot, fromRoot, nil
+	}
+	optCmt, err := cm.GetParent(ctx, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, nil, doltdb.ErrGhostCommitEncountered
+	}
+	fromRoot, err = parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return toRoot, fromRoot, nil
+}
+
+// queueCommitRows queues a row for every match that differs between |cm| and its first parent.
+func (itr *grepTableFunctionRowIter) queueCommitRows(ctx *sql.Context, cm *doltdb.Commit) error {
+	h, err := cm.HashOf()
+	if err != nil {
+		return err
+	}
+	toRoot, fromRoot, err := grepCommitRoots(ctx, cm)
+	if err != nil {
+		return err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	for _, delta := range deltas {
+		if delta.FromTable == nil && delta.ToTable == nil {
+			continue
+		}
+		if !itr.args.includesTable(delta.FromName.Name) && !itr.args.includesTable(delta.ToName.Name) {
+			continue
+		}
+		if (delta.FromSch == nil || !hasGrepColumns(delta.FromSch)) && (delta.ToSch == nil || !hasGrepColumns(delta.ToSch)) {
+			continue
+		}
+		if !schema.ArePrimaryKeySetsDiffable(delta.FromSch, delta.ToSch) {
+			ctx.Warn(dtables.PrimaryKeyChangeWarningCode, dtables.PrimaryKeyChangeWarning, h.String()+"^", h.String())
+			continue
+		}
+		if err = itr.queueDeltaRows(ctx, h.String(), delta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueDeltaRows queues a row for every match added or removed by the row-level changes in |delta|. As in
+// dolt_changes, the dolt_policies of the table are applied to both versions of each changed row, and a change is
+// skipped unless every version present passes the row filters.
+func (itr *grepTableFunctionRowIter) queueDeltaRows(ctx *sql.Context, commitHash string, delta diff.TableDelta) error {
+	fromIdx, toIdx, err := delta.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	var from, to prolly.Map
+	fromSch, toSch := schema.EmptySchema, schema.EmptySchema
+	if fromIdx != nil {
+		if from, err = durable.ProllyMapFromIndex(fromIdx); err != nil {
+			return err
+		}
+		fromSch = delta.FromSch
+	}
+	if toIdx != nil {
+		if to, err = durable.ProllyMapFromIndex(toIdx); err != nil {
+			return err
+		}
+		toSch = delta.ToSch
+	}
+
+	ns := delta.ToNodeStore
+	if delta.ToTable == nil {
+		ns = delta.FromNodeStore
+	}
+	fromConverter, err := dtables.NewProllyRowConverter(ctx, fromSch, fromSch, ctx.Warn, ns)
+	if err != nil {
+		return err
+	}
+	toConverter, err := dtables.NewProllyRowConverter(ctx, toSch, toSch, ctx.Warn, ns)
+	if err != nil {
+		return err
+	}
+
+	tableName := delta.CurName()
+	policies, err := policiesForDelta(ctx, itr.db, delta)
+	if err != nil {
+		return err
+	}
+	fromSide, err := itr.newGrepSide(ctx, tableName, fromSch, fromConverter, policies)
+	if err != nil {
+		return err
+	}
+	toSide, err := itr.newGrepSide(ctx, tableName, toSch, toConverter, policies)
+	if err != nil {
+		return err
+	}
+
+	keyless := schema.IsKeyless(fromSch) && schema.IsKeyless(toSch)
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, d tree.Diff) error {
+		if keyless {
+			d, _ = keylessDiffAndCardinality(d)
+		}
+
+		var fromMatches, toMatches []grepMatch
+		var ok bool
+		var err error
+		if d.Type != tree.AddedDiff {
+			if fromMatches, ok, err = fromSide.matchRow(ctx, itr.args.pattern, val.Tuple(d.Key), val.Tuple(d.From)); err != nil || !ok {
+				return err
+			}
+		}
+		if d.Type != tree.RemovedDiff {
+			if toMatches, ok, err = toSide.matchRow(ctx, itr.args.pattern, val.Tuple(d.Key), val.Tuple(d.To)); err != nil || !ok {
+				return err
+			}
+		}
+
+		for _, m := range fromMatches {
+			if !containsGrepColumn(toMatches, m.column) {
+				if err = itr.queueRow(ctx, sql.Row{commitHash, tableName, m.pk, m.column, tree.RemovedDiff.DiffTypeString(), m.value}); err != nil {
+					return err
+				}
+			}
+		}
+		for _, m := range toMatches {
+			if !containsGrepColumn(fromMatches, m.column) {
+				if err = itr.queueRow(ctx, sql.Row{commitHash, tableName, m.pk, m.column, tree.AddedDiff.DiffTypeString(), m.value}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// grepMatch is a cell matching the pattern of dolt_grep.
+type grepMatch struct {
+	pk     interface{}
+	column string
+	value  string
+}
+
+func containsGrepColumn(matches []grepMatch, column string) bool {
+	for _, m := range matches {
+		if m.column == column {
+			return true
+		}
+	}
+	return false
+}
+
+// isGrepColumn returns whether dolt_grep searches |col|: text, binary and JSON columns are searched.
+func isGrepColumn(col schema.Column) bool {
+	if col.Virtual {
+		return false
+	}
+	typ := col.TypeInfo.ToSqlType()
+	return types.IsText(typ) || types.IsJSON(typ)
+}
+
+func hasGrepColumns(sch schema.Schema) bool {
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if isGrepColumn(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// grepSide converts the rows of one version of a table for matching.
+type grepSide struct {
+	conv dtables.ProllyRowConverter
+	sch  schema.Schema
+	// policies is nil unless the current user's reads of the table are restricted by dolt_policies
+	policies *policy.RowEvaluator
+}
+
+func (itr *grepTableFunctionRowIter) newGrepSide(ctx *sql.Context, tableName string, sch schema.Schema, conv dtables.ProllyRowConverter, policies []policy.Policy) (grepSide, error) {
+	gs := grepSide{conv: conv, sch: sch}
+	if len(policies) == 0 || sch.GetAllCols().Size() == 0 {
+		return gs, nil
+	}
+	sqlSch, err := sqlutil.FromDoltSchema(ctx, itr.db.RevisionQualifiedName(), tableName, sch)
+	if err != nil {
+		return grepSide{}, err
+	}
+	gs.policies, err = policy.NewRowEvaluator(ctx, itr.db.RevisionQualifiedName(), tableName, policies, sqlSch.Schema)
+	if err != nil {
+		return grepSide{}, err
+	}
+	return gs, nil
+}
+
+// matchRow converts the row stored as |key| and |value| and returns its searchable cells which match |pattern|. The
+// table's policies are applied first, so masked values are matched as they are masked, and false is returned for a
+// row the policies filter out. The primary key of keyless rows is nil.
+func (gs grepSide) matchRow(ctx *sql.Context, pattern *regexp.Regexp, key, value val.Tuple) ([]grepMatch, bool, error) {
+	sch := gs.sch
+	cols := sch.GetAllCols().GetColumns()
+	r := make(sql.Row, len(cols))
+	if err := gs.conv.PutConverted(ctx, key, value, r); err != nil {
+		return nil, false, err
+	}
+	if gs.policies != nil {
+		var ok bool
+		var err error
+		if r, ok, err = gs.policies.Apply(ctx, r); err != nil || !ok {
+			return nil, false, err
+		}
+	}
+
+	var matches []grepMatch
+	for i, col := range cols {
+		if !isGrepColumn(col) {
+			continue
+		}
+		text, ok, err := grepCellText(ctx, r[i])
+		if err != nil {
+			return nil, false, err
+		}
+		if ok && pattern.MatchString(text) {
+			matches = append(matches, grepMatch{column: col.Name, value: text})
+		}
+	}
+	if len(matches) == 0 || schema.IsKeyless(sch) {
+		return matches, true, nil
+	}
+
+	pk := make(map[string]interface{}, sch.GetPKCols().Size())
+	for i, col := range cols {
+		if !col.IsPartOfPK {
+			continue
+		}
+		v, err := changeValueToJSON(ctx, col.TypeInfo.ToSqlType(), r[i])
+		if err != nil {
+			return nil, false, err
+		}
+		pk[col.Name] = v
+	}
+	for i := range matches {
+		matches[i].pk = types.JSONDocument{Val: pk}
+	}
+	return matches, true, nil
+}
+
+// grepCellText returns the text dolt_grep matches against for the cell value |v|. JSON values are searched in their
+// serialized form.
+func grepCellText(ctx *sql.Context, v interface{}) (string, bool, error) {
+	if w, ok := v.(sql.AnyWrapper); ok {
+		var err error
+		if v, err = sql.UnwrapAny(ctx, w); err != nil {
+			return "", false, err
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	case sql.JSONWrapper:
+		i, err := v.ToInterface(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		data, err := json.Marshal(i)
+		if err != nil {
+			return "", false, err
+		}
+		return string(data), true, nil
+	default:
+		return fmt.Sprint(v), true, nil
+	}
+}
@@ -47,6 +47,11 @@ type LogTableFunctionArgs struct {
 	decoration      string
 	showParents     bool
 	showSignature   bool
+	author          string
+	grep            string
+	since           string
+	until           string
+	filter          *dtables.LogFilter
 }
 
 // Name implements the sql.TableFunction interface
@@ -141,6 +146,15 @@ func (ltfa *LogTableFunctionArgs) addOptions(ctx *sql.Context, expressions []sql
 	ltfa.showParents = apr.Contains(cli.ParentsFlag)
 	ltfa.showSignature = apr.Contains(cli.ShowSignatureFlag)
 
+	ltfa.author = apr.GetValueOrDefault(cli.AuthorParam, "")
+	ltfa.grep = apr.GetValueOrDefault(cli.GrepParam, "")
+	ltfa.since = apr.GetValueOrDefault(cli.SinceParam, "")
+	ltfa.until = apr.GetValueOrDefault(cli.UntilParam, "")
+	ltfa.filter, err = dtables.NewLogFilter(ltfa.author, ltfa.grep, ltfa.since, ltfa.until)
+	if err != nil {
+		return sql.ErrInvalidArgumentDetails.New(ltfa.Name(), err.Error())
+	}
+
 	// Default to short so ad-hoc SQL callers see refs without an extra flag. auto has no
 	// defined meaning here because the server has no tty signal, so accept it for back
 	// compatibility with older dolt clients but warn and downgrade to short.
@@ -158,7 +172,13 @@ func (ltfa *LogTableFunctionArgs) addOptions(ctx *sql.Context, expressions []sql
 
 	// store revision strs directly from cli parse instead of mapping back exprs
 	// avoid circular conv expr -> str -> expr, downstream
-	for _, revisionStr := range apr.Args {
+	// args after a -- separator are table names, the same as --tables
+	revisionArgs := apr.Args
+	if apr.PositionalArgsSeparatorIndex >= 0 {
+		revisionArgs = apr.Args[:apr.PositionalArgsSeparatorIndex]
+		ltfa.tableNames = append(ltfa.tableNames, apr.Args[apr.PositionalArgsSeparatorIndex:]...)
+	}
+	for _, revisionStr := range revisionArgs {
 		if strings.HasPrefix(revisionStr, "^") {
 			revisionStr = strings.TrimPrefix(revisionStr, "^")
 			ltfa.notRevisionStrs = append(ltfa.notRevisionStrs, revisionStr)
@@ -277,6 +297,17 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, "--tables", strings.Join(ltf.tableNames, ","))
 	}
 
+	for _, opt := range []struct{ flag, val string }{
+		{cli.AuthorParam, ltf.author},
+		{cli.GrepParam, ltf.grep},
+		{cli.SinceParam, ltf.since},
+		{cli.UntilParam, ltf.until},
+	} {
+		if opt.val != "" {
+			options = append(options, fmt.Sprintf("--%s %s", opt.flag, opt.val))
+		}
+	}
+
 	return strings.Join(options, ", ")
 }
 
@@ -468,7 +499,17 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 			return false, nil
 		}
 
-		return commit.NumParents() >= ltf.minParents, nil
+		if commit.NumParents() < args.minParents {
+			return false, nil
+		}
+		if args.filter == nil {
+			return true, nil
+		}
+		meta, err := commit.GetCommitMeta(ctx)
+		if err != nil {
+			return false, err
+		}
+		return args.filter.Matches(meta), nil
 	}
 
 	cHashToRefs, err := dtables.GetCommitHashToRefs(ctx, sqledb.DbData().Ddb, args.decoration)
@@ -555,14 +596,14 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 
 		notCommits = append(notCommits, mergeCommit)
 
-		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
+		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, args.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
 	}
 
 	if len(revisionValStrs) <= 1 && len(notRevisionValStrs) == 0 {
-		return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits[0], matchFunc, cHashToRefs, ltf.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
+		return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits[0], matchFunc, cHashToRefs, args.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
 	}
 
-	return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, ltf.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
+	return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commits, notCommits, matchFunc, cHashToRefs, args.tableNames, dtables.LogRowOptions{ShowParents: args.showParents, ShowSignature: args.showSignature})
 }
 
 var _ sql.RowIter = (*logTableFunctionRowIter)(nil)
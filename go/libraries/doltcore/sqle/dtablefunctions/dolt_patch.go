@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -139,6 +140,10 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 	if err != nil {
 		return nil, err
 	}
+	tableDeltas, err = findRenames(ctx, tableDeltas)
+	if err != nil {
+		return nil, err
+	}
 
 	sort.Slice(tableDeltas, func(i, j int) bool {
 		return tableDeltas[i].ToName.Less(tableDeltas[j].ToName)
@@ -167,6 +172,24 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 	includeSchemaDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), schemaChangePartitionKey)
 	includeDataDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), dataChangePartitionKey)
 
+	// Patches are generated from the raw rows of each table, so they can't honor row filters and column masks
+	if includeDataDiff {
+		for _, td := range tableDeltas {
+			for _, name := range []string{td.ToName.Name, td.FromName.Name} {
+				if name == "" {
+					continue
+				}
+				policies, err := policy.ForTable(ctx, sqledb, name)
+				if err != nil {
+					return nil, err
+				}
+				if len(policies) > 0 {
+					return nil, policy.ErrRestrictedTable.New(p.Name(), name)
+				}
+			}
+		}
+	}
+
 	patches, err := getPatchNodes(ctx, sqledb.DbData(), tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff)
 	if err != nil {
 		return nil, err
@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/policy"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -440,6 +441,15 @@ func (pm *PreviewMergeConflictsTableFunction) RowIter(ctx *sql.Context, row sql.
 		return nil, err
 	}
 
+	// Conflicts hold the raw rows of each side of the merge, so they can't honor row filters and column masks
+	policies, err := policy.ForTable(ctx, pm.database.(dsess.SqlDatabase), pm.tblName.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) > 0 {
+		return nil, policy.ErrRestrictedTable.New(pm.Name(), pm.tblName.Name)
+	}
+
 	merger, err := merge.NewMerger(pm.rootInfo.leftRoot, pm.rootInfo.rightRoot, pm.rootInfo.baseRoot, pm.rootInfo.rightCm, pm.rootInfo.ancCm, pm.rootInfo.leftRoot.VRW(), pm.rootInfo.leftRoot.NodeStore())
 	if err != nil {
 		return nil, err
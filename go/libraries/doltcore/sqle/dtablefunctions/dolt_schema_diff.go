@@ -298,6 +298,10 @@ func (ds *SchemaDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.R
 	if err != nil {
 		return nil, err
 	}
+	deltas, err = findRenames(ctx, deltas)
+	if err != nil {
+		return nil, err
+	}
 
 	sort.Slice(deltas, func(i, j int) bool {
 		return deltas[i].ToName.Less(deltas[j].ToName)
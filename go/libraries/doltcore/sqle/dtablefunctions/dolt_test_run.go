@@ -33,6 +33,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/store/val"
 )
@@ -60,6 +61,10 @@ type TestsRunTableFunction struct {
 	engine        *gms.Engine
 }
 
+func init() {
+	dprocedures.RunDoltTests = runDoltTests
+}
+
 var testRunTableSchema = sql.Schema{
 	&sql.Column{Name: "test_name", Type: types.Text},
 	&sql.Column{Name: "test_group_name", Type: types.Text},
@@ -225,6 +230,23 @@ func (trtf *TestsRunTableFunction) RowCount(_ *sql.Context) (uint64, bool, error
 	return testsRunDefaultRowCount, false, nil
 }
 
+// runDoltTests runs dolt_tests rows that were read from a different database than the one they run against, as when
+// bisecting with the tests of the current branch, and returns a description of each failing test.
+func runDoltTests(ctx *sql.Context, engine *gms.Engine, tests []sql.Row) ([]string, error) {
+	trtf := &TestsRunTableFunction{catalog: engine.Analyzer.Catalog, engine: engine}
+	var failures []string
+	for _, row := range tests {
+		result, err := trtf.queryAndAssert(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status != "PASS" {
+			failures = append(failures, fmt.Sprintf("%s (%s)", result.TestName, result.Message))
+		}
+	}
+	return failures, nil
+}
+
 func (trtf *TestsRunTableFunction) queryAndAssert(ctx *sql.Context, row sql.Row) (result TestResult, err error) {
 	testName, groupName, query, assertion, comparison, value, err := parseDoltTestsRow(ctx, row)
 	if err != nil {
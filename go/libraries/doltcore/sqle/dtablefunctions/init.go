@@ -30,4 +30,7 @@ var DoltTableFunctions = []sql.TableFunction{
 	&QueryDiffTableFunction{},
 	&TestsRunTableFunction{},
 	&JsonDiffTableFunction{},
+	&CellDiffTableFunction{},
+	&ChangesTableFunction{},
+	&GrepTableFunction{},
 }
@@ -0,0 +1,312 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+const (
+	ProtectionTableName = "dolt_branch_protection"
+)
+
+// protectionSchema is the schema for the "dolt_branch_protection" table.
+var protectionSchema = sql.Schema{
+	&sql.Column{
+		Name:       "database",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Source:     ProtectionTableName,
+		PrimaryKey: true,
+	},
+	&sql.Column{
+		Name:       "branch",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Source:     ProtectionTableName,
+		PrimaryKey: true,
+	},
+	&sql.Column{
+		Name:     "required_test_groups",
+		Type:     types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_bin),
+		Source:   ProtectionTableName,
+		Nullable: true,
+	},
+	&sql.Column{
+		Name:     "deny_force_push",
+		Type:     types.Boolean,
+		Source:   ProtectionTableName,
+		Nullable: true,
+	},
+	&sql.Column{
+		Name:     "deny_hard_reset",
+		Type:     types.Boolean,
+		Source:   ProtectionTableName,
+		Nullable: true,
+	},
+	&sql.Column{
+		Name:     "require_merge",
+		Type:     types.Boolean,
+		Source:   ProtectionTableName,
+		Nullable: true,
+	},
+	&sql.Column{
+		Name:     "require_signed_commits",
+		Type:     types.Boolean,
+		Source:   ProtectionTableName,
+		Nullable: true,
+	},
+}
+
+// BranchProtectionTable provides a layer over the branch_control.Protection structure, exposing it as a system table.
+type BranchProtectionTable struct {
+	*branch_control.Protection
+}
+
+var _ sql.Table = BranchProtectionTable{}
+var _ sql.InsertableTable = BranchProtectionTable{}
+var _ sql.ReplaceableTable = BranchProtectionTable{}
+var _ sql.UpdatableTable = BranchProtectionTable{}
+var _ sql.DeletableTable = BranchProtectionTable{}
+var _ sql.RowInserter = BranchProtectionTable{}
+var _ sql.RowReplacer = BranchProtectionTable{}
+var _ sql.RowUpdater = BranchProtectionTable{}
+var _ sql.RowDeleter = BranchProtectionTable{}
+
+// NewBranchProtectionTable returns a new BranchProtectionTable.
+func NewBranchProtectionTable(protection *branch_control.Protection) BranchProtectionTable {
+	return BranchProtectionTable{protection}
+}
+
+// Name implements the interface sql.Table.
+func (tbl BranchProtectionTable) Name() string {
+	return ProtectionTableName
+}
+
+// String implements the interface sql.Table.
+func (tbl BranchProtectionTable) String() string {
+	return ProtectionTableName
+}
+
+// Schema implements the interface sql.Table.
+func (tbl BranchProtectionTable) Schema(ctx *sql.Context) sql.Schema {
+	return protectionSchema
+}
+
+// Collation implements the interface sql.Table.
+func (tbl BranchProtectionTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (tbl BranchProtectionTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (tbl BranchProtectionTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	tbl.RWMutex.RLock()
+	defer tbl.RWMutex.RUnlock()
+
+	var rows []sql.Row
+	for _, value := range tbl.Values {
+		rows = append(rows, sql.Row{
+			value.Database,
+			value.Branch,
+			value.RequiredTestGroups,
+			boolToInt8(value.DenyForcePush),
+			boolToInt8(value.DenyHardReset),
+			boolToInt8(value.RequireMerge),
+			boolToInt8(value.RequireSignedCommits),
+		})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Inserter implements the interface sql.InsertableTable.
+func (tbl BranchProtectionTable) Inserter(context *sql.Context) sql.RowInserter {
+	return tbl
+}
+
+// Replacer implements the interface sql.ReplaceableTable.
+func (tbl BranchProtectionTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return tbl
+}
+
+// Updater implements the interface sql.UpdatableTable.
+func (tbl BranchProtectionTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return tbl
+}
+
+// Deleter implements the interface sql.DeletableTable.
+func (tbl BranchProtectionTable) Deleter(context *sql.Context) sql.RowDeleter {
+	return tbl
+}
+
+// StatementBegin implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Insert implements the interface sql.RowInserter.
+func (tbl BranchProtectionTable) Insert(ctx *sql.Context, row sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	value, err := rowToProtectionValue(ctx, row)
+	if err != nil {
+		return err
+	}
+	if !tbl.canModify(ctx, value.Database, value.Branch) {
+		bas := branch_control.GetBranchAwareSession(ctx)
+		return branch_control.ErrInsertingProtection.New(bas.GetUser(), bas.GetHost(), value.Database, value.Branch)
+	}
+	// If we already have this in the table, then we return a duplicate PK error
+	if tbl.GetIndex(value.Database, value.Branch) != -1 {
+		return sql.NewUniqueKeyErr(
+			fmt.Sprintf(`[%q, %q]`, value.Database, value.Branch),
+			true,
+			sql.Row{value.Database, value.Branch})
+	}
+	tbl.Protection.Insert(value)
+	return nil
+}
+
+// Update implements the interface sql.RowUpdater.
+func (tbl BranchProtectionTable) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	oldValue, err := rowToProtectionValue(ctx, old)
+	if err != nil {
+		return err
+	}
+	newValue, err := rowToProtectionValue(ctx, new)
+	if err != nil {
+		return err
+	}
+
+	// If we're not updating the same row, then we pre-emptively check for a row violation
+	if oldValue.Database != newValue.Database || oldValue.Branch != newValue.Branch {
+		if tbl.GetIndex(newValue.Database, newValue.Branch) != -1 {
+			return sql.NewUniqueKeyErr(
+				fmt.Sprintf(`[%q, %q]`, newValue.Database, newValue.Branch),
+				true,
+				sql.Row{newValue.Database, newValue.Branch})
+		}
+	}
+	// Updates weaken the old rule, so the user must be able to modify both the old and new rows
+	if !tbl.canModify(ctx, oldValue.Database, oldValue.Branch) {
+		bas := branch_control.GetBranchAwareSession(ctx)
+		return branch_control.ErrUpdatingProtection.New(bas.GetUser(), bas.GetHost(), oldValue.Database, oldValue.Branch)
+	}
+	if !tbl.canModify(ctx, newValue.Database, newValue.Branch) {
+		bas := branch_control.GetBranchAwareSession(ctx)
+		return branch_control.ErrUpdatingProtection.New(bas.GetUser(), bas.GetHost(), newValue.Database, newValue.Branch)
+	}
+
+	tbl.Protection.Delete(oldValue.Database, oldValue.Branch)
+	tbl.Protection.Insert(newValue)
+	return nil
+}
+
+// Delete implements the interface sql.RowDeleter.
+func (tbl BranchProtectionTable) Delete(ctx *sql.Context, row sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	value, err := rowToProtectionValue(ctx, row)
+	if err != nil {
+		return err
+	}
+	if !tbl.canModify(ctx, value.Database, value.Branch) {
+		bas := branch_control.GetBranchAwareSession(ctx)
+		return branch_control.ErrDeletingProtection.New(bas.GetUser(), bas.GetHost(), value.Database, value.Branch)
+	}
+	tbl.Protection.Delete(value.Database, value.Branch)
+	return nil
+}
+
+// Close implements the interface sql.Closer.
+func (tbl BranchProtectionTable) Close(context *sql.Context) error {
+	return branch_control.SaveData(context)
+}
+
+// canModify returns whether the context's user may modify rows for the given database and branch expressions. Users
+// with the correct database privileges may modify any row, while all other users must have admin permissions on the
+// branch expression. Assumes that the expressions have already been folded.
+func (tbl BranchProtectionTable) canModify(ctx *sql.Context, database string, branch string) bool {
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	// A nil session means we're not in the SQL context, so we allow the modification in such a case
+	if branchAwareSession == nil || branch_control.HasDatabasePrivileges(branchAwareSession, database) {
+		return true
+	}
+	// tbl.Access() shares a lock with the protection table. No need to acquire its lock.
+	// As we've folded the branch expression, we can use it directly as though it were a normal branch name to
+	// determine if the user attempting the modification has permission to perform the modification.
+	_, modPerms := tbl.Access().Match(database, branch, branchAwareSession.GetUser(), branchAwareSession.GetHost())
+	return modPerms&branch_control.Permissions_Admin == branch_control.Permissions_Admin
+}
+
+// rowToProtectionValue converts the given row to a branch_control.ProtectionValue, folding the database and branch
+// expressions. A NULL column is treated as the absence of its rule.
+func rowToProtectionValue(ctx *sql.Context, row sql.Row) (branch_control.ProtectionValue, error) {
+	// Database and Branch are case-insensitive
+	value := branch_control.ProtectionValue{
+		Database: strings.ToLower(branch_control.FoldExpression(row[0].(string))),
+		Branch:   strings.ToLower(branch_control.FoldExpression(row[1].(string))),
+	}
+	// Verify that the lengths of each expression fit within an uint16
+	if len(value.Database) > math.MaxUint16 || len(value.Branch) > math.MaxUint16 {
+		return branch_control.ProtectionValue{}, branch_control.ErrExpressionsTooLong.New(value.Database, value.Branch, "", "")
+	}
+	if row[2] != nil {
+		value.RequiredTestGroups = strings.Join(branch_control.ParseTestGroups(row[2].(string)), ",")
+	}
+
+	var err error
+	flags := []*bool{&value.DenyForcePush, &value.DenyHardReset, &value.RequireMerge, &value.RequireSignedCommits}
+	for i, flag := range flags {
+		if row[3+i] == nil {
+			continue
+		}
+		if *flag, err = sql.ConvertToBool(ctx, row[3+i]); err != nil {
+			return branch_control.ProtectionValue{}, err
+		}
+	}
+	return value, nil
+}
+
+func boolToInt8(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*CIRunsTable)(nil)
+var _ sql.Table = (*CIRunStepsTable)(nil)
+
+// CIRunsTable is a read-only system table listing the dolt ci workflow runs recorded for a database.
+type CIRunsTable struct {
+	dbName    string
+	ddb       *doltdb.DoltDB
+	tableName string
+}
+
+// NewCIRunsTable returns a new CIRunsTable for the database given.
+func NewCIRunsTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB, tableName string) sql.Table {
+	return &CIRunsTable{dbName: dbName, ddb: ddb, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (t *CIRunsTable) Name() string {
+	return t.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (t *CIRunsTable) String() string {
+	return t.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the ci runs system table
+func (t *CIRunsTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "run_id", Type: types.Text, Source: t.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "workflow_name", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "event", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "branch", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "commit_hash", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "status", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "error", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "started_at", Type: types.DatetimeMaxPrecision, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "finished_at", Type: types.DatetimeMaxPrecision, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (t *CIRunsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (t *CIRunsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (t *CIRunsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	runs, err := t.ddb.GetCIRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, 0, len(runs))
+	for _, run := range runs {
+		rows = append(rows, sql.NewRow(
+			run.Id,
+			run.Workflow,
+			run.Event,
+			run.Branch,
+			nilIfEmpty(run.Commit),
+			run.Status,
+			nilIfEmpty(run.Error),
+			run.StartedAt,
+			run.FinishedAt,
+		))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// CIRunStepsTable is a read-only system table listing the outcome of every step of the dolt ci workflow runs
+// recorded for a database.
+type CIRunStepsTable struct {
+	dbName    string
+	ddb       *doltdb.DoltDB
+	tableName string
+}
+
+// NewCIRunStepsTable returns a new CIRunStepsTable for the database given.
+func NewCIRunStepsTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB, tableName string) sql.Table {
+	return &CIRunStepsTable{dbName: dbName, ddb: ddb, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (t *CIRunStepsTable) Name() string {
+	return t.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (t *CIRunStepsTable) String() string {
+	return t.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the ci run steps system table
+func (t *CIRunStepsTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "run_id", Type: types.Text, Source: t.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "step_order", Type: types.Int32, Source: t.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "job_name", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "step_name", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "status", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "failure_detail", Type: types.Text, Source: t.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (t *CIRunStepsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (t *CIRunStepsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (t *CIRunStepsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	runs, err := t.ddb.GetCIRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, run := range runs {
+		for i, step := range run.Steps {
+			rows = append(rows, sql.NewRow(
+				run.Id,
+				int32(i+1),
+				step.Job,
+				step.Step,
+				step.Status,
+				nilIfEmpty(step.Failure),
+			))
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
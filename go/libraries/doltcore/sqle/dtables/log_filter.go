@@ -0,0 +1,230 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// LogFilter restricts a commit log to the commits whose metadata matches every condition set on it. It is applied
+// while the commit graph is walked, so commits that don't match are skipped without building a row for them.
+type LogFilter struct {
+	// Author matches against the commit author formatted as "name <email>".
+	Author *regexp.Regexp
+	// Grep matches against the commit message.
+	Grep *regexp.Regexp
+	// Since and Until bound the committer date, inclusively. A zero value leaves that side of the range open.
+	Since time.Time
+	Until time.Time
+}
+
+// NewLogFilter returns a LogFilter built from the values of the --author, --grep, --since and --until options. Empty
+// values are ignored, and nil is returned when every value is empty.
+func NewLogFilter(author, grep, since, until string) (*LogFilter, error) {
+	if author == "" && grep == "" && since == "" && until == "" {
+		return nil, nil
+	}
+
+	f := &LogFilter{}
+	var err error
+	if author != "" {
+		if f.Author, err = regexp.Compile(author); err != nil {
+			return nil, fmt.Errorf("invalid --author pattern '%s': %w", author, err)
+		}
+	}
+	if grep != "" {
+		if f.Grep, err = regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern '%s': %w", grep, err)
+		}
+	}
+	if since != "" {
+		if f.Since, err = dconfig.ParseDate(since); err != nil {
+			return nil, err
+		}
+	}
+	if until != "" {
+		if f.Until, err = dconfig.ParseDate(until); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Matches returns whether |meta| satisfies the filter. A nil filter matches every commit.
+func (f *LogFilter) Matches(meta *datas.CommitMeta) bool {
+	if f == nil {
+		return true
+	}
+	if f.Author != nil && !f.Author.MatchString(fmt.Sprintf("%s <%s>", meta.Author.Name, meta.Author.Email)) {
+		return false
+	}
+	if f.Grep != nil && !f.Grep.MatchString(meta.Description) {
+		return false
+	}
+	committed := meta.Committer.Date.Time()
+	if !f.Since.IsZero() && committed.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && committed.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// logLookupPartition is the single partition of an index lookup on the date or author column of the dolt_log system
+// table. Its ranges are checked against each commit's metadata while the commit graph is walked, so commits outside of
+// them are skipped without building a row.
+type logLookupPartition struct {
+	indexName string
+	ranges    []logValueRange
+}
+
+var _ sql.Partition = (*logLookupPartition)(nil)
+
+// Key implements sql.Partition
+func (p *logLookupPartition) Key() []byte {
+	// Key is not used to identify the partition, so we return nil
+	return nil
+}
+
+// matches returns whether the indexed column of |meta| falls within any of the partition's ranges.
+func (p *logLookupPartition) matches(meta *datas.CommitMeta) bool {
+	var v any
+	switch p.indexName {
+	case logDateIndexName:
+		v = meta.Committer.Date.Time()
+	case logAuthorIndexName:
+		v = meta.Author.Name
+	}
+	for _, r := range p.ranges {
+		if r.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// logValueRange is an interval of values of a dolt_log column. A nil bound leaves that side of the interval open.
+type logValueRange struct {
+	lowerBound          any
+	lowerBoundInclusive bool
+	upperBound          any
+	upperBoundInclusive bool
+}
+
+func (r logValueRange) contains(v any) bool {
+	if r.lowerBound != nil {
+		c := compareLogValues(v, r.lowerBound)
+		if c < 0 || (c == 0 && !r.lowerBoundInclusive) {
+			return false
+		}
+	}
+	if r.upperBound != nil {
+		c := compareLogValues(v, r.upperBound)
+		if c > 0 || (c == 0 && !r.upperBoundInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareLogValues compares two values of the date or author column of dolt_log.
+func compareLogValues(a, b any) int {
+	switch a := a.(type) {
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case string:
+		return strings.Compare(a, b.(string))
+	default:
+		panic(fmt.Sprintf("unexpected dolt_log value type: %T", a))
+	}
+}
+
+// newLogLookupPartition converts the ranges of |lookup| on the date or author index of dolt_log into a partition.
+func newLogLookupPartition(lookup sql.IndexLookup) (*logLookupPartition, error) {
+	mysqlRanges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
+	if !ok {
+		return nil, fmt.Errorf("unsupported range cut type: %T", lookup.Ranges)
+	}
+
+	indexName := lookup.Index.ID()
+	checkKey := func(key any) error {
+		switch key.(type) {
+		case time.Time:
+			if indexName == logDateIndexName {
+				return nil
+			}
+		case string:
+			if indexName == logAuthorIndexName {
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported key type for index %s: %T", indexName, key)
+	}
+
+	p := &logLookupPartition{indexName: indexName}
+	for _, rng := range mysqlRanges.ToRanges() {
+		rangeExpr := rng.(sql.MySQLRange)[0]
+
+		var r logValueRange
+		switch x := rangeExpr.LowerBound.(type) {
+		case sql.Above:
+			r.lowerBound = x.Key
+		case sql.Below:
+			r.lowerBound = x.Key
+			r.lowerBoundInclusive = true
+		case sql.BelowNull, sql.AboveNull:
+			// no lower bound, since commit metadata is never NULL
+		case sql.AboveAll:
+			continue
+		default:
+			return nil, fmt.Errorf("unknown range cut type: %T", rangeExpr.LowerBound)
+		}
+
+		switch x := rangeExpr.UpperBound.(type) {
+		case sql.Above:
+			r.upperBound = x.Key
+			r.upperBoundInclusive = true
+		case sql.Below:
+			r.upperBound = x.Key
+		case sql.AboveAll:
+			// no upper bound
+		case sql.BelowNull, sql.AboveNull:
+			// only NULL values, which commit metadata never has
+			continue
+		default:
+			return nil, fmt.Errorf("unknown range cut type: %T", rangeExpr.UpperBound)
+		}
+
+		for _, key := range []any{r.lowerBound, r.upperBound} {
+			if key == nil {
+				continue
+			}
+			if err := checkKey(key); err != nil {
+				return nil, err
+			}
+		}
+		p.ranges = append(p.ranges, r)
+	}
+	return p, nil
+}
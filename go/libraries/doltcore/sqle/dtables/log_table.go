@@ -32,6 +32,14 @@ import (
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+)
+
+// logDateIndexName and logAuthorIndexName are the names of the indexes on the dolt_log system table that
+// cover the date and author columns. Lookups on them filter commits as the commit graph is walked.
+const (
+	logDateIndexName   = "dolt_log_date_idx"
+	logAuthorIndexName = "dolt_log_author_idx"
 )
 
 // LogTable is a sql.Table implementation that implements a system table which shows the dolt commit log
@@ -292,8 +300,10 @@ func (dt *LogTable) PartitionRows(ctx *sql.Context, p sql.Partition) (sql.RowIte
 			return nil, err
 		}
 		return sql.RowsToRowIter(row), nil
+	case *logLookupPartition:
+		return dt.NewLogItr(ctx, dt.ddb, dt.head, refs, rowOpts, p)
 	default:
-		return dt.NewLogItr(ctx, dt.ddb, dt.head, refs, rowOpts)
+		return dt.NewLogItr(ctx, dt.ddb, dt.head, refs, rowOpts, nil)
 	}
 }
 
@@ -313,7 +323,14 @@ func (dt *LogTable) getCachedRefs(ctx *sql.Context) (map[hash.Hash][]string, err
 }
 
 func (dt *LogTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
-	return index.DoltCommitIndexes(dt.dbName, dt.Name(), dt.ddb, true)
+	indexes, err := index.DoltCommitIndexes(dt.dbName, dt.Name(), dt.ddb, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(indexes,
+		index.NewCommitMetaIndex(index.MockIndex(logDateIndexName, dt.dbName, dt.Name(), "date", storetypes.TimestampKind, false)),
+		index.NewCommitMetaIndex(index.MockIndex(logAuthorIndexName, dt.dbName, dt.Name(), "author", storetypes.StringKind, false)),
+	), nil
 }
 
 // IndexedAccess implements sql.IndexAddressable
@@ -328,8 +345,15 @@ func (dt *LogTable) PreciseMatch() bool {
 }
 
 func (dt *LogTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
-	if lookup.Index.ID() == index.CommitHashIndexId {
+	switch lookup.Index.ID() {
+	case index.CommitHashIndexId:
 		return dt.commitHashPartitionIter(ctx, lookup)
+	case logDateIndexName, logAuthorIndexName:
+		p, err := newLogLookupPartition(lookup)
+		if err != nil {
+			return nil, err
+		}
+		return sql.PartitionsToPartitionIter(p), nil
 	}
 
 	return dt.Partitions(ctx)
@@ -413,8 +437,9 @@ type LogItr struct {
 }
 
 // NewLogItr creates a LogItr from the current environment. |rowOpts| selects which opt-in
-// columns the iterator should populate.
-func (dt *LogTable) NewLogItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, cHashToRefs map[hash.Hash][]string, rowOpts LogRowOptions) (*LogItr, error) {
+// columns the iterator should populate. When |lookup| is not nil, only the commits within its
+// ranges are returned.
+func (dt *LogTable) NewLogItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, cHashToRefs map[hash.Hash][]string, rowOpts LogRowOptions, lookup *logLookupPartition) (*LogItr, error) {
 	h, err := head.HashOf()
 	if err != nil {
 		return nil, err
@@ -423,8 +448,15 @@ func (dt *LogTable) NewLogItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb
 	// Returning false for ghost commits, which stand in for ancestors a shallow
 	// clone never fetched, stops the walk at the boundary of the available history.
 	matchFn := func(optCmt *doltdb.OptionalCommit) (bool, error) {
-		_, ok := optCmt.ToCommit()
-		return ok, nil
+		cm, ok := optCmt.ToCommit()
+		if !ok || lookup == nil {
+			return ok, nil
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return false, err
+		}
+		return lookup.matches(meta), nil
 	}
 
 	child, err := commitwalk.GetTopologicalOrderIterator[*sql.Context](ctx, ddb, []hash.Hash{h}, matchFn)
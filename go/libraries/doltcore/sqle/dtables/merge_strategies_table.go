@@ -0,0 +1,56 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func doltMergeStrategiesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.MergeStrategiesTableName, PrimaryKey: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: doltdb.MergeStrategiesTableName, PrimaryKey: true},
+		{Name: "strategy", Type: sqlTypes.Text, Source: doltdb.MergeStrategiesTableName, Nullable: false},
+	}
+}
+
+// GetDoltMergeStrategiesSchema returns the schema of the dolt_merge_strategies system table.
+var GetDoltMergeStrategiesSchema = doltMergeStrategiesSchema
+
+// NewMergeStrategiesTable creates a dolt_merge_strategies table
+func NewMergeStrategiesTable(_ *sql.Context, backingTable VersionableTable, schemaName string) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName: doltdb.TableName{
+			Name:   doltdb.MergeStrategiesTableName,
+			Schema: schemaName,
+		},
+		schema: GetDoltMergeStrategiesSchema(),
+	}
+}
+
+// NewEmptyMergeStrategiesTable creates an empty dolt_merge_strategies table
+func NewEmptyMergeStrategiesTable(_ *sql.Context, schemaName string) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName: doltdb.TableName{
+			Name:   doltdb.MergeStrategiesTableName,
+			Schema: schemaName,
+		},
+		schema: GetDoltMergeStrategiesSchema(),
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*NotesTable)(nil)
+
+// NotesTable is a read-only system table listing the notes attached to commits, from every notes ref of a database.
+// Notes are edited with the dolt_notes() procedure.
+type NotesTable struct {
+	dbName    string
+	ddb       *doltdb.DoltDB
+	tableName string
+}
+
+// NewNotesTable returns a new NotesTable for the database given.
+func NewNotesTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB, tableName string) sql.Table {
+	return &NotesTable{dbName: dbName, ddb: ddb, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (t *NotesTable) Name() string {
+	return t.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (t *NotesTable) String() string {
+	return t.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the notes system table
+func (t *NotesTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "ref", Type: types.Text, Source: t.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "commit_hash", Type: types.Text, Source: t.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "note", Type: types.LongText, Source: t.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (t *NotesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (t *NotesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (t *NotesTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	refs, err := t.ddb.GetNotesRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].GetPath() < refs[j].GetPath()
+	})
+
+	var rows []sql.Row
+	for _, r := range refs {
+		notes, _, err := t.ddb.ResolveNotes(ctx, ref.NewNotesRef(r.GetPath()))
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range notes.Sorted() {
+			rows = append(rows, sql.NewRow(r.GetPath(), h, notes[h]))
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
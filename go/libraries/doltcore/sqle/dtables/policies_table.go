@@ -0,0 +1,75 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+func doltPoliciesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "policy_name", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, PrimaryKey: true},
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, Nullable: false},
+		{Name: "user", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, Nullable: false},
+		{Name: "row_filter", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, Nullable: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, Nullable: true},
+		{Name: "column_mask", Type: sqlTypes.Text, Source: doltdb.PoliciesTableName, Nullable: true},
+	}
+}
+
+// GetDoltPoliciesSchema returns the schema of the dolt_policies system table.
+var GetDoltPoliciesSchema = doltPoliciesSchema
+
+// NewPoliciesTable creates a dolt_policies table for the database named |dbName|
+func NewPoliciesTable(_ *sql.Context, backingTable VersionableTable, dbName, schemaName string) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName: doltdb.TableName{
+			Name:   doltdb.PoliciesTableName,
+			Schema: schemaName,
+		},
+		schema:     GetDoltPoliciesSchema(),
+		writeCheck: policiesWriteCheck(dbName),
+	}
+}
+
+// NewEmptyPoliciesTable creates an empty dolt_policies table for the database named |dbName|
+func NewEmptyPoliciesTable(_ *sql.Context, dbName, schemaName string) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName: doltdb.TableName{
+			Name:   doltdb.PoliciesTableName,
+			Schema: schemaName,
+		},
+		schema:     GetDoltPoliciesSchema(),
+		writeCheck: policiesWriteCheck(dbName),
+	}
+}
+
+// policiesWriteCheck returns a write check that only allows users with administrative privileges on the database named
+// |dbName| to modify dolt_policies, since its rows restrict what every other user can read.
+func policiesWriteCheck(dbName string) func(ctx *sql.Context) error {
+	return func(ctx *sql.Context) error {
+		branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+		// A nil session means we're not in the SQL context, so we allow the modification in such a case
+		if branchAwareSession == nil || branch_control.HasDatabasePrivileges(branchAwareSession, dbName) {
+			return nil
+		}
+		return branch_control.ErrModifyingPolicies.New(branchAwareSession.GetUser(), branchAwareSession.GetHost(), dbName)
+	}
+}
@@ -41,6 +41,8 @@ type UserSpaceSystemTable struct {
 	backingTable VersionableTable
 	tableName    doltdb.TableName
 	schema       sql.Schema
+	// writeCheck, if set, is called at the start of every statement that writes to the table, and fails it on error
+	writeCheck func(ctx *sql.Context) error
 }
 
 func (bst *UserSpaceSystemTable) Name() string {
@@ -170,6 +172,12 @@ func (bstw *backedSystemTableWriter) Delete(ctx *sql.Context, r sql.Row) error {
 // StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
 // in some way that it may be returned to in the case of an error.
 func (bstw *backedSystemTableWriter) StatementBegin(ctx *sql.Context) {
+	if bstw.bst.writeCheck != nil {
+		if err := bstw.bst.writeCheck(ctx); err != nil {
+			bstw.errDuringStatementBegin = err
+			return
+		}
+	}
 	prevHash, tableWriter, err := createWriteableSystemTable(ctx, bstw.bst.tableName, bstw.bst.Schema(ctx))
 	if err != nil {
 		bstw.errDuringStatementBegin = err
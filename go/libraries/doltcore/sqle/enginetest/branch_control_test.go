@@ -2448,7 +2448,12 @@ var BranchControlTests = []BranchControlTest{
 }
 
 func TestBranchControl(t *testing.T) {
-	for _, test := range BranchControlTests {
+	runBranchControlTests(t, BranchControlTests)
+}
+
+// runBranchControlTests runs |tests|, each against a new engine, with each assertion run as the user it names.
+func runBranchControlTests(t *testing.T, tests []BranchControlTest) {
+	for _, test := range tests {
 		harness := newDoltHarness(t)
 		defer harness.Close()
 		t.Run(test.Name, func(t *testing.T) {
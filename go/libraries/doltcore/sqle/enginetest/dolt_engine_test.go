@@ -1291,6 +1291,46 @@ func TestDoltMergePrepared(t *testing.T) {
 	RunDoltMergePreparedTests(t, h)
 }
 
+func TestMergeStrategies(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunMergeStrategyTests(t, h)
+}
+
+func TestMergeStrategiesPrepared(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunMergeStrategyPreparedTests(t, h)
+}
+
+func TestOctopusMerge(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunOctopusMergeTests(t, h)
+}
+
+func TestOctopusMergePrepared(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunOctopusMergePreparedTests(t, h)
+}
+
+func TestBisect(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunBisectTests(t, h)
+}
+
+func TestBisectPrepared(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunBisectPreparedTests(t, h)
+}
+
+func TestNotes(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunNotesTests(t, h)
+}
+
+func TestNotesPrepared(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunNotesPreparedTests(t, h)
+}
+
 func TestDoltRebase(t *testing.T) {
 	h := newDoltEnginetestHarness(t)
 	RunDoltRebaseTests(t, h)
@@ -1550,6 +1590,46 @@ func TestDiffSummaryTableFunctionPrepared(t *testing.T) {
 	RunDiffSummaryTableFunctionTestsPrepared(t, harness)
 }
 
+func TestChangesTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunChangesTableFunctionTests(t, harness)
+}
+
+func TestChangesTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunChangesTableFunctionTestsPrepared(t, harness)
+}
+
+func TestGrepTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunGrepTableFunctionTests(t, harness)
+}
+
+func TestGrepTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunGrepTableFunctionTestsPrepared(t, harness)
+}
+
+func TestBulkLoad(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunBulkLoadTests(t, harness)
+}
+
+func TestBulkLoadPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunBulkLoadTestsPrepared(t, harness)
+}
+
+func TestHistoryPrune(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunHistoryPruneTests(t, harness)
+}
+
+func TestHistoryPrunePrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunHistoryPruneTestsPrepared(t, harness)
+}
+
 func TestPatchTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunDoltPatchTableFunctionTests(t, harness)
@@ -1580,6 +1660,16 @@ func TestJsonDiffTableFunctionPrepared(t *testing.T) {
 	RunJsonDiffTableFunctionTestsPrepared(t, harness)
 }
 
+func TestCellDiffTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunCellDiffTableFunctionTests(t, harness)
+}
+
+func TestCellDiffTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunCellDiffTableFunctionTestsPrepared(t, harness)
+}
+
 func TestBranchStatusTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunBranchStatusTableFunctionTests(t, harness)
@@ -1026,6 +1026,94 @@ func RunDoltMergePreparedTests(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunMergeStrategyTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range MergeStrategyScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunMergeStrategyPreparedTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range MergeStrategyScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
+func RunOctopusMergeTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range OctopusMergeScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunOctopusMergePreparedTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range OctopusMergeScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
+func RunBisectTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range BisectScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunBisectPreparedTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range BisectScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
+func RunNotesTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range NotesScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunNotesPreparedTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range NotesScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
 func RunDoltRebaseTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range DoltRebaseScriptTests {
 		func() {
@@ -1422,6 +1510,94 @@ func RunDiffSummaryTableFunctionTestsPrepared(t *testing.T, harness DoltEnginete
 	}
 }
 
+func RunChangesTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range ChangesTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunChangesTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range ChangesTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func RunGrepTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range GrepTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunGrepTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range GrepTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func RunBulkLoadTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range BulkLoadScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunBulkLoadTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range BulkLoadScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func RunHistoryPruneTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range HistoryPruneScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunHistoryPruneTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range HistoryPruneScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func RunDoltPatchTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range PatchTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -1486,6 +1662,28 @@ func RunJsonDiffTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestH
 	}
 }
 
+func RunCellDiffTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range CellDiffTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunCellDiffTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range CellDiffTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func RunBranchStatusTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range BranchStatusTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
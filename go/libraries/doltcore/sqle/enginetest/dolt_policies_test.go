@@ -0,0 +1,314 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// policiesSetUpScript creates an orders table shared by two tenants, who may only see their own orders and the last
+// four characters of each card number.
+var policiesSetUpScript = []string{
+	"CREATE TABLE orders (id INT PRIMARY KEY, tenant VARCHAR(20), amount INT, card VARCHAR(20));",
+	"INSERT INTO orders VALUES (1, 'tenant_a', 10, '4111-1111'), (2, 'tenant_b', 20, '5500-2222');",
+	"CALL DOLT_COMMIT('-Am', 'add orders');",
+	"UPDATE orders SET amount = amount + 1;",
+	"CALL DOLT_COMMIT('-am', 'update orders');",
+	"CALL DOLT_BRANCH('before_policies');",
+	"INSERT INTO dolt_policies VALUES " +
+		"('tenant_rows', 'orders', 'tenant%', 'tenant = SUBSTRING_INDEX(CURRENT_USER(), ''@'', 1)', NULL, NULL), " +
+		"('card_mask', 'orders', 'tenant%', NULL, 'card', 'CONCAT(''****'', RIGHT(card, 4))');",
+	"CALL DOLT_COMMIT('-Am', 'add policies');",
+	"CREATE USER tenant_a@localhost;",
+	"GRANT SELECT ON *.* TO tenant_a@localhost;",
+	"CREATE USER tenant_b@localhost;",
+	"GRANT SELECT ON *.* TO tenant_b@localhost;",
+}
+
+var PolicyTests = []BranchControlTest{
+	{
+		Name:        "Row filters and column masks on the table",
+		SetUpScript: policiesSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT * FROM orders;",
+				Expected: []sql.Row{{1, "tenant_a", 11, "****1111"}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT id, card FROM orders;",
+				Expected: []sql.Row{{2, "****2222"}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT id FROM orders WHERE card = '5500-2222';",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT o1.id, o2.id FROM orders o1 JOIN orders o2 ON o1.id + 1 = o2.id;",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT id, card FROM orders ORDER BY id;",
+				Expected: []sql.Row{{1, "4111-1111"}, {2, "5500-2222"}},
+			},
+		},
+	},
+	{
+		Name:        "Policies apply to history, diff and blame tables",
+		SetUpScript: policiesSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT DISTINCT id, amount, card FROM dolt_history_orders ORDER BY amount;",
+				Expected: []sql.Row{{1, 10, "****1111"}, {1, 11, "****1111"}},
+			},
+			{
+				User:  "tenant_a",
+				Host:  "localhost",
+				Query: "SELECT to_id, to_amount, to_card, from_amount, from_card, diff_type FROM dolt_diff_orders ORDER BY to_amount;",
+				Expected: []sql.Row{
+					{1, 10, "****1111", nil, nil, "added"},
+					{1, 11, "****1111", 10, "****1111", "modified"},
+				},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT to_id, to_amount FROM dolt_commit_diff_orders WHERE from_commit = HASHOF('HEAD~3') AND to_commit = HASHOF('HEAD');",
+				Expected: []sql.Row{{2, 21}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT id FROM dolt_blame_orders;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT to_id, to_card, diff_type FROM dolt_diff('HEAD~2', 'HEAD', 'orders');",
+				Expected: []sql.Row{{2, "****2222", "modified"}},
+			},
+			{
+				User:           "tenant_b",
+				Host:           "localhost",
+				Query:          "SELECT * FROM dolt_patch('HEAD~2', 'HEAD', 'orders');",
+				ExpectedErrStr: "dolt_patch cannot be used on table 'orders' because of its access policies",
+			},
+			{
+				User:           "tenant_b",
+				Host:           "localhost",
+				Query:          "SELECT * FROM dolt_conflicts_orders;",
+				ExpectedErrStr: "dolt_conflicts_orders cannot be used on table 'orders' because of its access policies",
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_diff_orders;",
+				Expected: []sql.Row{{4}},
+			},
+		},
+	},
+	{
+		Name:        "Policies apply to table functions that read rows",
+		SetUpScript: policiesSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:  "tenant_b",
+				Host:  "localhost",
+				Query: "SELECT diff_type, to_row->>'$.id', to_row->>'$.card', from_row->>'$.card' FROM dolt_changes('HEAD~3', 'HEAD', 'orders');",
+				Expected: []sql.Row{
+					{"added", "2", "****2222", nil},
+					{"modified", "2", "****2222", "****2222"},
+				},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_changes('HEAD~3', 'HEAD') WHERE table_name = 'orders';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_changes('HEAD~3', 'HEAD') WHERE to_row->>'$.card' LIKE '4111%' OR to_row->>'$.tenant' = 'tenant_b';",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_changes('HEAD~3', 'HEAD') WHERE table_name = 'orders';",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT primary_key->>'$.id', column_name, value FROM dolt_grep('2222', 'HEAD', '--', 'orders');",
+				Expected: []sql.Row{{"2", "card", "****2222"}},
+			},
+			{
+				User:     "tenant_b",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_grep('4111|5500', 'HEAD', '--', 'orders');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_grep('4111|5500|tenant_b', 'HEAD~3..HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_grep('4111|5500', 'HEAD', '--', 'orders');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				User:           "tenant_b",
+				Host:           "localhost",
+				Query:          "SELECT * FROM dolt_preview_merge_conflicts('main', 'before_policies', 'orders');",
+				ExpectedErrStr: "dolt_preview_merge_conflicts cannot be used on table 'orders' because of its access policies",
+			},
+		},
+	},
+	{
+		Name:        "Policies come from the default branch",
+		SetUpScript: policiesSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/before_policies`.orders;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT id, card FROM orders AS OF 'HEAD~1';",
+				Expected: []sql.Row{{1, "****1111"}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "DELETE FROM dolt_policies;",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT id FROM orders;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_COMMIT('-am', 'remove policies');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:     "tenant_a",
+				Host:     "localhost",
+				Query:    "SELECT id FROM orders ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "Only administrators can modify policies",
+		SetUpScript: append(append([]string{}, policiesSetUpScript...),
+			"CREATE USER writer@localhost;",
+			"GRANT SELECT, INSERT, UPDATE, DELETE ON *.* TO writer@localhost;",
+			"CREATE USER admin@localhost;",
+			"GRANT ALL ON mydb.* TO admin@localhost WITH GRANT OPTION;",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:           "writer",
+				Host:           "localhost",
+				Query:          "DELETE FROM dolt_policies;",
+				ExpectedErrStr: "`writer`@`localhost` cannot modify dolt_policies without administrative privileges on database `mydb`",
+			},
+			{
+				User:           "writer",
+				Host:           "localhost",
+				Query:          "UPDATE dolt_policies SET user = 'nobody';",
+				ExpectedErrStr: "`writer`@`localhost` cannot modify dolt_policies without administrative privileges on database `mydb`",
+			},
+			{
+				User:           "writer",
+				Host:           "localhost",
+				Query:          "INSERT INTO dolt_policies VALUES ('extra', 'orders', 'writer', 'false', NULL, NULL);",
+				ExpectedErrStr: "`writer`@`localhost` cannot modify dolt_policies without administrative privileges on database `mydb`",
+			},
+			{
+				User:     "writer",
+				Host:     "localhost",
+				Query:    "SELECT policy_name, user FROM dolt_policies ORDER BY policy_name;",
+				Expected: []sql.Row{{"card_mask", "tenant%"}, {"tenant_rows", "tenant%"}},
+			},
+			{
+				User:     "admin",
+				Host:     "localhost",
+				Query:    "DELETE FROM dolt_policies WHERE policy_name = 'card_mask';",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+		},
+	},
+	{
+		Name: "Invalid policies fail reads of their table",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, v INT);",
+			"CREATE TABLE u (pk INT PRIMARY KEY);",
+			"INSERT INTO t VALUES (1, 1);",
+			"INSERT INTO u VALUES (1);",
+			"INSERT INTO dolt_policies VALUES ('incomplete', 't', 'reader', NULL, 'v', NULL);",
+			"CALL DOLT_COMMIT('-Am', 'add tables');",
+			"CREATE USER reader@localhost;",
+			"GRANT SELECT ON *.* TO reader@localhost;",
+		},
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:           "reader",
+				Host:           "localhost",
+				Query:          "SELECT * FROM t;",
+				ExpectedErrStr: "invalid policy 'incomplete': column_name and column_mask must be set together",
+			},
+			{
+				User:     "reader",
+				Host:     "localhost",
+				Query:    "SELECT * FROM u;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+}
+
+func TestPolicies(t *testing.T) {
+	runBranchControlTests(t, PolicyTests)
+}
@@ -986,6 +986,52 @@ var DoltOnlyRevisionDbPrivilegeTests = []queries.UserPrivilegeTest{
 }
 
 var DoltOnlyRevisionTableFunctionPrivilegeTests = []queries.UserPrivilegeTest{
+	{
+		Name: "dolt_grep privilege checking with tables from an older revision",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk BIGINT PRIMARY KEY, col1 varchar(20));",
+			"CREATE TABLE secret (pk BIGINT PRIMARY KEY, col1 varchar(20));",
+			"INSERT INTO test VALUES (1, 'first row');",
+			"INSERT INTO secret VALUES (1, 'hidden row');",
+			"call dolt_commit('-Am', 'first commit');",
+			"DROP TABLE secret;",
+			"call dolt_commit('-am', 'drop secret');",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT ON mydb.test TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT table_name, value FROM dolt_grep('row', 'HEAD');",
+				Expected: []sql.Row{{"test", "first row"}},
+			},
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT table_name, value FROM dolt_grep('row', 'HEAD~1');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT table_name, value FROM dolt_grep('row', 'HEAD~2..HEAD');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT SELECT ON mydb.secret TO tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT table_name, value FROM dolt_grep('row', 'HEAD~1') ORDER BY table_name;",
+				Expected: []sql.Row{{"secret", "hidden row"}, {"test", "first row"}},
+			},
+		},
+	},
 	{
 		Name: "dolt_schema_diff privilege checking with revision database",
 		SetUpScript: []string{
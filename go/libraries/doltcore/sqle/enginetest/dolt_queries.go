@@ -5714,6 +5714,107 @@ var LogTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_log filters by author, message and date",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create table t', '--author', 'Alice <alice@example.com>', '--date', '2024-01-01T12:00:00');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'fix: first row', '--author', 'Bob <bob@example.com>', '--date', '2024-02-01T12:00:00');",
+			"create table u (pk int primary key);",
+			"call dolt_commit('-Am', 'create table u', '--author', 'Alice <alice@example.com>', '--date', '2024-03-01T12:00:00');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'fix: second row', '--author', 'Alice <alice@example.com>', '--date', '2024-04-01T12:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select message from dolt_log('--author', 'Alice');",
+				Expected: []sql.Row{{"fix: second row"}, {"create table u"}, {"create table t"}},
+			},
+			{
+				// the author is matched as "name <email>"
+				Query:    "select message from dolt_log('--author', '^Bob <bob@');",
+				Expected: []sql.Row{{"fix: first row"}},
+			},
+			{
+				Query:    "select message from dolt_log('--grep', '^fix:');",
+				Expected: []sql.Row{{"fix: second row"}, {"fix: first row"}},
+			},
+			{
+				Query:    "select message from dolt_log('--since', '2024-02-01T12:00:00', '--until', '2024-03-15');",
+				Expected: []sql.Row{{"create table u"}, {"fix: first row"}},
+			},
+			{
+				Query:    "select message from dolt_log('--author', 'Alice', '--grep', 'fix', '--since', '2024-01-15');",
+				Expected: []sql.Row{{"fix: second row"}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1', '--author', 'Alice');",
+				Expected: []sql.Row{{"create table u"}, {"create table t"}},
+			},
+			{
+				Query:    "select message from dolt_log('--', 't');",
+				Expected: []sql.Row{{"fix: second row"}, {"fix: first row"}, {"create table t"}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1', '--author', 'Alice', '--', 't');",
+				Expected: []sql.Row{{"create table t"}},
+			},
+			{
+				Query:    "select message from dolt_log('--author', 'Carol');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "select * from dolt_log('--since', 'last tuesday');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_log('--grep', '(');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log system table filters on date and author while walking commits",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create table t', '--author', 'Alice <alice@example.com>', '--date', '2024-01-01T12:00:00');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'first row', '--author', 'Bob <bob@example.com>', '--date', '2024-02-01T12:00:00');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'second row', '--author', 'Alice <alice@example.com>', '--date', '2024-03-01T12:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select message from dolt_log where date >= '2024-02-01 12:00:00' order by date;",
+				Expected: []sql.Row{{"first row"}, {"second row"}},
+			},
+			{
+				Query:    "select message from dolt_log where date > '2024-01-01 12:00:00' and date < '2024-03-01' order by date;",
+				Expected: []sql.Row{{"first row"}},
+			},
+			{
+				Query:    "select message from dolt_log where date < '2024-01-15' or date > '2024-02-15' order by date;",
+				Expected: []sql.Row{{"create table t"}, {"second row"}},
+			},
+			{
+				Query:    "select message from dolt_log where author = 'Alice' order by date;",
+				Expected: []sql.Row{{"create table t"}, {"second row"}},
+			},
+			{
+				Query:    "select message from dolt_log where author in ('Bob', 'Carol') order by date;",
+				Expected: []sql.Row{{"first row"}},
+			},
+			{
+				Query:    "select message from dolt_log where author = 'Alice' and message like '%row' order by date;",
+				Expected: []sql.Row{{"second row"}},
+			},
+			{
+				Query:    "select count(*) from dolt_log where date is null;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
 }
 
 var JsonDiffTableFunctionScriptTests = []queries.ScriptTest{
@@ -5817,6 +5918,111 @@ var JsonDiffTableFunctionScriptTests = []queries.ScriptTest{
 	},
 }
 
+var CellDiffTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name:        "text literals",
+		SetUpScript: []string{},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    `SELECT * from dolt_cell_diff('the quick brown fox', 'the quick red fox');`,
+				Expected: []sql.Row{{nil, "modified", int64(11), int64(5), int64(11), int64(3), "brown", "red"}},
+			},
+			{
+				Query: `SELECT diff_type, from_position, from_length, to_position, to_length, from_text, to_text from dolt_cell_diff('one two three four', 'one 2 three four five');`,
+				Expected: []sql.Row{
+					{"modified", int64(5), int64(3), int64(5), int64(1), "two", "2"},
+					{"added", int64(19), int64(0), int64(17), int64(5), "", " five"},
+				},
+			},
+			{
+				Query:    `SELECT diff_type, from_text, to_text from dolt_cell_diff('hello world', 'hello');`,
+				Expected: []sql.Row{{"removed", " world", ""}},
+			},
+			{
+				Query:    `SELECT * from dolt_cell_diff('unchanged', 'unchanged');`,
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    `SELECT diff_type, from_position, to_position, to_text from dolt_cell_diff(NULL, 'hello');`,
+				Expected: []sql.Row{{"added", int64(1), int64(1), "hello"}},
+			},
+		},
+	},
+	{
+		Name:        "granularity",
+		SetUpScript: []string{},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    `SELECT diff_type, from_text, to_text from dolt_cell_diff('cat', 'cart');`,
+				Expected: []sql.Row{{"modified", "cat", "cart"}},
+			},
+			{
+				Query:    `SELECT diff_type, from_text, to_text from dolt_cell_diff('cat', 'cart', 'word');`,
+				Expected: []sql.Row{{"modified", "cat", "cart"}},
+			},
+			{
+				Query:    `SELECT diff_type, from_position, to_position, from_text, to_text from dolt_cell_diff('cat', 'cart', 'char');`,
+				Expected: []sql.Row{{"added", int64(3), int64(3), "", "r"}},
+			},
+			{
+				Query:          `SELECT * from dolt_cell_diff('cat', 'cart', 'line');`,
+				ExpectedErrStr: "dolt_cell_diff granularity must be 'word' or 'char', got 'line'",
+			},
+			{
+				Query:       `SELECT * from dolt_cell_diff('cat');`,
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	},
+	{
+		Name:        "JSON values",
+		SetUpScript: []string{},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: `SELECT * from dolt_cell_diff(cast('{"n": 1, "title": "Hello world"}' as json), cast('{"n": 2, "title": "Hello there world"}' as json));`,
+				Expected: []sql.Row{
+					{"$.n", "modified", nil, nil, nil, nil, "1", "2"},
+					{"$.title", "added", int64(7), int64(0), int64(7), int64(6), "", "there "},
+				},
+			},
+			{
+				Query: `SELECT * from dolt_cell_diff(cast('{"a": "x"}' as json), cast('{"b": "x"}' as json));`,
+				Expected: []sql.Row{
+					{"$.a", "removed", nil, nil, nil, nil, `"x"`, nil},
+					{"$.b", "added", nil, nil, nil, nil, nil, `"x"`},
+				},
+			},
+			{
+				Query:    `SELECT * from dolt_cell_diff(NULL, cast('{"a": 1}' as json));`,
+				Expected: []sql.Row{{"$", "added", nil, nil, nil, nil, nil, `{"a":1}`}},
+			},
+		},
+	},
+	{
+		Name: "lateral join with dolt_diff table",
+		SetUpScript: []string{
+			"CREATE TABLE docs(pk int primary key, body longtext, meta json);",
+			`INSERT INTO docs VALUES (1, 'The committee will meet on Monday to review the draft.', '{"author": "kim", "status": "draft"}'), (2, 'Unchanged document.', '{}');`,
+			"CALL dolt_commit('-Am', 'add docs');",
+			`UPDATE docs SET body = 'The committee will meet on Tuesday to review the draft.', meta = '{"author": "kim", "status": "final draft"}' WHERE pk = 1;`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: `SELECT d.to_pk, c.from_position, c.from_text, c.to_text FROM dolt_diff_docs d JOIN LATERAL (SELECT * FROM dolt_cell_diff(d.from_body, d.to_body)) c WHERE d.to_commit = 'WORKING';`,
+				Expected: []sql.Row{
+					{1, int64(28), "Monday", "Tuesday"},
+				},
+			},
+			{
+				Query: `SELECT d.to_pk, c.path, c.diff_type, c.from_text, c.to_text FROM dolt_diff_docs d JOIN LATERAL (SELECT * FROM dolt_cell_diff(d.from_meta, d.to_meta)) c WHERE d.to_commit = 'WORKING';`,
+				Expected: []sql.Row{
+					{1, "$.status", "added", "", "final "},
+				},
+			},
+		},
+	},
+}
+
 var BranchStatusTableFunctionScriptTests = []queries.ScriptTest{
 	{
 		// * anc
@@ -8815,6 +9021,8 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_backups"},
 					{"dolt_branch_activity"},
 					{"dolt_branches"},
+					{"dolt_ci_run_steps"},
+					{"dolt_ci_runs"},
 					{"dolt_commit_ancestors"},
 					{"dolt_commit_diff_test"},
 					{"dolt_commits"},
@@ -8826,6 +9034,7 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_help"},
 					{"dolt_history_test"},
 					{"dolt_log"},
+					{"dolt_notes"},
 					{"dolt_remote_branches"},
 					{"dolt_remotes"},
 					{"dolt_stashes"},
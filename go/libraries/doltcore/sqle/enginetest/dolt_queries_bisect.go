@@ -0,0 +1,220 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// bisectSetUpScript creates a linear history of six commits after the initial one, where the fourth commit introduces
+// a negative key. The first commit is tagged v1.
+var bisectSetUpScript = []string{
+	"create table t (pk int primary key);",
+	"call dolt_commit('-Am', 'create t');",
+	"insert into t values (1);",
+	"call dolt_commit('-am', 'c1');",
+	"call dolt_tag('v1');",
+	"insert into t values (2);",
+	"call dolt_commit('-am', 'c2');",
+	"insert into t values (3);",
+	"call dolt_commit('-am', 'c3');",
+	"insert into t values (-4);",
+	"call dolt_commit('-am', 'c4');",
+	"insert into t values (5);",
+	"call dolt_commit('-am', 'c5');",
+	"insert into t values (6);",
+	"call dolt_commit('-am', 'c6');",
+}
+
+var BisectScripts = []queries.ScriptTest{
+	{
+		Name:        "dolt_bisect: marking commits by hand",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_bisect('status');",
+				ExpectedErrStr: "error: no bisect in progress; use 'start' to begin bisecting",
+			},
+			{
+				Query:    "call dolt_bisect('start');",
+				Expected: []sql.Row{{"waiting", nil, int64(0), "waiting for both good and bad commits"}},
+			},
+			{
+				Query:    "call dolt_bisect('bad');",
+				Expected: []sql.Row{{"waiting", nil, int64(0), "waiting for both good and bad commits"}},
+			},
+			{
+				// c4 splits the five candidates c2..c6 most evenly
+				Query:    "call dolt_bisect('good', 'v1');",
+				Expected: []sql.Row{{"bisecting", doltCommit, int64(3), "Bisecting: 3 revisions left to test after this (roughly 2 steps)"}},
+			},
+			{
+				Query:    "call dolt_bisect('bad');",
+				Expected: []sql.Row{{"bisecting", doltCommit, int64(1), "Bisecting: 1 revisions left to test after this (roughly 1 steps)"}},
+			},
+			{
+				Query:    "call dolt_bisect('good');",
+				Expected: []sql.Row{{"found", doltCommit, int64(0), "found the first bad commit"}},
+			},
+			{
+				Query:    "call dolt_bisect('status');",
+				Expected: []sql.Row{{"found", doltCommit, int64(0), "found the first bad commit"}},
+			},
+			{
+				// HEAD does not move while bisecting
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{"c6"}},
+			},
+			{
+				Query:    "call dolt_bisect('reset');",
+				Expected: []sql.Row{{"reset", nil, int64(0), "bisect session ended"}},
+			},
+			{
+				Query:          "call dolt_bisect('status');",
+				ExpectedErrStr: "error: no bisect in progress; use 'start' to begin bisecting",
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: skipped commits",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_bisect('start', 'HEAD', 'v1');",
+				Expected: []sql.Row{{"bisecting", doltCommit, int64(3), "Bisecting: 3 revisions left to test after this (roughly 2 steps)"}},
+			},
+			{
+				// c4 is skipped, so c3 is proposed next
+				Query:    "call dolt_bisect('skip');",
+				Expected: []sql.Row{{"bisecting", doltCommit, int64(2), "Bisecting: 2 revisions left to test after this (roughly 2 steps)"}},
+			},
+			{
+				Query:    "call dolt_bisect('good');",
+				Expected: []sql.Row{{"bisecting", doltCommit, int64(0), "Bisecting: 0 revisions left to test after this (roughly 0 steps)"}},
+			},
+			{
+				// only the skipped c4 and the bad c5 are left, and the message lists both
+				Query:            "call dolt_bisect('bad');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:          "call dolt_bisect('bad', 'v1');",
+				ExpectedErrStr: "error: a commit cannot be marked both good and bad",
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: run with a query",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "call dolt_bisect('start', 'HEAD', 'v1');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query: "call dolt_bisect('run', '--query', 'select min(pk) > 0 from t');",
+				Expected: []sql.Row{
+					{"bad", doltCommit, int64(3), "query returned false"},
+					{"good", doltCommit, int64(1), "query returned true"},
+					{"found", doltCommit, int64(0), "found the first bad commit"},
+				},
+			},
+			{
+				// the current database is restored after testing each commit
+				Query:    "select count(*) from t;",
+				Expected: []sql.Row{{6}},
+			},
+		},
+	},
+	{
+		Name: "dolt_bisect: run with dolt_tests from the current branch",
+		SetUpScript: append(bisectSetUpScript,
+			"insert into dolt_tests values ('no negative keys', 'sanity', 'select * from t where pk < 0', 'expected_rows', '==', '0');",
+		),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "call dolt_bisect('start', 'HEAD', 'v1');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "call dolt_bisect('run', '--test', 'sanity');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "call dolt_bisect('status');",
+				Expected: []sql.Row{{"found", doltCommit, int64(0), "found the first bad commit"}},
+			},
+			{
+				Query:          "call dolt_bisect('run', '--test', 'missing');",
+				ExpectedErrStr: "could not find tests for argument: missing",
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: errors",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_bisect();",
+				ExpectedErrStr: "error: a subcommand is required: start, good, bad, skip, reset, run, or status",
+			},
+			{
+				Query:          "call dolt_bisect('start', 'v1', 'HEAD');",
+				ExpectedErrStr: "error: the bad commit is an ancestor of a good commit; the bad commit must come after the good commits",
+			},
+			{
+				Query:          "call dolt_bisect('start', 'HEAD', 'HEAD');",
+				ExpectedErrStr: "error: a commit cannot be marked both good and bad",
+			},
+			{
+				Query:            "call dolt_bisect('start');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:          "call dolt_bisect('run', '--query', 'select 1');",
+				ExpectedErrStr: "error: run requires a bad commit and at least one good commit to be marked",
+			},
+			{
+				Query:          "call dolt_bisect('run');",
+				ExpectedErrStr: "error: run requires exactly one of --test or --query",
+			},
+			{
+				Query:          "call dolt_bisect('run', '--query', 'insert into t values (100)');",
+				ExpectedErrStr: "error: --query must be a single read-only SELECT statement",
+			},
+			{
+				Query:          "call dolt_bisect('run', '--query', 'select 1; drop table t');",
+				ExpectedErrStr: "error: --query must be a single read-only SELECT statement",
+			},
+			{
+				Query:          "call dolt_bisect('run', '--query', 'select 1 into @x');",
+				ExpectedErrStr: "error: --query must be a single read-only SELECT statement",
+			},
+			{
+				Query:          "call dolt_bisect('good', '--query', 'select 1');",
+				ExpectedErrStr: "error: --test and --query can only be used with run",
+			},
+			{
+				Query:          "call dolt_bisect('bad', 'HEAD', 'HEAD~1');",
+				ExpectedErrStr: "error: only one commit can be marked bad",
+			},
+			{
+				Query:          "call dolt_bisect('frobnicate');",
+				ExpectedErrStr: "error: unknown bisect subcommand 'frobnicate'",
+			},
+		},
+	},
+}
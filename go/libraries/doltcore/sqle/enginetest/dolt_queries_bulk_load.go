@@ -0,0 +1,248 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var BulkLoadScriptTests = []queries.ScriptTest{
+	{
+		Name: "sorted bulk load builds secondary indexes",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'sorted';",
+			"create table t (pk int primary key, c1 varchar(10), c2 int, index c1_idx (c1), index c2_idx (c2));",
+			"insert into t values (1, 'c', 10), (2, 'b', 20), (3, 'a', 30), (5, 'b', 50);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, "c", 10}, {2, "b", 20}, {3, "a", 30}, {5, "b", 50}},
+			},
+			{
+				Query:    "select pk from t where c1 = 'b' order by pk;",
+				Expected: []sql.Row{{2}, {5}},
+			},
+			{
+				Query:    "select pk from t where c2 = 30;",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				// the table is no longer empty, so this is written row by row
+				Query:    "insert into t values (4, 'd', 40);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query:    "select pk from t where c1 >= 'c' order by c1;",
+				Expected: []sql.Row{{1}, {4}},
+			},
+		},
+	},
+	{
+		Name: "sorted bulk load falls back to row-by-row writes for unsorted input",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'sorted';",
+			"create table t (pk int primary key, c1 int, index (c1));",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:                           "insert into t values (1, 1), (3, 3), (2, 2), (4, 4);",
+				ExpectedWarning:                 1105,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "not sorted by primary key",
+				SkipResultsCheck:                true,
+			},
+			{
+				Query:    "select * from t where c1 > 1 order by c1;",
+				Expected: []sql.Row{{2, 2}, {3, 3}, {4, 4}},
+			},
+			{
+				Query:       "insert into t values (5, 5), (3, 3);",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "select count(*) from t;",
+				Expected: []sql.Row{{4}},
+			},
+		},
+	},
+	{
+		Name: "sorted bulk load reports duplicate keys and discards the statement",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'sorted';",
+			"create table t (pk int primary key, c1 int);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "insert into t values (1, 1), (2, 2), (2, 3);",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "select count(*) from t;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert ignore into t values (1, 1), (2, 2), (2, 3), (3, 3);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(3)}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "sorted bulk load applies REPLACE and ON DUPLICATE KEY UPDATE",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'sorted';",
+			"create table t (pk int primary key, c1 int);",
+			"create table u (pk int primary key, c1 int);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "replace into t values (1, 1), (2, 2), (2, 3), (3, 3);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(5)}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 3}, {3, 3}},
+			},
+			{
+				Query:    "insert into u values (1, 1), (2, 2), (2, 3), (3, 3) on duplicate key update c1 = u.c1 + values(c1);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(5)}},
+			},
+			{
+				Query:    "select * from u order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 5}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "external sort bulk load",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'external_sort';",
+			"create table t (pk int primary key auto_increment, c1 varchar(10), unique key (c1));",
+			"create table u (pk int primary key, c1 int);",
+			"create table v (pk int primary key, c1 int, unique key (c1));",
+			"create table w (pk int primary key, c1 int);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "insert into t values (5, 'e'), (1, 'a'), (3, 'c'), (2, 'b'), (4, 'd');",
+				Expected: []sql.Row{{gmstypes.NewOkResult(5)}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}, {5, "e"}},
+			},
+			{
+				Query:    "select pk from t where c1 = 'd';",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "insert into t (c1) values ('f');",
+				Expected: []sql.Row{{gmstypes.OkResult{RowsAffected: 1, InsertID: 6}}},
+			},
+			{
+				// duplicate keys are detected when the statement completes
+				Query:       "insert into u values (3, 3), (1, 1), (3, 4);",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				Query:    "select count(*) from u;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// statements which handle duplicates row by row are not bulk loaded
+				Query:    "insert ignore into u values (3, 3), (1, 1), (3, 4);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(2)}},
+			},
+			{
+				Query:    "select * from u order by pk;",
+				Expected: []sql.Row{{1, 1}, {3, 3}},
+			},
+			{
+				Query:       "insert into v values (3, 1), (1, 1);",
+				ExpectedErr: sql.ErrUniqueKeyViolation,
+			},
+			{
+				Query:    "select count(*) from v;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "replace into v values (3, 1), (1, 1);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(3)}},
+			},
+			{
+				Query:    "select * from v order by pk;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "insert into w values (1, 1), (1, 2) on duplicate key update c1 = c1 + 10;",
+				Expected: []sql.Row{{gmstypes.NewOkResult(3)}},
+			},
+			{
+				Query:    "select * from w order by pk;",
+				Expected: []sql.Row{{1, 11}},
+			},
+		},
+	},
+	{
+		Name: "bulk load finishes when the statement reads the table",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'external_sort';",
+			"create table parent (pk int primary key, c1 int);",
+			"create table child (pk int primary key, parent_id int, foreign key (parent_id) references parent (pk));",
+			"create trigger add_child after insert on parent for each row insert into child values (new.pk, new.pk);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// the foreign key check of each child row reads the parent rows inserted so far
+				Query:                           "insert into parent values (3, 3), (1, 1), (2, 2);",
+				ExpectedWarning:                 1105,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "is read by the statement",
+				SkipResultsCheck:                true,
+			},
+			{
+				Query:    "select * from parent order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select * from child order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "bulk load is not used for tables with a self-referential foreign key",
+		SetUpScript: []string{
+			"set dolt_bulk_load = 'external_sort';",
+			"create table t (pk int primary key, parent int, foreign key (parent) references t (pk));",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "insert into t values (1, null), (2, 1), (3, 2);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(3)}},
+			},
+			{
+				Query:       "insert into t values (4, 10);",
+				ExpectedErr: sql.ErrForeignKeyChildViolation,
+			},
+		},
+	},
+}
@@ -3493,6 +3493,53 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_diff_summary detects renamed tables when dolt_find_renames is set",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20));",
+			"insert into t values (1, 1, 'one'), (2, 2, 'two'), (3, 3, 'three');",
+			"call dolt_commit('-Am', 'create t');",
+			"create table t_copy (pk int primary key, c1 int, c2 varchar(20));",
+			"insert into t_copy select * from t;",
+			"drop table t;",
+			"create table other (pk int primary key, c1 varchar(20));",
+			"call dolt_commit('-Am', 'copy t to t_copy');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select from_table_name, to_table_name, diff_type from dolt_diff_summary('HEAD~', 'HEAD') order by 1, 2;",
+				Expected: []sql.Row{
+					{"", "other", "added"},
+					{"", "t_copy", "added"},
+					{"t", "", "dropped"},
+				},
+			},
+			{
+				Query:    "set @@dolt_find_renames = 50;",
+				Expected: []sql.Row{{gmstypes.OkResult{}}},
+			},
+			{
+				Query: "select from_table_name, to_table_name, diff_type, data_change from dolt_diff_summary('HEAD~', 'HEAD') order by 1, 2;",
+				Expected: []sql.Row{
+					{"", "other", "added", false},
+					{"t", "t_copy", "renamed", false},
+				},
+			},
+			{
+				// other shares too few columns with t to be a rename of it
+				Query:    "select from_table_name, to_table_name, diff_type from dolt_diff_summary('HEAD~', 'HEAD', 'other');",
+				Expected: []sql.Row{{"", "other", "added"}},
+			},
+			{
+				Query:    "set @@dolt_find_renames = 100;",
+				Expected: []sql.Row{{gmstypes.OkResult{}}},
+			},
+			{
+				Query:    "select from_table_name, to_table_name, diff_type from dolt_diff_summary('HEAD~', 'HEAD', 't_copy');",
+				Expected: []sql.Row{{"t", "t_copy", "renamed"}},
+			},
+		},
+	},
 }
 
 var PatchTableFunctionScriptTests = []queries.ScriptTest{
@@ -4303,6 +4350,40 @@ var PatchTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_patch reports detected renames as renames",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1), (2, 2), (3, 3);",
+			"create table u (pk int primary key, a int);",
+			"insert into u values (1, 10), (2, 20), (3, 30);",
+			"call dolt_commit('-Am', 'create tables');",
+			"create table t_copy (pk int primary key, c1 int);",
+			"insert into t_copy select * from t;",
+			"drop table t;",
+			"alter table u add column renamed_a int;",
+			"update u set renamed_a = a;",
+			"alter table u drop column a;",
+			"call dolt_commit('-Am', 'copy t and re-add u.a');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(*) from dolt_patch('HEAD~', 'HEAD') where statement like 'DROP TABLE%' or statement like '%DROP `a`%';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "set @@dolt_find_renames = 50;",
+				Expected: []sql.Row{{gmstypes.OkResult{}}},
+			},
+			{
+				Query: "select statement from dolt_patch('HEAD~', 'HEAD') order by statement_order;",
+				Expected: []sql.Row{
+					{"RENAME TABLE `t` TO `t_copy`;"},
+					{"ALTER TABLE `u` RENAME COLUMN `a` TO `renamed_a`;"},
+				},
+			},
+		},
+	},
 }
 
 var UnscopedDiffSystemTableScriptTests = []queries.ScriptTest{
@@ -6396,6 +6477,32 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_schema_diff pairs renamed tables when dolt_find_renames is set",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-Am', 'create t');",
+			"create table t_copy (pk int primary key, c1 int);",
+			"insert into t_copy select * from t;",
+			"drop table t;",
+			"call dolt_commit('-Am', 'copy t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select from_table_name, to_table_name from dolt_schema_diff('HEAD~', 'HEAD') order by 1, 2;",
+				Expected: []sql.Row{{"", "t_copy"}, {"t", ""}},
+			},
+			{
+				Query:    "set @@dolt_find_renames = 50;",
+				Expected: []sql.Row{{gmstypes.OkResult{}}},
+			},
+			{
+				Query:    "select from_table_name, to_table_name from dolt_schema_diff('HEAD~', 'HEAD');",
+				Expected: []sql.Row{{"t", "t_copy"}},
+			},
+		},
+	},
 }
 
 var DoltDatabaseCollationScriptTests = []queries.ScriptTest{
@@ -7225,3 +7332,276 @@ var QueryDiffTableScriptTests = []queries.ScriptTest{
 		},
 	},
 }
+
+var ChangesTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "SELECT * from dolt_changes();",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_changes(@Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_changes(@Commit1, 'main', 't', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_changes(123, 'main');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "SELECT * from dolt_changes('fake-branch', 'main');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:       "SELECT * from dolt_changes(hashof('main'), 'main');",
+				ExpectedErr: dtablefunctions.ErrInvalidNonLiteralArgument,
+			},
+		},
+	},
+	{
+		Name: "changes across several commits are ordered oldest first",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values (1, 'one'), (2, 'two');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+
+			"update t set c1 = 'uno' where pk = 1;",
+			"delete from t where pk = 2;",
+			"create table u (pk int primary key);",
+			"insert into u values (10);",
+			"call dolt_add('.')",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'updating t and creating u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = @Commit2, table_name, diff_type, from_row->>'$.pk', from_row->>'$.c1', to_row->>'$.pk', to_row->>'$.c1' from dolt_changes(@Commit0, @Commit3);",
+				Expected: []sql.Row{
+					{true, "t", "added", nil, nil, "1", "one"},
+					{true, "t", "added", nil, nil, "2", "two"},
+					{false, "t", "modified", "1", "one", "1", "uno"},
+					{false, "t", "removed", "2", "two", nil, nil},
+					{false, "u", "added", nil, nil, "10", nil},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes(@Commit0, @Commit3) where commit_hash = @Commit3;",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT committer, email from dolt_changes(@Commit1, @Commit2) limit 1;",
+				Expected: []sql.Row{{"root", "root@localhost"}},
+			},
+			{
+				Query: "SELECT table_name, diff_type from dolt_changes('main~2..main', 'u');",
+				Expected: []sql.Row{
+					{"u", "added"},
+				},
+			},
+			{
+				Query: "SELECT table_name, diff_type from dolt_changes(@Commit2, 'main', 't');",
+				Expected: []sql.Row{
+					{"t", "modified"},
+					{"t", "removed"},
+				},
+			},
+			{
+				Query:    "SELECT * from dolt_changes(@Commit3, @Commit3);",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "merge commits report the changes they bring to the first parent",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"set @Base = HashOf('HEAD');",
+			"call dolt_checkout('-b', 'other');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert on other');",
+			"call dolt_checkout('main');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert on main');",
+			"call dolt_merge('other', '--no-ff', '-m', 'merge other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT count(*) from dolt_changes(@Base, 'main');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT diff_type, to_row->>'$.pk' from dolt_changes(@Base, 'main') where commit_hash = hashof('main');",
+				Expected: []sql.Row{{"added", "1"}},
+			},
+		},
+	},
+	{
+		Name: "keyless tables emit one row per duplicate",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"create table k (c1 int, c2 varchar(10));",
+			"insert into k values (1, 'a'), (1, 'a'), (2, 'b');",
+			"call dolt_commit('-Am', 'creating table k');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT diff_type, to_row->>'$.c1', to_row->>'$.c2' from dolt_changes(@Commit0, 'main') order by 2;",
+				Expected: []sql.Row{
+					{"added", "1", "a"},
+					{"added", "1", "a"},
+					{"added", "2", "b"},
+				},
+			},
+		},
+	},
+}
+
+var GrepTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_commit('-Am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "SELECT * from dolt_grep();",
+				ExpectedErrStr: "dolt_grep requires a pattern to search for",
+			},
+			{
+				Query:       "SELECT * from dolt_grep(123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "SELECT * from dolt_grep('(');",
+				ExpectedErrStr: "invalid pattern '(': error parsing regexp: missing closing ): `(`",
+			},
+			{
+				Query:          "SELECT * from dolt_grep('a', 'main...HEAD');",
+				ExpectedErrStr: "dolt_grep does not support three dot ranges: main...HEAD",
+			},
+			{
+				Query:       "SELECT * from dolt_grep(concat('a', 'b'));",
+				ExpectedErr: dtablefunctions.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:          "SELECT * from dolt_grep('a', 'fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+		},
+	},
+	{
+		Name: "searching revisions",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 text, j json, n int);",
+			"create table u (id varchar(10), b blob, primary key (id));",
+			"insert into t values (1, 'apple', 'banana', '{\"fruit\": \"cherry\"}', 10), (2, 'pear', 'APPLE pie', null, 20);",
+			"insert into u values ('a', 'pineapple'), ('b', 'grape');",
+			"call dolt_commit('-Am', 'adding fruit');",
+			"insert into t values (3, 'crabapple', null, null, 30);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash, table_name, primary_key, column_name, diff_type, value from dolt_grep('apple');",
+				Expected: []sql.Row{
+					{"WORKING", "t", gmstypes.MustJSON(`{"pk": 1}`), "c1", nil, "apple"},
+					{"WORKING", "t", gmstypes.MustJSON(`{"pk": 3}`), "c1", nil, "crabapple"},
+					{"WORKING", "u", gmstypes.MustJSON(`{"id": "a"}`), "b", nil, "pineapple"},
+				},
+			},
+			{
+				Query: "SELECT commit_hash = hashof('main'), table_name, primary_key, column_name, value from dolt_grep('-i', 'apple', 'main');",
+				Expected: []sql.Row{
+					{true, "t", gmstypes.MustJSON(`{"pk": 1}`), "c1", "apple"},
+					{true, "t", gmstypes.MustJSON(`{"pk": 2}`), "c2", "APPLE pie"},
+					{true, "u", gmstypes.MustJSON(`{"id": "a"}`), "b", "pineapple"},
+				},
+			},
+			{
+				Query:    "SELECT table_name, column_name, value from dolt_grep('cherry', 'HEAD');",
+				Expected: []sql.Row{{"t", "j", `{"fruit":"cherry"}`}},
+			},
+			{
+				Query: "SELECT commit_hash, table_name, column_name, value from dolt_grep('^crab|^pine', 'HEAD', 'WORKING', '--', 'U');",
+				Expected: []sql.Row{
+					{doltCommit, "u", "b", "pineapple"},
+					{"WORKING", "u", "b", "pineapple"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_grep('20');",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "searching a commit range reports when matches appear and disappear",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"create table t (pk int primary key, c1 varchar(20));",
+			"insert into t values (1, 'secret one'), (2, 'public');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-Am', 'creating table t');",
+
+			"update t set c1 = 'secret two' where pk = 2;",
+			"insert into t values (3, 'public');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'more secrets');",
+
+			"update t set c1 = 'redacted' where pk = 1;",
+			"update t set c1 = 'still a secret' where pk = 2;",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'redacting');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = @Commit1, commit_hash = @Commit2, primary_key, diff_type, value from dolt_grep('secret', 'HEAD~3..HEAD');",
+				Expected: []sql.Row{
+					{true, false, gmstypes.MustJSON(`{"pk": 1}`), "added", "secret one"},
+					{false, true, gmstypes.MustJSON(`{"pk": 2}`), "added", "secret two"},
+					{false, false, gmstypes.MustJSON(`{"pk": 1}`), "removed", "secret one"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_grep('secret', 'HEAD~1..HEAD', '--', 'other');",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "keyless tables have no primary key",
+		SetUpScript: []string{
+			"create table k (c1 varchar(10));",
+			"insert into k values ('needle'), ('hay');",
+			"call dolt_commit('-Am', 'creating table k');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT table_name, primary_key, column_name, value from dolt_grep('needle', 'HEAD~1..HEAD');",
+				Expected: []sql.Row{{"k", nil, "c1", "needle"}},
+			},
+			{
+				Query:    "SELECT table_name, primary_key, column_name, value from dolt_grep('needle');",
+				Expected: []sql.Row{{"k", nil, "c1", "needle"}},
+			},
+		},
+	},
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var HistoryPruneScriptTests = []queries.ScriptTest{
+	{
+		Name: "dolt_history_prune: keep the last n commits",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'c1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'c2');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'c3');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'c4');",
+			"call dolt_tag('v3', 'HEAD~1');",
+			"insert into t values (4);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "call dolt_history_prune('--keep', '2', '-m', 'squashed');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select message from dolt_log;",
+				Expected: []sql.Row{{"c4"}, {"c3"}, {"squashed"}},
+			},
+			{
+				// the working set is not touched
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "select * from t as of 'HEAD~2' order by pk;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select message from dolt_log join dolt_tags on commit_hash = tag_hash where tag_name = 'v3';",
+				Expected: []sql.Row{{"c3"}},
+			},
+			{
+				Query:    "select count(*) from dolt_branches where hash = hashof('HEAD');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:          "call dolt_history_prune('--keep', '2');",
+				ExpectedErrStr: "nothing to prune: no commits match the given options",
+			},
+		},
+	},
+	{
+		Name: "dolt_history_prune: merge commits are rewritten",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'c1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'c2');",
+			"call dolt_tag('v2');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'f1');",
+			"call dolt_checkout('main');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'c3');",
+			"call dolt_merge('feature', '--no-ff', '-m', 'merge feature');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'c4');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_history_prune('--keep', '3', '-m', 'squashed');",
+				ExpectedErrStr: "error: tags v2 refer to pruned commits; delete them or use --delete-tags",
+			},
+			{
+				Query:            "call dolt_history_prune('--keep', '3', '-m', 'squashed', '--delete-tags');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select count(*) from dolt_log;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select message from dolt_log where commit_hash = hashof('HEAD~3');",
+				Expected: []sql.Row{{"squashed"}},
+			},
+			{
+				// both sides of the merge now descend from the new root commit
+				Query:    "select message from dolt_log where commit_hash = hashof('HEAD~1^2~1');",
+				Expected: []sql.Row{{"squashed"}},
+			},
+			{
+				Query:    "select count(*) from dolt_tags;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_log('feature') where message = 'squashed';",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1}, {2}, {3}, {10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_history_prune: errors",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'c1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'c2');",
+			"call dolt_branch('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_history_prune();",
+				ExpectedErrStr: "error: one of --keep or --before is required",
+			},
+			{
+				Query:          "call dolt_history_prune('--keep', '0');",
+				ExpectedErrStr: "error: --keep must be a positive number of commits, got '0'",
+			},
+			{
+				Query:          "call dolt_history_prune('--keep', '1', 'missing');",
+				ExpectedErrStr: "branch 'missing' not found",
+			},
+			{
+				Query:          "call dolt_history_prune('--before', 'yesterday');",
+				ExpectedErrStr: "error: 'yesterday' is not in a supported format.",
+			},
+			{
+				Query:                           "call dolt_history_prune('--keep', '1', 'other');",
+				ExpectedWarning:                 1105,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "branches main still refer to pruned commits",
+				SkipResultsCheck:                true,
+			},
+			{
+				Query:    "select count(*) from dolt_log('other');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select count(*) from dolt_log('main');",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+}
@@ -3373,6 +3373,94 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "merge follows a table copied under a new name",
+		SetUpScript: []string{
+			"SET @@dolt_find_renames = 50",
+			"CREATE TABLE t (pk int primary key, c1 int, c2 varchar(20))",
+			"INSERT INTO t VALUES (1, 1, 'one'), (2, 2, 'two'), (3, 3, 'three')",
+			"CALL DOLT_COMMIT('-Am', 'create t')",
+			"CALL DOLT_CHECKOUT('-b', 'other')",
+			"UPDATE t SET c1 = 10 WHERE pk = 1",
+			"INSERT INTO t VALUES (4, 4, 'four')",
+			"CALL DOLT_COMMIT('-am', 'change t on other')",
+			"CALL DOLT_CHECKOUT('main')",
+			"CREATE TABLE t_copy (pk int primary key, c1 int, c2 varchar(20))",
+			"INSERT INTO t_copy SELECT * FROM t",
+			"DROP TABLE t",
+			"CALL DOLT_COMMIT('-Am', 'copy t to t_copy')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t_copy ORDER BY pk",
+				Expected: []sql.Row{{1, 10, "one"}, {2, 2, "two"}, {3, 3, "three"}, {4, 4, "four"}},
+			},
+			{
+				Query:       "SELECT * FROM t",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "merge follows a table renamed on their branch",
+		SetUpScript: []string{
+			"SET @@dolt_find_renames = 50",
+			"CREATE TABLE t (pk int primary key, c1 int)",
+			"INSERT INTO t VALUES (1, 1), (2, 2)",
+			"CALL DOLT_COMMIT('-Am', 'create t')",
+			"CALL DOLT_CHECKOUT('-b', 'other')",
+			"RENAME TABLE t TO t2",
+			"CALL DOLT_COMMIT('-Am', 'rename t to t2')",
+			"CALL DOLT_CHECKOUT('main')",
+			"INSERT INTO t VALUES (3, 3)",
+			"CALL DOLT_COMMIT('-am', 'insert into t')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t2 ORDER BY pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:       "SELECT * FROM t",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "merge follows a column dropped and added again under a new name",
+		SetUpScript: []string{
+			"SET @@dolt_find_renames = 50",
+			"CREATE TABLE t (pk int primary key, a int, b int)",
+			"INSERT INTO t VALUES (1, 10, 100), (2, 20, 200), (3, 30, 300)",
+			"CALL DOLT_COMMIT('-Am', 'create t')",
+			"CALL DOLT_CHECKOUT('-b', 'other')",
+			"UPDATE t SET a = 11 WHERE pk = 1",
+			"CALL DOLT_COMMIT('-am', 'update a on other')",
+			"CALL DOLT_CHECKOUT('main')",
+			"ALTER TABLE t ADD COLUMN renamed_a int",
+			"UPDATE t SET renamed_a = a",
+			"ALTER TABLE t DROP COLUMN a",
+			"CALL DOLT_COMMIT('-am', 'replace a with renamed_a')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT pk, b, renamed_a FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, 100, 11}, {2, 200, 20}, {3, 300, 30}},
+			},
+		},
+	},
 }
 
 var KeylessMergeCVsAndConflictsScripts = []queries.ScriptTest{
@@ -0,0 +1,191 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+// octopusSetUpScript creates three branches off of main, each of which adds its own table, and a commit on main.
+var octopusSetUpScript = []string{
+	"CREATE TABLE t (pk INT PRIMARY KEY, v INT);",
+	"INSERT INTO t VALUES (1, 1);",
+	"CALL dolt_commit('-Am', 'add t');",
+	"CALL dolt_checkout('-b', 'a');",
+	"CREATE TABLE a (pk INT PRIMARY KEY);",
+	"INSERT INTO a VALUES (1);",
+	"CALL dolt_commit('-Am', 'add a');",
+	"CALL dolt_checkout('-b', 'b', 'main');",
+	"CREATE TABLE b (pk INT PRIMARY KEY);",
+	"INSERT INTO b VALUES (1);",
+	"CALL dolt_commit('-Am', 'add b');",
+	"CALL dolt_checkout('-b', 'c', 'main');",
+	"INSERT INTO t VALUES (2, 2);",
+	"CALL dolt_commit('-am', 'add row 2');",
+	"CALL dolt_checkout('main');",
+	"INSERT INTO t VALUES (3, 3);",
+	"CALL dolt_commit('-am', 'add row 3');",
+}
+
+var OctopusMergeScripts = []queries.ScriptTest{
+	{
+		Name:        "octopus merge of three branches",
+		SetUpScript: octopusSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('a', 'b', 'c');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"Merge branches 'a', 'b' and 'c' into main"}},
+			},
+			{
+				Query: "SELECT dca.parent_index, (SELECT message FROM dolt_log WHERE commit_hash = dca.parent_hash) AS message " +
+					"FROM dolt_commit_ancestors dca WHERE dca.commit_hash = hashof('main') ORDER BY dca.parent_index;",
+				Expected: []sql.Row{{0, "add row 3"}, {1, "add a"}, {2, "add b"}, {3, "add row 2"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "SELECT (SELECT count(*) FROM a), (SELECT count(*) FROM b);",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL dolt_merge('a', 'c');",
+				Expected: []sql.Row{{"", 0, 0, "Everything up-to-date"}},
+			},
+		},
+	},
+	{
+		Name:        "octopus merge skips branches that are already merged",
+		SetUpScript: append(octopusSetUpScript, "CALL dolt_merge('a', '-m', 'merge a');"),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('-m', 'release', 'a', 'b', 'b', 'c');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"release"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('main');",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "octopus merge with conflicts is abandoned",
+		SetUpScript: append(octopusSetUpScript,
+			"CALL dolt_checkout('b');",
+			"UPDATE t SET v = 10 WHERE pk = 1;",
+			"CALL dolt_commit('-am', 'update row 1 on b');",
+			"CALL dolt_checkout('c');",
+			"UPDATE t SET v = 20 WHERE pk = 1;",
+			"CALL dolt_commit('-am', 'update row 1 on c');",
+			"CALL dolt_checkout('main');",
+		),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "CALL dolt_merge('a', 'b', 'c');",
+				ExpectedErr: dprocedures.ErrOctopusMergeConflicts,
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"add row 3"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_merge_status WHERE is_merging;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SHOW TABLES;",
+				Expected: []sql.Row{{"t"}},
+			},
+		},
+	},
+	{
+		Name: "octopus merge that fails commit verification is abandoned",
+		SetUpScript: append(octopusSetUpScript,
+			"SET GLOBAL dolt_commit_verification_groups = '*';",
+			"INSERT INTO dolt_tests (test_name, test_group, test_query, assertion_type, assertion_comparator, assertion_value) VALUES "+
+				"('test_will_fail', 'unit', 'SELECT COUNT(*) FROM t', 'expected_single_value', '==', '999');",
+			"CALL dolt_commit('--skip-verification', '-Am', 'add failing test');",
+		),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "CALL dolt_merge('a', 'b');",
+				ExpectedErr: actions.ErrCommitVerificationFailed,
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"add failing test"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_merge_status WHERE is_merging;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) FROM information_schema.tables WHERE table_schema = database() AND table_name IN ('a', 'b');",
+				Expected: []sql.Row{{0}},
+			},
+			{ // Test harness bleeds GLOBAL variable changes across tests, so reset after each test.
+				Query:            "SET GLOBAL dolt_commit_verification_groups = ''",
+				SkipResultsCheck: true,
+			},
+		},
+	},
+	{
+		Name:        "octopus merge errors",
+		SetUpScript: octopusSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL dolt_merge('--squash', 'a', 'b');",
+				ExpectedErrStr: "error: Flag '--squash' cannot be used when merging more than one branch",
+			},
+			{
+				Query:          "CALL dolt_merge('--no-commit', 'a', 'b');",
+				ExpectedErrStr: "error: Flag '--no-commit' cannot be used when merging more than one branch",
+			},
+			{
+				Query:            "INSERT INTO t VALUES (4, 4);",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:       "CALL dolt_merge('a', 'b');",
+				ExpectedErr: dprocedures.ErrUncommittedChanges,
+			},
+		},
+	},
+}
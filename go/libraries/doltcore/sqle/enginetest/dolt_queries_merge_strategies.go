@@ -0,0 +1,377 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+var MergeStrategyScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_merge_strategies schema",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "DESCRIBE dolt_merge_strategies;",
+				Expected: []sql.Row{
+					{"table_name", "text", "NO", "PRI", nil, ""},
+					{"column_name", "text", "NO", "PRI", nil, ""},
+					{"strategy", "text", "NO", "", nil, ""},
+				},
+			},
+			{
+				Query:    "SELECT * FROM dolt_merge_strategies;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "counter and tag columns are resolved by their strategies",
+		SetUpScript: []string{
+			"CREATE TABLE items (id INT PRIMARY KEY, views INT, high_score INT, low_score INT, tags JSON, notes TEXT, owner VARCHAR(20));",
+			"INSERT INTO items VALUES (1, 10, 50, 50, '[\"a\", \"b\"]', 'created.', 'alice');",
+			"INSERT INTO dolt_merge_strategies VALUES ('items', 'views', 'sum'), ('items', 'high_score', 'max'), ('items', 'low_score', 'min'), ('items', 'tags', 'union'), ('items', 'notes', 'concat');",
+			"CALL dolt_commit('-Am', 'add items');",
+			"CALL dolt_branch('other');",
+			"UPDATE items SET views = 15, high_score = 70, low_score = 40, tags = '[\"a\", \"b\", \"c\"]', notes = 'created. ours.';",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"UPDATE items SET views = 13, high_score = 60, low_score = 30, tags = '[\"b\", \"d\"]', notes = 'created. theirs.';",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT id, views, high_score, low_score, tags, notes, owner FROM items;",
+				Expected: []sql.Row{{1, 18, 70, 30, types.MustJSON(`["b", "c", "d"]`), "created. ours. theirs.", "alice"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "columns without a strategy still conflict",
+		SetUpScript: []string{
+			"CREATE TABLE items (id INT PRIMARY KEY, views INT, owner VARCHAR(20));",
+			"INSERT INTO items VALUES (1, 10, 'alice');",
+			"INSERT INTO dolt_merge_strategies VALUES ('ITEMS', 'Views', 'sum');",
+			"CALL dolt_commit('-Am', 'add items');",
+			"CALL dolt_branch('other');",
+			"UPDATE items SET views = 11, owner = 'bob';",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"UPDATE items SET views = 12, owner = 'carol';",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+			"SET autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT our_views, their_views, our_owner, their_owner FROM dolt_conflicts_items;",
+				Expected: []sql.Row{{11, 12, "bob", "carol"}},
+			},
+		},
+	},
+	{
+		Name: "conflicting inserts are resolved by their strategies",
+		SetUpScript: []string{
+			"CREATE TABLE counts (id INT PRIMARY KEY, n INT, owner VARCHAR(20));",
+			"INSERT INTO dolt_merge_strategies VALUES ('counts', 'n', 'sum'), ('counts', 'owner', 'theirs');",
+			"CALL dolt_commit('-Am', 'add counts');",
+			"CALL dolt_branch('other');",
+			"INSERT INTO counts VALUES (1, 2, 'alice');",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"INSERT INTO counts VALUES (1, 3, 'bob');",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM counts;",
+				Expected: []sql.Row{{1, 5, "bob"}},
+			},
+		},
+	},
+	{
+		Name: "latest commit timestamp wins",
+		SetUpScript: []string{
+			"CREATE TABLE settings (name VARCHAR(20) PRIMARY KEY, value VARCHAR(20));",
+			"INSERT INTO settings VALUES ('color', 'red');",
+			"INSERT INTO dolt_merge_strategies VALUES ('settings', 'value', 'latest');",
+			"CALL dolt_commit('-Am', 'add settings', '--date', '2024-01-01T00:00:00');",
+			"CALL dolt_branch('other');",
+			"UPDATE settings SET value = 'blue';",
+			"CALL dolt_commit('-am', 'ours', '--date', '2024-01-03T00:00:00');",
+			"CALL dolt_checkout('other');",
+			"UPDATE settings SET value = 'green';",
+			"CALL dolt_commit('-am', 'theirs', '--date', '2024-01-02T00:00:00');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM settings;",
+				Expected: []sql.Row{{"color", "blue"}},
+			},
+		},
+	},
+	{
+		Name: "strategies that don't apply to a column's type are errors",
+		SetUpScript: []string{
+			"CREATE TABLE items (id INT PRIMARY KEY, owner VARCHAR(20));",
+			"INSERT INTO items VALUES (1, 'alice');",
+			"INSERT INTO dolt_merge_strategies VALUES ('items', 'owner', 'sum');",
+			"CALL dolt_commit('-Am', 'add items');",
+			"CALL dolt_branch('other');",
+			"UPDATE items SET owner = 'bob';",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"UPDATE items SET owner = 'carol';",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "CALL dolt_merge('other');",
+				ExpectedErr: merge.ErrInapplicableMergeStrategy,
+			},
+			{
+				Query:    "UPDATE dolt_merge_strategies SET strategy = 'newest';",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "CALL dolt_commit('-am', 'typo');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:       "CALL dolt_merge('other');",
+				ExpectedErr: merge.ErrUnknownMergeStrategy,
+			},
+		},
+	},
+	{
+		Name: "dolt_merge -s ours keeps our root",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, v INT);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_branch('other');",
+			"UPDATE t SET v = 10;",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"UPDATE t SET v = 20;",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('-s', 'ours', 'other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_diff('HEAD^', 'HEAD', 't');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 0, "cannot fast forward from a to b. a is ahead of b already"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge -s ours makes a merge commit instead of fast-forwarding",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, v INT);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_checkout('-b', 'other');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_merge('--strategy', 'ours', '-m', 'record other', 'other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"record other"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge -X resolves data conflicts",
+		SetUpScript: []string{
+			"SET autocommit = 0;",
+			"CREATE TABLE a (pk INT PRIMARY KEY, v INT);",
+			"CREATE TABLE b (pk INT PRIMARY KEY, v INT);",
+			"INSERT INTO a VALUES (1, 1);",
+			"INSERT INTO b VALUES (1, 1);",
+			"CALL dolt_commit('-Am', 'add tables');",
+			"CALL dolt_branch('other');",
+			"UPDATE a SET v = 10;",
+			"UPDATE b SET v = 10;",
+			"INSERT INTO a VALUES (2, 2);",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"UPDATE a SET v = 20;",
+			"UPDATE b SET v = 20;",
+			"INSERT INTO a VALUES (3, 3);",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// b has no strategy, so its conflict is kept
+				Query:    "CALL dolt_merge('-X', 'a=theirs', 'other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT `table`, num_conflicts FROM dolt_conflicts;",
+				Expected: []sql.Row{{"b", uint64(1)}},
+			},
+			{
+				Query:    "SELECT * FROM a ORDER BY pk;",
+				Expected: []sql.Row{{1, 20}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "CALL dolt_merge('--abort');",
+				Expected: []sql.Row{{"", 0, 0, "merge aborted"}},
+			},
+			{
+				Query:    "CALL dolt_merge('-X', 'theirs, B=ours', 'other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM a ORDER BY pk;",
+				Expected: []sql.Row{{1, 20}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "SELECT * FROM b ORDER BY pk;",
+				Expected: []sql.Row{{1, 10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge -X resolves schema conflicts with the whole table",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY);",
+			"INSERT INTO t VALUES (1);",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_branch('other');",
+			"ALTER TABLE t ADD COLUMN c INT;",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL dolt_commit('-am', 'ours');",
+			"CALL dolt_checkout('other');",
+			"ALTER TABLE t ADD COLUMN c VARCHAR(10);",
+			"INSERT INTO t VALUES (3, 'three');",
+			"CALL dolt_commit('-am', 'theirs');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:                           "CALL dolt_merge('-X', 'theirs', 'other');",
+				Expected:                        []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+				ExpectedWarning:                 dprocedures.DoltMergeWarningCode,
+				ExpectedWarningsCount:           1,
+				ExpectedWarningMessageSubstring: "table t has a schema conflict, so it was taken whole from theirs",
+			},
+			{
+				// our insert of pk 2 didn't conflict, but it is discarded with the rest of our side of the table
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, nil}, {3, "three"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_schema_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge strategy errors",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY);",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_branch('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL dolt_merge('-s', 'recursive', 'other');",
+				ExpectedErrStr: "error: unknown merge strategy 'recursive'; the only supported strategy is 'ours'",
+			},
+			{
+				Query:          "CALL dolt_merge('-s', 'ours', '-X', 'theirs', 'other');",
+				ExpectedErrStr: "error: Flags '--strategy' and '--strategy-option' cannot be used together",
+			},
+			{
+				Query:          "CALL dolt_merge('-s', 'ours', '--squash', 'other');",
+				ExpectedErrStr: "error: Flags '--strategy' and '--squash' cannot be used together",
+			},
+			{
+				Query:       "CALL dolt_merge('-X', 'max', 'other');",
+				ExpectedErr: merge.ErrInvalidStrategyOption,
+			},
+			{
+				Query:       "CALL dolt_merge('-X', 't=', 'other');",
+				ExpectedErr: merge.ErrInvalidStrategyOption,
+			},
+		},
+	},
+}
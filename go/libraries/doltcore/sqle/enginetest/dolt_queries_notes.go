@@ -0,0 +1,215 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+)
+
+var notesSetUpScript = []string{
+	"create table t (pk int primary key);",
+	"call dolt_commit('-Am', 'create t');",
+	"insert into t values (1);",
+	"call dolt_commit('-am', 'c1');",
+	"set @c1 = dolt_hashof('HEAD');",
+	"set @c0 = dolt_hashof('HEAD~1');",
+}
+
+var NotesScripts = []queries.ScriptTest{
+	{
+		Name:        "dolt_notes: add, replace and remove notes",
+		SetUpScript: notesSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_notes;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "call dolt_notes('add', '-m', 'approved by review');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_notes('add', '-m', 'TICKET-12', 'HEAD~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query: "select ref, commit_hash = @c1, commit_hash = @c0, note from dolt_notes order by note;",
+				Expected: []sql.Row{
+					{"commits", false, true, "TICKET-12"},
+					{"commits", true, false, "approved by review"},
+				},
+			},
+			{
+				Query:          "call dolt_notes('add', '-m', 'again');",
+				ExpectedErrStr: "error: HEAD already has a note; use -f to replace it",
+			},
+			{
+				Query:    "call dolt_notes('add', '-f', '-m', 'approved twice');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select note from dolt_notes where commit_hash = @c1;",
+				Expected: []sql.Row{{"approved twice"}},
+			},
+			{
+				Query:    "call dolt_notes('remove', 'HEAD~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "call dolt_notes('remove', 'HEAD~1');",
+				ExpectedErrStr: "error: HEAD~1 has no note",
+			},
+			{
+				Query:    "select commit_hash = @c1, note from dolt_notes;",
+				Expected: []sql.Row{{true, "approved twice"}},
+			},
+			{
+				// notes don't change the commits they annotate
+				Query:    "select dolt_hashof('HEAD') = @c1, message from dolt_log limit 1;",
+				Expected: []sql.Row{{true, "c1"}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name:        "dolt_notes: named notes refs",
+		SetUpScript: notesSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_notes('add', '--ref', 'quality', '-m', 'all checks passed');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_notes('add', '--ref', 'refs/notes/review', '-m', 'lgtm');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query: "select ref, note from dolt_notes order by ref;",
+				Expected: []sql.Row{
+					{"quality", "all checks passed"},
+					{"review", "lgtm"},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_notes where ref = 'commits';",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_notes: merging notes refs",
+		SetUpScript: append(notesSetUpScript,
+			"call dolt_notes('add', '-m', 'base', 'HEAD~1');",
+			"call dolt_notes('merge', '--ref', 'theirs', 'commits');",
+			"call dolt_notes('add', '-m', 'ours', 'HEAD');",
+			"call dolt_notes('add', '-f', '--ref', 'theirs', '-m', 'theirs', 'HEAD~1');",
+		),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// theirs was created from commits by fast-forward, then both sides changed different notes
+				Query:    "call dolt_notes('merge', 'theirs');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query: "select commit_hash = @c1, note from dolt_notes where ref = 'commits' order by note;",
+				Expected: []sql.Row{
+					{true, "ours"},
+					{false, "theirs"},
+				},
+			},
+			{
+				Query:    "call dolt_notes('add', '-f', '-m', 'ours again', 'HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_notes('add', '--ref', 'theirs', '-m', 'theirs too', 'HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_notes('merge', 'theirs');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// both sides added a note to HEAD, so the default strategy keeps both
+				Query:    "select note from dolt_notes where ref = 'commits' and commit_hash = @c1;",
+				Expected: []sql.Row{{"ours again\n\ntheirs too"}},
+			},
+			{
+				Query:    "call dolt_notes('add', '-f', '--ref', 'theirs', '-m', 'theirs wins', 'HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_notes('merge', '-s', 'theirs', 'theirs');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select note from dolt_notes where ref = 'commits' and commit_hash = @c1;",
+				Expected: []sql.Row{{"theirs wins"}},
+			},
+		},
+	},
+	{
+		Name:        "dolt_notes: errors",
+		SetUpScript: notesSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_notes();",
+				ExpectedErrStr: "error: a subcommand is required: add, remove, merge, push, or pull; use the dolt_notes system table to read notes",
+			},
+			{
+				Query:          "call dolt_notes('show');",
+				ExpectedErrStr: "error: unknown notes subcommand 'show'",
+			},
+			{
+				Query:          "call dolt_notes('add');",
+				ExpectedErrStr: "error: add requires a note given with -m",
+			},
+			{
+				Query:          "call dolt_notes('remove', '-m', 'x');",
+				ExpectedErrStr: "error: --message can only be used with add",
+			},
+			{
+				Query:          "call dolt_notes('merge', '-s', 'manual', 'other');",
+				ExpectedErrStr: "unknown notes merge strategy 'manual'",
+			},
+			{
+				Query:          "call dolt_notes('merge', 'other');",
+				ExpectedErrStr: "error: notes ref refs/notes/other does not exist",
+			},
+			{
+				Query:          "call dolt_notes('add', '--ref', 'refs/heads/main', '-m', 'x');",
+				ExpectedErrStr: "'refs/heads/main' is not a notes ref; notes refs must be under refs/notes/",
+			},
+			{
+				Query:          "call dolt_notes('merge', 'refs/heads/main');",
+				ExpectedErrStr: "'refs/heads/main' is not a notes ref; notes refs must be under refs/notes/",
+			},
+			{
+				Query:          "call dolt_notes('add', '--ref', 'bad..name', '-m', 'x');",
+				ExpectedErrStr: "'bad..name' is not a valid notes ref name",
+			},
+			{
+				Query:       "call dolt_notes('push', 'origin');",
+				ExpectedErr: env.ErrInvalidRepository,
+			},
+		},
+	},
+}
@@ -221,6 +221,61 @@ var DoltTransactionTests = []queries.TransactionTest{
 			},
 		},
 	},
+	{
+		Name: "conflicting updates to a column with a merge strategy",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1), (2, 2)",
+			"insert into dolt_merge_strategies values ('t', 'y', 'ours')",
+			"call dolt_commit('-Am', 'add t')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "/* client a */ update t set y = 3 where x = 1",
+				Expected: []sql.Row{{types.OkResult{
+					RowsAffected: uint64(1),
+					Info: plan.UpdateInfo{
+						Matched: 1,
+						Updated: 1,
+					},
+				}}},
+			},
+			{
+				Query: "/* client b */ update t set y = 4 where x = 1",
+				Expected: []sql.Row{{types.OkResult{
+					RowsAffected: uint64(1),
+					Info: plan.UpdateInfo{
+						Matched: 1,
+						Updated: 1,
+					},
+				}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{ // merge strategies only apply to dolt_merge, so the concurrent write is still a conflict
+				Query:          "/* client b */ commit",
+				ExpectedErrStr: sql.ErrLockDeadlock.New(dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				Query:    "/* client a */ select * from t order by x",
+				Expected: []sql.Row{{1, 3}, {2, 2}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by x",
+				Expected: []sql.Row{{1, 3}, {2, 2}},
+			},
+		},
+	},
 	{
 		Name: "conflicting updates",
 		SetUpScript: []string{
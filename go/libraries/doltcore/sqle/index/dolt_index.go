@@ -129,6 +129,33 @@ func (p *CommitIndex) CanSupport(c *sql.Context, ranges ...sql.Range) bool {
 
 var _ DoltIndex = (*CommitIndex)(nil)
 
+// NewCommitMetaIndex returns an index over a column of commit metadata, such as the date or author of a commit. There is
+// nothing to seek into, so tables using it apply the lookup ranges to each commit as they walk the commit graph.
+func NewCommitMetaIndex(i *doltIndex) *CommitMetaIndex {
+	return &CommitMetaIndex{doltIndex: i}
+}
+
+type CommitMetaIndex struct {
+	*doltIndex
+}
+
+// CanSupportOrderBy implements the interface sql.Index.
+func (cmi *CommitMetaIndex) CanSupportOrderBy(_ sql.Expression) bool {
+	return false
+}
+
+func (cmi *CommitMetaIndex) ExtendedExpressions(ctx *sql.Context) []string {
+	// The MockIndex used by commit metadata indexes doesn't set an index schema, so
+	// we can't use the implementation of ExtendedExpressions from doltIndex.
+	return cmi.Expressions()
+}
+
+func (cmi *CommitMetaIndex) ExtendedColumnExpressionTypes(ctx *sql.Context) []sql.ColumnExpressionType {
+	return cmi.ColumnExpressionTypes(ctx)
+}
+
+var _ DoltIndex = (*CommitMetaIndex)(nil)
+
 func DoltDiffIndexesFromTable(ctx context.Context, db, tbl string, t *doltdb.Table) (indexes []sql.Index, err error) {
 	sch, err := t.GetSchema(ctx)
 	if err != nil {
@@ -61,6 +61,13 @@ func (t *IndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexScanBuild
 }
 
 func (idt *IndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	table, err := idt.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = idt.DoltTable.checkFetched(ctx, table); err != nil {
+		return nil, err
+	}
 	return index.NewRangePartitionIter(ctx, idt.DoltTable, lookup)
 }
 
@@ -143,6 +150,13 @@ func (t *WritableIndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexS
 }
 
 func (t *WritableIndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.DoltTable.checkFetched(ctx, table); err != nil {
+		return nil, err
+	}
 	if lookup.VectorOrderAndLimit.OrderBy != nil {
 		return index.NewVectorPartitionIter(lookup)
 	}
@@ -0,0 +1,142 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// Journal shipping lets a running SQL server continuously copy the
+// chunk journal of every database to a backup location, so that a
+// database can be restored as of any instant with
+// `dolt restore --to-time`.
+//
+// A JournalShippingController is created for a running SQL Engine
+// when journal shipping is configured. Every database in the
+// DoltDatabaseProvider gets an nbs.JournalShipper writing to
+// <url>/<database name>. A background thread calls Ship on each of
+// them once per interval, which copies the journal records written
+// since the previous tick, including the working set updates made
+// between commits.
+
+type JournalShippingController struct {
+	url      string
+	interval time.Duration
+	lgr      *logrus.Logger
+	shippers map[string]*nbs.JournalShipper
+	mu       sync.Mutex
+}
+
+// NewJournalShippingController returns a JournalShippingController shipping every database to a blobstore under
+// |url| once every |interval|.
+func NewJournalShippingController(url string, interval time.Duration, lgr *logrus.Logger) *JournalShippingController {
+	return &JournalShippingController{
+		url:      strings.TrimSuffix(url, "/"),
+		interval: interval,
+		lgr:      lgr,
+		shippers: make(map[string]*nbs.JournalShipper),
+	}
+}
+
+// During engine initialization, this should be called to start the
+// background thread which ships the journals.
+func (c *JournalShippingController) RunBackgroundThread(threads *sql.BackgroundThreads) error {
+	return threads.Add("journal_shipping_thread", c.shippingThread)
+}
+
+// During engine initialization, called on the original set of
+// databases to start shipping their journals.
+func (c *JournalShippingController) AddDatabases(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		if err := c.addDatabase(ctx, db.Name(), denv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *JournalShippingController) InitDatabaseHook() InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		return c.addDatabase(ctx, name, env)
+	}
+}
+
+func (c *JournalShippingController) DropDatabaseHook() DropDatabaseHook {
+	return func(_ *sql.Context, name string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.shippers, name)
+	}
+}
+
+func (c *JournalShippingController) addDatabase(ctx context.Context, name string, denv *env.DoltEnv) error {
+	dir, err := denv.FS.Abs(dbfactory.DoltDataDir)
+	if err != nil {
+		return err
+	}
+	bs, err := dbfactory.NewBlobstore(ctx, c.url+"/"+name)
+	if err != nil {
+		return err
+	}
+	shipper, err := nbs.NewJournalShipper(ctx, dir, bs)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shippers[name] = shipper
+	return nil
+}
+
+func (c *JournalShippingController) shippingThread(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		shippers := make(map[string]*nbs.JournalShipper, len(c.shippers))
+		for name, shipper := range c.shippers {
+			shippers[name] = shipper
+		}
+		c.mu.Unlock()
+
+		for name, shipper := range shippers {
+			if err := shipper.Ship(ctx); err != nil && ctx.Err() == nil {
+				c.lgr.Warnf("sqle/journal_shipping: error shipping the journal of database %s: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy implements the row filters and column masks stored in the dolt_policies system table.
+//
+// Each row of dolt_policies names a user table, a user pattern and either a row filter, a column mask, or both. A row
+// filter is a boolean SQL expression over the table's columns; users matching the pattern only see the rows for which
+// every one of their filters is true. A column mask is a SQL expression whose value replaces the named column in
+// every row those users read. User patterns use the same syntax as dolt_branch_control, where '%' matches any
+// sequence of characters and '_' matches any single character.
+//
+// Policies are applied to every read of the table, as well as its dolt_history_, dolt_diff_ and dolt_commit_diff_
+// system tables, dolt_blame_ (which is a view over dolt_diff_), and the dolt_diff() and dolt_changes() table
+// functions. Table functions that cannot apply them, such as dolt_patch(), refuse restricted tables. The policies that
+// apply are always those committed to the database's default branch, so that they cannot be bypassed by checking out
+// another branch, reading an older revision, or editing dolt_policies in a working set. Users with administrative
+// privileges on the database are never restricted, and only they can write to dolt_policies. Restricted tables are
+// read-only for the users they apply to.
+package policy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrInvalidPolicy is returned when a row of dolt_policies cannot be applied. Reads of the table it names fail until
+// the policy is fixed, rather than silently showing unfiltered data.
+var ErrInvalidPolicy = errors.NewKind("invalid policy '%s': %s")
+
+// ErrRestrictedTable is returned by operations that cannot apply the policies of a table, for users they apply to.
+var ErrRestrictedTable = errors.NewKind("%s cannot be used on table '%s' because of its access policies")
+
+// Policy is a single row of the dolt_policies system table.
+type Policy struct {
+	Name string
+	// Table is the name of the user table the policy applies to
+	Table string
+	// User is the pattern of the user names the policy applies to
+	User string
+	// RowFilter is a boolean expression over the table's columns, or empty if the policy doesn't filter rows
+	RowFilter string
+	// Column is the column masked by ColumnMask, or empty if the policy doesn't mask a column
+	Column     string
+	ColumnMask string
+
+	// err is set for incomplete policies, which fail every read they apply to
+	err error
+}
+
+// policySet is the parsed contents of one version of the dolt_policies table.
+type policySet struct {
+	policies []Policy
+	users    []branch_control.MatchExpression
+}
+
+// maxCachedSets bounds the number of parsed dolt_policies versions kept in memory.
+const maxCachedSets = 64
+
+var setCache = struct {
+	sync.Mutex
+	sets map[hash.Hash]*policySet
+}{sets: make(map[hash.Hash]*policySet)}
+
+// ForTable returns the policies of |db| that apply to the current user's reads of the table named |tableName|. It
+// returns no policies if the user is not restricted.
+func ForTable(ctx *sql.Context, db dsess.SqlDatabase, tableName string) ([]Policy, error) {
+	user := ctx.Session.Client().User
+	if user == "" {
+		// internal sessions are never restricted
+		return nil, nil
+	}
+	if doltdb.HasDoltPrefix(tableName) {
+		return nil, nil
+	}
+	if bcSess := branch_control.GetBranchAwareSession(ctx); bcSess == nil || branch_control.HasDatabasePrivileges(bcSess, db.AliasedName()) {
+		return nil, nil
+	}
+
+	set, err := loadDefaultBranchPolicies(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.policies) == 0 {
+		return nil, nil
+	}
+
+	var ret []Policy
+	for _, idx := range branch_control.Match(set.users, user, sql.Collation_utf8mb4_0900_bin) {
+		if p := set.policies[idx]; strings.EqualFold(p.Table, tableName) {
+			if p.err != nil {
+				return nil, p.err
+			}
+			ret = append(ret, p)
+		}
+	}
+	return ret, nil
+}
+
+// loadDefaultBranchPolicies returns the policies committed to the default branch of |db|.
+func loadDefaultBranchPolicies(ctx *sql.Context, db dsess.SqlDatabase) (*policySet, error) {
+	branch, err := dsess.DefaultHead(ctx, db.AliasedName(), db)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := db.DbData().Ddb.ResolveCommitRef(ctx, ref.NewBranchRef(branch))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s from default branch '%s': %w", doltdb.PoliciesTableName, branch, err)
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return loadPolicies(ctx, root)
+}
+
+// loadPolicies reads and validates the dolt_policies table of |root|. Parsed tables are cached by their hash.
+func loadPolicies(ctx *sql.Context, root doltdb.RootValue) (*policySet, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: doltdb.PoliciesTableName})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &policySet{}, nil
+	}
+	h, err := tbl.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	setCache.Lock()
+	set, ok := setCache.sets[h]
+	setCache.Unlock()
+	if ok {
+		return set, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := table.NewTableIterator(ctx, sch, rowData)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close(ctx)
+
+	cols := sch.GetAllCols()
+	colIdx := make([]int, 6)
+	for i, name := range []string{"policy_name", "table_name", "user", "row_filter", "column_name", "column_mask"} {
+		if colIdx[i] = cols.IndexOf(name); colIdx[i] < 0 {
+			return nil, fmt.Errorf("%s is missing column %s", doltdb.PoliciesTableName, name)
+		}
+	}
+
+	set = &policySet{}
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var fields [6]string
+		for i, idx := range colIdx {
+			if fields[i], err = textValue(ctx, row[idx]); err != nil {
+				return nil, err
+			}
+		}
+		p := Policy{
+			Name:       fields[0],
+			Table:      fields[1],
+			User:       fields[2],
+			RowFilter:  fields[3],
+			Column:     fields[4],
+			ColumnMask: fields[5],
+		}
+		p.err = validate(p)
+		set.users = append(set.users, branch_control.MatchExpression{
+			CollectionIndex: uint32(len(set.policies)),
+			SortOrders:      branch_control.ParseExpression(branch_control.FoldExpression(p.User), sql.Collation_utf8mb4_0900_bin),
+		})
+		set.policies = append(set.policies, p)
+	}
+
+	setCache.Lock()
+	defer setCache.Unlock()
+	if len(setCache.sets) >= maxCachedSets {
+		setCache.sets = make(map[hash.Hash]*policySet)
+	}
+	setCache.sets[h] = set
+	return set, nil
+}
+
+// validate returns an error if |p| is not a complete policy. Expressions are checked when they are first applied.
+func validate(p Policy) error {
+	switch {
+	case (p.Column == "") != (p.ColumnMask == ""):
+		return ErrInvalidPolicy.New(p.Name, "column_name and column_mask must be set together")
+	case p.RowFilter == "" && p.ColumnMask == "":
+		return ErrInvalidPolicy.New(p.Name, "one of row_filter or column_mask is required")
+	}
+	return nil
+}
+
+func textValue(ctx *sql.Context, v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	s, _, err := types.Text.Convert(ctx, v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(s.(string)), nil
+}
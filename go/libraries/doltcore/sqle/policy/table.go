@@ -0,0 +1,328 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+// Kind describes how the rows of a table relate to the rows of the user table its policies are defined on.
+type Kind int
+
+const (
+	// KindTable is the user table itself, or a table with the same columns, such as dolt_history_<table>.
+	KindTable Kind = iota
+	// KindDiff is a diff of the user table, such as dolt_diff_<table>, with each column of the user table appearing
+	// once prefixed with to_ and once prefixed with from_.
+	KindDiff
+)
+
+const diffTypeColumn = "diff_type"
+
+// sidePrefixes returns the column name prefixes of each version of a user table row held in a row of this kind.
+func (k Kind) sidePrefixes() []string {
+	if k == KindDiff {
+		return []string{"to_", "from_"}
+	}
+	return []string{""}
+}
+
+// Table wraps a table and applies policies to every row read from it. It only implements sql.Table, so that the
+// analyzer cannot push filters, projections or index lookups past the policies, and so that it is read-only.
+type Table struct {
+	underlying sql.Table
+	dbName     string
+	tableName  string
+	kind       Kind
+	policies   []Policy
+
+	once      sync.Once
+	evaluator *evaluator
+	err       error
+}
+
+var _ sql.Table = (*Table)(nil)
+
+// NewTable returns |tbl| with |policies| applied to its rows. |dbName| and |tableName| name the user table the
+// policies are defined on, and |kind| describes the rows of |tbl|.
+func NewTable(tbl sql.Table, dbName, tableName string, kind Kind, policies []Policy) *Table {
+	return &Table{
+		underlying: tbl,
+		dbName:     dbName,
+		tableName:  tableName,
+		kind:       kind,
+		policies:   policies,
+	}
+}
+
+// Name implements sql.Table
+func (t *Table) Name() string {
+	return t.underlying.Name()
+}
+
+// String implements sql.Table
+func (t *Table) String() string {
+	return t.underlying.String()
+}
+
+// Schema implements sql.Table
+func (t *Table) Schema(ctx *sql.Context) sql.Schema {
+	return t.underlying.Schema(ctx)
+}
+
+// Collation implements sql.Table
+func (t *Table) Collation() sql.CollationID {
+	return t.underlying.Collation()
+}
+
+// Partitions implements sql.Table
+func (t *Table) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	return t.underlying.Partitions(ctx)
+}
+
+// PartitionRows implements sql.Table
+func (t *Table) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	// Policy expressions are resolved against the user table, which in turn resolves this table, so they cannot be
+	// resolved until the table is read.
+	t.once.Do(func() {
+		t.evaluator, t.err = newEvaluator(ctx, t.dbName, t.tableName, t.kind, t.policies, t.underlying.Schema(ctx))
+	})
+	if t.err != nil {
+		return nil, t.err
+	}
+	iter, err := t.underlying.PartitionRows(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return &rowIter{inner: iter, evaluator: t.evaluator}, nil
+}
+
+// NewRowIter returns |iter| with |policies| applied to its rows, which have the schema |sch|. |dbName| and
+// |tableName| name the user table the policies are defined on, and |kind| describes the rows of |iter|.
+func NewRowIter(ctx *sql.Context, dbName, tableName string, kind Kind, policies []Policy, sch sql.Schema, iter sql.RowIter) (sql.RowIter, error) {
+	e, err := newEvaluator(ctx, dbName, tableName, kind, policies, sch)
+	if err != nil {
+		iter.Close(ctx)
+		return nil, err
+	}
+	return &rowIter{inner: iter, evaluator: e}, nil
+}
+
+// RowEvaluator applies policies to single rows of a user table, for readers that don't produce rows through a
+// sql.RowIter of the table's schema.
+type RowEvaluator struct {
+	evaluator *evaluator
+}
+
+// NewRowEvaluator returns a RowEvaluator applying |policies| to rows with the schema |sch|, which hold one version of
+// a row of the user table named by |dbName| and |tableName|.
+func NewRowEvaluator(ctx *sql.Context, dbName, tableName string, policies []Policy, sch sql.Schema) (*RowEvaluator, error) {
+	e, err := newEvaluator(ctx, dbName, tableName, KindTable, policies, sch)
+	if err != nil {
+		return nil, err
+	}
+	return &RowEvaluator{evaluator: e}, nil
+}
+
+// Apply returns |row| with all masks applied, and whether it passes all filters.
+func (re *RowEvaluator) Apply(ctx *sql.Context, row sql.Row) (sql.Row, bool, error) {
+	return re.evaluator.apply(ctx, row)
+}
+
+type rowIter struct {
+	inner     sql.RowIter
+	evaluator *evaluator
+}
+
+var _ sql.RowIter = (*rowIter)(nil)
+
+// Next implements sql.RowIter
+func (itr *rowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		row, err := itr.inner.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		row, ok, err := itr.evaluator.apply(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return row, nil
+		}
+	}
+}
+
+// Close implements sql.RowIter
+func (itr *rowIter) Close(ctx *sql.Context) error {
+	return itr.inner.Close(ctx)
+}
+
+// evaluator applies the filters and masks of a set of policies to rows of a single schema.
+type evaluator struct {
+	// diffTypeIdx is the index of the diff_type column of a KindDiff schema, used to tell which sides of a row are
+	// present, or -1 if every side is always present
+	diffTypeIdx int
+	sides       []side
+}
+
+// side holds the resolved policy expressions for one version of a user table row within a row.
+type side struct {
+	prefix  string
+	filters []sql.Expression
+	masks   []mask
+}
+
+type mask struct {
+	idx  int
+	typ  sql.Type
+	expr sql.Expression
+}
+
+func newEvaluator(ctx *sql.Context, dbName, tableName string, kind Kind, policies []Policy, sch sql.Schema) (*evaluator, error) {
+	qualifiedName := sqlfmt.QuoteIdentifier(ctx, dbName) + "." + sqlfmt.QuoteIdentifier(ctx, tableName)
+	e := &evaluator{diffTypeIdx: -1}
+	if kind == KindDiff {
+		e.diffTypeIdx = sch.IndexOfColName(diffTypeColumn)
+	}
+
+	for _, prefix := range kind.sidePrefixes() {
+		s := side{prefix: prefix}
+		masked := make(map[int]string)
+		for _, p := range policies {
+			if p.RowFilter != "" {
+				filter, err := resolve(ctx, qualifiedName, p, p.RowFilter, sch, prefix)
+				if err != nil {
+					return nil, err
+				}
+				s.filters = append(s.filters, filter)
+			}
+			if p.ColumnMask != "" {
+				idx := sch.IndexOfColName(prefix + p.Column)
+				if idx < 0 {
+					return nil, ErrInvalidPolicy.New(p.Name, fmt.Sprintf("column %s not found on table %s", p.Column, tableName))
+				}
+				if other, ok := masked[idx]; ok {
+					return nil, ErrInvalidPolicy.New(p.Name, fmt.Sprintf("column %s is already masked by policy '%s'", p.Column, other))
+				}
+				masked[idx] = p.Name
+				expr, err := resolve(ctx, qualifiedName, p, p.ColumnMask, sch, prefix)
+				if err != nil {
+					return nil, err
+				}
+				s.masks = append(s.masks, mask{idx: idx, typ: sch[idx].Type, expr: expr})
+			}
+		}
+		e.sides = append(e.sides, s)
+	}
+	return e, nil
+}
+
+// resolve resolves the policy expression |exprStr| against the user table named by |qualifiedName|, and binds its
+// column references to the columns of |sch| with the prefix given.
+func resolve(ctx *sql.Context, qualifiedName string, p Policy, exprStr string, sch sql.Schema, prefix string) (sql.Expression, error) {
+	expr, err := expranalysis.ResolveExpression(ctx, qualifiedName, exprStr)
+	if err != nil {
+		return nil, ErrInvalidPolicy.New(p.Name, err.Error())
+	}
+	// Subqueries are resolved relative to the query they appear in, so they can't be evaluated against our rows
+	if transform.InspectExpr(ctx, expr, func(ctx *sql.Context, e sql.Expression) bool {
+		_, ok := e.(*plan.Subquery)
+		return ok
+	}) {
+		return nil, ErrInvalidPolicy.New(p.Name, "subqueries are not supported in policy expressions")
+	}
+
+	expr, _, err = transform.Expr(ctx, expr, func(ctx *sql.Context, e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return e, transform.SameTree, nil
+		}
+		idx := sch.IndexOfColName(prefix + gf.Name())
+		if idx < 0 {
+			return nil, transform.SameTree, ErrInvalidPolicy.New(p.Name, fmt.Sprintf("column %s not found", gf.Name()))
+		}
+		return gf.WithIndex(idx), transform.NewTree, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// apply returns |row| with all masks applied, and whether it passes all filters. A row holding more than one version
+// of a user table row passes only if every version present passes.
+func (e *evaluator) apply(ctx *sql.Context, row sql.Row) (sql.Row, bool, error) {
+	var masked sql.Row
+	for i, s := range e.sides {
+		if !e.present(row, i) {
+			continue
+		}
+		for _, filter := range s.filters {
+			res, err := sql.EvaluateCondition(ctx, filter, row)
+			if err != nil {
+				return nil, false, err
+			}
+			if !sql.IsTrue(res) {
+				return nil, false, nil
+			}
+		}
+		for _, m := range s.masks {
+			// masks are evaluated against the original row, so they may refer to the columns they mask
+			v, err := m.expr.Eval(ctx, row)
+			if err != nil {
+				return nil, false, err
+			}
+			if v != nil {
+				if v, _, err = m.typ.Convert(ctx, v); err != nil {
+					return nil, false, err
+				}
+			}
+			if masked == nil {
+				masked = row.Copy()
+			}
+			masked[m.idx] = v
+		}
+	}
+	if masked != nil {
+		return masked, true, nil
+	}
+	return row, true, nil
+}
+
+// present returns whether the version of the user table row for side |i| is present in |row|. Added rows of a diff
+// have no from_ version and removed rows have no to_ version.
+func (e *evaluator) present(row sql.Row, i int) bool {
+	if e.diffTypeIdx < 0 {
+		return true
+	}
+	switch row[e.diffTypeIdx] {
+	case "added":
+		return e.sides[i].prefix != "from_"
+	case "removed":
+		return e.sides[i].prefix != "to_"
+	default:
+		return true
+	}
+}
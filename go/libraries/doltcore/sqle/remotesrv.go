@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"google.golang.org/grpc"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
@@ -71,7 +72,7 @@ func (s remotesrvStore) Get(ctx context.Context, path, _ string) (remotesrv.Remo
 	if !ok {
 		return nil, remotesrv.ErrUnimplemented
 	}
-	return hooksFiringRemoteSrvStore{RemoteSrvStore: rss, ddb: ddb, replicaWrite: s.replicaWrite}, nil
+	return hooksFiringRemoteSrvStore{RemoteSrvStore: rss, ddb: ddb, dbName: path, ctxFactory: s.ctxFactory, replicaWrite: s.replicaWrite}, nil
 }
 
 // hooksFiringRemoteSrvStore wraps a RemoteSrvStore and fires CommitHooks
@@ -83,9 +84,16 @@ func (s remotesrvStore) Get(ctx context.Context, path, _ string) (remotesrv.Remo
 // When replicaWrite is true, only hooks that return true from
 // ExecuteForReplicaWrite() are fired. This is used for cluster replication
 // writes on a standby replica, where replication hooks should not fire.
+//
+// Pushes from remote clients are also subject to the server's branch
+// protection rules: a commit that would move a branch which denies force
+// pushes to anything other than a descendant of its current head is
+// rejected. Replica writes are exempt, as a standby must mirror its primary.
 type hooksFiringRemoteSrvStore struct {
 	remotesrv.RemoteSrvStore
 	ddb          *doltdb.DoltDB
+	dbName       string
+	ctxFactory   func(context.Context) (*sql.Context, error)
 	replicaWrite bool
 }
 
@@ -93,8 +101,18 @@ type hooksFiringRemoteSrvStore struct {
 // fires the DoltDB's registered CommitHooks for every ref-typed dataset whose
 // head address changed between |last| and |current|.
 func (s hooksFiringRemoteSrvStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
-	// Snapshot old dataset head addresses before the commit.
-	oldDatasets, _ := s.ddb.DatasetsByRootHash(ctx, last)
+	// Snapshot old dataset head addresses before the commit. Branch
+	// protection cannot be checked without them, so a push fails if they
+	// cannot be read; for replica writes they are only used to fire hooks.
+	oldDatasets, err := s.ddb.DatasetsByRootHash(ctx, last)
+	if !s.replicaWrite {
+		if err != nil {
+			return false, err
+		}
+		if err = s.checkForcePushProtection(ctx, oldDatasets, current); err != nil {
+			return false, err
+		}
+	}
 
 	ok, err := s.RemoteSrvStore.Commit(ctx, current, last)
 	if !ok || err != nil {
@@ -150,6 +168,86 @@ func (s hooksFiringRemoteSrvStore) Commit(ctx context.Context, current, last has
 	return true, nil
 }
 
+// checkForcePushProtection returns an error if the root |current| moves or
+// deletes a branch that is protected from force pushes, unless the branch's
+// new head descends from its head in |oldDatasets|.
+func (s hooksFiringRemoteSrvStore) checkForcePushProtection(ctx context.Context, oldDatasets datas.DatasetsMap, current hash.Hash) error {
+	sqlCtx, err := s.ctxFactory(ctx)
+	if err != nil {
+		return err
+	}
+	newDatasets, err := s.ddb.DatasetsByRootHash(ctx, current)
+	if err != nil {
+		return err
+	}
+	newAddrs := make(map[string]hash.Hash)
+	err = newDatasets.IterAll(ctx, func(id string, addr hash.Hash) error {
+		newAddrs[id] = addr
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return oldDatasets.IterAll(ctx, func(id string, oldAddr hash.Hash) error {
+		if !ref.IsRef(id) {
+			return nil
+		}
+		dref, err := ref.Parse(id)
+		if err != nil || dref.GetType() != ref.BranchRefType {
+			return nil
+		}
+		newAddr, exists := newAddrs[id]
+		if exists && newAddr == oldAddr {
+			return nil
+		}
+		rules, err := branch_control.GetProtectionRules(sqlCtx, s.dbName, dref.GetPath())
+		if err != nil {
+			return err
+		}
+		if !rules.DenyForcePush {
+			return nil
+		}
+		if !exists {
+			return branch_control.ErrBranchProtected.New(rules.Branch, "force pushes are not allowed")
+		}
+		isFastForward, err := s.isFastForward(ctx, oldAddr, newAddr)
+		if err != nil {
+			return err
+		}
+		if !isFastForward {
+			return branch_control.ErrBranchProtected.New(rules.Branch, "force pushes are not allowed")
+		}
+		return nil
+	})
+}
+
+// isFastForward returns whether the commit at |newAddr| descends from the commit at |oldAddr|.
+func (s hooksFiringRemoteSrvStore) isFastForward(ctx context.Context, oldAddr, newAddr hash.Hash) (bool, error) {
+	oldOpt, err := s.ddb.ReadCommit(ctx, oldAddr)
+	if err != nil {
+		return false, err
+	}
+	newOpt, err := s.ddb.ReadCommit(ctx, newAddr)
+	if err != nil {
+		return false, err
+	}
+	oldCm, ok := oldOpt.ToCommit()
+	if !ok {
+		return false, doltdb.ErrGhostCommitEncountered
+	}
+	newCm, ok := newOpt.ToCommit()
+	if !ok {
+		return false, doltdb.ErrGhostCommitEncountered
+	}
+	canFF, err := oldCm.CanFastForwardTo(ctx, newCm)
+	if errors.Is(err, doltdb.ErrUpToDate) {
+		return true, nil
+	} else if errors.Is(err, doltdb.ErrIsAhead) || errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return false, nil
+	}
+	return canFF, err
+}
+
 // In the SQL context, the database provider that we use to expose the
 // remotesapi interface can choose to either create a newly accessed database
 // on first access or to return NotFound. Currently we allow creation in the
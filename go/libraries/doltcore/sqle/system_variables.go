@@ -293,6 +293,20 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltBulkLoad,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemEnumType(dsess.DoltBulkLoad, dsess.BulkLoadOff, dsess.BulkLoadSorted, dsess.BulkLoadExternalSort),
+		Default: dsess.BulkLoadOff,
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltFindRenames,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.DoltFindRenames, 0, 100, false),
+		Default: int64(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    actions.DoltCommitVerificationGroups,
 		Dynamic: true,
@@ -604,6 +618,20 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltBulkLoad,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemEnumType(dsess.DoltBulkLoad, dsess.BulkLoadOff, dsess.BulkLoadSorted, dsess.BulkLoadExternalSort),
+			Default: dsess.BulkLoadOff,
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltFindRenames,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.DoltFindRenames, 0, 100, false),
+			Default: int64(0),
+		},
 		&sql.MysqlSystemVariable{
 			Name:    dsess.DoltAuthorName,
 			Dynamic: true,
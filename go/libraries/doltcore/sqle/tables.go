@@ -557,12 +557,21 @@ func (t *DoltTable) sqlSchema(ctx *sql.Context) sql.PrimaryKeySchema {
 	return sqlSch
 }
 
+// checkFetched returns an error if the data of |table| was left out of a sparse clone, which has the schema of every
+// table but only the data of some of them.
+func (t *DoltTable) checkFetched(ctx *sql.Context, table *doltdb.Table) error {
+	return t.db.DbData().Ddb.CheckTableFetched(ctx, t.TableName(), table)
+}
+
 // Partitions returns the partitions for this table.
 func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	table, err := t.DoltTable(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err = t.checkFetched(ctx, table); err != nil {
+		return nil, err
+	}
 
 	rows, err := table.GetRowData(ctx)
 	if err != nil {
@@ -617,6 +626,9 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	if err != nil {
 		return nil, err
 	}
+	if err = t.checkFetched(ctx, table); err != nil {
+		return nil, err
+	}
 
 	// If we DON'T have an overridden schema, then we can pass in our projected columns as the
 	// ones set in the table that the analyzer has told us need to be projected. This will limit our returned
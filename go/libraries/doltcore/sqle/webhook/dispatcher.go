@@ -0,0 +1,247 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	// DefaultTimeout is used for endpoints which do not configure a timeout.
+	DefaultTimeout = 10 * time.Second
+
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 5 * time.Minute
+
+	EventTypeHeader = "X-Dolt-Event"
+	DeliveryHeader  = "X-Dolt-Delivery"
+	SignatureHeader = "X-Dolt-Signature-256"
+)
+
+// Dispatcher delivers Events to a set of Endpoints. Events are first written to a durable Outbox and
+// are then POSTed by one background worker per endpoint. Each worker delivers its events in order,
+// retrying failed deliveries with exponential backoff before moving on to the next event.
+type Dispatcher struct {
+	outbox    *Outbox
+	endpoints []Endpoint
+	client    *http.Client
+	lgr       *logrus.Logger
+
+	// wake has one entry per endpoint. An optimistic send notifies the endpoint's worker that new
+	// deliveries are available.
+	wake []chan struct{}
+	// backoff computes how long to wait before retrying a delivery which has failed |attempts| times.
+	backoff func(attempts int) time.Duration
+}
+
+// NewDispatcher returns a Dispatcher which persists pending deliveries for |endpoints| in |outboxDir|.
+// Pending deliveries for endpoints that are no longer configured are discarded.
+func NewDispatcher(fs filesys.Filesys, outboxDir string, endpoints []Endpoint, lgr *logrus.Logger) (*Dispatcher, error) {
+	outbox, err := NewOutbox(fs, outboxDir)
+	if err != nil {
+		return nil, err
+	}
+
+	configured := make(map[string]struct{}, len(endpoints))
+	wake := make([]chan struct{}, len(endpoints))
+	for i, e := range endpoints {
+		if _, ok := configured[e.URL]; ok {
+			return nil, fmt.Errorf("webhook endpoint %s is configured more than once", e.URL)
+		}
+		configured[e.URL] = struct{}{}
+		wake[i] = make(chan struct{}, 1)
+	}
+
+	for _, url := range outbox.URLs() {
+		if _, ok := configured[url]; ok {
+			continue
+		}
+		lgr.Warnf("sqle/webhook: discarding %d pending deliveries for unconfigured endpoint %s", outbox.Len(url), url)
+		for d, ok := outbox.Next(url); ok; d, ok = outbox.Next(url) {
+			if err = outbox.Remove(d); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &Dispatcher{
+		outbox:    outbox,
+		endpoints: endpoints,
+		client:    &http.Client{},
+		lgr:       lgr,
+		wake:      wake,
+		backoff:   exponentialBackoff,
+	}, nil
+}
+
+// Enqueue persists a delivery of |e| for every endpoint which is interested in it. Once Enqueue
+// returns, the event will be delivered even if the process restarts before it is sent.
+func (d *Dispatcher) Enqueue(e Event) error {
+	for i, endpoint := range d.endpoints {
+		if !endpoint.Matches(e.Database, e.Branch) {
+			continue
+		}
+		_, err := d.outbox.Put(endpoint.URL, e)
+		if err != nil {
+			return err
+		}
+		select {
+		case d.wake[i] <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run delivers events until |ctx| is canceled. Any deliveries still pending when it returns remain
+// in the outbox and are sent the next time a Dispatcher is run against it.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := range d.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.runEndpoint(ctx, d.endpoints[i], d.wake[i])
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) runEndpoint(ctx context.Context, endpoint Endpoint, wake chan struct{}) {
+	for {
+		delivery, ok := d.outbox.Next(endpoint.URL)
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-wake:
+				continue
+			}
+		}
+
+		retry, err := d.send(ctx, endpoint, delivery)
+		if err == nil || !retry {
+			if err != nil {
+				d.lgr.Warnf("sqle/webhook: abandoning delivery %s to %s: %v", delivery.ID, endpoint.URL, err)
+			}
+			if err = d.outbox.Remove(delivery); err != nil {
+				d.lgr.Errorf("sqle/webhook: could not remove delivery %s from outbox: %v", delivery.ID, err)
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		delivery.Attempts++
+		if endpoint.MaxAttempts > 0 && delivery.Attempts >= endpoint.MaxAttempts {
+			d.lgr.Warnf("sqle/webhook: abandoning delivery %s to %s after %d attempts: %v", delivery.ID, endpoint.URL, delivery.Attempts, err)
+			if err = d.outbox.Remove(delivery); err != nil {
+				d.lgr.Errorf("sqle/webhook: could not remove delivery %s from outbox: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		wait := d.backoff(delivery.Attempts)
+		d.lgr.Infof("sqle/webhook: delivery %s to %s failed, retrying in %v: %v", delivery.ID, endpoint.URL, wait, err)
+		if err = d.outbox.Update(delivery); err != nil {
+			d.lgr.Errorf("sqle/webhook: could not update delivery %s in outbox: %v", delivery.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send makes one attempt to POST |delivery| to |endpoint|. If it fails, |retry| reports whether the
+// failure might be transient.
+func (d *Dispatcher) send(ctx context.Context, endpoint Endpoint, delivery Delivery) (retry bool, err error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return false, err
+	}
+
+	timeout := endpoint.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range endpoint.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "dolt-webhook")
+	req.Header.Set(EventTypeHeader, string(delivery.Event.Type))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected response status %s", resp.Status)
+	// Client errors other than timeouts and rate limiting will not succeed on retry.
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retry, err
+}
+
+// Sign returns the value of the SignatureHeader for |body| signed with |secret|.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func exponentialBackoff(attempts int) time.Duration {
+	wait := initialBackoff
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+type recordingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	events   []Event
+	headers  []http.Header
+	failures int
+	status   int
+}
+
+func newRecordingServer(t *testing.T, failures int, status int) *recordingServer {
+	rs := &recordingServer{failures: failures, status: status}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		if rs.failures > 0 {
+			rs.failures--
+			w.WriteHeader(rs.status)
+			return
+		}
+		var e Event
+		require.NoError(t, json.Unmarshal(body, &e))
+		rs.events = append(rs.events, e)
+		rs.headers = append(rs.headers, r.Header.Clone())
+	}))
+	t.Cleanup(rs.Close)
+	return rs
+}
+
+func (rs *recordingServer) received() []Event {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return append([]Event(nil), rs.events...)
+}
+
+func testEvent(branch, newCommit string) Event {
+	return Event{
+		Type:          EventBranchUpdated,
+		Database:      "db",
+		Branch:        branch,
+		OldCommit:     "old",
+		NewCommit:     newCommit,
+		ChangedTables: []string{"t"},
+		Timestamp:     time.Unix(0, 0).UTC(),
+	}
+}
+
+func runDispatcher(t *testing.T, d *Dispatcher) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestEndpointMatches(t *testing.T) {
+	e := Endpoint{Databases: []string{"db"}, Branches: []string{"main", "release/*"}}
+	assert.True(t, e.Matches("db", "main"))
+	assert.True(t, e.Matches("db", "release/1.0"))
+	assert.False(t, e.Matches("db", "feature"))
+	assert.False(t, e.Matches("other", "main"))
+	assert.True(t, Endpoint{}.Matches("any", "branch"))
+}
+
+func TestDispatcherDeliversInOrder(t *testing.T) {
+	srv := newRecordingServer(t, 0, 0)
+	fs := filesys.EmptyInMemFS("/")
+	d, err := NewDispatcher(fs, "/outbox", []Endpoint{{URL: srv.URL, Secret: "s3cr3t", Headers: map[string]string{"Authorization": "Bearer abc"}}}, logrus.New())
+	require.NoError(t, err)
+
+	for _, c := range []string{"c1", "c2", "c3"} {
+		require.NoError(t, d.Enqueue(testEvent("main", c)))
+	}
+	stop := runDispatcher(t, d)
+	defer stop()
+
+	require.Eventually(t, func() bool { return len(srv.received()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	events := srv.received()
+	assert.Equal(t, "c1", events[0].NewCommit)
+	assert.Equal(t, "c2", events[1].NewCommit)
+	assert.Equal(t, "c3", events[2].NewCommit)
+
+	srv.mu.Lock()
+	h := srv.headers[0]
+	srv.mu.Unlock()
+	assert.Equal(t, "Bearer abc", h.Get("Authorization"))
+	assert.Equal(t, string(EventBranchUpdated), h.Get(EventTypeHeader))
+	body, err := json.Marshal(events[0])
+	require.NoError(t, err)
+	assert.Equal(t, Sign("s3cr3t", body), h.Get(SignatureHeader))
+
+	require.Eventually(t, func() bool { return d.outbox.Len(srv.URL) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcherRetriesFailedDeliveries(t *testing.T) {
+	srv := newRecordingServer(t, 2, http.StatusServiceUnavailable)
+	fs := filesys.EmptyInMemFS("/")
+	d, err := NewDispatcher(fs, "/outbox", []Endpoint{{URL: srv.URL}}, logrus.New())
+	require.NoError(t, err)
+	d.backoff = func(int) time.Duration { return time.Millisecond }
+
+	require.NoError(t, d.Enqueue(testEvent("main", "c1")))
+	stop := runDispatcher(t, d)
+	defer stop()
+
+	require.Eventually(t, func() bool { return len(srv.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcherAbandonsClientErrors(t *testing.T) {
+	srv := newRecordingServer(t, 1, http.StatusBadRequest)
+	fs := filesys.EmptyInMemFS("/")
+	d, err := NewDispatcher(fs, "/outbox", []Endpoint{{URL: srv.URL}}, logrus.New())
+	require.NoError(t, err)
+
+	require.NoError(t, d.Enqueue(testEvent("main", "c1")))
+	require.NoError(t, d.Enqueue(testEvent("main", "c2")))
+	stop := runDispatcher(t, d)
+	defer stop()
+
+	require.Eventually(t, func() bool { return len(srv.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "c2", srv.received()[0].NewCommit)
+}
+
+func TestDispatcherOutboxSurvivesRestart(t *testing.T) {
+	srv := newRecordingServer(t, 0, 0)
+	fs := filesys.EmptyInMemFS("/")
+	endpoints := []Endpoint{{URL: srv.URL, Branches: []string{"main"}}}
+
+	d, err := NewDispatcher(fs, "/outbox", endpoints, logrus.New())
+	require.NoError(t, err)
+	require.NoError(t, d.Enqueue(testEvent("main", "c1")))
+	require.NoError(t, d.Enqueue(testEvent("feature", "c2")))
+	require.NoError(t, d.Enqueue(testEvent("main", "c3")))
+	assert.Equal(t, 2, d.outbox.Len(srv.URL))
+
+	// A new dispatcher picks up where the first one left off.
+	d, err = NewDispatcher(fs, "/outbox", endpoints, logrus.New())
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.outbox.Len(srv.URL))
+	stop := runDispatcher(t, d)
+	defer stop()
+
+	require.Eventually(t, func() bool { return len(srv.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "c1", srv.received()[0].NewCommit)
+	assert.Equal(t, "c3", srv.received()[1].NewCommit)
+
+	// Deliveries for endpoints which are no longer configured are dropped.
+	require.NoError(t, d.Enqueue(testEvent("main", "c4")))
+	stop()
+	d, err = NewDispatcher(fs, "/outbox", nil, logrus.New())
+	require.NoError(t, err)
+	assert.Empty(t, d.outbox.URLs())
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	assert.Equal(t, initialBackoff, exponentialBackoff(1))
+	assert.Equal(t, 2*initialBackoff, exponentialBackoff(2))
+	assert.Equal(t, 4*initialBackoff, exponentialBackoff(3))
+	assert.Equal(t, maxBackoff, exponentialBackoff(100))
+}
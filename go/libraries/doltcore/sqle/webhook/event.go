@@ -0,0 +1,88 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"path"
+	"time"
+)
+
+// EventType describes how a branch head moved.
+type EventType string
+
+const (
+	EventBranchCreated EventType = "branch_created"
+	EventBranchUpdated EventType = "branch_updated"
+	EventBranchDeleted EventType = "branch_deleted"
+)
+
+// Event is the JSON payload POSTed to webhook endpoints whenever a branch head moves.
+type Event struct {
+	Type      EventType `json:"type"`
+	Database  string    `json:"database"`
+	Branch    string    `json:"branch"`
+	OldCommit string    `json:"old_commit"`
+	NewCommit string    `json:"new_commit"`
+	// ChangedTables are the names of the tables whose contents or schema differ between the
+	// old and the new commit.
+	ChangedTables []string  `json:"changed_tables"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Endpoint is a single configured webhook destination.
+type Endpoint struct {
+	// URL is the http or https URL events are POSTed to. It also identifies the endpoint in the outbox.
+	URL string
+	// Databases restricts the endpoint to events from the named databases. Empty matches every database.
+	Databases []string
+	// Branches restricts the endpoint to branches matching any of these path.Match style patterns.
+	// Empty matches every branch.
+	Branches []string
+	// Headers are added to every request sent to the endpoint.
+	Headers map[string]string
+	// Secret, if non-empty, is used to sign the request body with HMAC-SHA256.
+	Secret string
+	// Timeout bounds a single delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is the number of failed attempts after which a delivery is abandoned. Zero
+	// means deliveries are retried until they succeed.
+	MaxAttempts int
+}
+
+// Matches returns true if events for |branch| in |database| should be sent to this endpoint.
+func (e Endpoint) Matches(database, branch string) bool {
+	if len(e.Databases) > 0 {
+		found := false
+		for _, db := range e.Databases {
+			if db == database {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(e.Branches) == 0 {
+		return true
+	}
+	for _, pattern := range e.Branches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const outboxFileExt = ".json"
+
+// Delivery is a pending attempt to send one Event to one Endpoint. Every Delivery is persisted in
+// the Outbox until it is either acknowledged by the endpoint or abandoned.
+type Delivery struct {
+	// ID is unique within an Outbox. IDs sort in the order the deliveries were created.
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	Attempts int    `json:"attempts"`
+	Event    Event  `json:"event"`
+}
+
+// Outbox is a durable, ordered queue of Deliveries. Each Delivery is stored as its own file in a
+// directory, so pending events survive a server restart. Deliveries are queued per endpoint URL and
+// are handed out oldest first.
+type Outbox struct {
+	fs  filesys.Filesys
+	dir string
+
+	mu      sync.Mutex
+	pending map[string][]Delivery
+	lastID  int64
+}
+
+// NewOutbox opens the outbox stored in |dir|, creating the directory if it does not exist, and loads
+// every Delivery that was still pending when it was last used.
+func NewOutbox(fs filesys.Filesys, dir string) (*Outbox, error) {
+	err := fs.MkDirs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	err = fs.Iter(dir, false, func(path string, size int64, isDir bool) (stop bool) {
+		if !isDir && strings.HasSuffix(path, outboxFileExt) {
+			paths = append(paths, path)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	o := &Outbox{fs: fs, dir: dir, pending: make(map[string][]Delivery)}
+	for _, path := range paths {
+		var d Delivery
+		err = filesys.UnmarshalJSONFile(fs, path, &d)
+		if err != nil {
+			return nil, fmt.Errorf("error reading webhook outbox file %s: %w", path, err)
+		}
+		o.pending[d.URL] = append(o.pending[d.URL], d)
+		if id, err := strconv.ParseInt(d.ID, 10, 64); err == nil && id > o.lastID {
+			o.lastID = id
+		}
+	}
+	return o, nil
+}
+
+// Put persists a new Delivery of |e| to |url| and returns it.
+func (o *Outbox) Put(url string, e Event) (Delivery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	d := Delivery{ID: o.nextID(), URL: url, Event: e}
+	err := o.write(d)
+	if err != nil {
+		return Delivery{}, err
+	}
+	o.pending[url] = append(o.pending[url], d)
+	return d, nil
+}
+
+// Next returns the oldest pending Delivery for |url|, if there is one.
+func (o *Outbox) Next(url string) (Delivery, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	q := o.pending[url]
+	if len(q) == 0 {
+		return Delivery{}, false
+	}
+	return q[0], true
+}
+
+// Update persists the new attempt count of a pending Delivery.
+func (o *Outbox) Update(d Delivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	q := o.pending[d.URL]
+	for i := range q {
+		if q[i].ID == d.ID {
+			q[i] = d
+			return o.write(d)
+		}
+	}
+	return fmt.Errorf("webhook delivery %s not found in outbox", d.ID)
+}
+
+// Remove deletes a Delivery from the outbox once it has been delivered or abandoned.
+func (o *Outbox) Remove(d Delivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	q := o.pending[d.URL]
+	for i := range q {
+		if q[i].ID == d.ID {
+			o.pending[d.URL] = append(q[:i:i], q[i+1:]...)
+			if len(o.pending[d.URL]) == 0 {
+				delete(o.pending, d.URL)
+			}
+			return o.fs.DeleteFile(o.path(d.ID))
+		}
+	}
+	return nil
+}
+
+// Len returns the number of pending deliveries for |url|.
+func (o *Outbox) Len(url string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending[url])
+}
+
+// URLs returns the endpoint URLs that have pending deliveries.
+func (o *Outbox) URLs() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	urls := make([]string, 0, len(o.pending))
+	for url := range o.pending {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// nextID returns a new, sortable delivery id. IDs are derived from the wall clock but are forced to
+// be strictly increasing with respect to every delivery still in the outbox.
+func (o *Outbox) nextID() string {
+	id := time.Now().UnixNano()
+	if id <= o.lastID {
+		id = o.lastID + 1
+	}
+	o.lastID = id
+	return fmt.Sprintf("%020d", id)
+}
+
+func (o *Outbox) path(id string) string {
+	return filepath.Join(o.dir, id+outboxFileExt)
+}
+
+// write atomically replaces the file backing |d|.
+func (o *Outbox) write(d Delivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	tmp := o.path(d.ID) + ".tmp"
+	err = o.fs.WriteFile(tmp, data, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return o.fs.MoveFile(tmp, o.path(d.ID))
+}
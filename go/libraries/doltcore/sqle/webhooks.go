@@ -0,0 +1,278 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhook"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Webhooks let a running SQL server notify external systems whenever
+// a branch head moves.
+//
+// A WebhookController is created for a running SQL Engine when
+// webhooks are configured. Post Commit Hooks are installed on every
+// database in the DoltDatabaseProvider for the SQL Engine. When a
+// branch head moves, the hook computes the set of changed tables and
+// writes an event to the durable outbox of a webhook.Dispatcher. A
+// background thread delivers those events to the configured endpoints.
+
+type WebhookController struct {
+	dispatcher *webhook.Dispatcher
+	lgr        *logrus.Logger
+	hooks      map[string]*webhookCommitHook
+	mu         sync.Mutex
+}
+
+// NewWebhookController returns a WebhookController delivering to |endpoints|. Pending deliveries are
+// persisted in |outboxDir| on |fs|.
+func NewWebhookController(fs filesys.Filesys, outboxDir string, endpoints []webhook.Endpoint, lgr *logrus.Logger) (*WebhookController, error) {
+	dispatcher, err := webhook.NewDispatcher(fs, outboxDir, endpoints, lgr)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookController{
+		dispatcher: dispatcher,
+		lgr:        lgr,
+		hooks:      make(map[string]*webhookCommitHook),
+	}, nil
+}
+
+// During engine initialization, this should be called to start the
+// background thread which delivers webhook events.
+func (c *WebhookController) RunBackgroundThread(threads *sql.BackgroundThreads) error {
+	return threads.Add("webhook_delivery_thread", c.dispatcher.Run)
+}
+
+// During engine initialization, called on the original set of
+// databases to install webhook commit hooks.
+func (c *WebhookController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		ddb := denv.DoltDB(ctx)
+		hook, err := c.newCommitHook(ctx, db.Name(), ddb)
+		if err != nil {
+			return err
+		}
+		ddb.PrependCommitHooks(ctx, hook)
+	}
+	return nil
+}
+
+func (c *WebhookController) InitDatabaseHook() InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		ddb := env.DoltDB(ctx)
+		hook, err := c.newCommitHook(ctx, name, ddb)
+		if err != nil {
+			return err
+		}
+		ddb.PrependCommitHooks(ctx, hook)
+		return nil
+	}
+}
+
+func (c *WebhookController) DropDatabaseHook() DropDatabaseHook {
+	return func(_ *sql.Context, name string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		hook := c.hooks[name]
+		if hook != nil {
+			hook.stop()
+			delete(c.hooks, name)
+		}
+	}
+}
+
+// newCommitHook returns a hook for database |name|. The hook starts out knowing the current head of
+// every branch, so that the first event for each branch carries its previous commit.
+func (c *WebhookController) newCommitHook(ctx context.Context, name string, ddb *doltdb.DoltDB) (*webhookCommitHook, error) {
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(map[string]hash.Hash, len(branches))
+	for _, b := range branches {
+		heads[b.Ref.String()] = b.Hash
+	}
+
+	ret := &webhookCommitHook{
+		c:     c,
+		name:  name,
+		heads: heads,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev := c.hooks[name]; prev != nil {
+		prev.stop()
+	}
+	c.hooks[name] = ret
+	return ret, nil
+}
+
+// The doltdb.CommitHook which watches for branch head movements and
+// enqueues webhook events for them.
+type webhookCommitHook struct {
+	c    *WebhookController
+	name string
+
+	mu sync.Mutex
+	// heads is the last observed head of each branch, keyed by dataset id.
+	heads   map[string]hash.Hash
+	stopped bool
+}
+
+var _ doltdb.CommitHook = (*webhookCommitHook)(nil)
+
+// Execute implements CommitHook. It enqueues an event for every branch whose head has moved since
+// the last time it was observed.
+func (h *webhookCommitHook) Execute(ctx context.Context, ds datas.Dataset, db *doltdb.DoltDB) (func(context.Context) error, error) {
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	dref, err := ref.Parse(ds.ID())
+	if err != nil || dref.GetType() != ref.BranchRefType {
+		return nil, nil
+	}
+	newHead, _ := ds.MaybeHeadAddr()
+
+	// Holding the lock while the event is written keeps the outbox in the same order as the
+	// observed heads.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return nil, nil
+	}
+	oldHead := h.heads[ds.ID()]
+	if oldHead == newHead {
+		return nil, nil
+	}
+
+	event := webhook.Event{
+		Type:          webhook.EventBranchUpdated,
+		Database:      h.name,
+		Branch:        dref.GetPath(),
+		OldCommit:     hashString(oldHead),
+		NewCommit:     hashString(newHead),
+		ChangedTables: []string{},
+		Timestamp:     time.Now().UTC(),
+	}
+	if oldHead.IsEmpty() {
+		event.Type = webhook.EventBranchCreated
+	} else if newHead.IsEmpty() {
+		event.Type = webhook.EventBranchDeleted
+	} else {
+		changed, err := changedTablesBetweenCommits(ctx, db, oldHead, newHead)
+		if err != nil {
+			h.c.lgr.Warnf("sqle/webhook: could not compute changed tables for %s/%s: %v", h.name, event.Branch, err)
+		} else {
+			event.ChangedTables = changed
+		}
+	}
+
+	err = h.c.dispatcher.Enqueue(event)
+	if err != nil {
+		h.c.lgr.Errorf("sqle/webhook: could not enqueue event for %s/%s: %v", h.name, event.Branch, err)
+		return nil, err
+	}
+
+	if newHead.IsEmpty() {
+		delete(h.heads, ds.ID())
+	} else {
+		h.heads[ds.ID()] = newHead
+	}
+	return nil, nil
+}
+
+func (h *webhookCommitHook) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+}
+
+func (*webhookCommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
+
+func (*webhookCommitHook) ExecuteForReplicaWrite() bool {
+	return false
+}
+
+// changedTablesBetweenCommits returns the sorted names of the tables whose hashes differ between the
+// roots of |from| and |to|.
+func changedTablesBetweenCommits(ctx context.Context, db *doltdb.DoltDB, from, to hash.Hash) ([]string, error) {
+	fromHashes, err := tableHashesForCommit(ctx, db, from)
+	if err != nil {
+		return nil, err
+	}
+	toHashes, err := tableHashesForCommit(ctx, db, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]string, 0)
+	for name, h := range fromHashes {
+		if toH, ok := toHashes[name]; !ok || toH != h {
+			changed = append(changed, name.String())
+		}
+	}
+	for name := range toHashes {
+		if _, ok := fromHashes[name]; !ok {
+			changed = append(changed, name.String())
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func tableHashesForCommit(ctx context.Context, db *doltdb.DoltDB, h hash.Hash) (map[doltdb.TableName]hash.Hash, error) {
+	optCmt, err := db.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return doltdb.MapTableHashes(ctx, root)
+}
+
+func hashString(h hash.Hash) string {
+	if h.IsEmpty() {
+		return ""
+	}
+	return h.String()
+}
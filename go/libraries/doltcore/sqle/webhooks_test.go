@@ -0,0 +1,127 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhook"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestWebhookCommitHook(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var events []webhook.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e webhook.Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}))
+	defer srv.Close()
+	received := func() []webhook.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhook.Event(nil), events...)
+	}
+
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_DOLT, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	c, err := NewWebhookController(filesys.EmptyInMemFS("/"), "/outbox", []webhook.Endpoint{{URL: srv.URL}}, logrus.New())
+	require.NoError(t, err)
+	hook, err := c.newCommitHook(ctx, "mydb", ddb)
+	require.NoError(t, err)
+	ddb.PrependCommitHooks(ctx, hook)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.dispatcher.Run(runCtx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	cs, err := doltdb.NewCommitSpec("main")
+	require.NoError(t, err)
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	initial, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	initialHash, err := initial.HashOf()
+	require.NoError(t, err)
+
+	// Committing a new table to main moves its head.
+	root, err := initial.GetRootValue(ctx)
+	require.NoError(t, err)
+	tSchema := createTestSchema(t)
+	tbl, err := createHooksTestTable(ddb.ValueReadWriter(), ddb.NodeStore(), tSchema, createTestRowData(t, ddb.ValueReadWriter(), ddb.NodeStore(), tSchema))
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, doltdb.TableName{Name: "test"}, tbl)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "Sample data")
+	require.NoError(t, err)
+	cm, err := ddb.Commit(ctx, valHash, ref.NewBranchRef("main"), meta)
+	require.NoError(t, err)
+	cmHash, err := cm.HashOf()
+	require.NoError(t, err)
+
+	// Creating and deleting a branch.
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm, nil))
+	require.NoError(t, ddb.DeleteBranch(ctx, ref.NewBranchRef("feature"), nil))
+
+	require.Eventually(t, func() bool { return len(received()) == 3 }, 10*time.Second, 10*time.Millisecond)
+	evs := received()
+
+	assert.Equal(t, webhook.EventBranchUpdated, evs[0].Type)
+	assert.Equal(t, "mydb", evs[0].Database)
+	assert.Equal(t, "main", evs[0].Branch)
+	assert.Equal(t, initialHash.String(), evs[0].OldCommit)
+	assert.Equal(t, cmHash.String(), evs[0].NewCommit)
+	assert.Equal(t, []string{"test"}, evs[0].ChangedTables)
+
+	assert.Equal(t, webhook.EventBranchCreated, evs[1].Type)
+	assert.Equal(t, "feature", evs[1].Branch)
+	assert.Equal(t, "", evs[1].OldCommit)
+	assert.Equal(t, cmHash.String(), evs[1].NewCommit)
+
+	assert.Equal(t, webhook.EventBranchDeleted, evs[2].Type)
+	assert.Equal(t, "feature", evs[2].Branch)
+	assert.Equal(t, cmHash.String(), evs[2].OldCommit)
+	assert.Equal(t, "", evs[2].NewCommit)
+}
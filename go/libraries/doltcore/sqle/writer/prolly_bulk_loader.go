@@ -0,0 +1,265 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/prolly/sort"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	bulkSortBatchSize = 32 * 1024 * 1024 // 32MB
+	bulkSortFileMax   = 128
+)
+
+// prollyBulkLoader builds the clustered index of an empty table bottom-up. Rather than editing the index in place,
+// rows are appended to a tree.Chunker in primary key order, so that each chunk of the new index is written exactly
+// once.
+//
+// Without a sorter, rows must be inserted in primary key order. Duplicate keys are detected as they are inserted, and
+// the most recently inserted row is held back from the chunker so that INSERT IGNORE, REPLACE and ON DUPLICATE KEY
+// UPDATE can still act on it. With a sorter, rows are sorted externally before they are chunked, and duplicate keys
+// are only detected when the index is built.
+type prollyBulkLoader struct {
+	primary prollyIndexWriter
+	keyDesc *val.TupleDesc
+	chunker tree.Chunker
+
+	// lastKey and lastVal are the last row added to |chunker|
+	lastKey val.Tuple
+	lastVal val.Tuple
+	// pendingKey and pendingVal are the most recently inserted row, if it has not been added to |chunker|
+	pendingKey val.Tuple
+	pendingVal val.Tuple
+
+	sorter  *sort.TupleSorter
+	sortErr error
+}
+
+func newProllyBulkLoader(ctx context.Context, primary prollyIndexWriter, externalSort bool) (*prollyBulkLoader, error) {
+	ns := primary.mut.NodeStore()
+	serializer := message.NewProllyMapSerializer(primary.valBld.Desc, ns.Pool())
+	chunker, err := tree.NewEmptyChunker(ctx, ns, serializer)
+	if err != nil {
+		return nil, err
+	}
+
+	bl := &prollyBulkLoader{
+		primary: primary,
+		keyDesc: primary.keyBld.Desc,
+		chunker: chunker,
+	}
+	if externalSort {
+		bl.sorter = sort.NewTupleSorter(bulkSortBatchSize, bulkSortFileMax, func(l, r val.Tuple) bool {
+			if bl.sortErr != nil {
+				return false
+			}
+			cmp, err := bl.keyDesc.Compare(ctx, val.Tuple(l.GetField(0)), val.Tuple(r.GetField(0)))
+			if err != nil {
+				bl.sortErr = err
+				return false
+			}
+			return cmp < 0
+		}, tempfiles.MovableTempFileProvider)
+	}
+	return bl, nil
+}
+
+// insert adds |sqlRow| to the index. It returns false if the row could not be added because it is out of order, in
+// which case the caller must finish the bulk load and insert the row as a regular edit.
+func (bl *prollyBulkLoader) insert(ctx context.Context, sqlRow sql.Row) (bool, error) {
+	k, err := bl.primary.keyFromRow(ctx, sqlRow)
+	if err != nil {
+		return false, err
+	}
+	v, err := bl.primary.valFromRow(ctx, sqlRow)
+	if err != nil {
+		return false, err
+	}
+
+	if bl.sorter != nil {
+		if err = bl.sorter.Insert(ctx, val.NewTuple(sharePool, k, v)); err != nil {
+			return false, err
+		}
+		return true, bl.sortErr
+	}
+
+	prevKey, prevVal := bl.pendingKey, bl.pendingVal
+	if prevKey == nil {
+		prevKey, prevVal = bl.lastKey, bl.lastVal
+	}
+	if prevKey != nil {
+		cmp, err := bl.keyDesc.Compare(ctx, k, prevKey)
+		if err != nil {
+			return false, err
+		} else if cmp < 0 {
+			return false, nil
+		} else if cmp == 0 {
+			return false, bl.duplicateKeyError(ctx, sqlRow, prevKey, prevVal)
+		}
+	}
+
+	if err = bl.addPending(ctx); err != nil {
+		return false, err
+	}
+	bl.pendingKey, bl.pendingVal = k, v
+	return true, nil
+}
+
+// update applies an update of the most recently inserted row that does not change its key. It returns false for any
+// other update, in which case the caller must finish the bulk load and apply the update as a regular edit.
+func (bl *prollyBulkLoader) update(ctx context.Context, oldRow, newRow sql.Row) (bool, error) {
+	if ok, err := bl.isPending(ctx, oldRow); err != nil || !ok {
+		return false, err
+	}
+	if ok, err := bl.isPending(ctx, newRow); err != nil || !ok {
+		return false, err
+	}
+	v, err := bl.primary.valFromRow(ctx, newRow)
+	if err != nil {
+		return false, err
+	}
+	bl.pendingVal = v
+	return true, nil
+}
+
+// delete removes the most recently inserted row. It returns false for any other row, in which case the caller must
+// finish the bulk load and apply the delete as a regular edit.
+func (bl *prollyBulkLoader) delete(ctx context.Context, sqlRow sql.Row) (bool, error) {
+	if ok, err := bl.isPending(ctx, sqlRow); err != nil || !ok {
+		return false, err
+	}
+	bl.pendingKey, bl.pendingVal = nil, nil
+	return true, nil
+}
+
+func (bl *prollyBulkLoader) isPending(ctx context.Context, sqlRow sql.Row) (bool, error) {
+	if bl.sorter != nil || bl.pendingKey == nil {
+		return false, nil
+	}
+	k, err := bl.primary.keyFromRow(ctx, sqlRow)
+	if err != nil {
+		return false, err
+	}
+	cmp, err := bl.keyDesc.Compare(ctx, k, bl.pendingKey)
+	return cmp == 0, err
+}
+
+func (bl *prollyBulkLoader) addPending(ctx context.Context) error {
+	if bl.pendingKey == nil {
+		return nil
+	}
+	if err := bl.chunker.AddPair(ctx, tree.Item(bl.pendingKey), tree.Item(bl.pendingVal)); err != nil {
+		return err
+	}
+	bl.lastKey, bl.lastVal = bl.pendingKey, bl.pendingVal
+	bl.pendingKey, bl.pendingVal = nil, nil
+	return nil
+}
+
+// finish completes the index and returns it.
+func (bl *prollyBulkLoader) finish(ctx context.Context) (prolly.Map, error) {
+	if bl.sorter != nil {
+		if err := bl.chunkSorted(ctx); err != nil {
+			return prolly.Map{}, err
+		}
+	} else if err := bl.addPending(ctx); err != nil {
+		return prolly.Map{}, err
+	}
+
+	root, err := bl.chunker.Done(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return prolly.NewMap(root, bl.primary.mut.NodeStore(), bl.keyDesc, bl.primary.valBld.Desc), nil
+}
+
+// chunkSorted adds the externally sorted rows to the chunker, checking for duplicate keys.
+func (bl *prollyBulkLoader) chunkSorted(ctx context.Context) error {
+	sorted, err := bl.sorter.Flush(ctx)
+	if err != nil {
+		return err
+	}
+	if bl.sortErr != nil {
+		return bl.sortErr
+	}
+	defer sorted.Close()
+
+	iter, err := sorted.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for {
+		pair, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		k, v := val.Tuple(pair.GetField(0)), val.Tuple(pair.GetField(1))
+		if bl.lastKey != nil {
+			cmp, err := bl.keyDesc.Compare(ctx, k, bl.lastKey)
+			if err != nil {
+				return err
+			} else if cmp == 0 {
+				sqlRow, err := bl.primary.rowFromPair(ctx, k, v)
+				if err != nil {
+					return err
+				}
+				return bl.duplicateKeyError(ctx, sqlRow, bl.lastKey, bl.lastVal)
+			}
+		}
+
+		if err = bl.chunker.AddPair(ctx, tree.Item(k), tree.Item(v)); err != nil {
+			return err
+		}
+		bl.lastKey, bl.lastVal = k, v
+	}
+}
+
+// duplicateKeyError returns the error for inserting |sqlRow| when the row |existingKey|, |existingVal| has the same key.
+func (bl *prollyBulkLoader) duplicateKeyError(ctx context.Context, sqlRow sql.Row, existingKey, existingVal val.Tuple) error {
+	key := make(sql.Row, len(bl.primary.keyMap))
+	for to := range bl.primary.keyMap {
+		key[to] = sqlRow[bl.primary.keyMap.MapOrdinal(to)]
+	}
+	keyStr := FormatKeyForUniqKeyErr(ctx, existingKey, bl.keyDesc, key)
+
+	existing, err := bl.primary.rowFromPair(ctx, existingKey, existingVal)
+	if err != nil {
+		return err
+	}
+	return sql.NewUniqueKeyErr(keyStr, true, existing)
+}
+
+// close releases the temporary files of the sorter. Chunks that were already written are left for garbage collection.
+func (bl *prollyBulkLoader) close() {
+	if bl.sorter != nil {
+		bl.sorter.Close()
+	}
+}
@@ -78,6 +78,15 @@ func (n *prollyFkIndexer) Partitions(ctx *sql.Context) (sql.PartitionIter, error
 
 // PartitionRows implements the interface sql.Table.
 func (n *prollyFkIndexer) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	if n.writer.bulk != nil {
+		// Rows given to a bulk load are not in the index writers until the load finishes, so a statement that reads the
+		// table it is loading finishes the bulk load and writes its remaining rows one at a time.
+		ctx.Warn(1105, "table %s is read by the statement, falling back to row-by-row writes", n.writer.tblName.Name)
+		if err := n.writer.finishBulkLoad(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	var idxWriter indexWriter
 	var ok bool
 	if n.index.IsPrimaryKey() {
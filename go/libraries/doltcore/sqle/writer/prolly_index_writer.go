@@ -153,19 +153,23 @@ func (m prollyIndexWriter) ValidateKeyViolations(ctx context.Context, sqlRow sql
 	return nil
 }
 
+func (m prollyIndexWriter) valFromRow(ctx context.Context, sqlRow sql.Row) (val.Tuple, error) {
+	for to := range m.valMap {
+		from := m.valMap.MapOrdinal(to)
+		if err := tree.PutField(ctx, m.mut.NodeStore(), m.valBld, to, sqlRow[from]); err != nil {
+			return nil, err
+		}
+	}
+	return m.valBld.Build(ctx, sharePool)
+}
+
 func (m prollyIndexWriter) Insert(ctx context.Context, sqlRow sql.Row) error {
 	k, err := m.keyFromRow(ctx, sqlRow)
 	if err != nil {
 		return err
 	}
 
-	for to := range m.valMap {
-		from := m.valMap.MapOrdinal(to)
-		if err := tree.PutField(ctx, m.mut.NodeStore(), m.valBld, to, sqlRow[from]); err != nil {
-			return err
-		}
-	}
-	v, err := m.valBld.Build(ctx, sharePool)
+	v, err := m.valFromRow(ctx, sqlRow)
 	if err != nil {
 		return err
 	}
@@ -257,30 +261,41 @@ func (m prollyIndexWriter) errForSecondaryUniqueKeyError(ctx context.Context, er
 // uniqueKeyError builds a sql.UniqueKeyError. It fetches the existing row using
 // |key| and passes it as the |existing| row.
 func (m prollyIndexWriter) uniqueKeyError(ctx context.Context, keyStr string, key val.Tuple, isPk bool) error {
-	existing := make(sql.Row, len(m.keyMap)+len(m.valMap))
-
+	var existing sql.Row
 	_ = m.mut.Get(ctx, key, func(key, value val.Tuple) (err error) {
-		kd := m.keyBld.Desc
-		for from := range m.keyMap {
-			to := m.keyMap.MapOrdinal(from)
-			if existing[to], err = tree.GetField(ctx, kd, from, key, m.mut.NodeStore()); err != nil {
-				return err
-			}
-		}
-
-		vd := m.valBld.Desc
-		for from := range m.valMap {
-			to := m.valMap.MapOrdinal(from)
-			if existing[to], err = tree.GetField(ctx, vd, from, value, m.mut.NodeStore()); err != nil {
-				return err
-			}
-		}
-		return
+		existing, err = m.rowFromPair(ctx, key, value)
+		return err
 	})
+	if existing == nil {
+		existing = make(sql.Row, len(m.keyMap)+len(m.valMap))
+	}
 
 	return sql.NewUniqueKeyErr(keyStr, isPk, existing)
 }
 
+// rowFromPair returns the sql.Row stored as the pair |key|, |value|.
+func (m prollyIndexWriter) rowFromPair(ctx context.Context, key, value val.Tuple) (sql.Row, error) {
+	row := make(sql.Row, len(m.keyMap)+len(m.valMap))
+	var err error
+
+	kd := m.keyBld.Desc
+	for from := range m.keyMap {
+		to := m.keyMap.MapOrdinal(from)
+		if row[to], err = tree.GetField(ctx, kd, from, key, m.mut.NodeStore()); err != nil {
+			return nil, err
+		}
+	}
+
+	vd := m.valBld.Desc
+	for from := range m.valMap {
+		to := m.valMap.MapOrdinal(from)
+		if row[to], err = tree.GetField(ctx, vd, from, value, m.mut.NodeStore()); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
 type prollySecondaryIndexWriter struct {
 	mut prolly.MutableMapInterface
 	// pkBld builds key tuples for primary key index
@@ -16,8 +16,10 @@ package writer
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
@@ -25,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/pool"
 	"github.com/dolthub/dolt/go/store/prolly"
@@ -58,6 +61,12 @@ type prollyTableWriter struct {
 	aiSet      bool // True when an INSERT/UPDATE affects the auto increment value
 
 	errEncountered error
+
+	// bulk is non-nil while the clustered index is being built by a prollyBulkLoader, see startBulkLoad
+	bulk        *prollyBulkLoader
+	bulkChecked bool
+	// bulkBase is the table as it was before the bulk load started, restored if the statement fails
+	bulkBase *doltdb.Table
 }
 
 var _ dsess.TableWriter = &prollyTableWriter{}
@@ -152,6 +161,28 @@ func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, schS
 
 // Insert implements TableWriter.
 func (w *prollyTableWriter) Insert(ctx *sql.Context, sqlRow sql.Row) (err error) {
+	if !w.bulkChecked {
+		w.bulkChecked = true
+		if err = w.startBulkLoad(ctx); err != nil {
+			return err
+		}
+	}
+	if w.bulk != nil {
+		ok, err := w.bulk.insert(ctx, sqlRow)
+		if err != nil {
+			return err
+		}
+		if ok {
+			w.aiSet = true
+			w.aiTracker.Next(ctx, w.tblName.Name, sqlRow)
+			return nil
+		}
+		ctx.Warn(1105, "rows for table %s are not sorted by primary key, falling back to row-by-row writes", w.tblName.Name)
+		if err = w.finishBulkLoad(ctx); err != nil {
+			return err
+		}
+	}
+
 	if err = w.primary.ValidateKeyViolations(ctx, sqlRow); err != nil {
 		return err
 	}
@@ -183,6 +214,19 @@ func (w *prollyTableWriter) Insert(ctx *sql.Context, sqlRow sql.Row) (err error)
 
 // Update implements TableWriter.
 func (w *prollyTableWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) (err error) {
+	if w.bulk != nil {
+		ok, err := w.bulk.update(ctx, oldRow, newRow)
+		if err != nil {
+			return err
+		} else if ok {
+			w.aiSet = true
+			return nil
+		}
+		if err = w.finishBulkLoad(ctx); err != nil {
+			return err
+		}
+	}
+
 	for _, wr := range w.secondary {
 		if err := wr.Update(ctx, oldRow, newRow); err != nil {
 			if uke, ok := err.(secondaryUniqueKeyError); ok {
@@ -201,6 +245,16 @@ func (w *prollyTableWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.
 
 // Delete implements TableWriter.
 func (w *prollyTableWriter) Delete(ctx *sql.Context, sqlRow sql.Row) (err error) {
+	if w.bulk != nil {
+		ok, err := w.bulk.delete(ctx, sqlRow)
+		if err != nil || ok {
+			return err
+		}
+		if err = w.finishBulkLoad(ctx); err != nil {
+			return err
+		}
+	}
+
 	for _, wr := range w.secondary {
 		if err := wr.Delete(ctx, sqlRow); err != nil {
 			return err
@@ -217,6 +271,8 @@ func (w *prollyTableWriter) StatementBegin(ctx *sql.Context) {
 	// Table writers are reused in a session, which means we need to reset the error state resulting from previous
 	// errors on every new statement.
 	w.errEncountered = nil
+	w.bulkChecked = false
+	w.bulkBase = nil
 	return
 }
 
@@ -225,6 +281,9 @@ func (w *prollyTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered er
 	if _, ignored := errorEncountered.(sql.IgnorableError); !ignored {
 		w.errEncountered = errorEncountered
 	}
+	if w.bulkBase != nil {
+		return w.abortBulkLoad(ctx)
+	}
 	err := w.primary.Discard(ctx)
 	for _, secondary := range w.secondary {
 		sErr := secondary.Discard(ctx)
@@ -237,6 +296,17 @@ func (w *prollyTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered er
 
 // StatementComplete implements TableWriter.
 func (w *prollyTableWriter) StatementComplete(ctx *sql.Context) error {
+	if w.bulk != nil {
+		if err := w.finishBulkLoad(ctx); err != nil {
+			// the rows of this statement are lost, so make sure Close does not flush what remains
+			w.errEncountered = err
+			if aErr := w.abortBulkLoad(ctx); aErr != nil {
+				return aErr
+			}
+			return err
+		}
+	}
+	w.bulkBase = nil
 	err := w.primary.Commit(ctx)
 	for _, secondary := range w.secondary {
 		sErr := secondary.Commit(ctx)
@@ -247,6 +317,147 @@ func (w *prollyTableWriter) StatementComplete(ctx *sql.Context) error {
 	return err
 }
 
+// startBulkLoad begins a bulk load of the table if the dolt_bulk_load session variable enables it and the table
+// qualifies. Only empty tables with a primary key are bulk loaded, and tables with a self-referential foreign key are
+// excluded, since their foreign key checks read rows of the statement back through this writer. Any other read of the
+// statement's rows through this writer, such as a foreign key check of a row inserted by a trigger, finishes the bulk
+// load first (see prollyFkIndexer). Reads of the table through the session root do not see the rows of the current
+// statement whether or not it is bulk loaded.
+//
+// Primary key violations are reported as rows are inserted in sorted mode. With an external sort they are only found
+// when the statement completes, as are unique secondary index violations, so statements which handle duplicates row
+// by row are written row by row instead, see handlesDuplicatesPerRow.
+func (w *prollyTableWriter) startBulkLoad(ctx *sql.Context) error {
+	mode, err := ctx.GetSessionVariable(ctx, dsess.DoltBulkLoad)
+	if err != nil {
+		return err
+	}
+	if mode == nil || mode.(string) == dsess.BulkLoadOff || schema.IsKeyless(w.sch) {
+		return nil
+	}
+
+	priMap, err := w.primary.Map(ctx)
+	if err != nil {
+		return err
+	}
+	if cnt, err := priMap.Count(); err != nil || cnt > 0 {
+		return err
+	}
+
+	fkc, err := w.writeSess.GetWorkingSet().WorkingRoot().GetForeignKeyCollection(ctx)
+	if err != nil {
+		return err
+	}
+	declared, _ := fkc.KeysForTable(w.tblName)
+	for _, fk := range declared {
+		if fk.IsSelfReferential() {
+			return nil
+		}
+	}
+
+	// unique secondary indexes are only checked once the statement completes, so they would fail a statement that
+	// ignores duplicates rather than the offending row. Sorted bulk loads preserve row-level duplicate handling.
+	externalSort := strings.EqualFold(mode.(string), dsess.BulkLoadExternalSort)
+	if externalSort && handlesDuplicatesPerRow(ctx) {
+		return nil
+	}
+	if !externalSort {
+		for _, secondary := range w.secondary {
+			if secondary.(prollySecondaryIndexWriter).unique {
+				return nil
+			}
+		}
+	}
+
+	// materialize any edits from earlier statements, so that the loader starts from a clean table
+	tbl, err := w.table(ctx)
+	if err != nil {
+		return err
+	}
+	if err = w.Reset(ctx, tbl); err != nil {
+		return err
+	}
+
+	w.bulk, err = newProllyBulkLoader(ctx, w.primary.(prollyIndexWriter), externalSort)
+	if err != nil {
+		return err
+	}
+	w.bulkBase = tbl
+	return nil
+}
+
+// handlesDuplicatesPerRow returns whether the statement being run handles duplicate keys row by row, as INSERT IGNORE,
+// REPLACE, INSERT ... ON DUPLICATE KEY UPDATE and LOAD DATA with IGNORE or REPLACE do. Any statement other than an
+// INSERT or LOAD DATA, such as a CALL whose procedure inserts rows, is assumed to, since the statements it runs are
+// not known. Inserts built without a query, such as those of table import, choose their bulk load mode themselves.
+func handlesDuplicatesPerRow(ctx *sql.Context) bool {
+	query := ctx.Query()
+	if query == "" {
+		return false
+	}
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return true
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return stmt.Action == sqlparser.ReplaceStr || stmt.Ignore != "" || len(stmt.OnDup) > 0
+	case *sqlparser.Load:
+		return stmt.IgnoreOrReplace != ""
+	default:
+		return true
+	}
+}
+
+// finishBulkLoad builds the clustered index from the rows given to the bulk loader, builds each secondary index from
+// it, and resets the writer to the resulting table. Any further edits of the statement are applied row by row.
+func (w *prollyTableWriter) finishBulkLoad(ctx *sql.Context) error {
+	bulk := w.bulk
+	w.bulk = nil
+	defer bulk.close()
+
+	priMap, err := bulk.finish(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, err := w.tbl.UpdateRows(ctx, durable.IndexFromProllyMap(priMap))
+	if err != nil {
+		return err
+	}
+
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	for name, secondary := range w.secondary {
+		idx := w.sch.Indexes().GetByName(name)
+		predicate := secondary.(prollySecondaryIndexWriter).predicate
+		secIdx, err := creation.BuildSecondaryProllyIndex(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), w.sch, w.tblName.Name, idx, priMap, predicate)
+		if err != nil {
+			return err
+		}
+		if idxSet, err = idxSet.PutIndex(ctx, name, secIdx); err != nil {
+			return err
+		}
+	}
+	if tbl, err = tbl.SetIndexSet(ctx, idxSet); err != nil {
+		return err
+	}
+
+	return w.Reset(ctx, tbl)
+}
+
+// abortBulkLoad discards a bulk load and any edits made after it, restoring the table as it was before the statement.
+func (w *prollyTableWriter) abortBulkLoad(ctx *sql.Context) error {
+	if w.bulk != nil {
+		w.bulk.close()
+		w.bulk = nil
+	}
+	base := w.bulkBase
+	w.bulkBase = nil
+	return w.Reset(ctx, base)
+}
+
 // IndexedAccess implements sql.IndexAddressable.
 func (w *prollyTableWriter) IndexedAccess(_ *sql.Context, i sql.IndexLookup) sql.IndexedTable {
 	idx := index.DoltIndexFromSqlIndex(i.Index)
@@ -0,0 +1,229 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/decimal128"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+type bufferCloser struct {
+	*bytes.Buffer
+}
+
+func (bufferCloser) Close() error {
+	return nil
+}
+
+func mustColumn(t *testing.T, name string, tag uint64, sqlType sql.Type, isPk bool) schema.Column {
+	ti, err := typeinfo.FromSqlType(sqlType)
+	require.NoError(t, err)
+	var constraints []schema.ColConstraint
+	if isPk {
+		constraints = append(constraints, schema.NotNullConstraint{})
+	}
+	col, err := schema.NewColumnWithTypeInfo(name, tag, ti, isPk, "", false, "", constraints...)
+	require.NoError(t, err)
+	return col
+}
+
+func testSchema(t *testing.T) schema.Schema {
+	cols := []schema.Column{
+		mustColumn(t, "id", 0, gmstypes.Int64, true),
+		mustColumn(t, "i8", 1, gmstypes.Int8, false),
+		mustColumn(t, "u64", 2, gmstypes.Uint64, false),
+		mustColumn(t, "f", 3, gmstypes.Float32, false),
+		mustColumn(t, "d", 4, gmstypes.Float64, false),
+		mustColumn(t, "dec", 5, gmstypes.MustCreateDecimalType(10, 2), false),
+		mustColumn(t, "wide_dec", 6, gmstypes.MustCreateDecimalType(50, 10), false),
+		mustColumn(t, "dt", 7, gmstypes.DatetimeMaxPrecision, false),
+		mustColumn(t, "day", 8, gmstypes.Date, false),
+		mustColumn(t, "tm", 9, gmstypes.Time, false),
+		mustColumn(t, "name", 10, gmstypes.LongText, false),
+		mustColumn(t, "color", 11, gmstypes.MustCreateEnumType([]string{"red", "green", "blue"}, sql.Collation_Default), false),
+		mustColumn(t, "flags", 12, gmstypes.MustCreateSetType([]string{"a", "b", "c"}, sql.Collation_Default), false),
+		mustColumn(t, "doc", 13, gmstypes.JSON, false),
+		mustColumn(t, "geom", 14, gmstypes.GeometryType{}, false),
+		mustColumn(t, "bits", 15, gmstypes.MustCreateBitType(8), false),
+		mustColumn(t, "blob", 16, gmstypes.LongBlob, false),
+	}
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	require.NoError(t, err)
+	return sch
+}
+
+func TestArrowRoundTrip(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	sch := testSchema(t)
+
+	point := gmstypes.Point{X: 1, Y: 2}
+	pointSql, err := gmstypes.GeometryType{}.SQL(ctx, nil, point)
+	require.NoError(t, err)
+
+	rows := []sql.Row{
+		{int64(1), int8(-5), uint64(math.MaxUint64), float32(1.5), 2.25, "123.45", "-12345678901234567890123456789012345678.0123456789",
+			time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), time.Date(1960, 7, 4, 0, 0, 0, 0, time.UTC), "12:34:56.5",
+			"hello", "green", "a,c", `{"a": 1}`, point, uint64(5), []byte{0, 1, 2}},
+		{int64(2), nil, nil, nil, nil, "-0.05", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+
+	for _, isFile := range []bool{true, false} {
+		buf := bufferCloser{&bytes.Buffer{}}
+		var wr *ArrowRowWriter
+		if isFile {
+			wr, err = NewArrowFileWriter(ctx, buf, sch)
+		} else {
+			wr, err = NewArrowStreamWriter(ctx, buf, sch)
+		}
+		require.NoError(t, err)
+		for _, r := range rows {
+			require.NoError(t, wr.WriteSqlRow(ctx, r))
+		}
+		require.NoError(t, wr.Close(ctx))
+
+		if isFile {
+			assert.True(t, bytes.HasPrefix(buf.Bytes(), fileMagic))
+			assert.True(t, bytes.HasSuffix(buf.Bytes(), fileMagic))
+		}
+
+		rd, err := NewArrowReader(ctx, io.NopCloser(bytes.NewReader(buf.Bytes())))
+		require.NoError(t, err)
+
+		// The dolt schema, including primary keys, is restored from the file's metadata
+		rdSch := rd.GetSchema()
+		assert.Equal(t, []string{"id"}, rdSch.GetPKCols().GetColumnNames())
+		assert.Equal(t, sch.GetAllCols().GetColumnNames(), rdSch.GetAllCols().GetColumnNames())
+		for i, col := range rdSch.GetAllCols().GetColumns() {
+			assert.True(t, sch.GetAllCols().GetByIndex(i).TypeInfo.Equals(col.TypeInfo), col.Name)
+		}
+
+		r, err := rd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sql.Row{int64(1), int64(-5), uint64(math.MaxUint64), float32(1.5), 2.25, "123.45", "-12345678901234567890123456789012345678.0123456789",
+			time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), time.Date(1960, 7, 4, 0, 0, 0, 0, time.UTC), gmstypes.Timespan(45296500000),
+			"hello", "green", "a,c", `{"a": 1}`, pointSql.Raw(), "5", []byte{0, 1, 2}}, r)
+
+		r, err = rd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sql.Row{int64(2), nil, nil, nil, nil, "-0.05", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}, r)
+
+		_, err = rd.ReadSqlRow(ctx)
+		assert.Equal(t, io.EOF, err)
+		require.NoError(t, rd.Close(ctx))
+	}
+}
+
+func TestArrowTypeInference(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	withMetadata := func(key, value string) arrow.Metadata {
+		return arrow.NewMetadata([]string{key}, []string{value})
+	}
+	tests := []struct {
+		field    arrow.Field
+		expected sql.Type
+	}{
+		{arrow.Field{Type: arrow.PrimitiveTypes.Int32}, gmstypes.Int32},
+		{arrow.Field{Type: arrow.PrimitiveTypes.Uint16}, gmstypes.Uint16},
+		{arrow.Field{Type: arrow.PrimitiveTypes.Float64}, gmstypes.Float64},
+		{arrow.Field{Type: &arrow.Decimal128Type{Precision: 20, Scale: 4}}, gmstypes.MustCreateDecimalType(20, 4)},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Boolean}, gmstypes.Boolean},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Date32}, gmstypes.Date},
+		{arrow.Field{Type: &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}}, gmstypes.DatetimeMaxPrecision},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Time64us}, gmstypes.Time},
+		{arrow.Field{Type: arrow.BinaryTypes.LargeString}, gmstypes.LongText},
+		{arrow.Field{Type: arrow.BinaryTypes.String, Metadata: withMetadata(extensionNameKey, jsonExtension)}, gmstypes.JSON},
+		{arrow.Field{Type: arrow.BinaryTypes.Binary, Metadata: withMetadata(extensionNameKey, wkbExtension)}, gmstypes.GeometryType{}},
+		{arrow.Field{Type: arrow.BinaryTypes.Binary, Metadata: withMetadata(doltTypeKey, "varbinary(16)")}, gmstypes.MustCreateBinary(sqltypes.VarBinary, 16)},
+	}
+	for _, test := range tests {
+		actual, err := sqlTypeForField(ctx, test.field)
+		require.NoError(t, err)
+		assert.True(t, test.expected.Equals(actual), "expected %s, got %s", test.expected, actual)
+	}
+
+	_, err := sqlTypeForField(ctx, arrow.Field{Name: "nested", Type: arrow.ListOf(arrow.BinaryTypes.String)})
+	assert.Error(t, err)
+	_, err = sqlTypeForField(ctx, arrow.Field{Name: "dict", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}})
+	assert.Error(t, err)
+}
+
+// TestArrowReadsForeignData reads data written by the Arrow library from a schema that was not produced by Dolt.
+func TestArrowReadsForeignData(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	sch := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.LargeString, Nullable: true},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}, Nullable: true},
+		{Name: "tm", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+		{Name: "amount", Type: &arrow.Decimal128Type{Precision: 12, Scale: 3}, Nullable: true},
+	}, nil)
+
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, sch)
+	defer rb.Release()
+	rb.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	rb.Field(1).(*array.LargeStringBuilder).AppendValues([]string{"a", ""}, []bool{true, false})
+	rb.Field(2).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{1_700_000_000_123_456_789, 0}, []bool{true, false})
+	rb.Field(3).(*array.Time32Builder).AppendValues([]arrow.Time32{3_723_004, 0}, []bool{true, false})
+	rb.Field(4).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(-1_234_567), {}}, []bool{true, false})
+	rec := rb.NewRecord()
+	defer rec.Release()
+
+	for _, isFile := range []bool{true, false} {
+		buf := &bytes.Buffer{}
+		if isFile {
+			fw, err := ipc.NewFileWriter(&seekingWriter{wr: buf}, ipc.WithSchema(sch))
+			require.NoError(t, err)
+			require.NoError(t, fw.Write(rec))
+			require.NoError(t, fw.Close())
+		} else {
+			sw := ipc.NewWriter(buf, ipc.WithSchema(sch))
+			require.NoError(t, sw.Write(rec))
+			require.NoError(t, sw.Close())
+		}
+
+		rd, err := NewArrowReader(ctx, io.NopCloser(bytes.NewReader(buf.Bytes())))
+		require.NoError(t, err)
+		rdSch := rd.GetSchema()
+		assert.Equal(t, []string{"id", "name", "ts", "tm", "amount"}, rdSch.GetAllCols().GetColumnNames())
+		assert.Equal(t, 0, rdSch.GetPKCols().Size())
+
+		r, err := rd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sql.Row{int64(1), "a", time.Unix(1_700_000_000, 123_456_789).UTC(), gmstypes.Timespan(3_723_004_000), "-1234.567"}, r)
+		r, err = rd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sql.Row{int64(2), nil, nil, nil, nil}, r)
+		_, err = rd.ReadSqlRow(ctx)
+		assert.Equal(t, io.EOF, err)
+		require.NoError(t, rd.Close(ctx))
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/decimal128"
+	"github.com/apache/arrow/go/v12/arrow/decimal256"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// appendValue adds |val|, a value of |sqlType|, to the builder |b| of the column's Arrow type.
+func appendValue(ctx *sql.Context, b array.Builder, sqlType sql.Type, val interface{}) error {
+	if val == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch sqlType.Type() {
+	case query.Type_INT8, query.Type_INT16, query.Type_INT24, query.Type_INT32, query.Type_INT64, query.Type_YEAR:
+		v, _, err := gmstypes.Int64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		return appendInt(b, v.(int64))
+	case query.Type_UINT8, query.Type_UINT16, query.Type_UINT24, query.Type_UINT32, query.Type_UINT64, query.Type_BIT:
+		v, _, err := gmstypes.Uint64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		return appendUint(b, v.(uint64))
+	case query.Type_FLOAT32:
+		v, _, err := gmstypes.Float32.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.(*array.Float32Builder).Append(v.(float32))
+	case query.Type_FLOAT64:
+		v, _, err := gmstypes.Float64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.(*array.Float64Builder).Append(v.(float64))
+	case query.Type_DECIMAL:
+		str, err := sqlutil.SqlColToStr(ctx, sqlType, val)
+		if err != nil {
+			return err
+		}
+		dt := b.Type().(arrow.DecimalType)
+		unscaled, err := unscaledDecimal(str, int(dt.GetScale()))
+		if err != nil {
+			return err
+		}
+		switch db := b.(type) {
+		case *array.Decimal128Builder:
+			db.Append(decimal128.FromBigInt(unscaled))
+		case *array.Decimal256Builder:
+			db.Append(decimal256.FromBigInt(unscaled))
+		}
+	case query.Type_DATE, query.Type_DATETIME, query.Type_TIMESTAMP:
+		v, _, err := sqlType.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		t := v.(time.Time)
+		switch tb := b.(type) {
+		case *array.Date32Builder:
+			tb.Append(arrow.Date32FromTime(t))
+		case *array.TimestampBuilder:
+			tb.Append(arrow.Timestamp(t.UnixMicro()))
+		}
+	case query.Type_TIME:
+		v, _, err := sqlType.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.(*array.Time64Builder).Append(arrow.Time64(v.(gmstypes.Timespan).AsTimeDuration().Microseconds()))
+	case query.Type_GEOMETRY:
+		res, err := sqlType.SQL(ctx, nil, val)
+		if err != nil {
+			return err
+		}
+		// Dolt serializes geometries as a four byte SRID followed by WKB
+		raw := res.Raw()
+		if len(raw) < 4 {
+			return fmt.Errorf("invalid geometry value")
+		}
+		b.(*array.BinaryBuilder).Append(raw[4:])
+	default:
+		switch sb := b.(type) {
+		case *array.StringBuilder:
+			str, ok := val.(string)
+			if !ok {
+				var err error
+				if str, err = sqlutil.SqlColToStr(ctx, sqlType, val); err != nil {
+					return err
+				}
+			}
+			sb.Append(str)
+		case *array.BinaryBuilder:
+			switch v := val.(type) {
+			case []byte:
+				sb.Append(v)
+			case string:
+				sb.AppendString(v)
+			default:
+				str, err := sqlutil.SqlColToStr(ctx, sqlType, val)
+				if err != nil {
+					return err
+				}
+				sb.AppendString(str)
+			}
+		default:
+			return fmt.Errorf("unexpected arrow builder %T for type %s", b, sqlType.String())
+		}
+	}
+	return nil
+}
+
+// appendInt adds |v| to a builder of a signed integer type.
+func appendInt(b array.Builder, v int64) error {
+	switch ib := b.(type) {
+	case *array.Int8Builder:
+		ib.Append(int8(v))
+	case *array.Int16Builder:
+		ib.Append(int16(v))
+	case *array.Int32Builder:
+		ib.Append(int32(v))
+	case *array.Int64Builder:
+		ib.Append(v)
+	default:
+		return fmt.Errorf("unexpected arrow builder %T for an integer", b)
+	}
+	return nil
+}
+
+// appendUint adds |v| to a builder of an unsigned integer type.
+func appendUint(b array.Builder, v uint64) error {
+	switch ub := b.(type) {
+	case *array.Uint8Builder:
+		ub.Append(uint8(v))
+	case *array.Uint16Builder:
+		ub.Append(uint16(v))
+	case *array.Uint32Builder:
+		ub.Append(uint32(v))
+	case *array.Uint64Builder:
+		ub.Append(v)
+	default:
+		return fmt.Errorf("unexpected arrow builder %T for an unsigned integer", b)
+	}
+	return nil
+}
+
+// readValue returns the |i|th value of |arr| as a value of the column's SQL type |sqlType|. Values are copied out of
+// the record batch, which is released once the next batch is read.
+func readValue(sqlType sql.Type, arr arrow.Array, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch a := arr.(type) {
+	case array.ExtensionArray:
+		return readValue(sqlType, a.Storage(), i)
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int8:
+		return int64(a.Value(i)), nil
+	case *array.Int16:
+		return int64(a.Value(i)), nil
+	case *array.Int32:
+		return int64(a.Value(i)), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Uint8:
+		return uintValue(sqlType, uint64(a.Value(i))), nil
+	case *array.Uint16:
+		return uintValue(sqlType, uint64(a.Value(i))), nil
+	case *array.Uint32:
+		return uintValue(sqlType, uint64(a.Value(i))), nil
+	case *array.Uint64:
+		return uintValue(sqlType, a.Value(i)), nil
+	case *array.Float32:
+		return a.Value(i), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.Decimal128:
+		return decimalString(a.Value(i).BigInt(), a.DataType().(arrow.DecimalType).GetScale()), nil
+	case *array.Decimal256:
+		return decimalString(a.Value(i).BigInt(), a.DataType().(arrow.DecimalType).GetScale()), nil
+	case *array.Date32:
+		return a.Value(i).ToTime().UTC(), nil
+	case *array.Date64:
+		return a.Value(i).ToTime().UTC(), nil
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit).UTC(), nil
+	case *array.Time32:
+		d := time.Duration(a.Value(i)) * a.DataType().(*arrow.Time32Type).Unit.Multiplier()
+		return gmstypes.Timespan(d.Microseconds()), nil
+	case *array.Time64:
+		d := time.Duration(a.Value(i)) * a.DataType().(*arrow.Time64Type).Unit.Multiplier()
+		return gmstypes.Timespan(d.Microseconds()), nil
+	case *array.String:
+		return strings.Clone(a.Value(i)), nil
+	case *array.LargeString:
+		return strings.Clone(a.Value(i)), nil
+	case *array.Binary:
+		return bytesValue(sqlType, a.Value(i)), nil
+	case *array.LargeBinary:
+		return bytesValue(sqlType, a.Value(i)), nil
+	case *array.FixedSizeBinary:
+		return bytesValue(sqlType, a.Value(i)), nil
+	}
+	return nil, fmt.Errorf("unsupported arrow type %s", arr.DataType())
+}
+
+// uintValue returns the unsigned integer |v| as a value of |sqlType|. BIT values are imported from their string
+// representation.
+func uintValue(sqlType sql.Type, v uint64) interface{} {
+	if sqlType.Type() == query.Type_BIT {
+		return strconv.FormatUint(v, 10)
+	}
+	return v
+}
+
+// bytesValue copies |b| out of the record batch. Geometries are stored as WKB and are prefixed with the SRID of the
+// column so that they are in Dolt's geometry serialization format.
+func bytesValue(sqlType sql.Type, b []byte) []byte {
+	if st, ok := sqlType.(sql.SpatialColumnType); ok {
+		srid, _ := st.GetSpatialTypeSRID()
+		out := binary.LittleEndian.AppendUint32(make([]byte, 0, len(b)+4), srid)
+		return append(out, b...)
+	}
+	return append([]byte(nil), b...)
+}
+
+// unscaledDecimal returns the decimal string |s| multiplied by 10^|scale|, truncating any further digits.
+func unscaledDecimal(s string, scale int) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal value '%s'", s)
+	}
+	num := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	return num.Quo(num, r.Denom()), nil
+}
+
+// decimalString returns the decimal string of the unscaled value |v| with |scale| digits after the decimal point. The
+// decimal libraries format through big.Float, which cannot represent every value exactly.
+func decimalString(v *big.Int, scale int32) string {
+	r := new(big.Rat).SetFrac(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	return r.FloatString(int(scale))
+}
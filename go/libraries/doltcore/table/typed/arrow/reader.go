@@ -0,0 +1,197 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ArrowReader implements table.SqlRowReader. It reads rows from the Arrow IPC file or stream format.
+type ArrowReader struct {
+	closer   io.Closer
+	recRd    recordReader
+	sch      schema.Schema
+	sqlTypes []sql.Type
+	rec      arrow.Record
+	idx      int
+}
+
+var _ table.SqlRowReader = (*ArrowReader)(nil)
+
+// fileMagic begins and ends the Arrow IPC file format.
+var fileMagic = []byte("ARROW1")
+
+// recordReader iterates over the record batches of an Arrow stream or file. It is implemented by ipc.Reader.
+type recordReader interface {
+	Schema() *arrow.Schema
+	Next() bool
+	Record() arrow.Record
+	Err() error
+	Release()
+}
+
+// fileRecordReader implements recordReader for the Arrow file format.
+type fileRecordReader struct {
+	fr  *ipc.FileReader
+	rec arrow.Record
+	idx int
+	err error
+}
+
+func (f *fileRecordReader) Schema() *arrow.Schema {
+	return f.fr.Schema()
+}
+
+func (f *fileRecordReader) Next() bool {
+	if f.err != nil || f.idx >= f.fr.NumRecords() {
+		return false
+	}
+	// records returned by the file reader are released when it reads the next one
+	f.rec, f.err = f.fr.Record(f.idx)
+	f.idx++
+	return f.err == nil
+}
+
+func (f *fileRecordReader) Record() arrow.Record {
+	return f.rec
+}
+
+func (f *fileRecordReader) Err() error {
+	return f.err
+}
+
+func (f *fileRecordReader) Release() {
+	f.fr.Close()
+}
+
+// OpenArrowReader opens the Arrow file at |path| within |fs|.
+func OpenArrowReader(ctx context.Context, path string, fs filesys.ReadableFS) (*ArrowReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArrowReader(ctx, r)
+}
+
+// NewArrowReader creates an ArrowReader reading from |r|, which may hold either the file or the stream format. The
+// schema of the reader is derived from the Arrow schema.
+func NewArrowReader(ctx context.Context, r io.ReadCloser) (*ArrowReader, error) {
+	ar, err := newArrowReader(ctx, r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return ar, nil
+}
+
+func newArrowReader(ctx context.Context, r io.ReadCloser) (*ArrowReader, error) {
+	rd := bufio.NewReaderSize(r, 1024*1024)
+	var recRd recordReader
+	magic, err := rd.Peek(len(fileMagic))
+	if err == nil && bytes.Equal(magic, fileMagic) {
+		// The file format is read through its footer, which requires random access to the file. Data that is not
+		// seekable, such as a compressed file, is read into memory.
+		ras, ok := r.(ipc.ReadAtSeeker)
+		if !ok {
+			data, err := io.ReadAll(rd)
+			if err != nil {
+				return nil, err
+			}
+			ras = bytes.NewReader(data)
+		}
+		fr, err := ipc.NewFileReader(ras)
+		if err != nil {
+			return nil, fmt.Errorf("data is not a readable arrow file: %w", err)
+		}
+		recRd = &fileRecordReader{fr: fr}
+	} else {
+		sr, err := ipc.NewReader(rd)
+		if err != nil {
+			return nil, fmt.Errorf("data is not a readable arrow stream: %w", err)
+		}
+		recRd = sr
+	}
+
+	ar := &ArrowReader{closer: r, recRd: recRd}
+	ar.sch, ar.sqlTypes, err = doltSchemaForArrowSchema(ctx, recRd.Schema())
+	if err != nil {
+		recRd.Release()
+		return nil, err
+	}
+	return ar, nil
+}
+
+// ReadSqlRow implements table.SqlRowReader.
+func (ar *ArrowReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	for ar.rec == nil || ar.idx >= int(ar.rec.NumRows()) {
+		// the current record is released by the record reader when it advances
+		if !ar.recRd.Next() {
+			ar.rec = nil
+			if err := ar.recRd.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		ar.rec = ar.recRd.Record()
+		ar.idx = 0
+	}
+
+	r := make(sql.Row, len(ar.sqlTypes))
+	for i, col := range ar.rec.Columns() {
+		v, err := readValue(ar.sqlTypes[i], col, ar.idx)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", ar.rec.ColumnName(i), err)
+		}
+		r[i] = v
+	}
+	ar.idx++
+	return r, nil
+}
+
+// ReadRow implements table.Reader.
+func (ar *ArrowReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+// GetSchema implements table.Reader.
+func (ar *ArrowReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// Close implements table.Closer.
+func (ar *ArrowReader) Close(ctx context.Context) error {
+	if ar.closer != nil {
+		ar.recRd.Release()
+		err := ar.closer.Close()
+		ar.closer = nil
+		return err
+	}
+	return errors.New("already closed")
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+const (
+	// doltTypeKey is the field metadata key holding the SQL type of a column written by Dolt. It allows types such as
+	// enums, sets and decimals wider than the Arrow type to round trip exactly.
+	doltTypeKey = "dolt.type"
+
+	// doltPrimaryKeyKey is the schema metadata key holding a JSON array of the primary key column names.
+	doltPrimaryKeyKey = "dolt.primary_key"
+
+	// extensionNameKey and extensionMetadataKey identify Arrow extension types.
+	extensionNameKey     = "ARROW:extension:name"
+	extensionMetadataKey = "ARROW:extension:metadata"
+
+	// jsonExtension is the canonical Arrow extension type for JSON stored as Utf8.
+	jsonExtension = "arrow.json"
+
+	// wkbExtension is the GeoArrow extension type for geometries stored as well-known binary.
+	wkbExtension = "geoarrow.wkb"
+)
+
+// keyValue is an entry of Arrow field or schema metadata.
+type keyValue struct {
+	key, value string
+}
+
+// arrowTypeForSqlType returns the Arrow type used to store values of |t|, along with any extension metadata.
+func arrowTypeForSqlType(t sql.Type) (arrow.DataType, []keyValue, error) {
+	switch t.Type() {
+	case query.Type_INT8:
+		return arrow.PrimitiveTypes.Int8, nil, nil
+	case query.Type_UINT8:
+		return arrow.PrimitiveTypes.Uint8, nil, nil
+	case query.Type_INT16, query.Type_YEAR:
+		return arrow.PrimitiveTypes.Int16, nil, nil
+	case query.Type_UINT16:
+		return arrow.PrimitiveTypes.Uint16, nil, nil
+	case query.Type_INT24, query.Type_INT32:
+		return arrow.PrimitiveTypes.Int32, nil, nil
+	case query.Type_UINT24, query.Type_UINT32:
+		return arrow.PrimitiveTypes.Uint32, nil, nil
+	case query.Type_INT64:
+		return arrow.PrimitiveTypes.Int64, nil, nil
+	case query.Type_UINT64, query.Type_BIT:
+		return arrow.PrimitiveTypes.Uint64, nil, nil
+	case query.Type_FLOAT32:
+		return arrow.PrimitiveTypes.Float32, nil, nil
+	case query.Type_FLOAT64:
+		return arrow.PrimitiveTypes.Float64, nil, nil
+	case query.Type_DECIMAL:
+		dt := t.(gmstypes.DecimalType_)
+		if dt.Precision() > 38 {
+			return &arrow.Decimal256Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil, nil
+		}
+		return &arrow.Decimal128Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil, nil
+	case query.Type_DATE:
+		return arrow.FixedWidthTypes.Date32, nil, nil
+	case query.Type_DATETIME:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, nil, nil
+	case query.Type_TIMESTAMP:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, nil, nil
+	case query.Type_TIME:
+		return arrow.FixedWidthTypes.Time64us, nil, nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_ENUM, query.Type_SET:
+		return arrow.BinaryTypes.String, nil, nil
+	case query.Type_JSON:
+		return arrow.BinaryTypes.String, []keyValue{{extensionNameKey, jsonExtension}, {extensionMetadataKey, ""}}, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB:
+		return arrow.BinaryTypes.Binary, nil, nil
+	case query.Type_GEOMETRY:
+		return arrow.BinaryTypes.Binary, []keyValue{{extensionNameKey, wkbExtension}, {extensionMetadataKey, "{}"}}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported type for arrow export: %s", t.String())
+	}
+}
+
+// sqlTypeForField returns the SQL type of the column that stores |f|. Columns written by Dolt carry their SQL type in
+// their metadata, otherwise the type is inferred from the Arrow type. Timestamps are read as DATETIME regardless of
+// their time zone, as TIMESTAMP cannot hold instants past 2038.
+func sqlTypeForField(ctx context.Context, f arrow.Field) (sql.Type, error) {
+	dt := f.Type
+	ext, _ := metadataValue(f.Metadata, extensionNameKey)
+	if et, ok := dt.(arrow.ExtensionType); ok {
+		// the extension was registered with the arrow library, which moves it from the metadata into the type
+		dt, ext = et.StorageType(), et.ExtensionName()
+	}
+	if dt.ID() == arrow.DICTIONARY {
+		return nil, fmt.Errorf("column %s: dictionary encoded arrow columns are not supported", f.Name)
+	}
+	if _, ok := dt.(arrow.NestedType); ok {
+		return nil, fmt.Errorf("column %s: nested arrow columns are not supported", f.Name)
+	}
+
+	if typStr, ok := metadataValue(f.Metadata, doltTypeKey); ok {
+		t, err := planbuilder.ParseColumnTypeString(ctx, typStr)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.Name, err)
+		}
+		return t, nil
+	}
+
+	switch dt.ID() {
+	case arrow.NULL:
+		return gmstypes.LongText, nil
+	case arrow.BOOL:
+		return gmstypes.Boolean, nil
+	case arrow.INT8:
+		return gmstypes.Int8, nil
+	case arrow.UINT8:
+		return gmstypes.Uint8, nil
+	case arrow.INT16:
+		return gmstypes.Int16, nil
+	case arrow.UINT16:
+		return gmstypes.Uint16, nil
+	case arrow.INT32:
+		return gmstypes.Int32, nil
+	case arrow.UINT32:
+		return gmstypes.Uint32, nil
+	case arrow.INT64:
+		return gmstypes.Int64, nil
+	case arrow.UINT64:
+		return gmstypes.Uint64, nil
+	case arrow.FLOAT32:
+		return gmstypes.Float32, nil
+	case arrow.FLOAT64:
+		return gmstypes.Float64, nil
+	case arrow.DECIMAL128, arrow.DECIMAL256:
+		dec := dt.(arrow.DecimalType)
+		t, err := gmstypes.CreateColumnDecimalType(uint8(dec.GetPrecision()), uint8(dec.GetScale()))
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.Name, err)
+		}
+		return t, nil
+	case arrow.DATE32, arrow.DATE64:
+		return gmstypes.Date, nil
+	case arrow.TIMESTAMP:
+		return gmstypes.DatetimeMaxPrecision, nil
+	case arrow.TIME32, arrow.TIME64:
+		return gmstypes.Time, nil
+	case arrow.STRING, arrow.LARGE_STRING:
+		if ext == jsonExtension {
+			return gmstypes.JSON, nil
+		}
+		return gmstypes.LongText, nil
+	case arrow.BINARY, arrow.LARGE_BINARY:
+		if ext == wkbExtension {
+			return gmstypes.GeometryType{}, nil
+		}
+		return gmstypes.LongBlob, nil
+	case arrow.FIXED_SIZE_BINARY:
+		if width := dt.(*arrow.FixedSizeBinaryType).ByteWidth; width > 0 && width <= 255 {
+			return gmstypes.MustCreateBinary(sqltypes.Binary, int64(width)), nil
+		}
+		return gmstypes.LongBlob, nil
+	}
+	return nil, fmt.Errorf("column %s: unsupported arrow type %s", f.Name, dt)
+}
+
+// metadataValue returns the value of |key| in |md|.
+func metadataValue(md arrow.Metadata, key string) (string, bool) {
+	if i := md.FindKey(key); i >= 0 {
+		return md.Values()[i], true
+	}
+	return "", false
+}
+
+// newMetadata returns Arrow metadata holding |kvs|.
+func newMetadata(kvs []keyValue) arrow.Metadata {
+	keys := make([]string, len(kvs))
+	values := make([]string, len(kvs))
+	for i, kv := range kvs {
+		keys[i], values[i] = kv.key, kv.value
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+// arrowSchemaForSchema returns the Arrow schema used to export rows of |sch|.
+func arrowSchemaForSchema(sch schema.Schema, sqlSch sql.Schema) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(sqlSch))
+	for i, col := range sqlSch {
+		typ, metadata, err := arrowTypeForSqlType(col.Type)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, keyValue{doltTypeKey, col.Type.String()})
+		fields[i] = arrow.Field{
+			Name:     col.Name,
+			Type:     typ,
+			Nullable: col.Nullable,
+			Metadata: newMetadata(metadata),
+		}
+	}
+
+	var metadata []keyValue
+	pks := sch.GetPKCols().GetColumnNames()
+	if len(pks) > 0 {
+		pkJson, err := json.Marshal(pks)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, keyValue{doltPrimaryKeyKey, string(pkJson)})
+	}
+	md := newMetadata(metadata)
+	return arrow.NewSchema(fields, &md), nil
+}
+
+// doltSchemaForArrowSchema returns the schema of the rows read from a file with schema |as|, along with the SQL type of
+// each column. Primary keys recorded by Dolt are restored, otherwise the schema is keyless.
+func doltSchemaForArrowSchema(ctx context.Context, as *arrow.Schema) (schema.Schema, []sql.Type, error) {
+	var pks []string
+	if pkJson, ok := metadataValue(as.Metadata(), doltPrimaryKeyKey); ok {
+		if err := json.Unmarshal([]byte(pkJson), &pks); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s metadata: %w", doltPrimaryKeyKey, err)
+		}
+	}
+	isPk := make(map[string]bool, len(pks))
+	for _, pk := range pks {
+		isPk[pk] = true
+	}
+
+	fields := as.Fields()
+	cols := make([]schema.Column, len(fields))
+	sqlTypes := make([]sql.Type, len(fields))
+	for i, f := range fields {
+		t, err := sqlTypeForField(ctx, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		ti, err := typeinfo.FromSqlType(t)
+		if err != nil {
+			return nil, nil, fmt.Errorf("column %s: %w", f.Name, err)
+		}
+		var constraints []schema.ColConstraint
+		if !f.Nullable || isPk[f.Name] {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		cols[i], err = schema.NewColumnWithTypeInfo(f.Name, uint64(i), ti, isPk[f.Name], "", false, "", constraints...)
+		if err != nil {
+			return nil, nil, err
+		}
+		sqlTypes[i] = t
+	}
+
+	colColl := schema.NewColCollection(cols...)
+	sch, err := schema.SchemaFromCols(colColl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pks) > 1 {
+		// Restore the declared order of a composite key
+		ords := make([]int, len(pks))
+		for i, pk := range pks {
+			ords[i] = colColl.IndexOf(pk)
+		}
+		if err = sch.SetPkOrdinals(ords); err != nil {
+			return nil, nil, err
+		}
+	}
+	return sch, sqlTypes, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/ipc"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// batchSize is the number of rows written in each record batch
+const batchSize = 64 * 1024
+
+// ArrowRowWriter implements table.SqlRowWriter. It writes rows in the Arrow IPC file or stream format.
+type ArrowRowWriter struct {
+	closer   io.Closer
+	wr       *bufio.Writer
+	sqlSch   sql.Schema
+	builder  *array.RecordBuilder
+	ipcWr    recordWriter
+	rowCount int
+}
+
+var _ table.SqlRowWriter = (*ArrowRowWriter)(nil)
+
+// recordWriter is implemented by ipc.Writer and ipc.FileWriter.
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+// NewArrowFileWriter returns an ArrowRowWriter that writes rows of |sch| to |wr| in the Arrow IPC file format.
+func NewArrowFileWriter(ctx context.Context, wr io.WriteCloser, sch schema.Schema) (*ArrowRowWriter, error) {
+	return newArrowRowWriter(ctx, wr, sch, true)
+}
+
+// NewArrowStreamWriter returns an ArrowRowWriter that writes rows of |sch| to |wr| in the Arrow IPC stream format.
+func NewArrowStreamWriter(ctx context.Context, wr io.WriteCloser, sch schema.Schema) (*ArrowRowWriter, error) {
+	return newArrowRowWriter(ctx, wr, sch, false)
+}
+
+func newArrowRowWriter(ctx context.Context, wr io.WriteCloser, sch schema.Schema, isFile bool) (*ArrowRowWriter, error) {
+	sqlSch, err := sqlutil.FromDoltSchema(ctx, "", "", sch)
+	if err != nil {
+		return nil, err
+	}
+	as, err := arrowSchemaForSchema(sch, sqlSch.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	aw := &ArrowRowWriter{
+		closer:  wr,
+		wr:      bufio.NewWriterSize(wr, 1024*1024),
+		sqlSch:  sqlSch.Schema,
+		builder: array.NewRecordBuilder(memory.DefaultAllocator, as),
+	}
+	if isFile {
+		aw.ipcWr, err = ipc.NewFileWriter(&seekingWriter{wr: aw.wr}, ipc.WithSchema(as))
+		if err != nil {
+			aw.builder.Release()
+			return nil, err
+		}
+	} else {
+		aw.ipcWr = ipc.NewWriter(aw.wr, ipc.WithSchema(as))
+	}
+	return aw, nil
+}
+
+// WriteSqlRow implements table.SqlRowWriter.
+func (aw *ArrowRowWriter) WriteSqlRow(ctx *sql.Context, r sql.Row) error {
+	for i, col := range aw.sqlSch {
+		if err := appendValue(ctx, aw.builder.Field(i), col.Type, r[i]); err != nil {
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+	}
+	aw.rowCount++
+	if aw.rowCount >= batchSize {
+		return aw.flushBatch()
+	}
+	return nil
+}
+
+// flushBatch writes the buffered rows as a record batch.
+func (aw *ArrowRowWriter) flushBatch() error {
+	rec := aw.builder.NewRecord()
+	defer rec.Release()
+	aw.rowCount = 0
+	return aw.ipcWr.Write(rec)
+}
+
+// Close implements table.SqlRowWriter. It flushes any buffered rows, ends the stream and, for the file format, writes
+// the footer.
+func (aw *ArrowRowWriter) Close(ctx context.Context) error {
+	if aw.closer == nil {
+		return errors.New("already closed")
+	}
+
+	err := aw.finish()
+	aw.builder.Release()
+	errFl := aw.wr.Flush()
+	errCl := aw.closer.Close()
+	aw.closer = nil
+
+	if err != nil {
+		return err
+	}
+	if errFl != nil {
+		return errFl
+	}
+	return errCl
+}
+
+// finish writes any buffered rows and ends the stream, writing the footer for the file format.
+func (aw *ArrowRowWriter) finish() error {
+	if aw.rowCount > 0 {
+		if err := aw.flushBatch(); err != nil {
+			return err
+		}
+	}
+	return aw.ipcWr.Close()
+}
+
+// seekingWriter adapts a writer to the io.WriteSeeker required by ipc.FileWriter, which only seeks to find the
+// current offset of the data it has written.
+type seekingWriter struct {
+	wr      io.Writer
+	written int64
+}
+
+func (w *seekingWriter) Write(p []byte) (int, error) {
+	n, err := w.wr.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *seekingWriter) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekCurrent {
+		return 0, errors.New("arrow output is not seekable")
+	}
+	return w.written, nil
+}
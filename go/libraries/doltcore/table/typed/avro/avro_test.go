@@ -0,0 +1,212 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+type bufferCloser struct {
+	*bytes.Buffer
+}
+
+func (bufferCloser) Close() error {
+	return nil
+}
+
+func mustColumn(t *testing.T, name string, tag uint64, sqlType sql.Type, isPk bool) schema.Column {
+	ti, err := typeinfo.FromSqlType(sqlType)
+	require.NoError(t, err)
+	var constraints []schema.ColConstraint
+	if isPk {
+		constraints = append(constraints, schema.NotNullConstraint{})
+	}
+	col, err := schema.NewColumnWithTypeInfo(name, tag, ti, isPk, "", false, "", constraints...)
+	require.NoError(t, err)
+	return col
+}
+
+func testSchema(t *testing.T) schema.Schema {
+	cols := []schema.Column{
+		mustColumn(t, "id", 0, gmstypes.Int64, true),
+		mustColumn(t, "i8", 1, gmstypes.Int8, false),
+		mustColumn(t, "u64", 2, gmstypes.Uint64, false),
+		mustColumn(t, "f", 3, gmstypes.Float32, false),
+		mustColumn(t, "d", 4, gmstypes.Float64, false),
+		mustColumn(t, "dec", 5, gmstypes.MustCreateDecimalType(10, 2), false),
+		mustColumn(t, "wide_dec", 6, gmstypes.MustCreateDecimalType(50, 10), false),
+		mustColumn(t, "dt", 7, gmstypes.DatetimeMaxPrecision, false),
+		mustColumn(t, "day", 8, gmstypes.Date, false),
+		mustColumn(t, "tm", 9, gmstypes.Time, false),
+		mustColumn(t, "first name", 10, gmstypes.LongText, false),
+		mustColumn(t, "color", 11, gmstypes.MustCreateEnumType([]string{"red", "green", "blue"}, sql.Collation_Default), false),
+		mustColumn(t, "flags", 12, gmstypes.MustCreateSetType([]string{"a", "b", "c"}, sql.Collation_Default), false),
+		mustColumn(t, "doc", 13, gmstypes.JSON, false),
+		mustColumn(t, "geom", 14, gmstypes.GeometryType{}, false),
+		mustColumn(t, "bits", 15, gmstypes.MustCreateBitType(8), false),
+		mustColumn(t, "blob", 16, gmstypes.LongBlob, false),
+	}
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	require.NoError(t, err)
+	return sch
+}
+
+func TestAvroRoundTrip(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	sch := testSchema(t)
+
+	point := gmstypes.Point{X: 1, Y: 2}
+	pointSql, err := gmstypes.GeometryType{}.SQL(ctx, nil, point)
+	require.NoError(t, err)
+
+	buf := bufferCloser{&bytes.Buffer{}}
+	wr, err := NewAvroWriter(ctx, buf, sch, "test")
+	require.NoError(t, err)
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(1), int8(-5), uint64(math.MaxUint64), float32(1.5), 2.25, "123.45", "-12345678901234567890123456789012345678.0123456789",
+		time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), time.Date(1960, 7, 4, 0, 0, 0, 0, time.UTC), "12:34:56.5",
+		"hello", "green", "a,c", `{"a": 1}`, point, uint64(5), []byte{0, 1, 2}}))
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(2), nil, nil, nil, nil, "-0.05", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}))
+	require.NoError(t, wr.Close(ctx))
+	assert.Error(t, wr.Close(ctx))
+
+	rd, err := NewAvroReader(ctx, io.NopCloser(bytes.NewReader(buf.Bytes())))
+	require.NoError(t, err)
+
+	// The dolt schema, including primary keys and column names that are not valid avro names, is restored from the
+	// file's schema
+	rdSch := rd.GetSchema()
+	assert.Equal(t, []string{"id"}, rdSch.GetPKCols().GetColumnNames())
+	assert.Equal(t, sch.GetAllCols().GetColumnNames(), rdSch.GetAllCols().GetColumnNames())
+	for i, col := range rdSch.GetAllCols().GetColumns() {
+		assert.True(t, sch.GetAllCols().GetByIndex(i).TypeInfo.Equals(col.TypeInfo), col.Name)
+	}
+
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(1), int64(-5), "18446744073709551615", float32(1.5), 2.25, "123.45", "-12345678901234567890123456789012345678.0123456789",
+		time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), time.Date(1960, 7, 4, 0, 0, 0, 0, time.UTC), gmstypes.Timespan(45296500000),
+		"hello", "green", "a,c", `{"a": 1}`, pointSql.Raw(), "5", []byte{0, 1, 2}}, r)
+
+	r, err = rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(2), nil, nil, nil, nil, "-0.05", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}, r)
+
+	_, err = rd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+	require.NoError(t, rd.Close(ctx))
+}
+
+func TestAvroTypeInference(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	rec, err := parseSchema([]byte(`{
+		"type": "record", "name": "events", "namespace": "com.example",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "ok", "type": ["null", "boolean"]},
+			{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 12, "scale": 3}},
+			{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+			{"name": "other_kind", "type": "Kind"},
+			{"name": "hash", "type": {"type": "fixed", "name": "md5", "size": 16}},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "attrs", "type": {"type": "map", "values": "long"}}
+		]}`))
+	require.NoError(t, err)
+
+	sch, sqlTypes, err := doltSchemaForRecord(ctx, rec)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sch.GetPKCols().Size())
+	expected := []sql.Type{
+		gmstypes.Int64,
+		gmstypes.Boolean,
+		gmstypes.MustCreateDecimalType(12, 3),
+		gmstypes.Date,
+		gmstypes.DatetimeMaxPrecision,
+		gmstypes.MustCreateEnumType([]string{"A", "B"}, sql.Collation_Default),
+		gmstypes.MustCreateEnumType([]string{"A", "B"}, sql.Collation_Default),
+		gmstypes.MustCreateBinary(sqltypes.Binary, 16),
+		gmstypes.JSON,
+		gmstypes.JSON,
+	}
+	require.Len(t, sqlTypes, len(expected))
+	for i := range expected {
+		assert.True(t, expected[i].Equals(sqlTypes[i]), "expected %s, got %s", expected[i], sqlTypes[i])
+	}
+	assert.False(t, sch.GetAllCols().GetByIndex(0).IsNullable())
+	assert.True(t, sch.GetAllCols().GetByIndex(1).IsNullable())
+
+	v, err := sqlValue(sqlTypes[8], rec.fields[8].typ, []interface{}{"x", "y"})
+	require.NoError(t, err)
+	assert.Equal(t, `["x","y"]`, v)
+
+	rec, err = parseSchema([]byte(`{"type": "record", "name": "r", "fields": [{"name": "u", "type": ["int", "string"]}]}`))
+	require.NoError(t, err)
+	_, _, err = doltSchemaForRecord(ctx, rec)
+	assert.Error(t, err)
+}
+
+// TestAvroReadsForeignFile checks that files written by another producer, with none of the properties that Dolt adds
+// to its own schemas, are read with inferred types.
+func TestAvroReadsForeignFile(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	var buf bytes.Buffer
+	ocf, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W: &buf,
+		Schema: `{"type": "record", "name": "events", "fields": [
+			{"name": "id", "type": "long"},
+			{"name": "price", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 12, "scale": 3}]},
+			{"name": "at", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}]},
+			{"name": "tags", "type": {"type": "array", "items": "string"}}
+		]}`,
+		CompressionName: goavro.CompressionSnappyLabel,
+	})
+	require.NoError(t, err)
+	price, _ := new(big.Rat).SetString("12.345")
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	require.NoError(t, ocf.Append([]interface{}{
+		map[string]interface{}{"id": int64(1), "price": goavro.Union("bytes.decimal", price), "at": goavro.Union("long.timestamp-micros", at), "tags": []interface{}{"x"}},
+		map[string]interface{}{"id": int64(2), "price": nil, "at": nil, "tags": []interface{}{}},
+	}))
+
+	rd, err := NewAvroReader(ctx, io.NopCloser(bytes.NewReader(buf.Bytes())))
+	require.NoError(t, err)
+	assert.Equal(t, 0, rd.GetSchema().GetPKCols().Size())
+
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(1), "12.345", at, `["x"]`}, r)
+	r, err = rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(2), nil, nil, `[]`}, r)
+	_, err = rd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+	require.NoError(t, rd.Close(ctx))
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// AvroReader implements table.SqlRowReader. It reads rows from an Avro object container file whose schema is a
+// record. Decoding is done by goavro, which supports the null, deflate and snappy codecs.
+type AvroReader struct {
+	closer   io.Closer
+	ocf      *goavro.OCFReader
+	sch      schema.Schema
+	sqlTypes []sql.Type
+	record   *avroSchema
+}
+
+var _ table.SqlRowReader = (*AvroReader)(nil)
+
+// OpenAvroReader opens the Avro file at |path| within |fs|.
+func OpenAvroReader(ctx context.Context, path string, fs filesys.ReadableFS) (*AvroReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAvroReader(ctx, r)
+}
+
+// NewAvroReader creates an AvroReader reading an object container file from |r|. The schema of the reader is derived
+// from the schema of the file.
+func NewAvroReader(ctx context.Context, r io.ReadCloser) (*AvroReader, error) {
+	ocf, err := goavro.NewOCFReader(bufio.NewReaderSize(r, 1024*1024))
+	if err != nil {
+		r.Close()
+		return nil, fmt.Errorf("data is not a readable avro object container file: %w", err)
+	}
+
+	// The schema is parsed from the header rather than taken from the goavro codec, as the codec has no accessors for
+	// the custom properties that Dolt uses to restore column types, names and primary keys.
+	rec, err := parseSchema(ocf.MetaData()["avro.schema"])
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	ar := &AvroReader{closer: r, ocf: ocf, record: rec}
+	ar.sch, ar.sqlTypes, err = doltSchemaForRecord(ctx, rec)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return ar, nil
+}
+
+// ReadSqlRow implements table.SqlRowReader.
+func (ar *AvroReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	if !ar.ocf.Scan() {
+		if err := ar.ocf.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	datum, err := ar.ocf.Read()
+	if err != nil {
+		return nil, err
+	}
+	values, ok := datum.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an avro record but found %T", datum)
+	}
+
+	r := make(sql.Row, len(ar.record.fields))
+	for i, f := range ar.record.fields {
+		if r[i], err = sqlValue(ar.sqlTypes[i], f.typ, values[f.name]); err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.name, err)
+		}
+	}
+	return r, nil
+}
+
+// ReadRow implements table.Reader.
+func (ar *AvroReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+// GetSchema implements table.Reader.
+func (ar *AvroReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// Close implements table.Closer.
+func (ar *AvroReader) Close(ctx context.Context) error {
+	if ar.closer != nil {
+		err := ar.closer.Close()
+		ar.closer = nil
+		return err
+	}
+	return errors.New("already closed")
+}
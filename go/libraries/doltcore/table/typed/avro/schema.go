@@ -0,0 +1,221 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// avroSchema is a parsed Avro schema. See https://avro.apache.org/docs/1.11.1/specification/
+type avroSchema struct {
+	// kind is a primitive type name, or one of record, enum, array, map, fixed or union
+	kind    string
+	logical string
+	// name is the full name of a named type
+	name      string
+	precision int
+	scale     int
+	size      int
+	symbols   []string
+	fields    []avroField
+	items     *avroSchema
+	branches  []*avroSchema
+	props     map[string]interface{}
+}
+
+// avroField is a single field of an Avro record.
+type avroField struct {
+	name  string
+	typ   *avroSchema
+	props map[string]interface{}
+}
+
+var primitiveKinds = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true, "double": true, "bytes": true, "string": true,
+}
+
+var avroNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// propString returns the string value of the custom property |key|.
+func propString(props map[string]interface{}, key string) (string, bool) {
+	s, ok := props[key].(string)
+	return s, ok
+}
+
+// nullable returns the non-null branch of |s| if it is a union of null and one other type.
+func (s *avroSchema) nullable() (*avroSchema, bool) {
+	if s.kind != "union" || len(s.branches) != 2 {
+		return s, false
+	}
+	if s.branches[0].kind == "null" {
+		return s.branches[1], true
+	} else if s.branches[1].kind == "null" {
+		return s.branches[0], true
+	}
+	return s, false
+}
+
+// schemaParser parses JSON Avro schemas, resolving references to previously defined named types.
+type schemaParser struct {
+	named map[string]*avroSchema
+}
+
+func parseSchema(data []byte) (*avroSchema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid avro schema: %w", err)
+	}
+	p := schemaParser{named: make(map[string]*avroSchema)}
+	return p.parse(raw, "")
+}
+
+func (p schemaParser) parse(raw interface{}, namespace string) (*avroSchema, error) {
+	switch v := raw.(type) {
+	case string:
+		if primitiveKinds[v] {
+			return &avroSchema{kind: v}, nil
+		}
+		if s, ok := p.named[fullName(v, namespace)]; ok {
+			return s, nil
+		}
+		if s, ok := p.named[v]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("invalid avro schema: unknown type '%s'", v)
+	case []interface{}:
+		s := &avroSchema{kind: "union"}
+		for _, b := range v {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+	case map[string]interface{}:
+		return p.parseObject(v, namespace)
+	default:
+		return nil, fmt.Errorf("invalid avro schema: unexpected %v", raw)
+	}
+}
+
+func (p schemaParser) parseObject(obj map[string]interface{}, namespace string) (*avroSchema, error) {
+	kind, ok := obj["type"].(string)
+	if !ok {
+		// {"type": {...}} and {"type": [...]} wrap another schema
+		return p.parse(obj["type"], namespace)
+	}
+
+	s := &avroSchema{kind: kind, props: obj}
+	s.logical, _ = obj["logicalType"].(string)
+	if n, ok := obj["precision"].(float64); ok {
+		s.precision = int(n)
+	}
+	if n, ok := obj["scale"].(float64); ok {
+		s.scale = int(n)
+	}
+
+	switch kind {
+	case "record", "error", "enum", "fixed":
+		name, _ := obj["name"].(string)
+		if ns, ok := obj["namespace"].(string); ok {
+			namespace = ns
+		}
+		s.name = fullName(name, namespace)
+		if i := strings.LastIndexByte(s.name, '.'); i >= 0 {
+			namespace = s.name[:i]
+		}
+		p.named[s.name] = s
+	}
+
+	switch kind {
+	case "record", "error":
+		s.kind = "record"
+		fields, _ := obj["fields"].([]interface{})
+		for _, rawField := range fields {
+			fieldObj, ok := rawField.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid avro schema: record %s has an invalid field", s.name)
+			}
+			name, _ := fieldObj["name"].(string)
+			typ, err := p.parse(fieldObj["type"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.fields = append(s.fields, avroField{name: name, typ: typ, props: fieldObj})
+		}
+	case "enum":
+		symbols, _ := obj["symbols"].([]interface{})
+		for _, sym := range symbols {
+			str, _ := sym.(string)
+			s.symbols = append(s.symbols, str)
+		}
+	case "fixed":
+		n, _ := obj["size"].(float64)
+		s.size = int(n)
+	case "array":
+		items, err := p.parse(obj["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	case "map":
+		values, err := p.parse(obj["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = values
+	default:
+		if !primitiveKinds[kind] {
+			return p.parse(kind, namespace)
+		}
+	}
+	return s, nil
+}
+
+func fullName(name, namespace string) string {
+	if strings.ContainsRune(name, '.') || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// avroName returns |name| with characters that are not allowed in Avro names replaced.
+func avroName(name string) string {
+	if avroNameRegex.MatchString(name) {
+		return name
+	}
+	var sb strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			sb.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	if sb.Len() == 0 {
+		return "_"
+	}
+	return sb.String()
+}
@@ -0,0 +1,488 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const (
+	// doltTypeKey is the field property holding the SQL type of a column written by Dolt. It allows types such as sets,
+	// JSON and geometries, which have no Avro equivalent, to round trip exactly.
+	doltTypeKey = "dolt.type"
+
+	// doltColumnKey is the field property holding the name of a column whose name is not a valid Avro name.
+	doltColumnKey = "dolt.column"
+
+	// doltPrimaryKeyKey is the record property holding the primary key column names.
+	doltPrimaryKeyKey = "dolt.primary_key"
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+// avroTypeForSqlType returns the Avro type used to store values of |t| in the column |name| of the record |record|.
+func avroTypeForSqlType(t sql.Type, record, name string) (*avroSchema, error) {
+	switch t.Type() {
+	case query.Type_INT8, query.Type_INT16, query.Type_INT24, query.Type_INT32,
+		query.Type_UINT8, query.Type_UINT16, query.Type_UINT24, query.Type_YEAR:
+		return &avroSchema{kind: "int"}, nil
+	case query.Type_INT64, query.Type_UINT32, query.Type_BIT:
+		return &avroSchema{kind: "long"}, nil
+	case query.Type_UINT64:
+		// Avro has no unsigned types, and a long cannot hold the upper half of the range
+		return &avroSchema{kind: "bytes", logical: "decimal", precision: 20}, nil
+	case query.Type_FLOAT32:
+		return &avroSchema{kind: "float"}, nil
+	case query.Type_FLOAT64:
+		return &avroSchema{kind: "double"}, nil
+	case query.Type_DECIMAL:
+		dt := t.(gmstypes.DecimalType_)
+		return &avroSchema{kind: "bytes", logical: "decimal", precision: int(dt.Precision()), scale: int(dt.Scale())}, nil
+	case query.Type_DATE:
+		return &avroSchema{kind: "int", logical: "date"}, nil
+	case query.Type_DATETIME:
+		return &avroSchema{kind: "long", logical: "local-timestamp-micros"}, nil
+	case query.Type_TIMESTAMP:
+		return &avroSchema{kind: "long", logical: "timestamp-micros"}, nil
+	case query.Type_TIME:
+		return &avroSchema{kind: "long", logical: "time-micros"}, nil
+	case query.Type_ENUM:
+		// Enum values that are not valid Avro symbols are written as strings
+		values := t.(sql.EnumType).Values()
+		for _, v := range values {
+			if !avroNameRegex.MatchString(v) {
+				return &avroSchema{kind: "string"}, nil
+			}
+		}
+		return &avroSchema{kind: "enum", name: avroName(record + "_" + name), symbols: values}, nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_SET, query.Type_JSON:
+		return &avroSchema{kind: "string"}, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB, query.Type_GEOMETRY:
+		return &avroSchema{kind: "bytes"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type for avro export: %s", t.String())
+	}
+}
+
+// recordForSchema returns the Avro record used to export rows of |sch| as JSON.
+func recordForSchema(tableName string, sch schema.Schema, sqlSch sql.Schema) (*avroSchema, []byte, error) {
+	record := &avroSchema{kind: "record", name: avroName(tableName)}
+	var fields []interface{}
+	for _, col := range sqlSch {
+		typ, err := avroTypeForSqlType(col.Type, record.name, col.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		field := map[string]interface{}{
+			"name":      avroName(col.Name),
+			"type":      typ.jsonValue(),
+			doltTypeKey: col.Type.String(),
+		}
+		if field["name"] != col.Name {
+			field[doltColumnKey] = col.Name
+		}
+		if col.Nullable {
+			typ = &avroSchema{kind: "union", branches: []*avroSchema{{kind: "null"}, typ}}
+			field["type"] = typ.jsonValue()
+			field["default"] = nil
+		}
+		record.fields = append(record.fields, avroField{name: avroName(col.Name), typ: typ})
+		fields = append(fields, field)
+	}
+
+	obj := map[string]interface{}{
+		"type":   "record",
+		"name":   record.name,
+		"fields": fields,
+	}
+	if pks := sch.GetPKCols().GetColumnNames(); len(pks) > 0 {
+		obj[doltPrimaryKeyKey] = pks
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, data, nil
+}
+
+// jsonValue returns the JSON representation of a schema created by avroTypeForSqlType.
+func (s *avroSchema) jsonValue() interface{} {
+	switch s.kind {
+	case "union":
+		branches := make([]interface{}, len(s.branches))
+		for i, b := range s.branches {
+			branches[i] = b.jsonValue()
+		}
+		return branches
+	case "enum":
+		return map[string]interface{}{"type": "enum", "name": s.name, "symbols": s.symbols}
+	}
+	if s.logical == "" {
+		return s.kind
+	}
+	obj := map[string]interface{}{"type": s.kind, "logicalType": s.logical}
+	if s.logical == "decimal" {
+		obj["precision"] = s.precision
+		obj["scale"] = s.scale
+	}
+	return obj
+}
+
+// sqlTypeForField returns the SQL type of the column that stores |f|. Columns written by Dolt carry their SQL type as a
+// field property, otherwise the type is inferred from the Avro type. Timestamps are read as DATETIME, as TIMESTAMP
+// cannot hold instants past 2038, and nested types are read as JSON.
+func sqlTypeForField(ctx context.Context, f avroField) (sql.Type, error) {
+	if typStr, ok := propString(f.props, doltTypeKey); ok {
+		t, err := planbuilder.ParseColumnTypeString(ctx, typStr)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.name, err)
+		}
+		return t, nil
+	}
+
+	s, _ := f.typ.nullable()
+	switch s.logical {
+	case "decimal":
+		dt, err := gmstypes.CreateColumnDecimalType(uint8(s.precision), uint8(s.scale))
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.name, err)
+		}
+		return dt, nil
+	case "date":
+		return gmstypes.Date, nil
+	case "time-millis", "time-micros":
+		return gmstypes.Time, nil
+	case "timestamp-millis", "timestamp-micros", "timestamp-nanos",
+		"local-timestamp-millis", "local-timestamp-micros", "local-timestamp-nanos":
+		return gmstypes.DatetimeMaxPrecision, nil
+	}
+
+	switch s.kind {
+	case "null", "string":
+		return gmstypes.LongText, nil
+	case "boolean":
+		return gmstypes.Boolean, nil
+	case "int":
+		return gmstypes.Int32, nil
+	case "long":
+		return gmstypes.Int64, nil
+	case "float":
+		return gmstypes.Float32, nil
+	case "double":
+		return gmstypes.Float64, nil
+	case "bytes":
+		return gmstypes.LongBlob, nil
+	case "fixed":
+		if s.size > 0 && s.size <= 255 {
+			return gmstypes.MustCreateBinary(sqltypes.Binary, int64(s.size)), nil
+		}
+		return gmstypes.LongBlob, nil
+	case "enum":
+		t, err := gmstypes.CreateEnumType(s.symbols, sql.Collation_Default)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", f.name, err)
+		}
+		return t, nil
+	case "record", "array", "map":
+		return gmstypes.JSON, nil
+	}
+	return nil, fmt.Errorf("column %s: unsupported avro type %s", f.name, s.kind)
+}
+
+// doltSchemaForRecord returns the schema of the rows read from a file with the record schema |rec|, along with the
+// SQL type of each column. Primary keys recorded by Dolt are restored, otherwise the schema is keyless.
+func doltSchemaForRecord(ctx context.Context, rec *avroSchema) (schema.Schema, []sql.Type, error) {
+	if rec.kind != "record" {
+		return nil, nil, fmt.Errorf("avro data must contain records, found %s", rec.kind)
+	}
+
+	var pks []string
+	if rawPks, ok := rec.props[doltPrimaryKeyKey].([]interface{}); ok {
+		for _, pk := range rawPks {
+			if s, ok := pk.(string); ok {
+				pks = append(pks, s)
+			}
+		}
+	}
+	isPk := make(map[string]bool, len(pks))
+	for _, pk := range pks {
+		isPk[pk] = true
+	}
+
+	cols := make([]schema.Column, len(rec.fields))
+	sqlTypes := make([]sql.Type, len(rec.fields))
+	for i, f := range rec.fields {
+		name := f.name
+		if original, ok := propString(f.props, doltColumnKey); ok {
+			name = original
+		}
+		if f.typ.kind == "union" {
+			if _, ok := f.typ.nullable(); !ok {
+				return nil, nil, fmt.Errorf("column %s: avro unions of multiple types are not supported", name)
+			}
+		}
+		t, err := sqlTypeForField(ctx, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		ti, err := typeinfo.FromSqlType(t)
+		if err != nil {
+			return nil, nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		var constraints []schema.ColConstraint
+		if _, nullable := f.typ.nullable(); (!nullable && f.typ.kind != "null") || isPk[name] {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		cols[i], err = schema.NewColumnWithTypeInfo(name, uint64(i), ti, isPk[name], "", false, "", constraints...)
+		if err != nil {
+			return nil, nil, err
+		}
+		sqlTypes[i] = t
+	}
+
+	colColl := schema.NewColCollection(cols...)
+	sch, err := schema.SchemaFromCols(colColl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pks) > 1 {
+		// Restore the declared order of a composite key
+		ords := make([]int, len(pks))
+		for i, pk := range pks {
+			ords[i] = colColl.IndexOf(pk)
+		}
+		if err = sch.SetPkOrdinals(ords); err != nil {
+			return nil, nil, err
+		}
+	}
+	return sch, sqlTypes, nil
+}
+
+// nativeValue returns |val|, a value of the SQL type |t|, in the form goavro encodes as the type |s|.
+func nativeValue(ctx *sql.Context, t sql.Type, s *avroSchema, val interface{}) (interface{}, error) {
+	if s.kind == "union" {
+		if val == nil {
+			return nil, nil
+		}
+		branch := s.branches[1]
+		v, err := nativeValue(ctx, t, branch, val)
+		if err != nil {
+			return nil, err
+		}
+		return goavro.Union(branch.unionName(), v), nil
+	}
+
+	switch s.logical {
+	case "decimal":
+		str, err := sqlutil.SqlColToStr(ctx, t, val)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := new(big.Rat).SetString(str)
+		if !ok {
+			return nil, fmt.Errorf("invalid decimal value '%s'", str)
+		}
+		return r, nil
+	case "date", "local-timestamp-micros", "timestamp-micros":
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		tm := v.(time.Time)
+		if s.logical == "date" {
+			return floorDiv(tm.Unix(), secondsPerDay), nil
+		}
+		return tm.UnixMicro(), nil
+	case "time-micros":
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(gmstypes.Timespan).AsTimeDuration().Microseconds(), nil
+	}
+
+	switch s.kind {
+	case "int", "long":
+		if t.Type() == query.Type_BIT || t.Type() == query.Type_UINT32 {
+			v, _, err := gmstypes.Uint64.Convert(ctx, val)
+			if err != nil {
+				return nil, err
+			}
+			return int64(v.(uint64)), nil
+		}
+		v, _, err := gmstypes.Int64.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(int64), nil
+	case "float":
+		v, _, err := gmstypes.Float32.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(float32), nil
+	case "double":
+		v, _, err := gmstypes.Float64.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(float64), nil
+	case "bytes":
+		if t.Type() == query.Type_GEOMETRY {
+			res, err := t.SQL(ctx, nil, val)
+			if err != nil {
+				return nil, err
+			}
+			// Dolt serializes geometries as a four byte SRID followed by WKB
+			raw := res.Raw()
+			if len(raw) < 4 {
+				return nil, fmt.Errorf("invalid geometry value")
+			}
+			return raw[4:], nil
+		}
+		switch v := val.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		var err error
+		if str, err = sqlutil.SqlColToStr(ctx, t, val); err != nil {
+			return nil, err
+		}
+	}
+	if s.kind == "enum" {
+		for _, sym := range s.symbols {
+			if sym == str {
+				return str, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not a value of enum %s", str, s.name)
+	}
+	if s.kind == "bytes" {
+		return []byte(str), nil
+	}
+	return str, nil
+}
+
+// unionName returns the name goavro gives |s| as a member of a union. Logical types that goavro implements are named
+// after their underlying type and logical type.
+func (s *avroSchema) unionName() string {
+	switch s.kind {
+	case "record", "enum", "fixed":
+		return s.name
+	}
+	switch s.kind + "." + s.logical {
+	case "bytes.decimal", "int.date", "int.time-millis", "long.time-micros", "long.timestamp-millis", "long.timestamp-micros":
+		return s.kind + "." + s.logical
+	}
+	return s.kind
+}
+
+// sqlValue converts |v|, a value decoded by goavro as the Avro type |s|, to a value of the SQL type |t|.
+func sqlValue(t sql.Type, s *avroSchema, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	s, isNullable := s.nullable()
+	if isNullable {
+		// goavro wraps the values of unions in a map keyed by the name of the member type
+		if wrapped, ok := v.(map[string]interface{}); ok && len(wrapped) == 1 {
+			for _, inner := range wrapped {
+				v = inner
+			}
+		}
+	}
+
+	switch s.logical {
+	case "decimal":
+		if r, ok := v.(*big.Rat); ok {
+			return r.FloatString(s.scale), nil
+		}
+	case "date":
+		if tm, ok := v.(time.Time); ok {
+			return tm.UTC(), nil
+		}
+	case "time-millis", "time-micros":
+		if d, ok := v.(time.Duration); ok {
+			return gmstypes.Timespan(d.Microseconds()), nil
+		}
+	case "timestamp-millis", "timestamp-micros":
+		if tm, ok := v.(time.Time); ok {
+			return tm.UTC(), nil
+		}
+	case "local-timestamp-millis":
+		// goavro does not implement the local timestamps, so they are decoded as their underlying long
+		return time.UnixMilli(v.(int64)).UTC(), nil
+	case "local-timestamp-micros":
+		return time.UnixMicro(v.(int64)).UTC(), nil
+	case "timestamp-nanos", "local-timestamp-nanos":
+		return time.Unix(0, v.(int64)).UTC(), nil
+	}
+
+	switch val := v.(type) {
+	case int32:
+		return int64(val), nil
+	case int64:
+		if t.Type() == query.Type_BIT {
+			// BIT values are imported from their string representation
+			return strconv.FormatUint(uint64(val), 10), nil
+		}
+	case []byte:
+		if st, ok := t.(sql.SpatialColumnType); ok {
+			// Geometries are stored as WKB and are prefixed with the SRID of the column so that they are in Dolt's
+			// geometry serialization format
+			srid, _ := st.GetSpatialTypeSRID()
+			out := binary.LittleEndian.AppendUint32(make([]byte, 0, len(val)+4), srid)
+			return append(out, val...), nil
+		}
+	case map[string]interface{}, []interface{}:
+		doc, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		return string(doc), nil
+	}
+	return v, nil
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
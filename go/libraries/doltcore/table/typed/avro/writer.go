@@ -0,0 +1,119 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+// blockRows is the number of rows written in each block
+const blockRows = 4096
+
+// AvroWriter implements table.SqlRowWriter. It writes rows as an Avro object container file compressed with deflate.
+// Encoding is done by goavro.
+type AvroWriter struct {
+	closer io.Closer
+	wr     *bufio.Writer
+	ocf    *goavro.OCFWriter
+	sqlSch sql.Schema
+	record *avroSchema
+	block  []interface{}
+}
+
+var _ table.SqlRowWriter = (*AvroWriter)(nil)
+
+// NewAvroWriter returns an AvroWriter that writes rows of |sch| to |wr|. The record schema of the file is named after
+// |tableName|.
+func NewAvroWriter(ctx context.Context, wr io.WriteCloser, sch schema.Schema, tableName string) (*AvroWriter, error) {
+	sqlSch, err := sqlutil.FromDoltSchema(ctx, "", tableName, sch)
+	if err != nil {
+		return nil, err
+	}
+	record, schemaJson, err := recordForSchema(tableName, sch, sqlSch.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// |wr| is wrapped so that goavro never treats it as an existing file to append to
+	bw := bufio.NewWriterSize(wr, 1024*1024)
+	ocf, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               bw,
+		Schema:          string(schemaJson),
+		CompressionName: goavro.CompressionDeflateLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AvroWriter{closer: wr, wr: bw, ocf: ocf, sqlSch: sqlSch.Schema, record: record}, nil
+}
+
+// WriteSqlRow implements table.SqlRowWriter.
+func (aw *AvroWriter) WriteSqlRow(ctx *sql.Context, r sql.Row) error {
+	values := make(map[string]interface{}, len(aw.record.fields))
+	for i, f := range aw.record.fields {
+		v, err := nativeValue(ctx, aw.sqlSch[i].Type, f.typ, r[i])
+		if err != nil {
+			return err
+		}
+		values[f.name] = v
+	}
+	aw.block = append(aw.block, values)
+	if len(aw.block) >= blockRows {
+		return aw.flushBlock()
+	}
+	return nil
+}
+
+// flushBlock writes the buffered rows as a single block.
+func (aw *AvroWriter) flushBlock() error {
+	if err := aw.ocf.Append(aw.block); err != nil {
+		return err
+	}
+	aw.block = aw.block[:0]
+	return nil
+}
+
+// Close implements table.SqlRowWriter.
+func (aw *AvroWriter) Close(ctx context.Context) error {
+	if aw.closer == nil {
+		return errors.New("already closed")
+	}
+
+	var err error
+	if len(aw.block) > 0 {
+		err = aw.flushBlock()
+	}
+	errFl := aw.wr.Flush()
+	errCl := aw.closer.Close()
+	aw.closer = nil
+
+	if err != nil {
+		return err
+	}
+	if errFl != nil {
+		return errFl
+	}
+	return errCl
+}
@@ -197,46 +197,55 @@ func (j *RowWriter) jsonDataForSchema(ctx *sql.Context, row sql.Row) ([]byte, er
 func (j *RowWriter) jsonDataForSqlSchema(ctx *sql.Context, row sql.Row) ([]byte, error) {
 	colValMap := make(map[string]interface{}, len(j.sqlSch))
 	for i, col := range j.sqlSch {
-		val := row[i]
-		if val == nil {
+		if row[i] == nil {
 			continue
 		}
-
-		switch col.Type.(type) {
-		case sql.DatetimeType,
-			sql.DecimalType,
-			sql.EnumType,
-			sql.StringType,
-			sql.SetType,
-			types.TupleType:
-			sqlVal, err := col.Type.SQL(ctx, nil, val)
-			if err != nil {
-				return nil, err
-			}
-			val = sqlVal.ToString()
-		case types.JsonType:
-			sqlVal, err := col.Type.SQL(ctx, nil, val)
-			if err != nil {
-				return nil, err
-			}
-			str := sqlVal.ToString()
-
-			// This is kind of silly: we are unmarshalling JSON just to marshall it back again
-			// But it makes marshalling much simpler
-			// Reset val so we don't unmarshall into the old value.
-			val = nil
-			err = json.Unmarshal([]byte(str), &val)
-			if err != nil {
-				return nil, err
-			}
+		val, err := SqlValueToJSON(ctx, col.Type, row[i])
+		if err != nil {
+			return nil, err
 		}
-
 		colValMap[col.Name] = val
 	}
 
 	return types.MarshallJsonValue(colValMap)
 }
 
+// SqlValueToJSON returns |val|, a value of type |typ|, as a value that marshals to its JSON representation.
+func SqlValueToJSON(ctx *sql.Context, typ sql.Type, val interface{}) (interface{}, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	switch typ.(type) {
+	case sql.DatetimeType,
+		sql.DecimalType,
+		sql.EnumType,
+		sql.StringType,
+		sql.SetType,
+		types.TupleType:
+		sqlVal, err := typ.SQL(ctx, nil, val)
+		if err != nil {
+			return nil, err
+		}
+		return sqlVal.ToString(), nil
+	case types.JsonType:
+		sqlVal, err := typ.SQL(ctx, nil, val)
+		if err != nil {
+			return nil, err
+		}
+		str := sqlVal.ToString()
+
+		// This is kind of silly: we are unmarshalling JSON just to marshall it back again
+		// But it makes marshalling much simpler
+		var doc interface{}
+		if err = json.Unmarshal([]byte(str), &doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+	return val, nil
+}
+
 func (j *RowWriter) Flush() error {
 	return j.bWr.Flush()
 }
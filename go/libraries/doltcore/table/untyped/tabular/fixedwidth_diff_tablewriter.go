@@ -97,7 +97,7 @@ func (w FixedWidthDiffTableWriter) WriteCombinedRow(ctx *sql.Context, oldRow, ne
 		if err != nil {
 			return err
 		}
-		combinedRow[i+1], columnDiffs[i+1], widths[i+1] = w.generateTextDiff(oldRowStrs[i+1], newRowStrs[i+1], mode == diff.ModeInPlace)
+		combinedRow[i+1], columnDiffs[i+1], widths[i+1] = w.generateTextDiff(oldRowStrs[i+1], newRowStrs[i+1], mode)
 		hasNewlines = hasNewlines || (columnDiffs[i+1] && len(widths[i+1].Lines) > 2) || (!columnDiffs[i+1] && len(widths[i+1].Lines) > 1)
 	}
 
@@ -120,8 +120,9 @@ func (w FixedWidthDiffTableWriter) WriteCombinedRow(ctx *sql.Context, oldRow, ne
 }
 
 // generateTextDiff returns a new string that represents a diff between the old and new string. The returned string will
-// have color applied to it.
-func (w FixedWidthDiffTableWriter) generateTextDiff(oldStr string, newStr string, inPlace bool) (result string, hasDiff bool, width FixedWidthString) {
+// have color applied to it. In-place and word modes color only the changed spans, while other modes prefix changed
+// lines with "+" and "-". Without color, word mode marks removed spans as [-text-] and added spans as {+text+}.
+func (w FixedWidthDiffTableWriter) generateTextDiff(oldStr string, newStr string, mode diff.Mode) (result string, hasDiff bool, width FixedWidthString) {
 	// The diff routines will modify the strings, and we should just return the original if there will be no diff
 	if oldStr == newStr {
 		return oldStr, false, NewFixedWidthString(oldStr)
@@ -131,28 +132,32 @@ func (w FixedWidthDiffTableWriter) generateTextDiff(oldStr string, newStr string
 	var coloredStr strings.Builder
 	// uncoloredStr is the string that is measured to determine display width, as the colors interfere with measuring
 	var uncoloredStr strings.Builder
-	if inPlace {
+	switch mode {
+	case diff.ModeInPlace:
 		dmp := diffmatchpatch.New()
 		diffs := dmp.DiffMain(oldStr, newStr, false)
 		for _, diffPart := range diffs {
-			uncoloredStr.WriteString(diffPart.Text)
-			// We need to end color before any newlines, and reapply it after newlines, else the color will trail to the
-			// next line.
-			for i, part := range strings.Split(diffPart.Text, "\n") {
-				if i > 0 {
-					coloredStr.WriteRune('\n')
-				}
-				switch diffPart.Type {
-				case diffmatchpatch.DiffEqual:
-					coloredStr.WriteString(part)
-				case diffmatchpatch.DiffInsert:
-					coloredStr.WriteString(colorModifiedNew.Sprint(part))
-				case diffmatchpatch.DiffDelete:
-					coloredStr.WriteString(colorModifiedOld.Sprint(part))
-				}
+			switch diffPart.Type {
+			case diffmatchpatch.DiffEqual:
+				writeColoredSpan(&coloredStr, &uncoloredStr, diffPart.Text, nil)
+			case diffmatchpatch.DiffInsert:
+				writeColoredSpan(&coloredStr, &uncoloredStr, diffPart.Text, colorModifiedNew)
+			case diffmatchpatch.DiffDelete:
+				writeColoredSpan(&coloredStr, &uncoloredStr, diffPart.Text, colorModifiedOld)
+			}
+		}
+	case diff.ModeWord:
+		for _, span := range diff.TextDiff(oldStr, newStr, diff.WordGranularity) {
+			switch span.Type {
+			case diff.None:
+				writeColoredSpan(&coloredStr, &uncoloredStr, span.Text, nil)
+			case diff.Added:
+				writeColoredSpan(&coloredStr, &uncoloredStr, markWordSpan(span.Text, "{+", "+}"), colorModifiedNew)
+			case diff.Removed:
+				writeColoredSpan(&coloredStr, &uncoloredStr, markWordSpan(span.Text, "[-", "-]"), colorModifiedOld)
 			}
 		}
-	} else {
+	default:
 		diffStrs := strings.Split(computeDiff.Diff(oldStr, newStr), "\n")
 		for i, diffStr := range diffStrs {
 			if i > 0 {
@@ -175,6 +180,32 @@ func (w FixedWidthDiffTableWriter) generateTextDiff(oldStr string, newStr string
 	return coloredStr.String(), true, ColoredStringWidth(coloredStr.String(), uncoloredStr.String())
 }
 
+// writeColoredSpan writes |text| to |coloredStr| in color |c|, or uncolored if |c| is nil, and to |uncoloredStr|.
+func writeColoredSpan(coloredStr, uncoloredStr *strings.Builder, text string, c *color.Color) {
+	uncoloredStr.WriteString(text)
+	// We need to end color before any newlines, and reapply it after newlines, else the color will trail to the next
+	// line.
+	for i, part := range strings.Split(text, "\n") {
+		if i > 0 {
+			coloredStr.WriteRune('\n')
+		}
+		if c == nil {
+			coloredStr.WriteString(part)
+		} else {
+			coloredStr.WriteString(c.Sprint(part))
+		}
+	}
+}
+
+// markWordSpan returns |text| enclosed in |open| and |close| when color is disabled, so that the words that changed are
+// still visible in a word diff, and |text| unchanged otherwise.
+func markWordSpan(text, open, close string) string {
+	if !color.NoColor {
+		return text
+	}
+	return open + text + close
+}
+
 func colorsForDiffTypes(colDiffTypes []diff.ChangeType) []*color.Color {
 	colors := make([]*color.Color, len(colDiffTypes))
 	for i := range colDiffTypes {
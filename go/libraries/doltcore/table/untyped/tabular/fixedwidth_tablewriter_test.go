@@ -21,7 +21,10 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 )
 
 const (
@@ -201,3 +204,20 @@ func TestFixedWidthWriter(t *testing.T) {
 		assert.Equal(t, expectedTableString, stringWr.String())
 	})
 }
+
+func TestWordDiffWithoutColor(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() {
+		color.NoColor = noColor
+	}()
+
+	w := FixedWidthDiffTableWriter{}
+	result, hasDiff, _ := w.generateTextDiff("meet on Monday to review", "meet on Tuesday to review", diff.ModeWord)
+	assert.True(t, hasDiff)
+	assert.Equal(t, "meet on [-Monday-]{+Tuesday+} to review", result)
+
+	result, hasDiff, _ = w.generateTextDiff("same", "same", diff.ModeWord)
+	assert.False(t, hasDiff)
+	assert.Equal(t, "same", result)
+}
@@ -33,6 +33,8 @@ var ConfigOptions = map[string]struct{}{
 	ProfileKey:                 {},
 	VersionCheckDisabled:       {},
 	MmapArchiveIndexes:         {},
+	LazyFetchRemoteKey:         {},
+	SparseTablesKey:            {},
 }
 
 const UserEmailKey = "user.email"
@@ -74,3 +76,7 @@ const SignCommitsKey = "commit.gpgsign"
 const GPGSigningKeyKey = "user.signingkey"
 
 const MmapArchiveIndexes = "mmap_archive_indexes"
+
+const LazyFetchRemoteKey = "lazyfetch.remote"
+
+const SparseTablesKey = "sparse.tables"
@@ -36,6 +36,16 @@ func NewBlobRange(offset, length int64) BlobRange {
 	return BlobRange{offset, length}
 }
 
+// Offset returns the offset of the range. A negative offset is relative to the end of the blob.
+func (br BlobRange) Offset() int64 {
+	return br.offset
+}
+
+// Length returns the length of the range, or 0 if it extends to the end of the blob.
+func (br BlobRange) Length() int64 {
+	return br.length
+}
+
 // IsAllRange is true if it represents the entire blob from index 0 to the end
 // and false if it is any subset of the data
 func (br BlobRange) isAllRange() bool {
@@ -298,7 +298,7 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, addr hash.Hash, w
 
 				if _, ok := targetCmt.(types.SerialMessage); ok {
 					// TODO - construct new meta instance rather than using the default
-					updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil)
+					updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil, nil)
 					ref, err := db.WriteValue(ctx, types.SerialMessage(updateWS))
 					if err != nil {
 						return prolly.AddressMap{}, err
@@ -311,7 +311,7 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, addr hash.Hash, w
 				}
 			} else {
 				// TODO - construct new meta instance rather than using the default
-				updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil)
+				updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil, nil)
 				ref, err := db.WriteValue(ctx, types.SerialMessage(updateWS))
 				if err != nil {
 					return prolly.AddressMap{}, err
@@ -452,7 +452,7 @@ func (db *database) doFastForward(ctx context.Context, ds Dataset, newHeadAddr h
 					}
 
 					// TODO - construct new meta instance rather than using the default
-					updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil)
+					updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil, nil)
 					ref, err := db.WriteValue(ctx, types.SerialMessage(updateWS))
 					if err != nil {
 						return prolly.AddressMap{}, err
@@ -464,7 +464,7 @@ func (db *database) doFastForward(ctx context.Context, ds Dataset, newHeadAddr h
 					return prolly.AddressMap{}, errors.New("Modern Dolt Database required.")
 				}
 			} else {
-				updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil)
+				updateWS := workingset_flatbuffer(cmtRtHsh, &cmtRtHsh, nil, nil, nil, nil)
 				ref, err := db.WriteValue(ctx, types.SerialMessage(updateWS))
 				if err != nil {
 					return prolly.AddressMap{}, err
@@ -158,9 +158,28 @@ type WorkingSetHead struct {
 	StagedAddr  *hash.Hash
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 	WorkingAddr hash.Hash
 }
 
+type BisectState struct {
+	badCommitAddr      hash.Hash
+	goodCommitAddrs    []hash.Hash
+	skippedCommitAddrs []hash.Hash
+}
+
+func (bs *BisectState) BadCommitAddr() hash.Hash {
+	return bs.badCommitAddr
+}
+
+func (bs *BisectState) GoodCommitAddrs() []hash.Hash {
+	return bs.goodCommitAddrs
+}
+
+func (bs *BisectState) SkippedCommitAddrs() []hash.Hash {
+	return bs.skippedCommitAddrs
+}
+
 type RebaseState struct {
 	preRebaseWorkingAddr       *hash.Hash
 	ontoCommitAddr             *hash.Hash
@@ -394,6 +413,18 @@ func (h serialWorkingSetHead) HeadWorkingSet() (*WorkingSetHead, error) {
 		)
 	}
 
+	bisectState, err := h.msg.TryBisectState(nil)
+	if err != nil {
+		return nil, err
+	}
+	if bisectState != nil {
+		var bad hash.Hash
+		if bisectState.BadCommitAddrLength() != 0 {
+			bad = hash.New(bisectState.BadCommitAddrBytes())
+		}
+		ret.BisectState = NewBisectState(bad, parseAddrs(bisectState.GoodCommitAddrsBytes()), parseAddrs(bisectState.SkippedCommitAddrsBytes()))
+	}
+
 	return &ret, nil
 }
 
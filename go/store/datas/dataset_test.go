@@ -282,3 +282,28 @@ func TestValidateDatasetId(t *testing.T) {
 		})
 	}
 }
+
+func TestBisectStateAddrs(t *testing.T) {
+	working, staged := hash.Of([]byte("working")), hash.Of([]byte("staged"))
+	bad := hash.Of([]byte("bad"))
+	goods := []hash.Hash{hash.Of([]byte("good1")), hash.Of([]byte("good2"))}
+	skipped := []hash.Hash{hash.Of([]byte("skipped"))}
+
+	msg := workingset_flatbuffer(working, &staged, nil, nil, NewBisectState(bad, goods, skipped), nil)
+	head, err := newSerialWorkingSetHead(msg, hash.Hash{})
+	assert.NoError(t, err)
+	ws, err := head.HeadWorkingSet()
+	assert.NoError(t, err)
+	assert.Equal(t, bad, ws.BisectState.BadCommitAddr())
+	assert.Equal(t, goods, ws.BisectState.GoodCommitAddrs())
+	assert.Equal(t, skipped, ws.BisectState.SkippedCommitAddrs())
+
+	// the commits of a bisect are kept reachable from the working set
+	var addrs []hash.Hash
+	err = types.SerialMessage(msg).WalkAddrs(types.Format_DOLT, func(addr hash.Hash) error {
+		addrs = append(addrs, addr)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []hash.Hash{working, staged, bad, goods[0], goods[1], skipped[0]}, addrs)
+}
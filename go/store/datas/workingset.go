@@ -39,6 +39,7 @@ type WorkingSetSpec struct {
 	Meta        *WorkingSetMeta
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 	WorkingRoot types.Ref
 	StagedRoot  types.Ref
 }
@@ -66,9 +67,10 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 	stagedRef := workingSetSpec.StagedRoot
 	mergeState := workingSetSpec.MergeState
 	rebaseState := workingSetSpec.RebaseState
+	bisectState := workingSetSpec.BisectState
 
 	stagedAddr := stagedRef.TargetHash()
-	data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, meta)
+	data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, bisectState, meta)
 
 	r, err := db.WriteValue(ctx, types.SerialMessage(data))
 	if err != nil {
@@ -84,10 +86,10 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 }
 
 // workingset_flatbuffer creates a flatbuffer message for working set metadata.
-func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, meta *WorkingSetMeta) serial.Message {
+func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, bisectState *BisectState, meta *WorkingSetMeta) serial.Message {
 	builder := flatbuffers.NewBuilder(1024)
 	workingoff := builder.CreateByteVector(working[:])
-	var stagedOff, mergeStateOff, rebaseStateOffset flatbuffers.UOffsetT
+	var stagedOff, mergeStateOff, rebaseStateOffset, bisectStateOffset flatbuffers.UOffsetT
 	if staged != nil {
 		stagedOff = builder.CreateByteVector((*staged)[:])
 	}
@@ -136,6 +138,22 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		rebaseStateOffset = serial.RebaseStateEnd(builder)
 	}
 
+	if bisectState != nil {
+		var badOffset flatbuffers.UOffsetT
+		if !bisectState.badCommitAddr.IsEmpty() {
+			badOffset = builder.CreateByteVector(bisectState.badCommitAddr[:])
+		}
+		goodOffset := builder.CreateByteVector(concatAddrs(bisectState.goodCommitAddrs))
+		skippedOffset := builder.CreateByteVector(concatAddrs(bisectState.skippedCommitAddrs))
+		serial.BisectStateStart(builder)
+		if badOffset != 0 {
+			serial.BisectStateAddBadCommitAddr(builder, badOffset)
+		}
+		serial.BisectStateAddGoodCommitAddrs(builder, goodOffset)
+		serial.BisectStateAddSkippedCommitAddrs(builder, skippedOffset)
+		bisectStateOffset = serial.BisectStateEnd(builder)
+	}
+
 	var nameOff, emailOff, descOff flatbuffers.UOffsetT
 	if meta != nil {
 		nameOff = builder.CreateString(meta.Name)
@@ -154,6 +172,9 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 	if rebaseStateOffset != 0 {
 		serial.WorkingSetAddRebaseState(builder, rebaseStateOffset)
 	}
+	if bisectStateOffset != 0 {
+		serial.WorkingSetAddBisectState(builder, bisectStateOffset)
+	}
 
 	if meta != nil {
 		serial.WorkingSetAddName(builder, nameOff)
@@ -204,3 +225,29 @@ func NewRebaseState(preRebaseWorkingRoot hash.Hash, commitAddr hash.Hash, branch
 		skipVerification:           skipVerification,
 	}
 }
+
+func NewBisectState(badCommitAddr hash.Hash, goodCommitAddrs []hash.Hash, skippedCommitAddrs []hash.Hash) *BisectState {
+	return &BisectState{
+		badCommitAddr:      badCommitAddr,
+		goodCommitAddrs:    goodCommitAddrs,
+		skippedCommitAddrs: skippedCommitAddrs,
+	}
+}
+
+// concatAddrs returns |addrs| as a single byte slice, the way commit parent addresses are serialized.
+func concatAddrs(addrs []hash.Hash) []byte {
+	bs := make([]byte, 0, len(addrs)*hash.ByteLen)
+	for _, addr := range addrs {
+		bs = append(bs, addr[:]...)
+	}
+	return bs
+}
+
+// parseAddrs splits a byte slice serialized by concatAddrs back into addresses.
+func parseAddrs(bs []byte) []hash.Hash {
+	addrs := make([]hash.Hash, len(bs)/hash.ByteLen)
+	for i := range addrs {
+		addrs[i] = hash.New(bs[i*hash.ByteLen : (i+1)*hash.ByteLen])
+	}
+	return addrs
+}
@@ -220,9 +220,9 @@ func buildArchiveReader(ctx context.Context, reader tableReaderAt, footer archiv
 
 	var indexRdr archiveIndexReader
 
-	// Try to use memory mapping if the reader is a file
+	// Try to use memory mapping if the reader is an unencrypted file
 	if fileReader, ok := reader.(*fileReaderAt); ok && fileReader.mmapIndexes {
-		indexRdr, err = newMmapIndexReader(fileReader.f, footer)
+		indexRdr, err = newMmapIndexReader(fileReader.f.(plainFile).File, footer)
 		if err != nil {
 			return archiveReader{}, err
 		}
@@ -140,7 +140,7 @@ func main() {
 			cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion("us-west-2"))
 			d.PanicIfError(err)
 			open = func() (chunks.ChunkStore, error) {
-				return nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), dynamoTable, *toAWS, s3Bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), bufSize, nbs.NewUnlimitedMemQuotaProvider())
+				return nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), dynamoTable, *toAWS, s3Bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), bufSize, nbs.NewUnlimitedMemQuotaProvider(), nil)
 			}
 			reset = func() {
 				ddb := dynamodb.NewFromConfig(cfg)
@@ -170,7 +170,7 @@ func main() {
 			cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion("us-west-2"))
 			d.PanicIfError(err)
 			open = func() (chunks.ChunkStore, error) {
-				return nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), dynamoTable, *useAWS, s3Bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), bufSize, nbs.NewUnlimitedMemQuotaProvider())
+				return nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), dynamoTable, *useAWS, s3Bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), bufSize, nbs.NewUnlimitedMemQuotaProvider(), nil)
 			}
 		}
 		writeDB = func() {}
@@ -59,7 +59,7 @@ func TestBlobstoreSuite(t *testing.T) {
 		nbf := constants.FormatDefaultString
 		qp := NewUnlimitedMemQuotaProvider()
 		bs := blobstore.NewLocalBlobstore(dir)
-		return NewBSStore(ctx, nbf, bs, testMemTableSize, qp)
+		return NewBSStore(ctx, nbf, bs, testMemTableSize, qp, nil)
 	}
 	suite.Run(t, &BlockStoreSuite{factory: fn})
 }
@@ -32,13 +32,19 @@ import (
 )
 
 func flushSinkToFile(sink ByteSink, path string) (err error) {
-	var f *os.File
-	f, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+	var osf *os.File
+	osf, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
 
 	if err != nil {
 		return err
 	}
 
+	f, err := newStorageFile(osf)
+	if err != nil {
+		osf.Close()
+		return err
+	}
+
 	defer func() {
 		closeErr := f.Close()
 
@@ -219,9 +225,16 @@ type BufferedFileByteSink struct {
 
 // NewBufferedFileByteSink creates a BufferedFileByteSink
 func NewBufferedFileByteSink(tempDir string, blockSize, chBufferSize int) (*BufferedFileByteSink, error) {
-	f, err := tempfiles.MovableTempFileProvider.NewFile(tempDir, "buffered_file_byte_sink_")
+	tmp, err := tempfiles.MovableTempFileProvider.NewFile(tempDir, "buffered_file_byte_sink_")
+
+	if err != nil {
+		return nil, err
+	}
 
+	// the temp file becomes a table file in FlushToFile, so it is encrypted like one
+	f, err := newStorageFile(tmp)
 	if err != nil {
+		tmp.Close()
 		return nil, err
 	}
 
@@ -311,8 +324,8 @@ func (sink *BufferedFileByteSink) Flush(wr io.Writer) (err error) {
 		return err
 	}
 
-	var f *os.File
-	f, err = os.Open(sink.path)
+	var f storageFile
+	f, err = openStorageFile(sink.path, os.O_RDONLY)
 
 	if err != nil {
 		return err
@@ -346,7 +359,7 @@ func (sink *BufferedFileByteSink) Reader() (io.ReadCloser, error) {
 	if err != nil {
 		return nil, err
 	}
-	return os.Open(sink.path)
+	return openStorageFile(sink.path, os.O_RDONLY)
 }
 
 // HashingByteSink is a ByteSink that keeps an hash of all the data written to it.
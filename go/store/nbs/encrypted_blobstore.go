@@ -0,0 +1,365 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// encryptedBlobstore is a blobstore.Blobstore that encrypts the blobs it stores with the current key of |keys|, in the
+// format of encrypted table files, so that a remote or backup holds only ciphertext and the key never leaves the
+// client. |keys| belong to the remote or backup, and are independent of the keys that local stores are encrypted with
+// at rest. Blobs are decrypted as they are read, and blobs that are not encrypted are read as they are. Without keys,
+// blobs are stored in plaintext and reading an encrypted blob fails with ErrNoEncryptionKey.
+//
+// Encrypted blobs cannot be concatenated by the underlying blobstore, so stores that encrypt use a
+// singleBlobBSPersister, see NewBSStore.
+type encryptedBlobstore struct {
+	bs   blobstore.Blobstore
+	keys KeyProvider
+
+	mu sync.Mutex
+	// ciphers holds the blockCipher of each blob read by range, or nil if it is not encrypted
+	ciphers map[string]*blockCipher
+}
+
+var _ blobstore.Blobstore = (*encryptedBlobstore)(nil)
+
+// newEncryptedBlobstore returns an encryptedBlobstore over |bs| that encrypts with |keys|, which may be nil.
+func newEncryptedBlobstore(bs blobstore.Blobstore, keys KeyProvider) *encryptedBlobstore {
+	return &encryptedBlobstore{bs: bs, keys: keys, ciphers: make(map[string]*blockCipher)}
+}
+
+func (ebs *encryptedBlobstore) Path() string {
+	return ebs.bs.Path()
+}
+
+func (ebs *encryptedBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	return ebs.bs.Exists(ctx, key)
+}
+
+// Get returns the plaintext of the range |br| of the blob |key|, along with the size of its plaintext.
+func (ebs *encryptedBlobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, uint64, string, error) {
+	if br.Offset() == 0 && br.Length() == 0 {
+		return ebs.getAll(ctx, key)
+	}
+
+	c, err := ebs.blobCipher(ctx, key)
+	if err != nil {
+		return nil, 0, "", err
+	} else if c == nil {
+		return ebs.bs.Get(ctx, key, br)
+	}
+
+	size := c.blobSize()
+	off, length := br.Offset(), br.Length()
+	if off < 0 {
+		off = max(size+off, 0)
+	}
+	if length == 0 || off+length > size {
+		length = max(size-off, 0)
+	}
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), uint64(size), "", nil
+	}
+
+	first, last := off/encBlockSize, (off+length-1)/encBlockSize
+	rc, _, ver, err := ebs.bs.Get(ctx, key, blobstore.NewBlobRange(blockOffset(first), (last-first+1)*encPageSize))
+	if err != nil {
+		return nil, 0, "", err
+	}
+	r := &openingReader{c: c, rc: rc, next: first, skip: off - first*encBlockSize, remaining: length, onErr: func() { ebs.forget(key) }}
+	return r, uint64(size), ver, nil
+}
+
+// getAll returns the whole plaintext of the blob |key|. Blobs read whole, like the manifest, may be rewritten, so their
+// header is read from the same response as their contents instead of from |ebs.ciphers|.
+func (ebs *encryptedBlobstore) getAll(ctx context.Context, key string) (io.ReadCloser, uint64, string, error) {
+	rc, sz, ver, err := ebs.bs.Get(ctx, key, blobstore.AllRange)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	hdr := make([]byte, encHeaderSize)
+	n, err := io.ReadFull(rc, hdr)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		rc.Close()
+		return nil, 0, "", err
+	}
+	c, err := ebs.openHeader(key, hdr[:n])
+	if err != nil {
+		rc.Close()
+		return nil, 0, "", err
+	} else if c == nil {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(hdr[:n]), rc), rc}, sz, ver, nil
+	} else if n < encHeaderSize {
+		rc.Close()
+		return nil, 0, "", fmt.Errorf("%w: %s has a truncated encryption header", ErrDecryptionFailed, ebs.name(key))
+	}
+
+	size := c.blobSize()
+	return &openingReader{c: c, rc: rc, remaining: size}, uint64(size), ver, nil
+}
+
+// blobCipher returns the blockCipher of the blob |key|, or nil if it is not encrypted, reading its header if it has not
+// been read before.
+func (ebs *encryptedBlobstore) blobCipher(ctx context.Context, key string) (*blockCipher, error) {
+	ebs.mu.Lock()
+	c, ok := ebs.ciphers[key]
+	ebs.mu.Unlock()
+	if ok {
+		return c, nil
+	} else if ebs.keys == nil {
+		// an encrypted remote cannot be opened without a key, as its manifest is encrypted
+		return nil, nil
+	}
+
+	hdr, _, err := blobstore.GetBytes(ctx, ebs.bs, key, blobstore.NewBlobRange(0, int64(encHeaderLen)))
+	if err != nil {
+		return nil, err
+	}
+	if c, err = ebs.openHeader(key, hdr); err != nil {
+		return nil, err
+	}
+	ebs.remember(key, c)
+	return c, nil
+}
+
+// openHeader returns the blockCipher of the blob |key|, whose contents start with |hdr|, or nil if it is not encrypted.
+func (ebs *encryptedBlobstore) openHeader(key string, hdr []byte) (*blockCipher, error) {
+	c, err := openEncryptionHeader(ebs.name(key), hdr, ebs.keys)
+	if errors.Is(err, ErrNoEncryptionKey) {
+		return nil, fmt.Errorf("%w; configure the encryption keyfile of the remote or backup", err)
+	}
+	return c, err
+}
+
+func (ebs *encryptedBlobstore) remember(key string, c *blockCipher) {
+	ebs.mu.Lock()
+	defer ebs.mu.Unlock()
+	ebs.ciphers[key] = c
+}
+
+func (ebs *encryptedBlobstore) forget(key string) {
+	ebs.mu.Lock()
+	defer ebs.mu.Unlock()
+	delete(ebs.ciphers, key)
+}
+
+func (ebs *encryptedBlobstore) name(key string) string {
+	if p := ebs.bs.Path(); p != "" {
+		return p + "/" + key
+	}
+	return key
+}
+
+// seal returns a reader of the encrypted blob |key| with the |size| bytes of plaintext read from |r|, along with its
+// size. Without keys, |r| is returned as it is.
+func (ebs *encryptedBlobstore) seal(key string, size int64, r io.Reader) (io.Reader, int64, error) {
+	if ebs.keys == nil {
+		ebs.forget(key)
+		return r, size, nil
+	}
+	ek, err := ebs.keys.CurrentKey()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting encryption key: %w", err)
+	}
+	hdr, err := newEncryptionHeader(ek, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	c, err := newBlockCipher(ebs.name(key), ek, hdr)
+	if err != nil {
+		return nil, 0, err
+	}
+	// some blobstores keep an existing blob rather than replacing it, so its header is read again when it is next read
+	ebs.forget(key)
+	sr := &sealingReader{c: c, src: r, remaining: size}
+	return io.MultiReader(bytes.NewReader(hdr), sr), encryptedFileSize(size), nil
+}
+
+func (ebs *encryptedBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	r, sz, err := ebs.seal(key, totalSize, reader)
+	if err != nil {
+		return "", err
+	}
+	return ebs.bs.Put(ctx, key, sz, r)
+}
+
+func (ebs *encryptedBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	r, sz, err := ebs.seal(key, totalSize, reader)
+	if err != nil {
+		return "", err
+	}
+	return ebs.bs.CheckAndPut(ctx, expectedVersion, key, sz, r)
+}
+
+// Concatenate reads the plaintext of |sources| and stores it as the new blob |key|, as encrypted blobs cannot be
+// concatenated in place.
+func (ebs *encryptedBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	if ebs.keys == nil {
+		ebs.forget(key)
+		return ebs.bs.Concatenate(ctx, key, sources)
+	}
+
+	readers := make([]io.Reader, 0, len(sources))
+	var total int64
+	defer func() {
+		for _, r := range readers {
+			r.(io.Closer).Close()
+		}
+	}()
+	for _, src := range sources {
+		rc, sz, _, err := ebs.getAll(ctx, src)
+		if err != nil {
+			return "", err
+		}
+		readers = append(readers, rc)
+		total += int64(sz)
+	}
+	return ebs.Put(ctx, key, total, io.MultiReader(readers...))
+}
+
+func (ebs *encryptedBlobstore) Teardown(ctx context.Context) error {
+	return ebs.bs.Teardown(ctx)
+}
+
+func (ebs *encryptedBlobstore) Close() error {
+	if c, ok := ebs.bs.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// RangeReadsWholeBlob reports whether the underlying blobstore reads whole blobs for ranged reads, see shouldSpool.
+func (ebs *encryptedBlobstore) RangeReadsWholeBlob() bool {
+	return shouldSpool(ebs.bs)
+}
+
+// sealingReader encrypts the |remaining| bytes read from |src| as the pages of an encrypted blob.
+type sealingReader struct {
+	c         *blockCipher
+	src       io.Reader
+	remaining int64
+	next      int64
+	block     []byte
+	buf       []byte
+}
+
+// sealingBatchBlocks is the number of blocks sealingReader encrypts at a time.
+const sealingBatchBlocks = 64
+
+func (r *sealingReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.remaining == 0 {
+			return 0, io.EOF
+		}
+		if r.block == nil {
+			r.block = make([]byte, sealingBatchBlocks*encBlockSize)
+		}
+		n := min(r.remaining, int64(len(r.block)))
+		if _, err := io.ReadFull(r.src, r.block[:n]); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, fmt.Errorf("error encrypting %s: its contents are shorter than its size", r.c.name)
+		} else if err != nil {
+			return 0, err
+		}
+		sealed, err := r.c.sealBlocks(r.next, r.block[:n])
+		if err != nil {
+			return 0, err
+		}
+		r.next += (n + encBlockSize - 1) / encBlockSize
+		r.remaining -= n
+		r.buf = sealed
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// openingReader decrypts the pages of an encrypted blob read from |rc|, starting at block |next|, skipping the first
+// |skip| bytes of plaintext and returning the |remaining| bytes after them.
+type openingReader struct {
+	c         *blockCipher
+	rc        io.ReadCloser
+	next      int64
+	skip      int64
+	remaining int64
+	// onErr is called if the blob cannot be decrypted
+	onErr func()
+
+	page []byte
+	out  []byte
+	buf  []byte
+}
+
+func (r *openingReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.remaining == 0 {
+			return 0, io.EOF
+		}
+		data, err := r.openNext()
+		if err != nil {
+			if errors.Is(err, ErrDecryptionFailed) && r.onErr != nil {
+				r.onErr()
+			}
+			return 0, err
+		}
+		data = data[min(r.skip, int64(len(data))):]
+		r.skip = 0
+		if int64(len(data)) > r.remaining {
+			data = data[:r.remaining]
+		}
+		r.remaining -= int64(len(data))
+		r.buf = data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *openingReader) openNext() ([]byte, error) {
+	if r.page == nil {
+		r.page = make([]byte, encPageSize)
+		r.out = make([]byte, 0, encBlockSize)
+	}
+	if _, err := io.ReadFull(r.rc, r.page); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("%w: %s is truncated", ErrDecryptionFailed, r.c.name)
+	} else if err != nil {
+		return nil, err
+	}
+	data, err := r.c.openBlock(r.next, r.page, r.out[:0])
+	if err != nil {
+		return nil, err
+	}
+	if want := min(encBlockSize, r.c.blobSize()-r.next*encBlockSize); int64(len(data)) != want {
+		return nil, fmt.Errorf("%w: block %d of %s has length %d, expected %d", ErrDecryptionFailed, r.next, r.c.name, len(data), want)
+	}
+	r.next++
+	return data, nil
+}
+
+func (r *openingReader) Close() error {
+	return r.rc.Close()
+}
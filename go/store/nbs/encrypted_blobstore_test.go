@@ -0,0 +1,152 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/constants"
+)
+
+func TestEncryptedBlobstore(t *testing.T) {
+	ctx := context.Background()
+	keys := NewStaticKeyProvider(makeTestEncryptionKey(t, 1))
+	mem := blobstore.NewInMemoryBlobstore("")
+	ebs := newEncryptedBlobstore(mem, keys)
+
+	contents := map[string][]byte{}
+	for i, n := range []int{1, encBlockSize, 3*encBlockSize + 17, 100000} {
+		k := string(rune('a' + i))
+		contents[k] = randBuf(n)
+		_, err := blobstore.PutBytes(ctx, ebs, k, contents[k])
+		require.NoError(t, err)
+
+		raw, _, err := blobstore.GetBytes(ctx, mem, k, blobstore.AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, encryptedFileSize(int64(n)), int64(len(raw)))
+		if n >= 32 {
+			assert.False(t, bytes.Contains(raw, contents[k][:32]))
+		}
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		for k, data := range contents {
+			all, _, err := blobstore.GetBytes(ctx, ebs, k, blobstore.AllRange)
+			require.NoError(t, err)
+			assert.Equal(t, data, all)
+
+			for i := 0; i < 20; i++ {
+				off := rand.Intn(len(data))
+				length := rand.Intn(len(data) - off + 1)
+				rc, sz, _, err := ebs.Get(ctx, k, blobstore.NewBlobRange(int64(off), int64(length)))
+				require.NoError(t, err)
+				assert.Equal(t, uint64(len(data)), sz)
+				buf := new(bytes.Buffer)
+				_, err = buf.ReadFrom(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+				if length == 0 {
+					length = len(data) - off
+				}
+				assert.Equal(t, data[off:off+length], buf.Bytes())
+			}
+
+			fromEnd := min(len(data), 1000)
+			b, _, err := blobstore.GetBytes(ctx, ebs, k, blobstore.NewBlobRange(-int64(fromEnd), 0))
+			require.NoError(t, err)
+			assert.Equal(t, data[len(data)-fromEnd:], b)
+		}
+	})
+
+	t.Run("Concatenate", func(t *testing.T) {
+		_, err := ebs.Concatenate(ctx, "concat", []string{"b", "c"})
+		require.NoError(t, err)
+		all, _, err := blobstore.GetBytes(ctx, ebs, "concat", blobstore.AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, append(append([]byte{}, contents["b"]...), contents["c"]...), all)
+	})
+
+	t.Run("Plaintext", func(t *testing.T) {
+		data := randBuf(10000)
+		_, err := blobstore.PutBytes(ctx, mem, "plain", data)
+		require.NoError(t, err)
+		all, _, err := blobstore.GetBytes(ctx, ebs, "plain", blobstore.AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, data, all)
+		b, _, err := blobstore.GetBytes(ctx, ebs, "plain", blobstore.NewBlobRange(5000, 100))
+		require.NoError(t, err)
+		assert.Equal(t, data[5000:5100], b)
+	})
+
+	t.Run("Replaced", func(t *testing.T) {
+		// a blob rewritten with a new header is read with it
+		_, _, err := blobstore.GetBytes(ctx, ebs, "d", blobstore.NewBlobRange(10, 10))
+		require.NoError(t, err)
+		data := randBuf(50000)
+		_, err = blobstore.PutBytes(ctx, ebs, "d", data)
+		require.NoError(t, err)
+		b, _, err := blobstore.GetBytes(ctx, ebs, "d", blobstore.NewBlobRange(10, 10))
+		require.NoError(t, err)
+		assert.Equal(t, data[10:20], b)
+	})
+
+	t.Run("NoKeyProvider", func(t *testing.T) {
+		_, _, err := blobstore.GetBytes(ctx, newEncryptedBlobstore(mem, nil), "a", blobstore.AllRange)
+		assert.ErrorIs(t, err, ErrNoEncryptionKey)
+	})
+
+	t.Run("LocalKeysNotUsed", func(t *testing.T) {
+		// the keys of local stores neither encrypt nor decrypt the blobs of a remote
+		setTestKeyProvider(t, keys)
+		_, _, err := blobstore.GetBytes(ctx, newEncryptedBlobstore(mem, nil), "a", blobstore.AllRange)
+		assert.ErrorIs(t, err, ErrNoEncryptionKey)
+
+		data := randBuf(100)
+		_, err = blobstore.PutBytes(ctx, newEncryptedBlobstore(mem, nil), "unencrypted", data)
+		require.NoError(t, err)
+		raw, _, err := blobstore.GetBytes(ctx, mem, "unencrypted", blobstore.AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, data, raw)
+	})
+
+	t.Run("Modified", func(t *testing.T) {
+		raw, _, err := blobstore.GetBytes(ctx, mem, "c", blobstore.AllRange)
+		require.NoError(t, err)
+		raw[blockOffset(1)+100] ^= 0xff
+		_, err = blobstore.PutBytes(ctx, mem, "c", raw)
+		require.NoError(t, err)
+		_, _, err = blobstore.GetBytes(ctx, newEncryptedBlobstore(mem, keys), "c", blobstore.AllRange)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+}
+
+func TestEncryptedBlobstoreSuite(t *testing.T) {
+	keys := NewStaticKeyProvider(makeTestEncryptionKey(t, 1))
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		nbf := constants.FormatDefaultString
+		qp := NewUnlimitedMemQuotaProvider()
+		bs := blobstore.NewLocalBlobstore(dir)
+		return NewBSStore(ctx, nbf, bs, testMemTableSize, qp, keys)
+	}
+	suite.Run(t, &BlockStoreSuite{factory: fn})
+}
@@ -0,0 +1,600 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+// An encrypted table file, archive or chunk journal starts with a header page holding
+//
+//	magic (8 bytes) | key id (8 bytes) | file id (16 bytes) | blob size (8 bytes) | zero padding
+//
+// followed by the file's contents in blocks of up to encBlockSize bytes. Each block is sealed with AES-256-GCM under a
+// key derived from the encryption key and the random file id, and is written as one page:
+//
+//	nonce (12 bytes) | length (2 bytes) | ciphertext | tag (16 bytes) | zero padding
+//
+// The header, the index of the block and its length are authenticated with every block, so blocks cannot be modified,
+// reordered or moved between files. Only the last block of a file may be partial. Appending to a file rewrites its
+// partial last block, and because every write is of whole pages, a crash during an append leaves either the old or the
+// new version of that block, see dropTornBlocks. Blobs stored in a remote or backup use the same format, see
+// encryptedBlobstore, and record their size in the header so it is known before the last block is read. Files can be
+// appended to and leave it zero.
+const (
+	encryptedFileMagic = "DOLTENC1"
+
+	encPageSize   = 4096
+	encHeaderSize = encPageSize
+	encHeaderLen  = len(encryptedFileMagic) + len(KeyID{}) + 16 + 8
+	encNonceSize  = 12
+	encLenSize    = 2
+	encTagSize    = 16
+	encBlockSize  = encPageSize - encNonceSize - encLenSize - encTagSize
+)
+
+// storageFile is an open table file, archive or chunk journal. Reads and writes are of the file's plaintext contents,
+// whether or not the file is encrypted on disk.
+type storageFile interface {
+	io.ReaderAt
+	io.ReadSeeker
+	io.Writer
+	io.WriterAt
+	Truncate(size int64) error
+	Sync() error
+	Close() error
+	Name() string
+	// Size returns the size of the file's contents.
+	Size() (int64, error)
+}
+
+// StorageFileReader reads the plaintext contents of a table file, archive or chunk journal.
+type StorageFileReader interface {
+	io.ReaderAt
+	io.ReadSeeker
+	io.Closer
+}
+
+// OpenStorageFile opens the table file, archive or chunk journal at |path| for reading, returning a reader of its
+// contents and their size. Encrypted files are decrypted with the configured KeyProvider.
+func OpenStorageFile(path string) (StorageFileReader, int64, error) {
+	f, err := openStorageFile(path, os.O_RDONLY)
+	if err != nil {
+		return nil, 0, err
+	}
+	sz, err := f.Size()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, sz, nil
+}
+
+// openStorageFile opens the file at |path| with |flag|. An encrypted file is decrypted with the key it was written
+// with, which must be available from the configured KeyProvider.
+func openStorageFile(path string, flag int) (storageFile, error) {
+	f, err := os.OpenFile(path, flag, 0666)
+	if err != nil {
+		return nil, err
+	}
+	sf, err := wrapStorageFile(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return sf, nil
+}
+
+// newStorageFile prepares |f|, a new and empty file, to be written. If a KeyProvider is configured, the file is
+// encrypted with its current key. Otherwise it is written unencrypted, unless encryption is required.
+func newStorageFile(f *os.File) (storageFile, error) {
+	p := getKeyProvider()
+	if p == nil {
+		if encryptionRequired() {
+			return nil, fmt.Errorf("cannot create %s: %w, but no encryption key is configured; set %s or %s",
+				f.Name(), ErrEncryptionRequired, dconfig.EnvEncryptionKeyFile, dconfig.EnvEncryptionKey)
+		}
+		return plainFile{f}, nil
+	}
+	key, err := p.CurrentKey()
+	if err != nil {
+		return nil, fmt.Errorf("error getting encryption key: %w", err)
+	}
+	return createEncryptedFile(f, key)
+}
+
+// dropTornBlocks removes any blocks left at the end of |f| by a crash during an append that was never synced, if |f|
+// is encrypted. Such blocks fail authentication, for example when the file was extended but its new pages were never
+// written, or follow a partial block that was never replaced by its full version. They are removed from disk when the
+// file is next truncated.
+func dropTornBlocks(f storageFile) error {
+	ef, ok := f.(*encryptedFile)
+	if !ok {
+		return nil
+	}
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	for ef.size > 0 {
+		last := (ef.size - 1) / encBlockSize
+		data, err := ef.readBlock(last)
+		if err != nil && !errors.Is(err, ErrDecryptionFailed) {
+			return err
+		}
+		if err == nil {
+			prevFull := true
+			if last > 0 {
+				prev, err := ef.readBlock(last - 1)
+				if err != nil && !errors.Is(err, ErrDecryptionFailed) {
+					return err
+				}
+				prevFull = err == nil && len(prev) == encBlockSize
+			}
+			if prevFull {
+				ef.size = last*encBlockSize + int64(len(data))
+				break
+			}
+		}
+		ef.size = last * encBlockSize
+	}
+	ef.tailLoaded = false
+	return nil
+}
+
+// isEncrypted returns whether |f| is encrypted on disk.
+func isEncrypted(f storageFile) bool {
+	_, ok := f.(*encryptedFile)
+	return ok
+}
+
+// wrapStorageFile returns |f| as a storageFile, decrypting it if it is encrypted. When encryption is required, a file
+// with unencrypted contents is refused, so that plaintext placed in the store is never read.
+func wrapStorageFile(f *os.File) (storageFile, error) {
+	hdr := make([]byte, encHeaderLen)
+	n, err := f.ReadAt(hdr, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	c, err := parseEncryptionHeader(f.Name(), hdr[:n])
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		if n > 0 && encryptionRequired() {
+			return nil, fmt.Errorf("cannot open %s, which is not encrypted: %w; encrypt the database with a full gc while %s is unset",
+				f.Name(), ErrEncryptionRequired, dconfig.EnvEncryptionRequired)
+		}
+		return plainFile{f}, nil
+	}
+	ef := &encryptedFile{blockCipher: c, f: f}
+	if ef.size, err = ef.contentSize(); err != nil {
+		return nil, err
+	}
+	return ef, nil
+}
+
+// plainFile is an unencrypted storageFile.
+type plainFile struct {
+	*os.File
+}
+
+func (f plainFile) Size() (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// encryptedFile is an encrypted storageFile. Its contents can be read at any offset, but only appended to or truncated.
+type encryptedFile struct {
+	*blockCipher
+	f *os.File
+
+	mu   sync.RWMutex
+	size int64
+	pos  int64
+	// tail is the contents of the partial last block, once loaded
+	tail       []byte
+	tailLoaded bool
+}
+
+var _ storageFile = (*encryptedFile)(nil)
+
+// createEncryptedFile writes the encryption header for |key| to |f|, a new and empty file.
+func createEncryptedFile(f *os.File, key EncryptionKey) (*encryptedFile, error) {
+	hdr, err := newEncryptionHeader(key, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.WriteAt(hdr, 0); err != nil {
+		return nil, err
+	}
+	c, err := newBlockCipher(f.Name(), key, hdr)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFile{blockCipher: c, f: f, tailLoaded: true}, nil
+}
+
+// contentSize returns the size of the file's contents, which is read from the length of its last block. A last page
+// that was only partly written is ignored.
+func (ef *encryptedFile) contentSize() (int64, error) {
+	info, err := ef.f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if info.Size() < encHeaderSize {
+		return 0, fmt.Errorf("%w: %s has a truncated encryption header", ErrDecryptionFailed, ef.f.Name())
+	}
+	n := (info.Size() - encHeaderSize) / encPageSize
+	if n == 0 {
+		return 0, nil
+	}
+	var l [encLenSize]byte
+	if _, err = ef.f.ReadAt(l[:], blockOffset(n-1)+encNonceSize); err != nil {
+		return 0, err
+	}
+	// an invalid length fails authentication when the block is read
+	last := int64(binary.BigEndian.Uint16(l[:]))
+	if last == 0 || last > encBlockSize {
+		last = encBlockSize
+	}
+	return (n-1)*encBlockSize + last, nil
+}
+
+// encryptedFileSize returns the size of an encrypted file with |size| bytes of contents.
+func encryptedFileSize(size int64) int64 {
+	return blockOffset((size + encBlockSize - 1) / encBlockSize)
+}
+
+func blockOffset(i int64) int64 {
+	return encHeaderSize + i*encPageSize
+}
+
+// readBlock returns the contents of block |i|, whatever its length.
+func (ef *encryptedFile) readBlock(i int64) ([]byte, error) {
+	page := make([]byte, encPageSize)
+	if _, err := ef.f.ReadAt(page, blockOffset(i)); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: block %d of %s is truncated", ErrDecryptionFailed, i, ef.f.Name())
+	} else if err != nil {
+		return nil, err
+	}
+	return ef.openBlock(i, page, nil)
+}
+
+// readBlocks returns the contents of blocks |first| through |last|.
+func (ef *encryptedFile) readBlocks(first, last int64) ([]byte, error) {
+	buf := make([]byte, (last-first+1)*encPageSize)
+	if _, err := ef.f.ReadAt(buf, blockOffset(first)); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s is truncated", ErrDecryptionFailed, ef.f.Name())
+	} else if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, (last-first)*encBlockSize+encBlockSize)
+	for i := first; i <= last; i++ {
+		start := len(out)
+		var err error
+		out, err = ef.openBlock(i, buf[(i-first)*encPageSize:(i-first+1)*encPageSize], out)
+		if err != nil {
+			return nil, err
+		}
+		if want := min(encBlockSize, ef.size-i*encBlockSize); int64(len(out)-start) != want {
+			return nil, fmt.Errorf("%w: block %d of %s has length %d, expected %d", ErrDecryptionFailed, i, ef.f.Name(), len(out)-start, want)
+		}
+	}
+	return out, nil
+}
+
+func (ef *encryptedFile) ReadAt(p []byte, off int64) (int, error) {
+	ef.mu.RLock()
+	defer ef.mu.RUnlock()
+	return ef.readAt(p, off)
+}
+
+func (ef *encryptedFile) readAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d reading %s", off, ef.f.Name())
+	}
+	if off >= ef.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	end := min(off+int64(len(p)), ef.size)
+	first, last := off/encBlockSize, (end-1)/encBlockSize
+	data, err := ef.readBlocks(first, last)
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, data[off-first*encBlockSize:end-first*encBlockSize])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (ef *encryptedFile) Read(p []byte) (int, error) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	n, err := ef.readAt(p, ef.pos)
+	ef.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (ef *encryptedFile) Seek(offset int64, whence int) (int64, error) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += ef.pos
+	case io.SeekEnd:
+		offset += ef.size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d seeking %s", offset, ef.f.Name())
+	}
+	ef.pos = offset
+	return offset, nil
+}
+
+// Write appends |p| to the file.
+func (ef *encryptedFile) Write(p []byte) (int, error) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	return ef.append(p)
+}
+
+// WriteAt writes |p| at |off|, which must be the end of the file.
+func (ef *encryptedFile) WriteAt(p []byte, off int64) (int, error) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	if off != ef.size {
+		return 0, fmt.Errorf("cannot write at offset %d of encrypted file %s of size %d, encrypted files can only be appended to", off, ef.f.Name(), ef.size)
+	}
+	return ef.append(p)
+}
+
+func (ef *encryptedFile) loadTail() error {
+	if ef.tailLoaded {
+		return nil
+	}
+	ef.tail = nil
+	if ef.size%encBlockSize != 0 {
+		last := ef.size / encBlockSize
+		data, err := ef.readBlocks(last, last)
+		if err != nil {
+			return err
+		}
+		ef.tail = data
+	}
+	ef.tailLoaded = true
+	return nil
+}
+
+func (ef *encryptedFile) append(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := ef.loadTail(); err != nil {
+		return 0, err
+	}
+
+	first := ef.size / encBlockSize
+	data := make([]byte, 0, len(ef.tail)+len(p))
+	data = append(append(data, ef.tail...), p...)
+	sealed, err := ef.sealBlocks(first, data)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = ef.f.WriteAt(sealed, blockOffset(first)); err != nil {
+		return 0, err
+	}
+
+	ef.size += int64(len(p))
+	ef.tail = nil
+	if rem := len(data) % encBlockSize; rem != 0 {
+		ef.tail = data[len(data)-rem:]
+	}
+	return len(p), nil
+}
+
+// Truncate truncates the file's contents to |size|, which must not be larger than the current size.
+func (ef *encryptedFile) Truncate(size int64) error {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	if size > ef.size {
+		return fmt.Errorf("cannot extend encrypted file %s from %d to %d bytes", ef.f.Name(), ef.size, size)
+	} else if size < 0 {
+		return fmt.Errorf("cannot truncate encrypted file %s to negative size %d", ef.f.Name(), size)
+	} else if size == ef.size {
+		// the file may still hold blocks past its contents, see dropTornBlocks
+		return ef.f.Truncate(encryptedFileSize(size))
+	}
+
+	last, rem := size/encBlockSize, size%encBlockSize
+	var tail []byte
+	if rem != 0 {
+		data, err := ef.readBlocks(last, last)
+		if err != nil {
+			return err
+		}
+		tail = data[:rem]
+		sealed, err := ef.sealBlocks(last, tail)
+		if err != nil {
+			return err
+		}
+		if _, err = ef.f.WriteAt(sealed, blockOffset(last)); err != nil {
+			return err
+		}
+	}
+	if err := ef.f.Truncate(encryptedFileSize(size)); err != nil {
+		return err
+	}
+
+	ef.size = size
+	ef.tail = tail
+	ef.tailLoaded = true
+	return nil
+}
+
+func (ef *encryptedFile) Size() (int64, error) {
+	ef.mu.RLock()
+	defer ef.mu.RUnlock()
+	return ef.size, nil
+}
+
+func (ef *encryptedFile) Sync() error {
+	return ef.f.Sync()
+}
+
+func (ef *encryptedFile) Close() error {
+	return ef.f.Close()
+}
+
+func (ef *encryptedFile) Name() string {
+	return ef.f.Name()
+}
+
+// blockCipher seals and opens the blocks of one encrypted file or blob.
+type blockCipher struct {
+	name string
+	hdr  []byte
+	aead cipher.AEAD
+}
+
+// newEncryptionHeader returns the header page of new contents encrypted with |key|, recording |size| as the blob size.
+func newEncryptionHeader(key EncryptionKey, size int64) ([]byte, error) {
+	hdr := make([]byte, encHeaderSize)
+	copy(hdr, encryptedFileMagic)
+	copy(hdr[len(encryptedFileMagic):], key.ID[:])
+	fileID := hdr[len(encryptedFileMagic)+len(key.ID) : encHeaderLen-8]
+	if _, err := rand.Read(fileID); err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint64(hdr[encHeaderLen-8:encHeaderLen], uint64(size))
+	return hdr, nil
+}
+
+// parseEncryptionHeader returns the blockCipher for the contents of |name|, which start with |hdr|, or nil if they are
+// not encrypted. The key they were encrypted with must be available from the configured KeyProvider.
+func parseEncryptionHeader(name string, hdr []byte) (*blockCipher, error) {
+	c, err := openEncryptionHeader(name, hdr, getKeyProvider())
+	if errors.Is(err, ErrNoEncryptionKey) {
+		return nil, fmt.Errorf("%w; set %s or %s", err, dconfig.EnvEncryptionKeyFile, dconfig.EnvEncryptionKey)
+	}
+	return c, err
+}
+
+// openEncryptionHeader returns the blockCipher for the contents of |name|, which start with |hdr|, or nil if they are
+// not encrypted. The key they were encrypted with must be available from |keys|.
+func openEncryptionHeader(name string, hdr []byte, keys KeyProvider) (*blockCipher, error) {
+	if len(hdr) < len(encryptedFileMagic) || string(hdr[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return nil, nil
+	}
+	if len(hdr) < encHeaderLen {
+		return nil, fmt.Errorf("%w: %s has a truncated encryption header", ErrDecryptionFailed, name)
+	}
+
+	var id KeyID
+	copy(id[:], hdr[len(encryptedFileMagic):])
+	if keys == nil {
+		return nil, fmt.Errorf("cannot open %s, which is encrypted with key %s: %w", name, id, ErrNoEncryptionKey)
+	}
+	key, err := keys.Key(id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s, which is encrypted with key %s: %w", name, id, err)
+	}
+	return newBlockCipher(name, key, hdr)
+}
+
+func newBlockCipher(name string, key EncryptionKey, hdr []byte) (*blockCipher, error) {
+	// each file is encrypted with its own key, derived from the encryption key and the file id
+	mac := hmac.New(sha256.New, key.key)
+	mac.Write([]byte("dolt storage file key\x00"))
+	mac.Write(hdr[len(encryptedFileMagic)+len(key.ID) : encHeaderLen-8])
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &blockCipher{name: name, hdr: hdr[:encHeaderLen:encHeaderLen], aead: aead}, nil
+}
+
+// blobSize returns the blob size recorded in the header.
+func (c *blockCipher) blobSize() int64 {
+	return int64(binary.BigEndian.Uint64(c.hdr[encHeaderLen-8:]))
+}
+
+func (c *blockCipher) blockAD(i int64, length []byte) []byte {
+	ad := make([]byte, 0, len(c.hdr)+8+encLenSize)
+	ad = append(ad, c.hdr...)
+	ad = binary.BigEndian.AppendUint64(ad, uint64(i))
+	return append(ad, length...)
+}
+
+// openBlock decrypts |page|, the page of block |i|, appending its contents to |dst|.
+func (c *blockCipher) openBlock(i int64, page []byte, dst []byte) ([]byte, error) {
+	length := page[encNonceSize : encNonceSize+encLenSize]
+	n := int(binary.BigEndian.Uint16(length))
+	if n == 0 || n > encBlockSize {
+		return nil, fmt.Errorf("%w: block %d of %s has invalid length %d, the file is corrupt or was modified", ErrDecryptionFailed, i, c.name, n)
+	}
+	sealed := page[encNonceSize+encLenSize : encNonceSize+encLenSize+n+encTagSize]
+	out, err := c.aead.Open(dst, page[:encNonceSize], sealed, c.blockAD(i, length))
+	if err != nil {
+		return nil, fmt.Errorf("%w: block %d of %s failed authentication, the file is corrupt or was modified", ErrDecryptionFailed, i, c.name)
+	}
+	return out, nil
+}
+
+// sealBlocks encrypts |data| as the pages of the blocks starting at block |first|.
+func (c *blockCipher) sealBlocks(first int64, data []byte) ([]byte, error) {
+	n := (int64(len(data)) + encBlockSize - 1) / encBlockSize
+	buf := make([]byte, n*encPageSize)
+	for i := int64(0); i < n; i++ {
+		block := data[i*encBlockSize : min((i+1)*encBlockSize, int64(len(data)))]
+		page := buf[i*encPageSize : (i+1)*encPageSize]
+		nonce, length := page[:encNonceSize], page[encNonceSize:encNonceSize+encLenSize]
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint16(length, uint16(len(block)))
+		c.aead.Seal(page[encNonceSize+encLenSize:encNonceSize+encLenSize], nonce, block, c.blockAD(first+i, length))
+	}
+	return buf, nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/dolthub/dolt/go/libraries/utils/constants"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func makeTestEncryptionKey(t *testing.T, b byte) EncryptionKey {
+	k, err := NewEncryptionKey(bytes.Repeat([]byte{b}, EncryptionKeySize))
+	require.NoError(t, err)
+	return k
+}
+
+// setTestKeyProvider sets the KeyProvider for the duration of the test.
+func setTestKeyProvider(t *testing.T, p KeyProvider) {
+	prev := getKeyProvider()
+	SetKeyProvider(p)
+	t.Cleanup(func() { SetKeyProvider(prev) })
+}
+
+func createTestStorageFile(t *testing.T, path string) storageFile {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	sf, err := newStorageFile(f)
+	require.NoError(t, err)
+	return sf
+}
+
+func TestEncryptedFile(t *testing.T) {
+	key := makeTestEncryptionKey(t, 1)
+	setTestKeyProvider(t, NewStaticKeyProvider(key))
+	path := filepath.Join(t.TempDir(), "file")
+
+	// write blocks of many sizes, both at and across block boundaries
+	var contents []byte
+	sf := createTestStorageFile(t, path)
+	require.True(t, isEncrypted(sf))
+	for _, n := range []int{1, encBlockSize - 1, 1, encBlockSize, 3 * encBlockSize, 100, 12345} {
+		b := randBuf(n)
+		_, err := sf.WriteAt(b, int64(len(contents)))
+		require.NoError(t, err)
+		contents = append(contents, b...)
+	}
+	_, err := sf.WriteAt([]byte("x"), 0)
+	assert.Error(t, err)
+	require.NoError(t, sf.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, encryptedFileSize(int64(len(contents))), int64(len(raw)))
+	assert.False(t, bytes.Contains(raw, contents[100:132]))
+
+	sf, err = openStorageFile(path, os.O_RDWR)
+	require.NoError(t, err)
+	defer sf.Close()
+	sz, err := sf.Size()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(contents)), sz)
+
+	t.Run("ReadAt", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			off := rand.Intn(len(contents))
+			buf := make([]byte, rand.Intn(3*encBlockSize))
+			n, err := sf.ReadAt(buf, int64(off))
+			if off+len(buf) > len(contents) {
+				assert.Equal(t, io.EOF, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, contents[off:off+n], buf[:n])
+		}
+		n, err := sf.ReadAt(make([]byte, 1), int64(len(contents)))
+		assert.Equal(t, 0, n)
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		_, err := sf.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		all, err := io.ReadAll(sf)
+		require.NoError(t, err)
+		assert.Equal(t, contents, all)
+	})
+
+	t.Run("TruncateAndAppend", func(t *testing.T) {
+		for _, sz := range []int{len(contents) - 10, 2 * encBlockSize, encBlockSize + 7} {
+			require.NoError(t, sf.Truncate(int64(sz)))
+			contents = contents[:sz]
+			b := randBuf(50)
+			_, err := sf.Write(b)
+			require.NoError(t, err)
+			contents = append(contents, b...)
+
+			reopened, err := openStorageFile(path, os.O_RDONLY)
+			require.NoError(t, err)
+			all, err := io.ReadAll(reopened)
+			require.NoError(t, err)
+			assert.Equal(t, contents, all)
+			require.NoError(t, reopened.Close())
+		}
+		assert.Error(t, sf.Truncate(int64(len(contents)+1)))
+	})
+}
+
+func TestEncryptedFileKeys(t *testing.T) {
+	oldKey, newKey := makeTestEncryptionKey(t, 1), makeTestEncryptionKey(t, 2)
+	setTestKeyProvider(t, NewStaticKeyProvider(oldKey))
+	path := filepath.Join(t.TempDir(), "file")
+	contents := randBuf(5000)
+	sf := createTestStorageFile(t, path)
+	_, err := sf.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, sf.Close())
+
+	t.Run("RotatedKey", func(t *testing.T) {
+		SetKeyProvider(NewStaticKeyProvider(newKey, oldKey))
+		r, sz, err := OpenStorageFile(path)
+		require.NoError(t, err)
+		defer r.Close()
+		assert.Equal(t, int64(len(contents)), sz)
+		all, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, contents, all)
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		SetKeyProvider(NewStaticKeyProvider(newKey))
+		_, _, err := OpenStorageFile(path)
+		assert.ErrorIs(t, err, ErrEncryptionKeyNotFound)
+		assert.Contains(t, err.Error(), oldKey.ID.String())
+	})
+
+	t.Run("NoKeyProvider", func(t *testing.T) {
+		SetKeyProvider(nil)
+		_, _, err := OpenStorageFile(path)
+		assert.ErrorIs(t, err, ErrNoEncryptionKey)
+	})
+
+	t.Run("Modified", func(t *testing.T) {
+		SetKeyProvider(NewStaticKeyProvider(oldKey))
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		raw[blockOffset(1)+100] ^= 0xff
+		require.NoError(t, os.WriteFile(path, raw, 0666))
+		r, _, err := OpenStorageFile(path)
+		require.NoError(t, err)
+		defer r.Close()
+		_, err = r.ReadAt(make([]byte, 10), 0)
+		assert.NoError(t, err)
+		_, err = r.ReadAt(make([]byte, 10), encBlockSize)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+}
+
+func TestPlainStorageFile(t *testing.T) {
+	setTestKeyProvider(t, nil)
+	path := filepath.Join(t.TempDir(), "file")
+	sf := createTestStorageFile(t, path)
+	assert.False(t, isEncrypted(sf))
+	_, err := sf.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, sf.Close())
+
+	// plaintext files stay readable once encryption is configured
+	setTestKeyProvider(t, NewStaticKeyProvider(makeTestEncryptionKey(t, 1)))
+	r, sz, err := OpenStorageFile(path)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, int64(5), sz)
+	all, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(all))
+}
+
+func TestRequireEncryption(t *testing.T) {
+	setTestKeyProvider(t, nil)
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "plain")
+	sf := createTestStorageFile(t, plainPath)
+	_, err := sf.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, sf.Close())
+
+	SetRequireEncryption(true)
+	t.Cleanup(func() { SetRequireEncryption(false) })
+
+	// without a key, no file can be created
+	f, err := os.Create(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = newStorageFile(f)
+	assert.ErrorIs(t, err, ErrEncryptionRequired)
+
+	// with one, new files are encrypted, and plaintext files are refused
+	setTestKeyProvider(t, NewStaticKeyProvider(makeTestEncryptionKey(t, 1)))
+	encPath := filepath.Join(dir, "encrypted")
+	sf = createTestStorageFile(t, encPath)
+	require.True(t, isEncrypted(sf))
+	_, err = sf.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, sf.Close())
+
+	_, _, err = OpenStorageFile(plainPath)
+	assert.ErrorIs(t, err, ErrEncryptionRequired)
+
+	r, sz, err := OpenStorageFile(encPath)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, int64(5), sz)
+}
+
+func TestDropTornBlocks(t *testing.T) {
+	setTestKeyProvider(t, NewStaticKeyProvider(makeTestEncryptionKey(t, 1)))
+	path := filepath.Join(t.TempDir(), "file")
+	contents := randBuf(2*encBlockSize + 100)
+	sf := createTestStorageFile(t, path)
+	_, err := sf.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, sf.Close())
+	committed, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	reopen := func(t *testing.T) storageFile {
+		sf, err := openStorageFile(path, os.O_RDWR)
+		require.NoError(t, err)
+		t.Cleanup(func() { sf.Close() })
+		require.NoError(t, dropTornBlocks(sf))
+		sz, err := sf.Size()
+		require.NoError(t, err)
+		require.Equal(t, int64(len(contents)), sz)
+		require.NoError(t, sf.Truncate(sz))
+		all, err := io.ReadAll(io.NewSectionReader(sf, 0, sz))
+		require.NoError(t, err)
+		require.Equal(t, contents, all)
+		return sf
+	}
+
+	t.Run("UnwrittenPages", func(t *testing.T) {
+		// the file was extended, but its new pages were never written
+		f, err := os.OpenFile(path, os.O_RDWR, 0666)
+		require.NoError(t, err)
+		_, err = f.WriteAt(make([]byte, 2*encPageSize), int64(len(committed)))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		reopen(t)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(committed)), info.Size())
+	})
+
+	t.Run("PartialBlockNotReplaced", func(t *testing.T) {
+		// the new pages of an append were written, but the rewrite of the partial last block was not
+		sf := reopen(t)
+		_, err := sf.Write(randBuf(encBlockSize))
+		require.NoError(t, err)
+		f, err := os.OpenFile(path, os.O_RDWR, 0666)
+		require.NoError(t, err)
+		_, err = f.WriteAt(committed[blockOffset(2):], blockOffset(2))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		reopen(t)
+	})
+}
+
+func TestEncryptedLocalStoreSuite(t *testing.T) {
+	setTestKeyProvider(t, NewStaticKeyProvider(makeTestEncryptionKey(t, 1)))
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		nbf := constants.FormatDefaultString
+		qp := NewUnlimitedMemQuotaProvider()
+		return NewLocalStore(ctx, nbf, dir, testMemTableSize, qp, false)
+	}
+	suite.Run(t, &BlockStoreSuite{factory: fn})
+}
+
+func TestEncryptedChunkJournalBlockStoreSuite(t *testing.T) {
+	setTestKeyProvider(t, NewStaticKeyProvider(makeTestEncryptionKey(t, 1)))
+	fn := func(ctx context.Context, dir string) (*NomsBlockStore, error) {
+		q := NewUnlimitedMemQuotaProvider()
+		nbf := types.Format_DOLT.VersionString()
+		return NewLocalJournalingStore(ctx, nbf, dir, q, false, nil)
+	}
+	suite.Run(t, &BlockStoreSuite{
+		factory:        fn,
+		skipInterloper: true,
+	})
+}
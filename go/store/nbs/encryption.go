@@ -0,0 +1,255 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+// EncryptionKeySize is the size in bytes of the keys that table files, archives and the chunk journal are encrypted
+// with. Keys are used for AES-256-GCM.
+const EncryptionKeySize = 32
+
+var (
+	// ErrNoEncryptionKey is returned when opening an encrypted file while no KeyProvider is configured.
+	ErrNoEncryptionKey = errors.New("no encryption key is configured")
+	// ErrEncryptionKeyNotFound is returned when opening a file encrypted with a key that the configured KeyProvider
+	// does not have.
+	ErrEncryptionKeyNotFound = errors.New("encryption key not found")
+	// ErrDecryptionFailed is returned when encrypted data fails authentication, because it was modified or
+	// corrupted on disk.
+	ErrDecryptionFailed = errors.New("failed to decrypt data")
+	// ErrEncryptionRequired is returned when opening an unencrypted file, or creating a file without a configured
+	// KeyProvider, while encryption is required.
+	ErrEncryptionRequired = errors.New("encryption is required")
+)
+
+// KeyID identifies an EncryptionKey without revealing it. Encrypted files record the ID of the key they were written
+// with, so that they can be read after the current key is rotated.
+type KeyID [8]byte
+
+func (id KeyID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// EncryptionKey is a key that table files, archives and the chunk journal are encrypted with.
+type EncryptionKey struct {
+	ID  KeyID
+	key []byte
+}
+
+// NewEncryptionKey returns an EncryptionKey for the key material |key|, which must be EncryptionKeySize bytes.
+func NewEncryptionKey(key []byte) (EncryptionKey, error) {
+	if len(key) != EncryptionKeySize {
+		return EncryptionKey{}, fmt.Errorf("encryption keys must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	sum := sha256.Sum256(append([]byte("dolt encryption key id\x00"), key...))
+	var id KeyID
+	copy(id[:], sum[:])
+	return EncryptionKey{ID: id, key: bytes.Clone(key)}, nil
+}
+
+// ParseEncryptionKey parses a key given as base64 or as hex.
+func ParseEncryptionKey(s string) (EncryptionKey, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == hex.EncodedLen(EncryptionKeySize) {
+		if key, err := hex.DecodeString(s); err == nil {
+			return NewEncryptionKey(key)
+		}
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return EncryptionKey{}, fmt.Errorf("encryption keys must be %d bytes encoded as base64 or hex", EncryptionKeySize)
+	}
+	return NewEncryptionKey(key)
+}
+
+// KeyProvider supplies the keys that table files, archives and the chunk journal are encrypted with at rest. New files
+// are encrypted with the current key. Existing files keep the key they were written with until they are rewritten,
+// which for all of a database's files happens during a full `dolt gc`. Keys are rotated by making a new key current
+// while still providing the old one until the next garbage collection has finished. Manifests, the journal index and
+// the ghost objects file hold only hashes and table file names, and are not encrypted. Remotes and backups are
+// encrypted with keys of their own, given to the stores that are opened on them, see encryptedBlobstore.
+type KeyProvider interface {
+	// CurrentKey returns the key that new files are encrypted with.
+	CurrentKey() (EncryptionKey, error)
+	// Key returns the key with the ID given. It returns an error wrapping ErrEncryptionKeyNotFound if the provider
+	// has no such key.
+	Key(id KeyID) (EncryptionKey, error)
+}
+
+// StaticKeyProvider is a KeyProvider for a fixed list of keys, the first of which is the current key.
+type StaticKeyProvider struct {
+	keys []EncryptionKey
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+// NewStaticKeyProvider returns a KeyProvider that encrypts new files with |current| and can also read files encrypted
+// with any of |old|.
+func NewStaticKeyProvider(current EncryptionKey, old ...EncryptionKey) *StaticKeyProvider {
+	return &StaticKeyProvider{keys: append([]EncryptionKey{current}, old...)}
+}
+
+func (p *StaticKeyProvider) CurrentKey() (EncryptionKey, error) {
+	return p.keys[0], nil
+}
+
+func (p *StaticKeyProvider) Key(id KeyID) (EncryptionKey, error) {
+	for _, k := range p.keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return EncryptionKey{}, fmt.Errorf("%w: no key with id %s", ErrEncryptionKeyNotFound, id)
+}
+
+// NewEnvKeyProvider returns a KeyProvider for the key in the DOLT_ENCRYPTION_KEY environment variable. Keys rotated
+// out can be listed, comma separated, in DOLT_ENCRYPTION_OLD_KEYS.
+func NewEnvKeyProvider() (*StaticKeyProvider, error) {
+	s := os.Getenv(dconfig.EnvEncryptionKey)
+	if s == "" {
+		return nil, fmt.Errorf("%s is not set", dconfig.EnvEncryptionKey)
+	}
+	current, err := ParseEncryptionKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", dconfig.EnvEncryptionKey, err)
+	}
+
+	var old []EncryptionKey
+	if s := os.Getenv(dconfig.EnvEncryptionOldKeys); s != "" {
+		for _, ks := range strings.Split(s, ",") {
+			k, err := ParseEncryptionKey(ks)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", dconfig.EnvEncryptionOldKeys, err)
+			}
+			old = append(old, k)
+		}
+	}
+	return NewStaticKeyProvider(current, old...), nil
+}
+
+// NewKeyfileProvider returns a KeyProvider for the keys in the file at |path|. The file holds one key per line, as
+// base64 or hex. The first key is the current key, and any others are keys rotated out that are still needed to read
+// files written before the rotation. Blank lines and lines starting with # are ignored.
+func NewKeyfileProvider(path string) (*StaticKeyProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening encryption keyfile: %w", err)
+	}
+	defer f.Close()
+
+	var keys []EncryptionKey
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		k, err := ParseEncryptionKey(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key on line %d of encryption keyfile %s: %w", line, path, err)
+		}
+		keys = append(keys, k)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading encryption keyfile: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("encryption keyfile %s has no keys", path)
+	}
+	return NewStaticKeyProvider(keys[0], keys[1:]...), nil
+}
+
+// errKeyProvider is the KeyProvider when the configured one could not be loaded. Every use of it fails, so that
+// nothing is written unencrypted and the configuration error is reported.
+type errKeyProvider struct {
+	err error
+}
+
+func (p errKeyProvider) CurrentKey() (EncryptionKey, error) {
+	return EncryptionKey{}, p.err
+}
+
+func (p errKeyProvider) Key(KeyID) (EncryptionKey, error) {
+	return EncryptionKey{}, p.err
+}
+
+var (
+	keyProviderMu sync.RWMutex
+	keyProvider   KeyProvider
+)
+
+// SetKeyProvider sets the KeyProvider used to encrypt the table files, archives and chunk journals of local stores.
+// With a nil provider, new files are written unencrypted and encrypted files cannot be opened. By default, the provider
+// is configured from the DOLT_ENCRYPTION_KEY_FILE or DOLT_ENCRYPTION_KEY environment variables.
+func SetKeyProvider(p KeyProvider) {
+	keyProviderMu.Lock()
+	defer keyProviderMu.Unlock()
+	keyProvider = p
+}
+
+func getKeyProvider() KeyProvider {
+	keyProviderMu.RLock()
+	defer keyProviderMu.RUnlock()
+	return keyProvider
+}
+
+var requireEncryption atomic.Bool
+
+// SetRequireEncryption sets whether the table files, archives and chunk journals of local stores must be encrypted.
+// When they must, opening an unencrypted file fails instead of reading it as plaintext, and creating a file fails
+// unless a KeyProvider is configured. By default, encryption is required if the DOLT_ENCRYPTION_REQUIRED environment
+// variable is set.
+func SetRequireEncryption(require bool) {
+	requireEncryption.Store(require)
+}
+
+func encryptionRequired() bool {
+	return requireEncryption.Load()
+}
+
+func init() {
+	if os.Getenv(dconfig.EnvEncryptionRequired) != "" {
+		SetRequireEncryption(true)
+	}
+	if path := os.Getenv(dconfig.EnvEncryptionKeyFile); path != "" {
+		p, err := NewKeyfileProvider(path)
+		if err != nil {
+			SetKeyProvider(errKeyProvider{err})
+		} else {
+			SetKeyProvider(p)
+		}
+	} else if os.Getenv(dconfig.EnvEncryptionKey) != "" {
+		p, err := NewEnvKeyProvider()
+		if err != nil {
+			SetKeyProvider(errKeyProvider{err})
+		} else {
+			SetKeyProvider(p)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+func TestParseEncryptionKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{7}, EncryptionKeySize)
+	fromHex, err := ParseEncryptionKey(hex.EncodeToString(raw))
+	require.NoError(t, err)
+	fromBase64, err := ParseEncryptionKey(" " + base64.StdEncoding.EncodeToString(raw) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, fromHex, fromBase64)
+
+	other, err := NewEncryptionKey(bytes.Repeat([]byte{8}, EncryptionKeySize))
+	require.NoError(t, err)
+	assert.NotEqual(t, fromHex.ID, other.ID)
+
+	_, err = ParseEncryptionKey("not a key")
+	assert.Error(t, err)
+	_, err = ParseEncryptionKey(base64.StdEncoding.EncodeToString(raw[:16]))
+	assert.Error(t, err)
+}
+
+func TestKeyProviders(t *testing.T) {
+	k1, k2, k3 := makeTestEncryptionKey(t, 1), makeTestEncryptionKey(t, 2), makeTestEncryptionKey(t, 3)
+	b64 := func(b byte) string {
+		return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, EncryptionKeySize))
+	}
+
+	check := func(t *testing.T, p KeyProvider) {
+		current, err := p.CurrentKey()
+		require.NoError(t, err)
+		assert.Equal(t, k1, current)
+		k, err := p.Key(k2.ID)
+		require.NoError(t, err)
+		assert.Equal(t, k2, k)
+		_, err = p.Key(k3.ID)
+		assert.ErrorIs(t, err, ErrEncryptionKeyNotFound)
+	}
+
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv(dconfig.EnvEncryptionKey, b64(1))
+		t.Setenv(dconfig.EnvEncryptionOldKeys, b64(2))
+		p, err := NewEnvKeyProvider()
+		require.NoError(t, err)
+		check(t, p)
+
+		t.Setenv(dconfig.EnvEncryptionOldKeys, b64(2)+",bad")
+		_, err = NewEnvKeyProvider()
+		assert.ErrorContains(t, err, dconfig.EnvEncryptionOldKeys)
+	})
+
+	t.Run("Keyfile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys")
+		require.NoError(t, os.WriteFile(path, []byte("# current key\n"+b64(1)+"\n\n"+b64(2)+"\n"), 0600))
+		p, err := NewKeyfileProvider(path)
+		require.NoError(t, err)
+		check(t, p)
+
+		require.NoError(t, os.WriteFile(path, []byte("# no keys\n"), 0600))
+		_, err = NewKeyfileProvider(path)
+		assert.Error(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte(b64(1)+"\nbad\n"), 0600))
+		_, err = NewKeyfileProvider(path)
+		assert.ErrorContains(t, err, "line 2")
+	})
+}
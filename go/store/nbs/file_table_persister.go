@@ -224,7 +224,7 @@ func (ftp *fsTablePersister) Path() string {
 }
 
 func (ftp *fsTablePersister) CopyTableFile(_ context.Context, r io.Reader, fileId string, _ uint64, _ uint64) (io.Closer, error) {
-	return ftp.writeAndProtect(fileId, func(temp *os.File) error {
+	return ftp.writeAndProtect(fileId, func(temp storageFile) error {
 		if _, err := io.Copy(temp, r); err != nil {
 			return err
 		}
@@ -256,7 +256,7 @@ func (ftp *fsTablePersister) TryMoveCmpChunkTableWriter(ctx context.Context, fil
 // like ConjoinAll, where an I/O error reading a source under FatalBehaviorError
 // would otherwise leave the partially written conjoined table/archive file
 // behind until the next PruneTableFiles.
-func (ftp *fsTablePersister) writeAndProtect(finalName string, writeFn func(temp *os.File) error) (*pendingHandle, error) {
+func (ftp *fsTablePersister) writeAndProtect(finalName string, writeFn func(temp storageFile) error) (*pendingHandle, error) {
 	addr, ok := fileNameToAddr(finalName)
 	if !ok {
 		return nil, fmt.Errorf("invalid filename: %s", finalName)
@@ -265,11 +265,17 @@ func (ftp *fsTablePersister) writeAndProtect(finalName string, writeFn func(temp
 	ftp.pruneMu.RLock()
 	defer ftp.pruneMu.RUnlock()
 
-	temp, err := tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
+	tempFile, err := tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
 	if err != nil {
 		return nil, err
 	}
-	tempName := temp.Name()
+	tempName := tempFile.Name()
+	temp, err := newStorageFile(tempFile)
+	if err != nil {
+		_ = tempFile.Close()
+		_ = file.Remove(tempName)
+		return nil, err
+	}
 
 	if err = writeFn(temp); err != nil {
 		_ = temp.Close()
@@ -292,7 +298,7 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, behavior dherrors
 		return emptyChunkSource{}, nil
 	}
 
-	ph, err := ftp.writeAndProtect(name.String(), func(temp *os.File) error {
+	ph, err := ftp.writeAndProtect(name.String(), func(temp storageFile) error {
 		if _, err := io.Copy(temp, bytes.NewReader(data)); err != nil {
 			return err
 		}
@@ -317,7 +323,7 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, behavior dherrors.F
 		return emptyChunkSource{}, func() {}, nil
 	}
 
-	ph, err := ftp.writeAndProtect(plan.name.String()+plan.suffix, func(temp *os.File) error {
+	ph, err := ftp.writeAndProtect(plan.name.String()+plan.suffix, func(temp storageFile) error {
 		for _, sws := range plan.sources.sws {
 			r, _, err := sws.source.reader(ctx, behavior)
 			if err != nil {
@@ -330,7 +330,7 @@ func TestFSTablePersisterWriteAndProtectCleansUpTempOnError(t *testing.T) {
 			ftp := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false).(*fsTablePersister)
 
 			wantErr := errors.New("simulated I/O error during conjoin")
-			_, err := ftp.writeAndProtect(c.finalName, func(temp *os.File) error {
+			_, err := ftp.writeAndProtect(c.finalName, func(temp storageFile) error {
 				// Write a partial result, as a conjoin copy would before
 				// hitting an I/O error part way through.
 				_, _ = temp.Write([]byte("partial conjoined data"))
@@ -100,21 +100,23 @@ func newFileTableReader(ctx context.Context, dir string, h hash.Hash, chunkCount
 }
 
 func newFileReaderAt(path string, mmapArchiveIndexes bool) (*fileReaderAt, error) {
-	f, err := os.Open(path)
+	f, err := openStorageFile(path, os.O_RDONLY)
 	if err != nil {
 		return nil, err
 	}
-	fi, err := f.Stat()
+	sz, err := f.Size()
 	if err != nil {
 		return nil, err
 	}
-	if fi.Size() < 0 {
+	if sz < 0 {
 		// Size returns the number of bytes for regular files and is system dependent for others (Some of which can be negative).
-		return nil, fmt.Errorf("%s has invalid size: %d", path, fi.Size())
+		return nil, fmt.Errorf("%s has invalid size: %d", path, sz)
 	}
+	// indexes of encrypted files cannot be mmapped, since they are not plaintext on disk
+	mmapArchiveIndexes = mmapArchiveIndexes && !isEncrypted(f)
 	cnt := new(int32)
 	*cnt = 1
-	return &fileReaderAt{f, cnt, path, fi.Size(), mmapArchiveIndexes}, nil
+	return &fileReaderAt{f, cnt, path, sz, mmapArchiveIndexes}, nil
 }
 
 func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, refs refCounter, q MemoryQuotaProvider) (cs chunkSource, err error) {
@@ -204,8 +206,8 @@ func (ftr *fileTableReader) clone() (chunkSource, error) {
 }
 
 type fileReaderAt struct {
-	f *os.File
-	// refcnt, clone() increments and Close() decrements. The *os.File is closed when it reaches 0.
+	f storageFile
+	// refcnt, clone() increments and Close() decrements. The file is closed when it reaches 0.
 	cnt         *int32
 	path        string
 	sz          int64
@@ -237,7 +239,7 @@ func (fra *fileReaderAt) Close() error {
 }
 
 func (fra *fileReaderAt) Reader(ctx context.Context) (io.ReadCloser, error) {
-	return os.Open(fra.path)
+	return openStorageFile(fra.path, os.O_RDONLY)
 }
 
 func (fra *fileReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int64, stats *Stats) (n int, err error) {
@@ -76,7 +76,7 @@ func main() {
 	} else if *table != "" && *bucket != "" && *dbName != "" {
 		cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion("us-west-2"))
 		d.PanicIfError(err)
-		store, err = nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), *table, *dbName, *bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), memTableSize, nbs.NewUnlimitedMemQuotaProvider())
+		store, err = nbs.NewAWSStore(context.Background(), types.Format_DOLT.VersionString(), *table, *dbName, *bucket, s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), memTableSize, nbs.NewUnlimitedMemQuotaProvider(), nil)
 		d.PanicIfError(err)
 	} else {
 		log.Fatalf("Must set either --dir or ALL of --table, --bucket and --db\n")
@@ -41,6 +41,7 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+	lazy     *lazyFetcher
 }
 
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
@@ -99,6 +100,15 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		return chunks.EmptyChunk, err
 	}
 
+	if c.IsEmpty() && gcs.lazy != nil {
+		_, err = gcs.fetchMissing(ctx, hash.NewHashSet(h), func(_ context.Context, fetched *chunks.Chunk) {
+			c = *fetched
+		})
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+	}
+
 	if c.IsEmpty() && gcs.ghostGen != nil {
 		c, err = gcs.ghostGen.Get(ctx, h)
 		if err != nil {
@@ -148,6 +158,14 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
+	notFound, err = gcs.fetchMissing(ctx, notFound, found)
+	if err != nil {
+		return err
+	}
+	if len(notFound) == 0 {
+		return nil
+	}
+
 	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
 	// considers non-present chunks to be silently ignored, so we don't need to return an error here
 	if gcs.ghostGen == nil {
@@ -190,6 +208,20 @@ func (gcs *GenerationalNBS) getManyCompressed(ctx context.Context, hashes hash.H
 		return nil
 	}
 
+	// The walk of a garbage collection reads through here, and should not fetch the history skipped by a shallow
+	// clone.
+	if gcDepMode != gcDependencyMode_NoDependency {
+		notFound, err = gcs.fetchMissing(ctx, notFound, func(ctx context.Context, chunk *chunks.Chunk) {
+			found(ctx, ChunkToCompressedChunk(*chunk))
+		})
+		if err != nil {
+			return err
+		}
+		if len(notFound) == 0 {
+			return nil
+		}
+	}
+
 	// The missing chunks may be ghost chunks.
 	if gcs.ghostGen != nil {
 		return gcs.ghostGen.getManyCompressed(ctx, notFound, found, gcDepMode)
@@ -339,6 +371,10 @@ func (gcs *GenerationalNBS) StatsSummary() string {
 // Close() concurrently with any other ChunkStore method; behavior is
 // undefined and probably crashy.
 func (gcs *GenerationalNBS) Close() error {
+	if err := gcs.flushFetched(context.Background()); err != nil {
+		gcs.newGen.logger.WithError(err).Warn("failed to persist chunks fetched from remote")
+	}
+
 	oErr := gcs.oldGen.Close()
 	nErr := gcs.newGen.Close()
 
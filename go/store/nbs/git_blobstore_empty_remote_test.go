@@ -45,7 +45,7 @@ func TestNBS_GitBlobstore_EmptyRemote_OpenReturnsEmptyManifest(t *testing.T) {
 	out, err := cmd.CombinedOutput()
 	require.NoError(t, err, "git remote add failed: %s", string(out))
 
-	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider())
+	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider(), nil)
 	require.NoError(t, err)
 	defer store.Close()
 
@@ -97,7 +97,7 @@ func TestNBS_GitBlobstore_EmptyRemote_FirstManifestUpdateBootstrapsRef(t *testin
 	require.NoError(t, err, "git rev-parse failed: %s", string(revParseOut))
 
 	// Re-open via NBS and ensure manifest is readable.
-	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider())
+	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider(), nil)
 	require.NoError(t, err)
 	defer store.Close()
 
@@ -130,7 +130,7 @@ func TestNBS_NewGitStore_DefaultsMaxPartSizeTo50MB(t *testing.T) {
 	out, err := cmd.CombinedOutput()
 	require.NoError(t, err, "git remote add failed: %s", string(out))
 
-	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider())
+	store, err := NewGitStore(ctx, types.Format_DOLT.VersionString(), localRepo.GitDir, blobstore.DoltDataRef, blobstore.GitBlobstoreOptions{}, 0, NewUnlimitedMemQuotaProvider(), nil)
 	require.NoError(t, err)
 	defer store.Close()
 
@@ -138,8 +138,10 @@ func TestNBS_NewGitStore_DefaultsMaxPartSizeTo50MB(t *testing.T) {
 	bsp, ok := store.persister.(*singleBlobBSPersister)
 	require.True(t, ok, "expected persister to be *singleBlobBSPersister, got %T", store.persister)
 
-	gbs, ok := bsp.bs.(*blobstore.GitBlobstore)
-	require.True(t, ok, "expected blobstore to be *blobstore.GitBlobstore, got %T", bsp.bs)
+	ebs, ok := bsp.bs.(*encryptedBlobstore)
+	require.True(t, ok, "expected blobstore to be *encryptedBlobstore, got %T", bsp.bs)
+	gbs, ok := ebs.bs.(*blobstore.GitBlobstore)
+	require.True(t, ok, "expected blobstore to be *blobstore.GitBlobstore, got %T", ebs.bs)
 
 	// Use a totalSize larger than the default 50MB threshold, but provide a tiny reader.
 	// planPutWrites decides chunked-vs-inline based on totalSize, and should pick chunked mode
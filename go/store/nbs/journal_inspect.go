@@ -60,8 +60,8 @@ func JournalFilter(journalPath string, filterRootsStr, filterChunksStr string) (
 		return FilterResult{}, 1
 	}
 
-	var f *os.File
-	f, err = os.Open(journalPath)
+	var f StorageFileReader
+	f, _, err = OpenStorageFile(journalPath)
 	if err != nil {
 		logrus.Errorf("Error: could not open journal file: %v", err)
 		return FilterResult{}, 1
@@ -96,8 +96,7 @@ func parseHashList(hashStrs string, hashType string) ([]hash.Hash, error) {
 }
 
 func JournalInspect(journalPath string, seeRoots, seeChunks, crcScan, snapScan bool) int {
-	var f *os.File
-	f, err := os.Open(journalPath)
+	f, _, err := OpenStorageFile(journalPath)
 	if err != nil {
 		panic("could not open journal file")
 	}
@@ -679,11 +678,18 @@ func filterJournalFile(journalPath string, buf []byte, filterRoots, filterChunks
 	base := filepath.Base(journalPath)
 	outputPath := filepath.Join(dir, base+".filtered")
 
-	outputFile, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	osFile, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
 	if err != nil {
 		logrus.Errorf("Error creating filtered journal file: %v", err)
 		return FilterResult{}, 1
 	}
+	// the filtered journal replaces the original, so it is encrypted like a new journal
+	outputFile, err := newStorageFile(osFile)
+	if err != nil {
+		osFile.Close()
+		logrus.Errorf("Error creating filtered journal file: %v", err)
+		return FilterResult{}, 1
+	}
 	defer outputFile.Close()
 
 	_, filteredRecords, exitStatus := filterJournalCore(buf, outputFile, filterRoots, filterChunks)
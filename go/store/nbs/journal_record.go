@@ -269,11 +269,14 @@ func ReviveJournalWithDataLoss(nomsDir string) (preservePath string, err error)
 	defer lock.Unlock()
 
 	journalPath := filepath.Join(nomsDir, chunkJournalName)
-	journalFile, err := os.OpenFile(journalPath, os.O_RDWR, 0666)
+	journalFile, err := openStorageFile(journalPath, os.O_RDWR)
 	if err != nil {
 		return "", fmt.Errorf("could not open chunk journal file: %w", err)
 	}
 	defer journalFile.Close()
+	if err = dropTornBlocks(journalFile); err != nil {
+		return "", fmt.Errorf("could not open chunk journal file: %w", err)
+	}
 
 	noOp := func(o int64, r journalRec) error { return nil }
 	// First verify that the journal has data loss.
@@ -287,10 +290,12 @@ func ReviveJournalWithDataLoss(nomsDir string) (preservePath string, err error)
 		return "", fmt.Errorf("could not process chunk journal file: %w", err)
 	}
 
-	// Seek back to the start, to perform a full copy.
-	if _, err = journalFile.Seek(0, io.SeekStart); err != nil {
-		return "", fmt.Errorf("could not seek to start of chunk journal file: %w", err)
+	// Perform a full copy of the file as it is on disk, which is still encrypted if the journal is.
+	rawFile, err := os.Open(journalPath)
+	if err != nil {
+		return "", fmt.Errorf("could not open chunk journal file: %w", err)
 	}
+	defer rawFile.Close()
 
 	// Create a backup of the journal file before truncating.
 	now := time.Now()
@@ -301,7 +306,7 @@ func ReviveJournalWithDataLoss(nomsDir string) (preservePath string, err error)
 	if err != nil {
 		return "", fmt.Errorf("could not create backup of corrupted chunk journal file: %w", err)
 	}
-	if _, err = io.Copy(saveFile, journalFile); err != nil {
+	if _, err = io.Copy(saveFile, rawFile); err != nil {
 		return "", fmt.Errorf("could not backup corrupted chunk journal file: %w", err)
 	}
 
@@ -468,7 +473,10 @@ func processJournalRecords(ctx context.Context, path string, r io.ReadSeeker, tr
 	// When we have a real file, we truncate anything which is beyond the current offset. Historically we put
 	// null bytes there, and there have been cases of garbage data being present instead of nulls. If there is any
 	// data beyond the current offset which we can parse and looks like data loss, we would have errored out above.
-	if f, ok := r.(*os.File); ok && tryTruncate {
+	if f, ok := r.(interface {
+		Truncate(size int64) error
+		Sync() error
+	}); ok && tryTruncate {
 		err = f.Truncate(off)
 		if err != nil {
 			return 0, err
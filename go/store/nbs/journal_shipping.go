@@ -0,0 +1,608 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Journal shipping continuously copies a journaling store to a
+// blobstore, so that the store can be rebuilt as of any root hash
+// update it made, including the working set updates made between
+// commits.
+//
+// A JournalShipper copies the records appended to the chunk journal
+// since it last shipped as a new journal segment, along with a
+// snapshot of the manifests and every table file they list, whenever
+// Ship is called. A garbage collection replaces the chunk journal,
+// which starts a new epoch of segments and snapshots. The epochs
+// shipped so far are recorded in the head blob, which is updated
+// with a check-and-set so that two stores never ship to the same
+// blobstore at once.
+//
+// RestoreShippedJournal picks the latest epoch which started at or
+// before the time to restore to, concatenates its segments up to the
+// last root hash record made at or before that time, and writes that
+// journal along with the table files of the epoch and a manifest
+// listing them.
+//
+// Blobs are encrypted with the KeyProvider of local stores, if one is
+// configured, as they hold the files of a local store and are restored
+// as such.
+
+const (
+	shippedHeadKey        = "journal-shipping-head"
+	shippedTablePrefix    = "table-"
+	shippedSegmentPrefix  = "journal-"
+	shippedManifestPrefix = "manifest-"
+
+	// maxShippedSegmentSize bounds the size of a single journal segment, and so the memory used to ship one.
+	maxShippedSegmentSize = 64 * 1024 * 1024
+
+	oldGenDirName = "oldgen"
+)
+
+// ErrJournalShippingConflict is returned when another store has shipped to the same blobstore.
+var ErrJournalShippingConflict = errors.New("another database is shipping its journal to this location")
+
+// ErrNoShippedRoot is returned when restoring to a time before the first root hash update which was shipped.
+var ErrNoShippedRoot = errors.New("no database state was shipped at or before the requested time")
+
+// shippingHead is the contents of the head blob, which records the epochs shipped.
+type shippingHead struct {
+	Epochs []shippedEpoch `json:"epochs"`
+}
+
+// shippedEpoch records what has been shipped of a single chunk journal.
+type shippedEpoch struct {
+	ID string `json:"id"`
+	// Start is the Unix time of the first root hash record shipped, or zero if none has been.
+	Start int64 `json:"start"`
+	// Shipped is the length of the prefix of the journal which has been shipped.
+	Shipped int64 `json:"shipped"`
+	// Manifests is the number of manifest snapshots shipped.
+	Manifests int `json:"manifests"`
+	// First is the checksum of the first journal record, and Last and LastLen are the checksum and the length of
+	// the last record shipped. Together they tell whether the journal on disk is still the one being shipped.
+	First   uint32 `json:"first"`
+	Last    uint32 `json:"last"`
+	LastLen uint32 `json:"last_len"`
+}
+
+// shippedManifest is a snapshot of the manifests of a store, shipped whenever they change.
+type shippedManifest struct {
+	Manifest string `json:"manifest"`
+	Oldgen   string `json:"oldgen,omitempty"`
+	// Files maps the names of the table files listed in the manifests to the names of the files shipped for them.
+	Files map[string]string `json:"files"`
+}
+
+func segmentKey(epoch string, off int64) string {
+	return fmt.Sprintf("%s%s-%020d", shippedSegmentPrefix, epoch, off)
+}
+
+func manifestSnapshotKey(epoch string, i int) string {
+	return fmt.Sprintf("%s%s-%010d", shippedManifestPrefix, epoch, i)
+}
+
+// JournalShipper ships the chunk journal, manifests and table files of the journaling store in a directory to a
+// blobstore. It is not safe for concurrent use.
+type JournalShipper struct {
+	dir string
+	bs  blobstore.Blobstore
+
+	head    shippingHead
+	version string
+	dirty   bool
+
+	// snapshot is the last manifest snapshot shipped in the current epoch.
+	snapshot shippedManifest
+	// tables holds the keys of the table files known to be in |bs|.
+	tables map[string]struct{}
+}
+
+// NewJournalShipper returns a JournalShipper which ships the store in |dir| to |bs|, resuming from what was shipped
+// to |bs| before.
+func NewJournalShipper(ctx context.Context, dir string, bs blobstore.Blobstore) (*JournalShipper, error) {
+	s := &JournalShipper{
+		dir:    dir,
+		bs:     newEncryptedBlobstore(bs, getKeyProvider()),
+		tables: make(map[string]struct{}),
+	}
+	head, ver, err := readShippingHead(ctx, s.bs)
+	if err != nil && !blobstore.IsNotFoundError(err) {
+		return nil, err
+	}
+	s.head, s.version = head, ver
+	return s, nil
+}
+
+func readShippingHead(ctx context.Context, bs blobstore.Blobstore) (shippingHead, string, error) {
+	data, ver, err := blobstore.GetBytes(ctx, bs, shippedHeadKey, blobstore.AllRange)
+	if err != nil {
+		return shippingHead{}, "", err
+	}
+	var head shippingHead
+	if err = json.Unmarshal(data, &head); err != nil {
+		return shippingHead{}, "", fmt.Errorf("invalid journal shipping head: %w", err)
+	}
+	return head, ver, nil
+}
+
+// Ship ships the journal records appended since the last call, along with the current manifests and any table files
+// they list which have not been shipped yet.
+func (s *JournalShipper) Ship(ctx context.Context) error {
+	f, size, err := OpenStorageFile(filepath.Join(s.dir, chunkJournalName))
+	if errors.Is(err, os.ErrNotExist) {
+		// the journal is created by the first write after a garbage collection
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	first, ok, err := readJournalRecordAt(f, 0, size)
+	if err != nil || !ok {
+		return err
+	}
+	var ep *shippedEpoch
+	if n := len(s.head.Epochs); n > 0 {
+		ep = &s.head.Epochs[n-1]
+	}
+	if ep == nil || !journalContinues(f, size, ep, first) {
+		s.head.Epochs = append(s.head.Epochs, shippedEpoch{
+			ID:    fmt.Sprintf("%d-%08x", time.Now().Unix(), first.checksum),
+			First: first.checksum,
+		})
+		ep = &s.head.Epochs[len(s.head.Epochs)-1]
+		s.snapshot = shippedManifest{}
+		s.dirty = true
+	}
+
+	// Table files are added to the manifest before the root hash records which reference them are written to the
+	// journal. Reading the manifests after the size of the journal ships every table file the journal needs.
+	if err = s.shipManifests(ctx, ep); err != nil {
+		return err
+	}
+	if err = s.shipJournal(ctx, f, size, ep); err != nil {
+		return err
+	}
+	return s.putHead(ctx)
+}
+
+// journalContinues returns whether the journal |f| is the journal which |ep| has been shipping.
+func journalContinues(f io.ReaderAt, size int64, ep *shippedEpoch, first journalRec) bool {
+	if first.checksum != ep.First || size < ep.Shipped {
+		return false
+	} else if ep.Shipped == 0 {
+		return true
+	}
+	last, ok, err := readJournalRecordAt(f, ep.Shipped-int64(ep.LastLen), size)
+	return err == nil && ok && last.checksum == ep.Last && last.length == ep.LastLen
+}
+
+// readJournalRecordAt reads the journal record at |off| of |f|, returning false if there is no complete and valid
+// record there.
+func readJournalRecordAt(f io.ReaderAt, off, size int64) (journalRec, bool, error) {
+	if off < 0 || off+journalRecLenSz > size {
+		return journalRec{}, false, nil
+	}
+	var lenBuf [journalRecLenSz]byte
+	if _, err := f.ReadAt(lenBuf[:], off); err != nil {
+		return journalRec{}, false, err
+	}
+	l := int64(readUint32(lenBuf[:]))
+	if l == 0 || l > int64(journalWriterBuffSize) || off+l > size {
+		return journalRec{}, false, nil
+	}
+	buf := make([]byte, l)
+	if _, err := f.ReadAt(buf, off); err != nil {
+		return journalRec{}, false, err
+	}
+	if validateJournalRecord(buf) != nil {
+		return journalRec{}, false, nil
+	}
+	rec, err := readJournalRecord(buf)
+	if err != nil {
+		return journalRec{}, false, nil
+	}
+	return rec, true, nil
+}
+
+// shipManifests ships a snapshot of the manifests of the store if they have changed since the last one shipped in
+// |ep|, shipping the table files they list first.
+func (s *JournalShipper) shipManifests(ctx context.Context, ep *shippedEpoch) error {
+	newGen, err := readManifestFile(s.dir)
+	if err != nil {
+		return err
+	}
+	oldGen, err := readManifestFile(filepath.Join(s.dir, oldGenDirName))
+	if err != nil {
+		return err
+	}
+	if ep.Manifests > 0 && newGen == s.snapshot.Manifest && oldGen == s.snapshot.Oldgen {
+		return nil
+	}
+
+	snap := shippedManifest{Manifest: newGen, Oldgen: oldGen, Files: make(map[string]string)}
+	for _, gen := range []struct{ dir, contents string }{{s.dir, newGen}, {filepath.Join(s.dir, oldGenDirName), oldGen}} {
+		if gen.contents == "" {
+			continue
+		}
+		mc, err := parseManifest(strings.NewReader(gen.contents))
+		if err != nil {
+			return err
+		}
+		for _, spec := range mc.specs {
+			if spec.name == journalAddr {
+				continue
+			}
+			fileName, ok, err := s.shipTable(ctx, gen.dir, spec.name)
+			if err != nil {
+				return err
+			} else if !ok {
+				// The table file was removed, by a conjoin or a garbage collection, after the manifest was read.
+				// Shipping the manifests without it would leave a snapshot which cannot be restored, so fail and
+				// read the manifests again on the next call.
+				return fmt.Errorf("table file %s listed in the manifest of %s no longer exists", spec.name, gen.dir)
+			}
+			snap.Files[spec.name.String()] = fileName
+		}
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if _, err = blobstore.PutBytes(ctx, s.bs, manifestSnapshotKey(ep.ID, ep.Manifests), data); err != nil {
+		return err
+	}
+	ep.Manifests++
+	s.snapshot = snap
+	s.dirty = true
+	return nil
+}
+
+// readManifestFile returns the contents of the manifest in |dir|, or an empty string if there is none.
+func readManifestFile(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// shipTable ships the table file or archive named |name| in |dir|, unless it has already been shipped. It returns the
+// name of the file shipped, or false if the file no longer exists.
+func (s *JournalShipper) shipTable(ctx context.Context, dir string, name hash.Hash) (string, bool, error) {
+	fileNames := []string{name.String(), name.String() + ArchiveFileSuffix}
+	for _, fileName := range fileNames {
+		if _, ok := s.tables[shippedTablePrefix+fileName]; ok {
+			return fileName, true, nil
+		}
+	}
+	for _, fileName := range fileNames {
+		ok, err := s.putTableFile(ctx, filepath.Join(dir, fileName), shippedTablePrefix+fileName)
+		if err != nil {
+			return "", false, err
+		} else if ok {
+			s.tables[shippedTablePrefix+fileName] = struct{}{}
+			return fileName, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (s *JournalShipper) putTableFile(ctx context.Context, path, key string) (bool, error) {
+	r, size, err := OpenStorageFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	exists, err := s.bs.Exists(ctx, key)
+	if err != nil {
+		return false, err
+	} else if !exists {
+		if _, err = s.bs.Put(ctx, key, size, r); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// shipJournal ships the complete records of the first |size| bytes of the journal |f| which have not been shipped.
+func (s *JournalShipper) shipJournal(ctx context.Context, f io.ReaderAt, size int64, ep *shippedEpoch) error {
+	for ep.Shipped < size {
+		buf := make([]byte, min(size-ep.Shipped, maxShippedSegmentSize))
+		if _, err := f.ReadAt(buf, ep.Shipped); err != nil && err != io.EOF {
+			return err
+		}
+
+		end, start := ep.Shipped, ep.Start
+		var last journalRec
+		_, _, _, err := processJournalRecordsReader(ctx, bytes.NewReader(buf), ep.Shipped, func(o int64, r journalRec) error {
+			if r.kind == rootHashJournalRecKind && start == 0 && !r.timestamp.IsZero() {
+				start = r.timestamp.Unix()
+			}
+			end, last = o+int64(r.length), r
+			return nil
+		}, nil)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if end == ep.Shipped {
+			// the next record is still being written
+			return nil
+		}
+
+		if _, err = blobstore.PutBytes(ctx, s.bs, segmentKey(ep.ID, ep.Shipped), buf[:end-ep.Shipped]); err != nil {
+			return err
+		}
+		ep.Start, ep.Shipped, ep.Last, ep.LastLen = start, end, last.checksum, last.length
+		s.dirty = true
+	}
+	return nil
+}
+
+func (s *JournalShipper) putHead(ctx context.Context) error {
+	if !s.dirty {
+		return nil
+	}
+	data, err := json.Marshal(s.head)
+	if err != nil {
+		return err
+	}
+	ver, err := s.bs.CheckAndPut(ctx, s.version, shippedHeadKey, int64(len(data)), bytes.NewReader(data))
+	if blobstore.IsCheckAndPutError(err) {
+		return fmt.Errorf("%w: %s", ErrJournalShippingConflict, s.bs.Path())
+	} else if err != nil {
+		return err
+	}
+	s.version, s.dirty = ver, false
+	return nil
+}
+
+// RestoreShippedJournal rebuilds the store shipped to |bs| by a JournalShipper in |dir|, which must exist and be
+// empty, as of the last root hash update made at or before |t|. A zero |t| restores the last root hash update
+// shipped. It returns the time of the root hash update restored.
+func RestoreShippedJournal(ctx context.Context, bs blobstore.Blobstore, dir string, t time.Time) (time.Time, error) {
+	ebs := newEncryptedBlobstore(bs, getKeyProvider())
+	head, _, err := readShippingHead(ctx, ebs)
+	if blobstore.IsNotFoundError(err) {
+		return time.Time{}, fmt.Errorf("%w: nothing has been shipped to %s", ErrNoShippedRoot, bs.Path())
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	var ep *shippedEpoch
+	for i := len(head.Epochs) - 1; i >= 0; i-- {
+		e := &head.Epochs[i]
+		if e.Start != 0 && (t.IsZero() || e.Start <= t.Unix()) {
+			ep = e
+			break
+		}
+	}
+	if ep == nil {
+		return time.Time{}, ErrNoShippedRoot
+	}
+
+	root, at, chunkCount, err := restoreJournal(ctx, ebs, dir, ep, t)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	snaps := make([]shippedManifest, ep.Manifests)
+	for i := range snaps {
+		data, _, err := blobstore.GetBytes(ctx, ebs, manifestSnapshotKey(ep.ID, i), blobstore.AllRange)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if err = json.Unmarshal(data, &snaps[i]); err != nil {
+			return time.Time{}, fmt.Errorf("invalid shipped manifest: %w", err)
+		}
+	}
+
+	// Every table file of the epoch is restored, as the chunks of a table file removed by a conjoin before it could
+	// be shipped are only found in the table files shipped after it.
+	journalSpec := tableSpec{name: journalAddr, chunkCount: chunkCount}
+	ok, err := restoreManifest(ctx, ebs, dir, snaps, func(m shippedManifest) string { return m.Manifest }, root, journalSpec)
+	if err != nil {
+		return time.Time{}, err
+	} else if !ok {
+		return time.Time{}, errors.New("no manifest was shipped for the restored journal")
+	}
+	_, err = restoreManifest(ctx, ebs, filepath.Join(dir, oldGenDirName), snaps, func(m shippedManifest) string { return m.Oldgen }, hash.Hash{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return at, nil
+}
+
+var errRestorePointReached = errors.New("restore point reached")
+
+// restoreJournal writes the journal of |ep| to |dir|, up to the last root hash record made at or before |t|. It
+// returns that record's root hash and time, and the number of chunks in the restored journal.
+func restoreJournal(ctx context.Context, bs blobstore.Blobstore, dir string, ep *shippedEpoch, t time.Time) (root hash.Hash, at time.Time, chunkCount uint32, err error) {
+	osf, err := os.OpenFile(filepath.Join(dir, chunkJournalName), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return hash.Hash{}, time.Time{}, 0, err
+	}
+	f, err := newStorageFile(osf)
+	if err != nil {
+		osf.Close()
+		return hash.Hash{}, time.Time{}, 0, err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	var cut int64
+	var count uint32
+	done := false
+	for off := int64(0); off < ep.Shipped && !done; {
+		seg, _, err := blobstore.GetBytes(ctx, bs, segmentKey(ep.ID, off), blobstore.AllRange)
+		if blobstore.IsNotFoundError(err) || (err == nil && len(seg) == 0) {
+			return hash.Hash{}, time.Time{}, 0, fmt.Errorf("shipped journal is missing the segment at offset %d", off)
+		} else if err != nil {
+			return hash.Hash{}, time.Time{}, 0, err
+		}
+
+		_, _, _, err = processJournalRecordsReader(ctx, bytes.NewReader(seg), off, func(o int64, r journalRec) error {
+			switch r.kind {
+			case chunkJournalRecKind:
+				count++
+			case rootHashJournalRecKind:
+				if !t.IsZero() && r.timestamp.After(t) {
+					return errRestorePointReached
+				}
+				root, at, cut, chunkCount = r.address, r.timestamp, o+int64(r.length), count
+			}
+			return nil
+		}, nil)
+		if errors.Is(err, errRestorePointReached) {
+			done = true
+		} else if err != nil && err != io.EOF {
+			return hash.Hash{}, time.Time{}, 0, err
+		}
+
+		if _, err = f.Write(seg); err != nil {
+			return hash.Hash{}, time.Time{}, 0, err
+		}
+		off += int64(len(seg))
+	}
+	if root.IsEmpty() {
+		return hash.Hash{}, time.Time{}, 0, ErrNoShippedRoot
+	}
+
+	if err = f.Truncate(cut); err != nil {
+		return hash.Hash{}, time.Time{}, 0, err
+	}
+	if err = f.Sync(); err != nil {
+		return hash.Hash{}, time.Time{}, 0, err
+	}
+	return root, at, chunkCount, nil
+}
+
+// restoreManifest writes the table files listed by the manifests |contents| returns for |snaps| to |dir|, along with
+// a manifest listing them and |extra|. The manifest's root hash is |root|, or that of the last manifest if |root| is
+// empty. It returns false if none of |snaps| has a manifest.
+func restoreManifest(ctx context.Context, bs blobstore.Blobstore, dir string, snaps []shippedManifest, contents func(shippedManifest) string, root hash.Hash, extra ...tableSpec) (bool, error) {
+	var latest manifestContents
+	found := false
+	specs := extra
+	files := make(map[hash.Hash]string)
+	for _, snap := range snaps {
+		c := contents(snap)
+		if c == "" {
+			continue
+		}
+		mc, err := parseManifest(strings.NewReader(c))
+		if err != nil {
+			return false, err
+		}
+		latest, found = mc, true
+		for _, spec := range mc.specs {
+			fileName, ok := snap.Files[spec.name.String()]
+			if _, seen := files[spec.name]; !ok || seen || spec.name == journalAddr {
+				continue
+			}
+			files[spec.name] = fileName
+			specs = append(specs, spec)
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return false, err
+	}
+	for _, fileName := range files {
+		if err := restoreTableFile(ctx, bs, dir, fileName); err != nil {
+			return false, err
+		}
+	}
+
+	if root.IsEmpty() {
+		root = latest.root
+	}
+	mc := manifestContents{
+		nbfVers: latest.nbfVers,
+		root:    root,
+		gcGen:   latest.gcGen,
+		specs:   specs,
+	}
+	mc.lock = generateLockHash(mc.root, mc.specs, nil, nil)
+
+	f, err := os.OpenFile(filepath.Join(dir, manifestFileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return false, err
+	}
+	if err = writeManifest(f, mc); err != nil {
+		f.Close()
+		return false, err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return false, err
+	}
+	return true, f.Close()
+}
+
+func restoreTableFile(ctx context.Context, bs blobstore.Blobstore, dir, fileName string) (err error) {
+	rc, _, _, err := bs.Get(ctx, shippedTablePrefix+fileName, blobstore.AllRange)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	osf, err := os.OpenFile(filepath.Join(dir, fileName), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+	f, err := newStorageFile(osf)
+	if err != nil {
+		osf.Close()
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if _, err = io.Copy(f, rc); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestJournalShipping(t *testing.T) {
+	ctx := context.Background()
+	origTimestampGenerator := journalRecordTimestampGenerator
+	t.Cleanup(func() { journalRecordTimestampGenerator = origTimestampGenerator })
+	now := uint64(1_700_000_000)
+	journalRecordTimestampGenerator = func() uint64 { return now }
+
+	nbf := types.Format_DOLT.VersionString()
+	dir := t.TempDir()
+	st, err := NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider(), false, nil)
+	require.NoError(t, err)
+	defer st.Close()
+
+	bs := blobstore.NewInMemoryBlobstore("")
+	shipper, err := NewJournalShipper(ctx, dir, bs)
+	require.NoError(t, err)
+	interloper, err := NewJournalShipper(ctx, dir, bs)
+	require.NoError(t, err)
+
+	var roots []hash.Hash
+	for i := 0; i < 3; i++ {
+		now += 10
+		c := chunks.NewChunk([]byte(fmt.Sprintf("root %d", i)))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		last, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, c.Hash(), last)
+		require.NoError(t, err)
+		require.True(t, ok)
+		roots = append(roots, c.Hash())
+		require.NoError(t, shipper.Ship(ctx))
+	}
+	// shipping again without changes is a no-op
+	require.NoError(t, shipper.Ship(ctx))
+
+	t.Run("restore to a time", func(t *testing.T) {
+		for i, root := range roots {
+			at := time.Unix(int64(1_700_000_000+10*(i+1)+5), 0)
+			restored, restoredAt := restoreShippedStore(t, bs, at)
+			assert.Equal(t, root, restored)
+			assert.Equal(t, time.Unix(int64(1_700_000_000+10*(i+1)), 0), restoredAt)
+		}
+	})
+
+	t.Run("restore the latest root", func(t *testing.T) {
+		restored, _ := restoreShippedStore(t, bs, time.Time{})
+		assert.Equal(t, roots[len(roots)-1], restored)
+	})
+
+	t.Run("restore before the first root", func(t *testing.T) {
+		_, err := RestoreShippedJournal(ctx, bs, t.TempDir(), time.Unix(1_600_000_000, 0))
+		require.ErrorIs(t, err, ErrNoShippedRoot)
+	})
+
+	t.Run("resume shipping", func(t *testing.T) {
+		resumed, err := NewJournalShipper(ctx, dir, bs)
+		require.NoError(t, err)
+		now += 10
+		c := chunks.NewChunk([]byte("resumed"))
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+		ok, err := st.Commit(ctx, c.Hash(), roots[len(roots)-1])
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, resumed.Ship(ctx))
+
+		restored, _ := restoreShippedStore(t, bs, time.Time{})
+		assert.Equal(t, c.Hash(), restored)
+		restored, _ = restoreShippedStore(t, bs, time.Unix(1_700_000_035, 0))
+		assert.Equal(t, roots[2], restored)
+	})
+
+	t.Run("missing table file fails the tick", func(t *testing.T) {
+		path := filepath.Join(dir, manifestFileName)
+		orig, err := os.ReadFile(path)
+		require.NoError(t, err)
+		mc, err := parseManifest(bytes.NewReader(orig))
+		require.NoError(t, err)
+		mc.specs = append(mc.specs, tableSpec{name: hash.Of([]byte("removed table file")), chunkCount: 1})
+		var buf bytes.Buffer
+		require.NoError(t, writeManifest(&buf, mc))
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0666))
+
+		resumed, err := NewJournalShipper(ctx, dir, bs)
+		require.NoError(t, err)
+		shipped := resumed.head.Epochs[len(resumed.head.Epochs)-1].Manifests
+		require.Error(t, resumed.Ship(ctx))
+		assert.Equal(t, shipped, resumed.head.Epochs[len(resumed.head.Epochs)-1].Manifests)
+
+		// the next tick reads the manifests again
+		require.NoError(t, os.WriteFile(path, orig, 0666))
+		require.NoError(t, resumed.Ship(ctx))
+		root, err := st.Root(ctx)
+		require.NoError(t, err)
+		restored, _ := restoreShippedStore(t, bs, time.Time{})
+		assert.Equal(t, root, restored)
+	})
+
+	t.Run("concurrent shippers conflict", func(t *testing.T) {
+		err := interloper.Ship(ctx)
+		require.ErrorIs(t, err, ErrJournalShippingConflict)
+	})
+}
+
+// restoreShippedStore restores the store shipped to |bs| as of |at| and returns its root hash, checking that the
+// root chunk is present.
+func restoreShippedStore(t *testing.T, bs blobstore.Blobstore, at time.Time) (hash.Hash, time.Time) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	restoredAt, err := RestoreShippedJournal(ctx, bs, dir, at)
+	require.NoError(t, err)
+
+	st, err := NewLocalJournalingStore(ctx, types.Format_DOLT.VersionString(), dir, NewUnlimitedMemQuotaProvider(), false, nil)
+	require.NoError(t, err)
+	defer st.Close()
+	root, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Has(ctx, root)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	return root, restoredAt
+}
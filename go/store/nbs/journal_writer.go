@@ -83,7 +83,7 @@ func fileExists(path string) (bool, error) {
 }
 
 func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exists bool, err error) {
-	var f *os.File
+	var f storageFile
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, false, err
 	}
@@ -96,7 +96,11 @@ func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exi
 	} else if info.IsDir() {
 		return nil, true, fmt.Errorf("expected file %s found directory", chunkJournalName)
 	}
-	if f, err = os.OpenFile(path, os.O_RDWR, 0666); err != nil {
+	if f, err = openStorageFile(path, os.O_RDWR); err != nil {
+		return nil, true, err
+	}
+	if err = dropTornBlocks(f); err != nil {
+		f.Close()
 		return nil, true, err
 	}
 
@@ -108,7 +112,7 @@ func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exi
 }
 
 func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, err error) {
-	var f *os.File
+	var f storageFile
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, err
 	}
@@ -120,7 +124,13 @@ func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, e
 		return nil, err
 	}
 
-	if f, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666); err != nil {
+	osf, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return nil, err
+	}
+	// a new journal is encrypted with the current key, which is how a full gc rotates the journal's key
+	if f, err = newStorageFile(osf); err != nil {
+		osf.Close()
 		return nil, err
 	}
 
@@ -141,7 +151,7 @@ func deleteJournalAndIndexFiles(ctx context.Context, path string) (err error) {
 
 type journalWriter struct {
 	ranges      rangeIndex
-	journal     *os.File
+	journal     storageFile
 	indexWriter *bufio.Writer
 	index       *os.File
 	path        string
@@ -667,7 +677,7 @@ func (wr *journalWriter) snapshot(ctx context.Context, behavior dherrors.FatalBe
 	}
 	// open a new file descriptor with an
 	// independent lifecycle from |wr.file|
-	f, err := os.Open(wr.path)
+	f, err := openStorageFile(wr.path, os.O_RDONLY)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -0,0 +1,350 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// maxLazyFetchCommitAttempts bounds the number of times persisting lazily fetched chunks is retried when the root
+	// of the store is moved concurrently.
+	maxLazyFetchCommitAttempts = 5
+
+	// lazyFetchPersistBatch is the number of fetched chunks which are cached before they are persisted in the
+	// background. Chunks fetched since the last batch are persisted when the store is closed.
+	lazyFetchPersistBatch = 4096
+
+	// lazyFetchRefsFile is the file next to ghostObjects.txt recording the addresses referenced by fetched chunks.
+	lazyFetchRefsFile = "lazyFetchRefs.txt"
+)
+
+// LazyFetchSource opens the chunk store that chunks missing from a GenerationalNBS are fetched from. It is called
+// at most once, the first time a chunk is missing.
+type LazyFetchSource func(ctx context.Context) (chunks.ChunkStore, error)
+
+// lazyFetcher fetches chunks which are missing from a shallow clone from the remote it was cloned from. Only the
+// history skipped by the clone is fetched: its ghost commits and the chunks referenced by chunks fetched before.
+// Other missing chunks are not looked for in the remote.
+type lazyFetcher struct {
+	open     LazyFetchSource
+	getAddrs chunks.GetAddrs
+	// refsPath is the file |refs| are recorded in, or empty if they are not recorded.
+	refsPath string
+
+	mu  sync.Mutex
+	src chunks.ChunkStore
+	// refs holds the addresses referenced by fetched chunks, loaded from |refsPath| the first time a chunk is missing.
+	refs   hash.HashSet
+	loaded bool
+	// newRefs holds the members of |refs| which have not been recorded in |refsPath| yet.
+	newRefs hash.HashSet
+	// unpersisted is the number of chunks cached since fetched chunks were last persisted.
+	unpersisted int
+	// persisting is closed when the batch being persisted in the background is done, and is nil if there is none.
+	persisting chan struct{}
+}
+
+func (lf *lazyFetcher) source(ctx context.Context) (chunks.ChunkStore, error) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.src == nil {
+		src, err := lf.open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lf.src = src
+	}
+	return lf.src, nil
+}
+
+// fetchable returns the members of |hashes| which are ghosts of |ghostGen| or were referenced by fetched chunks.
+func (lf *lazyFetcher) fetchable(ctx context.Context, ghostGen *GhostBlockStore, hashes hash.HashSet) (hash.HashSet, error) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if err := lf.loadRefs(); err != nil {
+		return nil, err
+	}
+
+	fetch := hash.HashSet{}
+	for h := range hashes {
+		if lf.refs.Has(h) {
+			fetch.Insert(h)
+			continue
+		}
+		if ghostGen != nil {
+			ghost, err := ghostGen.Has(ctx, h)
+			if err != nil {
+				return nil, err
+			}
+			if ghost {
+				fetch.Insert(h)
+			}
+		}
+	}
+	return fetch, nil
+}
+
+// loadRefs reads |refs| from |refsPath| the first time it is called. It is called with |mu| held.
+func (lf *lazyFetcher) loadRefs() error {
+	if lf.loaded {
+		return nil
+	}
+	lf.refs, lf.newRefs = hash.HashSet{}, hash.HashSet{}
+	if lf.refsPath != "" {
+		f, err := os.Open(lf.refsPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		} else if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				h, ok := hash.MaybeParse(scanner.Text())
+				if !ok {
+					return fmt.Errorf("invalid hash %s in %s", scanner.Text(), lazyFetchRefsFile)
+				}
+				lf.refs.Insert(h)
+			}
+			if err = scanner.Err(); err != nil {
+				return err
+			}
+		}
+	}
+	lf.loaded = true
+	return nil
+}
+
+// addRefs adds the addresses referenced by |fetched| to |refs|.
+func (lf *lazyFetcher) addRefs(fetched []chunks.Chunk) error {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	for _, c := range fetched {
+		err := lf.getAddrs(c, func(h hash.Hash) error {
+			if !lf.refs.Has(h) {
+				lf.refs.Insert(h)
+				lf.newRefs.Insert(h)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRefs appends the members of |refs| which are not recorded yet to |refsPath|.
+func (lf *lazyFetcher) writeRefs() error {
+	lf.mu.Lock()
+	newRefs := lf.newRefs
+	lf.newRefs = hash.HashSet{}
+	lf.mu.Unlock()
+	if len(newRefs) == 0 || lf.refsPath == "" {
+		return nil
+	}
+
+	err := appendHashes(lf.refsPath, newRefs)
+	if err != nil {
+		// they are written with the next batch
+		lf.mu.Lock()
+		lf.newRefs.InsertAll(newRefs)
+		lf.mu.Unlock()
+	}
+	return err
+}
+
+func appendHashes(path string, hashes hash.HashSet) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for h := range hashes {
+		if _, err = w.WriteString(h.String() + "\n"); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SetLazyFetchSource makes |gcs| fetch the history it is missing, its ghost chunks and the chunks they reference,
+// from the chunk store opened by |open| the first time they are requested. |getAddrs| returns the addresses
+// referenced by a fetched chunk. Fetched chunks are added to the new generation, so that each is only fetched once.
+// Passing nil disables lazy fetching.
+func (gcs *GenerationalNBS) SetLazyFetchSource(open LazyFetchSource, getAddrs chunks.GetAddrs) {
+	if open == nil {
+		gcs.lazy = nil
+		return
+	}
+	lf := &lazyFetcher{open: open, getAddrs: getAddrs}
+	if gcs.ghostGen != nil {
+		lf.refsPath = filepath.Join(filepath.Dir(gcs.ghostGen.ghostObjectsFile), lazyFetchRefsFile)
+	}
+	gcs.lazy = lf
+}
+
+// fetchMissing fetches |hashes| from the lazy fetch source of |gcs|, calling |found| with each chunk found, and
+// returns the hashes which were not found. Hashes which are not part of the history skipped by a shallow clone are
+// not fetched. If lazy fetching is not enabled, all |hashes| are returned.
+func (gcs *GenerationalNBS) fetchMissing(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) (hash.HashSet, error) {
+	if gcs.lazy == nil || len(hashes) == 0 {
+		return hashes, nil
+	}
+	fetch, err := gcs.lazy.fetchable(ctx, gcs.ghostGen, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if len(fetch) == 0 {
+		return hashes, nil
+	}
+	src, err := gcs.lazy.source(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open remote to fetch missing chunks from: %w", err)
+	}
+
+	var mu sync.Mutex
+	var fetched []chunks.Chunk
+	notFound := hashes.Copy()
+	err = src.GetMany(ctx, fetch, func(ctx context.Context, c *chunks.Chunk) {
+		// the remote may itself be a shallow clone
+		if c.IsGhost() {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		delete(notFound, c.Hash())
+		fetched = append(fetched, *c)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch missing chunks from remote: %w", err)
+	}
+
+	if err = gcs.lazy.addRefs(fetched); err != nil {
+		return nil, err
+	}
+	if err = gcs.cacheFetched(ctx, fetched); err != nil {
+		return nil, err
+	}
+	for i := range fetched {
+		found(ctx, &fetched[i])
+	}
+	return notFound, nil
+}
+
+// cacheFetched adds |fetched| to the new generation. They are persisted in the background once a batch of them has
+// been cached, see persistFetched. Chunks fetched by a read only store are not cached.
+func (gcs *GenerationalNBS) cacheFetched(ctx context.Context, fetched []chunks.Chunk) error {
+	if len(fetched) == 0 || gcs.AccessMode() == chunks.ExclusiveAccessMode_ReadOnly {
+		return nil
+	}
+
+	for _, c := range fetched {
+		// The references of a fetched chunk may not be present yet; they are fetched when they are read.
+		err := gcs.newGen.putChunk(ctx, c, noFetchedAddrs, gcs.refCheck)
+		if err != nil {
+			return err
+		}
+	}
+
+	lf := gcs.lazy
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	lf.unpersisted += len(fetched)
+	if lf.unpersisted >= lazyFetchPersistBatch && lf.persisting == nil {
+		lf.unpersisted = 0
+		done := make(chan struct{})
+		lf.persisting = done
+		go func() {
+			// The batch outlives the read that filled it.
+			err := gcs.persistFetched(context.Background())
+			if err != nil {
+				gcs.newGen.logger.WithError(err).Warn("failed to persist chunks fetched from remote")
+			}
+			lf.mu.Lock()
+			lf.persisting = nil
+			lf.mu.Unlock()
+			close(done)
+		}()
+	}
+	return nil
+}
+
+// flushFetched waits for the batch of fetched chunks being persisted in the background, if any, and persists the
+// chunks fetched since.
+func (gcs *GenerationalNBS) flushFetched(ctx context.Context) error {
+	lf := gcs.lazy
+	if lf == nil || gcs.AccessMode() == chunks.ExclusiveAccessMode_ReadOnly {
+		return nil
+	}
+	lf.mu.Lock()
+	persisting := lf.persisting
+	lf.mu.Unlock()
+	if persisting != nil {
+		<-persisting
+	}
+
+	lf.mu.Lock()
+	unpersisted := lf.unpersisted
+	lf.unpersisted = 0
+	lf.mu.Unlock()
+	if unpersisted == 0 {
+		return lf.writeRefs()
+	}
+	return gcs.persistFetched(ctx)
+}
+
+// persistFetched records the addresses referenced by fetched chunks and persists the chunks cached in the new
+// generation, without moving the root of the store.
+func (gcs *GenerationalNBS) persistFetched(ctx context.Context) error {
+	// The references are recorded first, so that the history below persisted chunks can always be fetched.
+	if err := gcs.lazy.writeRefs(); err != nil {
+		return err
+	}
+
+	for i := 0; i < maxLazyFetchCommitAttempts; i++ {
+		root, err := gcs.Root(ctx)
+		if err != nil {
+			return err
+		}
+		ok, err := gcs.Commit(ctx, root, root)
+		if err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to persist chunks fetched from remote: root of the database changed %d times", maxLazyFetchCommitAttempts)
+}
+
+func noFetchedAddrs(chunks.Chunk) chunks.InsertAddrsCb {
+	return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+		return nil
+	}
+}
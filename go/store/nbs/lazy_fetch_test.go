@@ -0,0 +1,154 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestLazyFetch(t *testing.T) {
+	ctx := context.Background()
+	chnks := genChunks(t, 9, 1000)
+
+	// chunks 0-1 are local, 2-5 and 8 are only in the remote, 6 is a ghost in the remote too, and 7 is a ghost
+	// missing from the remote. 6 references 2-5 and 8, which are only fetched once 6 has been.
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, newGenDir, _ := makeTestLocalStore(t, 64)
+	ghostGen, err := NewGhostBlockStore(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, ghostGen.PersistGhostHashes(ctx, hash.NewHashSet(chnks[6].Hash(), chnks[7].Hash())))
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+	for _, c := range chnks[:2] {
+		require.NoError(t, cs.Put(ctx, c, noopGetAddrs))
+	}
+
+	src := (&chunks.MemoryStorage{}).NewView()
+	for _, c := range append(chnks[2:7:7], chnks[8]) {
+		require.NoError(t, src.Put(ctx, c, noopGetAddrs))
+	}
+	getAddrs := func(c chunks.Chunk, cb func(hash.Hash) error) error {
+		if c.Hash() != chnks[6].Hash() {
+			return nil
+		}
+		for _, ref := range append(chnks[2:6:6], chnks[8]) {
+			if err := cb(ref.Hash()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	c, err := cs.Get(ctx, chnks[2].Hash())
+	require.NoError(t, err)
+	assert.True(t, c.IsEmpty())
+
+	opened := 0
+	open := func(ctx context.Context) (chunks.ChunkStore, error) {
+		opened++
+		return src, nil
+	}
+	cs.SetLazyFetchSource(open, getAddrs)
+
+	t.Run("Unreachable", func(t *testing.T) {
+		c, err := cs.Get(ctx, chnks[2].Hash())
+		require.NoError(t, err)
+		assert.True(t, c.IsEmpty())
+		c, err = cs.Get(ctx, hash.Of([]byte("missing")))
+		require.NoError(t, err)
+		assert.True(t, c.IsEmpty())
+		assert.Equal(t, 0, opened)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		c, err := cs.Get(ctx, chnks[6].Hash())
+		require.NoError(t, err)
+		assert.False(t, c.IsGhost())
+		assert.Equal(t, chnks[6].Data(), c.Data())
+
+		c, err = cs.Get(ctx, chnks[2].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, chnks[2].Data(), c.Data())
+		has, err := newGen.Has(ctx, chnks[2].Hash())
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		c, err = cs.Get(ctx, chnks[7].Hash())
+		require.NoError(t, err)
+		assert.True(t, c.IsGhost())
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		expected := hash.NewHashSet(chnks[0].Hash(), chnks[3].Hash(), chnks[4].Hash())
+		received := foundHashes{}
+		require.NoError(t, cs.GetMany(ctx, expected, received.found))
+		assert.Equal(t, expected, hash.HashSet(received))
+		absent, err := newGen.HasMany(ctx, expected)
+		require.NoError(t, err)
+		assert.Empty(t, absent)
+	})
+
+	t.Run("GetManyCompressed", func(t *testing.T) {
+		var fetched []chunks.Chunk
+		err := cs.GetManyCompressed(ctx, hash.NewHashSet(chnks[5].Hash()), func(ctx context.Context, tc ToChunker) {
+			c, err := tc.ToChunk()
+			require.NoError(t, err)
+			fetched = append(fetched, c)
+		})
+		require.NoError(t, err)
+		require.Len(t, fetched, 1)
+		assert.Equal(t, chnks[5].Data(), fetched[0].Data())
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		require.NoError(t, cs.flushFetched(ctx))
+		root, err := cs.Root(ctx)
+		require.NoError(t, err)
+		assert.True(t, root.IsEmpty())
+
+		reopened, err := NewLocalStore(ctx, newGen.Version(), newGenDir, testMemTableSize, NewUnlimitedMemQuotaProvider(), false)
+		require.NoError(t, err)
+		defer reopened.Close()
+		for _, c := range chnks[2:7] {
+			has, err := reopened.Has(ctx, c.Hash())
+			require.NoError(t, err)
+			assert.True(t, has)
+		}
+		assert.Equal(t, 1, opened)
+
+		// the references of fetched chunks are fetched by a new source too
+		cs.SetLazyFetchSource(open, getAddrs)
+		c, err := cs.Get(ctx, chnks[8].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, chnks[8].Data(), c.Data())
+		assert.Equal(t, 2, opened)
+	})
+
+	t.Run("OpenError", func(t *testing.T) {
+		cs.SetLazyFetchSource(func(ctx context.Context) (chunks.ChunkStore, error) {
+			return nil, errors.New("remote unavailable")
+		}, getAddrs)
+		defer cs.SetLazyFetchSource(nil, nil)
+		_, err := cs.Get(ctx, chnks[7].Hash())
+		assert.ErrorContains(t, err, "remote unavailable")
+	})
+}
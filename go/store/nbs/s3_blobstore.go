@@ -0,0 +1,124 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// s3Blobstore is a blobstore.Blobstore over the table files of an AWS store, so that they can be encrypted by an
+// encryptedBlobstore. The manifest of an AWS store is kept in DynamoDB, so it does not support CheckAndPut or
+// Concatenate.
+type s3Blobstore struct {
+	s3p awsTablePersister
+}
+
+var _ blobstore.Blobstore = s3Blobstore{}
+
+var errS3BlobstoreUnsupported = errors.New("unsupported operation on the table files of an AWS store")
+
+func (s3bs s3Blobstore) Path() string {
+	return "s3://" + s3bs.s3p.bucket + "/" + s3bs.s3p.ns
+}
+
+func (s3bs s3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	rc, _, _, err := s3bs.Get(ctx, key, blobstore.NewBlobRange(0, 1))
+	if blobstore.IsNotFoundError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, rc.Close()
+}
+
+func (s3bs s3Blobstore) Get(ctx context.Context, key string, br blobstore.BlobRange) (io.ReadCloser, uint64, string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s3bs.s3p.bucket),
+		Key:    aws.String(s3bs.s3p.key(key)),
+	}
+	switch off, length := br.Offset(), br.Length(); {
+	case off == 0 && length == 0:
+	case off < 0 && length == 0:
+		input.Range = aws.String(httpEndRangeHeader(int(-off)))
+	case off >= 0 && length == 0:
+		input.Range = aws.String(fmt.Sprintf("%s=%d-", s3RangePrefix, off))
+	case off >= 0:
+		input.Range = aws.String(httpRangeHeader(off, length))
+	default:
+		return nil, 0, "", fmt.Errorf("cannot read %d bytes at offset %d of %s", length, off, key)
+	}
+
+	if s3bs.s3p.rl != nil {
+		s3bs.s3p.rl <- struct{}{}
+		defer func() {
+			<-s3bs.s3p.rl
+		}()
+	}
+	result, err := s3bs.s3p.s3.GetObject(ctx, input)
+	if isS3NotFoundError(err) {
+		return nil, 0, "", blobstore.NotFound{Key: key}
+	} else if err != nil {
+		return nil, 0, "", err
+	}
+
+	sz := uint64(aws.ToInt64(result.ContentLength))
+	if result.ContentRange != nil {
+		if i := strings.Index(*result.ContentRange, "/"); i != -1 {
+			if total, err := strconv.ParseUint((*result.ContentRange)[i+1:], 10, 64); err == nil {
+				sz = total
+			}
+		}
+	}
+	return result.Body, sz, aws.ToString(result.ETag), nil
+}
+
+func isS3NotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var nsk *s3types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return true
+	}
+	var coded interface{ ErrorCode() string }
+	return errors.As(err, &coded) && coded.ErrorCode() == "NoSuchKey"
+}
+
+func (s3bs s3Blobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", s3bs.s3p.multipartUpload(ctx, reader, uint64(totalSize), key)
+}
+
+func (s3bs s3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", errS3BlobstoreUnsupported
+}
+
+func (s3bs s3Blobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return "", errS3BlobstoreUnsupported
+}
+
+func (s3bs s3Blobstore) Teardown(ctx context.Context) error {
+	return nil
+}
@@ -18,7 +18,6 @@ import (
 	"context"
 	"errors"
 	"io"
-	"os"
 	"sync/atomic"
 	"time"
 
@@ -39,7 +38,7 @@ func shouldSpool(bs blobstore.Blobstore) bool {
 // reads. The whole blob is spooled once into a local temp file at construction. Every
 // read is served from that file, whose lifetime is bound to the open chunk source.
 type spoolingTableReaderAt struct {
-	f   *os.File
+	f   storageFile
 	sz  int64
 	cnt *int32 // clone() increments and Close() decrements it. The temp file is removed at zero.
 }
@@ -52,10 +51,16 @@ func newSpoolingTableReaderAt(ctx context.Context, bs blobstore.Blobstore, key s
 	}
 	defer rc.Close()
 
-	f, err := tempfiles.MovableTempFileProvider.NewFile("", "nbs-spool-")
+	tf, err := tempfiles.MovableTempFileProvider.NewFile("", "nbs-spool-")
 	if err != nil {
 		return nil, err
 	}
+	f, err := newStorageFile(tf)
+	if err != nil {
+		tf.Close()
+		_ = file.Remove(tf.Name())
+		return nil, err
+	}
 	sz, err := io.Copy(f, rc)
 	if err != nil {
 		f.Close()
@@ -77,7 +82,7 @@ func (s *spoolingTableReaderAt) ReadAtWithStats(ctx context.Context, p []byte, o
 
 // Reader returns an independent reader over the whole spooled file. It holds its own
 // reference, so the reader stays valid until the caller closes it, even after the owning
-// chunk source is closed. [os.File.ReadAt] does not move the file offset, so the reader
+// chunk source is closed. ReadAt does not move the file offset, so the reader
 // is safe alongside concurrent ReadAt calls.
 func (s *spoolingTableReaderAt) Reader(ctx context.Context) (io.ReadCloser, error) {
 	src := s.ref()
@@ -676,7 +676,9 @@ func OverwriteStoreManifest(ctx context.Context, store *NomsBlockStore, root has
 	return nil
 }
 
-func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string, s3 S3APIV2, ddb DynamoDBAPIV2, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+// NewAWSStore returns an nbs implementation backed by S3 and DynamoDB. If |keys| is not nil, the table files it stores
+// are encrypted with them, see encryptedBlobstore.
+func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string, s3 S3APIV2, ddb DynamoDBAPIV2, memTableSize uint64, q MemoryQuotaProvider, keys KeyProvider) (*NomsBlockStore, error) {
 	readRateLimiter := make(chan struct{}, 32)
 	p := &awsTablePersister{
 		s3,
@@ -687,13 +689,18 @@ func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string
 		awsLimits{defaultS3PartSize, minS3PartSize, maxS3PartSize},
 	}
 	mm := manifest(newDynamoManifest(table, ns, ddb))
+	if keys != nil {
+		// encrypted table files cannot be assembled from the parts of other table files, see encryptedBlobstore
+		bs := newEncryptedBlobstore(s3Blobstore{*p}, keys)
+		return newNomsBlockStore(ctx, nbfVerStr, mm, &singleBlobBSPersister{bs, q, s3BlockSize}, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	}
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
 }
 
 // NewGCSStore returns an nbs implementation backed by a GCSBlobstore
 func NewGCSStore(ctx context.Context, nbfVerStr string, bucketName, path string, gcs *storage.Client, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
 	bs := blobstore.NewGCSBlobstore(gcs, bucketName, path)
-	return NewBSStore(ctx, nbfVerStr, bs, memTableSize, q)
+	return NewBSStore(ctx, nbfVerStr, bs, memTableSize, q, nil)
 }
 
 // NewGCSStore returns an nbs implementation backed by a GCSBlobstore
@@ -703,22 +710,24 @@ func NewOCISStore(ctx context.Context, nbfVerStr string, bucketName, path string
 		return nil, err
 	}
 
-	return NewNoConjoinBSStore(ctx, nbfVerStr, bs, memTableSize, q)
+	return NewNoConjoinBSStore(ctx, nbfVerStr, bs, memTableSize, q, nil)
 }
 
-// NewGitStore returns an nbs implementation backed by a GitBlobstore.
-func NewGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+// NewGitStore returns an nbs implementation backed by a GitBlobstore. If |keys| is not nil, the blobs it stores are
+// encrypted with them.
+func NewGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider, keys KeyProvider) (*NomsBlockStore, error) {
 	// A Git remote may reject large blobs. To keep git-backed remotes broadly usable by default, enable
 	// chunked-object writes with a conservative max part size unless the caller explicitly overrides it.
 	if opts.MaxPartSize == 0 {
 		opts.MaxPartSize = defaultGitBlobstoreMaxPartSize
 	}
 
-	bs, err := blobstore.NewGitBlobstoreWithOptions(gitDir, ref, opts)
+	gbs, err := blobstore.NewGitBlobstoreWithOptions(gitDir, ref, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	bs := newEncryptedBlobstore(gbs, keys)
 	mm := manifest(blobstoreManifest{bs})
 	p := &singleBlobBSPersister{bs, q, s3BlockSize}
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
@@ -726,34 +735,42 @@ func NewGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref strin
 
 // NewNoConjoinGitStore returns an nbs implementation backed by a GitBlobstore, but disables conjoin.
 // This can be useful for deployments where conjoin's table rewrite cost is undesirable.
-func NewNoConjoinGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+func NewNoConjoinGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider, keys KeyProvider) (*NomsBlockStore, error) {
 	if opts.MaxPartSize == 0 {
 		opts.MaxPartSize = defaultGitBlobstoreMaxPartSize
 	}
 
-	bs, err := blobstore.NewGitBlobstoreWithOptions(gitDir, ref, opts)
+	gbs, err := blobstore.NewGitBlobstoreWithOptions(gitDir, ref, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	bs := newEncryptedBlobstore(gbs, keys)
 	mm := manifest(blobstoreManifest{bs})
 	p := &noConjoinBlobstorePersister{bs, q, s3BlockSize}
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, noopConjoiner{}, memTableSize)
 }
 
-// NewBSStore returns an nbs implementation backed by a Blobstore
-func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
-	mm := manifest(blobstoreManifest{bs})
+// NewBSStore returns an nbs implementation backed by a Blobstore. If |keys| is not nil, the blobs it stores are
+// encrypted with them.
+func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider, keys KeyProvider) (*NomsBlockStore, error) {
+	ebs := newEncryptedBlobstore(bs, keys)
+	mm := manifest(blobstoreManifest{ebs})
 
-	p := &blobstorePersister{bs, q, s3BlockSize}
+	var p tablePersister = &blobstorePersister{ebs, q, s3BlockSize}
+	if keys != nil {
+		// encrypted blobs cannot be concatenated in place, see encryptedBlobstore
+		p = &singleBlobBSPersister{ebs, q, s3BlockSize}
+	}
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
 }
 
 // NewNoConjoinBSStore returns a nbs implementation backed by a Blobstore
-func NewNoConjoinBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
-	mm := manifest(blobstoreManifest{bs})
+func NewNoConjoinBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider, keys KeyProvider) (*NomsBlockStore, error) {
+	ebs := newEncryptedBlobstore(bs, keys)
+	mm := manifest(blobstoreManifest{ebs})
 
-	p := &noConjoinBlobstorePersister{bs, q, s3BlockSize}
+	p := &noConjoinBlobstorePersister{ebs, q, s3BlockSize}
 	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, noopConjoiner{}, memTableSize)
 }
 
@@ -797,6 +814,10 @@ type JournalingStoreOptions struct {
 	// already been loaded in ExclusiveAccessMode_ReadOnly and there is no real reason to wait
 	// around trying to get Exclusive mode if you fail on the first non-blocking flock call.
 	SkipLockFileTimeout bool
+
+	// LockTimeout, if non-zero, replaces Dolt's internal lock timeout. Processes which share a store and expect to
+	// write to it, such as the CLIs of several worktrees of one repository, wait longer for each other's lock.
+	LockTimeout time.Duration
 }
 
 func NewLocalJournalingStoreWithOptions(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider, mmapArchiveIndexes bool, warningsCb func(error), opts JournalingStoreOptions) (*NomsBlockStore, error) {
@@ -805,6 +826,9 @@ func NewLocalJournalingStoreWithOptions(ctx context.Context, nbfVers, dir string
 	}
 
 	timeout := lockFileTimeout
+	if opts.LockTimeout > 0 {
+		timeout = opts.LockTimeout
+	}
 	if opts.SkipLockFileTimeout {
 		timeout = 0
 	}
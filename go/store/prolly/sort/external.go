@@ -29,28 +29,28 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
-// tupleSorter inputs a series of unsorted tuples and outputs a sorted list
+// TupleSorter inputs a series of unsorted tuples and outputs a sorted list
 // of tuples. Batches of tuples sorted in memory are written to disk, and
 // then k-way merge sorted to produce a final sorted list. The |fileMax|
 // parameter limits the number of files spilled to disk at any given time.
 // The maximum memory used will be |fileMax| * |batchSize|.
-type tupleSorter struct {
+type TupleSorter struct {
 	tmpProv   tempfiles.TempFileProvider
 	keyCmp    func(val.Tuple, val.Tuple) bool
 	inProg    *keyMem
-	files     [][]keyIterable
+	files     [][]KeyIterable
 	fileMax   int
 	fileCnt   int
 	batchSize int
 }
 
-func NewTupleSorter(batchSize, fileMax int, keyCmp func(val.Tuple, val.Tuple) bool, tmpProv tempfiles.TempFileProvider) *tupleSorter {
+func NewTupleSorter(batchSize, fileMax int, keyCmp func(val.Tuple, val.Tuple) bool, tmpProv tempfiles.TempFileProvider) *TupleSorter {
 	if fileMax%2 == 1 {
 		// round down to even
 		// fileMax/2 will be compact parallelism
 		fileMax -= 1
 	}
-	ret := &tupleSorter{
+	ret := &TupleSorter{
 		fileMax:   fileMax,
 		batchSize: batchSize,
 		keyCmp:    keyCmp,
@@ -60,7 +60,7 @@ func NewTupleSorter(batchSize, fileMax int, keyCmp func(val.Tuple, val.Tuple) bo
 	return ret
 }
 
-func (a *tupleSorter) Flush(ctx context.Context) (iter keyIterable, err error) {
+func (a *TupleSorter) Flush(ctx context.Context) (iter KeyIterable, err error) {
 	// don't flush in-progress, just sort in memory
 	a.inProg.sort(a.keyCmp)
 
@@ -69,7 +69,7 @@ func (a *tupleSorter) Flush(ctx context.Context) (iter keyIterable, err error) {
 		return a.inProg, nil
 	}
 
-	var iterables []keyIterable
+	var iterables []KeyIterable
 	iterables = append(iterables, a.inProg)
 	for _, level := range a.files {
 		for _, file := range level {
@@ -99,7 +99,7 @@ func (a *tupleSorter) Flush(ctx context.Context) (iter keyIterable, err error) {
 	return allKeys, nil
 }
 
-func (a *tupleSorter) Insert(ctx context.Context, k val.Tuple) (err error) {
+func (a *TupleSorter) Insert(ctx context.Context, k val.Tuple) (err error) {
 	if !a.inProg.insert(k) {
 		if err := a.flushMem(ctx); err != nil {
 			return err
@@ -108,7 +108,7 @@ func (a *tupleSorter) Insert(ctx context.Context, k val.Tuple) (err error) {
 	}
 	return
 }
-func (a *tupleSorter) Close() {
+func (a *TupleSorter) Close() {
 	for _, level := range a.files {
 		for _, f := range level {
 			f.Close()
@@ -116,7 +116,7 @@ func (a *tupleSorter) Close() {
 	}
 }
 
-func (a *tupleSorter) flushMem(ctx context.Context) error {
+func (a *TupleSorter) flushMem(ctx context.Context) error {
 	// flush and replace |inProg|
 	if a.inProg.Len() > 0 {
 		newF, err := a.newFile()
@@ -129,7 +129,7 @@ func (a *tupleSorter) flushMem(ctx context.Context) error {
 		}
 		a.inProg = newKeyMem(a.batchSize)
 		if len(a.files) == 0 {
-			a.files = append(a.files, []keyIterable{newFile})
+			a.files = append(a.files, []KeyIterable{newFile})
 		} else {
 			a.files[0] = append(a.files[0], newFile)
 		}
@@ -143,7 +143,7 @@ func (a *tupleSorter) flushMem(ctx context.Context) error {
 	return nil
 }
 
-func (a *tupleSorter) newFile() (*os.File, error) {
+func (a *TupleSorter) newFile() (*os.File, error) {
 	f, err := a.tmpProv.NewFile("", "key_file_")
 	if err != nil {
 		return nil, err
@@ -151,7 +151,7 @@ func (a *tupleSorter) newFile() (*os.File, error) {
 	return f, nil
 }
 
-func (a *tupleSorter) shouldCompact() (int, bool) {
+func (a *TupleSorter) shouldCompact() (int, bool) {
 	for i, level := range a.files {
 		if len(level) >= a.fileMax {
 			return i, true
@@ -161,7 +161,7 @@ func (a *tupleSorter) shouldCompact() (int, bool) {
 }
 
 // compact merges the first `a.fileMax` files in `a.files[level]` into a single sorted file which is added to `a.files[level+1]`
-func (a *tupleSorter) compact(ctx context.Context, level int) error {
+func (a *TupleSorter) compact(ctx context.Context, level int) error {
 	newF, err := a.newFile()
 	if err != nil {
 		return err
@@ -187,7 +187,7 @@ func (a *tupleSorter) compact(ctx context.Context, level int) error {
 	// add to next level
 	a.files[level] = a.files[level][a.fileMax:]
 	if len(a.files) <= level+1 {
-		a.files = append(a.files, []keyIterable{outF})
+		a.files = append(a.files, []KeyIterable{outF})
 	} else {
 		a.files[level+1] = append(a.files[level+1], outF)
 	}
@@ -351,7 +351,7 @@ func (r *keyFileReader) Close() {
 	}
 }
 
-type keyIterable interface {
+type KeyIterable interface {
 	IterAll(context.Context) (KeyIter, error)
 	Close()
 }
@@ -424,7 +424,7 @@ type fileMerger struct {
 	out *keyFile
 }
 
-func newFileMerger(ctx context.Context, keyCmp func(val.Tuple, val.Tuple) bool, target *keyFile, files ...keyIterable) (m *fileMerger, err error) {
+func newFileMerger(ctx context.Context, keyCmp func(val.Tuple, val.Tuple) bool, target *keyFile, files ...KeyIterable) (m *fileMerger, err error) {
 	var fileHeads []*mergeFileReader
 	defer func() {
 		if err != nil {
@@ -184,8 +184,8 @@ func TestMerge(t *testing.T) {
 				return cmp <= 0
 			}
 
-			var keyMems []keyIterable
-			var keyFiles []keyIterable
+			var keyMems []KeyIterable
+			var keyFiles []KeyIterable
 			expSize := 0
 			expCnt := 0
 			for _, cnt := range tt.counts {
@@ -292,7 +292,7 @@ func TestCompact(t *testing.T) {
 				return cmp <= 0
 			}
 
-			var keyFiles []keyIterable
+			var keyFiles []KeyIterable
 			expSize := 0
 			expCnt := 0
 			for i := 0; i < tt.fileCnt; i++ {
@@ -502,7 +502,7 @@ func mustNewFile(t *testing.T, prov tempfiles.TempFileProvider) *os.File {
 	return f
 }
 
-func drainIterCntSize(t *testing.T, ki keyIterable) (cnt int, size int) {
+func drainIterCntSize(t *testing.T, ki KeyIterable) (cnt int, size int) {
 	ctx := sql.NewEmptyContext()
 	iter, err := ki.IterAll(ctx)
 	require.NoError(t, err)
@@ -379,7 +379,7 @@ func parseAWSSpec(ctx context.Context, awsURL string, options SpecOptions) chunk
 
 	// DisableLogOutputChecksumValidationSkipped silences the per-GetObject WARN emitted when objects have no stored checksum.
 	s3c := s3.NewFromConfig(cfg, func(o *s3.Options) { o.DisableLogOutputChecksumValidationSkipped = true })
-	cs, err := nbs.NewAWSStore(ctx, types.Format_DOLT.VersionString(), parts[0], u.Path, parts[1], s3c, dynamodb.NewFromConfig(cfg), 1<<28, nbs.NewUnlimitedMemQuotaProvider())
+	cs, err := nbs.NewAWSStore(ctx, types.Format_DOLT.VersionString(), parts[0], u.Path, parts[1], s3c, dynamodb.NewFromConfig(cfg), 1<<28, nbs.NewUnlimitedMemQuotaProvider(), nil)
 	d.PanicIfError(err)
 
 	return cs
@@ -661,6 +661,24 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 				return err
 			}
 		}
+		bisectState, err := msg.TryBisectState(nil)
+		if err != nil {
+			return err
+		}
+		if bisectState != nil {
+			if bisectState.BadCommitAddrLength() != 0 {
+				if err = cb(hash.New(bisectState.BadCommitAddrBytes())); err != nil {
+					return err
+				}
+			}
+			for _, addrs := range [][]byte{bisectState.GoodCommitAddrsBytes(), bisectState.SkippedCommitAddrsBytes()} {
+				for ; len(addrs) >= hash.ByteLen; addrs = addrs[hash.ByteLen:] {
+					if err = cb(hash.New(addrs[:hash.ByteLen])); err != nil {
+						return err
+					}
+				}
+			}
+		}
 	case serial.RootValueFileID:
 		var msg serial.RootValue
 		err := serial.InitRootValueRoot(&msg, sm, serial.MessagePrefixSz)
@@ -0,0 +1,97 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    for i in 1 2 3 -4 5 6; do
+        dolt sql -q "insert into t values ($i);"
+        dolt commit -am "insert $i"
+    done
+    dolt tag v1 HEAD~5
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+# get_commit_hash returns the hash of the commit with the given message
+get_commit_hash() {
+    dolt sql -q "select commit_hash from dolt_log where message = '$1'" -r csv | tail -n 1
+}
+
+@test "bisect: mark commits by hand" {
+    run dolt bisect start HEAD v1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: 3 revisions left to test" ]] || false
+    [[ "$output" =~ "Next commit to test: $(get_commit_hash 'insert -4')" ]] || false
+
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Next commit to test: $(get_commit_hash 'insert 3')" ]] || false
+
+    run dolt bisect good
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(get_commit_hash 'insert -4') is the first bad commit" ]] || false
+
+    # HEAD and the working set are not changed
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "insert 6" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    run dolt bisect reset
+    [ "$status" -eq 0 ]
+    run dolt bisect status
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
+
+@test "bisect: state is kept in the working set between sessions" {
+    dolt bisect start HEAD v1
+    dolt sql -q "call dolt_bisect('bad')"
+
+    run dolt sql -q "call dolt_bisect('status')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "bisecting,$(get_commit_hash 'insert 3'),1," ]] || false
+}
+
+@test "bisect: run with a query" {
+    dolt bisect start HEAD v1
+
+    run dolt bisect run --query "select min(pk) > 0 from t"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(get_commit_hash 'insert -4'): bad (query returned false)" ]] || false
+    [[ "$output" =~ "$(get_commit_hash 'insert 3'): good (query returned true)" ]] || false
+    [[ "$output" =~ "$(get_commit_hash 'insert -4') is the first bad commit" ]] || false
+}
+
+@test "bisect: run with a dolt_tests group" {
+    dolt sql -q "insert into dolt_tests values ('no negative keys', 'sanity', 'select * from t where pk < 0', 'expected_rows', '==', '0')"
+    dolt bisect start HEAD v1
+
+    run dolt bisect run --test sanity
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$(get_commit_hash 'insert -4') is the first bad commit" ]] || false
+}
+
+@test "bisect: commits that fail the query are skipped" {
+    dolt sql -q "alter table t add column c int default 0;"
+    dolt commit -am "add column c"
+    dolt bisect start HEAD v1
+
+    # c does not exist before the last commit, so the query cannot be run there
+    run dolt bisect run --query "select sum(c) = 0 from t"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "skip (query error" ]] || false
+    [[ "$output" =~ "only skipped commits left to test" ]] || false
+}
+
+@test "bisect: good commits must come before the bad commit" {
+    run dolt bisect start v1 HEAD
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "the bad commit is an ancestor of a good commit" ]] || false
+}
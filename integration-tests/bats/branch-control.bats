@@ -377,3 +377,179 @@ SQL
   [ $status -eq 0 ]
   [[ ! $output =~ "cannot create" ]] || false
 }
+
+@test "branch-control: branch protection table exists and persists" {
+    run dolt sql -q "describe dolt_branch_protection"
+    [ $status -eq 0 ]
+    [[ $output =~ "required_test_groups" ]] || false
+    [[ $output =~ "deny_force_push" ]] || false
+    [[ $output =~ "deny_hard_reset" ]] || false
+    [[ $output =~ "require_merge" ]] || false
+    [[ $output =~ "require_signed_commits" ]] || false
+
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_hard_reset) values ('%', 'MAIN', true)"
+    run dolt sql -r csv -q "select * from dolt_branch_protection"
+    [ $status -eq 0 ]
+    [ ${lines[0]} = "database,branch,required_test_groups,deny_force_push,deny_hard_reset,require_merge,require_signed_commits" ]
+    [ ${lines[1]} = "%,main,,0,1,0,0" ]
+
+    run dolt sql -q "insert into dolt_branch_protection (\`database\`, branch) values ('%', 'main')"
+    [ $status -ne 0 ]
+    [[ $output =~ "duplicate primary key" ]] || false
+
+    start_sql_server
+    run dolt sql -r csv -q "select * from dolt_branch_protection"
+    [ $status -eq 0 ]
+    [ ${lines[1]} = "%,main,,0,1,0,0" ]
+}
+
+@test "branch-control: branch protection denies hard resets to another commit" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into t values (1)"
+    dolt commit -am "add a row"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_hard_reset) values ('%', 'main', true)"
+
+    run dolt sql -q "call dolt_reset('--hard', 'HEAD~1')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`main\` is protected" ]] || false
+
+    # discarding working changes is still allowed
+    dolt sql -q "insert into t values (2)"
+    dolt sql -q "call dolt_reset('--hard')"
+    run dolt sql -r csv -q "select count(*) from t"
+    [ ${lines[1]} = "1" ]
+
+    # other branches are unaffected
+    dolt branch other
+    dolt sql -q "call dolt_checkout('other'); call dolt_reset('--hard', 'HEAD~1');"
+}
+
+@test "branch-control: branch protection denies forcing a protected branch with dolt_branch" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into t values (1)"
+    dolt commit -am "add a row"
+    dolt branch old HEAD~1
+    dolt branch protected
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_hard_reset) values ('%', 'protected', true)"
+
+    run dolt sql -q "call dolt_branch('-f', 'protected', 'HEAD~1')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`protected\` is protected" ]] || false
+
+    run dolt sql -q "call dolt_branch('-c', '-f', 'old', 'protected')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`protected\` is protected" ]] || false
+
+    run dolt sql -q "call dolt_branch('-m', '-f', 'old', 'protected')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`protected\` is protected" ]] || false
+
+    run dolt sql -r csv -q "select hash = hashof('main') from dolt_branches where name = 'protected'"
+    [ $status -eq 0 ]
+    [ ${lines[1]} = "true" ]
+
+    # unprotected branches can still be forced
+    dolt sql -q "call dolt_branch('-f', 'old', 'main')"
+}
+
+@test "branch-control: branch protection requires merges" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, require_merge) values ('%', 'main', true)"
+
+    dolt sql -q "insert into t values (1)"
+    run dolt sql -q "call dolt_commit('-am', 'direct commit')"
+    [ $status -ne 0 ]
+    [[ $output =~ "commits must be merges" ]] || false
+    dolt sql -q "call dolt_reset('--hard')"
+
+    dolt sql <<SQL
+call dolt_checkout('-b', 'feature');
+insert into t values (1);
+call dolt_commit('-am', 'feature commit');
+call dolt_checkout('main');
+call dolt_merge('--no-ff', 'feature', '-m', 'merge feature');
+SQL
+    run dolt sql -r csv -q "select count(*) from t"
+    [ ${lines[1]} = "1" ]
+}
+
+@test "branch-control: branch protection runs required test groups" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt sql -q "insert into dolt_tests values ('one row', 'smoke', 'select * from t', 'expected_rows', '==', '1')"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, required_test_groups) values ('%', 'main', 'smoke')"
+
+    dolt sql -q "insert into t values (1), (2)"
+    run dolt sql -q "call dolt_commit('--skip-verification', '-am', 'two rows')"
+    [ $status -ne 0 ]
+    [[ $output =~ "commit verification failed" ]] || false
+    dolt sql -q "call dolt_reset('--hard')"
+
+    # fast-forward merges make a merge commit so that the tests are run
+    dolt sql <<SQL
+call dolt_checkout('-b', 'feature');
+insert into t values (1), (2);
+call dolt_commit('-am', 'two rows');
+SQL
+    run dolt sql -q "call dolt_merge('feature')"
+    [[ $output =~ "commit verification failed" ]] || false
+    dolt sql -q "call dolt_reset('--hard')"
+
+    run dolt sql -q "call dolt_merge('--ff-only', 'feature')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`main\` is protected" ]] || false
+
+    dolt sql <<SQL
+call dolt_checkout('feature');
+delete from t where pk = 2;
+call dolt_commit('-am', 'one row');
+call dolt_checkout('main');
+call dolt_merge('feature');
+SQL
+    run dolt sql -r csv -q "select count(*) from dolt_log where commit_hash = hashof('main') and message like 'Merge branch%'"
+    [ ${lines[1]} = "1" ]
+}
+
+@test "branch-control: branch protection requires signed commits" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, require_signed_commits) values ('%', 'main', true)"
+
+    dolt sql -q "insert into t values (1)"
+    run dolt sql -q "call dolt_commit('-am', 'unsigned')"
+    [ $status -ne 0 ]
+    [[ $output =~ "commits must be signed" ]] || false
+}
+
+@test "branch-control: branch protection rejects force pushes to protected branches" {
+    mkdir remote
+    dolt remote add origin file://remote/
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt push origin main
+    dolt sql -q "insert into t values (1)"
+    dolt commit -am "add a row"
+    dolt push origin main
+    dolt reset --hard HEAD~1
+    dolt sql -q "insert into t values (2)"
+    dolt commit -am "diverge"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_force_push) values ('%', 'main', true)"
+
+    run dolt sql -q "call dolt_push('--force', 'origin', 'main')"
+    [ $status -ne 0 ]
+    [[ $output =~ "force pushes are not allowed" ]] || false
+
+    # rules are matched against the local database
+    dolt sql -q "delete from dolt_branch_protection"
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_force_push) values ('dolt-repo-$$', 'main', true)"
+    run dolt sql -q "call dolt_push('--force', 'origin', 'main')"
+    [ $status -ne 0 ]
+    [[ $output =~ "force pushes are not allowed" ]] || false
+
+    dolt branch other
+    dolt sql -q "call dolt_push('origin', 'other')"
+    dolt sql -q "call dolt_push('--force', 'origin', 'other')"
+}
@@ -0,0 +1,118 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    TMPDIRS=$(pwd)/tmpdirs
+    mkdir -p $TMPDIRS/{src,media}
+
+    cd $TMPDIRS/src
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c int)"
+    dolt sql -q "insert into t values (1, 1)"
+    dolt add .
+    dolt commit -m "first"
+    dolt tag v1
+    cd $TMPDIRS
+}
+
+teardown() {
+    teardown_common
+    rm -rf $TMPDIRS
+    cd $BATS_TMPDIR
+}
+
+@test "bundle: clone from a full bundle and fetch from an incremental one" {
+    cd src
+    dolt bundle create ../media/full.bundle main v1
+    run dolt bundle list-heads ../media/full.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/tags/v1" ]] || false
+
+    cd ..
+    dolt clone bundle://media/full.bundle dest
+    cd dest
+    run dolt sql -q "select * from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+
+    cd ../src
+    dolt sql -q "insert into t values (2, 2)"
+    dolt commit -am "second"
+    dolt bundle create --base v1 ../media/incremental.bundle main
+
+    cd ../dest
+    run dolt bundle verify ../media/incremental.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "The bundle requires 1 commits" ]] || false
+    [[ "$output" =~ "is okay" ]] || false
+
+    dolt remote add usb bundle://../media/incremental.bundle
+    dolt pull usb main
+    run dolt sql -q "select * from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,2" ]] || false
+    run dolt log --oneline
+    [[ "$output" =~ "second" ]] || false
+}
+
+@test "bundle: unbundle adds commits without changing branches" {
+    cd src
+    dolt bundle create ../media/full.bundle
+
+    cd ..
+    mkdir dest && cd dest
+    dolt init
+    run dolt bundle unbundle ../media/full.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    hash=$(echo "$output" | grep "refs/heads/main" | cut -d ' ' -f 1)
+
+    run dolt log --oneline
+    [[ ! "$output" =~ "first" ]] || false
+
+    dolt branch bundled "$hash"
+    run dolt log --oneline bundled
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first" ]] || false
+}
+
+@test "bundle: incremental bundles require their prerequisites" {
+    cd src
+    dolt sql -q "insert into t values (2, 2)"
+    dolt commit -am "second"
+    dolt bundle create --base v1 ../media/incremental.bundle main
+
+    cd ..
+    mkdir dest && cd dest
+    dolt init
+    run dolt bundle verify ../media/incremental.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "which this database lacks" ]] || false
+
+    run dolt bundle unbundle ../media/incremental.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "lacks the prerequisite commits" ]] || false
+}
+
+@test "bundle: errors" {
+    cd src
+    run dolt bundle create --base main ../media/empty.bundle main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "refusing to create an empty bundle" ]] || false
+    [ ! -f ../media/empty.bundle ]
+
+    run dolt bundle create ../media/missing.bundle nosuchbranch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'nosuchbranch' is not a branch or tag" ]] || false
+
+    echo "hello" > ../media/not.bundle
+    run dolt bundle list-heads ../media/not.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a dolt bundle" ]] || false
+
+    run dolt remote add usb bundle://../media/nosuch.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not exist" ]] || false
+}
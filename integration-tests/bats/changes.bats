@@ -0,0 +1,61 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c1 varchar(20));"
+    dolt add -A && dolt commit -m "commit A"
+    dolt branch start
+
+    dolt sql -q "INSERT INTO test VALUES (1, 'one'), (2, 'two');"
+    dolt commit -am "commit B"
+
+    dolt sql -q "UPDATE test SET c1 = 'uno' WHERE pk = 1;"
+    dolt sql -q "CREATE TABLE other (pk int primary key);"
+    dolt sql -q "INSERT INTO other VALUES (10);"
+    dolt add -A && dolt commit -m "commit C"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "changes: lists row changes for every commit in the range" {
+    run dolt changes -r csv start main
+    [ "$status" -eq 0 ]
+    # header plus one line per changed row
+    [ "${#lines[@]}" -eq 5 ]
+    [[ "${lines[0]}" =~ "commit_hash,committer,email,date,table_name,diff_type,from_row,to_row" ]] || false
+    [[ "${lines[1]}" =~ "test,added" ]] || false
+    [[ "${lines[2]}" =~ "test,added" ]] || false
+    [[ "${lines[3]}" =~ "other,added" ]] || false
+    [[ "${lines[4]}" =~ "test,modified" ]] || false
+    [[ "${lines[4]}" =~ "uno" ]] || false
+}
+
+@test "changes: accepts a two dot range and a table name" {
+    run dolt changes -r csv start..main other
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[1]}" =~ "other,added" ]] || false
+
+    run dolt changes -r csv main~1 main test
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[1]}" =~ "test,modified" ]] || false
+}
+
+@test "changes: invalid arguments" {
+    run dolt changes main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "expected a commit range" ]] || false
+
+    run dolt changes start..main other extra
+    [ "$status" -ne 0 ]
+
+    run dolt changes start missing-branch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch not found" ]] || false
+}
@@ -242,6 +242,32 @@ SQL
     [[ "$output" =~ "| > | modify2 | CREATE PROCEDURE modify2() SELECT 43 |" ]] || false
 }
 
+@test "diff: word diff mode" {
+    dolt sql <<SQL
+CREATE TABLE docs (pk int PRIMARY KEY, body longtext, meta json);
+INSERT INTO docs VALUES (1, 'The committee will meet on Monday to review the draft.', '{"status": "draft"}');
+INSERT INTO docs VALUES (2, 'Unchanged.', '{}');
+SQL
+    dolt add -A
+    dolt commit -m "add docs"
+
+    dolt sql -q "UPDATE docs SET body = 'The committee will meet on Tuesday to review the draft.', meta = '{\"status\": \"final draft\"}' WHERE pk = 1"
+
+    run dolt diff --diff-mode=word
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| * | 1  | The committee will meet on [-Monday-]{+Tuesday+} to review the draft." ]] || false
+    [[ "$output" =~ '{+final +}draft' ]] || false
+    [[ ! "$output" =~ "Unchanged" ]] || false
+
+    run dolt diff --word-diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| * | 1  | The committee will meet on [-Monday-]{+Tuesday+} to review the draft." ]] || false
+
+    run dolt sql -q "SELECT c.from_position, c.from_text, c.to_text FROM dolt_diff_docs d JOIN LATERAL (SELECT * FROM dolt_cell_diff(d.from_body, d.to_body)) c WHERE d.to_commit = 'WORKING'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "28,Monday,Tuesday" ]] || false
+}
+
 @test "diff: reverse diff" {
     # We're not using the test table, so we might as well delete it
     dolt sql <<SQL
@@ -2528,4 +2554,46 @@ EOF
     [ $status -eq 1 ]
     [[ "$output" =~ "Incompatible schema change, skipping data diff for table 't'" ]] || false
 
-}
\ No newline at end of file
+}
+@test "diff: --find-renames detects copied tables and re-added columns" {
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, c1 int, c2 varchar(20));
+INSERT INTO t VALUES (1, 1, 'one'), (2, 2, 'two'), (3, 3, 'three');
+CREATE TABLE u (pk int PRIMARY KEY, a int, b int);
+INSERT INTO u VALUES (1, 10, 100), (2, 20, 200), (3, 30, 300);
+SQL
+    dolt add .
+    dolt commit -m "initial"
+
+    dolt sql <<SQL
+CREATE TABLE t_copy (pk int PRIMARY KEY, c1 int, c2 varchar(20));
+INSERT INTO t_copy SELECT * FROM t;
+DROP TABLE t;
+ALTER TABLE u ADD COLUMN renamed_a int;
+UPDATE u SET renamed_a = a;
+ALTER TABLE u DROP COLUMN a;
+SQL
+
+    run dolt diff --summary
+    [ $status -eq 0 ]
+    [[ "$output" =~ "| t          | dropped" ]] || false
+    [[ "$output" =~ "| t_copy     | added" ]] || false
+
+    run dolt diff -M --summary
+    [ $status -eq 0 ]
+    [[ "$output" =~ "t -> t_copy" ]] || false
+    [[ "$output" =~ "renamed" ]] || false
+    [[ ! "$output" =~ "added" ]] || false
+    [[ ! "$output" =~ "dropped" ]] || false
+
+    run dolt diff --find-renames --schema -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ 'RENAME TABLE `t` TO `t_copy`;' ]] || false
+    [[ "$output" =~ 'ALTER TABLE `u` RENAME COLUMN `a` TO `renamed_a`;' ]] || false
+    [[ ! "$output" =~ 'DROP TABLE' ]] || false
+    [[ ! "$output" =~ 'DROP COLUMN' ]] || false
+
+    run dolt sql -q "SET @@dolt_find_renames = 50; SELECT from_table_name, to_table_name, diff_type FROM dolt_diff_summary('HEAD', 'WORKING') ORDER BY 1" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "t,t_copy,renamed" ]] || false
+}
@@ -0,0 +1,238 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+KEY1=1111111111111111111111111111111111111111111111111111111111111111
+KEY2=2222222222222222222222222222222222222222222222222222222222222222
+
+setup() {
+    setup_no_dolt_init
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "encryption: table data is not stored in plaintext" {
+    export DOLT_ENCRYPTION_KEY=$KEY1
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c0 varchar(100));"
+    dolt sql -q "insert into t values (1, 'averysecretvalue');"
+    dolt commit -Am "add secret"
+
+    run grep -rl averysecretvalue .dolt/noms
+    [ "$status" -ne 0 ]
+
+    dolt gc
+    run grep -rl averysecretvalue .dolt/noms
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "select c0 from t where pk = 1;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "averysecretvalue" ]] || false
+}
+
+@test "encryption: encrypted databases cannot be read without the key" {
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt init
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "create table t (pk int primary key);"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt commit -Am "add t"
+
+    run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key is configured" ]] || false
+    [[ "$output" =~ "DOLT_ENCRYPTION_KEY" ]] || false
+
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encryption key not found" ]] || false
+
+    DOLT_ENCRYPTION_KEY=not-a-key run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid DOLT_ENCRYPTION_KEY" ]] || false
+}
+
+@test "encryption: keys are rotated by a full gc" {
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt init
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "create table t (pk int primary key, c0 int);"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "insert into t values (1, 1), (2, 2);"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt commit -Am "add t"
+
+    # new writes use the new key while files written with the old key stay readable
+    export DOLT_ENCRYPTION_KEY=$KEY2
+    export DOLT_ENCRYPTION_OLD_KEYS=$KEY1
+    dolt sql -q "insert into t values (3, 3);"
+    dolt commit -Am "add row"
+    dolt gc --full
+
+    unset DOLT_ENCRYPTION_OLD_KEYS
+    run dolt sql -q "select count(*) from t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    DOLT_ENCRYPTION_KEY=$KEY1 run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encryption key not found" ]] || false
+}
+
+@test "encryption: keys can be read from a keyfile" {
+    keyfile="$BATS_TMPDIR/dolt-keyfile-$$"
+    printf '# current key\n%s\n%s\n' $KEY2 $KEY1 > "$keyfile"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt init
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt sql -q "create table t (pk int primary key);"
+    DOLT_ENCRYPTION_KEY=$KEY1 dolt commit -Am "add t"
+
+    # the keyfile takes precedence over DOLT_ENCRYPTION_KEY
+    export DOLT_ENCRYPTION_KEY_FILE="$keyfile"
+    DOLT_ENCRYPTION_KEY=not-a-key dolt sql -q "insert into t values (1);"
+    dolt commit -Am "add row"
+    run dolt sql -q "select count(*) from t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    rm "$keyfile"
+}
+
+@test "encryption: existing plaintext databases are encrypted by a full gc" {
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c0 varchar(100));"
+    dolt sql -q "insert into t values (1, 'averysecretvalue');"
+    dolt commit -Am "add secret"
+    run grep -rl averysecretvalue .dolt/noms
+    [ "$status" -eq 0 ]
+
+    export DOLT_ENCRYPTION_KEY=$KEY1
+    run dolt sql -q "select c0 from t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "averysecretvalue" ]] || false
+
+    dolt gc --full
+    run grep -rl averysecretvalue .dolt/noms
+    [ "$status" -ne 0 ]
+
+    unset DOLT_ENCRYPTION_KEY
+    run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+}
+
+@test "encryption: DOLT_ENCRYPTION_REQUIRED refuses plaintext files" {
+    dolt init
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "add t"
+
+    export DOLT_ENCRYPTION_REQUIRED=1
+    run dolt sql -q "select * from t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encryption is required" ]] || false
+
+    # a full gc without the requirement encrypts the database, after which it can be required
+    unset DOLT_ENCRYPTION_REQUIRED
+    export DOLT_ENCRYPTION_KEY=$KEY1
+    dolt gc --full
+
+    export DOLT_ENCRYPTION_REQUIRED=1
+    dolt sql -q "insert into t values (1);"
+    dolt commit -Am "add row"
+    run dolt sql -q "select count(*) from t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    mkdir plain && cd plain
+    unset DOLT_ENCRYPTION_KEY
+    run dolt init
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encryption is required" ]] || false
+}
+
+@test "encryption: blobstore remotes hold only ciphertext" {
+    keyfile="$BATS_TMPDIR/dolt-remote-keyfile-$$"
+    echo $KEY2 > "$keyfile"
+    mkdir repo1 remotedir
+    remote="localbs://$(pwd)/remotedir"
+    cd repo1
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c0 varchar(100));"
+    dolt sql -q "insert into t values (1, 'averysecretvalue');"
+    dolt commit -Am "add secret"
+    dolt remote add --encryption-key-file "$keyfile" origin "$remote"
+    dolt push origin main
+    cd ..
+
+    run grep -rl averysecretvalue remotedir
+    [ "$status" -ne 0 ]
+
+    dolt clone --encryption-key-file "$keyfile" "$remote" repo2
+    cd repo2
+    run dolt sql -q "select c0 from t where pk = 1;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "averysecretvalue" ]] || false
+
+    dolt sql -q "insert into t values (2, 'anothersecretvalue');"
+    dolt commit -Am "add another secret"
+    dolt push origin main
+    cd ..
+    run grep -rl anothersecretvalue remotedir
+    [ "$status" -ne 0 ]
+
+    cd repo1
+    dolt pull origin main
+    run dolt sql -q "select count(*) from t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    cd ..
+
+    # the keys of local databases are not used for remotes
+    DOLT_ENCRYPTION_KEY=$KEY2 run dolt clone "$remote" repo3
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key is configured" ]] || false
+
+    run dolt remote add --encryption-key-file "$keyfile" other "file://$(pwd)/remotedir"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "only valid for remotes stored in a blobstore" ]] || false
+    rm "$keyfile"
+}
+
+@test "encryption: blobstore remotes are not encrypted with the local key" {
+    export DOLT_ENCRYPTION_KEY=$KEY1
+    mkdir repo1 remotedir
+    remote="localbs://$(pwd)/remotedir"
+    cd repo1
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c0 varchar(100));"
+    dolt sql -q "insert into t values (1, 'averysecretvalue');"
+    dolt commit -Am "add secret"
+    dolt remote add origin "$remote"
+    dolt push origin main
+    cd ..
+
+    run grep -rl averysecretvalue remotedir
+    [ "$status" -eq 0 ]
+}
+
+@test "encryption: backups hold only ciphertext" {
+    keyfile="$BATS_TMPDIR/dolt-backup-keyfile-$$"
+    echo $KEY2 > "$keyfile"
+    mkdir repo1 bac1
+    backup="localbs://$(pwd)/bac1"
+    cd repo1
+    dolt init
+    dolt sql -q "create table t (pk int primary key, c0 varchar(100));"
+    dolt sql -q "insert into t values (1, 'averysecretvalue');"
+    dolt commit -Am "add secret"
+    dolt backup add --encryption-key-file "$keyfile" bac1 "$backup"
+    dolt backup sync bac1
+    cd ..
+
+    run grep -rl averysecretvalue bac1
+    [ "$status" -ne 0 ]
+
+    run dolt backup restore "$backup" repo2
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no encryption key is configured" ]] || false
+
+    dolt backup restore --encryption-key-file "$keyfile" "$backup" repo2
+    cd repo2
+    run dolt sql -q "select c0 from t where pk = 1;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "averysecretvalue" ]] || false
+    rm "$keyfile"
+}
@@ -588,3 +588,59 @@ SQL
     run dolt sql -q "SELECT * FROM i"
     [ "$output" = "$int_output" ]
 }
+
+@test "export-tables: round trip arrow and avro files with import -c" {
+    dolt sql <<SQL
+CREATE TABLE typed (
+  pk1 INT NOT NULL,
+  pk2 VARCHAR(20) NOT NULL,
+  d DECIMAL(20,6),
+  e ENUM('small','medium','large'),
+  s SET('a','b','c'),
+  j JSON,
+  g POINT,
+  dt DATETIME(6),
+  u BIGINT UNSIGNED,
+  PRIMARY KEY (pk2, pk1)
+);
+INSERT INTO typed VALUES
+  (1, 'one', 12345678901234.123456, 'medium', 'a,c', '{"a": [1, 2]}', POINT(1, 2), '2024-01-02 03:04:05.678901', 18446744073709551615),
+  (2, 'two', -0.000001, NULL, NULL, NULL, NULL, NULL, NULL);
+SQL
+    run dolt sql -q "SHOW CREATE TABLE typed" -r csv
+    expected_schema=$output
+    run dolt sql -q "SELECT pk1, pk2, d, e, s, j, ST_AsText(g), dt, u FROM typed ORDER BY pk1" -r csv
+    expected_rows=$output
+
+    for ext in arrow arrows avro; do
+        run dolt table export typed "typed.$ext"
+        [ "$status" -eq 0 ]
+        [[ "$output" =~ "Successfully exported data." ]] || false
+        [ -f "typed.$ext" ]
+
+        dolt table import -c typed_copy "typed.$ext"
+
+        run dolt sql -q "SHOW CREATE TABLE typed_copy" -r csv
+        [ "$status" -eq 0 ]
+        [ "${output//typed_copy/typed}" = "$expected_schema" ]
+        run dolt sql -q "SELECT pk1, pk2, d, e, s, j, ST_AsText(g), dt, u FROM typed_copy ORDER BY pk1" -r csv
+        [ "$status" -eq 0 ]
+        [ "$output" = "$expected_rows" ]
+
+        dolt table rm typed_copy
+    done
+}
+
+@test "export-tables: export arrow stream and avro to stdout" {
+    dolt sql -q "INSERT INTO test_int VALUES (1, 2, 3, 4, 5, 6)"
+
+    dolt table export test_int --file-type arrows > test_int.arrows
+    dolt table export test_int --file-type avro > test_int.avro
+
+    dolt table import -c arrows_copy test_int.arrows
+    dolt table import -c avro_copy test_int.avro
+    run dolt sql -q "SELECT * FROM arrows_copy UNION ALL SELECT * FROM avro_copy" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,2,3,4,5,6" ]] || false
+    [ "${#lines[@]}" -eq 3 ]
+}
@@ -0,0 +1,74 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+create table t (pk int primary key, c1 varchar(20), j json);
+create table u (pk int primary key, c1 text);
+insert into t values (1, 'secret one', '{"note": "public"}'), (2, 'public', '{"note": "secret"}');
+insert into u values (1, 'nothing here');
+SQL
+    dolt commit -Am "add data"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "grep: search the working set" {
+    dolt sql -q "insert into u values (2, 'SECRET three')"
+
+    run dolt grep secret
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "WORKING:t:{\"pk\": 1}:c1: secret one" ]] || false
+    [[ "${lines[1]}" =~ "WORKING:t:{\"pk\": 2}:j: {\"note\":\"secret\"}" ]] || false
+
+    run dolt grep -i secret -- u
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "WORKING:u:{\"pk\": 2}:c1: SECRET three" ]] || false
+}
+
+@test "grep: search revisions" {
+    dolt sql -q "delete from t where pk = 1"
+    dolt commit -am "remove secret one"
+
+    head=$(dolt sql -q "select hashof('HEAD')" -r csv | tail -n 1)
+    run dolt grep "secret one" HEAD~1 HEAD
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ ! "$output" =~ "$head" ]] || false
+
+    run dolt grep "secret one" HEAD
+    [ "$status" -eq 1 ]
+    [ "$output" = "" ]
+}
+
+@test "grep: search a commit range" {
+    dolt sql -q "update t set c1 = 'redacted' where pk = 1"
+    dolt commit -am "redact"
+    redact=$(dolt sql -q "select hashof('HEAD')" -r csv | tail -n 1)
+
+    run dolt grep secret HEAD~2..HEAD -- t
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "+" ]] || false
+    [[ "${lines[2]}" =~ "-$redact:t:{\"pk\": 1}:c1: secret one" ]] || false
+}
+
+@test "grep: bad arguments" {
+    run dolt grep
+    [ "$status" -ne 0 ]
+
+    run dolt grep "("
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid pattern" ]] || false
+
+    run dolt grep secret main...HEAD
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "three dot ranges" ]] || false
+}
@@ -0,0 +1,152 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table test (pk int primary key, c0 int);"
+    dolt commit -Am "created table test" --date "2021-01-01T00:00:00Z"
+    dolt sql -q "insert into test values (1,1);"
+    dolt commit -am "added row 1" --date "2022-01-01T00:00:00Z"
+    dolt sql -q "insert into test values (2,2);"
+    dolt commit -am "added row 2" --date "2023-01-01T00:00:00Z"
+    dolt sql -q "insert into test values (3,3);"
+    dolt commit -am "added row 3" --date "2024-01-01T00:00:00Z"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "history-prune: keep the last n commits" {
+    run dolt history prune --keep 2 -m "squashed old history"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rewrote 2 commits" ]] || false
+    [[ "$output" =~ "dolt gc" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "added row 3" ]] || false
+    [[ "${lines[1]}" =~ "added row 2" ]] || false
+    [[ "${lines[2]}" =~ "squashed old history" ]] || false
+
+    run dolt sql -q "select * from test order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,1" ]
+    [ "${lines[3]}" = "3,3" ]
+
+    run dolt sql -q "select count(*) from dolt_history_test" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "6" ]
+}
+
+@test "history-prune: prune commits before a date" {
+    dolt history prune --before 2022-06-01
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[2]}" =~ "Pruned history up to commit" ]] || false
+
+    # the root commit keeps the author date of the newest pruned commit
+    run dolt sql -q "select date from dolt_log order by date limit 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2022-01-01" ]] || false
+}
+
+@test "history-prune: requires --keep or --before" {
+    run dolt history prune
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "call dolt_history_prune()"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "one of --keep or --before is required" ]] || false
+
+    run dolt history prune --keep 10
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "nothing to prune" ]] || false
+}
+
+@test "history-prune: tags are moved or deleted" {
+    dolt tag v1 HEAD~2
+    dolt tag v3 HEAD
+
+    run dolt history prune --keep 2
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "tags v1 refer to pruned commits" ]] || false
+
+    # nothing was rewritten
+    run dolt log --oneline
+    [ "${#lines[@]}" -eq 5 ]
+
+    dolt history prune --keep 2 --delete-tags
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v3" ]] || false
+
+    head=$(get_head_commit)
+    run dolt sql -q "select tag_hash = '$head' from dolt_tags where tag_name = 'v3'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "true" ]
+}
+
+@test "history-prune: other branches are left alone" {
+    dolt branch other HEAD~1
+    run dolt history prune --keep 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "warning: branches other still refer to pruned commits" ]] || false
+
+    run dolt log --oneline
+    [ "${#lines[@]}" -eq 2 ]
+    run dolt log --oneline other
+    [ "${#lines[@]}" -eq 4 ]
+
+    dolt checkout other
+    dolt history prune --keep 1
+    run dolt log --oneline
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -q "select name from dolt_branches order by name" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "main" ]
+    [ "${lines[2]}" = "other" ]
+}
+
+@test "history-prune: prune a branch that is not checked out" {
+    dolt branch other
+    dolt sql -q "call dolt_history_prune('--keep', '1', 'other')"
+
+    run dolt log --oneline other
+    [ "${#lines[@]}" -eq 2 ]
+    run dolt log --oneline main
+    [ "${#lines[@]}" -eq 5 ]
+
+    run dolt history prune --keep 1 missing
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch 'missing' not found" ]] || false
+}
+
+@test "history-prune: old head is kept in the reflog until gc" {
+    old_head=$(get_head_commit)
+    old_root=$(dolt log --oneline | tail -n 1 | cut -d ' ' -f 1)
+    dolt history prune --keep 1
+
+    run dolt reflog main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$old_head" ]] || false
+
+    dolt gc
+    run dolt show "$old_root"
+    [ "$status" -ne 0 ]
+}
+
+@test "history-prune: protected branches cannot be pruned" {
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_hard_reset) values ('%', 'main', true)"
+
+    run dolt history prune --keep 1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "history rewrites are not allowed" ]] || false
+}
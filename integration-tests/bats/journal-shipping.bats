@@ -0,0 +1,139 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests use sockets"
+    setup_common
+
+    SHIPPED="$BATS_TMPDIR/shipped-$$"
+    RESTORED="$BATS_TMPDIR/restored-$$"
+    mkdir -p "$RESTORED"
+
+    dolt sql <<SQL
+CREATE TABLE t (pk INT PRIMARY KEY, c VARCHAR(20));
+INSERT INTO t VALUES (1, 'one');
+CALL DOLT_COMMIT('-Am', 'add t');
+SQL
+}
+
+teardown() {
+    stop_sql_server 1
+    rm -rf "$SHIPPED" "$RESTORED"
+    teardown_common
+}
+
+# start_shipping_server starts a sql-server which ships the journal of its databases to $SHIPPED.
+start_shipping_server() {
+    PORT=$( definePORT )
+    cat > config.yml <<EOF
+listener:
+  host: "0.0.0.0"
+  port: ${PORT}
+
+journal_shipping:
+  url: file://${SHIPPED}
+  interval_millis: 100
+EOF
+    dolt sql-server --config ./config.yml --socket "dolt.$PORT.sock" &
+    SERVER_PID=$!
+    wait_for_connection $PORT 8500
+}
+
+# now prints the current time, then waits until the next second so that later changes are made after it.
+now() {
+    sleep 1
+    date -u +%Y-%m-%dT%H:%M:%SZ
+    sleep 1
+}
+
+@test "journal-shipping: restore a database as of a point in time" {
+    start_shipping_server
+
+    t1=$(now)
+    dolt sql -q "INSERT INTO t VALUES (2, 'two')"
+    t2=$(now)
+    dolt sql -q "CALL DOLT_COMMIT('-am', 'add two'); INSERT INTO t VALUES (3, 'three')"
+    sleep 1
+    stop_sql_server 1
+
+    db=$(ls "$SHIPPED")
+    [ -n "$db" ]
+
+    cd "$RESTORED"
+    run dolt restore --to-time "$t1" "file://$SHIPPED/$db" at_t1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "restored at_t1" ]] || false
+    cd at_t1
+    run dolt sql -q "SELECT pk FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${#lines[@]}" -eq 2 ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    # uncommitted changes shipped between commits are restored in the working set
+    cd "$RESTORED"
+    dolt restore --to-time "$t2" "file://$SHIPPED/$db" at_t2
+    cd at_t2
+    run dolt sql -q "SELECT pk FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[2]}" = "2" ]
+    [ "${#lines[@]}" -eq 3 ]
+    run dolt status
+    [[ "$output" =~ "modified:" ]] || false
+    run dolt log --oneline
+    [[ ! "$output" =~ "add two" ]] || false
+
+    cd "$RESTORED"
+    dolt restore "file://$SHIPPED/$db" latest
+    cd latest
+    run dolt sql -q "SELECT pk FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[3]}" = "3" ]
+    [ "${#lines[@]}" -eq 4 ]
+    run dolt log --oneline
+    [[ "$output" =~ "add two" ]] || false
+}
+
+@test "journal-shipping: shipping resumes after a restart" {
+    start_shipping_server
+    dolt sql -q "INSERT INTO t VALUES (2, 'two')"
+    sleep 1
+    stop_sql_server 1
+
+    start_shipping_server
+    dolt sql -q "INSERT INTO t VALUES (3, 'three')"
+    sleep 1
+    stop_sql_server 1
+
+    db=$(ls "$SHIPPED")
+    cd "$RESTORED"
+    dolt restore "file://$SHIPPED/$db" latest
+    cd latest
+    run dolt sql -q "SELECT count(*) FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "journal-shipping: restore errors" {
+    start_shipping_server
+    sleep 1
+    stop_sql_server 1
+    db=$(ls "$SHIPPED")
+
+    cd "$RESTORED"
+    run dolt restore --to-time 2000-01-01 "file://$SHIPPED/$db" too_early
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no database state was shipped at or before the requested time" ]] || false
+    [ ! -d too_early ]
+
+    run dolt restore --to-time yesterday "file://$SHIPPED/$db" bad_time
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid --to-time" ]] || false
+
+    mkdir exists
+    run dolt restore "file://$SHIPPED/$db" exists
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+}
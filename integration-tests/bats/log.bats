@@ -1108,4 +1108,44 @@ export NO_COLOR=1
     [[ "$output" =~ "A table for br1" ]] || false
     ! [[ "$output" =~ "Initialize data repository" ]] || false
     ! [[ "$output" =~ "commit 1 br2" ]] || false
-}
\ No newline at end of file
+}
+@test "log: --author, --grep, --since and --until filter commits" {
+    dolt sql -q "create table test (i int primary key)"
+    dolt commit -Am "create test" --author "Alice <alice@example.com>" --date 2024-01-01T12:00:00
+    dolt sql -q "insert into test values (1)"
+    dolt commit -am "fix: insert 1" --author "Bob <bob@example.com>" --date 2024-02-01T12:00:00
+    dolt sql -q "create table other (i int primary key)"
+    dolt commit -Am "create other" --author "Alice <alice@example.com>" --date 2024-03-01T12:00:00
+    dolt sql -q "insert into test values (2)"
+    dolt commit -am "fix: insert 2" --author "Alice <alice@example.com>" --date 2024-04-01T12:00:00
+
+    run dolt log --oneline --author Alice
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    ! [[ "$output" =~ "fix: insert 1" ]] || false
+
+    run dolt log --oneline --author "bob@example.com"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "fix: insert 1" ]] || false
+
+    run dolt log --oneline --grep "^fix:"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt log --oneline --since 2024-02-01T12:00:00 --until 2024-03-15
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "fix: insert 1" ]] || false
+    [[ "$output" =~ "create other" ]] || false
+
+    run dolt log --oneline --author Alice -- test
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "fix: insert 2" ]] || false
+    [[ "$output" =~ "create test" ]] || false
+
+    run dolt log --since "last tuesday"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not in a supported format" ]] || false
+}
@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 27 ]
+    [ "${#lines[@]}" -eq 30 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_status_ignored" ]] || false
@@ -88,6 +88,9 @@ teardown() {
     [[ "$output" =~ "dolt_workspace_table_one" ]] || false
     [[ "$output" =~ "dolt_workspace_table_two" ]] || false
     [[ "$output" =~ "dolt_stashes" ]] || false
+    [[ "$output" =~ "dolt_ci_runs" ]] || false
+    [[ "$output" =~ "dolt_ci_run_steps" ]] || false
+    [[ "$output" =~ "dolt_notes" ]] || false
 }
 
 @test "ls: --all shows tables in working set and system tables" {
@@ -0,0 +1,84 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table t (pk int primary key, v int);"
+    dolt sql -q "insert into t values (1, 1);"
+    dolt commit -Am "add t"
+
+    for b in feature1 feature2 feature3; do
+        dolt checkout -b $b main
+        dolt sql -q "create table $b (pk int primary key);"
+        dolt commit -Am "add $b"
+    done
+    dolt checkout main
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "merge-octopus: merge several branches in one commit" {
+    run dolt merge feature1 feature2 feature3
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge made by the 'octopus' strategy." ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge branches 'feature1', 'feature2' and 'feature3' into main" ]] || false
+    [[ "$output" =~ "Merge: $(dolt sql -q "select hashof('main~1')" -r csv | tail -n 1) $(dolt sql -q "select hashof('feature1')" -r csv | tail -n 1) $(dolt sql -q "select hashof('feature2')" -r csv | tail -n 1) $(dolt sql -q "select hashof('feature3')" -r csv | tail -n 1)" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_commit_ancestors where commit_hash = hashof('main')" -r csv
+    [ "${lines[1]}" = "4" ]
+
+    run dolt ls
+    [[ "$output" =~ "feature1" ]] || false
+    [[ "$output" =~ "feature2" ]] || false
+    [[ "$output" =~ "feature3" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "merge-octopus: log --graph draws every parent" {
+    dolt merge feature1 feature2 feature3 -m "release"
+
+    run dolt log --graph --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "release" ]] || false
+    [[ "$output" =~ "add feature1" ]] || false
+    [[ "$output" =~ "add feature2" ]] || false
+    [[ "$output" =~ "add feature3" ]] || false
+    [[ "$output" =~ "add t" ]] || false
+}
+
+@test "merge-octopus: conflicts abandon the whole merge" {
+    dolt checkout feature2
+    dolt sql -q "update t set v = 2"
+    dolt commit -am "update t on feature2"
+    dolt checkout feature3
+    dolt sql -q "update t set v = 3"
+    dolt commit -am "update t on feature3"
+    dolt checkout main
+    head=$(dolt sql -q "select hashof('main')" -r csv | tail -n 1)
+
+    run dolt merge feature1 feature2 feature3
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "merging 'feature3' produced conflicts or constraint violations" ]] || false
+
+    [ "$(dolt sql -q "select hashof('main')" -r csv | tail -n 1)" = "$head" ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+    ! [[ "$output" =~ "merging" ]] || false
+}
+
+@test "merge-octopus: requires a clean working set" {
+    dolt sql -q "insert into t values (2, 2)"
+
+    run dolt merge feature1 feature2
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot merge with uncommitted changes" ]] || false
+}
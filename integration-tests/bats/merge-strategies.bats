@@ -0,0 +1,70 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE pages (id INT PRIMARY KEY, views INT, tags JSON, title VARCHAR(50));
+INSERT INTO pages VALUES (1, 100, '["docs"]', 'Home');
+INSERT INTO dolt_merge_strategies VALUES ('pages', 'views', 'sum'), ('pages', 'tags', 'union');
+SQL
+    dolt add .
+    dolt commit -m "add pages"
+
+    dolt checkout -b feature
+    dolt sql -q "UPDATE pages SET views = 110, tags = '[\"docs\", \"beta\"]'"
+    dolt commit -am "feature traffic"
+
+    dolt checkout main
+    dolt sql -q "UPDATE pages SET views = 105, tags = '[\"docs\", \"news\"]'"
+    dolt commit -am "main traffic"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "merge-strategies: conflicting cells are resolved by their strategies" {
+    run dolt merge feature -m "merge feature"
+    [ "$status" -eq 0 ]
+    ! [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -r csv -q "SELECT views, tags FROM pages"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '115,"[""docs"", ""news"", ""beta""]"' ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_conflicts"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "merge-strategies: columns without a strategy still conflict" {
+    dolt checkout feature
+    dolt sql -q "UPDATE pages SET title = 'Welcome'"
+    dolt commit -am "retitle on feature"
+    dolt checkout main
+    dolt sql -q "UPDATE pages SET title = 'Start'"
+    dolt commit -am "retitle on main"
+
+    run dolt merge feature -m "merge feature"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT (content)" ]] || false
+
+    run dolt sql -r csv -q "SELECT our_views, their_views, our_title, their_title FROM dolt_conflicts_pages"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "105,110,Start,Welcome" ]
+}
+
+@test "merge-strategies: strategies are versioned with the data" {
+    run dolt diff HEAD~2 HEAD dolt_merge_strategies
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "sum" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_merge_strategies WHERE column_name = 'views'"
+    dolt commit -am "stop summing views"
+
+    run dolt merge feature -m "merge feature"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT (content)" ]] || false
+}
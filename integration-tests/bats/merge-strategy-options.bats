@@ -0,0 +1,83 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE customers (id INT PRIMARY KEY, name VARCHAR(20));
+CREATE TABLE orders (id INT PRIMARY KEY, status VARCHAR(20));
+INSERT INTO customers VALUES (1, 'alice');
+INSERT INTO orders VALUES (1, 'new');
+SQL
+    dolt add .
+    dolt commit -m "add tables"
+
+    dolt checkout -b upstream
+    dolt sql -q "UPDATE customers SET name = 'alice upstream'"
+    dolt sql -q "UPDATE orders SET status = 'shipped'"
+    dolt commit -am "upstream changes"
+
+    dolt checkout main
+    dolt sql -q "UPDATE customers SET name = 'alice local'"
+    dolt sql -q "UPDATE orders SET status = 'cancelled'"
+    dolt commit -am "local changes"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "merge-strategy-options: -X theirs resolves every conflict" {
+    run dolt merge -X theirs upstream -m "nightly sync"
+    [ "$status" -eq 0 ]
+    ! [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -r csv -q "SELECT name FROM customers; SELECT status FROM orders"
+    [[ "$output" =~ "alice upstream" ]] || false
+    [[ "$output" =~ "shipped" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "merge-strategy-options: per-table overrides" {
+    run dolt merge -X ours,orders=theirs upstream -m "nightly sync"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "SELECT name FROM customers; SELECT status FROM orders"
+    [[ "$output" =~ "alice local" ]] || false
+    [[ "$output" =~ "shipped" ]] || false
+}
+
+@test "merge-strategy-options: tables without a strategy still conflict" {
+    run dolt merge -X orders=theirs upstream
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in customers" ]] || false
+    ! [[ "$output" =~ "Merge conflict in orders" ]] || false
+}
+
+@test "merge-strategy-options: -s ours records the merge without its changes" {
+    run dolt merge -s ours upstream -m "record upstream"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "SELECT name FROM customers"
+    [[ "$output" =~ "alice local" ]] || false
+
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "record upstream" ]] || false
+
+    # upstream is now merged, so merging it again does nothing
+    run dolt merge upstream
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Everything up-to-date" ]] || false
+}
+
+@test "merge-strategy-options: unknown strategies are rejected" {
+    run dolt merge -s octopus upstream
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "the only supported strategy is 'ours'" ]] || false
+
+    run dolt merge -X newest upstream
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid strategy option 'newest'" ]] || false
+}
@@ -1171,6 +1171,80 @@ SQL
     [[ "$output" =~ "CONFLICT (schema): Merge conflict in test1" ]] || false
 }
 
+@test "merge: ourRoot renames, theirRoot modifies, with dolt_find_renames set" {
+    dolt checkout -b merge_branch
+    dolt sql -q "INSERT INTO test1 VALUES (0,1,2)"
+    dolt sql -q "ALTER TABLE test1 DROP COLUMN c2;"
+    dolt commit -am "modify test1"
+
+    dolt checkout main
+    dolt sql -q "ALTER TABLE test1 RENAME TO new_name"
+    dolt add .
+    dolt commit -am "rename test1"
+
+    run dolt sql -q "SET @@dolt_find_renames = 50; CALL DOLT_MERGE('merge_branch', '-m', 'merge');"
+    log_status_eq 0
+
+    run dolt sql -q "SELECT * FROM new_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0,1" ]] || false
+
+    run dolt schema show new_name
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "\`c2\` int" ]] || false
+
+    run dolt ls
+    [[ ! "$output" =~ "test1" ]] || false
+}
+
+@test "merge: ourRoot modifies, theirRoot renames, with dolt_find_renames set" {
+    dolt checkout -b merge_branch
+    dolt sql -q "ALTER TABLE test1 RENAME TO new_name"
+    dolt add .
+    dolt commit -am "rename test1"
+
+    dolt checkout main
+    dolt sql -q "INSERT INTO test1 VALUES (0,1,2)"
+    dolt commit -am "add pk 0 to test1"
+
+    run dolt sql -q "SET @@dolt_find_renames = 50; CALL DOLT_MERGE('merge_branch', '-m', 'merge');"
+    log_status_eq 0
+
+    run dolt sql -q "SELECT * FROM new_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0,1,2" ]] || false
+
+    run dolt ls
+    [[ ! "$output" =~ "test1" ]] || false
+}
+
+@test "merge: table copied under a new name on one branch keeps changes from the other with dolt_find_renames set" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,1,1), (2,2,2), (3,3,3)"
+    dolt commit -am "add rows to test1"
+
+    dolt checkout -b merge_branch
+    dolt sql -q "UPDATE test1 SET c1 = 10 WHERE pk = 1"
+    dolt commit -am "update test1"
+
+    dolt checkout main
+    dolt sql -q "CREATE TABLE copied (pk int NOT NULL, c1 int, c2 int, PRIMARY KEY (pk))"
+    dolt sql -q "INSERT INTO copied SELECT * FROM test1"
+    dolt sql -q "DROP TABLE test1"
+    dolt add .
+    dolt commit -am "copy test1 to copied"
+
+    run dolt sql -q "SET @@dolt_find_renames = 50; CALL DOLT_MERGE('merge_branch', '-m', 'merge');"
+    log_status_eq 0
+
+    run dolt sql -q "SELECT * FROM copied ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10,1" ]] || false
+    [[ "$output" =~ "2,2,2" ]] || false
+
+    run dolt ls
+    [[ ! "$output" =~ "test1" ]] || false
+}
+
 @test "merge: dolt merge commits successful non-fast-forward merge" {
     dolt branch other
     dolt sql -q "INSERT INTO test1 VALUES (1,2,3)"
@@ -0,0 +1,105 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into t values (1);"
+    dolt commit -am "insert 1"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "notes: add, show, list and remove notes" {
+    head=$(dolt sql -q "select dolt_hashof('HEAD')" -r csv | tail -n 1)
+    parent=$(dolt sql -q "select dolt_hashof('HEAD~1')" -r csv | tail -n 1)
+
+    dolt notes add -m "approved by review"
+    dolt notes add -m "TICKET-12" HEAD~1
+
+    run dolt notes show
+    [ "$status" -eq 0 ]
+    [ "$output" = "approved by review" ]
+
+    run dolt notes list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head" ]] || false
+    [[ "$output" =~ "$parent" ]] || false
+
+    run dolt notes add -m "again"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "HEAD already has a note; use -f to replace it" ]] || false
+
+    dolt notes add -f -m "approved twice"
+    run dolt notes show HEAD
+    [ "$output" = "approved twice" ]
+
+    run dolt log --notes
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Notes:" ]] || false
+    [[ "$output" =~ "approved twice" ]] || false
+    [[ "$output" =~ "TICKET-12" ]] || false
+
+    run dolt log
+    [[ ! "$output" =~ "Notes:" ]] || false
+
+    dolt notes remove HEAD~1
+    run dolt notes show HEAD~1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no note found for commit $parent" ]] || false
+
+    # notes don't change the commits they annotate
+    run dolt sql -q "select dolt_hashof('HEAD')" -r csv
+    [[ "$output" =~ "$head" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "notes: named notes refs" {
+    dolt notes add --ref quality -m "all checks passed"
+    run dolt notes show
+    [ "$status" -ne 0 ]
+
+    run dolt notes show --ref quality
+    [ "$status" -eq 0 ]
+    [ "$output" = "all checks passed" ]
+
+    run dolt sql -q "select ref, note from dolt_notes" -r csv
+    [[ "$output" =~ "quality,all checks passed" ]] || false
+}
+
+@test "notes: push, pull and merge notes through a remote" {
+    mkdir remote
+    dolt remote add origin file://./remote
+    dolt push origin main
+    dolt notes add -m "first note"
+    dolt notes push origin
+
+    dolt clone file://./remote clone
+    cd clone
+    dolt notes pull origin
+    run dolt notes show
+    [ "$status" -eq 0 ]
+    [ "$output" = "first note" ]
+
+    dolt notes add -m "from the clone" HEAD~1
+    dolt notes push
+
+    cd ..
+    dolt notes add -f -m "first note, edited"
+    run dolt notes push origin
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "pull them before pushing" ]] || false
+
+    dolt notes pull origin
+    dolt notes push origin
+    run dolt notes show HEAD~1
+    [ "$output" = "from the clone" ]
+    run dolt notes show
+    [ "$output" = "first note, edited" ]
+}
@@ -74,8 +74,7 @@ RENAME TABLE test TO quiz;
 INSERT INTO quiz VALUES (9);
 SQL
     dolt add -A && dolt commit -m "renamed test to quiz, added values"
-    skip "merge works on matching table names currently, panics on renames"
-    run dolt merge other -m "merge"
+    run dolt sql -q "SET @@dolt_find_renames = 50; CALL DOLT_MERGE('other', '-m', 'merge');"
     [ "$status" -eq 0 ]
     run dolt ls
     [ "$status" -eq 0 ]
@@ -397,6 +397,68 @@ seed_and_start_serial_remote() {
     [[ "$output" =~ "Commit not found. You are using a shallow clone" ]] || false
 }
 
+@test "shallow-clone: lazy clone fetches history as it is read" {
+    seed_and_start_serial_remote
+
+    mkdir clones
+    cd clones
+
+    run dolt clone --depth 1 --lazy http://localhost:50051/test-org/test-repo
+    [ "$status" -eq 0 ]
+
+    cd test-repo
+
+    run dolt config --local --get lazyfetch.remote
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin" ]] || false
+
+    run dolt sql -q "select sum(i) from vals as of 'HEAD~2'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "6" ]] || false # 1+2+3 = 6.
+
+    run dolt show HEAD~3
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Added Val: 2" ]] || false
+
+    # Fetched history is kept locally.
+    stop_remotesrv
+    run dolt sql -q "select sum(i) from vals as of 'HEAD~2'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "6" ]] || false
+}
+
+@test "shallow-clone: prefetch a revision of a lazy clone" {
+    seed_and_start_serial_remote
+
+    mkdir clones
+    cd clones
+
+    dolt clone --depth 1 http://localhost:50051/test-org/test-repo shallow
+    cd shallow
+    run dolt prefetch HEAD~3
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "lazyfetch.remote" ]] || false
+    cd ..
+
+    dolt sql -q "call dolt_clone('--depth', '1', '--lazy', 'http://localhost:50051/test-org/test-repo')"
+    cd test-repo
+
+    run dolt prefetch HEAD~2 vals
+    [ "$status" -eq 0 ]
+
+    run dolt prefetch HEAD~2 nosuchtable
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table not found" ]] || false
+
+    run dolt prefetch HEAD~3
+    [ "$status" -eq 0 ]
+
+    stop_remotesrv
+    run dolt sql -q "select sum(i) from vals as of 'HEAD~3'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "3" ]] || false # 1+2 = 3.
+}
+
 @test "shallow-clone: hashof sql function gives an error message" {
     seed_and_start_serial_remote
 
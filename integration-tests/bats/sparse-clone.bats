@@ -0,0 +1,237 @@
+#!/usr/bin/env bats
+#
+# Tests for sparse clones, which only fetch the row and index data
+# of some tables, while keeping the schemas of all tables and the
+# full commit history.
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+}
+
+teardown() {
+    teardown_common
+}
+
+# The remote has three tables, dim_color and dim_size which are small,
+# and facts which is large, across three commits.
+seed_remote() {
+    mkdir repo
+    cd repo
+    dolt init
+    dolt sql <<SQL
+create table dim_color (id int primary key, name varchar(20));
+create table dim_size (id int primary key, name varchar(20));
+create table facts (id int primary key, color int, size int, amount int, index (color));
+insert into dim_color values (1, 'red'), (2, 'blue');
+insert into dim_size values (1, 'small');
+insert into facts values (1, 1, 1, 10), (2, 2, 1, 20);
+SQL
+    dolt add .
+    dolt commit -m 'create tables'
+
+    dolt sql -q "insert into dim_color values (3, 'green')"
+    dolt sql -q "insert into facts values (3, 3, 1, 30)"
+    dolt commit -am 'add green'
+
+    dolt sql -q "insert into facts values (4, 1, 1, 40)"
+    dolt commit -am 'add facts'
+
+    mkdir ../remote
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+}
+
+@test "sparse-clone: clone only the data of the given tables" {
+    seed_remote
+
+    run dolt clone --tables dim_color file://./remote sparse
+    [ "$status" -eq 0 ]
+    cd sparse
+
+    run dolt config --local --get sparse.tables
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dim_color" ]] || false
+
+    run dolt sql -q "select count(*) from dim_color" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "3" ]] || false
+
+    # History of the fetched tables is kept.
+    run dolt sql -q "select count(*) from dim_color as of 'HEAD~2'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add facts" ]] || false
+    [[ "$output" =~ "create tables" ]] || false
+
+    # The schemas of all tables are present.
+    run dolt sql -q "show tables"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "facts" ]] || false
+    run dolt schema show facts
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "amount" ]] || false
+
+    run dolt sql -q "select * from facts"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+    [[ "$output" =~ "dolt fetch --tables facts" ]] || false
+
+    run dolt sql -q "select * from dim_size"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+}
+
+@test "sparse-clone: clone the tables matching a pattern" {
+    seed_remote
+
+    dolt clone --tables 'dim_*' file://./remote sparse
+    cd sparse
+
+    run dolt sql -q "select count(*) from dim_color join dim_size" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "3" ]] || false
+
+    run dolt sql -q "select * from facts"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+}
+
+@test "sparse-clone: writing to a table that was not fetched fails" {
+    seed_remote
+
+    dolt clone --tables dim_color file://./remote sparse
+    cd sparse
+
+    run dolt sql -q "insert into facts values (5, 2, 1, 50)"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+    [[ ! "$output" =~ "panic" ]] || false
+
+    run dolt sql -q "update facts set amount = 0"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+
+    # Fetched tables can still be written.
+    run dolt sql -q "insert into dim_color values (4, 'black')"
+    [ "$status" -eq 0 ]
+}
+
+@test "sparse-clone: diffing a table that was not fetched fails" {
+    seed_remote
+
+    dolt clone --tables dim_color file://./remote sparse
+    cd sparse
+
+    run dolt diff HEAD~1 HEAD facts
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+    [[ ! "$output" =~ "panic" ]] || false
+
+    run dolt sql -q "select * from dolt_diff_facts"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+    [[ ! "$output" =~ "panic" ]] || false
+
+    # Fetched tables can still be diffed.
+    run dolt diff HEAD~2 HEAD dim_color
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "green" ]] || false
+}
+
+@test "sparse-clone: fetch widens the tables of a sparse clone" {
+    seed_remote
+
+    dolt clone --tables dim_color file://./remote sparse
+    cd sparse
+
+    run dolt fetch --tables facts
+    [ "$status" -eq 0 ]
+
+    run dolt config --local --get sparse.tables
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dim_color" ]] || false
+    [[ "$output" =~ "facts" ]] || false
+
+    run dolt sql -q "select sum(amount) from facts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "100" ]] || false
+
+    run dolt sql -q "select sum(amount) from facts as of 'HEAD~1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "60" ]] || false
+
+    run dolt sql -q "select * from dim_size"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+
+    # New commits bring the data of all the fetched tables.
+    cd ../repo
+    dolt sql -q "insert into facts values (5, 2, 1, 50)"
+    dolt commit -am 'more facts'
+    dolt push origin main
+    cd ../sparse
+
+    dolt pull origin main
+    run dolt sql -q "select sum(amount) from facts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "150" ]] || false
+}
+
+@test "sparse-clone: fetch --tables requires a sparse clone" {
+    seed_remote
+
+    dolt clone file://./remote full
+    cd full
+
+    run dolt fetch --tables facts
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a sparse clone" ]] || false
+}
+
+@test "sparse-clone: push changes from a sparse clone" {
+    seed_remote
+
+    dolt clone --tables dim_color file://./remote sparse
+    cd sparse
+    dolt sql -q "insert into dim_color values (4, 'yellow')"
+    dolt commit -am 'add yellow'
+    dolt push origin main
+    cd ..
+
+    dolt clone file://./remote full
+    cd full
+    run dolt sql -q "select name from dim_color where id = 4" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "yellow" ]] || false
+
+    run dolt sql -q "select sum(amount) from facts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "100" ]] || false
+}
+
+@test "sparse-clone: dolt_clone with --tables" {
+    seed_remote
+
+    mkdir dbs
+    cd dbs
+    dolt sql -q "call dolt_clone('--tables', 'dim_size', 'file://../remote', 'sparse')"
+    cd sparse
+
+    run dolt sql -q "select count(*) from dim_size" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1" ]] || false
+
+    run dolt sql -q "select * from dim_color"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not fetched" ]] || false
+
+    run dolt gc
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not supported in a sparse clone" ]] || false
+}
@@ -635,3 +635,50 @@ lines terminated by '\n'
     [[ $output =~ "0,0,0" ]] || false
     [[ $output =~ "1,1,1" ]] || false
 }
+
+@test "sql-load-data: bulk load with dolt_bulk_load" {
+    cat <<CSV > sorted.csv
+1,a,10
+2,b,20
+3,c,30
+CSV
+    cat <<CSV > unsorted.csv
+3,c,30
+1,a,10
+2,b,20
+CSV
+
+    dolt sql <<SQL
+CREATE TABLE t1 (pk int primary key, c1 varchar(10), c2 int, index (c1));
+CREATE TABLE t2 (pk int primary key, c1 varchar(10), c2 int, index (c1));
+CREATE TABLE t3 (pk int primary key, c1 varchar(10), c2 int, unique index (c1));
+SET dolt_bulk_load = 'sorted';
+LOAD DATA INFILE 'sorted.csv' INTO TABLE t1 FIELDS TERMINATED BY ',' LINES TERMINATED BY '\n';
+LOAD DATA INFILE 'unsorted.csv' INTO TABLE t2 FIELDS TERMINATED BY ',' LINES TERMINATED BY '\n';
+SET dolt_bulk_load = 'external_sort';
+LOAD DATA INFILE 'unsorted.csv' INTO TABLE t3 FIELDS TERMINATED BY ',' LINES TERMINATED BY '\n';
+SQL
+
+    for t in t1 t2 t3; do
+        run dolt sql -r csv -q "select * from $t where c1 >= 'b' order by c1"
+        [ "$status" -eq 0 ]
+        [ "${lines[1]}" = "2,b,20" ]
+        [ "${lines[2]}" = "3,c,30" ]
+    done
+
+    cat <<CSV > dupes.csv
+2,b,20
+1,a,10
+2,d,40
+CSV
+    dolt sql -q "CREATE TABLE t4 (pk int primary key, c1 varchar(10), c2 int)"
+    run dolt sql <<SQL
+SET dolt_bulk_load = 'external_sort';
+LOAD DATA INFILE 'dupes.csv' INTO TABLE t4 FIELDS TERMINATED BY ',' LINES TERMINATED BY '\n';
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+
+    run dolt sql -r csv -q "select count(*) from t4"
+    [ "${lines[1]}" = "0" ]
+}
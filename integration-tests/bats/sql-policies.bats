@@ -0,0 +1,128 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE orders (id INT PRIMARY KEY, tenant VARCHAR(20), amount INT, card VARCHAR(20));
+INSERT INTO orders VALUES (1, 'tenant_a', 10, '4111-1111'), (2, 'tenant_b', 20, '5500-2222');
+CALL DOLT_COMMIT('-Am', 'add orders');
+UPDATE orders SET amount = amount + 1;
+CALL DOLT_COMMIT('-am', 'update orders');
+INSERT INTO dolt_policies VALUES
+    ('tenant_rows', 'orders', 'tenant%', 'tenant = SUBSTRING_INDEX(CURRENT_USER(), ''@'', 1)', NULL, NULL),
+    ('card_mask', 'orders', 'tenant%', NULL, 'card', 'CONCAT(''****'', RIGHT(card, 4))');
+CALL DOLT_COMMIT('-Am', 'add policies');
+CREATE USER tenant_a IDENTIFIED BY '';
+GRANT SELECT ON *.* TO tenant_a;
+CREATE USER tenant_b IDENTIFIED BY '';
+GRANT SELECT, INSERT ON *.* TO tenant_b;
+SQL
+}
+
+teardown() {
+    assert_feature_version
+    stop_sql_server 1
+    teardown_common
+}
+
+@test "sql-policies: dolt_policies schema" {
+    run dolt sql -r csv -q "DESCRIBE dolt_policies"
+    [ $status -eq 0 ]
+    [[ $output =~ "policy_name,text,NO,PRI" ]] || false
+    [[ $output =~ "table_name,text,NO" ]] || false
+    [[ $output =~ "user,text,NO" ]] || false
+    [[ $output =~ "row_filter,text,YES" ]] || false
+    [[ $output =~ "column_name,text,YES" ]] || false
+    [[ $output =~ "column_mask,text,YES" ]] || false
+
+    run dolt sql -r csv -q "SELECT policy_name FROM dolt_policies ORDER BY policy_name"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "card_mask" ]
+    [ "${lines[2]}" = "tenant_rows" ]
+}
+
+@test "sql-policies: tenants only see their own rows" {
+    start_sql_server
+
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT * FROM orders"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1,tenant_a,11,****1111" ]
+
+    run dolt -u tenant_b -p '' sql -r csv -q "SELECT id, card FROM orders"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "2,****2222" ]
+
+    # root administers the database, so it isn't restricted
+    run dolt sql -r csv -q "SELECT id, card FROM orders ORDER BY id"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1,4111-1111" ]
+    [ "${lines[2]}" = "2,5500-2222" ]
+}
+
+@test "sql-policies: history, diff and blame tables are filtered" {
+    start_sql_server
+
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT DISTINCT id, amount FROM dolt_history_orders ORDER BY amount"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[1]}" = "1,10" ]
+    [ "${lines[2]}" = "1,11" ]
+
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT to_id, to_card, diff_type FROM dolt_diff_orders ORDER BY to_amount"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[1]}" = "1,****1111,added" ]
+    [ "${lines[2]}" = "1,****1111,modified" ]
+
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT id FROM dolt_blame_orders"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1" ]
+
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT to_id FROM dolt_diff('HEAD~2', 'HEAD', 'orders')"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1" ]
+
+    run dolt -u tenant_a -p '' sql -q "SELECT * FROM dolt_patch('HEAD~2', 'HEAD', 'orders')"
+    [ $status -ne 0 ]
+    [[ $output =~ "dolt_patch cannot be used on table 'orders' because of its access policies" ]] || false
+}
+
+@test "sql-policies: policies are read from the default branch" {
+    dolt branch before_policies HEAD~1
+    dolt sql -q "DELETE FROM dolt_policies"
+    start_sql_server
+
+    # uncommitted changes to dolt_policies don't take effect
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT id FROM orders"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    # branches without the policies are still filtered
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT id FROM \`dolt-repo-$$/before_policies\`.orders"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1" ]
+
+    dolt sql -q "CALL DOLT_COMMIT('-am', 'remove policies')"
+    run dolt -u tenant_a -p '' sql -r csv -q "SELECT id FROM orders ORDER BY id"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "sql-policies: restricted tables are read-only" {
+    start_sql_server
+
+    run dolt -u tenant_b -p '' sql -q "INSERT INTO orders VALUES (3, 'tenant_b', 30, '6011-3333')"
+    [ $status -ne 0 ]
+
+    run dolt sql -r csv -q "SELECT count(*) FROM orders"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
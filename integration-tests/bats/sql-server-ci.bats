@@ -0,0 +1,228 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests are flaky on Windows"
+    if [ "$SQL_ENGINE" = "remote-engine" ]; then
+      skip "This test tests remote connections directly, SQL_ENGINE is not needed."
+    fi
+    setup_common
+    dolt sql -q "CREATE TABLE t (id INT PRIMARY KEY);"
+    dolt sql -q "INSERT INTO t VALUES (1), (2);"
+    dolt commit -Am "create t"
+    dolt ci init
+    dolt sql --save "count t" -q "SELECT * FROM t;"
+    dolt commit -Am "save query"
+
+    PORT=$( definePORT )
+    cat > server.yaml <<EOF
+listener:
+  port: $PORT
+
+behavior:
+  ci_workflows: true
+EOF
+}
+
+teardown() {
+    stop_sql_server 1
+    teardown_common
+}
+
+# wait_for_ci_run waits for a run of the workflow given to be recorded for the current head of main.
+wait_for_ci_run() {
+    local workflow=$1
+    for i in $(seq 1 50); do
+        run dolt sql -r csv -q "SELECT count(*) FROM dolt_ci_runs WHERE workflow_name = '$workflow' AND commit_hash = HASHOF('main');"
+        if [ "${lines[1]}" = "1" ]; then
+            return 0
+        fi
+        sleep 0.2
+    done
+    echo "no run of $workflow recorded for main"
+    return 1
+}
+
+@test "sql-server-ci: push to a matching branch runs workflow" {
+    cat > workflow.yaml <<EOF
+name: on push
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+        expected_rows: "== 2"
+EOF
+    start_sql_server_with_args_no_port "--config" "server.yaml"
+
+    dolt ci import ./workflow.yaml
+    wait_for_ci_run "on push"
+
+    run dolt sql -r csv -q "SELECT event, branch, status, error FROM dolt_ci_runs WHERE commit_hash = HASHOF('main');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "push,main,success," ]] || false
+
+    run dolt sql -r csv -q "SELECT s.step_order, s.job_name, s.step_name, s.status FROM dolt_ci_run_steps s JOIN dolt_ci_runs r ON s.run_id = r.run_id WHERE r.commit_hash = HASHOF('main');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,check t,two rows,success" ]] || false
+
+    # a commit which breaks the assertion records a failure
+    dolt sql -q "INSERT INTO t VALUES (3);"
+    dolt commit -am "add a row"
+    wait_for_ci_run "on push"
+
+    run dolt sql -r csv -q "SELECT r.status, s.failure_detail FROM dolt_ci_run_steps s JOIN dolt_ci_runs r ON s.run_id = r.run_id WHERE r.commit_hash = HASHOF('main');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "failure,Assertion failed: expected row count 2, got 3" ]] || false
+}
+
+@test "sql-server-ci: saved queries which write are not run" {
+    dolt sql -q "CREATE DATABASE other;"
+    dolt sql -q "CREATE TABLE other.secrets (id INT PRIMARY KEY);"
+    dolt sql -q "INSERT INTO dolt_query_catalog VALUES ('write other', 2, 'write other', 'INSERT INTO other.secrets VALUES (1)', '');"
+    dolt commit -Am "save a write"
+    cat > workflow.yaml <<EOF
+name: on push
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: write
+    steps:
+      - name: write other
+        saved_query_name: write other
+EOF
+    start_sql_server_with_args_no_port "--config" "server.yaml"
+
+    dolt ci import ./workflow.yaml
+    wait_for_ci_run "on push"
+
+    run dolt sql -r csv -q "SELECT r.status, s.failure_detail FROM dolt_ci_run_steps s JOIN dolt_ci_runs r ON s.run_id = r.run_id WHERE r.commit_hash = HASHOF('main');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "failure,saved queries run by workflows must be read-only" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM other.secrets;"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "sql-server-ci: workflows run with the privileges of the committer" {
+    cat > workflow.yaml <<EOF
+name: on push
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+        expected_rows: "== 2"
+EOF
+    start_sql_server_with_args_no_port "--config" "server.yaml"
+
+    dolt sql -q "CREATE USER committer@'%' IDENTIFIED BY 'pass';"
+    dolt sql -q "GRANT INSERT, UPDATE ON *.* TO committer@'%';"
+    dolt ci import ./workflow.yaml
+    wait_for_ci_run "on push"
+    dolt -u committer -p pass sql -q "CALL DOLT_COMMIT('--allow-empty', '-m', 'empty');"
+    wait_for_ci_run "on push"
+
+    # the committer cannot read t, so neither can the workflow run for their commit
+    run dolt sql -r csv -q "SELECT r.status, s.failure_detail FROM dolt_ci_run_steps s JOIN dolt_ci_runs r ON s.run_id = r.run_id WHERE r.commit_hash = HASHOF('main');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "failure" ]] || false
+    [[ "$output" =~ "command denied" ]] || false
+}
+
+@test "sql-server-ci: push to another branch does not run workflow" {
+    cat > workflow.yaml <<EOF
+name: on push
+on:
+  push:
+    branches:
+      - release
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+        expected_rows: "== 2"
+EOF
+    start_sql_server_with_args_no_port "--config" "server.yaml"
+
+    dolt ci import ./workflow.yaml
+    dolt sql -q "INSERT INTO t VALUES (3);"
+    dolt commit -am "add a row"
+    sleep 1
+
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "sql-server-ci: workflows do not run unless enabled" {
+    cat > workflow.yaml <<EOF
+name: on push
+on:
+  push: {}
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+        expected_rows: "== 2"
+EOF
+    start_sql_server
+
+    dolt ci import ./workflow.yaml
+    sleep 1
+
+    run dolt sql -r csv -q "SELECT count(*) FROM dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "sql-server-ci: import accepts a schedule trigger" {
+    cat > workflow.yaml <<EOF
+name: nightly
+on:
+  schedule:
+    - cron: "0 2 * * *"
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+        expected_rows: "== 2"
+EOF
+    dolt ci import ./workflow.yaml
+
+    run dolt ci export "nightly"
+    [ "$status" -eq 0 ]
+    run cat nightly.yaml
+    [[ "$output" =~ "schedule:" ]] || false
+    [[ "$output" =~ '- cron: "0 2 * * *"' ]] || false
+
+    cat > bad.yaml <<EOF
+name: bad
+on:
+  schedule:
+    - cron: "61 * * * *"
+jobs:
+  - name: check t
+    steps:
+      - name: two rows
+        saved_query_name: count t
+EOF
+    run dolt ci import ./bad.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid config" ]] || false
+}
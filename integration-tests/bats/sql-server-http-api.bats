@@ -0,0 +1,190 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    skiponwindows "tests use curl and nc"
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE people (id INT PRIMARY KEY, name VARCHAR(20), info JSON);
+INSERT INTO people VALUES (1, 'alice', '{"age": 30}'), (2, 'bob', NULL), (3, 'carol', NULL);
+CALL DOLT_COMMIT('-Am', 'add people');
+CALL DOLT_BRANCH('feature');
+CREATE USER api IDENTIFIED BY 'secret';
+GRANT ALL ON *.* TO api;
+CREATE USER reader IDENTIFIED BY 'secret';
+SQL
+    dolt checkout feature
+    dolt sql -q "DELETE FROM people WHERE id = 3"
+    dolt commit -am "remove carol"
+    dolt checkout main
+}
+
+teardown() {
+    assert_feature_version
+    stop_sql_server 1
+    teardown_common
+}
+
+# start_http_api_server starts a sql-server with the HTTP API enabled on $API_PORT, with any extra http_api settings
+# given as the first argument. The API is served without TLS, so cleartext passwords are allowed unless the second
+# argument is "false".
+start_http_api_server() {
+    SQL_PORT=$( definePORT )
+    API_PORT=$( definePORT )
+    PORT=$SQL_PORT
+    cat > config.yml <<EOF
+listener:
+  host: "0.0.0.0"
+  port: ${SQL_PORT}
+
+http_api:
+  host: 127.0.0.1
+  port: ${API_PORT}
+  allow_cleartext_passwords: ${2:-true}
+$1
+EOF
+    dolt sql-server --config ./config.yml --socket "dolt.$SQL_PORT.sock" &
+    SERVER_PID=$!
+    wait_for_connection $SQL_PORT 8500
+
+    end_time=$((SECONDS+10))
+    while [ $SECONDS -lt $end_time ]; do
+        nc -z 127.0.0.1 "$API_PORT" >/dev/null 2>&1 && return 0
+        sleep 1
+    done
+    return 1
+}
+
+# api_query posts the JSON request body given to the query endpoint as the api user.
+api_query() {
+    curl -sS -u api:secret -H 'Content-Type: application/json' --data-binary "$1" "${@:2}" "http://127.0.0.1:${API_PORT}/v1/query"
+}
+
+@test "sql-server-http-api: select returns columns and rows" {
+    start_http_api_server
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"query\": \"SELECT id, name, info FROM people ORDER BY id\"}"
+    [ $status -eq 0 ]
+    [[ $output =~ '"columns":[{"name":"id","type":"int"},{"name":"name","type":"varchar(20)"},{"name":"info","type":"json"}]' ]] || false
+    [[ $output =~ '"rows":[[1,"alice",{"age":30}],[2,"bob",null],[3,"carol",null]]' ]] || false
+    [[ $output =~ '"truncated":false' ]] || false
+}
+
+@test "sql-server-http-api: parameterized queries and ndjson" {
+    start_http_api_server
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"query\": \"SELECT name FROM people WHERE id > ? AND name <> ? ORDER BY id\", \"params\": [1, \"carol\"], \"format\": \"ndjson\"}"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = '{"columns":[{"name":"name","type":"varchar(20)"}]}' ]
+    [ "${lines[1]}" = '["bob"]' ]
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"query\": \"SELECT id FROM people WHERE id = ?\", \"params\": [2]}" -H 'Accept: application/x-ndjson'
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = '[2]' ]
+}
+
+@test "sql-server-http-api: branch and commit selection" {
+    start_http_api_server
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"branch\": \"feature\", \"query\": \"SELECT count(*) FROM people\", \"format\": \"ndjson\"}"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = '[2]' ]
+
+    head=$(dolt sql -r csv -q "SELECT hashof('main')" | tail -n 1)
+    run api_query "{\"database\": \"dolt-repo-$$\", \"commit\": \"$head\", \"query\": \"SELECT count(*) FROM people\", \"format\": \"ndjson\"}"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = '[3]' ]
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"branch\": \"feature\", \"commit\": \"$head\", \"query\": \"SELECT 1\"}"
+    [ $status -eq 0 ]
+    [[ $output =~ "only one of branch or commit may be given" ]] || false
+}
+
+@test "sql-server-http-api: writes return rows affected" {
+    start_http_api_server
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"query\": \"INSERT INTO people (id, name) VALUES (?, ?), (?, ?)\", \"params\": [4, \"dave\", 5, \"erin\"]}"
+    [ $status -eq 0 ]
+    [[ $output =~ '"rows_affected":2' ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM people"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "5" ]
+}
+
+@test "sql-server-http-api: authentication and privileges" {
+    start_http_api_server
+
+    run curl -sS -o /dev/null -w '%{http_code}' -H 'Content-Type: application/json' --data-binary '{"query": "SELECT 1"}' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [ "$output" = "401" ]
+
+    run curl -sS -o /dev/null -w '%{http_code}' -u api:wrong -H 'Content-Type: application/json' --data-binary '{"query": "SELECT 1"}' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [ "$output" = "401" ]
+
+    run curl -sS -w '\n%{http_code}' -u reader:secret -H 'Content-Type: application/json' --data-binary "{\"database\": \"dolt-repo-$$\", \"query\": \"SELECT * FROM people\"}" "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [[ $output =~ "denied" ]] || false
+    [ "${lines[1]}" = "400" ]
+
+    run curl -sS -o /dev/null -w '%{http_code}' -u api:secret "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [ "$output" = "405" ]
+}
+
+@test "sql-server-http-api: passwords are refused without tls by default" {
+    start_http_api_server "" false
+
+    run curl -sS -w '\n%{http_code}' -u api:secret -H 'Content-Type: application/json' --data-binary '{"query": "SELECT 1"}' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "401" ]
+}
+
+@test "sql-server-http-api: accounts are matched against the remote host" {
+    dolt sql -q "CREATE USER api@'10.%' IDENTIFIED BY 'other'; GRANT ALL ON *.* TO api@'10.%';"
+    start_http_api_server
+
+    # the request comes from 127.0.0.1, so the api@'10.%' account and its password don't apply
+    run curl -sS -o /dev/null -w '%{http_code}' -u api:other -H 'Content-Type: application/json' --data-binary '{"query": "SELECT 1"}' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [ "$output" = "401" ]
+
+    run api_query '{"query": "SELECT 1"}'
+    [ $status -eq 0 ]
+    [[ $output =~ '"rows":[[1]]' ]] || false
+}
+
+@test "sql-server-http-api: max_rows truncates results" {
+    start_http_api_server "  max_rows: 2"
+
+    run api_query "{\"database\": \"dolt-repo-$$\", \"query\": \"SELECT id FROM people ORDER BY id\"}"
+    [ $status -eq 0 ]
+    [[ $output =~ '"rows":[[1],[2]],"truncated":true' ]] || false
+}
+
+@test "sql-server-http-api: cors preflight for allowed origins" {
+    start_http_api_server '  allowed_origins: ["https://app.example.com"]'
+
+    run curl -sS -D - -o /dev/null -X OPTIONS -H 'Origin: https://app.example.com' -H 'Access-Control-Request-Method: POST' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    [[ $output =~ "Access-Control-Allow-Origin: https://app.example.com" ]] || false
+
+    run curl -sS -D - -o /dev/null -X OPTIONS -H 'Origin: https://evil.example.com' -H 'Access-Control-Request-Method: POST' "http://127.0.0.1:${API_PORT}/v1/query"
+    [ $status -eq 0 ]
+    ! [[ $output =~ "Access-Control-Allow-Origin" ]] || false
+}
+
+@test "sql-server-http-api: invalid config is rejected" {
+    cat > config.yml <<EOF
+http_api:
+  port: 8080
+  jwks: missing
+EOF
+    run dolt sql-server --config ./config.yml
+    [ $status -ne 0 ]
+    [[ $output =~ 'no jwks config is named "missing"' ]] || false
+}
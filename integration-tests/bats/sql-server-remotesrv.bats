@@ -629,6 +629,52 @@ call dolt_commit('-am', 'add one val');"
     ! [[ "$output" =~ "zeek" ]] || false
 }
 
+@test "sql-server-remotesrv: force push to a protected branch through remotesapi is rejected" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table names (name varchar(10) primary key);'
+    dolt sql -q 'insert into names (name) values ("abe");'
+    dolt add names
+    dolt commit -m 'initial names.'
+    dolt sql -q "insert into dolt_branch_protection (\`database\`, branch, deny_force_push) values ('%', 'main', true)"
+
+    APIPORT=$( definePORT )
+    dolt sql -q "CREATE USER root@'%' identified by 'rootpass'; GRANT ALL ON *.* to root@'%';"
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u root
+
+    cd remote
+    dolt sql -q 'insert into names (name) values ("zeek");'
+    dolt commit -a -m 'add Zeek.'
+
+    cd ../cloned_db
+    dolt sql -q 'insert into names values ("dave");'
+    dolt commit -am 'add dave'
+
+    # The client has no protection rules of its own, so only the server can refuse this push
+    run dolt push origin --force --user $SQL_USER main:main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "force pushes are not allowed" ]] || false
+
+    cd ../remote
+    run dolt sql -q 'select * from names;'
+    [[ "$output" =~ "zeek" ]] || false
+    ! [[ "$output" =~ "dave" ]] || false
+
+    # Fast-forward pushes to the protected branch are still accepted
+    cd ../cloned_db
+    dolt fetch origin --user $SQL_USER
+    dolt reset --hard origin/main
+    dolt sql -q 'insert into names values ("erin");'
+    dolt commit -am 'add erin'
+    dolt push origin --user $SQL_USER main:main
+}
+
 @test "sql-server-remotesrv: push to remoteapi port as non-super user rejected" {
     mkdir remote
     cd remote
@@ -0,0 +1,185 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt branch feature
+    dolt branch other
+    WT="$BATS_TEST_TMPDIR/feature"
+}
+
+teardown() {
+    assert_feature_version
+    stop_sql_server 1
+    teardown_common
+}
+
+@test "worktree: add a worktree and work on its branch" {
+    dolt worktree add "$WT" feature
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "[main]" ]] || false
+    [[ "${lines[1]}" =~ "$WT" ]] || false
+    [[ "${lines[1]}" =~ "[feature]" ]] || false
+
+    cd "$WT"
+    [ ! -d .dolt/noms ]
+    run dolt branch --show-current
+    [ "$output" = "feature" ]
+
+    dolt sql -q "insert into t values (1);"
+    dolt commit -am "insert 1 on feature"
+
+    run dolt worktree list
+    [[ "${lines[0]}" =~ "[main]" ]] || false
+
+    cd -
+    run dolt status
+    [[ "$output" =~ "On branch main" ]] || false
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    run dolt log --oneline feature
+    [[ "$output" =~ "insert 1 on feature" ]] || false
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "worktree: a branch is only checked out in one worktree" {
+    run dolt worktree add "$WT" main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'main' is already checked out at" ]] || false
+
+    dolt worktree add "$WT" feature
+    run dolt worktree add "$BATS_TEST_TMPDIR/again" feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    run dolt checkout feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    run dolt branch -d feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    run dolt sql -q "call dolt_checkout('feature')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    run dolt sql -q "call dolt_branch('-D', 'feature')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    run dolt checkout -B feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'feature' is already checked out at '$WT'" ]] || false
+
+    cd "$WT"
+    run dolt checkout main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+
+    dolt checkout other
+    run dolt branch --show-current
+    [ "$output" = "other" ]
+    cd -
+    dolt checkout feature
+}
+
+@test "worktree: add fails for missing branches and non empty directories" {
+    run dolt worktree add "$WT" missing
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch not found" ]] || false
+
+    mkdir -p "$WT"
+    touch "$WT/file"
+    run dolt worktree add "$WT" feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already exists and is not empty" ]] || false
+}
+
+@test "worktree: remove a worktree" {
+    dolt worktree add "$WT" feature
+    cd "$WT"
+    dolt sql -q "insert into t values (1);"
+    cd -
+
+    run dolt worktree remove "$WT"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "worktree has uncommitted changes: branch 'feature'" ]] || false
+
+    dolt worktree remove -f "$WT"
+    [ ! -d "$WT" ]
+    run dolt worktree list
+    [ "${#lines[@]}" -eq 1 ]
+
+    # the branch and its working set are kept
+    dolt checkout feature
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "worktree: remove keeps a worktree holding other files unless forced" {
+    dolt worktree add "$WT" feature
+    echo "notes" > "$WT/notes.txt"
+
+    run dolt worktree remove "$WT"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "worktree contains files other than its .dolt directory" ]] || false
+    [[ "$output" =~ "notes.txt" ]] || false
+    [ -f "$WT/notes.txt" ]
+    run dolt worktree list
+    [ "${#lines[@]}" -eq 2 ]
+
+    dolt worktree remove --force "$WT"
+    [ ! -d "$WT" ]
+}
+
+@test "worktree: commands in different worktrees can run at once" {
+    dolt worktree add "$WT" feature
+
+    for i in 1 2 3 4 5; do
+        dolt sql -q "insert into t values ($i);" &
+        (cd "$WT" && dolt sql -q "insert into t values ($((i + 10)));") &
+    done
+    wait
+
+    dolt commit -am "on main"
+    cd "$WT"
+    dolt commit -am "on feature"
+    cd -
+
+    run dolt sql -q "select count(*) from t as of 'main'" -r csv
+    [[ "$output" =~ "5" ]] || false
+    run dolt sql -q "select count(*) from t as of 'feature'" -r csv
+    [[ "$output" =~ "5" ]] || false
+}
+
+@test "worktree: commands in worktrees work through a server running in another worktree" {
+    dolt worktree add "$WT" feature
+    start_sql_server
+
+    cd "$WT"
+    dolt sql -q "insert into t values (1);"
+    dolt commit -am "on feature"
+    run dolt branch --show-current
+    [ "$output" = "feature" ]
+
+    cd -
+    dolt sql -q "insert into t values (2);"
+    dolt commit -am "on main"
+    run dolt branch --show-current
+    [ "$output" = "main" ]
+
+    stop_sql_server 1
+
+    run dolt log --oneline -n 1 feature
+    [[ "$output" =~ "on feature" ]] || false
+    run dolt log --oneline -n 1 main
+    [[ "$output" =~ "on main" ]] || false
+}